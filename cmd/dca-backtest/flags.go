@@ -5,6 +5,19 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"time"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/backtest"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/montecarlo"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/optimization"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/validation"
+)
+
+// Trade report formats accepted by -report
+const (
+	ReportFormatXLSX = "xlsx"
+	ReportFormatHTML = "html"
+	ReportFormatAll  = "all"
 )
 
 // DCAFlags holds all command line flags for the DCA backtest command
@@ -20,28 +33,62 @@ type DCAFlags struct {
 	InitialBalance   *float64
 	Commission       *float64
 	
+	// Strategy selection
+	Strategy                 *string // Registered strategy name (default enhanced_dca)
+	StrategyParams           *string // Comma-separated name=value strategy parameters
+	ListStrategies           *bool   // List the registered strategies and exit
+	
 	// DCA strategy parameters
 	BaseAmount               *float64
 	MaxMultiplier            *float64
 	
 	// DCA spacing strategy parameters
-	DCASpacingStrategy       *string  // DCA spacing strategy (fixed, volatility_adaptive)
+	DCASpacingStrategy       *string  // DCA spacing strategy (fixed, volatility_adaptive, volume_profile, swing_low, band, drawdown_percentile)
 	SpacingBaseThreshold     *float64 // Base threshold for spacing strategy
 	SpacingMultiplier        *float64 // Multiplier for fixed spacing strategy  
 	SpacingVolatilitySens    *float64 // Volatility sensitivity for adaptive spacing
 	SpacingATRPeriod         *int     // ATR period for adaptive spacing
+	SpacingLookback          *int     // Candles searched for swing lows or pullbacks (0 = strategy default)
+	SpacingPivotStrength     *int     // Higher lows on each side of a swing low
+	SpacingBuffer            *float64 // Distance below the swing low
+	SpacingBand              *string  // Band of band spacing (bollinger, keltner)
+	SpacingBandPeriod        *int     // Period of band spacing
+	SpacingBandMult          *float64 // Band width multiplier at the first level
+	SpacingBandStep          *float64 // Band width multiplier added per DCA level
+	SpacingPercentile        *float64 // Pullback depth percentile at the first level
+	SpacingPercentileStep    *float64 // Percentile added per DCA level
 	
 	// Take profit parameters
 	TPPercent               *float64 // Base take profit percentage
 	UseTPLevels             *bool    // Enable multi-level TP system
 	
 	// Dynamic take profit parameters
-	DynamicTPStrategy       *string  // Dynamic TP strategy (fixed, volatility_adaptive, indicator_based)
+	DynamicTPStrategy       *string  // Dynamic TP strategy (fixed, volatility_adaptive, indicator_based, time_decay)
 	TPVolatilityMult        *float64 // Volatility multiplier for dynamic TP
 	TPMinPercent            *float64 // Minimum TP percentage
 	TPMaxPercent            *float64 // Maximum TP percentage
 	TPStrengthMult          *float64 // Signal strength multiplier for indicator-based TP
 	TPIndicatorWeights      *string  // Comma-separated indicator:weight pairs for indicator-based TP
+	TPDecayGraceDays        *float64 // Cycle age in days before a time-decay TP starts to fall
+	TPDecayDays             *float64 // Days for a time-decay TP to fall to the floor
+	TPFloorPercent          *float64 // Floor of a time-decay TP (0 = round-trip commission)
+	TPBreakEvenLevels       *int     // Entries after which a time-decay TP drops to the floor (0 = off)
+	
+	// Signal aggregation parameters
+	Aggregation             *string  // Signal aggregation mode (majority, weighted, strength, quorum)
+	AggregationWeights      *string  // Comma-separated indicator:weight vote weights
+	AggregationRequired     *string  // Comma-separated indicators that must signal buy
+	AggregationVeto         *string  // Comma-separated indicators whose sell signal blocks entries
+	AggregationQuorum       *int     // Buy votes needed in quorum mode
+	
+	// Expression condition parameters
+	EntryCondition          *string  // Condition that must hold for an entry
+	VetoCondition           *string  // Condition that blocks entries while it holds
+	TPModifiers             *string  // Semicolon-separated condition:multiplier take profit modifiers
+	
+	// Higher timeframe confirmation parameters
+	Timeframes              *string  // Semicolon-separated interval:condition confirmations
+	LookaheadCheck          *bool    // Run the look-ahead audit instead of the backtest
 	
 	// Indicator selection (flexible system)
 	Indicators       *string  // Comma-separated list of indicators
@@ -58,12 +105,27 @@ type DCAFlags struct {
 	UseWaveTrend     *bool
 	UseOBV           *bool
 	UseStochasticRSI *bool
+	UseADX           *bool
+	UseIchimoku      *bool
+	UseDonchian      *bool
+	UseAVWAP         *bool
+	UseCMF           *bool
+	UseVolumeProfile *bool
 	
 	// Analysis options
 	Optimize         *bool
 	AllIntervals     *bool
 	Period           *string
 	
+	// Optimizer options
+	Optimizer        *string        // Search method (ga, grid, random, tpe)
+	Seed             *int64         // RNG seed, 0 = random
+	MaxEvals         *int           // Maximum number of backtests
+	MaxTime          *time.Duration // Wall-clock budget
+	EarlyStop        *int           // Evaluations without improvement before stopping
+	IndicatorCacheMB *int           // Memory budget of the shared indicator series cache
+	BenchIndicatorCache *bool       // Time the optimizer without and with the indicator cache
+	
 	// Walk-forward validation
 	WFEnable         *bool
 	WFSplitRatio     *float64
@@ -71,10 +133,22 @@ type DCAFlags struct {
 	WFTrainDays      *int
 	WFTestDays       *int
 	WFRollDays       *int
+	WFAnchored       *bool // Expanding training window from the start of the data
+	WFEmbargoDays    *int  // Gap between train and test windows
+	
+	// Monte Carlo robustness analysis
+	MonteCarlo       *int           // Runs per experiment, 0 = disabled
+	MCSeed           *int64         // RNG seed, 0 = random
+	MCBlockSize      *int           // Candles per bootstrap block
+	MCLatency        *time.Duration // Max random entry delay
+	MCFeeJitter      *float64       // Relative commission spread
+	MCRuinDrawdown   *float64       // Drawdown counted as ruin
 	
 	// Output options
 	DataRoot         *string
 	ConsoleOnly      *bool
+	Report           *string // Trade report format (xlsx, html, all)
+	Snapshots        *string // Decision snapshots in the report (off, entries, all)
 	WindowSize       *int
 	EnvFile          *string
 	
@@ -98,27 +172,60 @@ func NewDCAFlags() *DCAFlags {
 		Commission:       flag.Float64("commission", DefaultCommission, "Trading commission (0.0005 = 0.05%)"),
 		
 		// DCA strategy parameters
+		Strategy:                 flag.String("strategy", "", "Registered strategy to run (default: enhanced_dca, see -list-strategies)"),
+		StrategyParams:           flag.String("strategy-params", "", "Comma-separated name=value strategy parameters (e.g., consensus_threshold=0.7)"),
+		ListStrategies:           flag.Bool("list-strategies", false, "List the registered strategies and their parameters"),
+		
 		BaseAmount:               flag.Float64("base-amount", DefaultBaseAmount, "Base DCA amount"),
 		MaxMultiplier:            flag.Float64("max-multiplier", DefaultMaxMultiplier, "Maximum position multiplier"),
 		
 		// DCA spacing strategy parameters
-		DCASpacingStrategy:       flag.String("dca-spacing", "fixed", "DCA spacing strategy (fixed, volatility_adaptive)"),
+		DCASpacingStrategy:       flag.String("dca-spacing", "fixed", "DCA spacing strategy (fixed, volatility_adaptive, volume_profile, swing_low, band, drawdown_percentile)"),
 		SpacingBaseThreshold:     flag.Float64("spacing-threshold", 0.01, "Base threshold for DCA spacing (0.01 = 1%)"),
 		SpacingMultiplier:        flag.Float64("spacing-multiplier", 1.15, "Multiplier for fixed progressive spacing"),
 		SpacingVolatilitySens:    flag.Float64("spacing-sensitivity", 1.8, "Volatility sensitivity for adaptive spacing"),
 		SpacingATRPeriod:         flag.Int("spacing-atr-period", 14, "ATR period for adaptive spacing"),
+		SpacingLookback:          flag.Int("spacing-lookback", 0, "Candles searched for swing lows or pullbacks (0 = 100 for swing_low, 500 for drawdown_percentile)"),
+		SpacingPivotStrength:     flag.Int("spacing-pivot-strength", 3, "Higher lows needed on each side of a swing low"),
+		SpacingBuffer:            flag.Float64("spacing-buffer", 0.002, "Distance below the swing low (0.002 = 0.2%)"),
+		SpacingBand:              flag.String("spacing-band", "bollinger", "Band for band spacing (bollinger, keltner)"),
+		SpacingBandPeriod:        flag.Int("spacing-band-period", 20, "Period of the band for band spacing"),
+		SpacingBandMult:          flag.Float64("spacing-band-mult", 2.0, "Band width multiplier (std devs or ATRs) at the first DCA level"),
+		SpacingBandStep:          flag.Float64("spacing-band-step", 0.5, "Band width multiplier added per DCA level"),
+		SpacingPercentile:        flag.Float64("spacing-percentile", 50, "Pullback depth percentile at the first DCA level"),
+		SpacingPercentileStep:    flag.Float64("spacing-percentile-step", 10, "Pullback depth percentile added per DCA level"),
 		
 		// Take profit parameters
 		TPPercent:               flag.Float64("tp-percent", 0.02, "Base take profit percentage (0.02 = 2%)"),
 		UseTPLevels:             flag.Bool("use-tp-levels", true, "Enable multi-level TP system (5 levels)"),
 		
 		// Dynamic take profit parameters
-		DynamicTPStrategy:       flag.String("dynamic-tp", "fixed", "Dynamic TP strategy (fixed, volatility_adaptive, indicator_based)"),
+		DynamicTPStrategy:       flag.String("dynamic-tp", "fixed", "Dynamic TP strategy (fixed, volatility_adaptive, indicator_based, time_decay)"),
 		TPVolatilityMult:        flag.Float64("tp-volatility-mult", 0.5, "Volatility multiplier for dynamic TP (0.5 = half of ATR)"),
 		TPMinPercent:            flag.Float64("tp-min-percent", 0.01, "Minimum TP percentage (0.01 = 1%)"),
 		TPMaxPercent:            flag.Float64("tp-max-percent", 0.05, "Maximum TP percentage (0.05 = 5%)"),
 		TPStrengthMult:          flag.Float64("tp-strength-mult", 0.3, "Signal strength multiplier for indicator-based TP"),
 		TPIndicatorWeights:      flag.String("tp-indicator-weights", "", "Comma-separated indicator:weight pairs (e.g., rsi:0.3,macd:0.4)"),
+		TPDecayGraceDays:        flag.Float64("tp-decay-grace", 0, "Cycle age in days before the time-decay TP starts to fall"),
+		TPDecayDays:             flag.Float64("tp-decay-days", 7, "Days for the time-decay TP to fall from the base TP to the floor"),
+		TPFloorPercent:          flag.Float64("tp-floor-percent", 0, "Floor of the time-decay TP (0 = round-trip commission, i.e. break-even plus fees)"),
+		TPBreakEvenLevels:       flag.Int("tp-break-even-levels", 0, "Drop the time-decay TP to the floor once a cycle has this many entries (0 = off)"),
+		
+		// Signal aggregation parameters
+		Aggregation:             flag.String("aggregation", "majority", "Signal aggregation mode (majority, weighted, strength, quorum)"),
+		AggregationWeights:      flag.String("aggregation-weights", "", "Comma-separated indicator:weight vote weights (e.g., rsi:1,macd:0.5)"),
+		AggregationRequired:     flag.String("aggregation-required", "", "Comma-separated indicators that must signal buy (e.g., rsi)"),
+		AggregationVeto:         flag.String("aggregation-veto", "", "Comma-separated indicators whose sell signal blocks entries"),
+		AggregationQuorum:       flag.Int("aggregation-quorum", 0, "Buy votes needed in quorum mode"),
+		
+		// Expression condition parameters
+		EntryCondition:          flag.String("entry-condition", "", "Condition that must hold for an entry (e.g., \"rsi(14) < 30 && close < bb(20,2).lower\")"),
+		VetoCondition:           flag.String("veto-condition", "", "Condition that blocks entries while it holds (e.g., \"adx(14).minus_di > 30\")"),
+		TPModifiers:             flag.String("tp-modifiers", "", "Semicolon-separated condition:multiplier TP modifiers (e.g., \"adx(14).adx > 30:1.5\")"),
+		
+		// Higher timeframe confirmation parameters
+		Timeframes:              flag.String("timeframes", "", "Semicolon-separated interval:condition higher timeframe confirmations (e.g., \"4h:ema(200) > ema(200)[1]\")"),
+		LookaheadCheck:          flag.Bool("lookahead-check", false, "Check that no decision reads a candle that had not closed, instead of running the backtest"),
 		
 		// Indicator selection (flexible system)
 		Indicators:       flag.String("indicators", "", "Comma-separated list of indicators (e.g., rsi,macd,supertrend)"),
@@ -135,12 +242,27 @@ func NewDCAFlags() *DCAFlags {
 		UseWaveTrend:     flag.Bool("wavetrend", false, "Include WaveTrend indicator"),
 		UseOBV:           flag.Bool("obv", false, "Include OBV (On-Balance Volume) indicator"),
 		UseStochasticRSI: flag.Bool("stochrsi", false, "Include Stochastic RSI indicator"),
+		UseADX:           flag.Bool("adx", false, "Include ADX/DMI trend-strength indicator"),
+		UseIchimoku:      flag.Bool("ichimoku", false, "Include Ichimoku Cloud indicator"),
+		UseDonchian:      flag.Bool("donchian", false, "Include Donchian Channels indicator"),
+		UseAVWAP:         flag.Bool("avwap", false, "Include Anchored VWAP indicator"),
+		UseCMF:           flag.Bool("cmf", false, "Include Chaikin Money Flow indicator"),
+		UseVolumeProfile: flag.Bool("volume-profile", false, "Include Volume Profile indicator"),
 		
 		// Analysis options
-		Optimize:         flag.Bool("optimize", false, "Run genetic algorithm optimization"),
+		Optimize:         flag.Bool("optimize", false, "Run parameter optimization (method selected by -optimizer)"),
 		AllIntervals:     flag.Bool("all-intervals", false, "Test all available intervals"),
 		Period:           flag.String("period", "", "Limit data to period (7d, 30d, 180d, 365d)"),
 		
+		// Optimizer options
+		Optimizer:        flag.String("optimizer", "ga", "Optimization method (ga, grid, random, tpe)"),
+		Seed:             flag.Int64("seed", 0, "Random seed for reproducible optimization (0 = random, printed after the run)"),
+		MaxEvals:         flag.Int("max-evals", 0, "Maximum number of backtests during optimization (0 = optimizer default)"),
+		MaxTime:          flag.Duration("max-time", 0, "Wall-clock budget for optimization, e.g. 10m (0 = unlimited)"),
+		EarlyStop:        flag.Int("early-stop", 0, "Stop after N backtests without improvement (0 = disabled)"),
+		IndicatorCacheMB: flag.Int("indicator-cache-mb", 512, "Memory budget in MB of the indicator series shared between optimizer backtests (0 = disabled)"),
+		BenchIndicatorCache: flag.Bool("bench-indicator-cache", false, "Run the optimizer without and then with the indicator cache and compare wall-clock time and results"),
+		
 		// Walk-forward validation
 		WFEnable:         flag.Bool("wf-enable", false, "Enable walk-forward validation"),
 		WFSplitRatio:     flag.Float64("wf-split-ratio", 0.7, "Train/test split (0.7 = 70% train)"),
@@ -148,10 +270,22 @@ func NewDCAFlags() *DCAFlags {
 		WFTrainDays:      flag.Int("wf-train-days", 180, "Training window (days)"),
 		WFTestDays:       flag.Int("wf-test-days", 60, "Test window (days)"),
 		WFRollDays:       flag.Int("wf-roll-days", 30, "Roll step (days)"),
+		WFAnchored:       flag.Bool("wf-anchored", false, "Use anchored (expanding window) walk-forward"),
+		WFEmbargoDays:    flag.Int("wf-embargo-days", 0, "Gap in days between each train and test window"),
+		
+		// Monte Carlo robustness analysis
+		MonteCarlo:       flag.Int("monte-carlo", 0, "Monte Carlo runs per experiment after the backtest (0 = disabled)"),
+		MCSeed:           flag.Int64("mc-seed", 0, "Random seed for Monte Carlo (0 = random, printed after the run)"),
+		MCBlockSize:      flag.Int("mc-block-size", montecarlo.DefaultBlockSize, "Candles per block when bootstrapping price paths"),
+		MCLatency:        flag.Duration("mc-latency", 0, "Max random entry latency, e.g. 30s (0 = disabled)"),
+		MCFeeJitter:      flag.Float64("mc-fee-jitter", 0, "Relative commission randomization, 0.5 = ±50% (0 = disabled)"),
+		MCRuinDrawdown:   flag.Float64("mc-ruin-dd", montecarlo.DefaultRuinThreshold, "Max drawdown counted as ruin (0.5 = 50%)"),
 		
 		// Output options
 		DataRoot:         flag.String("data-root", DefaultDataRoot, "Data root directory"),
 		ConsoleOnly:      flag.Bool("console-only", false, "Console output only (no files)"),
+		Report:           flag.String("report", ReportFormatXLSX, "Trade report format: xlsx, html (interactive charts) or all"),
+		Snapshots:        flag.String("snapshots", backtest.SnapshotsEntries, "Decision snapshots in the report: off, entries or all (holds included)"),
 		WindowSize:       flag.Int("window", DefaultWindowSize, "Analysis window size"),
 		EnvFile:          flag.String("env", ".env", "Environment file path"),
 		
@@ -201,6 +335,22 @@ func PrintDCAUsageExamples() {
 			"dca-backtest -symbol BTCUSDT -optimize -wf-rolling -wf-train-days 90 -wf-test-days 30",
 			"Optimize with rolling walk-forward validation (90-day train, 30-day test)",
 		},
+		{
+			"dca-backtest -symbol BTCUSDT -optimize -wf-enable -wf-anchored -wf-train-days 120 -wf-test-days 30 -wf-embargo-days 2",
+			"Anchored walk-forward with a 2-day embargo and parameter stability report",
+		},
+		{
+			"dca-backtest -config configs/bybit/btc_5m.json -monte-carlo 500 -mc-latency 30s -mc-fee-jitter 0.5",
+			"Monte Carlo robustness analysis with random entry latency and fees",
+		},
+		{
+			"dca-backtest -config configs/bybit/btc_5m.json -report html",
+			"Write an interactive HTML report with price, equity and drawdown charts",
+		},
+		{
+			"dca-backtest -config configs/bybit/dca/btc_5m_bybit.json -snapshots all",
+			"Export every decision with its indicator votes and DCA spacing for post-hoc analysis",
+		},
 		{
 			"dca-backtest -symbol BTCUSDT -dca-spacing fixed -spacing-threshold 0.02 -spacing-multiplier 1.2",
 			"Use fixed progressive DCA spacing (2% base, 1.2x multiplier)",
@@ -209,10 +359,38 @@ func PrintDCAUsageExamples() {
 			"dca-backtest -symbol ETHUSDT -dca-spacing volatility_adaptive -spacing-sensitivity 2.0 -spacing-atr-period 21",
 			"Use volatility-adaptive DCA spacing with high sensitivity",
 		},
+		{
+			"dca-backtest -symbol BTCUSDT -dca-spacing swing_low -spacing-pivot-strength 4",
+			"Place each DCA level just below the most recent swing low",
+		},
+		{
+			"dca-backtest -symbol ETHUSDT -dca-spacing band -spacing-band keltner -spacing-band-mult 1.5",
+			"Require price below a Keltner band that widens with each DCA level",
+		},
+		{
+			"dca-backtest -symbol BTCUSDT -dca-spacing drawdown_percentile -spacing-percentile 60 -window 500",
+			"Space DCA levels at percentiles of past pullback depths over 500 candles",
+		},
+		{
+			"dca-backtest -symbol BTCUSDT -optimize -optimizer tpe -max-evals 300 -seed 42",
+			"Bayesian (TPE) optimization with a 300-backtest budget, reproducible via seed",
+		},
+		{
+			"dca-backtest -symbol BTCUSDT -interval 5m -period 365d -optimize -bench-indicator-cache -max-evals 200 -seed 42",
+			"Time the optimizer without and with the shared indicator cache on a year of 5m data",
+		},
+		{
+			"dca-backtest -symbol ETHUSDT -optimize -optimizer random -max-time 15m -early-stop 200",
+			"Random search for up to 15 minutes, stopping early after 200 backtests without improvement",
+		},
 		{
 			"dca-backtest -symbol BTCUSDT -optimize -dca-spacing volatility_adaptive",
 			"Optimize with volatility-adaptive DCA spacing strategy",
 		},
+		{
+			"dca-backtest -symbol BTCUSDT -strategy multi_indicator -strategy-params consensus_threshold=0.7",
+			"Run the multi-indicator strategy with a stricter consensus",
+		},
 		{
 			"dca-backtest -symbol BTCUSDT -dynamic-tp volatility_adaptive -tp-volatility-mult 0.5",
 			"Use volatility-adaptive dynamic TP (0.5x ATR multiplier)",
@@ -225,6 +403,10 @@ func PrintDCAUsageExamples() {
 			"dca-backtest -symbol ADAUSDT -dynamic-tp volatility_adaptive -tp-min-percent 0.005 -tp-max-percent 0.08",
 			"Dynamic TP with custom bounds (0.5% min, 8% max)",
 		},
+		{
+			"dca-backtest -symbol BTCUSDT -dynamic-tp time_decay -tp-decay-grace 2 -tp-decay-days 7 -tp-break-even-levels 6",
+			"Lower the TP to break-even over a week after 2 days, or at once after 6 entries",
+		},
 	}
 	
 	fmt.Printf("\n📚 USAGE EXAMPLES:\n")
@@ -250,34 +432,87 @@ func PrintDCAFlagGroups() {
   -balance AMOUNT       Initial balance (default: 500)
   -commission RATE      Trading commission (default: 0.0005)
 
+🧩 STRATEGY FLAGS:
+  -strategy NAME        Registered strategy to run (default: enhanced_dca)
+  -strategy-params LIST Comma-separated name=value strategy parameters (e.g., consensus_threshold=0.7)
+  -list-strategies      List the registered strategies with their parameters and exit
+
 🔄 DCA STRATEGY FLAGS:
   -base-amount AMOUNT   Base DCA amount (default: 40)
   -max-multiplier MULT  Maximum position multiplier (default: 3.0)
 
 📊 DCA SPACING STRATEGY FLAGS:
-  -dca-spacing STRATEGY         DCA spacing strategy: fixed, volatility_adaptive (default: fixed)
+  -dca-spacing STRATEGY         DCA spacing strategy: fixed, volatility_adaptive, volume_profile,
+                                swing_low, band, drawdown_percentile (default: fixed)
   -spacing-threshold PCT        Base threshold for DCA spacing (default: 0.01)
-  -spacing-multiplier MULT      Multiplier for fixed progressive spacing (default: 1.15)
+  -spacing-multiplier MULT      Multiplier for fixed/volume-profile spacing (default: 1.15)
   -spacing-sensitivity SENS     Volatility sensitivity for adaptive spacing (default: 1.8)
   -spacing-atr-period PERIOD    ATR period for adaptive spacing (default: 14)
+  -spacing-lookback N           Candles searched for swing lows or pullbacks (default: 0 = 100 / 500)
+  -spacing-pivot-strength N     Higher lows on each side of a swing low (default: 3)
+  -spacing-buffer PCT           Distance below the swing low (default: 0.002)
+  -spacing-band BAND            Band for band spacing: bollinger, keltner (default: bollinger)
+  -spacing-band-period PERIOD   Band period (default: 20)
+  -spacing-band-mult MULT       Band width multiplier at the first DCA level (default: 2.0)
+  -spacing-band-step MULT       Band width multiplier added per DCA level (default: 0.5)
+  -spacing-percentile P         Pullback depth percentile at the first DCA level (default: 50)
+  -spacing-percentile-step P    Percentile added per DCA level (default: 10)
 
 🎯 TAKE PROFIT FLAGS:
   -tp-percent PCT               Base take profit percentage (default: 0.02)
   -use-tp-levels                Enable multi-level TP system (default: true)
 
 🚀 DYNAMIC TAKE PROFIT FLAGS:
-  -dynamic-tp STRATEGY          Dynamic TP strategy: fixed, volatility_adaptive, indicator_based (default: fixed)
+  -dynamic-tp STRATEGY          Dynamic TP strategy: fixed, volatility_adaptive, indicator_based, time_decay (default: fixed)
   -tp-volatility-mult MULT      Volatility multiplier for dynamic TP (default: 0.5)
   -tp-min-percent PCT           Minimum TP percentage (default: 0.01)
   -tp-max-percent PCT           Maximum TP percentage (default: 0.05)
   -tp-strength-mult MULT        Signal strength multiplier for indicator-based TP (default: 0.3)
   -tp-indicator-weights PAIRS   Indicator weights for dynamic TP (e.g., rsi:0.3,macd:0.4)
+  -tp-decay-grace DAYS          Cycle age before the time-decay TP starts to fall (default: 0)
+  -tp-decay-days DAYS           Days for the time-decay TP to reach the floor (default: 7)
+  -tp-floor-percent PCT         Floor of the time-decay TP (default: 0 = round-trip commission)
+  -tp-break-even-levels N       Time-decay TP drops to the floor after N entries (default: 0 = off)
+
+🗳️ SIGNAL AGGREGATION FLAGS:
+  -aggregation MODE             Signal aggregation: majority, weighted, strength, quorum (default: majority)
+  -aggregation-weights PAIRS    Vote weights 0-1 per indicator (e.g., rsi:1,macd:0.5); tuned by -optimize
+  -aggregation-required LIST    Indicators that must signal buy (e.g., rsi)
+  -aggregation-veto LIST        Indicators whose sell signal blocks entries
+  -aggregation-quorum K         Buy votes needed in quorum mode
+
+🧮 EXPRESSION CONDITION FLAGS:
+  -entry-condition EXPR         Condition that must hold for an entry (e.g., "rsi(14) < 30 && ema(50) > ema(200)")
+  -veto-condition EXPR          Condition that blocks entries while it holds
+  -tp-modifiers LIST            Semicolon-separated condition:multiplier pairs scaling the TP (e.g., "adx(14).adx > 30:1.5")
+
+🕰️ HIGHER TIMEFRAME FLAGS:
+  -timeframes LIST              Semicolon-separated interval:condition confirmations on closed higher timeframe
+                                candles resampled from the data (e.g., "4h:ema(200) > ema(200)[1]")
+  -lookahead-check              Audit the backtest for look-ahead instead of running it; exits 1 on a violation
 
 🧬 ANALYSIS FLAGS:
-  -optimize             Run genetic algorithm optimization
+  -optimize             Run parameter optimization (method selected by -optimizer)
   -all-intervals        Test all available intervals for symbol
   -period PERIOD        Limit data to period (7d, 30d, 180d, 365d)
 
+🔍 OPTIMIZER FLAGS:
+  -optimizer METHOD     Search method: ga, grid, random, tpe (default: ga)
+  -seed N               Random seed for reproducible runs (default: 0 = random, printed after the run)
+  -max-evals N          Maximum number of backtests (default: 0 = optimizer default)
+  -max-time DURATION    Wall-clock budget, e.g. 30m (default: 0 = unlimited)
+  -early-stop N         Stop after N backtests without improvement (default: 0 = disabled)
+  -indicator-cache-mb N Memory budget of the shared indicator series cache (default: 512, 0 = disabled)
+  -bench-indicator-cache Run the optimizer without and with the indicator cache, compare time and results
+
+🎲 MONTE CARLO FLAGS:
+  -monte-carlo N        Runs per experiment after the backtest (default: 0 = disabled)
+  -mc-seed N            Random seed (default: 0 = random, printed after the run)
+  -mc-block-size N      Candles per bootstrap block (default: 24)
+  -mc-latency DURATION  Max random entry latency, e.g. 30s (default: 0 = disabled)
+  -mc-fee-jitter RATIO  Commission randomization, 0.5 = ±50%% (default: 0 = disabled)
+  -mc-ruin-dd RATIO     Drawdown counted as ruin (default: 0.5)
+
 🔄 WALK-FORWARD VALIDATION FLAGS:
   -wf-enable            Enable walk-forward validation
   -wf-split-ratio RATIO Train/test split ratio (default: 0.7)
@@ -285,10 +520,15 @@ func PrintDCAFlagGroups() {
   -wf-train-days DAYS   Training window size (default: 180)
   -wf-test-days DAYS    Test window size (default: 60)
   -wf-roll-days DAYS    Roll forward step (default: 30)
+  -wf-anchored          Anchored walk-forward: training window grows from the start
+  -wf-embargo-days DAYS Gap between train and test windows (default: 0)
 
 📁 OUTPUT FLAGS:
   -data-root DIR        Data root directory (default: data)
   -console-only         Console output only, no file output
+  -report FORMAT        Trade report: xlsx, html (offline interactive charts) or all (default: xlsx)
+  -snapshots MODE       Decision snapshots (indicator votes, DCA spacing) in the Excel report
+                        and signal_snapshots.json: off, entries or all (default: entries)
   -window SIZE          Analysis window size (default: 100)
   -env FILE             Environment file path (default: .env)
 
@@ -312,13 +552,72 @@ func ValidateDCAFlags(flags *DCAFlags) error {
 	
 	// Price threshold validation is now handled by DCA spacing strategy validation
 	
+	// Validate optimizer settings
+	if _, err := optimization.ParseSearchMethod(*flags.Optimizer); err != nil {
+		return err
+	}
+	if *flags.MaxEvals < 0 {
+		return fmt.Errorf("max-evals must not be negative, got: %d", *flags.MaxEvals)
+	}
+	if *flags.MaxTime < 0 {
+		return fmt.Errorf("max-time must not be negative, got: %v", *flags.MaxTime)
+	}
+	if *flags.EarlyStop < 0 {
+		return fmt.Errorf("early-stop must not be negative, got: %d", *flags.EarlyStop)
+	}
+	if *flags.IndicatorCacheMB < 0 {
+		return fmt.Errorf("indicator-cache-mb must not be negative, got: %d", *flags.IndicatorCacheMB)
+	}
+	if *flags.BenchIndicatorCache && *flags.IndicatorCacheMB == 0 {
+		return fmt.Errorf("bench-indicator-cache needs the indicator cache (indicator-cache-mb > 0)")
+	}
+	
+	// Validate report format
+	switch strings.ToLower(*flags.Report) {
+	case ReportFormatXLSX, ReportFormatHTML, ReportFormatAll:
+	default:
+		return fmt.Errorf("invalid report format: %s (supported: xlsx, html, all)", *flags.Report)
+	}
+	
+	// Validate decision snapshot mode
+	if _, err := backtest.ParseSnapshotMode(*flags.Snapshots); err != nil {
+		return err
+	}
+	
+	// Validate Monte Carlo settings
+	if *flags.MonteCarlo < 0 {
+		return fmt.Errorf("monte-carlo runs must not be negative, got: %d", *flags.MonteCarlo)
+	}
+	if *flags.MonteCarlo > 0 {
+		if *flags.MCBlockSize <= 0 {
+			return fmt.Errorf("mc-block-size must be positive, got: %d", *flags.MCBlockSize)
+		}
+		if *flags.MCLatency < 0 {
+			return fmt.Errorf("mc-latency must not be negative, got: %v", *flags.MCLatency)
+		}
+		if *flags.MCFeeJitter < 0 || *flags.MCFeeJitter > 1 {
+			return fmt.Errorf("mc-fee-jitter must be between 0 and 1, got: %.2f", *flags.MCFeeJitter)
+		}
+		if *flags.MCRuinDrawdown <= 0 || *flags.MCRuinDrawdown > 1 {
+			return fmt.Errorf("mc-ruin-dd must be between 0 and 1, got: %.2f", *flags.MCRuinDrawdown)
+		}
+	}
+	
 	// Validate walk-forward settings if enabled
 	if *flags.WFEnable {
 		if *flags.WFSplitRatio <= 0 || *flags.WFSplitRatio >= 1.0 {
 			return fmt.Errorf("walk-forward split ratio must be between 0 and 1.0, got: %.2f", *flags.WFSplitRatio)
 		}
 		
-		if *flags.WFRolling {
+		if *flags.WFEmbargoDays < 0 {
+			return fmt.Errorf("walk-forward embargo days must not be negative, got: %d", *flags.WFEmbargoDays)
+		}
+		
+		if *flags.WFAnchored {
+			if *flags.WFTrainDays <= 0 || *flags.WFTestDays <= 0 {
+				return fmt.Errorf("walk-forward anchored train and test days must be positive")
+			}
+		} else if *flags.WFRolling {
 			if *flags.WFTrainDays <= 0 || *flags.WFTestDays <= 0 || *flags.WFRollDays <= 0 {
 				return fmt.Errorf("walk-forward rolling days must be positive")
 			}
@@ -375,7 +674,9 @@ func ResolveIndicators(flags *DCAFlags) ([]string, error) {
 	// Check for conflicts first
 	hasIndividualFlags := *flags.UseRSI || *flags.UseMACD || *flags.UseBB || *flags.UseEMA ||
 		*flags.UseHullMA || *flags.UseSuperTrend || *flags.UseMFI || *flags.UseKeltner ||
-		*flags.UseWaveTrend || *flags.UseOBV || *flags.UseStochasticRSI
+		*flags.UseWaveTrend || *flags.UseOBV || *flags.UseStochasticRSI ||
+		*flags.UseADX || *flags.UseIchimoku || *flags.UseDonchian ||
+		*flags.UseAVWAP || *flags.UseCMF || *flags.UseVolumeProfile
 	hasIndicatorsList := *flags.Indicators != ""
 	
 	if hasIndividualFlags && hasIndicatorsList {
@@ -435,6 +736,24 @@ func ResolveIndicators(flags *DCAFlags) ([]string, error) {
 		if *flags.UseStochasticRSI {
 			indicators = append(indicators, "stochrsi")
 		}
+		if *flags.UseADX {
+			indicators = append(indicators, "adx")
+		}
+		if *flags.UseIchimoku {
+			indicators = append(indicators, "ichimoku")
+		}
+		if *flags.UseDonchian {
+			indicators = append(indicators, "donchian")
+		}
+		if *flags.UseAVWAP {
+			indicators = append(indicators, "avwap")
+		}
+		if *flags.UseCMF {
+			indicators = append(indicators, "cmf")
+		}
+		if *flags.UseVolumeProfile {
+			indicators = append(indicators, "volume_profile")
+		}
 	}
 	
 	if len(indicators) == 0 {
@@ -449,6 +768,8 @@ func isValidIndicator(indicator string) bool {
 	validIndicators := []string{
 		"rsi", "macd", "bb", "bollinger", "ema", "sma",
 		"hullma", "hull_ma", "supertrend", "st", "mfi", "keltner", "kc", "wavetrend", "wt", "obv", "stochrsi", "stochastic_rsi", "stoch_rsi",
+		"adx", "dmi", "ichimoku", "ichi", "donchian", "dc",
+		"anchored_vwap", "avwap", "vwap", "cmf", "chaikin", "volume_profile", "vp", "vpvr",
 	}
 	
 	for _, valid := range validIndicators {
@@ -494,6 +815,18 @@ func GetIndicatorDescription(indicators []string) string {
 			upperIndicators[i] = "OBV"
 		case "stochrsi", "stochastic_rsi", "stoch_rsi":
 			upperIndicators[i] = "Stochastic RSI"
+		case "adx", "dmi":
+			upperIndicators[i] = "ADX"
+		case "ichimoku", "ichi":
+			upperIndicators[i] = "Ichimoku"
+		case "donchian", "dc":
+			upperIndicators[i] = "Donchian"
+		case "anchored_vwap", "avwap", "vwap":
+			upperIndicators[i] = "Anchored VWAP"
+		case "cmf", "chaikin":
+			upperIndicators[i] = "CMF"
+		case "volume_profile", "vp", "vpvr":
+			upperIndicators[i] = "Volume Profile"
 		default:
 			upperIndicators[i] = strings.ToUpper(ind)
 		}
@@ -511,3 +844,56 @@ func contains(slice []string, item string) bool {
 	}
 	return false
 }
+
+// ResolveSearchOptions builds optimizer options from the command line flags
+func ResolveSearchOptions(flags *DCAFlags) (optimization.SearchOptions, error) {
+	opts := optimization.DefaultSearchOptions()
+	
+	method, err := optimization.ParseSearchMethod(*flags.Optimizer)
+	if err != nil {
+		return opts, err
+	}
+	
+	opts.Method = method
+	opts.Seed = *flags.Seed
+	opts.MaxEvaluations = *flags.MaxEvals
+	opts.MaxDuration = *flags.MaxTime
+	opts.EarlyStopPatience = *flags.EarlyStop
+	return opts, nil
+}
+
+// ResolveWalkForwardConfig builds the walk-forward settings from the command
+// line flags, returning nil when validation is disabled
+func ResolveWalkForwardConfig(flags *DCAFlags) *validation.WalkForwardConfig {
+	if !*flags.WFEnable {
+		return nil
+	}
+	
+	return &validation.WalkForwardConfig{
+		Enable:      true,
+		Rolling:     *flags.WFRolling,
+		Anchored:    *flags.WFAnchored,
+		SplitRatio:  *flags.WFSplitRatio,
+		TrainDays:   *flags.WFTrainDays,
+		TestDays:    *flags.WFTestDays,
+		RollDays:    *flags.WFRollDays,
+		EmbargoDays: *flags.WFEmbargoDays,
+	}
+}
+
+// ResolveMonteCarloConfig builds the Monte Carlo settings from the command line
+// flags, returning nil when Monte Carlo analysis is disabled
+func ResolveMonteCarloConfig(flags *DCAFlags) *montecarlo.Config {
+	if *flags.MonteCarlo <= 0 {
+		return nil
+	}
+	
+	mcConfig := montecarlo.DefaultConfig()
+	mcConfig.Runs = *flags.MonteCarlo
+	mcConfig.Seed = *flags.MCSeed
+	mcConfig.BlockSize = *flags.MCBlockSize
+	mcConfig.MaxLatency = *flags.MCLatency
+	mcConfig.FeeJitter = *flags.MCFeeJitter
+	mcConfig.RuinThreshold = *flags.MCRuinDrawdown
+	return &mcConfig
+}
@@ -4,14 +4,19 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/ducminhle1904/crypto-dca-bot/internal/backtest"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/strategy"
 	"github.com/ducminhle1904/crypto-dca-bot/pkg/config"
 	datamanager "github.com/ducminhle1904/crypto-dca-bot/pkg/data"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/montecarlo"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/optimization"
 	"github.com/ducminhle1904/crypto-dca-bot/pkg/orchestrator"
 	"github.com/ducminhle1904/crypto-dca-bot/pkg/reporting"
 	"github.com/ducminhle1904/crypto-dca-bot/pkg/validation"
@@ -54,6 +59,11 @@ func main() {
 		return
 	}
 	
+	if *flags.ListStrategies {
+		printStrategies()
+		return
+	}
+	
 	// Header
 	printHeader()
 	
@@ -67,6 +77,11 @@ func main() {
 		log.Fatalf("❌ Configuration error: %v", err)
 	}
 	
+	// Decision snapshots only feed the report files
+	if !*flags.ConsoleOnly {
+		cfg.DecisionSnapshots, _ = backtest.ParseSnapshotMode(*flags.Snapshots)
+	}
+	
 	// Parse period filter
 	var selectedPeriod time.Duration
 	if *flags.Period != "" {
@@ -82,18 +97,34 @@ func main() {
 		}
 	}
 	
+	// Resolve optimizer options
+	searchOptions, err := ResolveSearchOptions(flags)
+	if err != nil {
+		log.Fatalf("❌ Optimizer configuration error: %v", err)
+	}
+	
+	// Share indicator series between optimizer backtests
+	indicatorCacheBytes := int64(*flags.IndicatorCacheMB) << 20
+	optimization.SetIndicatorCacheBudget(indicatorCacheBytes)
+	
+	if *flags.BenchIndicatorCache {
+		runIndicatorCacheBenchmark(cfg, selectedPeriod, searchOptions, indicatorCacheBytes)
+		return
+	}
+	
 	// Create orchestrator
-	orch := orchestrator.NewOrchestrator()
+	orch := orchestrator.NewOrchestratorWithSearchOptions(searchOptions)
 	
 	// Execute based on options
-	if *flags.AllIntervals {
+	if *flags.LookaheadCheck {
+		runLookaheadCheck(orch, cfg, selectedPeriod)
+	} else if *flags.AllIntervals {
 		runMultiIntervalAnalysis(orch, cfg, *flags.DataRoot, *flags.Exchange, *flags.Optimize, selectedPeriod, 
-			*flags.WFEnable, *flags.WFSplitRatio, *flags.WFRolling, *flags.WFTrainDays, *flags.WFTestDays, *flags.WFRollDays, *flags.ConsoleOnly)
+			ResolveWalkForwardConfig(flags), *flags.Report, *flags.ConsoleOnly)
 	} else if *flags.Optimize {
-		runOptimization(orch, cfg, selectedPeriod, *flags.WFEnable, *flags.WFSplitRatio, *flags.WFRolling, 
-			*flags.WFTrainDays, *flags.WFTestDays, *flags.WFRollDays, *flags.ConsoleOnly)
+		runOptimization(orch, cfg, selectedPeriod, ResolveWalkForwardConfig(flags), ResolveMonteCarloConfig(flags), *flags.Report, *flags.ConsoleOnly)
 	} else {
-		runSingleBacktest(orch, cfg, selectedPeriod, *flags.ConsoleOnly)
+		runSingleBacktest(orch, cfg, selectedPeriod, ResolveMonteCarloConfig(flags), *flags.Report, *flags.ConsoleOnly)
 	}
 }
 
@@ -161,8 +192,29 @@ func loadDCAConfiguration(configFile, dataFile, symbol, interval string,
 		cfg.TPPercent = DefaultTPPercent
 	}
 	
+	// Select the strategy from command line flags if the config names none
+	if cfg.StrategyName == "" {
+		cfg.StrategyName = strings.TrimSpace(*flags.Strategy)
+	}
+	if cfg.StrategyParams == nil && strings.TrimSpace(*flags.StrategyParams) != "" {
+		strategyParams, err := parseStrategyParams(*flags.StrategyParams)
+		if err != nil {
+			return nil, fmt.Errorf("invalid strategy parameters: %w", err)
+		}
+		cfg.StrategyParams = strategyParams
+	}
+	def, err := strategy.Lookup(cfg.StrategyName)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := def.ResolveParams(cfg.StrategyParams); err != nil {
+		return nil, err
+	}
+	
 	// Priority: Config file indicators > Command line indicators > ERROR (no defaults)
-	if configFile != "" && len(cfg.Indicators) > 0 {
+	if !def.DCASettings {
+		// The strategy chooses its own indicators
+	} else if configFile != "" && len(cfg.Indicators) > 0 {
 		// Config file has indicators - use them (highest priority)
 		// log.Printf("📋 Using indicators from config file: %s", strings.Join(cfg.Indicators, ", "))
 	} else {
@@ -182,7 +234,7 @@ func loadDCAConfiguration(configFile, dataFile, symbol, interval string,
 				"  • Individual flags: -rsi -macd -bb -ema\n" +
 				"  • Indicator list: -indicators \"rsi,macd,bb,ema\"\n" +
 				"  • Config file with indicators specified\n" +
-				"\nAvailable indicators: rsi, macd, bb, ema, hullma, supertrend, mfi, keltner, wavetrend, obv, stochrsi")
+				"\nAvailable indicators: rsi, macd, bb, ema, hullma, supertrend, mfi, keltner, wavetrend, obv, stochrsi, adx, ichimoku, donchian, avwap, cmf, volume_profile")
 		}
 	}
 	
@@ -218,6 +270,36 @@ func loadDCAConfiguration(configFile, dataFile, symbol, interval string,
 		cfg.DynamicTP = dynamicTPConfig
 	}
 	
+	// Configure signal aggregation from command line flags if not present in config
+	if cfg.SignalAggregation == nil {
+		aggregation, err := createSignalAggregationFromFlags(flags)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create signal aggregation configuration: %w", err)
+		}
+		cfg.SignalAggregation = aggregation
+	}
+	if err := cfg.SignalAggregation.ValidateFor(cfg.Indicators); err != nil {
+		return nil, fmt.Errorf("invalid signal aggregation: %w", err)
+	}
+	
+	// Configure expression conditions from command line flags if not present in config
+	if cfg.Conditions == nil {
+		conditions, err := createConditionsFromFlags(flags)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create conditions: %w", err)
+		}
+		cfg.Conditions = conditions
+	}
+	
+	// Configure higher timeframe confirmations from command line flags if not present in config
+	if cfg.Timeframes == nil {
+		timeframes, err := createTimeframesFromFlags(flags)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create timeframes: %w", err)
+		}
+		cfg.Timeframes = timeframes
+	}
+	
 	// Set TP parameters from flags if not in config
 	if cfg.TPPercent == 0 {
 		cfg.TPPercent = *flags.TPPercent
@@ -269,6 +351,12 @@ func loadDCAConfiguration(configFile, dataFile, symbol, interval string,
 				return nil, fmt.Errorf("CRITICAL VALIDATION ERROR: Dynamic TP min (%.3f) >= max (%.3f) - this should never happen", min, max)
 			}
 		}
+		if cfg.DynamicTP.TimeDecayConfig != nil {
+			floor := cfg.DynamicTP.TimeDecayConfig.FloorPercent
+			if floor >= cfg.DynamicTP.BaseTPPercent {
+				return nil, fmt.Errorf("CRITICAL VALIDATION ERROR: Time-decay TP floor (%.3f) >= base TP (%.3f) - this should never happen", floor, cfg.DynamicTP.BaseTPPercent)
+			}
+		}
 	}
 
 	return cfg, nil
@@ -278,6 +366,7 @@ func printConfigSummary(cfg *config.DCAConfig) {
 	fmt.Printf("📊 DCA Strategy Configuration\n")
 	fmt.Printf("   Symbol: %s\n", cfg.Symbol)
 	fmt.Printf("   Interval: %s\n", cfg.Interval)
+	printStrategyName(cfg)
 	fmt.Printf("   Balance: $%.2f\n", cfg.InitialBalance)
 	fmt.Printf("   Base Amount: $%.2f\n", cfg.BaseAmount)
 	fmt.Printf("   Max Multiplier: %.2fx\n", cfg.MaxMultiplier)
@@ -304,11 +393,42 @@ func printConfigSummary(cfg *config.DCAConfig) {
 					fmt.Printf("   ATR-based: %.2f%% base, %.1fx sensitivity\n", baseThreshold*100, sensitivity)
 				}
 			}
+		} else if cfg.DCASpacing.Strategy == "volume_profile" {
+			if baseThreshold, ok := cfg.DCASpacing.Parameters["base_threshold"].(float64); ok {
+				if multiplier, ok := cfg.DCASpacing.Parameters["threshold_multiplier"].(float64); ok {
+					fmt.Printf("   Volume nodes: %.2f%% base, %.2fx multiplier, snapped to high-volume support\n", baseThreshold*100, multiplier)
+				}
+			}
+		} else if detail := describeSpacingDetail(cfg.DCASpacing); detail != "" {
+			fmt.Printf("   %s\n", detail)
 		}
 	} else {
 		fmt.Printf("   DCA Spacing: Not configured\n")
 	}
 	
+	// Ladder sizing display, with a warning when the full ladder does not fit the balance
+	if budget := cfg.LadderBudget(); budget != nil {
+		fmt.Printf("   Sizing: %s ladder, %s\n", cfg.PositionSizing.Mode, budget)
+		if warning := budget.Warning(); warning != "" {
+			fmt.Printf("   ⚠️  %s\n", warning)
+		}
+	}
+	
+	if cfg.SignalAggregation != nil {
+		fmt.Printf("   Signal Aggregation: %s\n", describeSignalAggregation(cfg.SignalAggregation))
+	}
+	printConditions(cfg.Conditions)
+	printTimeframes(cfg.Timeframes)
+	
+	if cfg.LimitGrid.IsEnabled() {
+		commission := cfg.LimitGrid.MakerCommission
+		if commission <= 0 {
+			commission = cfg.Commission
+		}
+		fmt.Printf("   Entries: limit grid, %d levels resting (Low-touch fills, %.3f%% commission)\n",
+			cfg.LimitGrid.GridLevels(), commission*100)
+	}
+	
 	// Display TP system information
 	if cfg.UseTPLevels && cfg.DynamicTP != nil {
 		fmt.Printf("   TP System: Multi-level Dynamic %s (5 levels, base: %.2f%%", cfg.DynamicTP.Strategy, cfg.TPPercent*100)
@@ -320,6 +440,8 @@ func printConfigSummary(cfg *config.DCAConfig) {
 			fmt.Printf(", range: %.2f%%-%.2f%%", 
 				cfg.DynamicTP.IndicatorConfig.MinTPPercent*100,
 				cfg.DynamicTP.IndicatorConfig.MaxTPPercent*100)
+		} else if cfg.DynamicTP.TimeDecayConfig != nil {
+			fmt.Printf(", %s", describeTimeDecay(cfg.DynamicTP.TimeDecayConfig))
 		}
 		fmt.Printf(")\n")
 	} else if cfg.UseTPLevels {
@@ -334,6 +456,8 @@ func printConfigSummary(cfg *config.DCAConfig) {
 			fmt.Printf(", range: %.2f%%-%.2f%%", 
 				cfg.DynamicTP.IndicatorConfig.MinTPPercent*100,
 				cfg.DynamicTP.IndicatorConfig.MaxTPPercent*100)
+		} else if cfg.DynamicTP.TimeDecayConfig != nil {
+			fmt.Printf(", %s", describeTimeDecay(cfg.DynamicTP.TimeDecayConfig))
 		}
 		fmt.Printf(")\n")
 	} else {
@@ -383,12 +507,28 @@ func printIndicatorSettings(cfg *config.DCAConfig) {
 		case "stochrsi", "stochastic_rsi", "stoch_rsi":
 			fmt.Printf("      • Stochastic RSI: period=%d, overbought=%.1f, oversold=%.1f\n", 
 				cfg.StochasticRSIPeriod, cfg.StochasticRSIOverbought, cfg.StochasticRSIOversold)
+		case "adx", "dmi":
+			fmt.Printf("      • ADX: period=%d, threshold=%.0f\n", 
+				cfg.ADXPeriod, cfg.ADXThreshold)
+		case "ichimoku", "ichi":
+			fmt.Printf("      • Ichimoku: tenkan=%d, kijun=%d, senkou_b=%d\n", 
+				cfg.IchimokuTenkan, cfg.IchimokuKijun, cfg.IchimokuSenkouB)
+		case "donchian", "dc":
+			fmt.Printf("      • Donchian: period=%d\n", cfg.DonchianPeriod)
+		case "anchored_vwap", "avwap", "vwap":
+			fmt.Printf("      • Anchored VWAP: anchor=%s, anchor_lookback=%d, band_multiplier=%.1f\n", 
+				cfg.AVWAPAnchor, cfg.AVWAPAnchorLookback, cfg.AVWAPBandMultiplier)
+		case "cmf", "chaikin":
+			fmt.Printf("      • CMF: period=%d, threshold=%.2f\n", cfg.CMFPeriod, cfg.CMFThreshold)
+		case "volume_profile", "vp", "vpvr":
+			fmt.Printf("      • Volume Profile: lookback=%d, bins=%d, node_factor=%.1f, proximity=%.3f\n", 
+				cfg.VolumeProfileLookback, cfg.VolumeProfileBins, cfg.VolumeProfileNodeFactor, cfg.VolumeProfileProximity)
 		}
 	}
 }
 
 func runSingleBacktest(orch orchestrator.Orchestrator, cfg *config.DCAConfig, 
-	selectedPeriod time.Duration, consoleOnly bool) {
+	selectedPeriod time.Duration, mcConfig *montecarlo.Config, reportFormat string, consoleOnly bool) {
 	
 	fmt.Printf("🚀 Starting DCA Backtest\n\n")
 	
@@ -402,29 +542,138 @@ func runSingleBacktest(orch orchestrator.Orchestrator, cfg *config.DCAConfig,
 	reporting.OutputConsoleWithContext(results, cfg.Symbol, interval)
 	
 	if !consoleOnly {
-		saveResults(results, cfg.Symbol, interval, "optimized_trades.xlsx")
+		saveResults(results, cfg.Symbol, interval, "optimized_trades.xlsx", reportFormat)
 	}
+	
+	runMonteCarlo(orch, cfg, results, selectedPeriod, mcConfig, interval, consoleOnly)
 }
 
-func runOptimization(orch orchestrator.Orchestrator, cfg *config.DCAConfig, 
-	selectedPeriod time.Duration, wfEnable bool, wfSplitRatio float64, wfRolling bool,
-	wfTrainDays, wfTestDays, wfRollDays int, consoleOnly bool) {
+// lookaheadCuts is the number of altered-future reruns of the look-ahead audit
+const lookaheadCuts = 5
+
+// runLookaheadCheck audits the backtest for decisions that read candles that
+// had not closed yet and exits 1 on a violation
+func runLookaheadCheck(orch orchestrator.Orchestrator, cfg *config.DCAConfig, selectedPeriod time.Duration) {
+	fmt.Printf("🔬 Starting Look-Ahead Check\n\n")
 	
-	fmt.Printf("🧬 Starting DCA Optimization\n\n")
+	audit, err := orch.RunLookaheadAudit(cfg, selectedPeriod, lookaheadCuts)
+	if err != nil {
+		log.Fatalf("❌ Look-ahead check failed: %v", err)
+	}
 	
-	// Create walk-forward config if enabled
-	var wfConfig *validation.WalkForwardConfig
-	if wfEnable {
-		wfConfig = &validation.WalkForwardConfig{
-			Enable:     true,
-			Rolling:    wfRolling,
-			SplitRatio: wfSplitRatio,
-			TrainDays:  wfTrainDays,
-			TestDays:   wfTestDays,
-			RollDays:   wfRollDays,
+	fmt.Printf("   Decisions checked: %d\n", audit.Decisions)
+	fmt.Printf("   Higher timeframe candle sets rebuilt: %d\n", audit.FeedChecks)
+	fmt.Printf("   Altered-future reruns: %d\n", audit.Cuts)
+	if audit.Passed() {
+		fmt.Printf("\n✅ No look-ahead: every decision only read closed candles\n")
+		return
+	}
+	
+	fmt.Printf("\n❌ %d look-ahead violation(s):\n", len(audit.Violations))
+	for i, v := range audit.Violations {
+		if i == 20 {
+			fmt.Printf("   ... and %d more\n", len(audit.Violations)-i)
+			break
+		}
+		fmt.Printf("   - %s\n", v)
+	}
+	os.Exit(1)
+}
+
+// runIndicatorCacheBenchmark runs the same seeded optimization without and
+// with the indicator series cache, then compares wall-clock time and checks
+// that every evaluated config scored the same
+func runIndicatorCacheBenchmark(cfg *config.DCAConfig, selectedPeriod time.Duration, opts optimization.SearchOptions, cacheBytes int64) {
+	fmt.Printf("⏱️ Starting Indicator Cache Benchmark\n\n")
+	
+	if opts.Seed == 0 {
+		opts.Seed = time.Now().UnixNano()
+	}
+	
+	// Load the data up front so neither run pays for it
+	data, err := datamanager.LoadHistoricalDataCached(cfg.DataFile)
+	if err != nil {
+		log.Fatalf("❌ Failed to load data: %v", err)
+	}
+	if selectedPeriod > 0 {
+		data = datamanager.FilterDataByPeriod(data, selectedPeriod)
+	}
+	fmt.Printf("   Data: %d candles (%s)\n", len(data), cfg.DataFile)
+	fmt.Printf("   Optimizer: %s, seed %d\n\n", opts.Method, opts.Seed)
+	
+	run := func(label string, budget int64) *optimization.SearchResult {
+		optimization.SetIndicatorCacheBudget(budget)
+		start := time.Now()
+		_, _, result, err := optimization.OptimizeWithOptions(cfg, cfg.DataFile, selectedPeriod, opts)
+		if err != nil {
+			log.Fatalf("❌ Optimization %s failed: %v", label, err)
 		}
+		result.Elapsed = time.Since(start)
+		return result
 	}
 	
+	uncached := run("without cache", 0)
+	cached := run("with cache", cacheBytes)
+	stats, _ := optimization.IndicatorCacheStats()
+	
+	// Parallel evaluation lets the two searches wander apart, so only configs
+	// evaluated by both runs are compared; each of them must have scored the same
+	fitness := make(map[string]float64, len(uncached.Evaluations))
+	for _, rec := range uncached.Evaluations {
+		fitness[describeParams(rec.Params)] = rec.Fitness
+	}
+	compared, mismatches := 0, 0
+	for _, rec := range cached.Evaluations {
+		want, ok := fitness[describeParams(rec.Params)]
+		if !ok {
+			continue
+		}
+		compared++
+		if want != rec.Fitness {
+			mismatches++
+		}
+	}
+	
+	fmt.Printf("\n%s\n", strings.Repeat("=", 50))
+	fmt.Printf("⏱️ INDICATOR CACHE BENCHMARK\n")
+	fmt.Printf("%s\n", strings.Repeat("=", 50))
+	fmt.Printf("   Backtests:        %d without cache, %d with cache\n", len(uncached.Evaluations), len(cached.Evaluations))
+	fmt.Printf("   Without cache:    %v\n", uncached.Elapsed.Round(time.Millisecond))
+	fmt.Printf("   With cache:       %v\n", cached.Elapsed.Round(time.Millisecond))
+	if cached.Elapsed > 0 {
+		fmt.Printf("   Speedup:          %.2fx\n", float64(uncached.Elapsed)/float64(cached.Elapsed))
+	}
+	fmt.Printf("   Series computed:  %d (served %d times from the cache)\n", stats.Misses, stats.Hits)
+	fmt.Printf("   Cache held:       %d series, %.1f MB of %d MB (%d evicted)\n",
+		stats.Entries, float64(stats.Bytes)/(1<<20), cacheBytes>>20, stats.Evictions)
+	
+	if mismatches > 0 {
+		fmt.Printf("\n❌ %d of %d configs evaluated by both runs scored differently with the cache\n", mismatches, compared)
+		os.Exit(1)
+	}
+	fmt.Printf("\n✅ %d configs evaluated by both runs scored the same with the cache\n", compared)
+}
+
+// describeParams renders optimizer params in a stable order
+func describeParams(params map[string]string) string {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = name + "=" + params[name]
+	}
+	return strings.Join(parts, " ")
+}
+
+func runOptimization(orch orchestrator.Orchestrator, cfg *config.DCAConfig, 
+	selectedPeriod time.Duration, wfConfig *validation.WalkForwardConfig, mcConfig *montecarlo.Config, reportFormat string, consoleOnly bool) {
+	
+	fmt.Printf("🧬 Starting DCA Optimization\n\n")
+	
 	bestResults, bestConfig, err := orch.RunOptimizedBacktest(cfg, selectedPeriod, wfConfig)
 	if err != nil {
 		log.Fatalf("❌ Optimization failed: %v", err)
@@ -434,43 +683,67 @@ func runOptimization(orch orchestrator.Orchestrator, cfg *config.DCAConfig,
 	printOptimizationResults(bestConfig, bestResults)
 	
 	interval := guessIntervalFromPath(bestConfig.DataFile)
+	reporting.PrintSearchSummary(orch.LastSearchResult(), 10)
 	reporting.OutputConsoleWithContext(bestResults, bestConfig.Symbol, interval)
 	
 	if !consoleOnly {
-		saveResults(bestResults, bestConfig.Symbol, interval, "optimized_trades.xlsx")
+		saveResults(bestResults, bestConfig.Symbol, interval, "optimized_trades.xlsx", reportFormat)
 		saveOptimizedConfig(bestConfig, bestConfig.Symbol, interval)
+		saveSearchEvaluations(orch.LastSearchResult(), bestConfig.Symbol, interval)
+		saveWalkForwardReport(orch.LastWalkForwardSummary(), bestConfig.Symbol, interval)
+	}
+	
+	runMonteCarlo(orch, bestConfig, bestResults, selectedPeriod, mcConfig, interval, consoleOnly)
+}
+
+// runMonteCarlo runs the robustness analysis around a finished backtest when enabled
+func runMonteCarlo(orch orchestrator.Orchestrator, cfg *config.DCAConfig, baseline *backtest.BacktestResults,
+	selectedPeriod time.Duration, mcConfig *montecarlo.Config, interval string, consoleOnly bool) {
+	if mcConfig == nil {
+		return
+	}
+	
+	mcResults, err := orch.RunMonteCarlo(cfg, baseline, selectedPeriod, *mcConfig)
+	if err != nil {
+		log.Printf("⚠️  Monte Carlo analysis failed: %v", err)
+		return
+	}
+	
+	reporting.PrintMonteCarloSummary(mcResults)
+	
+	if consoleOnly {
+		return
+	}
+	outputDir := reporting.DefaultOutputDir(cfg.Symbol, interval)
+	
+	jsonPath := filepath.Join(outputDir, "monte_carlo.json")
+	if err := reporting.WriteMonteCarloJSON(mcResults, jsonPath); err != nil {
+		log.Printf("⚠️  Failed to save Monte Carlo summary: %v", err)
+	} else {
+		fmt.Printf("💾 Monte Carlo summary saved: %s\n", jsonPath)
+	}
+	
+	csvPath := filepath.Join(outputDir, "monte_carlo_runs.csv")
+	if err := reporting.WriteMonteCarloRunsCSV(mcResults, csvPath); err != nil {
+		log.Printf("⚠️  Failed to save Monte Carlo runs: %v", err)
 	}
 }
 
 func runMultiIntervalAnalysis(orch orchestrator.Orchestrator, cfg *config.DCAConfig,
 	dataRoot, exchange string, optimize bool, selectedPeriod time.Duration,
-	wfEnable bool, wfSplitRatio float64, wfRolling bool, wfTrainDays, wfTestDays, wfRollDays int,
-	consoleOnly bool) {
+	wfConfig *validation.WalkForwardConfig, reportFormat string, consoleOnly bool) {
 	
 	fmt.Printf("📊 Starting Multi-Interval Analysis\n\n")
 	
-	// Create walk-forward config if enabled
-	var wfConfig *validation.WalkForwardConfig
-	if wfEnable {
-		wfConfig = &validation.WalkForwardConfig{
-			Enable:     wfEnable,
-			Rolling:    wfRolling,
-			SplitRatio: wfSplitRatio,
-			TrainDays:  wfTrainDays,
-			TestDays:   wfTestDays,
-			RollDays:   wfRollDays,
-		}
-	}
-	
 	results, err := orch.RunMultiIntervalAnalysis(cfg, dataRoot, exchange, optimize, selectedPeriod, wfConfig)
 	if err != nil {
 		log.Fatalf("❌ Multi-interval analysis failed: %v", err)
 	}
 	
-	displayIntervalResults(results, consoleOnly)
+	displayIntervalResults(results, reportFormat, consoleOnly)
 }
 
-func displayIntervalResults(results *orchestrator.IntervalAnalysisResult, consoleOnly bool) {
+func displayIntervalResults(results *orchestrator.IntervalAnalysisResult, reportFormat string, consoleOnly bool) {
 	fmt.Printf("\n📈 INTERVAL COMPARISON - %s\n", results.Symbol)
 	fmt.Printf("%s\n", strings.Repeat("=", 90))
 	fmt.Printf("%-8s | %7s | %6s | %5s | %7s | %5s | %8s | %6s | %s\n",
@@ -526,11 +799,17 @@ func displayIntervalResults(results *orchestrator.IntervalAnalysisResult, consol
 		best.OptimizedCfg.TPPercent*100, spacingInfo)
 	
 	// Show detailed results for best interval
+	reporting.PrintSearchSummary(best.Search, 10)
 	reporting.OutputConsole(best.Results)
 	
 	if !consoleOnly {
-		saveResults(best.Results, results.Symbol, best.Interval, "optimized_trades.xlsx")
+		saveResults(best.Results, results.Symbol, best.Interval, "optimized_trades.xlsx", reportFormat)
 		saveOptimizedConfig(best.OptimizedCfg, results.Symbol, best.Interval)
+		for _, r := range results.Results {
+			if r.Error == nil && r.Search != nil {
+				saveSearchEvaluations(r.Search, results.Symbol, r.Interval)
+			}
+		}
 	}
 }
 
@@ -538,6 +817,7 @@ func printOptimizationResults(bestConfig *config.DCAConfig, bestResults *backtes
 	fmt.Printf("\n🎯 OPTIMIZED DCA STRATEGY CONFIGURATION\n")
 	fmt.Printf("%s\n", strings.Repeat("=", 50))
 	fmt.Printf("   Best Return: %.2f%%\n", bestResults.TotalReturn*100)
+	printStrategyName(bestConfig)
 	fmt.Printf("   Base Amount: $%.2f\n", bestConfig.BaseAmount)
 	fmt.Printf("   Max Multiplier: %.2fx\n", bestConfig.MaxMultiplier)
 	
@@ -571,11 +851,38 @@ func printOptimizationResults(bestConfig *config.DCAConfig, bestResults *backtes
 					fmt.Printf("\n")
 				}
 			}
+		} else if bestConfig.DCASpacing.Strategy == "volume_profile" {
+			if baseThreshold, ok := bestConfig.DCASpacing.Parameters["base_threshold"].(float64); ok {
+				if multiplier, ok := bestConfig.DCASpacing.Parameters["threshold_multiplier"].(float64); ok {
+					fmt.Printf("   Volume nodes: %.3f%% base, %.3fx multiplier", baseThreshold*100, multiplier)
+					if lookback, ok := bestConfig.DCASpacing.Parameters["lookback"].(int); ok {
+						fmt.Printf(", %d-candle profile", lookback)
+					}
+					if snapRange, ok := bestConfig.DCASpacing.Parameters["snap_range"].(float64); ok {
+						fmt.Printf(", ±%.0f%% snap range", snapRange*100)
+					}
+					fmt.Printf("\n")
+				}
+			}
+		} else if detail := describeSpacingDetail(bestConfig.DCASpacing); detail != "" {
+			fmt.Printf("   %s\n", detail)
 		}
 	} else {
 		fmt.Printf("   DCA Spacing: Not configured\n")
 	}
 	
+	if budget := bestConfig.LadderBudget(); budget != nil {
+		fmt.Printf("   Sizing: %s ladder, %s\n", bestConfig.PositionSizing.Mode, budget)
+	}
+	if bestConfig.LimitGrid.IsEnabled() {
+		fmt.Printf("   Entries: limit grid, %d levels resting\n", bestConfig.LimitGrid.GridLevels())
+	}
+	if bestConfig.SignalAggregation != nil {
+		fmt.Printf("   Signal Aggregation: %s\n", describeSignalAggregation(bestConfig.SignalAggregation))
+	}
+	printConditions(bestConfig.Conditions)
+	printTimeframes(bestConfig.Timeframes)
+	
 	// Display TP system information
 	if bestConfig.UseTPLevels {
 		fmt.Printf("   TP System: Multi-level (5 levels, %.2f%% max)\n", bestConfig.TPPercent*100)
@@ -589,6 +896,8 @@ func printOptimizationResults(bestConfig *config.DCAConfig, bestResults *backtes
 			fmt.Printf(", range: %.2f%%-%.2f%%", 
 				bestConfig.DynamicTP.IndicatorConfig.MinTPPercent*100,
 				bestConfig.DynamicTP.IndicatorConfig.MaxTPPercent*100)
+		} else if bestConfig.DynamicTP.TimeDecayConfig != nil {
+			fmt.Printf(", %s", describeTimeDecay(bestConfig.DynamicTP.TimeDecayConfig))
 		}
 		fmt.Printf(")\n")
 	} else {
@@ -638,6 +947,22 @@ func printOptimizedIndicatorSettings(cfg *config.DCAConfig) {
 		case "stochrsi", "stochastic_rsi", "stoch_rsi":
 			fmt.Printf("      • Stochastic RSI: period=%d, overbought=%.1f, oversold=%.1f\n", 
 				cfg.StochasticRSIPeriod, cfg.StochasticRSIOverbought, cfg.StochasticRSIOversold)
+		case "adx", "dmi":
+			fmt.Printf("      • ADX: period=%d, threshold=%.0f\n", 
+				cfg.ADXPeriod, cfg.ADXThreshold)
+		case "ichimoku", "ichi":
+			fmt.Printf("      • Ichimoku: tenkan=%d, kijun=%d, senkou_b=%d\n", 
+				cfg.IchimokuTenkan, cfg.IchimokuKijun, cfg.IchimokuSenkouB)
+		case "donchian", "dc":
+			fmt.Printf("      • Donchian: period=%d\n", cfg.DonchianPeriod)
+		case "anchored_vwap", "avwap", "vwap":
+			fmt.Printf("      • Anchored VWAP: anchor=%s, anchor_lookback=%d, band_multiplier=%.1f\n", 
+				cfg.AVWAPAnchor, cfg.AVWAPAnchorLookback, cfg.AVWAPBandMultiplier)
+		case "cmf", "chaikin":
+			fmt.Printf("      • CMF: period=%d, threshold=%.2f\n", cfg.CMFPeriod, cfg.CMFThreshold)
+		case "volume_profile", "vp", "vpvr":
+			fmt.Printf("      • Volume Profile: lookback=%d, bins=%d, node_factor=%.1f, proximity=%.3f\n", 
+				cfg.VolumeProfileLookback, cfg.VolumeProfileBins, cfg.VolumeProfileNodeFactor, cfg.VolumeProfileProximity)
 		}
 	}
 }
@@ -665,14 +990,35 @@ func guessIntervalFromPath(path string) string {
 	return interval
 }
 
-func saveResults(results *backtest.BacktestResults, symbol, interval, filename string) {
+func saveResults(results *backtest.BacktestResults, symbol, interval, filename, reportFormat string) {
 	outputDir := reporting.DefaultOutputDir(symbol, interval)
 	filePath := filepath.Join(outputDir, filename)
+	format := strings.ToLower(reportFormat)
 	
-	if err := reporting.WriteTradesXLSX(results, filePath); err != nil {
-		log.Printf("⚠️  Failed to save results: %v", err)
-	} else {
-		fmt.Printf("💾 Results saved: %s\n", filePath)
+	if format != ReportFormatHTML {
+		if err := reporting.WriteTradesXLSX(results, filePath); err != nil {
+			log.Printf("⚠️  Failed to save results: %v", err)
+		} else {
+			fmt.Printf("💾 Results saved: %s\n", filePath)
+		}
+	}
+	
+	if len(results.DecisionSnapshots) > 0 {
+		jsonPath := filepath.Join(outputDir, "signal_snapshots.json")
+		if err := reporting.WriteDecisionSnapshotsJSON(results, jsonPath); err != nil {
+			log.Printf("⚠️  Failed to save signal snapshots: %v", err)
+		} else {
+			fmt.Printf("💾 Signal snapshots saved: %s (%d decisions)\n", jsonPath, len(results.DecisionSnapshots))
+		}
+	}
+	
+	if format == ReportFormatHTML || format == ReportFormatAll {
+		htmlPath := strings.TrimSuffix(filePath, filepath.Ext(filePath)) + ".html"
+		if err := reporting.WriteTradesHTML(results, symbol, interval, htmlPath); err != nil {
+			log.Printf("⚠️  Failed to save HTML report: %v", err)
+		} else {
+			fmt.Printf("💾 HTML report saved: %s\n", htmlPath)
+		}
 	}
 }
 
@@ -687,6 +1033,55 @@ func saveOptimizedConfig(cfg *config.DCAConfig, symbol, interval string) {
 	}
 }
 
+func saveSearchEvaluations(result *optimization.SearchResult, symbol, interval string) {
+	if result == nil {
+		return
+	}
+	outputDir := reporting.DefaultOutputDir(symbol, interval)
+	
+	csvPath := filepath.Join(outputDir, "optimization_evaluations.csv")
+	if err := reporting.WriteSearchEvaluationsCSV(result, csvPath); err != nil {
+		log.Printf("⚠️  Failed to save optimization evaluations: %v", err)
+	} else {
+		fmt.Printf("💾 Evaluations saved: %s\n", csvPath)
+	}
+	
+	jsonPath := filepath.Join(outputDir, "optimization_evaluations.json")
+	if err := reporting.WriteSearchResultJSON(result, jsonPath); err != nil {
+		log.Printf("⚠️  Failed to save optimization summary: %v", err)
+	}
+}
+
+// saveWalkForwardReport writes the fold comparison and parameter stability
+// analysis, plus the consensus config when one was built
+func saveWalkForwardReport(summary *validation.WalkForwardSummary, symbol, interval string) {
+	if summary == nil {
+		return
+	}
+	outputDir := reporting.DefaultOutputDir(symbol, interval)
+	
+	jsonPath := filepath.Join(outputDir, "walk_forward.json")
+	if err := reporting.WriteWalkForwardJSON(summary, jsonPath); err != nil {
+		log.Printf("⚠️  Failed to save walk-forward report: %v", err)
+	} else {
+		fmt.Printf("💾 Walk-forward report saved: %s\n", jsonPath)
+	}
+	
+	xlsxPath := filepath.Join(outputDir, "walk_forward.xlsx")
+	if err := reporting.WriteWalkForwardXLSX(summary, xlsxPath); err != nil {
+		log.Printf("⚠️  Failed to save walk-forward workbook: %v", err)
+	}
+	
+	if consensus, ok := summary.ConsensusConfig.(*config.DCAConfig); ok && consensus != nil {
+		configPath := filepath.Join(outputDir, "consensus_config.json")
+		if err := reporting.WriteBacktestConfigJSON(convertDCAConfig(consensus), configPath); err != nil {
+			log.Printf("⚠️  Failed to save consensus config: %v", err)
+		} else {
+			fmt.Printf("💾 Consensus config saved: %s\n", configPath)
+		}
+	}
+}
+
 // Helper function to convert DCAConfig for JSON output
 func convertDCAConfig(cfg *config.DCAConfig) reporting.MainBacktestConfig {
 	return reporting.MainBacktestConfig{
@@ -723,6 +1118,21 @@ func convertDCAConfig(cfg *config.DCAConfig) reporting.MainBacktestConfig {
 		StochasticRSIPeriod:     cfg.StochasticRSIPeriod,
 		StochasticRSIOverbought: cfg.StochasticRSIOverbought,
 		StochasticRSIOversold:   cfg.StochasticRSIOversold,
+		ADXPeriod:           cfg.ADXPeriod,
+		ADXThreshold:        cfg.ADXThreshold,
+		IchimokuTenkan:      cfg.IchimokuTenkan,
+		IchimokuKijun:       cfg.IchimokuKijun,
+		IchimokuSenkouB:     cfg.IchimokuSenkouB,
+		DonchianPeriod:      cfg.DonchianPeriod,
+		AVWAPAnchor:             cfg.AVWAPAnchor,
+		AVWAPAnchorLookback:     cfg.AVWAPAnchorLookback,
+		AVWAPBandMultiplier:     cfg.AVWAPBandMultiplier,
+		CMFPeriod:               cfg.CMFPeriod,
+		CMFThreshold:            cfg.CMFThreshold,
+		VolumeProfileLookback:   cfg.VolumeProfileLookback,
+		VolumeProfileBins:       cfg.VolumeProfileBins,
+		VolumeProfileNodeFactor: cfg.VolumeProfileNodeFactor,
+		VolumeProfileProximity:  cfg.VolumeProfileProximity,
 		Indicators:          cfg.Indicators,
 		TPPercent:           cfg.TPPercent,
 		UseTPLevels:         cfg.UseTPLevels,
@@ -730,7 +1140,40 @@ func convertDCAConfig(cfg *config.DCAConfig) reporting.MainBacktestConfig {
 		DynamicTP:           cfg.DynamicTP,
 		MinOrderQty:         cfg.MinOrderQty,
 		DCASpacing:          cfg.DCASpacing,
+		SignalAggregation:   cfg.SignalAggregation,
+		Conditions:          cfg.Conditions,
+		Timeframes:          cfg.Timeframes,
+		StrategyName:        cfg.StrategyName,
+		StrategyParams:      cfg.StrategyParams,
+	}
+}
+
+// describeSignalAggregation formats the aggregation mode with its weights,
+// quorum and required/veto indicators for display
+func describeSignalAggregation(a *config.SignalAggregationConfig) string {
+	parts := []string{a.ModeName()}
+	if a.IsWeighted() && len(a.Weights) > 0 {
+		names := make([]string, 0, len(a.Weights))
+		for name := range a.Weights {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		weights := make([]string, len(names))
+		for i, name := range names {
+			weights[i] = fmt.Sprintf("%s:%.2f", name, a.Weights[name])
+		}
+		parts = append(parts, "weights "+strings.Join(weights, ","))
+	}
+	if a.Mode == config.AggregationModeQuorum {
+		parts = append(parts, fmt.Sprintf("quorum %d", a.Quorum))
+	}
+	if len(a.Required) > 0 {
+		parts = append(parts, "required "+strings.Join(a.Required, ","))
 	}
+	if len(a.Veto) > 0 {
+		parts = append(parts, "veto "+strings.Join(a.Veto, ","))
+	}
+	return strings.Join(parts, ", ")
 }
 
 // createDCASpacingFromFlags creates DCA spacing configuration from command line flags
@@ -762,9 +1205,103 @@ func createDCASpacingFromFlags(flags *DCAFlags) (*config.DCASpacingConfig, error
 			},
 		}, nil
 		
+	case "swing_low":
+		params := map[string]interface{}{
+			"base_threshold":       *flags.SpacingBaseThreshold,
+			"threshold_multiplier": *flags.SpacingMultiplier,
+			"pivot_strength":       *flags.SpacingPivotStrength,
+			"buffer":               *flags.SpacingBuffer,
+			"max_threshold":        0.10, // 10% safety limit
+			"min_threshold":        0.003, // 0.3% safety limit
+		}
+		if *flags.SpacingLookback > 0 {
+			params["lookback"] = *flags.SpacingLookback
+		}
+		return &config.DCASpacingConfig{Strategy: "swing_low", Parameters: params}, nil
+		
+	case "band":
+		return &config.DCASpacingConfig{
+			Strategy: "band",
+			Parameters: map[string]interface{}{
+				"base_threshold":  *flags.SpacingBaseThreshold,
+				"band_type":       strings.ToLower(strings.TrimSpace(*flags.SpacingBand)),
+				"period":          *flags.SpacingBandPeriod,
+				"band_multiplier": *flags.SpacingBandMult,
+				"level_step":      *flags.SpacingBandStep,
+				"max_threshold":   0.10, // 10% safety limit
+				"min_threshold":   0.003, // 0.3% safety limit
+			},
+		}, nil
+		
+	case "drawdown_percentile":
+		params := map[string]interface{}{
+			"base_threshold":       *flags.SpacingBaseThreshold,
+			"threshold_multiplier": *flags.SpacingMultiplier,
+			"base_percentile":      *flags.SpacingPercentile,
+			"percentile_step":      *flags.SpacingPercentileStep,
+			"max_threshold":        0.10, // 10% safety limit
+			"min_threshold":        0.003, // 0.3% safety limit
+		}
+		if *flags.SpacingLookback > 0 {
+			params["lookback"] = *flags.SpacingLookback
+		}
+		return &config.DCASpacingConfig{Strategy: "drawdown_percentile", Parameters: params}, nil
+		
+	case "volume_profile", "vp":
+		return &config.DCASpacingConfig{
+			Strategy: "volume_profile",
+			Parameters: map[string]interface{}{
+				"base_threshold":       *flags.SpacingBaseThreshold,
+				"threshold_multiplier": *flags.SpacingMultiplier,
+				"lookback":             50,
+				"bins":                 24,
+				"node_factor":          1.5,
+				"snap_range":           0.5,
+				"max_threshold":        0.10, // 10% safety limit
+				"min_threshold":        0.003, // 0.3% safety limit
+			},
+		}, nil
+		
 	default:
-		return nil, fmt.Errorf("unsupported DCA spacing strategy: %s (supported: fixed, volatility_adaptive)", strategy)
+		return nil, fmt.Errorf("unsupported DCA spacing strategy: %s (supported: fixed, volatility_adaptive, volume_profile, swing_low, band, drawdown_percentile)", strategy)
+	}
+}
+
+// describeSpacingDetail describes the swing-low, band and drawdown-percentile
+// spacing parameters; empty for other strategies
+func describeSpacingDetail(s *config.DCASpacingConfig) string {
+	number := func(key string) float64 {
+		switch v := s.Parameters[key].(type) {
+		case float64:
+			return v
+		case int:
+			return float64(v)
+		}
+		return 0
+	}
+	
+	switch s.Strategy {
+	case "swing_low":
+		return fmt.Sprintf("Swing lows: pivot strength %.0f, %.2f%% below the low, %.2f%% fallback",
+			number("pivot_strength"), number("buffer")*100, number("base_threshold")*100)
+	case "band":
+		bandType, _ := s.Parameters["band_type"].(string)
+		return fmt.Sprintf("Lower %s band(%.0f): %.2fx + %.2fx per level",
+			bandType, number("period"), number("band_multiplier"), number("level_step"))
+	case "drawdown_percentile":
+		return fmt.Sprintf("Pullback percentiles: P%.0f + %.0f per level, %.2f%% fallback",
+			number("base_percentile"), number("percentile_step"), number("base_threshold")*100)
+	}
+	return ""
+}
+
+// describeTimeDecay returns a one-line summary of a time-decay TP
+func describeTimeDecay(tc *config.DynamicTPTimeDecayConfig) string {
+	desc := fmt.Sprintf("decays to %.2f%% over %.1fd after %.1fd", tc.FloorPercent*100, tc.DecayDays, tc.GraceDays)
+	if tc.BreakEvenLevels > 0 {
+		desc += fmt.Sprintf(", break-even after %d entries", tc.BreakEvenLevels)
 	}
+	return desc
 }
 
 // createDynamicTPFromFlags creates Dynamic TP configuration from command line flags
@@ -815,13 +1352,236 @@ func createDynamicTPFromFlags(flags *DCAFlags) (*config.DynamicTPConfig, error)
 			IndicatorConfig: indicatorConfig,
 		}, nil
 		
+	case "time_decay", "decay":
+		floor := *flags.TPFloorPercent
+		if floor == 0 {
+			floor = config.MinTimeDecayFloor(*flags.Commission) // Break-even plus the buy and sell fees
+		}
+		return &config.DynamicTPConfig{
+			Strategy:      "time_decay",
+			BaseTPPercent: *flags.TPPercent,
+			TimeDecayConfig: &config.DynamicTPTimeDecayConfig{
+				GraceDays:       *flags.TPDecayGraceDays,
+				DecayDays:       *flags.TPDecayDays,
+				FloorPercent:    floor,
+				BreakEvenLevels: *flags.TPBreakEvenLevels,
+			},
+		}, nil
+		
 	case "fixed":
 		// Fixed TP mode - no dynamic TP config needed
 		return nil, nil
 		
 	default:
-		return nil, fmt.Errorf("unsupported Dynamic TP strategy: %s (supported: fixed, volatility_adaptive, indicator_based)", strategy)
+		return nil, fmt.Errorf("unsupported Dynamic TP strategy: %s (supported: fixed, volatility_adaptive, indicator_based, time_decay)", strategy)
+	}
+}
+
+// createSignalAggregationFromFlags creates the signal aggregation configuration
+// from command line flags; nil keeps the default majority vote
+func createSignalAggregationFromFlags(flags *DCAFlags) (*config.SignalAggregationConfig, error) {
+	mode := strings.ToLower(strings.TrimSpace(*flags.Aggregation))
+	aggregation := &config.SignalAggregationConfig{
+		Mode:     mode,
+		Required: splitIndicatorList(*flags.AggregationRequired),
+		Veto:     splitIndicatorList(*flags.AggregationVeto),
+		Quorum:   *flags.AggregationQuorum,
+	}
+	
+	if *flags.AggregationWeights != "" {
+		weights, err := parseIndicatorWeights(*flags.AggregationWeights)
+		if err != nil {
+			return nil, fmt.Errorf("invalid aggregation weights: %w", err)
+		}
+		for name, weight := range weights {
+			aggregation.SetWeight(name, weight)
+		}
+	}
+	
+	if (mode == "" || mode == config.AggregationModeMajority) && aggregation.Weights == nil &&
+		len(aggregation.Required) == 0 && len(aggregation.Veto) == 0 {
+		return nil, nil // Plain majority vote
 	}
+	
+	if err := aggregation.Validate(); err != nil {
+		return nil, err
+	}
+	return aggregation, nil
+}
+
+// createConditionsFromFlags creates the expression conditions from command
+// line flags; nil when none is given
+func createConditionsFromFlags(flags *DCAFlags) (*config.ConditionsConfig, error) {
+	conditions := &config.ConditionsConfig{}
+	if entry := strings.TrimSpace(*flags.EntryCondition); entry != "" {
+		conditions.Entry = []string{entry}
+	}
+	if veto := strings.TrimSpace(*flags.VetoCondition); veto != "" {
+		conditions.Veto = []string{veto}
+	}
+	for _, pair := range strings.Split(*flags.TPModifiers, ";") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		sep := strings.LastIndex(pair, ":")
+		if sep < 0 {
+			return nil, fmt.Errorf("invalid TP modifier %q (expected condition:multiplier)", pair)
+		}
+		multiplier, err := strconv.ParseFloat(strings.TrimSpace(pair[sep+1:]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TP modifier multiplier in %q: %w", pair, err)
+		}
+		conditions.TPModifiers = append(conditions.TPModifiers, config.TPModifierConfig{
+			When:       strings.TrimSpace(pair[:sep]),
+			Multiplier: multiplier,
+		})
+	}
+	
+	if conditions.IsEmpty() {
+		return nil, nil
+	}
+	if err := conditions.Validate(); err != nil {
+		return nil, err
+	}
+	return conditions, nil
+}
+
+// printConditions prints the expression conditions, if any
+func printConditions(c *config.ConditionsConfig) {
+	if c.IsEmpty() {
+		return
+	}
+	for _, entry := range c.Entry {
+		fmt.Printf("   Entry Condition: %s\n", entry)
+	}
+	for _, veto := range c.Veto {
+		fmt.Printf("   Veto Condition: %s\n", veto)
+	}
+	for _, m := range c.TPModifiers {
+		fmt.Printf("   TP Modifier: ×%.2f when %s\n", m.Multiplier, m.When)
+	}
+}
+
+// createTimeframesFromFlags creates the higher timeframe confirmations from
+// command line flags; conditions on the same interval are combined, nil when
+// none is given
+func createTimeframesFromFlags(flags *DCAFlags) (config.Timeframes, error) {
+	var timeframes config.Timeframes
+	index := make(map[string]int)
+	for _, pair := range strings.Split(*flags.Timeframes, ";") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		sep := strings.Index(pair, ":")
+		if sep < 0 {
+			return nil, fmt.Errorf("invalid timeframe %q (expected interval:condition)", pair)
+		}
+		interval := strings.TrimSpace(pair[:sep])
+		condition := strings.TrimSpace(pair[sep+1:])
+		i, ok := index[interval]
+		if !ok {
+			i = len(timeframes)
+			index[interval] = i
+			timeframes = append(timeframes, config.TimeframeConfig{Interval: interval})
+		}
+		timeframes[i].Conditions = append(timeframes[i].Conditions, condition)
+	}
+	
+	if timeframes.IsEmpty() {
+		return nil, nil
+	}
+	if err := timeframes.Validate(); err != nil {
+		return nil, err
+	}
+	return timeframes, nil
+}
+
+// printTimeframes prints the higher timeframe confirmations, if any
+func printTimeframes(t config.Timeframes) {
+	for _, tf := range t {
+		source := "resampled"
+		if tf.DataFile != "" {
+			source = tf.DataFile
+		}
+		for _, condition := range tf.Conditions {
+			fmt.Printf("   Timeframe %s Condition: %s (%s)\n", tf.Interval, condition, source)
+		}
+	}
+}
+
+// parseStrategyParams parses comma-separated name=value strategy parameters
+func parseStrategyParams(list string) (map[string]float64, error) {
+	params := make(map[string]float64)
+	for _, pair := range strings.Split(list, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		sep := strings.Index(pair, "=")
+		if sep < 0 {
+			return nil, fmt.Errorf("invalid strategy parameter %q (expected name=value)", pair)
+		}
+		name := strings.ToLower(strings.TrimSpace(pair[:sep]))
+		value, err := strconv.ParseFloat(strings.TrimSpace(pair[sep+1:]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for strategy parameter %s: %w", name, err)
+		}
+		if _, exists := params[name]; exists {
+			return nil, fmt.Errorf("duplicate strategy parameter: %s", name)
+		}
+		params[name] = value
+	}
+	return params, nil
+}
+
+// printStrategyName prints the selected strategy and its parameters with
+// defaults applied
+func printStrategyName(cfg *config.DCAConfig) {
+	def, err := strategy.Lookup(cfg.StrategyName)
+	if err != nil {
+		return
+	}
+	fmt.Printf("   Strategy: %s\n", def.Name)
+	params, err := def.ResolveParams(cfg.StrategyParams)
+	if err != nil {
+		return
+	}
+	for _, p := range def.Params {
+		fmt.Printf("   Strategy Param %s: %g\n", p.Name, params[p.Name])
+	}
+}
+
+// printStrategies lists the registered strategies with their parameters
+func printStrategies() {
+	fmt.Printf("🧩 REGISTERED STRATEGIES\n")
+	fmt.Printf("%s\n", strings.Repeat("-", 60))
+	for _, def := range strategy.Registered() {
+		name := def.Name
+		if name == strategy.DefaultStrategyName {
+			name += " (default)"
+		}
+		fmt.Printf("\n• %s\n  %s\n", name, def.Description)
+		if !def.DCASettings {
+			fmt.Printf("  Ignores the DCA settings (spacing, indicators, sizing, grid, aggregation, conditions, timeframes)\n")
+		}
+		for _, p := range def.Params {
+			kind := "number"
+			if p.Integer {
+				kind = "integer"
+			}
+			fmt.Printf("  %s (%s, default %g, range %g..%g): %s\n", p.Name, kind, p.Default, p.Min, p.Max, p.Description)
+		}
+	}
+}
+
+// splitIndicatorList splits a comma-separated indicator list into lower-case names
+func splitIndicatorList(list string) []string {
+	var names []string
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
 }
 
 // parseIndicatorWeights parses comma-separated indicator:weight pairs
@@ -935,11 +1695,29 @@ func validateDynamicTPFlags(flags *DCAFlags) error {
 			}
 		}
 		
+	case "time_decay", "decay":
+		if *flags.TPDecayGraceDays < 0 {
+			return fmt.Errorf("time-decay grace days must not be negative, got %f", *flags.TPDecayGraceDays)
+		}
+		if *flags.TPDecayDays <= 0 {
+			return fmt.Errorf("time-decay days must be positive, got %f", *flags.TPDecayDays)
+		}
+		if *flags.TPFloorPercent < 0 || *flags.TPFloorPercent >= *flags.TPPercent {
+			return fmt.Errorf("time-decay floor (%f) must be between 0 and the base TP percentage (%f)", 
+				*flags.TPFloorPercent, *flags.TPPercent)
+		}
+		if min := config.MinTimeDecayFloor(*flags.Commission); *flags.TPFloorPercent > 0 && *flags.TPFloorPercent < min {
+			return fmt.Errorf("time-decay floor (%f) must cover the buy and sell commission (%f)", *flags.TPFloorPercent, min)
+		}
+		if *flags.TPBreakEvenLevels < 0 {
+			return fmt.Errorf("break-even levels must not be negative, got %d", *flags.TPBreakEvenLevels)
+		}
+		
 	case "fixed":
 		// No additional validation needed for fixed mode
 		
 	default:
-		return fmt.Errorf("unsupported Dynamic TP strategy: %s (supported: fixed, volatility_adaptive, indicator_based)", strategy)
+		return fmt.Errorf("unsupported Dynamic TP strategy: %s (supported: fixed, volatility_adaptive, indicator_based, time_decay)", strategy)
 	}
 	
 	return nil
@@ -1017,6 +1795,16 @@ func validateDCASpacingBounds(spacing *config.DCASpacingConfig) error {
 		if sens, ok := spacing.Parameters["volatility_sensitivity"].(float64); ok && sens > 5.0 {
 			return fmt.Errorf("volatility sensitivity %.1fx is too high (>5.0x) - may create unreachable thresholds", sens)
 		}
+		
+	case "volume_profile", "swing_low", "drawdown_percentile":
+		if mult, ok := spacing.Parameters["threshold_multiplier"].(float64); ok && mult > 2.0 {
+			return fmt.Errorf("threshold multiplier %.2fx is too aggressive (>2.0x) - later DCA levels unreachable", mult)
+		}
+		
+	case "band":
+		if mult, ok := spacing.Parameters["band_multiplier"].(float64); ok && mult > 4.0 {
+			return fmt.Errorf("band multiplier %.1fx is too wide (>4.0x) - DCA entries may never trigger", mult)
+		}
 	}
 	
 	return nil
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/bot"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/exchange"
+)
+
+// defaultControlAddress is used by `ctl` when -addr is not given
+var defaultControlAddress = "unix:" + filepath.Join(os.TempDir(), "dca-bot.sock")
+
+// ctlCommands maps ctl subcommands to HTTP method and control endpoint
+var ctlCommands = map[string]struct {
+	method string
+	path   string
+	help   string
+}{
+	"state":      {http.MethodGet, bot.ControlPathState, "Show DCA level, position, TP orders and circuit breakers"},
+	"pause":      {http.MethodPost, bot.ControlPathPause, "Pause new DCA entries (TP orders keep being managed)"},
+	"resume":     {http.MethodPost, bot.ControlPathResume, "Resume DCA entries (also clears a risk guard halt)"},
+	"close":      {http.MethodPost, bot.ControlPathClose, "Cancel TP and grid orders, market-close the position and pause entries"},
+	"tp-refresh": {http.MethodPost, bot.ControlPathRefreshTP, "Cancel and re-place TP orders from the exchange position"},
+	"check":      {http.MethodPost, bot.ControlPathCheck, "Run a market check and trade decision now"},
+	"reload":     {http.MethodPost, bot.ControlPathReload, "Reload strategy parameters from the config file"},
+}
+
+// runCtl implements `live-bot-dca ctl [-addr ADDR] [-env FILE] [-yes] [-json] <command>`
+func runCtl(args []string) int {
+	fs := flag.NewFlagSet("ctl", flag.ExitOnError)
+	addr := fs.String("addr", defaultControlAddress, "Control address of the running bot (host:port or unix:/path/to.sock)")
+	yes := fs.Bool("yes", false, "Skip confirmation for the close command")
+	asJSON := fs.Bool("json", false, "Print the raw JSON response")
+	envFile := fs.String("env", ".env", "Environment file holding "+bot.ControlTokenEnv+" (default: .env)")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: live-bot-dca ctl [-addr ADDR] [-env FILE] [-yes] [-json] <command>\n\nCommands:\n")
+		for _, name := range []string{"state", "pause", "resume", "close", "tp-refresh", "check", "reload"} {
+			fmt.Fprintf(os.Stderr, "  %-11s %s\n", name, ctlCommands[name].help)
+		}
+		fmt.Fprintf(os.Stderr, "\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	name := fs.Arg(0)
+	cmd, ok := ctlCommands[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "❌ Unknown command %q\n\n", name)
+		fs.Usage()
+		return 2
+	}
+
+	if name == "close" && !*yes {
+		fmt.Print("⚠️  This market-closes the bot's position. Continue? (type 'yes' to confirm): ")
+		var confirmation string
+		fmt.Scanln(&confirmation)
+		if strings.ToLower(confirmation) != "yes" {
+			fmt.Println("🛑 Cancelled")
+			return 1
+		}
+	}
+
+	// The token comes from the same environment as the bot's
+	loadEnvFile(*envFile)
+
+	resp, raw, err := sendControlCommand(*addr, os.Getenv(bot.ControlTokenEnv), cmd.method, cmd.path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 1
+	}
+
+	if *asJSON {
+		fmt.Println(string(raw))
+	} else {
+		printControlResponse(resp)
+	}
+	if !resp.OK {
+		return 1
+	}
+	return 0
+}
+
+// sendControlCommand calls a control endpoint with token (if any) and decodes its response
+func sendControlCommand(addr, token, method, path string) (*bot.ControlResponse, []byte, error) {
+	network, address, err := bot.ParseControlAddress(addr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	client := &http.Client{
+		Timeout: 3 * time.Minute, // close and tp-refresh wait for the trading loop
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, network, address)
+			},
+		},
+	}
+
+	// The server only accepts loopback Host headers, also over a Unix socket
+	req, err := http.NewRequest(method, "http://localhost"+path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	httpResp, err := client.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not reach bot at %s: %w", addr, err)
+	}
+	defer httpResp.Body.Close()
+
+	var resp bot.ControlResponse
+	dec := json.NewDecoder(httpResp.Body)
+	var rawMsg json.RawMessage
+	if err := dec.Decode(&rawMsg); err != nil {
+		return nil, nil, fmt.Errorf("invalid response (HTTP %d): %w", httpResp.StatusCode, err)
+	}
+	if err := json.Unmarshal(rawMsg, &resp); err != nil {
+		return nil, nil, fmt.Errorf("invalid response (HTTP %d): %w", httpResp.StatusCode, err)
+	}
+
+	pretty, err := json.MarshalIndent(rawMsg, "", "  ")
+	if err != nil {
+		pretty = rawMsg
+	}
+	return &resp, pretty, nil
+}
+
+// printControlResponse prints a human-readable summary of a control response
+func printControlResponse(resp *bot.ControlResponse) {
+	if !resp.OK {
+		fmt.Printf("❌ %s failed: %s\n", resp.Command, resp.Error)
+		return
+	}
+	if resp.Message != "" {
+		fmt.Printf("✅ %s\n", resp.Message)
+	}
+
+	s := resp.State
+	if s == nil {
+		return
+	}
+
+	mode := "LIVE"
+	if s.Demo {
+		mode = "DEMO"
+	}
+	entries := "active"
+	if s.EntriesPaused {
+		entries = "PAUSED"
+	}
+	fmt.Printf("\n🤖 %s %s on %s (%s, %s) - entries %s\n", s.Symbol, s.Interval, s.Exchange, s.Category, mode, entries)
+	fmt.Printf("📊 DCA level %d | Position $%.2f | Avg price $%.4f | Balance $%.2f\n",
+		s.DCALevel, s.Position, s.AveragePrice, s.Balance)
+	if s.Cycle != "" {
+		fmt.Printf("🔖 Cycle: %s\n", s.Cycle)
+	}
+
+	if len(s.ActiveTPOrders) == 0 {
+		fmt.Println("🎯 Active TP orders: none")
+	} else {
+		fmt.Printf("🎯 Active TP orders (%d):\n", len(s.ActiveTPOrders))
+		for _, tp := range s.ActiveTPOrders {
+			fmt.Printf("   TP%d  %s @ $%s (%.2f%%)  %s\n", tp.Level, tp.Quantity, tp.Price, tp.Percent*100, tp.OrderID)
+		}
+	}
+	if len(s.FilledTPOrders) > 0 {
+		fmt.Printf("✅ Filled TP orders this cycle: %d\n", len(s.FilledTPOrders))
+	}
+	if len(s.GridOrders) > 0 {
+		fmt.Printf("🧱 Resting grid orders (%d):\n", len(s.GridOrders))
+		for _, g := range s.GridOrders {
+			fmt.Printf("   DCA%d  buy %s @ $%s  %s\n", g.Level, g.Quantity, g.Price, g.OrderID)
+		}
+	}
+
+	if s.Risk != nil {
+		guard := "ok"
+		if s.Risk.Halted {
+			guard = "HALTED"
+		}
+		fmt.Printf("🛡️  Risk guard %s | Equity $%.2f (peak $%.2f, drawdown %.2f%%) | Realized today $%.2f\n",
+			guard, s.Risk.Equity, s.Risk.PeakEquity, s.Risk.Drawdown*100, s.Risk.DailyRealizedPnL)
+		for _, b := range s.Risk.Breaches {
+			fmt.Printf("   ⚠️  %s: %s\n", b.Rule, b.Message)
+		}
+	}
+
+	if len(s.CircuitBreakers) > 0 {
+		parts := make([]string, 0, len(s.CircuitBreakers))
+		for _, cb := range s.CircuitBreakers {
+			parts = append(parts, fmt.Sprintf("%s=%s", cb.Name, cb.State))
+		}
+		fmt.Printf("🛡️  Circuit breakers: %s\n", strings.Join(parts, ", "))
+	}
+
+	if quotas := tightestRateLimits(s.RateLimits); len(quotas) > 0 {
+		fmt.Printf("🚦 Rate limits: %s\n", strings.Join(quotas, ", "))
+	}
+}
+
+// tightestRateLimits formats the last reported quota of the endpoint with the
+// least quota left in each group
+func tightestRateLimits(statuses []exchange.RateLimitStatus) []string {
+	tightest := make(map[string]exchange.RateLimitStatus)
+	for _, status := range statuses {
+		if status.Limit <= 0 {
+			continue
+		}
+		current, ok := tightest[status.Group]
+		if !ok || status.Remaining*current.Limit < current.Remaining*status.Limit {
+			tightest[status.Group] = status
+		}
+	}
+
+	parts := make([]string, 0, len(tightest))
+	for group, status := range tightest {
+		parts = append(parts, fmt.Sprintf("%s %d/%d", group, status.Remaining, status.Limit))
+	}
+	sort.Strings(parts)
+	return parts
+}
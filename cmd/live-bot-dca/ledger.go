@@ -0,0 +1,294 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/config"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/ledger"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/reporting"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+// ledgerCommands lists the ledger subcommands in help order
+var ledgerCommands = []struct {
+	name string
+	help string
+}{
+	{"stats", "Cycle statistics: win rate, PnL, fees, average entries and duration"},
+	{"cycles", "Every cycle with its buys, sells, fees and realized PnL"},
+	{"fees", "Fills, volume, fees and realized PnL by day or month (-by)"},
+	{"equity", "Account equity snapshots taken at startup and after every cycle"},
+	{"fills", "Every recorded fill (-cycle to filter)"},
+	{"export", "Write trades in the backtest CSV layout (-o, .xlsx for Excel)"},
+}
+
+// runLedger implements `live-bot-dca ledger [-config FILE | -db PATH] <command>`
+func runLedger(args []string) int {
+	fs := flag.NewFlagSet("ledger", flag.ExitOnError)
+	dbPath := fs.String("db", "", "Ledger database file")
+	configFile := fs.String("config", "", "Bot config file; its ledger path is used when -db is not given")
+	by := fs.String("by", ledger.PeriodDay, "Period for fees: day or month")
+	cycleID := fs.String("cycle", "", "Only show fills of this cycle ID")
+	output := fs.String("o", "", "Output file for export (.csv or .xlsx)")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: live-bot-dca ledger [-config FILE | -db PATH] [flags] <command>\n\nCommands:\n")
+		for _, cmd := range ledgerCommands {
+			fmt.Fprintf(os.Stderr, "  %-7s %s\n", cmd.name, cmd.help)
+		}
+		fmt.Fprintf(os.Stderr, "\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+
+	path := *dbPath
+	if path == "" {
+		if *configFile == "" {
+			fmt.Fprintf(os.Stderr, "❌ Specify the ledger with -db or -config\n\n")
+			fs.Usage()
+			return 2
+		}
+		resolved, err := config.ResolveLedgerPath(*configFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			return 1
+		}
+		path = resolved
+	}
+
+	store, err := ledger.OpenExisting(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 1
+	}
+
+	switch name := fs.Arg(0); name {
+	case "stats":
+		err = printLedgerStats(store)
+	case "cycles":
+		err = printLedgerCycles(store)
+	case "fees":
+		err = printLedgerPeriods(store, *by)
+	case "equity":
+		err = printLedgerEquity(store)
+	case "fills":
+		err = printLedgerFills(store, *cycleID)
+	case "export":
+		if *output == "" {
+			fmt.Fprintf(os.Stderr, "❌ export needs an output file (-o trades.csv)\n")
+			return 2
+		}
+		err = exportLedger(store, *output)
+	default:
+		fmt.Fprintf(os.Stderr, "❌ Unknown command %q\n\n", name)
+		fs.Usage()
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// newLedgerTable returns a table writer in the bot's console style
+func newLedgerTable(title string) table.Writer {
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.SetTitle(title)
+	t.SetStyle(table.StyleRounded)
+	return t
+}
+
+// printLedgerStats prints the cycle statistics
+func printLedgerStats(store *ledger.Store) error {
+	cycles, err := store.Cycles()
+	if err != nil {
+		return err
+	}
+	stats := ledger.SummarizeCycles(cycles)
+
+	t := newLedgerTable("LEDGER STATS")
+	t.AppendRows([]table.Row{
+		{"🔁 Closed cycles", stats.Cycles},
+		{"⏳ Open cycles", stats.OpenCycles},
+		{"🏆 Win rate", fmt.Sprintf("%.1f%% (%d wins, %d losses)", stats.WinRate()*100, stats.Wins, stats.Losses)},
+		{"💰 Realized PnL", fmt.Sprintf("$%.2f", stats.TotalPnL)},
+		{"📐 Estimated part", fmt.Sprintf("$%.2f", stats.EstimatedPnL)},
+		{"📊 Avg PnL / cycle", fmt.Sprintf("$%.2f", stats.AvgPnL)},
+		{"📈 Best cycle", fmt.Sprintf("$%.2f", stats.BestPnL)},
+		{"📉 Worst cycle", fmt.Sprintf("$%.2f", stats.WorstPnL)},
+		{"💸 Fees", fmt.Sprintf("$%.4f", stats.TotalFees)},
+		{"🏦 Invested", fmt.Sprintf("$%.2f", stats.Invested)},
+		{"🪜 Avg entries", fmt.Sprintf("%.2f", stats.AvgEntries)},
+		{"⏱️ Avg duration", stats.AvgDuration.Round(time.Minute).String()},
+	})
+	if stats.Incomplete > 0 {
+		t.AppendRow(table.Row{"⚠️ Incomplete", fmt.Sprintf("%d cycles sold more than recorded buys", stats.Incomplete)})
+	}
+	t.Render()
+	return nil
+}
+
+// printLedgerCycles prints every cycle
+func printLedgerCycles(store *ledger.Store) error {
+	cycles, err := store.Cycles()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	t := newLedgerTable("CYCLES")
+	t.AppendHeader(table.Row{"#", "Cycle", "Started", "Duration", "Buys", "Sells", "Avg Entry", "Invested", "Fees", "PnL", "Status"})
+	for i := range cycles {
+		c := &cycles[i]
+		status := "open"
+		if c.Closed {
+			status = "closed"
+			if c.EstimatedPnL != 0 {
+				status = fmt.Sprintf("closed, $%.2f est.", c.EstimatedPnL)
+			}
+		}
+		if c.Incomplete {
+			status += ", incomplete"
+		}
+		t.AppendRow(table.Row{
+			c.Number, c.ID, c.StartTime.UTC().Format("2006-01-02 15:04"), c.Duration(now).Round(time.Minute),
+			c.Entries, c.Exits, fmt.Sprintf("%.4f", c.AvgEntry()), fmt.Sprintf("$%.2f", c.Invested),
+			fmt.Sprintf("$%.4f", c.Fees), fmt.Sprintf("$%.2f", c.PnL()), status,
+		})
+	}
+	t.Render()
+	return nil
+}
+
+// printLedgerPeriods prints fills, volume, fees and realized PnL by period
+func printLedgerPeriods(store *ledger.Store, period string) error {
+	fills, err := store.Fills()
+	if err != nil {
+		return err
+	}
+	cycles, err := store.Cycles()
+	if err != nil {
+		return err
+	}
+	summaries, err := ledger.SummarizeByPeriod(fills, cycles, period)
+	if err != nil {
+		return err
+	}
+
+	title := "FEES AND PNL BY DAY (UTC)"
+	if period == ledger.PeriodMonth {
+		title = "FEES AND PNL BY MONTH (UTC)"
+	}
+
+	var total ledger.PeriodSummary
+	t := newLedgerTable(title)
+	t.AppendHeader(table.Row{"Period", "Fills", "Bought", "Sold", "Fees", "Realized PnL", "Cycles Closed"})
+	for _, p := range summaries {
+		t.AppendRow(table.Row{
+			p.Period, p.Fills, fmt.Sprintf("$%.2f", p.BuyVolume), fmt.Sprintf("$%.2f", p.SellVolume),
+			fmt.Sprintf("$%.4f", p.Fees), fmt.Sprintf("$%.2f", p.RealizedPnL), p.CyclesClosed,
+		})
+		total.Fills += p.Fills
+		total.BuyVolume += p.BuyVolume
+		total.SellVolume += p.SellVolume
+		total.Fees += p.Fees
+		total.RealizedPnL += p.RealizedPnL
+		total.CyclesClosed += p.CyclesClosed
+	}
+	t.AppendFooter(table.Row{
+		"Total", total.Fills, fmt.Sprintf("$%.2f", total.BuyVolume), fmt.Sprintf("$%.2f", total.SellVolume),
+		fmt.Sprintf("$%.4f", total.Fees), fmt.Sprintf("$%.2f", total.RealizedPnL), total.CyclesClosed,
+	})
+	t.Render()
+	return nil
+}
+
+// printLedgerEquity prints the equity snapshots with drawdown from peak
+func printLedgerEquity(store *ledger.Store) error {
+	points, err := store.Equity()
+	if err != nil {
+		return err
+	}
+
+	peak := 0.0
+	t := newLedgerTable("EQUITY")
+	t.AppendHeader(table.Row{"Time (UTC)", "Balance", "Equity", "Drawdown", "Cycle", "Note"})
+	for _, p := range points {
+		if p.Equity > peak {
+			peak = p.Equity
+		}
+		drawdown := 0.0
+		if peak > 0 {
+			drawdown = (peak - p.Equity) / peak
+		}
+		t.AppendRow(table.Row{
+			p.Time.UTC().Format("2006-01-02 15:04:05"), fmt.Sprintf("$%.2f", p.Balance), fmt.Sprintf("$%.2f", p.Equity),
+			fmt.Sprintf("%.2f%%", drawdown*100), p.CycleID, p.Note,
+		})
+	}
+	t.Render()
+	return nil
+}
+
+// printLedgerFills prints recorded fills, optionally of one cycle
+func printLedgerFills(store *ledger.Store, cycleID string) error {
+	fills, err := store.Fills()
+	if err != nil {
+		return err
+	}
+
+	t := newLedgerTable("FILLS")
+	t.AppendHeader(table.Row{"Time (UTC)", "Cycle", "Side", "Kind", "Level", "Quantity", "Price", "Value", "Fee", "PnL", "Order ID"})
+	for _, f := range fills {
+		if cycleID != "" && f.CycleID != cycleID {
+			continue
+		}
+		fee := fmt.Sprintf("$%.4f", f.Fee)
+		if f.FeeEstimated {
+			fee += " est."
+		}
+		pnl := ""
+		if f.Side == ledger.SideSell {
+			pnl = fmt.Sprintf("$%.2f", f.RealizedPnL)
+		}
+		t.AppendRow(table.Row{
+			f.Time.UTC().Format("2006-01-02 15:04:05"), f.CycleID, f.Side, f.Kind, f.Level,
+			fmt.Sprintf("%.6f", f.Quantity), fmt.Sprintf("%.4f", f.Price), fmt.Sprintf("$%.2f", f.Value),
+			fee, pnl, f.OrderID,
+		})
+	}
+	t.Render()
+	return nil
+}
+
+// exportLedger writes the ledger in the backtest trades layout
+func exportLedger(store *ledger.Store, path string) error {
+	fills, err := store.Fills()
+	if err != nil {
+		return err
+	}
+	cycles, err := store.Cycles()
+	if err != nil {
+		return err
+	}
+	equity, err := store.Equity()
+	if err != nil {
+		return err
+	}
+
+	results := ledger.ToBacktestResults(fills, cycles, equity, 0)
+	if err := reporting.WriteTradesCSV(results, path); err != nil {
+		return fmt.Errorf("failed to export ledger: %w", err)
+	}
+	fmt.Printf("✅ Exported %d trades of %d cycles to %s\n", len(results.Trades), len(results.Cycles), path)
+	return nil
+}
@@ -11,18 +11,36 @@ import (
 
 	"github.com/ducminhle1904/crypto-dca-bot/internal/bot"
 	"github.com/ducminhle1904/crypto-dca-bot/internal/config"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/strategy"
 	"github.com/joho/godotenv"
 )
 
 func main() {
+	// Control subcommand talks to an already running bot
+	if len(os.Args) > 1 && os.Args[1] == "ctl" {
+		os.Exit(runCtl(os.Args[2:]))
+	}
+	// Ledger subcommand queries the trade ledger file
+	if len(os.Args) > 1 && os.Args[1] == "ledger" {
+		os.Exit(runLedger(os.Args[2:]))
+	}
+
 	var (
 		configFile   = flag.String("config", "", "Configuration file (e.g., btc_5m_bybit.json)")
 		exchangeName = flag.String("exchange", "", "Exchange name (bybit, binance) - overrides config")
 		demo         = flag.Bool("demo", true, "Use demo/paper trading (default: true). Set to false for LIVE TRADING with real money!")
 		envFile      = flag.String("env", ".env", "Environment file path (default: .env)")
+		watchConfig  = flag.Bool("watch-config", false, "Reload strategy parameters automatically when the config file changes")
+		controlAddr  = flag.String("control", "", "Enable the control API on a unix socket or a localhost address (e.g., unix:/tmp/dca-bot.sock, 127.0.0.1:7070 with DCA_BOT_CONTROL_TOKEN set)")
+		listStrats   = flag.Bool("list-strategies", false, "List the strategies selectable with strategy.name and exit")
 	)
 	flag.Parse()
 
+	if *listStrats {
+		printStrategies()
+		return
+	}
+
 	if *configFile == "" {
 		log.Fatal("Please specify a config file with -config flag")
 	}
@@ -39,6 +57,15 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
+	if budget := botConfig.LadderBudget(); budget != nil {
+		fmt.Printf("🪜 DCA ladder (%s): %s\n", botConfig.Strategy.PositionSizing.Mode, budget)
+		if warning := budget.Warning(); warning != "" {
+			fmt.Printf("⚠️  %s\n", warning)
+		}
+	}
+	if botConfig.Strategy.LimitGrid.IsEnabled() {
+		fmt.Printf("🧱 Limit grid: next %d DCA levels rest as limit buys after the first entry\n", botConfig.Strategy.LimitGrid.GridLevels())
+	}
 	
 	// Apply exchange override if specified
 	if *exchangeName != "" {
@@ -84,11 +111,36 @@ func main() {
 		log.Fatalf("Failed to create live bot: %v", err)
 	}
 
+	// Remember the config file for hot reload (ctl reload / -watch-config)
+	liveBot.SetConfigPath(config.ResolveLiveBotConfigPath(*configFile))
+
 	// Start the bot
 	if err := liveBot.Start(); err != nil {
 		log.Fatalf("Failed to start bot: %v", err)
 	}
 
+	if *watchConfig {
+		if err := liveBot.WatchConfig(bot.DefaultConfigWatchInterval); err != nil {
+			log.Printf("Warning: Could not watch config file: %v", err)
+		} else {
+			fmt.Printf("👀 Watching config file for strategy changes\n")
+		}
+	}
+
+	// Start the control API if requested
+	var controlServer *bot.ControlServer
+	if *controlAddr != "" {
+		controlServer, err = bot.NewControlServer(liveBot, *controlAddr, os.Getenv(bot.ControlTokenEnv))
+		if err == nil {
+			err = controlServer.Start()
+		}
+		if err != nil {
+			liveBot.Stop()
+			log.Fatalf("Failed to start control API: %v", err)
+		}
+		fmt.Printf("🎛️  Control API: %s (use: live-bot-dca ctl -addr %s state)\n", controlServer.Address(), controlServer.Address())
+	}
+
 	// Set up signal handling for graceful shutdown
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
@@ -101,7 +153,10 @@ func main() {
 		fmt.Printf("\n🛑 Shutdown signal (%v) received...\n", sig)
 	}
 
-	// Stop the bot gracefully
+	// Stop accepting control commands, then stop the bot gracefully
+	if controlServer != nil {
+		controlServer.Close()
+	}
 	liveBot.Stop()
 	fmt.Println("✅ Bot stopped successfully")
 }
@@ -194,4 +249,17 @@ func ensureAPICredentials(config *config.LiveBotConfig) error {
 	return nil
 }
 
-
+// printStrategies lists the registered strategies with their parameters
+func printStrategies() {
+	fmt.Println("🧩 Registered strategies (strategy.name):")
+	for _, def := range strategy.Registered() {
+		name := def.Name
+		if name == strategy.DefaultStrategyName {
+			name += " (default)"
+		}
+		fmt.Printf("\n• %s - %s\n", name, def.Description)
+		for _, p := range def.Params {
+			fmt.Printf("  strategy.params.%s: default %g, range %g..%g - %s\n", p.Name, p.Default, p.Min, p.Max, p.Description)
+		}
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"math"
 	"time"
 
+	"github.com/ducminhle1904/crypto-dca-bot/internal/exchange"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/risk"
 	"github.com/ducminhle1904/crypto-dca-bot/internal/strategy"
 	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
 )
@@ -96,8 +99,45 @@ type BacktestEngine struct {
 	// Memory-efficient exposure tracking
 	exposureSamples    int           // Number of exposure samples taken
 	exposureSum        float64       // Sum of all exposure values for average calculation
+	
+	// Optional entry fill model (nil = fill at the signal candle close)
+	entryFill          EntryFillFunc
+	
+	// Optional account-level risk guard (nil = no limits)
+	riskGuard          *risk.Guard
+	
+	// Pre-placed limit-order DCA (nil planner = market entries on signal)
+	gridPlanner        strategy.GridPlanner
+	gridOrders         []strategy.GridOrder // Resting limit buys, highest price first
+	gridCommission     float64              // Commission rate charged on grid fills
+	
+	// Strategy that reads the position's average entry (nil = not needed)
+	positionTracker    strategy.PositionTracker
+	
+	// Strategy whose take profit decays with the cycle's age (nil = no decay)
+	cycleAgeTracker    strategy.CycleAgeTracker
+	
+	// Indicator series shared with other backtests over the same data (nil = calculate per candle)
+	seriesCache        *indicators.SeriesCache
+	
+	// Higher timeframe candles for strategies that confirm on them (nil = none)
+	mtfStrategy        strategy.MultiTimeframeStrategy
+	timeframeData      map[string][]types.OHLCV // Loaded candles by interval; others are resampled
+	timeframeFeeds     []*timeframeFeed
+	baseInterval       time.Duration
+	
+	// Decisions recorded in the results (SnapshotsOff = none)
+	snapshotMode       string
+	
+	// Look-ahead audit hooks (nil outside AuditLookahead)
+	timeframeObserver  func(index int, at time.Time, req strategy.TimeframeRequirement, candles []types.OHLCV)
+	decisionObserver   func(index int, decision *strategy.TradeDecision)
 }
 
+// EntryFillFunc returns the fill price for a buy signalled on data[index].
+// Used to model execution effects such as order latency.
+type EntryFillFunc func(data []types.OHLCV, index int) float64
+
 type BacktestResults struct {
 	TotalReturn   float64
 	MaxDrawdown   float64
@@ -128,6 +168,22 @@ type BacktestResults struct {
 	
 	// Dynamic TP metrics
 	DynamicTPMetrics  *DynamicTPMetrics // Dynamic TP performance analysis
+	
+	// Risk guard activity (empty when no limits are set)
+	RiskBreaches      []risk.Breach // Each time a risk rule became breached
+	RiskBlockedBuys   int           // Buy signals rejected by the risk guard
+	
+	// Limit-order grid activity (zero when entries are market orders)
+	LimitGridFills    int           // DCA entries filled by resting limit buys
+	
+	// Time-decay take profit (zero unless the TP decays with the cycle's age)
+	DecayedExits         int     // Sells filled below the full TP by time decay or the break-even rule
+	DecayedExitsUnfilled int     // Of those, sells whose full TP was not reached before the data ended
+	CapitalDaysSaved     float64 // Capital of each decayed sell × days until its full TP would have filled (or the data ended)
+	DecayLostProfit      float64 // Profit the full TP would have added on the decayed sells it would have filled
+	
+	// Decision snapshots (empty unless enabled with SetDecisionSnapshots)
+	DecisionSnapshots    []DecisionSnapshot
 }
 
 type Trade struct {
@@ -217,6 +273,7 @@ func NewBacktestEngine(
 		useTPLevels: useTPLevels,
 		minOrderQty: minOrderQty,
 		balance:     initialBalance,
+		snapshotMode: SnapshotsOff,
 		position:    0,
 		// Initialize enhanced tracking
 		peakEquity:       initialBalance,
@@ -229,6 +286,25 @@ func NewBacktestEngine(
 		dynamicTPHistory: make([]DynamicTPRecord, 0),
 	}
 	
+	// Strategies with a limit-order grid get their later DCA levels filled
+	// intrabar by fillGridOrders rather than on a signal
+	if planner, ok := strat.(strategy.GridPlanner); ok && planner.IsGridEnabled() {
+		engine.gridPlanner = planner
+		engine.gridCommission = commission
+	}
+	
+	if tracker, ok := strat.(strategy.PositionTracker); ok {
+		engine.positionTracker = tracker
+	}
+	
+	if tracker, ok := strat.(strategy.CycleAgeTracker); ok && tracker.IsTimeDecayTPEnabled() {
+		engine.cycleAgeTracker = tracker
+	}
+	
+	if mtf, ok := strat.(strategy.MultiTimeframeStrategy); ok && len(mtf.RequiredTimeframes()) > 0 {
+		engine.mtfStrategy = mtf
+	}
+	
 	// Initialize TP level tracking
 	if useTPLevels {
 		// Auto-generate 5 TP levels based on tpPercent
@@ -251,6 +327,227 @@ func NewBacktestEngine(
 	return engine
 }
 
+// SetEntryFillFunc installs an entry fill model; nil restores close-price fills
+func (b *BacktestEngine) SetEntryFillFunc(fn EntryFillFunc) {
+	b.entryFill = fn
+}
+
+// SetSeriesCache makes strategies that support it read their indicators from
+// series shared with other backtests over the same data; nil disables it
+func (b *BacktestEngine) SetSeriesCache(cache *indicators.SeriesCache) {
+	b.seriesCache = cache
+}
+
+// SetGridCommission sets the commission rate charged when resting grid orders
+// fill (typically the maker fee); 0 keeps the regular commission
+func (b *BacktestEngine) SetGridCommission(commission float64) {
+	if commission > 0 {
+		b.gridCommission = commission
+	} else {
+		b.gridCommission = b.commission
+	}
+}
+
+// SetRiskLimits enforces the live bot's account-level risk rules during the
+// backtest. Drawdown uses candle-close equity and the daily loss window follows
+// candle timestamps (UTC). Limits with no rule enabled remove the guard.
+func (b *BacktestEngine) SetRiskLimits(limits risk.Limits) {
+	if !limits.Enabled() {
+		b.riskGuard = nil
+		return
+	}
+	b.riskGuard = risk.NewGuard(limits)
+	b.riskGuard.SetBreachCallback(func(breach risk.Breach) {
+		b.results.RiskBreaches = append(b.results.RiskBreaches, breach)
+	})
+}
+
+// allowBuy asks the risk guard whether a buy of amount at price may be placed
+func (b *BacktestEngine) allowBuy(amount, price float64, t time.Time) bool {
+	if b.riskGuard == nil {
+		return true
+	}
+
+	portfolio := &risk.Portfolio{
+		Balance: b.balance,
+		Equity:  b.balance + b.position*price,
+		Time:    t,
+	}
+	if b.cycleOpen {
+		portfolio.CycleCapital = b.cycleGrossCostSum
+		portfolio.DCALevel = b.cycleEntries
+	}
+	order := &risk.Order{Side: exchange.OrderSideBuy, Amount: amount, Price: price}
+
+	if err := b.riskGuard.ValidateOrder(order, portfolio); err != nil {
+		b.results.RiskBlockedBuys++
+		return false
+	}
+	return true
+}
+
+// recordCycleRisk feeds a completed cycle's realized PnL to the risk guard
+func (b *BacktestEngine) recordCycleRisk(realized float64, t time.Time) {
+	if b.riskGuard != nil {
+		b.riskGuard.RecordRealizedPnL(realized, t)
+	}
+}
+
+// openEntry executes a DCA buy of amount at fillPrice on data[i], charging
+// commissionRate, and records it in the current cycle. Returns false if the
+// balance cannot cover it or the risk guard rejects it.
+func (b *BacktestEngine) openEntry(amount, fillPrice, commissionRate, strength float64, data []types.OHLCV, i int) bool {
+	// Calculate initial quantity and amount
+	targetAmount := amount
+	quantity := targetAmount / fillPrice
+	actualAmount := targetAmount
+
+	// Apply minimum lot size constraint and step size (simulate real exchange behavior)
+	if b.minOrderQty > 0 {
+		// Round to nearest multiple of minOrderQty (step size)
+		multiplier := math.Round(quantity / b.minOrderQty)
+		// Ensure at least 1 step (minimum quantity)
+		if multiplier < 1 {
+			multiplier = 1
+		}
+		adjustedQuantity := multiplier * b.minOrderQty
+		if adjustedQuantity != quantity {
+			quantity = adjustedQuantity
+			actualAmount = quantity * fillPrice
+		}
+	}
+
+	// Calculate commission on executed notional (after lot adjustment)
+	commission := actualAmount * commissionRate
+	totalCost := actualAmount + commission
+	
+	// Skip the buy if the balance cannot cover the total cost (adjusted amount +
+	// commission) or the risk guard rejects it
+	if b.balance < totalCost || !b.allowBuy(actualAmount, fillPrice, data[i].Timestamp) {
+		return false
+	}
+
+	// Execute buy with actual amount, but deduct commission separately
+	netAmount := actualAmount // The lot-adjusted amount becomes the net investment
+	actualQuantity := netAmount / fillPrice
+
+	b.position += actualQuantity
+	b.balance -= totalCost // Deduct both adjusted amount and commission
+
+	// begin a new cycle if needed (only when TP enabled)
+	if (b.tpPercent > 0 || b.useTPLevels) && !b.cycleOpen {
+		b.currentCycleNumber++
+		b.cycleOpen = true
+		b.cycleEntries = 0
+		b.cycleStartTime = data[i].Timestamp
+		b.cycleQtySum = 0
+		b.cycleCostSum = 0
+		b.cycleGrossCostSum = 0
+		b.cycleGrossQtySum = 0
+		b.cycleCommissionSum = 0
+		b.cycleRemainingQty = 0
+		b.cycleUnrealizedPnL = 0
+		
+		// Reset TP level progress for new cycle
+		if b.useTPLevels {
+			for j := range b.tpLevels {
+				b.cycleTPProgress[j] = false
+				b.tpLevels[j].Hit = false
+				b.tpLevels[j].HitTime = nil
+				b.tpLevels[j].HitPrice = 0
+				b.tpLevels[j].PnL = 0
+				b.tpLevels[j].SoldQty = 0
+				b.tpLevels[j].SellCommission = 0
+			}
+		}
+	}
+
+	//Recording the transaction (input) - use actual executed values
+	trade := Trade{
+		EntryTime:  data[i].Timestamp,
+		EntryPrice: fillPrice,
+		Quantity:   actualQuantity, // Use actual quantity after commission
+		Commission: commission,
+	}
+	
+	// Add dynamic TP tracking for the trade
+	if b.dynamicTPEnabled {
+		// Calculate what the TP target would be for this trade
+		historyData := data[:i+1]
+		avgEntryEstimate := fillPrice // For new trades, average entry is current price
+		_, dynamicRecord, err := b.calculateCurrentTPTarget(data[i], historyData, avgEntryEstimate)
+		if err == nil && dynamicRecord != nil {
+			trade.TPTarget = dynamicRecord.CalculatedTP
+			trade.TPStrategy = dynamicRecord.Strategy
+			trade.MarketVolatility = dynamicRecord.MarketVolatility
+			trade.SignalStrength = strength
+		}
+	} else {
+		// Fixed TP mode
+		trade.TPTarget = b.tpPercent
+		trade.TPStrategy = "fixed"
+		trade.MarketVolatility = 0
+		trade.SignalStrength = 0
+	}
+	if b.cycleOpen {
+		b.cycleEntries++
+		b.cycleQtySum += actualQuantity
+		b.cycleRemainingQty += actualQuantity
+		// Track net cost (actual quantity after commission deduction)
+		b.cycleCostSum += fillPrice * actualQuantity
+		// Track gross cost (what we would have bought without commission)
+		grossQuantity := actualAmount / fillPrice
+		b.cycleGrossCostSum += fillPrice * grossQuantity
+		b.cycleGrossQtySum += grossQuantity
+		// Track commission for this cycle
+		b.cycleCommissionSum += commission
+		trade.Cycle = b.currentCycleNumber
+		
+		if b.positionTracker != nil {
+			b.positionTracker.SetAverageEntryPrice(b.calculateCurrentAvgEntry())
+		}
+		
+		// Initialize absolute TP quantities on first entry of cycle
+		if b.useTPLevels && b.cycleEntries == 1 {
+			b.setTPLevelsQuantities(b.cycleRemainingQty)
+		}
+		// Reset TP levels when new DCA entry is added (recalculate and start from TP1)
+		if b.useTPLevels && b.cycleEntries > 1 {
+			b.resetTPLevelsForNewEntry()
+		}
+	}
+
+	b.results.Trades = append(b.results.Trades, trade)
+	return true
+}
+
+// fillGridOrders fills resting grid limit buys touched by the candle's Low at
+// their limit price, or at the open when the candle gapped below it. Each fill
+// re-anchors the grid, so deeper levels can fill on the same candle. Returns
+// true if any order filled.
+func (b *BacktestEngine) fillGridOrders(data []types.OHLCV, i, windowSize int) bool {
+	candle := data[i]
+	// Re-plan from candles before this one - its close is not known intrabar
+	history := data[i-windowSize : i]
+
+	filled := false
+	for len(b.gridOrders) > 0 && candle.Low <= b.gridOrders[0].Price {
+		order := b.gridOrders[0]
+		fillPrice := math.Min(order.Price, candle.Open)
+		if !b.openEntry(order.Amount, fillPrice, b.gridCommission, 0, data, i) {
+			// Unfunded or blocked by the risk guard - the exchange would reject it too
+			b.gridOrders = nil
+			return filled
+		}
+		filled = true
+		b.results.LimitGridFills++
+		b.recordGridFill(order, candle)
+		b.gridPlanner.RecordGridFill(fillPrice, candle.Timestamp)
+		b.gridOrders = b.gridPlanner.PlanGrid(history)
+	}
+	return filled
+}
+
 func (b *BacktestEngine) Run(data []types.OHLCV, windowSize int) *BacktestResults {
 	// Handle empty or insufficient data
 	if len(data) == 0 {
@@ -263,6 +560,7 @@ func (b *BacktestEngine) Run(data []types.OHLCV, windowSize int) *BacktestResult
 	// Initialize balance and position
 	b.balance = b.initialBalance
 	b.position = 0.0
+	b.gridOrders = nil
 	maxBalance := b.balance
 	
 	// Initialize enhanced tracking
@@ -270,133 +568,54 @@ func (b *BacktestEngine) Run(data []types.OHLCV, windowSize int) *BacktestResult
 	b.exposureSamples = 0
 	b.exposureSum = 0
 	cyclePeakEquity := b.initialBalance
+	b.startTimeframes(data)
+	if binder, ok := b.strategy.(strategy.SeriesBinder); ok {
+		// A nil cache also drops series bound by an earlier run
+		binder.BindSeries(b.seriesCache, data, windowSize)
+	}
 
 	for i := windowSize; i < len(data); i++ {
 		// get a data window for analysis
 		window := data[i-windowSize : i+1]
 		currentPrice := data[i].Close
 
+		// Resting grid orders placed on earlier candles fill when the Low touches them
+		gridFilled := false
+		if len(b.gridOrders) > 0 && b.position > 0 {
+			gridFilled = b.fillGridOrders(data, i, windowSize)
+		}
+
+		// Higher timeframe candles that closed by this candle's close
+		if b.timeframeFeeds != nil {
+			b.feedTimeframes(data[i].Timestamp.Add(b.baseInterval), i)
+		}
+
 		// get a signal from the strategy
 		decision, err := b.strategy.ShouldExecuteTrade(window)
+		if err == nil && b.decisionObserver != nil {
+			b.decisionObserver(i, decision)
+		}
 		if err == nil && decision.Action == strategy.ActionBuy {
-			// Calculate initial quantity and amount
-			targetAmount := decision.Amount
-			quantity := targetAmount / currentPrice
-			actualAmount := targetAmount
-
-			// Apply minimum lot size constraint and step size (simulate real exchange behavior)
-			if b.minOrderQty > 0 {
-				// Round to nearest multiple of minOrderQty (step size)
-				multiplier := math.Round(quantity / b.minOrderQty)
-				// Ensure at least 1 step (minimum quantity)
-				if multiplier < 1 {
-					multiplier = 1
-				}
-				adjustedQuantity := multiplier * b.minOrderQty
-				if adjustedQuantity != quantity {
-					quantity = adjustedQuantity
-					actualAmount = quantity * currentPrice
-				}
+			fillPrice := currentPrice
+			if b.entryFill != nil {
+				fillPrice = b.entryFill(data, i)
 			}
-
-			// Calculate commission on executed notional (after lot adjustment)
-			commission := actualAmount * b.commission
-			totalCost := actualAmount + commission
 			
-			// Check if we have enough balance for total cost (adjusted amount + commission)
-			if b.balance >= totalCost {
-				// Execute buy with actual amount, but deduct commission separately
-				netAmount := actualAmount // The lot-adjusted amount becomes the net investment
-				actualQuantity := netAmount / currentPrice
-
-				b.position += actualQuantity
-				b.balance -= totalCost // Deduct both adjusted amount and commission
-
-				// begin a new cycle if needed (only when TP enabled)
-				if (b.tpPercent > 0 || b.useTPLevels) && !b.cycleOpen {
-					b.currentCycleNumber++
-					b.cycleOpen = true
-					b.cycleEntries = 0
-					b.cycleStartTime = data[i].Timestamp
-					b.cycleQtySum = 0
-					b.cycleCostSum = 0
-					b.cycleGrossCostSum = 0
-					b.cycleGrossQtySum = 0
-					b.cycleCommissionSum = 0
-					b.cycleRemainingQty = 0
-					b.cycleUnrealizedPnL = 0
-					
-					// Reset TP level progress for new cycle
-					if b.useTPLevels {
-						for i := range b.tpLevels {
-							b.cycleTPProgress[i] = false
-							b.tpLevels[i].Hit = false
-							b.tpLevels[i].HitTime = nil
-							b.tpLevels[i].HitPrice = 0
-							b.tpLevels[i].PnL = 0
-							b.tpLevels[i].SoldQty = 0
-							b.tpLevels[i].SellCommission = 0
-						}
-					}
-				}
-
-				//Recording the transaction (input) - use actual executed values
-				trade := Trade{
-					EntryTime:  data[i].Timestamp,
-					EntryPrice: currentPrice,
-					Quantity:   actualQuantity, // Use actual quantity after commission
-					Commission: commission,
-				}
-				
-				// Add dynamic TP tracking for the trade
-				if b.dynamicTPEnabled && decision != nil {
-					// Calculate what the TP target would be for this trade
-					historyData := data[:i+1]
-					avgEntryEstimate := currentPrice // For new trades, average entry is current price
-					_, dynamicRecord, err := b.calculateCurrentTPTarget(data[i], historyData, avgEntryEstimate)
-					if err == nil && dynamicRecord != nil {
-						trade.TPTarget = dynamicRecord.CalculatedTP
-						trade.TPStrategy = dynamicRecord.Strategy
-						trade.MarketVolatility = dynamicRecord.MarketVolatility
-						trade.SignalStrength = decision.Strength
-					}
-				} else {
-					// Fixed TP mode
-					trade.TPTarget = b.tpPercent
-					trade.TPStrategy = "fixed"
-					trade.MarketVolatility = 0
-					trade.SignalStrength = 0
-				}
-				if b.cycleOpen {
-					b.cycleEntries++
-					b.cycleQtySum += actualQuantity
-					b.cycleRemainingQty += actualQuantity
-					// Track net cost (actual quantity after commission deduction)
-					b.cycleCostSum += currentPrice * actualQuantity
-					// Track gross cost (what we would have bought without commission)
-					grossQuantity := actualAmount / currentPrice
-					b.cycleGrossCostSum += currentPrice * grossQuantity
-					b.cycleGrossQtySum += grossQuantity
-					// Track commission for this cycle
-					b.cycleCommissionSum += commission
-					trade.Cycle = b.currentCycleNumber
-					
-					// Initialize absolute TP quantities on first entry of cycle
-					if b.useTPLevels && b.cycleEntries == 1 {
-						b.setTPLevelsQuantities(b.cycleRemainingQty)
-					}
-					// Reset TP levels when new DCA entry is added (recalculate and start from TP1)
-					if b.useTPLevels && b.cycleEntries > 1 {
-						b.resetTPLevelsForNewEntry()
-					}
-				}
-
-				b.results.Trades = append(b.results.Trades, trade)
+			filled := b.openEntry(decision.Amount, fillPrice, b.commission, decision.Strength, data, i)
+			if filled && b.gridPlanner != nil {
+				// Rest the next DCA levels on the book, anchored at this entry
+				b.gridOrders = b.gridPlanner.PlanGrid(window)
 			}
+			b.recordDecision(decision, data[i], filled)
+		} else if err == nil {
+			b.recordDecision(decision, data[i], false)
 		}
 
-		// Check and execute take profit orders using High price for realistic TP execution
-		if b.cycleOpen && b.position > 0 {
+		// Check and execute take profit orders using High price for realistic TP execution.
+		// After a grid fill at this candle's Low it is unknown whether the High
+		// came before or after it, so the averaged position takes profit from
+		// the next candle on.
+		if b.cycleOpen && b.position > 0 && !gridFilled {
 			if b.useTPLevels {
 				// Use High price to check which TP levels were hit during the candle
 				b.checkAndExecuteMultipleTPWithHigh(data[i].High, data[i].Timestamp, data, i)
@@ -416,6 +635,9 @@ func (b *BacktestEngine) Run(data []types.OHLCV, windowSize int) *BacktestResult
 		if currentValue > b.peakEquity {
 			b.peakEquity = currentValue
 		}
+		if b.riskGuard != nil {
+			b.riskGuard.UpdateEquity(currentValue, data[i].Timestamp)
+		}
 		
 		// Track cycle peak equity for intra-cycle drawdown
 		if b.cycleOpen {
@@ -615,6 +837,19 @@ func (b *BacktestResults) PrintSummary() {
 	fmt.Printf("Max Drawdown: %.2f%%\n", b.MaxDrawdown*100)
 	fmt.Printf("Total Trades: %d\n", b.TotalTrades)
 	fmt.Printf("Profit Factor: %.2f\n", b.ProfitFactor)
+	if len(b.RiskBreaches) > 0 || b.RiskBlockedBuys > 0 {
+		fmt.Printf("Risk Guard: %d buys blocked, %d breaches\n", b.RiskBlockedBuys, len(b.RiskBreaches))
+		for _, breach := range b.RiskBreaches {
+			fmt.Printf("  %s %s: %s\n", breach.Time.Format("2006-01-02 15:04"), breach.Rule, breach.Message)
+		}
+	}
+	if b.LimitGridFills > 0 {
+		fmt.Printf("Limit Grid Fills: %d DCA entries filled by resting limit orders\n", b.LimitGridFills)
+	}
+	if b.DecayedExits > 0 {
+		fmt.Printf("Time-Decayed Exits: %d (%d never reached the full TP), %.0f capital-days saved, $%.2f profit lost\n",
+			b.DecayedExits, b.DecayedExitsUnfilled, b.CapitalDaysSaved, b.DecayLostProfit)
+	}
 	if len(b.Cycles) > 0 {
 		fmt.Printf("Completed Cycles: %d (Total Cycles: %d)\n", b.CompletedCycles, len(b.Cycles))
 		b.PrintCycleDetails()
@@ -728,6 +963,10 @@ func (b *BacktestEngine) checkAndExecuteSingleTPWithHigh(highPrice float64, time
 				Completed:       true,
 			})
 			b.results.CompletedCycles++
+			b.recordCycleRisk(realized, timestamp)
+			if dynamicRecord != nil {
+				b.recordDecayedExit(data, currentIndex, totalQty, avgEntry, 1, dynamicRecord.CalculatedTP)
+			}
 
 			// Reset position and cycle state for next DCA cycle
 			b.resetCycle()
@@ -790,6 +1029,7 @@ func (b *BacktestEngine) checkAndExecuteSingleTP(currentPrice float64, timestamp
 				Completed:       true,
 			})
 			b.results.CompletedCycles++
+			b.recordCycleRisk(realized, timestamp)
 
 			// Reset position and cycle state for next DCA cycle
 			b.resetCycle()
@@ -811,6 +1051,7 @@ func (b *BacktestEngine) checkAndExecuteMultipleTPWithHigh(highPrice float64, ti
 	// Determine base TP percentage (fixed or dynamic based on market conditions)
 	var baseTPPercent float64
 	var dynamicRecord *DynamicTPRecord
+	dynamicBase := false // Whether baseTPPercent came from the strategy
 	if b.dynamicTPEnabled && data != nil && currentIndex > 0 {
 		currentCandle := data[currentIndex]
 		historyData := data[:currentIndex+1]
@@ -819,6 +1060,7 @@ func (b *BacktestEngine) checkAndExecuteMultipleTPWithHigh(highPrice float64, ti
 			baseTPPercent = b.tpPercent // Fallback to fixed TP on calculation error
 		} else if dynamicRecord != nil {
 			baseTPPercent = dynamicRecord.CalculatedTP
+			dynamicBase = true
 			b.addDynamicTPRecord(dynamicRecord) // Track for performance analysis
 		} else {
 			baseTPPercent = b.tpPercent
@@ -844,6 +1086,9 @@ func (b *BacktestEngine) checkAndExecuteMultipleTPWithHigh(highPrice float64, ti
 			// Execute at exact target price for realistic simulation
 			// Pass dynamic TP info for tracking
 			b.executeTPLevelWithDynamicInfo(i, target, timestamp, avgEntry, levelTPPercent, dynamicRecord)
+			if dynamicBase {
+				b.recordDecayedExit(data, currentIndex, b.tpLevels[i].SoldQty, avgEntry, levelMultiplier, baseTPPercent)
+			}
 		}
 	}
 	
@@ -1064,6 +1309,7 @@ func (b *BacktestEngine) completeCycle(timestamp time.Time) {
     })
 	
 	b.results.CompletedCycles++
+	b.recordCycleRisk(b.cycleUnrealizedPnL, timestamp)
 	
 	// Reset cycle state
 	b.resetCycle()
@@ -1156,6 +1402,9 @@ func (b *BacktestEngine) resetCycle() {
     // Reset cycle exposure tracking
     b.maxCycleExposure = 0
     
+    // Cancel resting grid orders of the finished cycle
+    b.gridOrders = nil
+    
     // Notify strategy that cycle is complete so it can reset state
     b.strategy.OnCycleComplete()
 }
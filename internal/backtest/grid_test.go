@@ -0,0 +1,78 @@
+package backtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/strategy"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
+)
+
+// gridProbe buys on its first decision and rests one grid level at gridPrice
+// until it fills
+type gridProbe struct {
+	gridPrice float64
+	entries   int
+}
+
+func (g *gridProbe) ShouldExecuteTrade(data []types.OHLCV) (*strategy.TradeDecision, error) {
+	last := data[len(data)-1]
+	if g.entries == 0 {
+		g.entries++
+		return &strategy.TradeDecision{Action: strategy.ActionBuy, Amount: 100, Timestamp: last.Timestamp}, nil
+	}
+	return &strategy.TradeDecision{Action: strategy.ActionHold, Timestamp: last.Timestamp}, nil
+}
+
+func (g *gridProbe) GetName() string          { return "grid probe" }
+func (g *gridProbe) OnCycleComplete()         { g.entries = 0 }
+func (g *gridProbe) ResetForNewPeriod()       {}
+func (g *gridProbe) IsDynamicTPEnabled() bool { return false }
+func (g *gridProbe) GetDynamicTPPercent(types.OHLCV, []types.OHLCV) (float64, error) {
+	return 0, nil
+}
+
+func (g *gridProbe) IsGridEnabled() bool { return true }
+func (g *gridProbe) PlanGrid([]types.OHLCV) []strategy.GridOrder {
+	if g.entries != 1 {
+		return nil
+	}
+	return []strategy.GridOrder{{Level: 1, Price: g.gridPrice, Amount: 100}}
+}
+func (g *gridProbe) RecordGridFill(float64, time.Time) { g.entries++ }
+
+func TestGridFillTakesProfitFromTheNextCandle(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	candle := func(i int, open, high, low, close float64) types.OHLCV {
+		return types.OHLCV{Timestamp: start.Add(time.Duration(i) * time.Hour), Open: open, High: high, Low: low, Close: close, Volume: 1}
+	}
+	// Entry at 100 on candle 1. Candle 2 fills the grid level at 95, which
+	// averages the entry down to 97.44 and its 2% TP to 99.38; its High of 101
+	// is above that TP but below the 102 TP of the entry alone. Candle 3
+	// reaches the averaged TP.
+	data := []types.OHLCV{
+		candle(0, 100, 100.5, 99.5, 100),
+		candle(1, 100, 100.5, 99.5, 100),
+		candle(2, 100, 101, 94, 97),
+		candle(3, 97, 99.5, 96.5, 99),
+		candle(4, 99, 99.5, 98.5, 99),
+	}
+
+	engine := NewBacktestEngine(1000, 0, &gridProbe{gridPrice: 95}, 0.02, 0, false)
+	results := engine.Run(data, 1)
+
+	if results.LimitGridFills != 1 {
+		t.Fatalf("expected the grid level to fill once, got %d fills", results.LimitGridFills)
+	}
+	// The entry and the grid fill; the probe buys again once the cycle closes
+	if len(results.Trades) < 2 {
+		t.Fatalf("expected the entry and the grid fill, got %d trades", len(results.Trades))
+	}
+	for _, trade := range results.Trades[:2] {
+		// Whether candle 2 reached its High before or after its Low is unknown
+		if !trade.ExitTime.Equal(data[3].Timestamp) {
+			t.Errorf("expected the averaged position to take profit on candle 3 at %s, exited at %s",
+				data[3].Timestamp, trade.ExitTime)
+		}
+	}
+}
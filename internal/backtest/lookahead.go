@@ -0,0 +1,247 @@
+package backtest
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/strategy"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/data"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
+)
+
+// The look-ahead audit checks that no decision of a backtest can read a
+// candle that had not closed when the decision was made:
+//
+//   - every higher timeframe candle passed to the strategy closed by the
+//     decision time, and the candles passed equal those built from only the
+//     data available then (the data up to the decision, resampled, or the
+//     loaded candles that had closed);
+//   - rerunning the backtest with every candle after a cut point altered
+//     leaves all decisions up to the cut unchanged.
+
+// maxFeedChecks bounds the decisions whose higher timeframe candles are
+// rebuilt from truncated data; each rebuild resamples the data seen so far
+const maxFeedChecks = 2000
+
+// LookaheadAudit is the outcome of AuditLookahead
+type LookaheadAudit struct {
+	Decisions  int      // Decisions made in the reference run
+	FeedChecks int      // Higher timeframe candle sets rebuilt and compared
+	Cuts       int      // Reruns with the candles after a cut point altered
+	Violations []string // Each look-ahead found, empty when none
+}
+
+// Passed returns true if no look-ahead was found
+func (a *LookaheadAudit) Passed() bool {
+	return len(a.Violations) == 0
+}
+
+// auditRecord is what a decision saw and decided
+type auditRecord struct {
+	at        time.Time
+	candles   map[string][]types.OHLCV
+	decision  *strategy.TradeDecision
+	decisions int
+}
+
+// AuditLookahead runs the look-ahead audit on data. newEngine must return a
+// fresh engine with a fresh strategy, configured like the backtest, on each
+// call; cuts is the number of altered-future reruns.
+func AuditLookahead(newEngine func() (*BacktestEngine, error), candles []types.OHLCV, windowSize, cuts int) (*LookaheadAudit, error) {
+	if len(candles) <= windowSize+1 {
+		return nil, fmt.Errorf("need more than %d candles for the audit, got %d", windowSize+1, len(candles))
+	}
+
+	engine, err := newEngine()
+	if err != nil {
+		return nil, err
+	}
+	if err := engine.PrepareTimeframes(candles); err != nil {
+		return nil, err
+	}
+	reference := recordRun(engine, candles, windowSize)
+
+	audit := &LookaheadAudit{Decisions: len(reference)}
+	audit.checkFeeds(engine, candles, reference)
+
+	for c := 1; c <= cuts; c++ {
+		cut := windowSize + (len(candles)-1-windowSize)*c/(cuts+1)
+		if err := audit.checkCut(newEngine, candles, windowSize, cut, reference); err != nil {
+			return nil, err
+		}
+		audit.Cuts++
+	}
+	return audit, nil
+}
+
+// recordRun runs the engine and records every decision by candle index
+func recordRun(engine *BacktestEngine, candles []types.OHLCV, windowSize int) map[int]*auditRecord {
+	records := make(map[int]*auditRecord)
+	record := func(index int) *auditRecord {
+		r, ok := records[index]
+		if !ok {
+			r = &auditRecord{candles: make(map[string][]types.OHLCV)}
+			records[index] = r
+		}
+		return r
+	}
+
+	engine.timeframeObserver = func(index int, at time.Time, req strategy.TimeframeRequirement, fed []types.OHLCV) {
+		r := record(index)
+		r.at = at
+		r.candles[req.Interval] = append([]types.OHLCV(nil), fed...)
+	}
+	engine.decisionObserver = func(index int, decision *strategy.TradeDecision) {
+		r := record(index)
+		r.decision = decision
+		r.decisions++
+	}
+	engine.Run(candles, windowSize)
+	return records
+}
+
+// checkFeeds checks the closing times of the higher timeframe candles passed
+// at each decision and rebuilds them from the data available at the time
+func (a *LookaheadAudit) checkFeeds(engine *BacktestEngine, candles []types.OHLCV, records map[int]*auditRecord) {
+	stride := len(candles)/maxFeedChecks + 1
+	last := make(map[string]int)
+
+	for i := 0; i < len(candles); i++ {
+		r, ok := records[i]
+		if !ok || r.decisions == 0 {
+			continue
+		}
+		if want := candles[i].Timestamp.Add(engine.baseInterval); !r.at.IsZero() && !r.at.Equal(want) {
+			a.violation("decision at %s was fed as of %s", formatAuditTime(want), formatAuditTime(r.at))
+		}
+
+		for _, f := range engine.timeframeFeeds {
+			fed := r.candles[f.req.Interval]
+			for _, c := range fed {
+				if closes := c.Timestamp.Add(f.req.Duration); closes.After(r.at) {
+					a.violation("%s candle %s closes at %s, after the decision at %s",
+						f.req.Interval, formatAuditTime(c.Timestamp), formatAuditTime(closes), formatAuditTime(r.at))
+				}
+			}
+
+			// Rebuilding is costly, so only where the candles passed changed
+			// and at a regular stride
+			if len(fed) == last[f.req.Interval] && i%stride != 0 {
+				continue
+			}
+			last[f.req.Interval] = len(fed)
+
+			known, ok := engine.timeframeData[f.req.Interval]
+			if !ok {
+				var err error
+				if known, err = data.Resample(candles[:i+1], f.req.Duration); err != nil {
+					a.violation("%s candles at %s cannot be rebuilt: %v", f.req.Interval, formatAuditTime(r.at), err)
+					continue
+				}
+			}
+			closed := known[:data.ClosedCount(known, f.req.Duration, r.at)]
+			if start := len(closed) - f.req.Window; start > 0 {
+				closed = closed[start:]
+			}
+			a.FeedChecks++
+			if !reflect.DeepEqual(nonNil(closed), nonNil(fed)) {
+				a.violation("%s candles passed at %s differ from those built from the data available then (%d vs %d candles)",
+					f.req.Interval, formatAuditTime(r.at), len(fed), len(closed))
+			}
+		}
+	}
+}
+
+// checkCut reruns the backtest with every candle after cut altered and checks
+// the decisions up to the cut are unchanged
+func (a *LookaheadAudit) checkCut(newEngine func() (*BacktestEngine, error), candles []types.OHLCV, windowSize, cut int, reference map[int]*auditRecord) error {
+	engine, err := newEngine()
+	if err != nil {
+		return err
+	}
+
+	altered := alterAfter(candles, cut)
+	cutClose := candles[cut].Timestamp.Add(data.InferInterval(candles))
+	for interval, loaded := range engine.timeframeData {
+		d, err := data.IntervalDuration(interval)
+		if err != nil {
+			return err
+		}
+		engine.timeframeData[interval] = alterAfter(loaded, data.ClosedCount(loaded, d, cutClose)-1)
+	}
+	rerun := recordRun(engine, altered, windowSize)
+
+	for i := windowSize; i <= cut; i++ {
+		want, got := reference[i], rerun[i]
+		if want == nil || got == nil || want.decision == nil || got.decision == nil {
+			continue
+		}
+		if !reflect.DeepEqual(want.candles, got.candles) {
+			a.violation("higher timeframe candles at %s changed when the candles after %s were altered",
+				formatAuditTime(candles[i].Timestamp), formatAuditTime(candles[cut].Timestamp))
+			return nil
+		}
+		if !sameDecision(want.decision, got.decision) {
+			a.violation("decision at %s changed when the candles after %s were altered: %s %q became %s %q",
+				formatAuditTime(candles[i].Timestamp), formatAuditTime(candles[cut].Timestamp),
+				want.decision.Action, want.decision.Reason, got.decision.Action, got.decision.Reason)
+			return nil
+		}
+	}
+	return nil
+}
+
+// alterAfter returns a copy of candles with the prices and volumes after
+// index replaced: the later candles are mirrored around the price at index
+// and reversed in order, so every later value differs
+func alterAfter(candles []types.OHLCV, index int) []types.OHLCV {
+	altered := append([]types.OHLCV(nil), candles...)
+	if index >= len(candles)-1 {
+		return altered
+	}
+	pivot := candles[0].Open
+	if index >= 0 {
+		pivot = candles[index].Close
+	}
+	for j := index + 1; j < len(candles); j++ {
+		src := candles[len(candles)-1-(j-index-1)]
+		c := &altered[j]
+		c.Open = mirror(pivot, src.Open)
+		c.Close = mirror(pivot, src.Close)
+		c.High = mirror(pivot, src.Low)
+		c.Low = mirror(pivot, src.High)
+		c.Volume = src.Volume*1.5 + 1
+	}
+	return altered
+}
+
+// mirror reflects price around pivot, kept positive
+func mirror(pivot, price float64) float64 {
+	v := 2*pivot - price
+	if v < pivot*0.01 {
+		v = pivot * 0.01
+	}
+	return v
+}
+
+// sameDecision compares the parts of a decision that depend on market data
+func sameDecision(a, b *strategy.TradeDecision) bool {
+	return a.Action == b.Action && a.Amount == b.Amount && a.Confidence == b.Confidence &&
+		a.Strength == b.Strength && a.Reason == b.Reason && reflect.DeepEqual(a.Trace, b.Trace)
+}
+
+func (a *LookaheadAudit) violation(format string, args ...interface{}) {
+	a.Violations = append(a.Violations, fmt.Sprintf(format, args...))
+}
+
+func nonNil(candles []types.OHLCV) []types.OHLCV {
+	if candles == nil {
+		return []types.OHLCV{}
+	}
+	return candles
+}
+
+func formatAuditTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04")
+}
@@ -0,0 +1,208 @@
+package backtest
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/strategy"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/config"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
+)
+
+// probeStrategy holds on every candle and reports the last close and the
+// last higher timeframe close in its reason, so any data change shows up in
+// the decisions. With peek set it also reads the candle after the window by
+// reslicing past its end, as a strategy with look-ahead would.
+type probeStrategy struct {
+	req  strategy.TimeframeRequirement
+	htf  []types.OHLCV
+	peek bool
+}
+
+func (p *probeStrategy) ShouldExecuteTrade(data []types.OHLCV) (*strategy.TradeDecision, error) {
+	last := data[len(data)-1]
+	reason := fmt.Sprintf("close %.4f", last.Close)
+	if n := len(p.htf); n > 0 {
+		reason += fmt.Sprintf(", %s close %.4f", p.req.Interval, p.htf[n-1].Close)
+	}
+	if p.peek && cap(data) > len(data) {
+		reason += fmt.Sprintf(", next close %.4f", data[:len(data)+1][len(data)].Close)
+	}
+	return &strategy.TradeDecision{Action: strategy.ActionHold, Reason: reason, Timestamp: last.Timestamp}, nil
+}
+
+func (p *probeStrategy) GetName() string          { return "probe" }
+func (p *probeStrategy) OnCycleComplete()         {}
+func (p *probeStrategy) ResetForNewPeriod()       {}
+func (p *probeStrategy) IsDynamicTPEnabled() bool { return false }
+func (p *probeStrategy) GetDynamicTPPercent(types.OHLCV, []types.OHLCV) (float64, error) {
+	return 0, nil
+}
+
+func (p *probeStrategy) RequiredTimeframes() []strategy.TimeframeRequirement {
+	return []strategy.TimeframeRequirement{p.req}
+}
+
+func (p *probeStrategy) SetTimeframeCandles(interval string, candles []types.OHLCV) {
+	p.htf = candles
+}
+
+// hourlyCandles returns n 1h candles from midnight following a sine wave, so
+// resampled candles and indicator signals vary along the series
+func hourlyCandles(n int) []types.OHLCV {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	candles := make([]types.OHLCV, n)
+	for i := range candles {
+		p := 100 + 10*math.Sin(float64(i)/15) + float64(i%7)*0.3
+		candles[i] = types.OHLCV{
+			Timestamp: start.Add(time.Duration(i) * time.Hour),
+			Open:      p, High: p * 1.01, Low: p * 0.99, Close: p * 1.002, Volume: 100 + float64(i%11),
+		}
+	}
+	return candles
+}
+
+func fourHourProbe() *probeStrategy {
+	return &probeStrategy{req: strategy.TimeframeRequirement{Interval: "4h", Duration: 4 * time.Hour, Window: 2}}
+}
+
+func TestFeedTimeframesPassesOnlyClosedCandles(t *testing.T) {
+	candles := hourlyCandles(12)
+	probe := fourHourProbe()
+	engine := NewBacktestEngine(1000, 0.001, probe, 0, 0, false)
+	if err := engine.PrepareTimeframes(candles); err != nil {
+		t.Fatal(err)
+	}
+
+	start := candles[0].Timestamp
+	wantOpens := map[int][]time.Time{
+		2:  nil,                                                  // decision at 03:00, the 00:00 candle is forming
+		3:  {start},                                              // decision at 04:00, the 00:00 candle closes exactly then
+		6:  {start},                                              // decision at 07:00
+		7:  {start, start.Add(4 * time.Hour)},                    // decision at 08:00
+		11: {start.Add(4 * time.Hour), start.Add(8 * time.Hour)}, // window of 2 at 12:00
+	}
+	for i := range candles {
+		engine.feedTimeframes(candles[i].Timestamp.Add(time.Hour), i)
+		want, ok := wantOpens[i]
+		if !ok {
+			continue
+		}
+		if len(probe.htf) != len(want) {
+			t.Fatalf("decision %d: expected %d 4h candles, got %d", i, len(want), len(probe.htf))
+		}
+		for j, open := range want {
+			if !probe.htf[j].Timestamp.Equal(open) {
+				t.Errorf("decision %d: expected 4h candle %d to open at %s, got %s", i, j, open, probe.htf[j].Timestamp)
+			}
+		}
+	}
+
+	// The first 4h candle aggregates the first four 1h candles
+	first := engine.timeframeFeeds[0].candles[0]
+	if first.Open != candles[0].Open || first.Close != candles[3].Close {
+		t.Errorf("unexpected first 4h candle %+v", first)
+	}
+}
+
+func TestAuditLookaheadPassesWithoutLookahead(t *testing.T) {
+	candles := hourlyCandles(300)
+	audit, err := AuditLookahead(func() (*BacktestEngine, error) {
+		return NewBacktestEngine(1000, 0.001, fourHourProbe(), 0, 0, false), nil
+	}, candles, 50, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !audit.Passed() {
+		t.Fatalf("unexpected violations: %v", audit.Violations)
+	}
+	if audit.Decisions != len(candles)-50 || audit.Cuts != 3 || audit.FeedChecks == 0 {
+		t.Errorf("audit did not check every decision: %+v", audit)
+	}
+}
+
+func TestAuditLookaheadDetectsFutureReads(t *testing.T) {
+	candles := hourlyCandles(300)
+	audit, err := AuditLookahead(func() (*BacktestEngine, error) {
+		probe := fourHourProbe()
+		probe.peek = true
+		return NewBacktestEngine(1000, 0.001, probe, 0, 0, false), nil
+	}, candles, 50, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if audit.Passed() {
+		t.Fatal("expected the read of the next candle to be reported")
+	}
+	if !strings.Contains(audit.Violations[0], "changed when the candles after") {
+		t.Errorf("unexpected violation: %s", audit.Violations[0])
+	}
+}
+
+func TestAuditLookaheadDetectsFormingCandles(t *testing.T) {
+	candles := hourlyCandles(120)
+	engine := NewBacktestEngine(1000, 0.001, fourHourProbe(), 0, 0, false)
+	if err := engine.PrepareTimeframes(candles); err != nil {
+		t.Fatal(err)
+	}
+
+	// A decision at 03:00 that was passed the 00:00 4h candle still forming
+	at := candles[2].Timestamp.Add(time.Hour)
+	forming := engine.timeframeFeeds[0].candles[:1]
+	audit := &LookaheadAudit{}
+	audit.checkFeeds(engine, candles, map[int]*auditRecord{
+		2: {at: at, candles: map[string][]types.OHLCV{"4h": forming}, decisions: 1},
+	})
+	if audit.Passed() || !strings.Contains(audit.Violations[0], "closes at") {
+		t.Fatalf("expected the forming 4h candle to be reported, got %v", audit.Violations)
+	}
+}
+
+func TestAlteredFutureLeavesEarlierDecisionsUnchanged(t *testing.T) {
+	const windowSize = 60
+	candles := hourlyCandles(400)
+	cfg := config.NewDefaultDCAConfig()
+	cfg.Indicators = []string{"rsi", "macd", "bb"}
+	cfg.DCASpacing = &config.DCASpacingConfig{
+		Strategy:   "fixed",
+		Parameters: map[string]interface{}{"base_threshold": 0.01, "threshold_multiplier": 1.15},
+	}
+	cfg.Timeframes = config.Timeframes{{Interval: "4h", Conditions: []string{"close > sma(5)"}}}
+
+	run := func(data []types.OHLCV) map[int]*auditRecord {
+		strat, err := strategy.New(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		engine := NewBacktestEngine(cfg.InitialBalance, cfg.Commission, strat, cfg.TPPercent, 0, false)
+		if err := engine.PrepareTimeframes(data); err != nil {
+			t.Fatal(err)
+		}
+		return recordRun(engine, data, windowSize)
+	}
+
+	reference := run(candles)
+	for _, cut := range []int{windowSize + 40, 200, 330} {
+		rerun := run(alterAfter(candles, cut))
+		changedAfter := false
+		for i := windowSize; i < len(candles); i++ {
+			want, got := reference[i], rerun[i]
+			if want == nil || got == nil || want.decision == nil || got.decision == nil {
+				t.Fatalf("cut %d: no decision at %d", cut, i)
+			}
+			same := sameDecision(want.decision, got.decision)
+			if i <= cut && !same {
+				t.Fatalf("cut %d: decision at %d changed from %q to %q", cut, i, want.decision.Reason, got.decision.Reason)
+			}
+			if i > cut && !same {
+				changedAfter = true
+			}
+		}
+		if !changedAfter {
+			t.Errorf("cut %d: altering the candles after the cut changed no later decision", cut)
+		}
+	}
+}
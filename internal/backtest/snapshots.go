@@ -0,0 +1,179 @@
+package backtest
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/strategy"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
+)
+
+// Decision snapshot modes
+const (
+	SnapshotsOff     = "off"     // No snapshots
+	SnapshotsEntries = "entries" // Buy decisions and limit grid fills
+	SnapshotsAll     = "all"     // Every decision, holds included
+)
+
+// ParseSnapshotMode normalizes a decision snapshot mode; empty means off
+func ParseSnapshotMode(mode string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case SnapshotsOff, "none", "":
+		return SnapshotsOff, nil
+	case SnapshotsEntries, "entry", "buys":
+		return SnapshotsEntries, nil
+	case SnapshotsAll, "holds":
+		return SnapshotsAll, nil
+	default:
+		return "", fmt.Errorf("unknown decision snapshot mode: %s (supported: off, entries, all)", mode)
+	}
+}
+
+// DecisionSnapshot records what the strategy saw when it decided on a candle
+type DecisionSnapshot struct {
+	Time       time.Time `json:"time"`
+	Price      float64   `json:"price"`  // Close of the decision candle
+	Action     string    `json:"action"` // BUY or HOLD
+	Source     string    `json:"source"` // "signal" or "grid" for limit grid fills
+	Filled     bool      `json:"filled"` // Whether the buy opened an entry (false when unfunded or blocked by the risk guard)
+	FillPrice  float64   `json:"fill_price,omitempty"`
+	Cycle      int       `json:"cycle,omitempty"` // Cycle of the entry, 0 when not filled
+	Amount     float64   `json:"amount,omitempty"`
+	Confidence float64   `json:"confidence"`
+	Strength   float64   `json:"strength"`
+	Reason     string    `json:"reason"`
+
+	// DCA spacing context (zero when the strategy does not report it)
+	DCALevel         int     `json:"dca_level"`
+	LastEntryPrice   float64 `json:"last_entry_price,omitempty"`
+	PriceDrop        float64 `json:"price_drop,omitempty"`
+	SpacingThreshold float64 `json:"spacing_threshold,omitempty"` // 0 when the spacing was not checked
+	SpacingStrategy  string  `json:"spacing_strategy,omitempty"`
+
+	Indicators []IndicatorSnapshot `json:"indicators"`
+	Trace      []string            `json:"trace,omitempty"` // Expression condition trace
+}
+
+// IndicatorSnapshot is one indicator's result behind a decision
+type IndicatorSnapshot struct {
+	Name     string  `json:"name"`
+	Value    float64 `json:"value"`
+	Buy      bool    `json:"buy"`
+	Sell     bool    `json:"sell"`
+	Strength float64 `json:"strength"`
+	Error    string  `json:"error,omitempty"`
+}
+
+// SetDecisionSnapshots sets which decisions are recorded in the results'
+// DecisionSnapshots; an unknown mode disables them
+func (b *BacktestEngine) SetDecisionSnapshots(mode string) {
+	parsed, err := ParseSnapshotMode(mode)
+	if err != nil {
+		parsed = SnapshotsOff
+	}
+	b.snapshotMode = parsed
+}
+
+// recordDecision snapshots a strategy decision on candle if the mode covers it
+func (b *BacktestEngine) recordDecision(decision *strategy.TradeDecision, candle types.OHLCV, filled bool) {
+	switch {
+	case b.snapshotMode == SnapshotsAll:
+	case b.snapshotMode == SnapshotsEntries && decision.Action == strategy.ActionBuy:
+	default:
+		return
+	}
+
+	snapshot := b.newSnapshot(candle)
+	snapshot.Action = decision.Action.String()
+	snapshot.Source = "signal"
+	snapshot.Amount = decision.Amount
+	snapshot.Confidence = decision.Confidence
+	snapshot.Strength = decision.Strength
+	snapshot.Reason = decision.Reason
+	snapshot.Trace = decision.Trace
+	if filled {
+		b.markFilled(&snapshot)
+	}
+	b.results.DecisionSnapshots = append(b.results.DecisionSnapshots, snapshot)
+}
+
+// recordGridFill snapshots a limit grid fill with the indicators of the last
+// decision, those the resting order was placed on
+func (b *BacktestEngine) recordGridFill(order strategy.GridOrder, candle types.OHLCV) {
+	if b.snapshotMode == SnapshotsOff {
+		return
+	}
+
+	snapshot := b.newSnapshot(candle)
+	snapshot.Action = strategy.ActionBuy.String()
+	snapshot.Source = "grid"
+	snapshot.Amount = order.Amount
+	snapshot.Reason = fmt.Sprintf("Limit grid fill at $%.4f", order.Price)
+	// The grid planner spaced the order; the last decision's drop does not apply
+	snapshot.DCALevel = order.Level
+	snapshot.LastEntryPrice = 0
+	snapshot.PriceDrop = 0
+	snapshot.SpacingThreshold = 0
+	b.markFilled(&snapshot)
+	b.results.DecisionSnapshots = append(b.results.DecisionSnapshots, snapshot)
+}
+
+// newSnapshot starts a snapshot on candle with the strategy's last indicator
+// results and DCA context
+func (b *BacktestEngine) newSnapshot(candle types.OHLCV) DecisionSnapshot {
+	snapshot := DecisionSnapshot{
+		Time:  candle.Timestamp,
+		Price: candle.Close,
+	}
+
+	if explainer, ok := b.strategy.(strategy.DecisionExplainer); ok {
+		ctx := explainer.GetDecisionContext()
+		snapshot.DCALevel = ctx.DCALevel
+		snapshot.LastEntryPrice = ctx.LastEntryPrice
+		snapshot.PriceDrop = ctx.PriceDrop
+		snapshot.SpacingThreshold = ctx.Threshold
+		snapshot.SpacingStrategy = ctx.SpacingStrategy
+	}
+
+	if reporter, ok := b.strategy.(strategy.IndicatorReporter); ok {
+		results := reporter.GetLastResults()
+		names := make([]string, 0, len(results))
+		for name := range results {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		// Copy the values - results may be shared with other backtests
+		snapshot.Indicators = make([]IndicatorSnapshot, 0, len(names))
+		for _, name := range names {
+			result := results[name]
+			indicator := IndicatorSnapshot{
+				Name:     name,
+				Value:    result.Value,
+				Buy:      result.ShouldBuy,
+				Sell:     result.ShouldSell,
+				Strength: result.Strength,
+			}
+			if result.Error != nil {
+				indicator.Error = result.Error.Error()
+			}
+			if math.IsNaN(indicator.Value) || math.IsInf(indicator.Value, 0) {
+				indicator.Value = 0 // Not representable in JSON
+			}
+			snapshot.Indicators = append(snapshot.Indicators, indicator)
+		}
+	}
+
+	return snapshot
+}
+
+// markFilled links a snapshot to the entry just opened
+func (b *BacktestEngine) markFilled(snapshot *DecisionSnapshot) {
+	trade := b.results.Trades[len(b.results.Trades)-1]
+	snapshot.Filled = true
+	snapshot.FillPrice = trade.EntryPrice
+	snapshot.Cycle = trade.Cycle
+}
@@ -0,0 +1,47 @@
+package backtest
+
+import (
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
+)
+
+// recordDecayedExit compares a sell of qty at a time-decayed take profit with
+// the full take profit. levelMultiplier scales both to the TP level sold (1
+// for a single TP). The sell counts as decayed when its TP was below the full
+// TP; the capital it freed is credited with the days until the full target
+// would have been touched (or the data ended), and the full target's extra
+// profit is counted as lost when it would have been reached.
+func (b *BacktestEngine) recordDecayedExit(data []types.OHLCV, index int, qty, avgEntry, levelMultiplier, tpPercent float64) {
+	if b.cycleAgeTracker == nil || qty <= 0 || avgEntry <= 0 {
+		return
+	}
+
+	fullPercent := b.cycleAgeTracker.GetFullTPPercent()
+	if tpPercent >= fullPercent-1e-9 {
+		return
+	}
+
+	exitPrice := avgEntry * (1 + tpPercent*levelMultiplier)
+	fullPrice := avgEntry * (1 + fullPercent*levelMultiplier)
+	exitTime := data[index].Timestamp
+
+	b.results.DecayedExits++
+
+	// The full TP would have filled on the first later candle reaching it
+	fillTime := data[len(data)-1].Timestamp
+	filled := false
+	for j := index + 1; j < len(data); j++ {
+		if data[j].High >= fullPrice {
+			fillTime = data[j].Timestamp
+			filled = true
+			break
+		}
+	}
+
+	days := fillTime.Sub(exitTime).Hours() / 24
+	b.results.CapitalDaysSaved += qty * avgEntry * days
+	if filled {
+		b.results.DecayLostProfit += qty * (fullPrice - exitPrice) * (1 - b.commission)
+	} else {
+		b.results.DecayedExitsUnfilled++
+	}
+}
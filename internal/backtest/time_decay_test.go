@@ -0,0 +1,173 @@
+package backtest
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/strategy"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/config"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
+)
+
+// fullTP reports a fixed full take profit for the decayed exit accounting
+type fullTP float64
+
+func (f fullTP) IsTimeDecayTPEnabled() bool  { return true }
+func (f fullTP) GetFullTPPercent() float64   { return float64(f) }
+func (f fullTP) SetCycleStartTime(time.Time) {}
+
+// dailyHighs returns one daily candle per high from 2024-01-01
+func dailyHighs(highs ...float64) []types.OHLCV {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	candles := make([]types.OHLCV, len(highs))
+	for i, h := range highs {
+		candles[i] = types.OHLCV{Timestamp: start.AddDate(0, 0, i), Open: 100, High: h, Low: 99, Close: 100, Volume: 1}
+	}
+	return candles
+}
+
+func TestRecordDecayedExit(t *testing.T) {
+	tests := []struct {
+		name            string
+		highs           []float64
+		levelMultiplier float64
+		tpPercent       float64
+		wantExits       int
+		wantUnfilled    int
+		wantCapitalDays float64
+		wantLostProfit  float64
+	}{
+		{
+			// Sold 2 at 101 on day 1, the full TP of 102 fills on day 3
+			name: "full TP reached", highs: []float64{100, 101, 101.5, 102.5, 101, 101},
+			levelMultiplier: 1, tpPercent: 0.01,
+			wantExits: 1, wantCapitalDays: 2 * 100 * 2, wantLostProfit: 2 * 1 * 0.999,
+		},
+		{
+			name: "full TP touched exactly", highs: []float64{100, 101, 102},
+			levelMultiplier: 1, tpPercent: 0.01,
+			wantExits: 1, wantCapitalDays: 2 * 100 * 1, wantLostProfit: 2 * 1 * 0.999,
+		},
+		{
+			// Never reaches 102: credited until the data ends on day 5
+			name: "full TP not reached", highs: []float64{100, 101, 101.5, 101.9, 101, 101},
+			levelMultiplier: 1, tpPercent: 0.01,
+			wantExits: 1, wantUnfilled: 1, wantCapitalDays: 2 * 100 * 4,
+		},
+		{
+			// Level 2 of a multi-level TP: sold at 102, full target 104
+			name: "scaled TP level", highs: []float64{100, 102, 103, 104},
+			levelMultiplier: 2, tpPercent: 0.01,
+			wantExits: 1, wantCapitalDays: 2 * 100 * 2, wantLostProfit: 2 * 2 * 0.999,
+		},
+		{
+			name: "sold at the full TP", highs: []float64{100, 102, 103},
+			levelMultiplier: 1, tpPercent: 0.02,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := NewBacktestEngine(1000, 0.001, fourHourProbe(), 0.02, 0, false)
+			engine.cycleAgeTracker = fullTP(0.02)
+
+			engine.recordDecayedExit(dailyHighs(tt.highs...), 1, 2, 100, tt.levelMultiplier, tt.tpPercent)
+
+			r := engine.results
+			if r.DecayedExits != tt.wantExits || r.DecayedExitsUnfilled != tt.wantUnfilled {
+				t.Errorf("expected %d decayed exits (%d unfilled), got %d (%d)",
+					tt.wantExits, tt.wantUnfilled, r.DecayedExits, r.DecayedExitsUnfilled)
+			}
+			if math.Abs(r.CapitalDaysSaved-tt.wantCapitalDays) > 1e-9 {
+				t.Errorf("expected %.4f capital-days saved, got %.4f", tt.wantCapitalDays, r.CapitalDaysSaved)
+			}
+			if math.Abs(r.DecayLostProfit-tt.wantLostProfit) > 1e-9 {
+				t.Errorf("expected %.4f lost profit, got %.4f", tt.wantLostProfit, r.DecayLostProfit)
+			}
+		})
+	}
+}
+
+func TestRecordDecayedExitWithoutTimeDecay(t *testing.T) {
+	engine := NewBacktestEngine(1000, 0.001, fourHourProbe(), 0.02, 0, false)
+	if engine.cycleAgeTracker != nil {
+		t.Fatal("expected no cycle age tracker for a strategy without time decay")
+	}
+	engine.recordDecayedExit(dailyHighs(100, 101, 103), 1, 2, 100, 1, 0.01)
+	if engine.results.DecayedExits != 0 || engine.results.CapitalDaysSaved != 0 {
+		t.Errorf("expected no decay accounting, got %+v", engine.results)
+	}
+}
+
+// decayingEntry is an EnhancedDCAStrategy with a time-decay TP that buys on
+// its first decision and holds afterwards, so the exit depends only on the
+// decay schedule
+type decayingEntry struct {
+	*strategy.EnhancedDCAStrategy
+	bought bool
+}
+
+func (d *decayingEntry) ShouldExecuteTrade(data []types.OHLCV) (*strategy.TradeDecision, error) {
+	last := data[len(data)-1]
+	if d.bought {
+		return &strategy.TradeDecision{Action: strategy.ActionHold, Timestamp: last.Timestamp}, nil
+	}
+	d.bought = true
+	d.SetCycleStartTime(last.Timestamp)
+	return &strategy.TradeDecision{Action: strategy.ActionBuy, Amount: 100, Timestamp: last.Timestamp}, nil
+}
+
+func TestEngineExitsAtTheDecayedTP(t *testing.T) {
+	dca := strategy.NewEnhancedDCAStrategy(100)
+	dca.SetCommission(0.001)
+	dca.SetDynamicTPConfig(&config.DynamicTPConfig{
+		Strategy:      "time_decay",
+		BaseTPPercent: 0.02,
+		TimeDecayConfig: &config.DynamicTPTimeDecayConfig{
+			GraceDays:    1,
+			DecayDays:    2,
+			FloorPercent: 0.004,
+		},
+	})
+
+	// Entry at 100 on candle 1, then highs of 100.95 that only the decayed TP
+	// reaches, until the price rallies through the full 2% TP on candle 70
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	data := make([]types.OHLCV, 80)
+	for i := range data {
+		high := 100.95
+		switch {
+		case i <= 1:
+			high = 100.5
+		case i >= 70:
+			high = 102.5
+		}
+		data[i] = types.OHLCV{Timestamp: start.Add(time.Duration(i) * time.Hour), Open: 100, High: high, Low: 99.5, Close: 100, Volume: 1}
+	}
+
+	engine := NewBacktestEngine(1000, 0, &decayingEntry{EnhancedDCAStrategy: dca}, 0.02, 0, false)
+	results := engine.Run(data, 1)
+
+	// The TP falls 0.8% a day after the first day: 56h into the cycle it is
+	// 2% - 0.8% x 4/3 = 0.9333%, the first below the 0.95% the highs reach
+	exitIndex := 1 + 56
+	wantTP := 0.02 - 0.008*(56.0/24-1)
+	if len(results.Trades) != 1 || !results.Trades[0].ExitTime.Equal(data[exitIndex].Timestamp) {
+		t.Fatalf("expected one trade exiting on candle %d, got %+v", exitIndex, results.Trades)
+	}
+	if got, want := results.Trades[0].ExitPrice, 100*(1+wantTP); math.Abs(got-want) > 1e-9 {
+		t.Errorf("expected the exit at the decayed TP %.4f, got %.4f", want, got)
+	}
+
+	// The full TP of 102 would have filled on candle 70, 13 hours later
+	if results.DecayedExits != 1 || results.DecayedExitsUnfilled != 0 {
+		t.Errorf("expected one decayed exit whose full TP filled, got %d (%d unfilled)", results.DecayedExits, results.DecayedExitsUnfilled)
+	}
+	if want := 100 * 13.0 / 24; math.Abs(results.CapitalDaysSaved-want) > 1e-9 {
+		t.Errorf("expected %.4f capital-days saved, got %.4f", want, results.CapitalDaysSaved)
+	}
+	if want := 102 - 100*(1+wantTP); math.Abs(results.DecayLostProfit-want) > 1e-9 {
+		t.Errorf("expected %.4f lost profit, got %.4f", want, results.DecayLostProfit)
+	}
+}
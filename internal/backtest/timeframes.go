@@ -0,0 +1,101 @@
+package backtest
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/strategy"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/config"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/data"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
+)
+
+// timeframeFeed passes one higher timeframe to a MultiTimeframeStrategy
+type timeframeFeed struct {
+	req     strategy.TimeframeRequirement
+	candles []types.OHLCV // Sorted by open time
+	closed  int           // Candles closed by the last decision
+}
+
+// SetTimeframeData sets loaded candles for a higher timeframe; timeframes
+// without loaded candles are resampled from the backtest data
+func (b *BacktestEngine) SetTimeframeData(interval string, candles []types.OHLCV) {
+	if b.timeframeData == nil {
+		b.timeframeData = make(map[string][]types.OHLCV)
+	}
+	sorted := append([]types.OHLCV(nil), candles...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Timestamp.Before(sorted[j].Timestamp) })
+	b.timeframeData[interval] = sorted
+}
+
+// LoadTimeframeData loads the candles of the timeframes that name a data file
+func (b *BacktestEngine) LoadTimeframeData(timeframes config.Timeframes) error {
+	for _, tf := range timeframes {
+		if tf.DataFile == "" {
+			continue
+		}
+		candles, err := data.LoadHistoricalDataCached(tf.DataFile)
+		if err != nil {
+			return fmt.Errorf("failed to load %s timeframe data from '%s': %w", tf.Interval, tf.DataFile, err)
+		}
+		b.SetTimeframeData(tf.Interval, candles)
+	}
+	return nil
+}
+
+// PrepareTimeframes builds the candle feeds of the strategy's higher
+// timeframes for data. Run prepares them itself; calling it first surfaces
+// errors such as a timeframe that is not a multiple of the data interval.
+func (b *BacktestEngine) PrepareTimeframes(candles []types.OHLCV) error {
+	b.timeframeFeeds = nil
+	if b.mtfStrategy == nil {
+		return nil
+	}
+
+	b.baseInterval = data.InferInterval(candles)
+	var feeds []*timeframeFeed
+	for _, req := range b.mtfStrategy.RequiredTimeframes() {
+		if req.Duration <= b.baseInterval {
+			return fmt.Errorf("timeframe %s is not higher than the data interval %v", req.Interval, b.baseInterval)
+		}
+		htf, ok := b.timeframeData[req.Interval]
+		if !ok {
+			var err error
+			if htf, err = data.Resample(candles, req.Duration); err != nil {
+				return fmt.Errorf("timeframe %s: %w", req.Interval, err)
+			}
+		}
+		feeds = append(feeds, &timeframeFeed{req: req, candles: htf})
+	}
+	b.timeframeFeeds = feeds
+	return nil
+}
+
+// startTimeframes prepares the feeds at the start of Run. On error the
+// strategy gets no higher timeframe candles, which blocks its entries.
+func (b *BacktestEngine) startTimeframes(candles []types.OHLCV) {
+	if err := b.PrepareTimeframes(candles); err != nil {
+		log.Printf("⚠️ Higher timeframes unavailable, entries blocked: %v", err)
+	}
+}
+
+// feedTimeframes passes the strategy the candles of each higher timeframe that
+// closed by at, the close time of the candle being decided on
+func (b *BacktestEngine) feedTimeframes(at time.Time, index int) {
+	for _, f := range b.timeframeFeeds {
+		for f.closed < len(f.candles) && !f.candles[f.closed].Timestamp.Add(f.req.Duration).After(at) {
+			f.closed++
+		}
+		start := f.closed - f.req.Window
+		if start < 0 {
+			start = 0
+		}
+		window := f.candles[start:f.closed]
+		b.mtfStrategy.SetTimeframeCandles(f.req.Interval, window)
+		if b.timeframeObserver != nil {
+			b.timeframeObserver(index, at, f.req, window)
+		}
+	}
+}
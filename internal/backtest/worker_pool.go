@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ducminhle1904/crypto-dca-bot/internal/risk"
 	"github.com/ducminhle1904/crypto-dca-bot/internal/strategy"
 	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
 )
@@ -48,6 +49,8 @@ type BacktestConfig struct {
 	UseTPLevels    bool
 	Symbol         string
 	Interval       string
+	RiskLimits     risk.Limits
+	GridCommission float64 // Commission on limit-grid fills (0 = Commission)
 }
 
 // NewWorkerPool creates a new worker pool for parallel backtesting
@@ -141,6 +144,8 @@ func (wp *WorkerPool) processJob(job BacktestJob) BacktestResult {
 		job.Config.MinOrderQty,
 		job.Config.UseTPLevels,
 	)
+	engine.SetRiskLimits(job.Config.RiskLimits)
+	engine.SetGridCommission(job.Config.GridCommission)
 
 	// Run backtest
 	backtestResults := engine.Run(job.Data, job.Config.WindowSize)
@@ -0,0 +1,278 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/exchange"
+)
+
+// Client order IDs make order placement idempotent. Every order the bot sends
+// carries an ID of the form
+//
+//	dca-<symbol>-<cycle>-<kind><level>[r<revision>]-<attempt>
+//
+// e.g. dca-BTCUSDT-t1x2k0-b3-1 for the third DCA buy of a cycle, or
+// dca-BTCUSDT-t1x2k0-t2r4-1 for TP level 2 of the fourth TP placement in that
+// cycle, or dca-BTCUSDT-t1x2k0-g4r2-1 for the fourth buy of the cycle resting
+// as a limit-grid order in the second grid placement. Retries of the same logical order keep the base ID and only bump the
+// attempt, so the bot can ask the exchange whether an earlier attempt already
+// went through before sending another one. With the symbol capped at 12
+// characters the ID stays within Bybit's 36-character orderLinkId limit.
+
+const (
+	clientOrderIDPrefix        = "dca"
+	maxClientOrderSymbolLength = 12
+)
+
+// Order kinds encoded in client order IDs
+const (
+	orderKindDCA        = "b" // Market buy, level = DCA level
+	orderKindTP         = "t" // TP limit sell, level = TP level
+	orderKindFallbackTP = "f" // TP limit sell for leftover quantity
+	orderKindExit       = "x" // Market sell closing the position
+	orderKindGrid       = "g" // Resting limit buy of the DCA grid, level = DCA buy number
+)
+
+// clientOrderRef is the decoded form of a client order ID
+type clientOrderRef struct {
+	Symbol   string
+	Cycle    string
+	Kind     string
+	Level    int
+	Revision int // TP or grid placement round within the cycle, 0 for other orders
+	Attempt  int
+}
+
+// isTP reports whether the ID belongs to one of the bot's take profit orders
+func (r clientOrderRef) isTP() bool {
+	return r.Kind == orderKindTP || r.Kind == orderKindFallbackTP
+}
+
+// clientOrderSymbol reduces a symbol to the characters allowed in an order ID
+func clientOrderSymbol(symbol string) string {
+	var b strings.Builder
+	for _, c := range strings.ToUpper(symbol) {
+		if (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') {
+			b.WriteRune(c)
+		}
+	}
+	s := b.String()
+	if len(s) > maxClientOrderSymbolLength {
+		s = s[:maxClientOrderSymbolLength]
+	}
+	return s
+}
+
+// newCycleToken derives a short cycle identifier from the cycle start time
+func newCycleToken(t time.Time) string {
+	return strconv.FormatInt(t.Unix(), 36)
+}
+
+// buildClientOrderBase returns the attempt-independent part of a client order ID
+func buildClientOrderBase(symbol, cycle, kind string, level, revision int) string {
+	tag := kind + strconv.Itoa(level)
+	if revision > 0 {
+		tag += "r" + strconv.Itoa(revision)
+	}
+	return strings.Join([]string{clientOrderIDPrefix, clientOrderSymbol(symbol), cycle, tag}, "-")
+}
+
+// clientOrderAttemptID appends the attempt number to a base ID
+func clientOrderAttemptID(base string, attempt int) string {
+	return base + "-" + strconv.Itoa(attempt)
+}
+
+// parseClientOrderID decodes an ID built by this bot. ok is false for IDs
+// that were not produced by buildClientOrderBase/clientOrderAttemptID.
+func parseClientOrderID(id string) (ref clientOrderRef, ok bool) {
+	parts := strings.Split(id, "-")
+	if len(parts) != 5 || parts[0] != clientOrderIDPrefix || parts[1] == "" || parts[2] == "" || len(parts[3]) < 2 {
+		return clientOrderRef{}, false
+	}
+
+	attempt, err := strconv.Atoi(parts[4])
+	if err != nil || attempt < 1 {
+		return clientOrderRef{}, false
+	}
+
+	tag := parts[3]
+	kind := tag[:1]
+	switch kind {
+	case orderKindDCA, orderKindTP, orderKindFallbackTP, orderKindExit, orderKindGrid:
+	default:
+		return clientOrderRef{}, false
+	}
+
+	levelPart, revisionPart, hasRevision := strings.Cut(tag[1:], "r")
+	level, err := strconv.Atoi(levelPart)
+	if err != nil {
+		return clientOrderRef{}, false
+	}
+	revision := 0
+	if hasRevision {
+		if revision, err = strconv.Atoi(revisionPart); err != nil {
+			return clientOrderRef{}, false
+		}
+	}
+
+	return clientOrderRef{
+		Symbol:   parts[1],
+		Cycle:    parts[2],
+		Kind:     kind,
+		Level:    level,
+		Revision: revision,
+		Attempt:  attempt,
+	}, true
+}
+
+// clientOrderBase returns the base ID for an order of the given kind in the
+// current cycle, starting a new cycle token if none is active
+func (bot *LiveBot) clientOrderBase(kind string, level int) string {
+	bot.orderIDMutex.Lock()
+	defer bot.orderIDMutex.Unlock()
+
+	if bot.cycleToken == "" {
+		bot.cycleToken = newCycleToken(time.Now())
+		bot.logger.SetCycle(bot.cycleToken)
+	}
+	revision := 0
+	switch kind {
+	case orderKindTP, orderKindFallbackTP:
+		revision = bot.tpRevision
+	case orderKindGrid:
+		revision = bot.gridRevision
+	}
+	return buildClientOrderBase(bot.symbol, bot.cycleToken, kind, level, revision)
+}
+
+// beginTPRevision starts a new TP placement round so re-placed TP orders get
+// fresh IDs (exchanges reject a reused client ID)
+func (bot *LiveBot) beginTPRevision() {
+	bot.orderIDMutex.Lock()
+	bot.tpRevision++
+	bot.orderIDMutex.Unlock()
+}
+
+// beginGridRevision starts a new grid placement round so re-anchored grid
+// orders get fresh IDs
+func (bot *LiveBot) beginGridRevision() {
+	bot.orderIDMutex.Lock()
+	bot.gridRevision++
+	bot.orderIDMutex.Unlock()
+}
+
+// cycleStartTime returns when the current cycle started, decoded from its
+// token (zero when no cycle is active)
+func (bot *LiveBot) cycleStartTime() time.Time {
+	bot.orderIDMutex.Lock()
+	token := bot.cycleToken
+	bot.orderIDMutex.Unlock()
+
+	if token == "" {
+		return time.Time{}
+	}
+	seconds, err := strconv.ParseInt(token, 36, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(seconds, 0)
+}
+
+// resetOrderCycle forgets the cycle token once a cycle completes
+func (bot *LiveBot) resetOrderCycle() {
+	bot.orderIDMutex.Lock()
+	defer bot.orderIDMutex.Unlock()
+
+	bot.cycleToken = ""
+	bot.logger.SetCycle("")
+	bot.tpRevision = 0
+	bot.gridRevision = 0
+	for k := range bot.orderAttempts {
+		delete(bot.orderAttempts, k)
+	}
+}
+
+// nextClientOrderAttempt returns the next attempt number for a base ID. Attempts
+// keep counting across calls so a later placement still checks earlier ones.
+func (bot *LiveBot) nextClientOrderAttempt(base string) int {
+	bot.orderIDMutex.Lock()
+	defer bot.orderIDMutex.Unlock()
+
+	bot.orderAttempts[base]++
+	return bot.orderAttempts[base]
+}
+
+// nextClientOrderID returns a complete ID for an order placed once, outside
+// placeOrderWithRetry
+func (bot *LiveBot) nextClientOrderID(kind string, level int) string {
+	base := bot.clientOrderBase(kind, level)
+	return clientOrderAttemptID(base, bot.nextClientOrderAttempt(base))
+}
+
+// findPlacedOrder asks the exchange whether any attempt up to lastAttempt of
+// base was accepted. Returns nil, nil if none was or the exchange cannot look
+// orders up by client ID.
+func (bot *LiveBot) findPlacedOrder(ctx context.Context, category, symbol, base string, lastAttempt int) (*exchange.Order, error) {
+	lookup, ok := bot.exchange.(exchange.OrderLookupExchange)
+	if !ok {
+		return nil, nil
+	}
+
+	for attempt := lastAttempt; attempt >= 1; attempt-- {
+		clientOrderID := clientOrderAttemptID(base, attempt)
+		order, err := lookup.GetOrderByClientID(ctx, category, symbol, clientOrderID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up order %s: %w", clientOrderID, err)
+		}
+		if order != nil && isLiveOrExecuted(order) {
+			return order, nil
+		}
+	}
+	return nil, nil
+}
+
+// isLiveOrExecuted reports whether an order found by client ID is resting on
+// the book or has executed, i.e. placing it again would duplicate it
+func isLiveOrExecuted(order *exchange.Order) bool {
+	if qty, err := parseFloat(order.CumExecQty); err == nil && qty > 0 {
+		return true
+	}
+	switch order.OrderStatus {
+	case "New", "PartiallyFilled", "Filled", "Untriggered", "Created":
+		return true
+	}
+	return false
+}
+
+// adoptClientOrderIDs restores the cycle token and TP/grid revisions from the bot's
+// own open orders so IDs stay consistent across a restart mid-cycle
+func (bot *LiveBot) adoptClientOrderIDs(orders []*exchange.Order) {
+	symbol := clientOrderSymbol(bot.symbol)
+
+	bot.orderIDMutex.Lock()
+	defer bot.orderIDMutex.Unlock()
+
+	for _, order := range orders {
+		ref, ok := parseClientOrderID(order.ClientOrderID)
+		if !ok || ref.Symbol != symbol {
+			continue
+		}
+		if bot.cycleToken == "" {
+			bot.cycleToken = ref.Cycle
+			bot.logger.SetCycle(bot.cycleToken)
+		}
+		if ref.Cycle != bot.cycleToken {
+			continue
+		}
+		if ref.isTP() && ref.Revision > bot.tpRevision {
+			bot.tpRevision = ref.Revision
+		}
+		if ref.Kind == orderKindGrid && ref.Revision > bot.gridRevision {
+			bot.gridRevision = ref.Revision
+		}
+	}
+}
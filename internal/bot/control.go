@@ -0,0 +1,264 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/exchange"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/risk"
+)
+
+// controlCommandTimeout bounds how long a control command may wait for and run
+// on the trading loop (TP placement alone can take up to 90s)
+const controlCommandTimeout = 2 * time.Minute
+
+// controlRequest is a control command executed on the trading loop goroutine,
+// so it never races with checkAndTrade
+type controlRequest struct {
+	fn   func() error
+	done chan error
+}
+
+// BotState is a point-in-time snapshot of the bot for the control API
+type BotState struct {
+	Symbol          string                     `json:"symbol"`
+	Interval        string                     `json:"interval"`
+	Category        string                     `json:"category"`
+	Exchange        string                     `json:"exchange"`
+	Demo            bool                       `json:"demo"`
+	Running         bool                       `json:"running"`
+	EntriesPaused   bool                       `json:"entries_paused"`
+	DCALevel        int                        `json:"dca_level"`
+	Position        float64                    `json:"position"`
+	AveragePrice    float64                    `json:"average_price"`
+	TotalInvested   float64                    `json:"total_invested"`
+	Balance         float64                    `json:"balance"`
+	Cycle           string                     `json:"cycle,omitempty"` // Cycle token used in client order IDs
+	ActiveTPOrders  []TPOrderInfo              `json:"active_tp_orders"`
+	FilledTPOrders  []TPOrderInfo              `json:"filled_tp_orders"`
+	GridOrders      []GridOrderInfo            `json:"grid_orders,omitempty"` // Resting limit-grid DCA buys
+	CircuitBreakers []CircuitBreakerState      `json:"circuit_breakers"`
+	RateLimits      []exchange.RateLimitStatus `json:"rate_limits,omitempty"` // Latest exchange-reported quota per endpoint
+	Risk            *risk.Status               `json:"risk,omitempty"`        // Risk guard state, nil when no limits are set
+	Timestamp       time.Time                  `json:"timestamp"`
+}
+
+// CircuitBreakerState is the control API view of one circuit breaker
+type CircuitBreakerState struct {
+	Name        string    `json:"name"`
+	State       string    `json:"state"`
+	Failures    uint32    `json:"failures"`
+	LastFailure time.Time `json:"last_failure"`
+	NextAttempt time.Time `json:"next_attempt"`
+}
+
+// State returns a snapshot of the bot's trading state
+func (bot *LiveBot) State() BotState {
+	state := BotState{
+		Symbol:        bot.symbol,
+		Interval:      bot.interval,
+		Category:      bot.category,
+		Exchange:      bot.exchange.GetName(),
+		Demo:          bot.exchange.IsDemo(),
+		Running:       bot.running,
+		EntriesPaused: bot.EntriesPaused(),
+		Timestamp:     time.Now(),
+	}
+
+	bot.positionMutex.RLock()
+	state.DCALevel = bot.dcaLevel
+	state.Position = bot.currentPosition
+	state.AveragePrice = bot.averagePrice
+	state.TotalInvested = bot.totalInvested
+	state.Balance = bot.balance
+	bot.positionMutex.RUnlock()
+
+	bot.orderIDMutex.Lock()
+	state.Cycle = bot.cycleToken
+	bot.orderIDMutex.Unlock()
+
+	bot.tpOrderMutex.RLock()
+	state.ActiveTPOrders = sortedTPOrders(bot.activeTPOrders)
+	state.FilledTPOrders = sortedTPOrders(bot.filledTPOrders)
+	bot.tpOrderMutex.RUnlock()
+
+	state.GridOrders = bot.sortedGridOrders()
+
+	for _, stats := range bot.circuitBreakers.GetStats() {
+		state.CircuitBreakers = append(state.CircuitBreakers, CircuitBreakerState{
+			Name:        stats.Name,
+			State:       stats.State.String(),
+			Failures:    stats.Failures,
+			LastFailure: stats.LastFailure,
+			NextAttempt: stats.NextAttempt,
+		})
+	}
+	sort.Slice(state.CircuitBreakers, func(i, j int) bool {
+		return state.CircuitBreakers[i].Name < state.CircuitBreakers[j].Name
+	})
+
+	state.RateLimits = bot.rateLimitStatuses()
+
+	if riskStatus := bot.riskGuard.Status(); riskStatus.Limits.Enabled() {
+		state.Risk = &riskStatus
+	}
+
+	return state
+}
+
+// sortedTPOrders copies TP order infos ordered by level
+func sortedTPOrders(orders map[string]*TPOrderInfo) []TPOrderInfo {
+	result := make([]TPOrderInfo, 0, len(orders))
+	for _, info := range orders {
+		result = append(result, *info)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Level != result[j].Level {
+			return result[i].Level < result[j].Level
+		}
+		return result[i].OrderID < result[j].OrderID
+	})
+	return result
+}
+
+// EntriesPaused reports whether new DCA entries are paused, either via the
+// control API or by a halting risk limit breach
+func (bot *LiveBot) EntriesPaused() bool {
+	return bot.entriesPaused.Load() || bot.riskGuard.Halted()
+}
+
+// PauseEntries stops new DCA entries. TP orders and exits keep being managed.
+func (bot *LiveBot) PauseEntries() {
+	if !bot.entriesPaused.Swap(true) {
+		bot.logger.Info("⏸️ DCA entries paused - TP management continues")
+	}
+}
+
+// ResumeEntries allows new DCA entries again. A risk guard halt is cleared
+// too, re-basing the drawdown peak to the current equity.
+func (bot *LiveBot) ResumeEntries() {
+	halted := bot.riskGuard.Halted()
+	if halted {
+		bot.riskGuard.Resume()
+		bot.updateRiskHealth()
+		bot.logger.Info("🛡️ Risk guard halt cleared by operator")
+	}
+	if bot.entriesPaused.Swap(false) || halted {
+		bot.logger.Info("▶️ DCA entries resumed")
+	}
+}
+
+// ForceClose cancels the bot's TP and grid orders and market-closes the position.
+// Entries are paused afterwards so the strategy does not immediately re-enter.
+func (bot *LiveBot) ForceClose() error {
+	return bot.runOnTradingLoop(func() error {
+		bot.logger.Info("🚨 Force close requested - cancelling TP orders and closing position")
+
+		if err := bot.cancelGridOrders(); err != nil {
+			bot.logger.LogWarning("Force Close", "Error canceling grid orders: %v", err)
+		}
+		if err := bot.cancelAllTPOrders(); err != nil {
+			bot.logger.LogWarning("Force Close", "Error canceling TP orders: %v", err)
+		}
+		if err := bot.closeOpenPositions(); err != nil {
+			return fmt.Errorf("failed to close position: %w", err)
+		}
+
+		bot.PauseEntries()
+		return nil
+	})
+}
+
+// RefreshTPOrders cancels the bot's TP orders and places them again from the
+// current exchange position
+func (bot *LiveBot) RefreshTPOrders() error {
+	return bot.runOnTradingLoop(func() error {
+		size, avgPrice, err := bot.getExchangePosition()
+		if err != nil {
+			return err
+		}
+		if size == "" || avgPrice <= 0 {
+			return fmt.Errorf("no open position to place TP orders for")
+		}
+
+		if err := bot.cancelAllTPOrders(); err != nil {
+			bot.logger.LogWarning("TP Refresh", "Error canceling TP orders: %v", err)
+		}
+
+		bot.logger.Info("🔄 Re-placing TP orders - Position Size: %s, Avg Price: $%.4f", size, avgPrice)
+		return bot.placeMultiLevelTPOrders(size, avgPrice)
+	})
+}
+
+// CheckNow runs checkAndTrade immediately instead of waiting for the next candle
+func (bot *LiveBot) CheckNow() error {
+	return bot.runOnTradingLoop(func() error {
+		bot.checkAndTrade()
+		return nil
+	})
+}
+
+// runOnTradingLoop hands fn to the trading loop and waits for its result
+func (bot *LiveBot) runOnTradingLoop(fn func() error) error {
+	if !bot.running {
+		return fmt.Errorf("bot is not running")
+	}
+
+	req := controlRequest{fn: fn, done: make(chan error, 1)}
+	timeout := time.NewTimer(controlCommandTimeout)
+	defer timeout.Stop()
+
+	select {
+	case bot.controlChan <- req:
+	case <-bot.stopChan:
+		return fmt.Errorf("bot is stopping")
+	case <-timeout.C:
+		return fmt.Errorf("trading loop busy, command not started within %v", controlCommandTimeout)
+	}
+
+	select {
+	case err := <-req.done:
+		return err
+	case <-timeout.C:
+		return fmt.Errorf("command still running after %v, check the log for its outcome", controlCommandTimeout)
+	}
+}
+
+// handleControlRequest runs a control command on the trading loop goroutine
+func (bot *LiveBot) handleControlRequest(req controlRequest) {
+	var err error
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("control command panicked: %v", r)
+				bot.logger.Error("Error in control command: %v", r)
+			}
+		}()
+		err = req.fn()
+	}()
+	req.done <- err
+}
+
+// getExchangePosition returns size and average price of the bot's long position
+// on the exchange, or an empty size if there is none
+func (bot *LiveBot) getExchangePosition() (string, float64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	positions, err := bot.protectedGetPositions(ctx, bot.category, bot.symbol)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to get position: %w", err)
+	}
+	for _, pos := range positions {
+		if pos.Symbol == bot.symbol && pos.Side == "Buy" {
+			size, sizeErr := parseFloat(pos.Size)
+			avgPrice, priceErr := parseFloat(pos.AvgPrice)
+			if sizeErr == nil && priceErr == nil && size > 0 {
+				return pos.Size, avgPrice, nil
+			}
+		}
+	}
+	return "", 0, nil
+}
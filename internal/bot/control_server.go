@@ -0,0 +1,291 @@
+package bot
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// Control API endpoints served by ControlServer
+const (
+	ControlPathState     = "/state"
+	ControlPathPause     = "/pause"
+	ControlPathResume    = "/resume"
+	ControlPathClose     = "/close"
+	ControlPathRefreshTP = "/tp/refresh"
+	ControlPathCheck     = "/check"
+	ControlPathReload    = "/reload"
+	ControlPathHealth    = "/health"
+)
+
+// ControlTokenEnv is the environment variable holding the shared token that
+// control requests send as "Authorization: Bearer <token>"
+const ControlTokenEnv = "DCA_BOT_CONTROL_TOKEN"
+
+// ControlResponse is the JSON body returned by every control endpoint
+type ControlResponse struct {
+	OK      bool      `json:"ok"`
+	Command string    `json:"command"`
+	Message string    `json:"message,omitempty"`
+	Error   string    `json:"error,omitempty"`
+	State   *BotState `json:"state,omitempty"`
+}
+
+// ControlServer exposes runtime control of a LiveBot over HTTP on a loopback
+// address or a Unix socket. Every command is written to the bot's trading log.
+// Requests from browsers are refused: a request must name a loopback Host,
+// carry no Origin header and, when a token is set, present the token.
+type ControlServer struct {
+	bot      *LiveBot
+	network  string
+	address  string
+	token    string
+	listener net.Listener
+	server   *http.Server
+}
+
+// ParseControlAddress splits a control address into network and address.
+// "unix:/path/to.sock" selects a Unix socket; anything else must be a
+// host:port on the loopback interface (e.g. 127.0.0.1:7070 or localhost:7070).
+func ParseControlAddress(addr string) (network, address string, err error) {
+	addr = strings.TrimSpace(addr)
+	if addr == "" {
+		return "", "", fmt.Errorf("control address is empty")
+	}
+
+	if path, ok := strings.CutPrefix(addr, "unix:"); ok {
+		if path == "" {
+			return "", "", fmt.Errorf("unix socket path is empty")
+		}
+		return "unix", path, nil
+	}
+
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid control address %q: %w", addr, err)
+	}
+	if host != "localhost" {
+		ip := net.ParseIP(host)
+		if ip == nil || !ip.IsLoopback() {
+			return "", "", fmt.Errorf("control address %q must be on localhost or a unix: socket", addr)
+		}
+	}
+	return "tcp", addr, nil
+}
+
+// NewControlServer creates a control server for bot listening on addr.
+// Commands must present token; it is required on TCP addresses, while a Unix
+// socket is only reachable by its owner and may leave it empty.
+func NewControlServer(bot *LiveBot, addr, token string) (*ControlServer, error) {
+	if bot == nil {
+		return nil, fmt.Errorf("bot is required")
+	}
+	network, address, err := ParseControlAddress(addr)
+	if err != nil {
+		return nil, err
+	}
+	if network == "tcp" && token == "" {
+		return nil, fmt.Errorf("control address %s requires a token: set %s or use a unix: socket", addr, ControlTokenEnv)
+	}
+
+	s := &ControlServer{bot: bot, network: network, address: address, token: token}
+
+	mux := http.NewServeMux()
+	mux.Handle(ControlPathState, s.handle(http.MethodGet, "state", func() (string, error) {
+		return "", nil
+	}))
+	mux.Handle(ControlPathPause, s.handle(http.MethodPost, "pause", func() (string, error) {
+		bot.PauseEntries()
+		return "DCA entries paused, TP orders still managed", nil
+	}))
+	mux.Handle(ControlPathResume, s.handle(http.MethodPost, "resume", func() (string, error) {
+		bot.ResumeEntries()
+		return "DCA entries resumed", nil
+	}))
+	mux.Handle(ControlPathClose, s.handle(http.MethodPost, "close", func() (string, error) {
+		if err := bot.ForceClose(); err != nil {
+			return "", err
+		}
+		return "Position closed, TP orders cancelled, entries paused (resume to trade again)", nil
+	}))
+	mux.Handle(ControlPathRefreshTP, s.handle(http.MethodPost, "tp-refresh", func() (string, error) {
+		if err := bot.RefreshTPOrders(); err != nil {
+			return "", err
+		}
+		return "TP orders cancelled and re-placed", nil
+	}))
+	mux.Handle(ControlPathCheck, s.handle(http.MethodPost, "check", func() (string, error) {
+		if err := bot.CheckNow(); err != nil {
+			return "", err
+		}
+		return "Market check completed", nil
+	}))
+	mux.Handle(ControlPathReload, s.handle(http.MethodPost, "reload", func() (string, error) {
+		return bot.ReloadConfig()
+	}))
+	// Health is polled by monitoring, so it is served without audit logging or a token
+	mux.Handle(ControlPathHealth, s.guard(bot.health, false))
+
+	s.server = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	return s, nil
+}
+
+// Start begins serving in the background
+func (s *ControlServer) Start() error {
+	if s.network == "unix" {
+		// Remove a socket left behind by a previous run
+		if info, err := os.Stat(s.address); err == nil && info.Mode()&os.ModeSocket != 0 {
+			os.Remove(s.address)
+		}
+	}
+
+	listener, err := net.Listen(s.network, s.address)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.Address(), err)
+	}
+	if s.network == "unix" {
+		if err := os.Chmod(s.address, 0600); err != nil {
+			listener.Close()
+			return fmt.Errorf("failed to restrict control socket permissions: %w", err)
+		}
+	}
+	s.listener = listener
+
+	go func() {
+		if err := s.server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			s.bot.logger.Error("Control server stopped: %v", err)
+		}
+	}()
+
+	s.bot.logger.Info("🎛️ Control API listening on %s", s.Address())
+	return nil
+}
+
+// Close stops the server and removes its Unix socket
+func (s *ControlServer) Close() error {
+	if s.listener == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := s.server.Shutdown(ctx)
+
+	if s.network == "unix" {
+		os.Remove(s.address)
+	}
+	return err
+}
+
+// Address returns the listen address in the form accepted by ParseControlAddress
+func (s *ControlServer) Address() string {
+	if s.network == "unix" {
+		return "unix:" + s.address
+	}
+	if s.listener != nil {
+		return s.listener.Addr().String()
+	}
+	return s.address
+}
+
+// guard refuses requests a browser could send: any request with an Origin
+// header or a Host other than loopback (DNS rebinding), and, if authenticate
+// is set, any request without the server's token
+func (s *ControlServer) guard(next http.Handler, authenticate bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status, reason := http.StatusOK, ""
+		switch {
+		case r.Header.Get("Origin") != "":
+			status, reason = http.StatusForbidden, "cross-origin requests are not accepted"
+		case !isLoopbackHost(r.Host):
+			status, reason = http.StatusForbidden, fmt.Sprintf("host %q is not a loopback host", r.Host)
+		case authenticate && !s.authorized(r):
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			status, reason = http.StatusUnauthorized, "missing or invalid control token"
+		}
+		if status != http.StatusOK {
+			s.bot.logger.LogWarning("Control", "Rejected %s %s from %s: %s", r.Method, r.URL.Path, r.RemoteAddr, reason)
+			writeControlResponse(w, status, ControlResponse{Error: reason})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// authorized returns true if the request presents the server's token
+func (s *ControlServer) authorized(r *http.Request) bool {
+	if s.token == "" {
+		return true
+	}
+	presented, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(presented), []byte(s.token)) == 1
+}
+
+// isLoopbackHost returns true if a Host header names localhost or a loopback IP
+func isLoopbackHost(hostport string) bool {
+	host := hostport
+	if h, _, err := net.SplitHostPort(hostport); err == nil {
+		host = h
+	}
+	host = strings.Trim(host, "[]")
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// handle wraps a command with request checks, audit logging and a JSON response
+func (s *ControlServer) handle(method, command string, fn func() (string, error)) http.Handler {
+	return s.guard(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != method {
+			w.Header().Set("Allow", method)
+			writeControlResponse(w, http.StatusMethodNotAllowed, ControlResponse{
+				Command: command,
+				Error:   fmt.Sprintf("use %s", method),
+			})
+			return
+		}
+
+		client := r.RemoteAddr
+		if client == "" || client == "@" {
+			client = "unix socket"
+		}
+		s.bot.logger.Info("🎛️ Control command '%s' received from %s", command, client)
+
+		start := time.Now()
+		message, err := fn()
+		if err != nil {
+			s.bot.logger.LogWarning("Control", "Command '%s' failed after %v: %v", command, time.Since(start).Round(time.Millisecond), err)
+			writeControlResponse(w, http.StatusConflict, ControlResponse{
+				Command: command,
+				Error:   err.Error(),
+			})
+			return
+		}
+
+		s.bot.logger.Info("🎛️ Control command '%s' completed in %v", command, time.Since(start).Round(time.Millisecond))
+		state := s.bot.State()
+		writeControlResponse(w, http.StatusOK, ControlResponse{
+			OK:      true,
+			Command: command,
+			Message: message,
+			State:   &state,
+		})
+	}), true)
+}
+
+func writeControlResponse(w http.ResponseWriter, status int, resp ControlResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,154 @@
+package bot
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/config"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/exchange/sim"
+)
+
+// newControlTestBot builds a bot on a simulated exchange without starting it
+func newControlTestBot(t *testing.T) *LiveBot {
+	t.Helper()
+	cfg, err := config.LoadLiveBotConfig(faultScenarioConfig)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	prepareFaultConfig(cfg, t.TempDir())
+
+	bot, err := NewLiveBotWithExchange(cfg, sim.New(sim.DefaultConfig(cfg.Strategy.Symbol, faultScenarioPrice)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { bot.logger.Close() })
+	return bot
+}
+
+func TestControlServerRequiresTokenOnTCP(t *testing.T) {
+	bot := newControlTestBot(t)
+
+	if _, err := NewControlServer(bot, "127.0.0.1:0", ""); err == nil || !strings.Contains(err.Error(), ControlTokenEnv) {
+		t.Errorf("expected a TCP address without a token to be refused, got %v", err)
+	}
+	if _, err := NewControlServer(bot, "127.0.0.1:0", "secret"); err != nil {
+		t.Errorf("unexpected error with a token: %v", err)
+	}
+	socket := "unix:" + filepath.Join(t.TempDir(), "bot.sock")
+	if _, err := NewControlServer(bot, socket, ""); err != nil {
+		t.Errorf("expected a unix socket without a token to be accepted, got %v", err)
+	}
+}
+
+func TestControlServerRejectsBrowserRequests(t *testing.T) {
+	bot := newControlTestBot(t)
+	s, err := NewControlServer(bot, "127.0.0.1:7070", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Healthy, so /health answers 200 once a request passes the checks
+	bot.health.SetConnected(true)
+	bot.health.UpdateLastTrade(time.Now())
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		host       string
+		origin     string
+		token      string
+		wantStatus int
+	}{
+		{name: "state with token", method: http.MethodGet, path: ControlPathState, host: "127.0.0.1:7070", token: "secret", wantStatus: http.StatusOK},
+		{name: "localhost host", method: http.MethodGet, path: ControlPathState, host: "localhost:7070", token: "secret", wantStatus: http.StatusOK},
+		{name: "ipv6 loopback host", method: http.MethodGet, path: ControlPathState, host: "[::1]:7070", token: "secret", wantStatus: http.StatusOK},
+		{name: "missing token", method: http.MethodGet, path: ControlPathState, host: "127.0.0.1:7070", wantStatus: http.StatusUnauthorized},
+		{name: "wrong token", method: http.MethodPost, path: ControlPathPause, host: "127.0.0.1:7070", token: "guess", wantStatus: http.StatusUnauthorized},
+		{name: "cross-site request", method: http.MethodPost, path: ControlPathClose, host: "127.0.0.1:7070", origin: "https://evil.example", token: "secret", wantStatus: http.StatusForbidden},
+		{name: "dns rebinding", method: http.MethodPost, path: ControlPathClose, host: "evil.example:7070", token: "secret", wantStatus: http.StatusForbidden},
+		{name: "health without token", method: http.MethodGet, path: ControlPathHealth, host: "127.0.0.1:7070", wantStatus: http.StatusOK},
+		{name: "cross-site health", method: http.MethodGet, path: ControlPathHealth, host: "127.0.0.1:7070", origin: "https://evil.example", wantStatus: http.StatusForbidden},
+		{name: "rebound health", method: http.MethodGet, path: ControlPathHealth, host: "evil.example:7070", wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Host = tt.host
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+			s.server.Handler.ServeHTTP(rec, req)
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected HTTP %d, got %d: %s", tt.wantStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+
+	if bot.EntriesPaused() {
+		t.Fatal("a rejected pause paused entries")
+	}
+
+	// A request that passes every check runs the command
+	req := httptest.NewRequest(http.MethodPost, ControlPathPause, nil)
+	req.Host = "127.0.0.1:7070"
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+	s.server.Handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || !bot.EntriesPaused() {
+		t.Errorf("expected the authorized pause to pause entries, got HTTP %d", rec.Code)
+	}
+}
+
+func TestControlServerUnixSocket(t *testing.T) {
+	bot := newControlTestBot(t)
+	path := filepath.Join(t.TempDir(), "bot.sock")
+	s, err := NewControlServer(bot, "unix:"+path, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("expected the socket to be readable by its owner only, got %v", perm)
+	}
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", path)
+		},
+	}}
+	for token, want := range map[string]int{"": http.StatusUnauthorized, "secret": http.StatusOK} {
+		req, _ := http.NewRequest(http.MethodGet, "http://localhost"+ControlPathState, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Errorf("token %q: expected HTTP %d, got %d", token, want, resp.StatusCode)
+		}
+	}
+}
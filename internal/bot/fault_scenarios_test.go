@@ -0,0 +1,584 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/config"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/exchange"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/exchange/faults"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/exchange/sim"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/safety"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/strategy"
+)
+
+// Failure scenarios run the bot against a simulated exchange (sim) wrapped in
+// a fault injector (faults). Each scenario starts a fresh bot, drives entries
+// and price moves directly instead of waiting for candles, and checks that no
+// logical order was placed twice, that the trading circuit breaker moved
+// through the expected states, and that once the faults stop the bot's
+// position, balance and TP orders match the exchange.
+
+// faultScenarioConfig supplies the strategy the scenarios trade
+const faultScenarioConfig = "../../configs/bybit/dca/btc_5m_bybit.json"
+
+// Scenario settings: short waits so open breakers recover and rate-limited
+// orders retry within a scenario
+const (
+	faultScenarioPrice       = 100.0
+	faultScenarioBreakerWait = 2 * time.Second
+	faultScenarioRateDelay   = 1 * time.Second
+	faultScenarioDCADrop     = 0.15 // Price drop per DCA entry, beyond any spacing threshold
+	faultScenarioSeed        = 1    // Seed for probabilistic faults
+)
+
+// faultScenario is one failure scenario
+type faultScenario struct {
+	Name        string
+	Description string
+	run         func(h *faultHarness) error
+}
+
+// faultScenarios lists the failure scenarios in run order
+var faultScenarios = []faultScenario{
+	{
+		Name:        "baseline",
+		Description: "No faults: entry and one DCA, TP orders placed and re-placed",
+		run: func(h *faultHarness) error {
+			return h.entryAndDCA(2)
+		},
+	},
+	{
+		Name:        "latency",
+		Description: "Every call delayed by 300ms",
+		run: func(h *faultHarness) error {
+			h.faults.AddRule(faults.Rule{Kind: faults.Latency, Latency: 300 * time.Millisecond})
+			return h.entryAndDCA(2)
+		},
+	},
+	{
+		Name:        "timeout",
+		Description: "First market and limit order time out before reaching the exchange",
+		run: func(h *faultHarness) error {
+			h.faults.AddRule(faults.Rule{Kind: faults.Timeout, Ops: []faults.Op{faults.OpPlaceMarketOrder}, Count: 1, Latency: 500 * time.Millisecond})
+			h.faults.AddRule(faults.Rule{Kind: faults.Timeout, Ops: []faults.Op{faults.OpPlaceLimitOrder}, Count: 1, Latency: 500 * time.Millisecond})
+			if err := h.entryAndDCA(1); err != nil {
+				return err
+			}
+			return h.expectInjected(faults.Timeout, 2)
+		},
+	},
+	{
+		Name:        "lost_response",
+		Description: "Orders accepted by the exchange but their responses lost",
+		run: func(h *faultHarness) error {
+			h.faults.AddRule(faults.Rule{Kind: faults.LostResponse, Ops: []faults.Op{faults.OpPlaceMarketOrder}, Count: 2})
+			h.faults.AddRule(faults.Rule{Kind: faults.LostResponse, Ops: []faults.Op{faults.OpPlaceLimitOrder}, Count: 2})
+			if err := h.entryAndDCA(2); err != nil {
+				return err
+			}
+			return h.expectInjected(faults.LostResponse, 4)
+		},
+	},
+	{
+		Name:        "rate_limit",
+		Description: "Orders rejected by the exchange rate limit, then a burst through the trading limiter",
+		run: func(h *faultHarness) error {
+			h.faults.AddRule(faults.Rule{Kind: faults.RateLimit, Ops: []faults.Op{faults.OpPlaceMarketOrder}, Count: 2})
+			if err := h.entryAndDCA(1); err != nil {
+				return err
+			}
+			if err := h.expectInjected(faults.RateLimit, 2); err != nil {
+				return err
+			}
+			return h.checkRateLimiter("trading")
+		},
+	},
+	{
+		Name:        "server_errors",
+		Description: "5xx errors on TP orders open the trading breaker, which recovers through half-open",
+		run: func(h *faultHarness) error {
+			h.faults.AddRule(faults.Rule{Kind: faults.ServerError, Ops: []faults.Op{faults.OpPlaceLimitOrder}, Count: 3})
+			if err := h.entryAndDCA(1); err != nil {
+				return err
+			}
+			return h.checkBreakerRecovered()
+		},
+	},
+	{
+		Name:        "partial_fill",
+		Description: "Entry market order only half filled, TP orders sized to the real position",
+		run: func(h *faultHarness) error {
+			h.faults.AddRule(faults.Rule{Kind: faults.PartialFill, Count: 1, FillRatio: 0.5})
+			return h.entryAndDCA(1)
+		},
+	},
+	{
+		Name:        "stale_price",
+		Description: "Price feed frozen after a drop: no DCA until fresh prices arrive",
+		run: func(h *faultHarness) error {
+			if err := h.buy(); err != nil {
+				return err
+			}
+			h.faults.AddRule(faults.Rule{Kind: faults.StalePrice})
+			h.sim.SetPrice(faultScenarioPrice * (1 - faultScenarioDCADrop))
+			if err := h.buy(); err != nil {
+				return err
+			}
+			if err := h.expectBuys(1); err != nil {
+				return fmt.Errorf("with a stale price: %w", err)
+			}
+			h.faults.ClearRules()
+			if err := h.buy(); err != nil {
+				return err
+			}
+			if err := h.expectBuys(2); err != nil {
+				return err
+			}
+			return h.checkEventualConsistency(true)
+		},
+	},
+	{
+		Name:        "flaky",
+		Description: "Random latency, 5xx, timeouts, rate limits and lost responses, then TP fills",
+		run: func(h *faultHarness) error {
+			orderOps := []faults.Op{faults.OpPlaceMarketOrder, faults.OpPlaceLimitOrder, faults.OpCancelOrder, faults.OpGetOrderByClientID}
+			placeOps := []faults.Op{faults.OpPlaceMarketOrder, faults.OpPlaceLimitOrder}
+			h.faults.AddRule(faults.Rule{Kind: faults.Latency, Probability: 0.3, Latency: 100 * time.Millisecond})
+			h.faults.AddRule(faults.Rule{Kind: faults.ServerError, Ops: orderOps, Probability: 0.1})
+			h.faults.AddRule(faults.Rule{Kind: faults.Timeout, Ops: orderOps, Probability: 0.05, Latency: 200 * time.Millisecond})
+			h.faults.AddRule(faults.Rule{Kind: faults.RateLimit, Ops: placeOps, Probability: 0.05})
+			h.faults.AddRule(faults.Rule{Kind: faults.LostResponse, Ops: placeOps, Probability: 0.1})
+
+			for i := 0; i < 3; i++ {
+				if i > 0 {
+					h.sim.SetPrice(h.sim.Price() * (1 - faultScenarioDCADrop))
+				}
+				if err := h.buy(); err != nil {
+					return err
+				}
+			}
+			// Fill the lower TP levels
+			if size, avg := h.sim.Position(); size > 0 {
+				h.sim.SetPrice(avg * (1 + h.bot.config.Strategy.TPPercent*0.45))
+			}
+			h.resync()
+
+			// Orders can be lost for good once retries run out, so only
+			// duplicates, over-selling and the position are checked
+			return h.checkEventualConsistency(false)
+		},
+	},
+}
+
+func TestFaultScenarios(t *testing.T) {
+	if testing.Short() {
+		t.Skip("fault scenarios wait for breaker and retry timeouts")
+	}
+
+	for _, scenario := range faultScenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := config.LoadLiveBotConfig(faultScenarioConfig)
+			if err != nil {
+				t.Fatalf("failed to load config: %v", err)
+			}
+			prepareFaultConfig(cfg, t.TempDir())
+
+			h, err := newFaultHarness(cfg, faultScenarioSeed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer h.close()
+
+			h.bot.logger.Info("🧪 Fault scenario %s: %s", scenario.Name, scenario.Description)
+			err = scenario.run(h)
+			if err == nil {
+				err = h.checkNoDuplicates()
+			}
+
+			t.Logf("%d orders, faults: %s", len(h.sim.Orders()), formatInjected(h.faults.Stats().Injected))
+			if transitions := h.breakerTransitions(); len(transitions) > 0 {
+				t.Logf("trading breaker: %s", strings.Join(transitions, ", "))
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// TestTPUpdateCancelsEachOrderOnce checks that re-placing TP orders after a
+// DCA fill cancels every old order exactly once
+func TestTPUpdateCancelsEachOrderOnce(t *testing.T) {
+	cfg, err := config.LoadLiveBotConfig(faultScenarioConfig)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	prepareFaultConfig(cfg, t.TempDir())
+
+	h, err := newFaultHarness(cfg, faultScenarioSeed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.close()
+
+	if err := h.entryAndDCA(2); err != nil {
+		t.Fatal(err)
+	}
+
+	cancelled := 0
+	for _, o := range h.sim.Orders() {
+		if o.OrderStatus == "Cancelled" {
+			cancelled++
+		}
+	}
+	if cancelled == 0 {
+		t.Fatal("expected the DCA fill to replace TP orders")
+	}
+	if calls := h.faults.Stats().Calls[faults.OpCancelOrder]; calls != cancelled {
+		t.Errorf("%d cancel calls for %d cancelled orders", calls, cancelled)
+	}
+}
+
+// prepareFaultConfig points a config at the simulation: logs go to a scratch
+// directory, and the ledger, notifications, risk limits and limit grid are off
+// so only the order and TP paths under test run
+func prepareFaultConfig(c *config.LiveBotConfig, logDir string) {
+	if c.Logging == nil {
+		c.Logging = &config.LoggingConfig{}
+	}
+	c.Logging.Dir = logDir
+	c.Logging.Console = false
+	c.Ledger = nil
+	c.Notifications = nil
+	c.Strategy.TPMode = config.TPModeOrders
+	c.Strategy.StopLossPercent = 0
+	c.Strategy.LimitGrid = nil
+	c.Strategy.CancelOrphanedOrders = false
+	c.Risk = config.RiskConfig{InitialBalance: c.Risk.InitialBalance, Commission: c.Risk.Commission}
+}
+
+// formatInjected lists fault counts by kind
+func formatInjected(injected map[faults.Kind]int) string {
+	if len(injected) == 0 {
+		return "none"
+	}
+	parts := make([]string, 0, len(injected))
+	for kind, n := range injected {
+		parts = append(parts, fmt.Sprintf("%s×%d", kind, n))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, " ")
+}
+
+// faultHarness is a bot wired to a simulated exchange through a fault injector
+type faultHarness struct {
+	bot    *LiveBot
+	sim    *sim.Exchange
+	faults *faults.Injector
+
+	mu          sync.Mutex
+	transitions []string // Trading circuit breaker state changes, "FROM->TO"
+}
+
+// newFaultHarness builds the bot and runs its startup sync, without starting
+// the trading loop
+func newFaultHarness(cfg *config.LiveBotConfig, seed int64) (*faultHarness, error) {
+	simConfig := sim.DefaultConfig(cfg.Strategy.Symbol, faultScenarioPrice)
+	simConfig.Balance = cfg.Risk.InitialBalance
+	simConfig.FeeRate = cfg.Risk.Commission
+	simConfig.MinOrderValue = 1
+	market := sim.New(simConfig)
+
+	injector := faults.Wrap(market)
+	injector.SetSeed(seed)
+
+	bot, err := NewLiveBotWithExchange(cfg, injector.Exchange())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bot: %w", err)
+	}
+	h := &faultHarness{bot: bot, sim: market, faults: injector}
+
+	bot.circuitBreakers = safety.NewCircuitBreakerManager()
+	bot.initializeCircuitBreakers(faultScenarioBreakerWait, faultScenarioBreakerWait)
+	if tradingCB, exists := bot.circuitBreakers.Get("trading"); exists {
+		tradingCB.SetStateChangeCallback(func(from, to safety.CircuitBreakerState) {
+			bot.logger.LogWarning("Circuit Breaker", "Trading circuit breaker state changed: %s -> %s", from, to)
+			h.mu.Lock()
+			h.transitions = append(h.transitions, from.String()+"->"+to.String())
+			h.mu.Unlock()
+		})
+	}
+	retry := bot.recoveryHandler.RetryConfig()
+	retry.RateLimitDelay = faultScenarioRateDelay
+	bot.recoveryHandler.SetRetryConfig(retry)
+
+	if err := bot.exchange.Connect(context.Background()); err != nil {
+		h.close()
+		return nil, fmt.Errorf("failed to connect to exchange: %w", err)
+	}
+	if err := bot.syncAccountBalance(); err != nil {
+		h.close()
+		return nil, err
+	}
+	if err := bot.syncExistingPosition(); err != nil {
+		h.close()
+		return nil, err
+	}
+	if err := bot.syncExistingOrders(); err != nil {
+		h.close()
+		return nil, err
+	}
+	return h, nil
+}
+
+func (h *faultHarness) close() {
+	h.bot.logger.Close()
+}
+
+// buy runs the bot's buy path for one entry at the price the bot sees
+func (h *faultHarness) buy() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	price, err := h.bot.exchange.GetLatestPrice(ctx, h.bot.symbol)
+	if err != nil {
+		h.bot.logger.LogWarning("Fault Scenario", "Entry skipped - no price: %v", err)
+		return nil
+	}
+	h.bot.syncStrategyState()
+	h.bot.executeBuy(&strategy.TradeDecision{
+		Action:     strategy.ActionBuy,
+		Amount:     h.bot.config.Strategy.BaseAmount,
+		Confidence: 1,
+		Strength:   1,
+		Reason:     "fault scenario",
+		Timestamp:  time.Now(),
+	}, price)
+	return nil
+}
+
+// entryAndDCA buys, drops the price and buys again until buys entries are
+// on the exchange, then checks consistency with the faults removed
+func (h *faultHarness) entryAndDCA(buys int) error {
+	for i := 0; i < buys; i++ {
+		if i > 0 {
+			h.sim.SetPrice(h.sim.Price() * (1 - faultScenarioDCADrop))
+		}
+		if err := h.buy(); err != nil {
+			return err
+		}
+	}
+	if err := h.expectBuys(buys); err != nil {
+		return err
+	}
+	return h.checkEventualConsistency(true)
+}
+
+// resync runs the sync part of a trading loop tick: balance, position,
+// strategy state and TP fill detection
+func (h *faultHarness) resync() {
+	bot := h.bot
+	if err := bot.syncAccountBalance(); err != nil {
+		bot.logger.LogWarning("Could not refresh balance", "%v", err)
+	}
+	if err := bot.syncPositionData(); err != nil {
+		if err.Error() == "STRATEGY_SYNC_REQUIRED" {
+			bot.syncStrategyState()
+			bot.recordCycleClose()
+		} else {
+			bot.logger.LogWarning("Could not sync position data", "%v", err)
+		}
+	}
+	bot.syncStrategyState()
+	bot.detectFilledTPOrders()
+}
+
+// expectBuys checks how many DCA entries executed on the exchange
+func (h *faultHarness) expectBuys(want int) error {
+	got := 0
+	for _, o := range h.sim.Orders() {
+		ref, ok := parseClientOrderID(o.ClientOrderID)
+		if ok && ref.Kind == orderKindDCA && isLiveOrExecuted(&o) {
+			got++
+		}
+	}
+	if got != want {
+		return fmt.Errorf("expected %d DCA entries on the exchange, found %d", want, got)
+	}
+	return nil
+}
+
+// expectInjected checks that the scenario's faults actually fired
+func (h *faultHarness) expectInjected(kind faults.Kind, want int) error {
+	if got := h.faults.Stats().Injected[kind]; got != want {
+		return fmt.Errorf("expected %d %s faults, injected %d", want, kind, got)
+	}
+	return nil
+}
+
+// checkNoDuplicates fails if any logical order, i.e. client order ID without
+// the attempt number, has more than one attempt resting or executed
+func (h *faultHarness) checkNoDuplicates() error {
+	attempts := make(map[string][]string)
+	for _, o := range h.sim.Orders() {
+		if _, ok := parseClientOrderID(o.ClientOrderID); !ok || !isLiveOrExecuted(&o) {
+			continue
+		}
+		base := o.ClientOrderID[:strings.LastIndex(o.ClientOrderID, "-")]
+		attempts[base] = append(attempts[base], o.ClientOrderID)
+	}
+
+	var duplicated []string
+	for base, ids := range attempts {
+		if len(ids) > 1 {
+			duplicated = append(duplicated, fmt.Sprintf("%s (%s)", base, strings.Join(ids, ", ")))
+		}
+	}
+	if len(duplicated) > 0 {
+		sort.Strings(duplicated)
+		return fmt.Errorf("orders placed more than once: %s", strings.Join(duplicated, "; "))
+	}
+	return nil
+}
+
+// checkEventualConsistency removes the faults, resyncs the bot and compares
+// it with the exchange. With strictTP every open TP order must be tracked by
+// the bot and a position must have TP orders; otherwise TP orders are only
+// checked for over-selling and for tracked orders that are gone.
+func (h *faultHarness) checkEventualConsistency(strictTP bool) error {
+	h.faults.ClearRules()
+	h.resync()
+
+	if err := h.checkNoDuplicates(); err != nil {
+		return err
+	}
+
+	size, avgPrice := h.sim.Position()
+	available, _ := h.sim.GetTradableBalance(context.Background(), exchange.AccountTypeUnified, "USDT")
+
+	h.bot.positionMutex.RLock()
+	position := h.bot.currentPosition
+	botAvgPrice := h.bot.averagePrice
+	balance := h.bot.balance
+	dcaLevel := h.bot.dcaLevel
+	h.bot.positionMutex.RUnlock()
+
+	if math.Abs(position-size*avgPrice) > 0.01 {
+		return fmt.Errorf("bot position $%.4f, exchange position $%.4f", position, size*avgPrice)
+	}
+	if size > 0 && math.Abs(botAvgPrice-avgPrice) > avgPrice*1e-6 {
+		return fmt.Errorf("bot average price $%.6f, exchange $%.6f", botAvgPrice, avgPrice)
+	}
+	if size == 0 && dcaLevel != 0 {
+		return fmt.Errorf("bot at DCA level %d without a position", dcaLevel)
+	}
+	if math.Abs(balance-available) > 0.01 {
+		return fmt.Errorf("bot balance $%.4f, exchange available $%.4f", balance, available)
+	}
+	return h.checkTPOrders(size, strictTP)
+}
+
+// checkTPOrders compares the bot's TP orders with those open on the exchange
+func (h *faultHarness) checkTPOrders(size float64, strict bool) error {
+	open := make(map[string]bool)
+	openQty := 0.0
+	for _, o := range h.sim.Orders() {
+		ref, ok := parseClientOrderID(o.ClientOrderID)
+		if !ok || !ref.isTP() || (o.OrderStatus != "New" && o.OrderStatus != "PartiallyFilled") {
+			continue
+		}
+		qty, _ := parseFloat(o.Quantity)
+		executed, _ := parseFloat(o.CumExecQty)
+		open[o.OrderID] = true
+		openQty += qty - executed
+	}
+	if openQty > size+1e-9 {
+		return fmt.Errorf("open TP orders sell %.6f, position is %.6f", openQty, size)
+	}
+
+	h.bot.tpOrderMutex.RLock()
+	defer h.bot.tpOrderMutex.RUnlock()
+	for orderID, tp := range h.bot.activeTPOrders {
+		if !open[orderID] {
+			return fmt.Errorf("bot tracks TP level %d order %s that is not open on the exchange", tp.Level, orderID)
+		}
+	}
+	if !strict {
+		return nil
+	}
+	for orderID := range open {
+		if _, tracked := h.bot.activeTPOrders[orderID]; !tracked {
+			return fmt.Errorf("TP order %s is open on the exchange but not tracked by the bot", orderID)
+		}
+	}
+	if size > 0 && h.bot.config.Strategy.AutoTPOrders && !h.bot.usePositionTPSL() && len(open) == 0 {
+		return fmt.Errorf("position of %.6f has no TP orders", size)
+	}
+	return nil
+}
+
+// checkRateLimiter sends a burst beyond the limiter's bucket and checks it
+// is spread out at the refill rate rather than passed through or stalled
+func (h *faultHarness) checkRateLimiter(name string) error {
+	limiter, exists := h.bot.rateLimiters.Get(name)
+	if !exists {
+		return fmt.Errorf("no %s rate limiter", name)
+	}
+	stats := limiter.GetStats()
+	burst := stats.Capacity + 2*stats.RefillRate
+	minWait := time.Duration(float64(burst-stats.Capacity) / float64(stats.RefillRate) * float64(time.Second))
+
+	ctx, cancel := context.WithTimeout(context.Background(), minWait+10*time.Second)
+	defer cancel()
+	start := time.Now()
+	for i := 0; i < burst; i++ {
+		if err := limiter.Wait(ctx); err != nil {
+			return fmt.Errorf("%s rate limiter stalled after %d of %d calls: %w", name, i, burst, err)
+		}
+	}
+	elapsed := time.Since(start)
+	if elapsed < minWait*9/10 {
+		return fmt.Errorf("%s rate limiter let %d calls through in %v, expected at least %v", name, burst, elapsed, minWait)
+	}
+	if elapsed > minWait+5*time.Second {
+		return fmt.Errorf("%s rate limiter took %v for %d calls, expected about %v", name, elapsed, burst, minWait)
+	}
+	return nil
+}
+
+// checkBreakerRecovered checks the trading breaker opened, let a trial call
+// through half-open and closed again
+func (h *faultHarness) checkBreakerRecovered() error {
+	// State change callbacks run on their own goroutines
+	time.Sleep(100 * time.Millisecond)
+	transitions := h.breakerTransitions()
+	for _, want := range []string{"CLOSED->OPEN", "OPEN->HALF_OPEN", "HALF_OPEN->CLOSED"} {
+		found := false
+		for _, t := range transitions {
+			found = found || t == want
+		}
+		if !found {
+			return fmt.Errorf("trading circuit breaker never went %s (transitions: %v)", want, transitions)
+		}
+	}
+	tradingCB, _ := h.bot.circuitBreakers.Get("trading")
+	if state := tradingCB.GetState(); state != safety.StateClosed {
+		return fmt.Errorf("trading circuit breaker ended %s, expected CLOSED", state)
+	}
+	return nil
+}
+
+func (h *faultHarness) breakerTransitions() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]string(nil), h.transitions...)
+}
@@ -0,0 +1,232 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/exchange"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/ledger"
+)
+
+// The trade ledger (ledger.Store) records every fill of the bot with its fee
+// and the cycle it belongs to, taken from the client order ID. Recording is
+// idempotent by order ID, so a fill seen by several code paths (grid checks,
+// syncAfterTrade, TP detection) is stored once. Ledger errors are logged and
+// never interrupt trading.
+
+// ledgerFillKinds maps client order ID kinds to ledger fill kinds
+var ledgerFillKinds = map[string]string{
+	orderKindDCA:        "dca",
+	orderKindGrid:       "grid",
+	orderKindTP:         "tp",
+	orderKindFallbackTP: "fallback_tp",
+	orderKindExit:       "exit",
+}
+
+// restoreLedgerCycle picks up the cycle of an existing position after a
+// restart, and closes a ledger cycle whose position was closed while the bot
+// was not running
+func (bot *LiveBot) restoreLedgerCycle() {
+	if bot.ledger == nil {
+		return
+	}
+
+	open, err := bot.ledger.OpenCycle(bot.symbol)
+	if err != nil {
+		bot.logger.LogWarning("Ledger", "Could not read open cycle: %v", err)
+		return
+	}
+
+	bot.positionMutex.RLock()
+	inPosition := bot.currentPosition > 0
+	bot.positionMutex.RUnlock()
+	bot.orderIDMutex.Lock()
+	token := bot.cycleToken
+	bot.orderIDMutex.Unlock()
+
+	switch {
+	case inPosition && token != "":
+		bot.ledgerCycle = token
+	case inPosition && open != nil:
+		bot.ledgerCycle = open.ID
+	case open != nil:
+		bot.ledgerCycle = open.ID
+		bot.closeLedgerCycle()
+		return
+	}
+	if bot.ledgerCycle != "" {
+		bot.logger.Info("📒 Ledger: continuing cycle %s", bot.ledgerCycle)
+	}
+	bot.recordLedgerEquity("startup", bot.ledgerCycle)
+}
+
+// recordLedgerFill stores an executed order in the ledger. Execution details
+// missing from the order are looked up by client order ID; without them the
+// order quantity and price (or the latest price) are used and the fee is
+// estimated from the configured commission.
+func (bot *LiveBot) recordLedgerFill(order *exchange.Order, side string) {
+	if bot.ledger == nil || order == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	executed, _ := parseFloat(order.CumExecQty)
+	lookedUp := false
+	if lookup, ok := bot.exchange.(exchange.OrderLookupExchange); ok && executed <= 0 && order.ClientOrderID != "" {
+		found, err := lookup.GetOrderByClientID(ctx, bot.category, bot.symbol, order.ClientOrderID)
+		if err != nil {
+			bot.logger.LogWarning("Ledger", "Could not look up order %s: %v", order.ClientOrderID, err)
+		} else if found != nil {
+			order = found
+			lookedUp = true
+			executed, _ = parseFloat(order.CumExecQty)
+		}
+	}
+
+	qty := executed
+	if qty <= 0 {
+		if lookedUp && order.OrderStatus != "Filled" {
+			bot.logger.LogWarning("Ledger", "Order %s not recorded - nothing executed (%s)", order.ClientOrderID, order.OrderStatus)
+			return
+		}
+		qty, _ = parseFloat(order.Quantity)
+	}
+	price, _ := parseFloat(order.AvgPrice)
+	if price <= 0 {
+		price, _ = parseFloat(order.Price)
+	}
+	if price <= 0 {
+		price, _ = bot.exchange.GetLatestPrice(ctx, bot.symbol)
+	}
+	if qty <= 0 || price <= 0 {
+		bot.logger.LogWarning("Ledger", "Order %s not recorded - unknown quantity or price", order.OrderID)
+		return
+	}
+
+	fill := ledger.Fill{
+		Time:          time.Now(),
+		Symbol:        bot.symbol,
+		Side:          side,
+		Quantity:      qty,
+		Price:         price,
+		OrderID:       order.OrderID,
+		ClientOrderID: order.ClientOrderID,
+	}
+	if !order.UpdatedTime.IsZero() {
+		fill.Time = order.UpdatedTime
+	}
+
+	if fee, err := parseFloat(order.CumExecFee); err == nil && executed > 0 {
+		// Spot buys pay the fee in the base coin
+		if bot.category == "spot" && side == ledger.SideBuy {
+			fee *= price
+		}
+		fill.Fee = fee
+	} else {
+		fill.Fee = qty * price * bot.config.Risk.Commission
+		fill.FeeEstimated = true
+	}
+
+	if ref, ok := parseClientOrderID(order.ClientOrderID); ok {
+		fill.CycleID = ref.Cycle
+		fill.Kind = ledgerFillKinds[ref.Kind]
+		fill.Level = ref.Level
+	}
+	if fill.CycleID == "" {
+		fill.CycleID = bot.ledgerCycle
+	}
+	if fill.CycleID == "" {
+		fill.CycleID = newCycleToken(fill.Time)
+	}
+	if side == ledger.SideBuy {
+		bot.ledgerCycle = fill.CycleID
+	}
+
+	recorded, ok, err := bot.ledger.RecordFill(fill)
+	if err != nil {
+		bot.logger.LogWarning("Ledger", "Could not record %s fill %s: %v", side, order.OrderID, err)
+		return
+	}
+	if !ok {
+		return
+	}
+
+	feeNote := ""
+	if recorded.FeeEstimated {
+		feeNote = " est."
+	}
+	if side == ledger.SideSell {
+		bot.logger.Info("📒 Ledger: SELL %.6f @ $%.4f (fee $%.4f%s) - realized PnL $%.2f [cycle %s]",
+			recorded.Quantity, recorded.Price, recorded.Fee, feeNote, recorded.RealizedPnL, recorded.CycleID)
+	} else {
+		bot.logger.Info("📒 Ledger: BUY %.6f @ $%.4f (fee $%.4f%s) [cycle %s]",
+			recorded.Quantity, recorded.Price, recorded.Fee, feeNote, recorded.CycleID)
+	}
+}
+
+// closeLedgerCycle closes the current ledger cycle once the position is flat
+// and snapshots account equity
+func (bot *LiveBot) closeLedgerCycle() {
+	if bot.ledger == nil || bot.ledgerCycle == "" {
+		return
+	}
+	cycleID := bot.ledgerCycle
+	bot.ledgerCycle = ""
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// The mark price values any quantity closed without a recorded sell,
+	// e.g. by an exchange-side position TP or stop loss
+	price, err := bot.exchange.GetLatestPrice(ctx, bot.symbol)
+	if err != nil {
+		bot.logger.LogWarning("Ledger", "Could not get price to close cycle %s: %v", cycleID, err)
+	}
+
+	cycle, err := bot.ledger.CloseCycle(cycleID, time.Now(), price)
+	if err != nil {
+		bot.logger.LogWarning("Ledger", "Could not close cycle %s: %v", cycleID, err)
+		return
+	}
+	if cycle != nil {
+		estimate := ""
+		if cycle.EstimatedPnL != 0 {
+			estimate = fmt.Sprintf(" (incl. $%.2f estimated at $%.4f)", cycle.EstimatedPnL, cycle.ExitPrice)
+		}
+		bot.logger.Info("📒 Ledger: cycle #%d %s closed - %d buys, %d sells, fees $%.4f, PnL $%.2f%s",
+			cycle.Number, cycle.ID, cycle.Entries, cycle.Exits, cycle.Fees, cycle.PnL(), estimate)
+	}
+	bot.recordLedgerEquity("cycle_closed", cycleID)
+}
+
+// recordLedgerEquity stores an account equity snapshot in the ledger
+func (bot *LiveBot) recordLedgerEquity(note, cycleID string) {
+	if bot.ledger == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	equity, err := bot.accountEquity(ctx)
+	if err != nil {
+		bot.logger.LogWarning("Ledger", "Could not determine account equity: %v", err)
+		return
+	}
+	bot.positionMutex.RLock()
+	point := ledger.EquityPoint{
+		Time:    time.Now(),
+		Balance: bot.balance,
+		Equity:  equity,
+		CycleID: cycleID,
+		Note:    note,
+	}
+	bot.positionMutex.RUnlock()
+
+	if err := bot.ledger.RecordEquity(point); err != nil {
+		bot.logger.LogWarning("Ledger", "Could not record equity: %v", err)
+	}
+}
@@ -0,0 +1,360 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/exchange"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/ledger"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/logger"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/risk"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/strategy"
+)
+
+// In limit-grid mode only the first entry of a cycle is a market buy. The
+// next DCA levels then rest on the book as limit buys at the prices the
+// spacing strategy computes from the average entry, so averaging down pays
+// maker fees and no slippage. Whenever a grid order fills the remaining
+// orders are cancelled and the grid is re-anchored at the new average price;
+// the whole grid is cancelled when the cycle completes or entries are paused.
+// The backtest engine models the same grid with intrabar Low-touch fills.
+
+// GridOrderInfo holds information about a resting DCA grid order
+type GridOrderInfo struct {
+	Level         int    `json:"level"`           // DCA buy number the order fills (2 = first averaging buy)
+	Quantity      string `json:"quantity"`        // Order quantity
+	Price         string `json:"price"`           // Limit price
+	OrderID       string `json:"order_id"`        // Exchange order ID
+	ClientOrderID string `json:"client_order_id"` // Client order ID, used to confirm fills
+}
+
+// gridPlanner returns the strategy's grid planner, or nil when the strategy
+// does not pre-place DCA levels
+func (bot *LiveBot) gridPlanner() strategy.GridPlanner {
+	if planner, ok := bot.strategy.(strategy.GridPlanner); ok && planner.IsGridEnabled() {
+		return planner
+	}
+	return nil
+}
+
+// placeGridOrders cancels any resting grid orders and places the next DCA
+// levels planned by the strategy from its current DCA level and entry price
+func (bot *LiveBot) placeGridOrders() error {
+	if err := bot.cancelGridOrders(); err != nil {
+		bot.logger.LogWarning("Limit Grid", "Error canceling previous grid orders: %v", err)
+	}
+	planner := bot.gridPlanner()
+	if planner == nil {
+		return nil
+	}
+
+	bot.positionMutex.RLock()
+	inPosition := bot.currentPosition > 0
+	dcaLevel := bot.dcaLevel
+	avgPrice := bot.averagePrice
+	cycleCapital := bot.totalInvested
+	balance := bot.balance
+	bot.positionMutex.RUnlock()
+
+	if !inPosition || dcaLevel == 0 {
+		return nil
+	}
+	if bot.EntriesPaused() {
+		bot.logger.Info("⏸️ Grid orders not placed - DCA entries are paused")
+		return nil
+	}
+
+	klines, err := bot.getRecentKlines()
+	if err != nil {
+		return fmt.Errorf("failed to get klines for grid: %w", err)
+	}
+	planned := planner.PlanGrid(klines)
+	if len(planned) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 90*time.Second)
+	defer cancel()
+
+	constraints, err := bot.exchange.GetTradingConstraints(ctx, bot.category, bot.symbol)
+	if err != nil {
+		return fmt.Errorf("failed to get trading constraints: %w", err)
+	}
+
+	// Re-anchored grid orders need fresh client order IDs
+	bot.beginGridRevision()
+
+	placed := 0
+	pending := 0.0 // Capital committed by the grid orders placed so far
+	for i, level := range planned {
+		price := level.Price
+		if constraints.MinPriceStep > 0 {
+			price = math.Floor(price/constraints.MinPriceStep) * constraints.MinPriceStep
+		}
+		if price <= 0 {
+			break
+		}
+
+		quantity := level.Amount / price
+		if constraints.QtyStep > 0 {
+			quantity = math.Floor(quantity/constraints.QtyStep) * constraints.QtyStep
+		}
+		amount := quantity * price
+		if quantity < constraints.MinOrderQty || amount < constraints.MinOrderValue {
+			bot.logger.LogWarning("Limit Grid", "Level %d skipped: qty %.6f / value $%.2f below exchange minimum", dcaLevel+i+1, quantity, amount)
+			continue
+		}
+
+		// Deeper levels are only placed while the whole grid stays fundable
+		// and within the risk limits
+		if pending+amount > balance {
+			bot.logger.LogWarning("Limit Grid", "Level %d not placed: grid needs $%.2f, balance $%.2f", dcaLevel+i+1, pending+amount, balance)
+			break
+		}
+		if err := bot.checkGridRiskLimits(amount, price, balance-pending, cycleCapital+pending, dcaLevel+i); err != nil {
+			bot.logger.Info("🛡️ RISK GUARD: Grid level %d at $%.4f not placed - %v", dcaLevel+i+1, price, err)
+			break
+		}
+
+		formattedQty := fmt.Sprintf("%.6f", quantity)
+		formattedPrice := fmt.Sprintf("%.4f", price)
+		params := exchange.OrderParams{
+			Category:      bot.category,
+			Symbol:        bot.symbol,
+			Side:          exchange.OrderSideBuy,
+			Quantity:      formattedQty,
+			OrderType:     exchange.OrderTypeLimit,
+			Price:         formattedPrice,
+			ClientOrderID: bot.clientOrderBase(orderKindGrid, dcaLevel+i+1),
+		}
+
+		order, err := bot.placeOrderWithRetry(params, false) // false for limit order
+		if err != nil {
+			bot.logger.LogWarning("Limit Grid", "Failed to place grid level %d: %v", dcaLevel+i+1, err)
+			break
+		}
+
+		clientOrderID := order.ClientOrderID
+		if clientOrderID == "" {
+			clientOrderID = params.ClientOrderID
+		}
+		bot.gridOrderMutex.Lock()
+		bot.gridOrders[order.OrderID] = &GridOrderInfo{
+			Level:         dcaLevel + i + 1,
+			Quantity:      formattedQty,
+			Price:         formattedPrice,
+			OrderID:       order.OrderID,
+			ClientOrderID: clientOrderID,
+		}
+		bot.gridOrderMutex.Unlock()
+
+		pending += amount
+		placed++
+		bot.logger.Info("🧱 Grid level %d placed: buy %s %s at $%s ($%.2f)", dcaLevel+i+1, formattedQty, bot.symbol, formattedPrice, amount)
+	}
+
+	if placed > 0 {
+		bot.logger.Info("🧱 Limit grid anchored at $%.4f: %d/%d levels resting, $%.2f committed",
+			avgPrice, placed, len(planned), pending)
+		fmt.Printf("🧱 Limit grid: %d DCA levels resting ($%.2f)\n", placed, pending)
+	}
+	return nil
+}
+
+// checkGridRiskLimits validates one grid level against the risk guard, counting
+// the capital of the grid levels above it as already invested
+func (bot *LiveBot) checkGridRiskLimits(amount, price, balance, cycleCapital float64, dcaLevel int) error {
+	portfolio := &risk.Portfolio{
+		Balance:      balance,
+		Symbol:       bot.symbol,
+		CycleCapital: cycleCapital,
+		DCALevel:     dcaLevel,
+		Time:         time.Now(),
+	}
+	order := &risk.Order{
+		Symbol: bot.symbol,
+		Side:   exchange.OrderSideBuy,
+		Amount: amount,
+		Price:  price,
+	}
+	return bot.riskGuard.ValidateOrder(order, portfolio)
+}
+
+// cancelGridOrders cancels all resting grid orders and stops tracking them
+func (bot *LiveBot) cancelGridOrders() error {
+	bot.gridOrderMutex.Lock()
+	ordersToCancel := make([]*GridOrderInfo, 0, len(bot.gridOrders))
+	for orderID, info := range bot.gridOrders {
+		ordersToCancel = append(ordersToCancel, info)
+		delete(bot.gridOrders, orderID)
+	}
+	bot.gridOrderMutex.Unlock()
+
+	var lastErr error
+	for _, info := range ordersToCancel {
+		if err := bot.cancelOrderWithRetry(bot.category, bot.symbol, info.OrderID); err != nil {
+			bot.logger.LogWarning("Limit Grid", "Failed to cancel grid level %d order %s: %v", info.Level, info.OrderID, err)
+			lastErr = err
+		} else {
+			bot.logger.Info("🧹 Cancelled grid level %d order %s", info.Level, info.OrderID)
+		}
+	}
+	return lastErr
+}
+
+// checkGridFills detects grid orders that left the book. Filled orders advance
+// the DCA level, re-price TP orders and re-anchor the grid; orders cancelled
+// outside the bot are dropped.
+func (bot *LiveBot) checkGridFills() {
+	bot.gridOrderMutex.RLock()
+	tracked := len(bot.gridOrders)
+	bot.gridOrderMutex.RUnlock()
+	if tracked == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	orders, err := bot.exchange.GetOpenOrders(ctx, bot.category, bot.symbol)
+	if err != nil {
+		bot.logger.LogWarning("Limit Grid", "Failed to get open orders: %v", err)
+		return
+	}
+	onBook := make(map[string]bool, len(orders))
+	for _, order := range orders {
+		onBook[order.OrderID] = true
+	}
+
+	bot.gridOrderMutex.Lock()
+	var gone []*GridOrderInfo
+	for orderID, info := range bot.gridOrders {
+		if !onBook[orderID] {
+			gone = append(gone, info)
+			delete(bot.gridOrders, orderID)
+		}
+	}
+	bot.gridOrderMutex.Unlock()
+	if len(gone) == 0 {
+		return
+	}
+	sort.Slice(gone, func(i, j int) bool { return gone[i].Level < gone[j].Level })
+
+	lookup, canLookup := bot.exchange.(exchange.OrderLookupExchange)
+	var filled []*exchange.Order
+	for _, info := range gone {
+		// Without order lookup an order that left the book is assumed filled;
+		// the position sync below confirms the executed size either way
+		order := &exchange.Order{
+			OrderID:       info.OrderID,
+			ClientOrderID: info.ClientOrderID,
+			Side:          exchange.OrderSideBuy,
+			OrderType:     exchange.OrderTypeLimit,
+			Quantity:      info.Quantity,
+			Price:         info.Price,
+			OrderStatus:   "Filled",
+		}
+		if canLookup && info.ClientOrderID != "" {
+			found, err := lookup.GetOrderByClientID(ctx, bot.category, bot.symbol, info.ClientOrderID)
+			if err != nil {
+				bot.logger.LogWarning("Limit Grid", "Could not look up grid order %s: %v", info.ClientOrderID, err)
+			} else if found != nil {
+				order = found
+			}
+		}
+
+		executed, _ := parseFloat(order.CumExecQty)
+		if order.OrderStatus != "Filled" && executed <= 0 {
+			bot.logger.LogWarning("Limit Grid", "Grid level %d order %s left the book unfilled (%s)", info.Level, info.OrderID, order.OrderStatus)
+			continue
+		}
+
+		bot.logger.Event(logger.LogLevelTrade, logger.EventGridFilled, logger.Fields{
+			logger.FieldOrderID:       info.OrderID,
+			logger.FieldClientOrderID: info.ClientOrderID,
+			"dca_level":               info.Level,
+			"quantity":                info.Quantity,
+			"price":                   info.Price,
+		}, "🧱 Grid level %d FILLED: buy %s %s at $%s - OrderID: %s", info.Level, info.Quantity, bot.symbol, info.Price, info.OrderID)
+		fmt.Printf("🧱 Grid level %d filled at $%s\n", info.Level, info.Price)
+		filled = append(filled, order)
+		bot.recordLedgerFill(order, ledger.SideBuy)
+	}
+	if len(filled) == 0 {
+		return
+	}
+	bot.health.UpdateLastTrade(time.Now())
+
+	// syncAfterTrade advances the DCA level by one; account for any other
+	// levels that filled since the last check first
+	if len(filled) > 1 {
+		bot.positionMutex.Lock()
+		bot.dcaLevel += len(filled) - 1
+		bot.positionMutex.Unlock()
+	}
+	bot.syncAfterTrade(filled[len(filled)-1], "BUY")
+
+	// Re-anchor the remaining levels at the new average price
+	if err := bot.placeGridOrders(); err != nil {
+		bot.logger.LogWarning("Limit Grid", "Could not re-anchor grid: %v", err)
+	}
+}
+
+// ensureGridOrders keeps the grid consistent with the bot state: it is
+// cancelled when flat, paused or disabled, and placed when a position has
+// no resting grid orders (e.g. after a restart or when entries resume)
+func (bot *LiveBot) ensureGridOrders() {
+	bot.gridOrderMutex.RLock()
+	resting := len(bot.gridOrders)
+	bot.gridOrderMutex.RUnlock()
+
+	bot.positionMutex.RLock()
+	inPosition := bot.currentPosition > 0 && bot.dcaLevel > 0
+	bot.positionMutex.RUnlock()
+
+	if bot.gridPlanner() == nil || !inPosition || bot.EntriesPaused() {
+		if resting > 0 {
+			bot.logger.Info("🧹 Cancelling %d resting grid orders (grid disabled, flat or entries paused)", resting)
+			if err := bot.cancelGridOrders(); err != nil {
+				bot.logger.LogWarning("Limit Grid", "Error canceling grid orders: %v", err)
+			}
+		}
+		return
+	}
+
+	if resting == 0 {
+		if err := bot.placeGridOrders(); err != nil {
+			bot.logger.LogWarning("Limit Grid", "Could not place grid orders: %v", err)
+		}
+	}
+}
+
+// adoptGridOrder tracks a grid order found on the exchange at startup
+func (bot *LiveBot) adoptGridOrder(order *exchange.Order, level int) {
+	bot.gridOrderMutex.Lock()
+	defer bot.gridOrderMutex.Unlock()
+
+	bot.gridOrders[order.OrderID] = &GridOrderInfo{
+		Level:         level,
+		Quantity:      order.Quantity,
+		Price:         order.Price,
+		OrderID:       order.OrderID,
+		ClientOrderID: order.ClientOrderID,
+	}
+}
+
+// sortedGridOrders returns the resting grid orders ordered by level
+func (bot *LiveBot) sortedGridOrders() []GridOrderInfo {
+	bot.gridOrderMutex.RLock()
+	defer bot.gridOrderMutex.RUnlock()
+
+	result := make([]GridOrderInfo, 0, len(bot.gridOrders))
+	for _, info := range bot.gridOrders {
+		result = append(result, *info)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Level < result[j].Level })
+	return result
+}
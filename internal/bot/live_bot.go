@@ -10,17 +10,17 @@ import (
 	"time"
 
 	"sync"
+	"sync/atomic"
 
 	"github.com/ducminhle1904/crypto-dca-bot/internal/config"
 	"github.com/ducminhle1904/crypto-dca-bot/internal/exchange"
 	"github.com/ducminhle1904/crypto-dca-bot/internal/exchange/adapters"
-	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators/bands"
-	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators/common"
-	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators/oscillators"
-	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators/trend"
-	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators/volume"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/ledger"
 	"github.com/ducminhle1904/crypto-dca-bot/internal/logger"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/monitoring"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/notifications"
 	"github.com/ducminhle1904/crypto-dca-bot/internal/recovery"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/risk"
 	"github.com/ducminhle1904/crypto-dca-bot/internal/safety"
 	"github.com/ducminhle1904/crypto-dca-bot/internal/strategy"
 	"github.com/ducminhle1904/crypto-dca-bot/internal/strategy/spacing"
@@ -36,13 +36,14 @@ type TPOrderInfo struct {
 	OrderID     string  `json:"order_id"`     // Exchange order ID
 	Filled      bool    `json:"filled"`       // Whether this level was filled
 	FilledQty   string  `json:"filled_qty"`   // Actual filled quantity
+	ClientOrderID string `json:"client_order_id,omitempty"` // Client order ID, identifies the cycle in the ledger
 }
 
 // LiveBot represents the live trading bot with exchange interface support
 type LiveBot struct {
 	config   *config.LiveBotConfig
 	exchange exchange.LiveTradingExchange
-	strategy *strategy.EnhancedDCAStrategy
+	strategy strategy.Strategy
 	spacingStrategy spacing.DCASpacingStrategy
 	logger   *logger.Logger
 	
@@ -54,6 +55,9 @@ type LiveBot struct {
 	// Bot control
 	running  bool
 	stopChan chan struct{}
+	controlChan   chan controlRequest // Control API commands run on the trading loop
+	configPath    string              // Config file for hot reload
+	entriesPaused atomic.Bool         // Skip new DCA entries, keep managing TP orders
 	
 	// Trading state - exchange agnostic
 	currentPosition float64
@@ -66,18 +70,42 @@ type LiveBot struct {
 	activeTPOrders map[string]*TPOrderInfo // orderID -> TP order info mapping
 	filledTPOrders map[string]*TPOrderInfo // orderID -> filled TP order info mapping
 	tpOrderMutex   sync.RWMutex            // Protect TP order map access
+	positionTPPercent float64              // TP of the last full-position TP/SL, 0 if not set since start
+	
+	// Limit-order DCA grid - resting buys for the next DCA levels
+	gridOrders     map[string]*GridOrderInfo // orderID -> grid order info mapping
+	gridOrderMutex sync.RWMutex              // Protect grid order map access
 	
 	// Position synchronization
 	positionMutex  sync.RWMutex      // Protect position data access
 	
+	// Client order IDs for idempotent placement
+	cycleToken    string          // Identifies the current cycle in client order IDs
+	tpRevision    int             // TP placement round within the cycle
+	gridRevision  int             // Limit-grid placement round within the cycle
+	orderAttempts map[string]int  // Base client order ID -> attempts made
+	orderIDMutex  sync.Mutex      // Protect client order ID state
+	
 	// Debugging counters
 	holdLogCounter int               // Counter for HOLD decision logging
 	
+	// Account-level risk guard and its outputs
+	riskGuard         *risk.Guard                 // Limits enforced before every buy
+	cycleStartBalance float64                     // Flat balance before the cycle's first buy
+	notifier          notifications.Notifier      // Alert channel, nil if notifications are off
+	health            *monitoring.HealthChecker   // Health status served by the control API
+	
+	// Trade ledger, nil if disabled
+	ledger      *ledger.Store // Fills, cycles and equity for realized PnL accounting
+	ledgerCycle string        // Ledger cycle of the open position, kept until the cycle is closed
+	
 	// Safety infrastructure
 	validator          *safety.Validator                  // Input validation
 	recoveryHandler    *recovery.RecoveryHandler         // Error recovery with backoff
 	circuitBreakers    *safety.CircuitBreakerManager     // Circuit breakers for resilience
 	rateLimiters       *safety.RateLimiterManager        // Rate limiting for API calls
+	quotaWarnMutex     sync.Mutex                        // Protects quotaWarnedUntil
+	quotaWarnedUntil   map[string]time.Time              // Window reset of the last exhausted-quota warning per endpoint
 }
 
 // NewLiveBot creates a new live trading bot instance
@@ -93,6 +121,19 @@ func NewLiveBot(config *config.LiveBotConfig) (*LiveBot, error) {
 		return nil, fmt.Errorf("failed to create exchange: %w", err)
 	}
 
+	return NewLiveBotWithExchange(config, exchangeInstance)
+}
+
+// NewLiveBotWithExchange creates a bot trading on the given exchange instead of
+// one built from the config, e.g. a simulated or fault-injecting exchange
+func NewLiveBotWithExchange(config *config.LiveBotConfig, exchangeInstance exchange.LiveTradingExchange) (*LiveBot, error) {
+	if config == nil {
+		return nil, fmt.Errorf("bot configuration is required")
+	}
+	if exchangeInstance == nil {
+		return nil, fmt.Errorf("exchange is required")
+	}
+
 	// Extract trading parameters
 	symbol := config.Strategy.Symbol
 	interval := config.Strategy.Interval
@@ -101,8 +142,9 @@ func NewLiveBot(config *config.LiveBotConfig) (*LiveBot, error) {
 
 
 	// Initialize file logger with debug mode (can be controlled via environment variable)
-	debugMode := os.Getenv("DCA_BOT_DEBUG") == "true"
-	fileLogger, err := logger.NewLoggerWithDebug(symbol, interval, debugMode)
+	logOptions := config.Logging.Options()
+	logOptions.DebugMode = os.Getenv("DCA_BOT_DEBUG") == "true"
+	fileLogger, err := logger.NewLoggerWithOptions(symbol, interval, logOptions)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create logger: %w", err)
 	}
@@ -117,9 +159,14 @@ func NewLiveBot(config *config.LiveBotConfig) (*LiveBot, error) {
 		category: category,
 		balance:  config.Risk.InitialBalance,
 		stopChan: make(chan struct{}),
+		controlChan: make(chan controlRequest),
 		activeTPOrders: make(map[string]*TPOrderInfo),
 		filledTPOrders: make(map[string]*TPOrderInfo),
 		tpOrderMutex: sync.RWMutex{},
+		gridOrders: make(map[string]*GridOrderInfo),
+		orderAttempts: make(map[string]int),
+		notifier: newNotifier(config.Notifications),
+		health:   monitoring.NewHealthChecker(),
 		
 		// Initialize safety infrastructure
 		validator:       safety.NewValidator(),
@@ -134,20 +181,37 @@ func NewLiveBot(config *config.LiveBotConfig) (*LiveBot, error) {
 		return nil, fmt.Errorf("failed to initialize strategy: %w", err)
 	}
 
+	bot.riskGuard = bot.newRiskGuard(config.Risk.Limits())
+
+	if path := config.LedgerPath(); path != "" {
+		store, err := ledger.Open(path)
+		if err != nil {
+			fileLogger.Close()
+			return nil, fmt.Errorf("failed to open trade ledger: %w", err)
+		}
+		bot.ledger = store
+	}
+
 	// Initialize circuit breakers and rate limiters for different exchange operations
-	bot.initializeCircuitBreakers()
+	bot.initializeCircuitBreakers(defaultTradingBreakerTimeout, defaultDataBreakerTimeout)
 	bot.initializeRateLimiters()
 
 	return bot, nil
 }
 
+// Time an open circuit breaker waits before letting a trial call through
+const (
+	defaultTradingBreakerTimeout = 2 * time.Minute
+	defaultDataBreakerTimeout    = 1 * time.Minute
+)
+
 // initializeCircuitBreakers sets up circuit breakers for different exchange operations
-func (bot *LiveBot) initializeCircuitBreakers() {
+func (bot *LiveBot) initializeCircuitBreakers(tradingTimeout, dataTimeout time.Duration) {
 	// Circuit breaker for trading operations (stricter)
 	tradingConfig := safety.CircuitBreakerConfig{
 		FailureThreshold: 3,
 		SuccessThreshold: 2,
-		Timeout:          2 * time.Minute,
+		Timeout:          tradingTimeout,
 		MaxFailures:      5,
 		ResetTimeout:     5 * time.Minute,
 	}
@@ -156,7 +220,7 @@ func (bot *LiveBot) initializeCircuitBreakers() {
 	dataConfig := safety.CircuitBreakerConfig{
 		FailureThreshold: 5,
 		SuccessThreshold: 3,
-		Timeout:          1 * time.Minute,
+		Timeout:          dataTimeout,
 		MaxFailures:      10,
 		ResetTimeout:     3 * time.Minute,
 	}
@@ -187,6 +251,9 @@ func (bot *LiveBot) initializeRateLimiters() {
 	// Rate limiter for account data (moderate)
 	bot.rateLimiters.GetOrCreate("account_data", 20, 20) // 20 capacity, 20 per second refill
 	
+	// Pace the limiters by the quota the exchange reports, when it does
+	bot.watchExchangeRateLimits()
+	
 	bot.logger.Info("🚦 Rate limiters initialized for trading, market data, and account operations")
 }
 
@@ -361,13 +428,30 @@ func (bot *LiveBot) syncStrategyState() {
 	
 	if currentPosition > 0 && avgPrice > 0 {
 		// Active position - sync strategy state with bot state
-		bot.strategy.SetDCALevel(currentDCALevel)
-		bot.strategy.SetLastEntryPrice(avgPrice)
+		if syncer, ok := bot.strategy.(strategy.DCAStateSyncer); ok {
+			syncer.SetDCALevel(currentDCALevel)
+			syncer.SetLastEntryPrice(avgPrice)
+		}
+		if tracker, ok := bot.strategy.(strategy.PositionTracker); ok {
+			tracker.SetAverageEntryPrice(avgPrice)
+		}
+		// Restore the cycle clock after a restart or reload
+		if tracker, ok := bot.strategy.(strategy.CycleAgeTracker); ok {
+			if start := bot.cycleStartTime(); !start.IsZero() {
+				tracker.SetCycleStartTime(start)
+			}
+		}
 	} else {
 		// No position - reset strategy state completely
 		bot.strategy.OnCycleComplete()
 		// Clear filled TP orders tracking for fresh cycle
 		bot.clearFilledTPOrders()
+		// Cancel resting grid buys of the finished cycle
+		if err := bot.cancelGridOrders(); err != nil {
+			bot.logger.LogWarning("Limit Grid", "Error canceling grid orders after cycle completion: %v", err)
+		}
+		// Next order starts a new cycle in client order IDs
+		bot.resetOrderCycle()
 	}
 }
 
@@ -380,6 +464,7 @@ func (bot *LiveBot) Start() error {
 	if err := bot.exchange.Connect(ctx); err != nil {
 		return fmt.Errorf("failed to connect to exchange: %w", err)
 	}
+	bot.health.SetConnected(true)
 
 	// Sync with real account balance if possible
 	if err := bot.syncAccountBalance(); err != nil {
@@ -397,13 +482,20 @@ func (bot *LiveBot) Start() error {
 	if err := bot.syncExistingOrders(); err != nil {
 		bot.logger.LogWarning("Could not sync existing orders", "%v", err)
 	}
+	
+	// Continue the ledger cycle of an existing position
+	bot.restoreLedgerCycle()
 
 	// Print startup information
 	bot.printStartupInfo()
 	bot.printBotConfiguration()
 
 	// Show log file location
-	fmt.Printf("📝 Trading logs: %s\n", bot.logger.GetLogPath())
+	logOptions := bot.config.Logging.Options()
+	fmt.Printf("📝 Trading logs: %s (%s, rotated %s)\n", bot.logger.GetLogPath(), logOptions.Format, logOptions.Rotation)
+	if bot.ledger != nil {
+		fmt.Printf("📒 Trade ledger: %s (query with: live-bot-dca ledger -db %s stats)\n", bot.ledger.Path(), bot.ledger.Path())
+	}
 	fmt.Printf("🔄 Bot is running... (trading activity logged to file)\n\n")
 
 	// Start the main trading loop
@@ -441,6 +533,14 @@ func (bot *LiveBot) Stop() {
 	go func() {
 		defer close(cleanupDone)
 		
+		// Cancel resting grid buys so they cannot reopen the position
+		if err := bot.cancelGridOrders(); err != nil {
+			fmt.Printf("⚠️ Error canceling grid orders: %v\n", err)
+			if bot.logger != nil {
+				bot.logger.Error("Error canceling grid orders during shutdown: %v", err)
+			}
+		}
+		
 		// Cancel all active TP orders before closing positions
 		fmt.Printf("🧹 Cleaning up TP orders...\n")
 		if err := bot.cancelAllTPOrders(); err != nil {
@@ -485,150 +585,113 @@ func (bot *LiveBot) Stop() {
 
 // initializeStrategy sets up the trading strategy with indicators
 func (bot *LiveBot) initializeStrategy() error {
-	// Create strategy
-	bot.strategy = strategy.NewEnhancedDCAStrategy(bot.config.Strategy.BaseAmount)
+	strat, spacingStrategy, err := bot.buildStrategy(bot.config)
+	if err != nil {
+		return err
+	}
 	
-	// Configure DCA spacing strategy
-	if bot.config.Strategy.DCASpacing == nil {
-		return fmt.Errorf("DCA spacing configuration is required")
+	bot.strategy = strat
+	bot.spacingStrategy = spacingStrategy
+	return nil
+}
+
+// buildStrategy creates the strategy cfg selects from the strategy registry,
+// the same one a backtest of cfg runs, without touching the running bot so a
+// config reload can be validated first. The spacing strategy is nil for
+// strategies that do not space DCA entries.
+func (bot *LiveBot) buildStrategy(cfg *config.LiveBotConfig) (strategy.Strategy, spacing.DCASpacingStrategy, error) {
+	strat, err := strategy.New(cfg.DCAConfig())
+	if err != nil {
+		return nil, nil, err
 	}
 
-	spacingConfig := spacing.SpacingConfig{
-		Strategy:   bot.config.Strategy.DCASpacing.Strategy,
-		Parameters: bot.config.Strategy.DCASpacing.Parameters,
+	var spacingStrategy spacing.DCASpacingStrategy
+	if provider, ok := strat.(strategy.SpacingProvider); ok {
+		spacingStrategy = provider.GetSpacingStrategy()
 	}
-	
-	spacingStrategy, err := spacing.CreateSpacingStrategy(spacingConfig)
-	if err != nil {
-		return fmt.Errorf("failed to create spacing strategy: %w", err)
+
+	// Higher timeframes must map to exchange kline intervals
+	if mtf, ok := strat.(strategy.MultiTimeframeStrategy); ok {
+		if err := checkTimeframeIntervals(mtf.RequiredTimeframes()); err != nil {
+			return nil, nil, err
+		}
 	}
-	
-	// Validate strategy configuration
-	if err := spacingStrategy.ValidateConfig(); err != nil {
-		return fmt.Errorf("invalid spacing strategy configuration: %w", err)
+
+	bot.logStrategy(cfg, strat, spacingStrategy)
+	return strat, spacingStrategy, nil
+}
+
+// logStrategy logs the strategy settings in effect
+func (bot *LiveBot) logStrategy(cfg *config.LiveBotConfig, strat strategy.Strategy, spacingStrategy spacing.DCASpacingStrategy) {
+	bot.logger.Info("🧠 Using %s strategy", strat.GetName())
+	if len(cfg.Strategy.Params) > 0 {
+		bot.logger.Info("🧠 Strategy parameters: %v", cfg.Strategy.Params)
+	}
+	if spacingStrategy != nil {
+		bot.logger.Info("✅ Using %s spacing strategy", spacingStrategy.GetName())
 	}
-	
-	// Set the spacing strategy in both bot and enhanced strategy
-	bot.spacingStrategy = spacingStrategy
-	bot.strategy.SetSpacingStrategy(spacingStrategy)
-	bot.logger.Info("✅ Using %s spacing strategy", spacingStrategy.GetName())
 
-	// Configure dynamic TP if enabled
-	if bot.config.Strategy.DynamicTP != nil {
-		bot.strategy.SetDynamicTPConfig(bot.config.Strategy.DynamicTP)
-		if bot.strategy.IsDynamicTPEnabled() {
-			bot.logger.Info("✅ Dynamic TP enabled: %s strategy", bot.config.Strategy.DynamicTP.Strategy)
-			bot.logger.LogDebugOnly("🔍 Dynamic TP Config: Strategy=%s, BaseTP=%.3f%%", 
-				bot.config.Strategy.DynamicTP.Strategy, bot.config.Strategy.DynamicTP.BaseTPPercent*100)
-			
+	if budget := cfg.LadderBudget(); budget != nil {
+		bot.logger.Info("🪜 Using %s ladder sizing: %s", cfg.Strategy.PositionSizing.Mode, budget)
+		if warning := budget.Warning(); warning != "" {
+			bot.logger.LogWarning("Ladder Budget", "%s", warning)
+		}
+	}
+	if cfg.Strategy.SignalAggregation != nil {
+		bot.logger.Info("🗳️ Using %s signal aggregation", cfg.Strategy.SignalAggregation.ModeName())
+	}
+	if planner, ok := strat.(strategy.GridPlanner); ok && planner.IsGridEnabled() {
+		bot.logger.Info("🧱 Using limit-order DCA grid: %d levels resting", cfg.Strategy.LimitGrid.GridLevels())
+	}
+	if conditions := cfg.Strategy.Conditions; !conditions.IsEmpty() {
+		bot.logger.Info("🧮 Using expression conditions: %d entry, %d veto, %d TP modifier(s)",
+			len(conditions.Entry), len(conditions.Veto), len(conditions.TPModifiers))
+	}
+	if mtf, ok := strat.(strategy.MultiTimeframeStrategy); ok {
+		if reqs := mtf.RequiredTimeframes(); len(reqs) > 0 {
+			bot.logger.Info("🕰️ Confirming entries on higher timeframes: %s", describeTimeframes(reqs))
+		}
+	}
+
+	if dynamicTP := cfg.Strategy.DynamicTP; dynamicTP != nil {
+		if strat.IsDynamicTPEnabled() {
+			bot.logger.Info("✅ Dynamic TP enabled: %s strategy", dynamicTP.Strategy)
+			bot.logger.LogDebugOnly("🔍 Dynamic TP Config: Strategy=%s, BaseTP=%.3f%%",
+				dynamicTP.Strategy, dynamicTP.BaseTPPercent*100)
+
 			// Log strategy-specific parameters
-			if bot.config.Strategy.DynamicTP.VolatilityConfig != nil {
-				vc := bot.config.Strategy.DynamicTP.VolatilityConfig
+			if vc := dynamicTP.VolatilityConfig; vc != nil {
 				bot.logger.LogDebugOnly("🔍 Volatility Config: Multiplier=%.2f, MinTP=%.2f%%, MaxTP=%.2f%%, ATRPeriod=%d",
 					vc.Multiplier, vc.MinTPPercent*100, vc.MaxTPPercent*100, vc.ATRPeriod)
 			}
-			
-			if bot.config.Strategy.DynamicTP.IndicatorConfig != nil {
-				ic := bot.config.Strategy.DynamicTP.IndicatorConfig
+			if ic := dynamicTP.IndicatorConfig; ic != nil {
 				bot.logger.LogDebugOnly("🔍 Indicator Config: StrengthMult=%.2f, MinTP=%.2f%%, MaxTP=%.2f%%, Weights=%v",
 					ic.StrengthMultiplier, ic.MinTPPercent*100, ic.MaxTPPercent*100, ic.Weights)
 			}
+			if tc := dynamicTP.TimeDecayConfig; tc != nil {
+				bot.logger.Info("⏳ Time-decay TP: %.2f%% decays to %.2f%% over %.1f days after a %.1f-day grace",
+					dynamicTP.BaseTPPercent*100, tc.FloorPercent*100, tc.DecayDays, tc.GraceDays)
+				if tc.BreakEvenLevels > 0 {
+					bot.logger.Info("⏳ Time-decay TP: floor after %d DCA entries", tc.BreakEvenLevels)
+				}
+			}
 		} else {
-			bot.logger.Info("🔧 Dynamic TP configured but not enabled (strategy: %s)", bot.config.Strategy.DynamicTP.Strategy)
+			bot.logger.Info("🔧 Dynamic TP configured but not enabled (strategy: %s)", dynamicTP.Strategy)
 		}
+	} else if strat.IsDynamicTPEnabled() {
+		bot.logger.Info("🔧 Using fixed TP scaled by TP modifier conditions")
 	} else {
 		bot.logger.Info("🔧 Using fixed TP strategy")
 	}
 
-	// Add indicators based on configuration
-	bot.logger.Info("🔧 Initializing %d indicators: %v", len(bot.config.Strategy.Indicators), bot.config.Strategy.Indicators)
-	for _, indName := range bot.config.Strategy.Indicators {
-		bot.logger.Info("🔧 Processing indicator: '%s'", indName)
-		switch strings.ToLower(indName) {
-		case "rsi":
-			rsi := oscillators.NewRSI(bot.config.Strategy.RSI.Period)
-			rsi.SetOversold(bot.config.Strategy.RSI.Oversold)
-			rsi.SetOverbought(bot.config.Strategy.RSI.Overbought)
-			bot.strategy.AddIndicator(rsi)
-			bot.logger.Info("✅ RSI indicator added successfully")
-		case "macd":
-			macd := oscillators.NewMACD(
-				bot.config.Strategy.MACD.FastPeriod,
-				bot.config.Strategy.MACD.SlowPeriod,
-				bot.config.Strategy.MACD.SignalPeriod,
-			)
-			bot.strategy.AddIndicator(macd)
-			bot.logger.Info("✅ MACD indicator added successfully")
-		case "bb", "bollinger":
-			bb := bands.NewBollingerBands(
-				bot.config.Strategy.BollingerBands.Period,
-				bot.config.Strategy.BollingerBands.StdDev,
-			)
-			bot.strategy.AddIndicator(bb)
-			bot.logger.Info("✅ Bollinger Bands indicator added successfully")
-		case "ema":
-			ema := common.NewEMA(bot.config.Strategy.EMA.Period)
-			bot.strategy.AddIndicator(ema)
-			bot.logger.Info("✅ EMA indicator added successfully")
-		case "sma":
-			sma := common.NewSMA(bot.config.Strategy.EMA.Period)
-			bot.strategy.AddIndicator(sma)
-			bot.logger.Info("✅ SMA indicator added successfully")
-		case "hull_ma", "hullma":
-			hullMA := trend.NewHullMA(bot.config.Strategy.HullMA.Period)
-			bot.strategy.AddIndicator(hullMA)
-			bot.logger.Info("✅ Hull MA indicator added successfully")
-		case "mfi":
-			mfi := oscillators.NewMFIWithPeriod(bot.config.Strategy.MFI.Period)
-			mfi.SetOversold(bot.config.Strategy.MFI.Oversold)
-			mfi.SetOverbought(bot.config.Strategy.MFI.Overbought)
-			bot.strategy.AddIndicator(mfi)
-			bot.logger.Info("✅ MFI indicator added successfully")
-		case "keltner_channels", "keltner", "kc":
-			keltner := bands.NewKeltnerChannelsCustom(
-				bot.config.Strategy.Keltner.Period,
-				bot.config.Strategy.Keltner.Multiplier,
-			)
-			bot.strategy.AddIndicator(keltner)
-			bot.logger.Info("✅ Keltner Channels indicator added successfully")
-		case "wavetrend", "wt":
-			wavetrend := oscillators.NewWaveTrendCustom(
-				bot.config.Strategy.WaveTrend.N1,
-				bot.config.Strategy.WaveTrend.N2,
-			)
-			wavetrend.SetOverbought(bot.config.Strategy.WaveTrend.Overbought)
-			wavetrend.SetOversold(bot.config.Strategy.WaveTrend.Oversold)
-			bot.strategy.AddIndicator(wavetrend)
-			bot.logger.Info("✅ WaveTrend indicator added successfully")
-		case "supertrend", "st":
-			supertrend := trend.NewSuperTrendWithParams(
-				bot.config.Strategy.SuperTrend.Period,
-				bot.config.Strategy.SuperTrend.Multiplier,
-			)
-			bot.strategy.AddIndicator(supertrend)
-			bot.logger.Info("✅ SuperTrend indicator added successfully")
-		case "obv":
-			obv := volume.NewOBVWithThreshold(bot.config.Strategy.OBV.TrendThreshold)
-			bot.strategy.AddIndicator(obv)
-			bot.logger.Info("✅ OBV indicator added successfully")
-		case "stochrsi", "stochastic_rsi", "stoch_rsi":
-			stochRSI := oscillators.NewStochasticRSIWithThresholds(
-				bot.config.Strategy.StochasticRSI.Period,
-				bot.config.Strategy.StochasticRSI.Overbought,
-				bot.config.Strategy.StochasticRSI.Oversold,
-			)
-			bot.strategy.AddIndicator(stochRSI)
-			bot.logger.Info("✅ Stochastic RSI indicator added successfully")
-		default:
-			bot.logger.Info("❌ Unknown indicator: '%s'", indName)
+	if reporter, ok := strat.(strategy.IndicatorReporter); ok {
+		bot.logger.Info("🔧 Indicators configured: %v", cfg.Strategy.Indicators)
+		if count := reporter.GetIndicatorCount(); count < len(cfg.Strategy.Indicators) {
+			bot.logger.LogWarning("Indicators", "Only %d of %d configured indicators are known: %v",
+				count, len(cfg.Strategy.Indicators), cfg.Strategy.Indicators)
 		}
+		bot.logger.Info("🎯 Strategy initialization complete: %d indicators active", reporter.GetIndicatorCount())
 	}
-	
-	// Log final indicator count
-	indicatorCount := bot.strategy.GetIndicatorCount()
-	bot.logger.Info("🎯 Strategy initialization complete: %d indicators active", indicatorCount)
-	
-	return nil
 }
 
 // syncAccountBalance syncs bot balance with real exchange balance
@@ -658,6 +721,9 @@ func (bot *LiveBot) syncAccountBalance() error {
 	}
 	
 	// Update bot balance
+	if realBalance != bot.balance {
+		bot.logger.LogBalanceSync(bot.balance, realBalance)
+	}
 	bot.balance = realBalance
 	return nil
 }
@@ -739,13 +805,20 @@ func (bot *LiveBot) tradingLoop() {
 	waitTimer := time.NewTimer(waitDuration)
 	defer waitTimer.Stop()
 	
-	select {
-	case <-waitTimer.C:
-		// Timer expired - continue to initial check
-		bot.checkAndTrade()
-	case <-bot.stopChan:
-		bot.logger.Info("Stop signal received during initial wait - ending trading loop")
-		return
+initialWait:
+	for {
+		select {
+		case <-waitTimer.C:
+			// Timer expired - continue to initial check
+			bot.checkAndTrade()
+			break initialWait
+		case req := <-bot.controlChan:
+			// Control commands are served while waiting for the first candle
+			bot.handleControlRequest(req)
+		case <-bot.stopChan:
+			bot.logger.Info("Stop signal received during initial wait - ending trading loop")
+			return
+		}
 	}
 
 	// Create ticker for regular checks
@@ -761,6 +834,8 @@ func (bot *LiveBot) tradingLoop() {
 				return
 			}
 			bot.checkAndTrade()
+		case req := <-bot.controlChan:
+			bot.handleControlRequest(req)
 		case <-bot.stopChan:
 			bot.logger.Info("Stop signal received - ending trading loop")
 			return
@@ -806,6 +881,7 @@ func (bot *LiveBot) checkAndTrade() {
 		if err.Error() == "STRATEGY_SYNC_REQUIRED" {
 			// Position was reset, strategy sync is needed
 			bot.syncStrategyState()
+			bot.recordCycleClose()
 		} else {
 			bot.logger.LogWarning("Could not sync position data", "%v", err)
 		}
@@ -816,17 +892,28 @@ func (bot *LiveBot) checkAndTrade() {
 	// This ensures the strategy has current DCA level and last entry price
 	bot.syncStrategyState()
 
+	// Limit-grid mode: pick up filled grid buys and keep the grid resting
+	bot.checkGridFills()
+	bot.ensureGridOrders()
+
+	// Time-decay TP: lower resting TP orders as the cycle ages
+	bot.checkTPDecay()
+
 	// Check for stop signal after position sync
 	if bot.shouldStop() {
 		return
 	}
 
+	// Track account equity for the drawdown limit
+	bot.updateRiskEquity(ctx)
+
 	// Get current market price
 	currentPrice, err := bot.exchange.GetLatestPrice(ctx, bot.symbol)
 	if err != nil {
 		bot.logger.Error("Failed to get current price: %v", err)
 		return
 	}
+	bot.health.UpdatePrice(currentPrice)
 
 	// Get recent klines for analysis
 	klines, err := bot.getRecentKlines()
@@ -852,23 +939,31 @@ func (bot *LiveBot) checkAndTrade() {
 		bot.logger.LogWarning("Limited data", "Using %d data points (less than configured %d, but sufficient for analysis)", len(klines), bot.config.Strategy.WindowSize)
 	}
 
+	// Higher timeframe candles that have closed, for entry confirmation
+	bot.feedTimeframes()
+
 	// Analyze market conditions with detailed logging
 	decision, action := bot.analyzeMarket(klines, currentPrice)
 	
 	// Log detailed market analysis for debugging
 	if decision != nil {
 		// Get indicator results for detailed logging
-		indicatorResults := bot.strategy.GetLastResults()
 		indicatorMap := make(map[string]interface{})
-		for name, result := range indicatorResults {
-			if result.Error != nil {
-				indicatorMap[name] = fmt.Sprintf("ERROR: %v", result.Error)
-			} else {
-				bias := bot.interpretIndicatorBias(name, result.Value, currentPrice)
-				indicatorMap[name] = bias
+		if reporter, ok := bot.strategy.(strategy.IndicatorReporter); ok {
+			for name, result := range reporter.GetLastResults() {
+				if result.Error != nil {
+					indicatorMap[name] = fmt.Sprintf("ERROR: %v", result.Error)
+				} else {
+					bias := bot.interpretIndicatorBias(name, result.Value, currentPrice)
+					indicatorMap[name] = bias
+				}
 			}
 		}
 		
+		if len(decision.Trace) > 0 {
+			indicatorMap["conditions"] = decision.Trace
+		}
+		
 		// Convert klines to interface{} for logging
 		klinesInterface := make([]interface{}, len(klines))
 		for i, kline := range klines {
@@ -990,6 +1085,9 @@ func (bot *LiveBot) analyzeMarket(klines []types.OHLCV, currentPrice float64) (*
 	if decision.Action == strategy.ActionBuy {
 		bot.logger.Info("🎯 BUY Signal: %s (Confidence: %.1f%%, Strength: %.1f%%)", 
 			decision.Reason, decision.Confidence*100, decision.Strength*100)
+		for _, line := range decision.Trace {
+			bot.logger.Info("🔎 %s", line)
+		}
 		// Reset hold log counter so next HOLD decision is logged
 		bot.holdLogCounter = 0
 		return decision, "BUY"
@@ -1002,6 +1100,9 @@ func (bot *LiveBot) analyzeMarket(klines []types.OHLCV, currentPrice float64) (*
 		
 		if currentDCALevel == 0 || bot.holdLogCounter%10 == 0 {
 			bot.logger.Info("⏸️ HOLD Decision: %s", decision.Reason)
+			for _, line := range decision.Trace {
+				bot.logger.LogDebugOnly("🔎 %s", line)
+			}
 		}
 		bot.holdLogCounter++
 		return decision, "HOLD"
@@ -1015,6 +1116,14 @@ func (bot *LiveBot) analyzeMarket(klines []types.OHLCV, currentPrice float64) (*
 func (bot *LiveBot) executeTrade(decision *strategy.TradeDecision, action string, price float64) {
 	switch action {
 	case "BUY":
+		if bot.riskGuard.ShouldStopTrading(&risk.Portfolio{Symbol: bot.symbol}) {
+			bot.logger.Info("⏸️ BUY signal at $%.2f skipped - DCA entries paused by risk guard", price)
+			return
+		}
+		if bot.EntriesPaused() {
+			bot.logger.Info("⏸️ BUY signal at $%.2f skipped - DCA entries paused via control API", price)
+			return
+		}
 		bot.executeBuy(decision, price)
 	case "SELL":
 		bot.executeSell(price)
@@ -1038,8 +1147,9 @@ func (bot *LiveBot) executeBuy(decision *strategy.TradeDecision, price float64)
 	currentAvgPrice := bot.averagePrice
 	bot.positionMutex.RUnlock()
 	
-	// DCA spacing strategy validation with proper market context
-	if currentDCALevel > 0 && currentAvgPrice > 0 {
+	// DCA spacing strategy validation with proper market context (strategies
+	// without DCA spacing leave averaging to their own signals)
+	if currentDCALevel > 0 && currentAvgPrice > 0 && bot.spacingStrategy != nil {
 		// Get recent klines for spacing strategy context
 		recentCandles, err := bot.getRecentKlines()
 		if err != nil {
@@ -1096,8 +1206,13 @@ func (bot *LiveBot) executeBuy(decision *strategy.TradeDecision, price float64)
 	// - Max multiplier constraints
 	
 	// Log position sizing info
-	bot.logger.Info("🧠 Strategy Position Sizing: Confidence: %.1f%%, Strength: %.1f%%, DCA Level: %d, Amount: $%.2f", 
-		decision.Confidence*100, decision.Strength*100, currentDCALevelForLogging, amount)
+	if bot.config.Strategy.PositionSizing.IsLadder() {
+		bot.logger.Info("🪜 Ladder Position Sizing: Mode: %s, DCA Level: %d, Amount: $%.2f", 
+			bot.config.Strategy.PositionSizing.Mode, currentDCALevelForLogging, amount)
+	} else {
+		bot.logger.Info("🧠 Strategy Position Sizing: Confidence: %.1f%%, Strength: %.1f%%, DCA Level: %d, Amount: $%.2f", 
+			decision.Confidence*100, decision.Strength*100, currentDCALevelForLogging, amount)
+	}
 
 	// Get trading constraints
 	constraints, err := bot.exchange.GetTradingConstraints(ctx, bot.category, bot.symbol)
@@ -1174,6 +1289,13 @@ func (bot *LiveBot) executeBuy(decision *strategy.TradeDecision, price float64)
 		return
 	}
 
+	// Account-level risk limits
+	if err := bot.checkRiskLimits(amount, price); err != nil {
+		bot.logger.Info("🛡️ RISK GUARD: Blocking buy of $%.2f at $%.2f - %v", amount, price, err)
+		return
+	}
+	bot.markCycleStart()
+
 	// Place order with timeout and retry
 	orderParams := exchange.OrderParams{
 		Category:  bot.category,
@@ -1181,6 +1303,7 @@ func (bot *LiveBot) executeBuy(decision *strategy.TradeDecision, price float64)
 		Side:      exchange.OrderSideBuy,
 		Quantity:  fmt.Sprintf("%.6f", quantity),
 		OrderType: exchange.OrderTypeMarket,
+		ClientOrderID: bot.clientOrderBase(orderKindDCA, currentDCALevelForLogging+1),
 	}
 
 	// Log execution now that all checks have passed
@@ -1226,6 +1349,7 @@ func (bot *LiveBot) executeBuy(decision *strategy.TradeDecision, price float64)
 
 	// Log order placement result (execution details will be synced from exchange)
 	bot.logger.Info("📤 Order placed successfully - ID: %s, syncing actual execution from exchange...", order.OrderID)
+	bot.health.UpdateLastTrade(time.Now())
 
 	// Sync with exchange data first to get actual executed values
 	bot.syncAfterTrade(order, "BUY")
@@ -1294,6 +1418,13 @@ func (bot *LiveBot) executeBuy(decision *strategy.TradeDecision, price float64)
 	} else if bot.config.Strategy.AutoTPOrders && currentDCALevelForTP > 1 {
 		bot.logger.Info("🔄 DCA trade detected - TP orders will be updated by syncAfterTrade process")
 	}
+
+	// Rest the next DCA levels as limit buys anchored at this entry
+	if bot.gridPlanner() != nil {
+		if err := bot.placeGridOrders(); err != nil {
+			bot.logger.LogWarning("Limit Grid", "Could not place grid orders: %v", err)
+		}
+	}
 }
 
 // placeMultiLevelTPOrders places multiple take profit limit orders after a buy
@@ -1329,6 +1460,10 @@ func (bot *LiveBot) placeMultiLevelTPOrders(totalQuantity string, avgEntryPrice
 	done := make(chan error, 1)
 	
 	go func() {
+		if bot.usePositionTPSL() {
+			done <- bot.setPositionTPSLInternal(ctx, totalQuantity, avgEntryPrice)
+			return
+		}
 		done <- bot.placeMultiLevelTPOrdersInternal(ctx, totalQuantity, avgEntryPrice)
 	}()
 	
@@ -1372,6 +1507,9 @@ func (bot *LiveBot) placeMultiLevelTPOrdersInternal(ctx context.Context, totalQu
 	bot.logger.Info("🎯 Placing %d-level TP orders from avg entry $%.4f: %.6f %s total", 
 		bot.config.Strategy.TPLevels, avgEntryPrice, totalQty, bot.symbol)
 	
+	// Re-placed TP orders need fresh client order IDs
+	bot.beginTPRevision()
+	
 	successCount := 0
 	skippedLevels := 0
 	
@@ -1498,6 +1636,7 @@ func (bot *LiveBot) placeMultiLevelTPOrdersInternal(ctx context.Context, totalQu
 			Quantity:  formattedQty,
 			OrderType: exchange.OrderTypeLimit,
 			Price:     formattedPrice,
+			ClientOrderID: bot.clientOrderBase(orderKindTP, level),
 		}
 		
 		// Place TP limit order with timing
@@ -1523,6 +1662,7 @@ func (bot *LiveBot) placeMultiLevelTPOrdersInternal(ctx context.Context, totalQu
 				OrderID:   tpOrder.OrderID,
 				Filled:    false,
 				FilledQty: "0",
+				ClientOrderID: tpOrder.ClientOrderID,
 			}
 		}()
 		
@@ -1578,6 +1718,11 @@ func (bot *LiveBot) placeMultiLevelTPOrdersInternal(ctx context.Context, totalQu
 
 // updateMultiLevelTPOrders updates existing multi-level TP orders when average price changes
 func (bot *LiveBot) updateMultiLevelTPOrders(newAveragePrice float64) error {
+	// Exchange-native position TP/SL is amended in place rather than cancelled and re-placed
+	if bot.usePositionTPSL() {
+		return bot.updatePositionTPSL(newAveragePrice)
+	}
+
 	bot.logger.Info("🔄 Updating TP orders for new average price $%.4f", newAveragePrice)
 	
 	// First, collect order information and clear maps while holding mutex
@@ -1636,6 +1781,11 @@ func (bot *LiveBot) updateMultiLevelTPOrders(newAveragePrice float64) error {
 			continue
 		}
 		
+		// The exchange sync above re-tracks still-open orders; drop the cancelled
+		// one so placement cleanup does not cancel it a second time
+		bot.tpOrderMutex.Lock()
+		delete(bot.activeTPOrders, orderID)
+		bot.tpOrderMutex.Unlock()
 		cancelledCount++
 	}
 	
@@ -1728,6 +1878,7 @@ func (bot *LiveBot) executeSell(price float64) {
 		Side:      exchange.OrderSideSell,
 		Quantity:  positionSize, // Use exact position size from exchange
 		OrderType: exchange.OrderTypeMarket,
+		ClientOrderID: bot.nextClientOrderID(orderKindExit, bot.dcaLevel),
 	}
 
 	order, err := bot.exchange.PlaceMarketOrder(ctx, orderParams)
@@ -1750,6 +1901,9 @@ func (bot *LiveBot) syncAfterTrade(order *exchange.Order, tradeType string) {
 		time.Sleep(500 * time.Millisecond)
 	}
 	
+	// Record the fill before a position sync can close its ledger cycle
+	bot.recordLedgerFill(order, tradeType)
+	
 	// Sync balance with exchange
 	if err := bot.syncAccountBalance(); err != nil {
 		bot.logger.LogWarning("Could not refresh balance after trade", "%v", err)
@@ -1765,6 +1919,7 @@ func (bot *LiveBot) syncAfterTrade(order *exchange.Order, tradeType string) {
 		if err.Error() == "STRATEGY_SYNC_REQUIRED" {
 			// Position was reset, strategy sync is needed
 			bot.syncStrategyState()
+			bot.recordCycleClose()
 		} else {
 			bot.logger.LogWarning("Could not sync position after trade", "%v", err)
 		}
@@ -1806,7 +1961,7 @@ func (bot *LiveBot) syncAfterTrade(order *exchange.Order, tradeType string) {
 		executedPrice := fmt.Sprintf("%.4f", avgPrice)
 		executedValue := fmt.Sprintf("%.2f", currentPosition)
 		
-		bot.logger.LogTradeExecution(tradeType, order.OrderID, executedQty, executedPrice, executedValue, currentDCALevel, currentPosition, avgPrice)
+		bot.logger.LogTradeExecution(tradeType, order.OrderID, order.ClientOrderID, executedQty, executedPrice, executedValue, currentDCALevel, currentPosition, avgPrice)
 		
 		// Update multi-level TP orders for DCA trades (level >= 2) where average price changes
 		// Note: We do this in syncAfterTrade to ensure it happens after position sync
@@ -1885,7 +2040,7 @@ func (bot *LiveBot) syncAfterTrade(order *exchange.Order, tradeType string) {
 		}
 		
 		// Log trade execution details
-		bot.logger.LogTradeExecution(tradeType, order.OrderID, order.CumExecQty, order.AvgPrice, order.CumExecValue, 0, 0, 0)
+		bot.logger.LogTradeExecution(tradeType, order.OrderID, order.ClientOrderID, order.CumExecQty, order.AvgPrice, order.CumExecValue, 0, 0, 0)
 		
 		// Reset internal counters after sell with mutex protection
 		bot.positionMutex.Lock()
@@ -1923,27 +2078,52 @@ func (bot *LiveBot) syncExistingOrders() error {
 		return bot.cancelOrphanedOrders(orders)
 	}
 	
+	// Continue the cycle the surviving orders belong to
+	bot.positionMutex.RLock()
+	hasPosition := bot.currentPosition > 0
+	bot.positionMutex.RUnlock()
+	if hasPosition {
+		bot.adoptClientOrderIDs(orders)
+	}
+	
 	// Sync existing orders instead of canceling
 	tpOrderCount := 0
+	gridOrderCount := 0
 	otherOrderCount := 0
 	
 	bot.tpOrderMutex.Lock()
 	defer bot.tpOrderMutex.Unlock()
 	
 	for _, order := range orders {
-		// Check if this looks like a TP order (sell side, limit order)
-		if order.Side == "Sell" && order.OrderType == "Limit" {
+		// Our own orders are identified by client order ID, others by side and type
+		isTP := order.Side == "Sell" && order.OrderType == "Limit"
+		level := 0 // Unknown level
+		if order.ClientOrderID != "" {
+			ref, ok := parseClientOrderID(order.ClientOrderID)
+			isTP = ok && ref.Symbol == clientOrderSymbol(bot.symbol) && ref.isTP()
+			level = ref.Level
+			
+			// Resting grid buys of the current cycle keep being managed
+			if ok && ref.Symbol == clientOrderSymbol(bot.symbol) && ref.Kind == orderKindGrid && hasPosition {
+				bot.adoptGridOrder(order, ref.Level)
+				gridOrderCount++
+				continue
+			}
+		}
+		
+		if isTP {
 			tpOrderCount++
 			
 			// Try to reconstruct TP order info
-			// Note: We can't perfectly reconstruct level/percent without more context
+			// Note: We can't perfectly reconstruct percent without more context
 			// but we can track the order for cancellation purposes
 			bot.activeTPOrders[order.OrderID] = &TPOrderInfo{
-				Level:     0, // Unknown level
+				Level:     level,
 				Percent:   0, // Unknown percent
 				Quantity:  order.Quantity,
 				Price:     order.Price,
 				OrderID:   order.OrderID,
+				ClientOrderID: order.ClientOrderID,
 			}
 			
 		} else {
@@ -1954,6 +2134,9 @@ func (bot *LiveBot) syncExistingOrders() error {
 	if tpOrderCount > 0 {
 		fmt.Printf("🎯 Synced %d existing TP orders\n", tpOrderCount)
 	}
+	if gridOrderCount > 0 {
+		fmt.Printf("🧱 Synced %d existing grid orders\n", gridOrderCount)
+	}
 	if otherOrderCount > 0 {
 		fmt.Printf("📋 Found %d other orders (not TP orders)\n", otherOrderCount)
 	}
@@ -2110,8 +2293,12 @@ func (bot *LiveBot) syncTPOrdersFromExchange(ctx context.Context) error {
 	for orderID, order := range exchangeTPOrders {
 		if _, exists := bot.activeTPOrders[orderID]; !exists {
 			// Found TP order we weren't tracking
+			level := 0 // Unknown level unless the client order ID tells us
+			if ref, ok := parseClientOrderID(order.ClientOrderID); ok {
+				level = ref.Level
+			}
 			bot.activeTPOrders[orderID] = &TPOrderInfo{
-				Level:     0, // Unknown level
+				Level:     level,
 				Percent:   0, // Unknown percent
 				Quantity:  order.Quantity,
 				Price:     order.Price,
@@ -2335,6 +2522,13 @@ func (bot *LiveBot) isTPOrder(order *exchange.Order, currentAvgPrice float64) bo
 		return false
 	}
 	
+	// Orders carrying a client order ID are identified by it; price heuristics
+	// below only apply to orders placed without one
+	if order.ClientOrderID != "" {
+		ref, ok := parseClientOrderID(order.ClientOrderID)
+		return ok && ref.Symbol == clientOrderSymbol(bot.symbol) && ref.isTP()
+	}
+	
 	// Parse order price
 	orderPrice, err := parseFloat(order.Price)
 	if err != nil {
@@ -2422,6 +2616,7 @@ func (bot *LiveBot) detectFilledTPOrders() []string {
 	// Find filled TP orders (in our tracking but not on exchange)
 	bot.tpOrderMutex.Lock()
 	var filledOrderDetails []string
+	var filledTPOrders []*TPOrderInfo
 	
 	for orderID, tpInfo := range bot.activeTPOrders {
 		if !activeOnExchange[orderID] {
@@ -2443,6 +2638,7 @@ func (bot *LiveBot) detectFilledTPOrders() []string {
 				// Create detailed string for display
 				filledDetail := fmt.Sprintf("TP%d@$%s(%.1f%%)", tpInfo.Level, tpInfo.Price, tpInfo.Percent*100)
 				filledOrderDetails = append(filledOrderDetails, filledDetail)
+				filledTPOrders = append(filledTPOrders, tpInfo)
 			} else {
 				// Invalid TP order data - log warning
 				bot.logger.LogWarning("TP Fill Detection", "Invalid TP order data detected - Level: %d, Percent: %.4f, OrderID: %s", 
@@ -2456,6 +2652,18 @@ func (bot *LiveBot) detectFilledTPOrders() []string {
 	
 	bot.tpOrderMutex.Unlock()
 	
+	for _, tpInfo := range filledTPOrders {
+		bot.recordLedgerFill(&exchange.Order{
+			OrderID:       tpInfo.OrderID,
+			ClientOrderID: tpInfo.ClientOrderID,
+			Side:          exchange.OrderSideSell,
+			OrderType:     exchange.OrderTypeLimit,
+			Quantity:      tpInfo.Quantity,
+			Price:         tpInfo.Price,
+			OrderStatus:   "Filled",
+		}, ledger.SideSell)
+	}
+	
 	return filledOrderDetails
 }
 
@@ -2590,15 +2798,37 @@ func (bot *LiveBot) placeOrderWithRetry(params exchange.OrderParams, isMarket bo
 	defer cancel()
 	
 	var result *exchange.Order
+	baseID := params.ClientOrderID
 	
 	// Use recovery handler for intelligent retry with backoff and circuit breaker protection
 	err := bot.recoveryHandler.ExecuteWithRecovery(ctx, "OrderPlacement", "PlaceOrder", func() error {
 		var orderErr error
 		
+		attemptParams := params
+		if baseID != "" {
+			attempt := bot.nextClientOrderAttempt(baseID)
+			
+			// An earlier attempt may have been accepted even though we saw an error
+			// (e.g. a timeout after the exchange received it) - never place it twice
+			if attempt > 1 {
+				existing, lookupErr := bot.findPlacedOrder(ctx, params.Category, params.Symbol, baseID, attempt-1)
+				if lookupErr != nil {
+					return lookupErr
+				}
+				if existing != nil {
+					bot.logger.Info("♻️ Order %s already on exchange (ID: %s, status: %s) - not placing again",
+						existing.ClientOrderID, existing.OrderID, existing.OrderStatus)
+					result = existing
+					return nil
+				}
+			}
+			attemptParams.ClientOrderID = clientOrderAttemptID(baseID, attempt)
+		}
+		
 		if isMarket {
-			result, orderErr = bot.protectedPlaceMarketOrder(ctx, params)
+			result, orderErr = bot.protectedPlaceMarketOrder(ctx, attemptParams)
 		} else {
-			result, orderErr = bot.protectedPlaceLimitOrder(ctx, params)
+			result, orderErr = bot.protectedPlaceLimitOrder(ctx, attemptParams)
 		}
 		
 		return orderErr
@@ -2608,6 +2838,23 @@ func (bot *LiveBot) placeOrderWithRetry(params exchange.OrderParams, isMarket bo
 		return nil, fmt.Errorf("order placement failed after retries: %w", err)
 	}
 	
+	if result != nil {
+		fields := logger.Fields{
+			logger.FieldOrderID:       result.OrderID,
+			logger.FieldClientOrderID: result.ClientOrderID,
+			"order_type":              params.OrderType,
+			"side":                    params.Side,
+			"quantity":                params.Quantity,
+			"price":                   params.Price,
+		}
+		if ref, ok := parseClientOrderID(result.ClientOrderID); ok {
+			fields["kind"] = ref.Kind
+			fields["level"] = ref.Level
+		}
+		bot.logger.Event(logger.LogLevelExchange, logger.EventOrderPlaced, fields,
+			"%s %s order placed: %s %s - OrderID: %s", params.OrderType, params.Side, params.Quantity, bot.symbol, result.OrderID)
+	}
+	
 	return result, nil
 }
 
@@ -2618,6 +2865,11 @@ func (bot *LiveBot) cancelOrderWithRetry(category, symbol, orderID string) error
 	
 	// Use recovery handler for intelligent retry with backoff
 	return bot.recoveryHandler.ExecuteWithRecovery(ctx, "OrderCancellation", "CancelOrder", func() error {
+		// Cancels share the order quota with placements
+		tradingRL, _ := bot.rateLimiters.Get("trading")
+		if err := tradingRL.Wait(ctx); err != nil {
+			return fmt.Errorf("rate limiting failed: %w", err)
+		}
 		return bot.exchange.CancelOrder(ctx, category, symbol, orderID)
 	})
 }
@@ -2713,6 +2965,7 @@ func (bot *LiveBot) placeFallbackTPOrder(quantity float64, avgEntryPrice float64
 		Quantity:  formattedQty,
 		OrderType: exchange.OrderTypeLimit,
 		Price:     formattedPrice,
+		ClientOrderID: bot.clientOrderBase(orderKindFallbackTP, level),
 	}
 	
 	tpOrder, err := bot.placeOrderWithRetry(orderParams, false) // false for limit order
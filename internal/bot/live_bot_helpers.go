@@ -11,7 +11,9 @@ import (
 
 	"math"
 
+	"github.com/ducminhle1904/crypto-dca-bot/internal/config"
 	"github.com/ducminhle1904/crypto-dca-bot/internal/exchange"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/ledger"
 	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/jedib0t/go-pretty/v6/text"
 )
@@ -195,6 +197,7 @@ func (bot *LiveBot) closeOpenPositions() error {
 				Side:      exchange.OrderSideSell,
 				Quantity:  pos.Size,
 				OrderType: exchange.OrderTypeMarket,
+				ClientOrderID: bot.nextClientOrderID(orderKindExit, bot.dcaLevel),
 			}
 			
 			order, err := bot.exchange.PlaceMarketOrder(ctx, orderParams)
@@ -219,6 +222,8 @@ func (bot *LiveBot) closeOpenPositions() error {
 			bot.logger.Info("🔄 POSITION CLOSED (SHUTDOWN) - Order: %s, Qty: %s %s, Entry: $%.2f, Exit: $%.2f, P&L: $%.2f (%.2f%%)", 
 				order.OrderID, pos.Size, bot.symbol, avgPrice, currentPrice, profit, profitPercent)
 			
+			bot.recordLedgerFill(order, ledger.SideSell)
+			
 			// Reset bot state with mutex protection
 			bot.positionMutex.Lock()
 			bot.currentPosition = 0
@@ -233,6 +238,8 @@ func (bot *LiveBot) closeOpenPositions() error {
 			// Refresh balance after closing position
 			if err := bot.syncAccountBalance(); err != nil {
 				bot.logger.LogWarning("Balance refresh", "Could not refresh balance after closing position: %v", err)
+			} else {
+				bot.recordCycleClose()
 			}
 			
 			return nil // Only close one position at a time
@@ -469,6 +476,7 @@ func (bot *LiveBot) printBotConfiguration() {
 		{"🔄 Max Multiplier", fmt.Sprintf("%.2f", bot.config.Strategy.MaxMultiplier)},
 		{"📊 DCA Spacing", bot.getDCASpacingDisplay()},
 		{"🎯 Take Profit", fmt.Sprintf("%.2f%%", bot.config.Strategy.TPPercent*100)},
+		{"🧷 TP Mode", bot.getTPModeDisplay()},
 	})
 	
 	t.AppendSeparator()
@@ -497,6 +505,18 @@ func (bot *LiveBot) printBotConfiguration() {
 	fmt.Println()
 }
 
+// getTPModeDisplay returns a formatted string for the TP mode and optional stop loss
+func (bot *LiveBot) getTPModeDisplay() string {
+	mode := bot.config.Strategy.TPMode
+	if mode != config.TPModeOrders && !bot.usePositionTPSL() {
+		mode = fmt.Sprintf("%s (unsupported, using orders)", mode)
+	}
+	if bot.config.Strategy.StopLossPercent > 0 {
+		return fmt.Sprintf("%s, SL %.2f%%", mode, bot.config.Strategy.StopLossPercent*100)
+	}
+	return mode
+}
+
 // getEnvironmentString returns a formatted environment string
 func (bot *LiveBot) getEnvironmentString() string {
 	env := bot.exchange.GetEnvironment()
@@ -0,0 +1,332 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/config"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/exchange"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/strategy"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
+)
+
+// Bybit stop order types created by position-level TP/SL
+const (
+	stopOrderTypePartialTP = "PartialTakeProfit"
+	stopOrderTypePartialSL = "PartialStopLoss"
+)
+
+// usePositionTPSL reports whether TP/SL should be managed as exchange-native position TP/SL
+func (bot *LiveBot) usePositionTPSL() bool {
+	mode := bot.config.Strategy.TPMode
+	if mode != config.TPModePositionFull && mode != config.TPModePositionPartial {
+		return false
+	}
+	if bot.category != "linear" && bot.category != "inverse" {
+		return false
+	}
+	_, ok := bot.exchange.(exchange.PositionTPSLExchange)
+	return ok
+}
+
+// protectedSetPositionTPSL sets position TP/SL with rate limiting, circuit breaker and retry protection
+func (bot *LiveBot) protectedSetPositionTPSL(ctx context.Context, params exchange.PositionTPSLParams) error {
+	tpslExchange, ok := bot.exchange.(exchange.PositionTPSLExchange)
+	if !ok {
+		return fmt.Errorf("exchange %s does not support position TP/SL", bot.exchange.GetName())
+	}
+
+	return bot.recoveryHandler.ExecuteWithRecovery(ctx, "OrderPlacement", "SetPositionTPSL", func() error {
+		tradingRL, _ := bot.rateLimiters.Get("trading")
+		if err := tradingRL.Wait(ctx); err != nil {
+			return fmt.Errorf("rate limiting failed: %w", err)
+		}
+
+		tradingCB, _ := bot.circuitBreakers.Get("trading")
+		return tradingCB.Call(func() error {
+			return tpslExchange.SetPositionTPSL(ctx, params)
+		})
+	})
+}
+
+// getBaseTPPercent returns the full TP percentage (dynamic if enabled, fixed otherwise)
+func (bot *LiveBot) getBaseTPPercent(avgEntryPrice float64) float64 {
+	if !bot.strategy.IsDynamicTPEnabled() {
+		return bot.config.Strategy.TPPercent
+	}
+
+	recentKlines, err := bot.getRecentKlines()
+	if err != nil {
+		bot.logger.LogWarning("Dynamic TP", "Failed to get recent klines for dynamic TP calculation: %v, falling back to fixed TP", err)
+		return bot.config.Strategy.TPPercent
+	}
+
+	currentCandle := types.OHLCV{Close: avgEntryPrice}
+	if len(recentKlines) > 0 {
+		currentCandle = recentKlines[len(recentKlines)-1]
+	}
+
+	dynamicTPPercent, err := bot.strategy.GetDynamicTPPercent(currentCandle, recentKlines)
+	if err != nil || dynamicTPPercent <= 0 {
+		bot.logger.LogWarning("Dynamic TP", "Dynamic TP unavailable (%v), falling back to fixed TP", err)
+		return bot.config.Strategy.TPPercent
+	}
+
+	if tracer, ok := bot.strategy.(strategy.TPModifierTracer); ok {
+		for _, line := range tracer.GetTPModifierTrace() {
+			bot.logger.Info("🔎 TP condition %s", line)
+		}
+	}
+
+	return dynamicTPPercent
+}
+
+// roundToTick rounds a price to the exchange tick size
+func roundToTick(price float64, constraints *exchange.TradingConstraints) float64 {
+	if constraints != nil && constraints.MinPriceStep > 0 {
+		return math.Round(price/constraints.MinPriceStep) * constraints.MinPriceStep
+	}
+	return price
+}
+
+// setPositionTPSLInternal sets or amends exchange-native TP/SL for the current position.
+// Full mode attaches one TP (and optional SL) to the whole position; calling it again amends it.
+// Partial mode places one partial limit TP per level, replacing any previous partial TP/SL orders.
+func (bot *LiveBot) setPositionTPSLInternal(ctx context.Context, totalQuantity string, avgEntryPrice float64) error {
+	totalQty, err := parseFloat(totalQuantity)
+	if err != nil {
+		return fmt.Errorf("invalid quantity: %w", err)
+	}
+	if totalQty <= 0 || avgEntryPrice <= 0 {
+		return fmt.Errorf("invalid position for TP/SL: qty=%.6f, avg=%.4f", totalQty, avgEntryPrice)
+	}
+
+	constraints, err := bot.exchange.GetTradingConstraints(ctx, bot.category, bot.symbol)
+	if err != nil {
+		bot.logger.LogWarning("TP Constraints", "Could not get trading constraints: %v", err)
+		constraints = &exchange.TradingConstraints{QtyStep: 0.001, MinPriceStep: 0.0001}
+	}
+
+	basePercent := bot.getBaseTPPercent(avgEntryPrice)
+
+	stopLoss := ""
+	if bot.config.Strategy.StopLossPercent > 0 {
+		slPrice := roundToTick(avgEntryPrice*(1-bot.config.Strategy.StopLossPercent), constraints)
+		stopLoss = fmt.Sprintf("%.4f", slPrice)
+	}
+
+	if bot.config.Strategy.TPMode == config.TPModePositionFull {
+		tpPrice := roundToTick(avgEntryPrice*(1+basePercent), constraints)
+		params := exchange.PositionTPSLParams{
+			Category:   bot.category,
+			Symbol:     bot.symbol,
+			Mode:       exchange.TPSLModeFull,
+			TakeProfit: fmt.Sprintf("%.4f", tpPrice),
+			StopLoss:   stopLoss,
+			TriggerBy:  bot.config.Strategy.TPSLTriggerBy,
+		}
+		if err := bot.protectedSetPositionTPSL(ctx, params); err != nil {
+			return fmt.Errorf("failed to set position TP/SL: %w", err)
+		}
+
+		bot.tpOrderMutex.Lock()
+		bot.positionTPPercent = basePercent
+		bot.tpOrderMutex.Unlock()
+
+		bot.logger.Info("✅ Position TP/SL set (full): TP $%s (%.3f%%), SL %s, avg entry $%.4f",
+			params.TakeProfit, basePercent*100, displayOrNone(stopLoss), avgEntryPrice)
+		fmt.Printf("🎯 Position TP set: $%s (%.3f%%) for %.6f %s\n", params.TakeProfit, basePercent*100, totalQty, bot.symbol)
+		return nil
+	}
+
+	// Partial mode: place the new ladder and SL first and only then cancel the
+	// previous orders, so the position is never left without protection
+	staleTPs, staleSLs := bot.partialTPSLOrderIDs(ctx)
+
+	levelQuantities := bot.calculateTPLevelQuantities(totalQty, constraints)
+	successCount := 0
+	for level := 1; level <= len(levelQuantities); level++ {
+		levelQty := levelQuantities[level-1]
+		if levelQty <= 0 {
+			continue
+		}
+
+		levelPercent := basePercent * float64(level) / float64(bot.config.Strategy.TPLevels)
+		tpPrice := roundToTick(avgEntryPrice*(1+levelPercent), constraints)
+		if levelQty < constraints.MinOrderQty || levelQty*tpPrice < constraints.MinOrderValue {
+			bot.logger.LogWarning("TP Level %d", "Constraint violation: qty=%.6f, value=$%.2f, skipping", level, levelQty, levelQty*tpPrice)
+			continue
+		}
+
+		formattedQty := fmt.Sprintf("%.6f", levelQty)
+		formattedPrice := fmt.Sprintf("%.4f", tpPrice)
+		params := exchange.PositionTPSLParams{
+			Category:     bot.category,
+			Symbol:       bot.symbol,
+			Mode:         exchange.TPSLModePartial,
+			TakeProfit:   formattedPrice,
+			TPSize:       formattedQty,
+			TPLimitPrice: formattedPrice,
+			TriggerBy:    bot.config.Strategy.TPSLTriggerBy,
+		}
+		if err := bot.protectedSetPositionTPSL(ctx, params); err != nil {
+			bot.logger.LogWarning("TP Level %d", "Failed to set partial position TP: %v", level, err)
+			continue
+		}
+
+		bot.logger.Info("✅ Position TP Level %d set (partial): %s %s at $%s (%.3f%%)",
+			level, formattedQty, bot.symbol, formattedPrice, levelPercent*100)
+		successCount++
+	}
+
+	if stopLoss != "" {
+		params := exchange.PositionTPSLParams{
+			Category:  bot.category,
+			Symbol:    bot.symbol,
+			Mode:      exchange.TPSLModePartial,
+			StopLoss:  stopLoss,
+			SLSize:    fmt.Sprintf("%.6f", totalQty),
+			TriggerBy: bot.config.Strategy.TPSLTriggerBy,
+		}
+		if err := bot.protectedSetPositionTPSL(ctx, params); err != nil {
+			// Keep the resting SL: a stale stop beats no stop
+			bot.logger.LogWarning("Position SL", "Failed to set position stop loss, keeping previous SL: %v", err)
+			staleSLs = nil
+		} else {
+			bot.logger.Info("🛡️ Position SL set (partial): $%s for %.6f %s", stopLoss, totalQty, bot.symbol)
+		}
+	}
+	bot.cancelPartialTPSLOrders(staleSLs)
+
+	if successCount == 0 {
+		// Keep the previous ladder rather than leaving the position without TPs
+		return fmt.Errorf("failed to set any partial position TP levels")
+	}
+	bot.cancelPartialTPSLOrders(staleTPs)
+
+	// Track the resulting conditional orders so fill detection works like regular TP orders
+	bot.trackPartialTPOrders(ctx, avgEntryPrice, basePercent, constraints, staleTPs)
+
+	fmt.Printf("🎯 Position TP (partial): %d/%d levels set at avg $%.4f\n", successCount, bot.config.Strategy.TPLevels, avgEntryPrice)
+	return nil
+}
+
+// partialTPSLOrderIDs returns the IDs of the resting partial TP and SL conditional orders
+func (bot *LiveBot) partialTPSLOrderIDs(ctx context.Context) (tps, sls map[string]bool) {
+	tps, sls = make(map[string]bool), make(map[string]bool)
+	orders, err := bot.exchange.GetOpenOrders(ctx, bot.category, bot.symbol)
+	if err != nil {
+		bot.logger.LogWarning("Position TP/SL", "Failed to get open orders for cleanup: %v", err)
+		return tps, sls
+	}
+
+	for _, order := range orders {
+		switch order.StopOrderType {
+		case stopOrderTypePartialTP:
+			tps[order.OrderID] = true
+		case stopOrderTypePartialSL:
+			sls[order.OrderID] = true
+		}
+	}
+	return tps, sls
+}
+
+// cancelPartialTPSLOrders cancels partial TP/SL conditional orders left by a previous ladder
+func (bot *LiveBot) cancelPartialTPSLOrders(orderIDs map[string]bool) {
+	for orderID := range orderIDs {
+		if err := bot.cancelOrderWithRetry(bot.category, bot.symbol, orderID); err != nil {
+			bot.logger.LogWarning("Position TP/SL", "Failed to cancel partial TP/SL order %s: %v", orderID, err)
+			continue
+		}
+
+		bot.tpOrderMutex.Lock()
+		delete(bot.activeTPOrders, orderID)
+		bot.tpOrderMutex.Unlock()
+	}
+}
+
+// trackPartialTPOrders registers partial TP conditional orders in activeTPOrders, matching levels by price;
+// orders of the previous ladder that could not be cancelled are skipped
+func (bot *LiveBot) trackPartialTPOrders(ctx context.Context, avgEntryPrice, basePercent float64, constraints *exchange.TradingConstraints, stale map[string]bool) {
+	orders, err := bot.exchange.GetOpenOrders(ctx, bot.category, bot.symbol)
+	if err != nil {
+		bot.logger.LogWarning("Position TP/SL", "Failed to get open orders for TP tracking: %v", err)
+		return
+	}
+
+	tolerance := constraints.MinPriceStep / 2
+	if tolerance <= 0 {
+		tolerance = avgEntryPrice * 0.00001
+	}
+
+	bot.tpOrderMutex.Lock()
+	defer bot.tpOrderMutex.Unlock()
+
+	for _, order := range orders {
+		if order.StopOrderType != stopOrderTypePartialTP || stale[order.OrderID] {
+			continue
+		}
+		orderPrice, err := parseFloat(order.Price)
+		if err != nil {
+			continue
+		}
+
+		for level := 1; level <= bot.config.Strategy.TPLevels; level++ {
+			levelPercent := basePercent * float64(level) / float64(bot.config.Strategy.TPLevels)
+			if math.Abs(roundToTick(avgEntryPrice*(1+levelPercent), constraints)-orderPrice) > tolerance {
+				continue
+			}
+			bot.activeTPOrders[order.OrderID] = &TPOrderInfo{
+				Level:     level,
+				Percent:   levelPercent,
+				Quantity:  order.Quantity,
+				Price:     order.Price,
+				OrderID:   order.OrderID,
+				Filled:    false,
+				FilledQty: "0",
+			}
+			break
+		}
+	}
+}
+
+// updatePositionTPSL amends position TP/SL after a DCA fill using the exchange position as source of truth
+func (bot *LiveBot) updatePositionTPSL(newAveragePrice float64) error {
+	bot.logger.Info("🔄 Amending position TP/SL for new average price $%.4f", newAveragePrice)
+
+	bot.tpOrderMutex.Lock()
+	for k := range bot.filledTPOrders {
+		delete(bot.filledTPOrders, k)
+	}
+	bot.tpOrderMutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	positions, err := bot.exchange.GetPositions(ctx, bot.category, bot.symbol)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("failed to get position for TP/SL update: %w", err)
+	}
+
+	for _, pos := range positions {
+		if pos.Symbol != bot.symbol || pos.Side != "Buy" || pos.Size == "" || pos.Size == "0" {
+			continue
+		}
+		if exchangeAvg, err := parseFloat(pos.AvgPrice); err == nil && exchangeAvg > 0 {
+			newAveragePrice = exchangeAvg
+		}
+		return bot.placeMultiLevelTPOrders(pos.Size, newAveragePrice)
+	}
+
+	bot.logger.LogWarning("TP Update", "No position found for TP/SL update - possibly already closed")
+	return nil
+}
+
+// displayOrNone returns the value or "none" when empty
+func displayOrNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return "$" + value
+}
@@ -0,0 +1,78 @@
+package bot
+
+import (
+	"time"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/exchange"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/safety"
+)
+
+// rateLimiterForGroup maps an exchange endpoint group to the bot rate limiter
+// that paces its calls
+var rateLimiterForGroup = map[string]string{
+	exchange.RateLimitGroupOrder:    "trading",
+	exchange.RateLimitGroupPosition: "account_data",
+	exchange.RateLimitGroupAccount:  "account_data",
+	exchange.RateLimitGroupMarket:   "market_data",
+}
+
+// watchExchangeRateLimits feeds the quotas the exchange reports into the
+// matching rate limiters, so a burst slows down before the exchange starts
+// rejecting calls (Bybit error 10006)
+func (bot *LiveBot) watchExchangeRateLimits() {
+	reporter, ok := bot.exchange.(exchange.RateLimitReportingExchange)
+	if !ok {
+		return
+	}
+	bot.quotaWarnedUntil = make(map[string]time.Time)
+	reporter.SetRateLimitObserver(bot.applyRateLimitStatus)
+	bot.logger.Info("🚦 Rate limiters follow the %s rate-limit quota", bot.exchange.GetName())
+}
+
+// applyRateLimitStatus passes one reported quota to its rate limiter
+func (bot *LiveBot) applyRateLimitStatus(status exchange.RateLimitStatus) {
+	name, ok := rateLimiterForGroup[status.Group]
+	if !ok {
+		return
+	}
+	limiter, exists := bot.rateLimiters.Get(name)
+	if !exists {
+		return
+	}
+
+	quota := safety.Quota{
+		Group:     status.Group,
+		Endpoint:  status.Endpoint,
+		Limit:     status.Limit,
+		Remaining: status.Remaining,
+		ResetAt:   status.ResetAt,
+	}
+	limiter.UpdateQuota(quota)
+
+	if quota.Exhausted(time.Now()) && bot.shouldWarnQuota(status.Endpoint, status.ResetAt) {
+		bot.logger.LogWarning("Rate Limit", "%s quota nearly exhausted on %s (%d/%d left), holding %s calls until %s",
+			status.Group, status.Endpoint, status.Remaining, status.Limit, name, status.ResetAt.Format("15:04:05.000"))
+	}
+}
+
+// shouldWarnQuota reports whether an exhausted quota of endpoint has not been
+// logged yet for the window ending at resetAt
+func (bot *LiveBot) shouldWarnQuota(endpoint string, resetAt time.Time) bool {
+	bot.quotaWarnMutex.Lock()
+	defer bot.quotaWarnMutex.Unlock()
+
+	if !resetAt.After(bot.quotaWarnedUntil[endpoint]) {
+		return false
+	}
+	bot.quotaWarnedUntil[endpoint] = resetAt
+	return true
+}
+
+// rateLimitStatuses returns the exchange-reported quotas, nil when the
+// exchange does not report them
+func (bot *LiveBot) rateLimitStatuses() []exchange.RateLimitStatus {
+	if reporter, ok := bot.exchange.(exchange.RateLimitReportingExchange); ok {
+		return reporter.RateLimitStatuses()
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+package bot
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/config"
+)
+
+// DefaultConfigWatchInterval is how often WatchConfig polls the config file
+const DefaultConfigWatchInterval = 5 * time.Second
+
+// SetConfigPath records the config file the bot was loaded from, enabling
+// ReloadConfig and WatchConfig
+func (bot *LiveBot) SetConfigPath(path string) {
+	bot.configPath = path
+}
+
+// ReloadConfig re-reads the config file and applies its strategy and
+// notification sections without restarting. Cycle state (DCA level, average price, TP tracking) is
+// kept; TP orders are re-priced if TP settings changed. On any error the
+// running configuration is left untouched.
+func (bot *LiveBot) ReloadConfig() (string, error) {
+	if bot.configPath == "" {
+		return "", fmt.Errorf("config path not set, reload unavailable")
+	}
+
+	var summary string
+	err := bot.runOnTradingLoop(func() error {
+		var applyErr error
+		summary, applyErr = bot.applyConfigFile()
+		return applyErr
+	})
+	if err != nil {
+		bot.logger.LogWarning("Config Reload", "Rejected %s: %v", bot.configPath, err)
+		return "", err
+	}
+	return summary, nil
+}
+
+// applyConfigFile loads, validates and applies the config file. Must run on
+// the trading loop.
+func (bot *LiveBot) applyConfigFile() (string, error) {
+	next, err := config.LoadLiveBotConfig(bot.configPath)
+	if err != nil {
+		return "", err
+	}
+
+	bot.positionMutex.RLock()
+	inPosition := bot.currentPosition > 0
+	avgPrice := bot.averagePrice
+	bot.positionMutex.RUnlock()
+
+	if err := bot.config.CheckReloadable(next, inPosition); err != nil {
+		return "", err
+	}
+
+	// Only the strategy and notification sections are hot-reloaded. Exchange
+	// credentials and command-line overrides (e.g. -demo) stay as they were
+	// at startup.
+	merged := *bot.config
+	merged.Strategy = next.Strategy
+	merged.Notifications = next.Notifications
+
+	changed := changedStrategyFields(&bot.config.Strategy, &merged.Strategy)
+	strategyChanged := len(changed) > 0
+	notificationsChanged := !reflect.DeepEqual(bot.config.Notifications, merged.Notifications)
+	if !strategyChanged && !notificationsChanged {
+		return "No strategy or notification changes", nil
+	}
+
+	previous := bot.config.Strategy
+	if strategyChanged {
+		strat, spacingStrategy, err := bot.buildStrategy(&merged)
+		if err != nil {
+			return "", fmt.Errorf("new strategy is invalid: %w", err)
+		}
+		bot.strategy = strat
+		bot.spacingStrategy = spacingStrategy
+
+		// Carry the cycle over into the new strategy instance
+		bot.syncStrategyState()
+	}
+	bot.config = &merged
+	if notificationsChanged {
+		bot.notifier = newNotifier(merged.Notifications)
+		changed = append(changed, "notifications")
+	}
+
+	summary := fmt.Sprintf("Applied changes to: %s", strings.Join(changed, ", "))
+	bot.logger.Info("♻️ Config reloaded from %s - %s", bot.configPath, summary)
+	fmt.Printf("♻️ Config reloaded: %s\n", strings.Join(changed, ", "))
+
+	// With the strategy unchanged, the cycle's TP orders and grid stay as they are
+	if !strategyChanged {
+		return summary, nil
+	}
+
+	if inPosition && merged.Strategy.AutoTPOrders && tpSettingsChanged(&previous, &merged.Strategy) {
+		bot.logger.Info("🔄 TP settings changed - re-pricing outstanding TP orders")
+		if err := bot.updateMultiLevelTPOrders(avgPrice); err != nil {
+			bot.logger.LogWarning("Config Reload", "Config applied but TP re-pricing failed: %v", err)
+			return summary + " (TP re-pricing failed, see log)", nil
+		}
+		summary += " (TP orders re-priced)"
+	}
+
+	// Re-plan resting grid orders with the new spacing, sizing and grid settings
+	if inPosition && (bot.gridPlanner() != nil || len(bot.sortedGridOrders()) > 0) {
+		bot.logger.Info("🧱 Strategy changed - re-placing limit grid")
+		if err := bot.placeGridOrders(); err != nil {
+			bot.logger.LogWarning("Config Reload", "Config applied but grid re-placement failed: %v", err)
+			return summary + " (grid re-placement failed, see log)", nil
+		}
+		summary += " (grid re-placed)"
+	}
+
+	return summary, nil
+}
+
+// WatchConfig polls the config file and reloads it whenever its content
+// changes. Rejected reloads are logged, the running config is kept and the
+// same content is not retried until the file is edited again.
+func (bot *LiveBot) WatchConfig(interval time.Duration) error {
+	if bot.configPath == "" {
+		return fmt.Errorf("config path not set, cannot watch")
+	}
+	if interval <= 0 {
+		interval = DefaultConfigWatchInterval
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		var lastMod time.Time
+		var lastHash [sha256.Size]byte
+		if info, err := os.Stat(bot.configPath); err == nil {
+			lastMod = info.ModTime()
+		}
+		if data, err := os.ReadFile(bot.configPath); err == nil {
+			lastHash = sha256.Sum256(data)
+		}
+
+		for {
+			select {
+			case <-ticker.C:
+				info, err := os.Stat(bot.configPath)
+				if err != nil || info.ModTime().Equal(lastMod) {
+					continue
+				}
+				lastMod = info.ModTime()
+
+				data, err := os.ReadFile(bot.configPath)
+				if err != nil {
+					continue
+				}
+				hash := sha256.Sum256(data)
+				if hash == lastHash {
+					continue
+				}
+				lastHash = hash
+
+				bot.logger.Info("👀 Config file changed, reloading %s", bot.configPath)
+				if _, err := bot.ReloadConfig(); err != nil {
+					fmt.Printf("⚠️ Config reload rejected: %v\n", err)
+				}
+			case <-bot.stopChan:
+				return
+			}
+		}
+	}()
+
+	bot.logger.Info("👀 Watching %s for changes every %v", bot.configPath, interval)
+	return nil
+}
+
+// tpSettingsChanged reports whether outstanding TP orders would be priced or sized differently
+func tpSettingsChanged(old, next *config.StrategyConfig) bool {
+	return old.TPPercent != next.TPPercent ||
+		old.TPLevels != next.TPLevels ||
+		old.TPQuantity != next.TPQuantity ||
+		old.UseTPLevels != next.UseTPLevels ||
+		old.StopLossPercent != next.StopLossPercent ||
+		old.TPSLTriggerBy != next.TPSLTriggerBy ||
+		!reflect.DeepEqual(old.DynamicTP, next.DynamicTP)
+}
+
+// changedStrategyFields lists the JSON keys of the strategy config that differ
+func changedStrategyFields(old, next *config.StrategyConfig) []string {
+	oldFields, errOld := strategyFieldMap(old)
+	nextFields, errNext := strategyFieldMap(next)
+	if errOld != nil || errNext != nil {
+		if reflect.DeepEqual(old, next) {
+			return nil
+		}
+		return []string{"strategy"}
+	}
+
+	var changed []string
+	for key, value := range nextFields {
+		if !reflect.DeepEqual(oldFields[key], value) {
+			changed = append(changed, key)
+		}
+	}
+	for key := range oldFields {
+		if _, ok := nextFields[key]; !ok {
+			changed = append(changed, key)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+func strategyFieldMap(cfg *config.StrategyConfig) (map[string]interface{}, error) {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]interface{})
+	err = json.Unmarshal(data, &fields)
+	return fields, err
+}
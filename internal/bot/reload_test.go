@@ -0,0 +1,68 @@
+package bot
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/config"
+)
+
+// writeReloadConfig saves cfg as the bot's config file
+func writeReloadConfig(t *testing.T, path string, cfg *config.LiveBotConfig) {
+	t.Helper()
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReloadAppliesNotificationsOnlyChange(t *testing.T) {
+	bot := newControlTestBot(t)
+	path := filepath.Join(t.TempDir(), "bot.json")
+	bot.SetConfigPath(path)
+
+	cfg := *bot.config
+	writeReloadConfig(t, path, &cfg)
+	summary, err := bot.applyConfigFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary != "No strategy or notification changes" {
+		t.Fatalf("expected an unchanged file to be a no-op, got %q", summary)
+	}
+
+	strat := bot.strategy
+	cfg.Notifications = &config.NotificationConfig{Enabled: true, TelegramToken: "token", TelegramChat: "chat"}
+	writeReloadConfig(t, path, &cfg)
+	summary, err = bot.applyConfigFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary != "Applied changes to: notifications" {
+		t.Errorf("expected the reload to report the notification change, got %q", summary)
+	}
+	if bot.notifier == nil {
+		t.Error("expected the reloaded notifications to be enabled")
+	}
+	if bot.config.Notifications == nil || bot.config.Notifications.TelegramChat != "chat" {
+		t.Errorf("expected the new notification settings, got %+v", bot.config.Notifications)
+	}
+	if bot.strategy != strat {
+		t.Error("a notifications-only reload rebuilt the strategy")
+	}
+
+	// Turning notifications off again is applied too
+	cfg.Notifications = nil
+	writeReloadConfig(t, path, &cfg)
+	if summary, err = bot.applyConfigFile(); err != nil || summary != "Applied changes to: notifications" {
+		t.Fatalf("expected notifications to be turned off, got %q, %v", summary, err)
+	}
+	if bot.notifier != nil {
+		t.Error("expected notifications to be off")
+	}
+}
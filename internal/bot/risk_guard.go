@@ -0,0 +1,162 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/config"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/exchange"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/notifications"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/risk"
+)
+
+// The account-level risk guard (risk.Guard) runs before every buy. Sells -
+// TP orders and exits - are never blocked since they only reduce exposure.
+// Order-level breaches (notional, DCA levels, cycle capital, free margin)
+// reject the buy; halting breaches (daily realized loss, equity drawdown)
+// pause entries until the daily window rolls over or the operator resumes.
+// The backtest engine enforces the same guard via SetRiskLimits.
+
+// newRiskGuard creates the bot's risk guard with its breach handler installed
+func (bot *LiveBot) newRiskGuard(limits risk.Limits) *risk.Guard {
+	guard := risk.NewGuard(limits)
+	guard.SetBreachCallback(bot.onRiskBreach)
+	return guard
+}
+
+// newNotifier returns the configured alert channel, or nil if notifications are off
+func newNotifier(cfg *config.NotificationConfig) notifications.Notifier {
+	if cfg == nil || !cfg.Enabled || cfg.TelegramToken == "" || cfg.TelegramChat == "" {
+		return nil
+	}
+	return notifications.NewTelegramNotifier(cfg.TelegramToken, cfg.TelegramChat)
+}
+
+// checkRiskLimits validates a buy of amount at price against the risk guard
+func (bot *LiveBot) checkRiskLimits(amount, price float64) error {
+	bot.positionMutex.RLock()
+	portfolio := &risk.Portfolio{
+		Balance:      bot.balance,
+		Symbol:       bot.symbol,
+		CycleCapital: bot.totalInvested,
+		DCALevel:     bot.dcaLevel,
+		Time:         time.Now(),
+	}
+	bot.positionMutex.RUnlock()
+
+	order := &risk.Order{
+		Symbol: bot.symbol,
+		Side:   exchange.OrderSideBuy,
+		Amount: amount,
+		Price:  price,
+	}
+	return bot.riskGuard.ValidateOrder(order, portfolio)
+}
+
+// onRiskBreach logs, alerts and updates health when a risk rule is breached
+func (bot *LiveBot) onRiskBreach(breach risk.Breach) {
+	bot.logger.LogWarning("Risk Guard", "%s: %s", breach.Rule, breach.Message)
+
+	level := "warning"
+	if breach.Halt {
+		level = "error"
+		bot.logger.Info("⏸️ DCA entries paused by risk guard (%s) - TP management continues", breach.Rule)
+		fmt.Printf("🛑 Risk limit %s breached: %s - entries paused\n", breach.Rule, breach.Message)
+	}
+	bot.updateRiskHealth()
+
+	if bot.notifier != nil {
+		message := fmt.Sprintf("%s %s\nRisk limit *%s* breached: %s", bot.symbol, bot.interval, breach.Rule, breach.Message)
+		if breach.Halt {
+			message += "\nNew DCA entries are paused."
+		}
+		notifier := bot.notifier
+		go func() {
+			if err := notifier.SendAlert(level, message); err != nil {
+				bot.logger.LogWarning("Risk Guard", "Could not send alert: %v", err)
+			}
+		}()
+	}
+}
+
+// updateRiskHealth publishes the risk guard state to the health checker
+func (bot *LiveBot) updateRiskHealth() {
+	status := bot.riskGuard.Status()
+	breaches := make([]string, 0, len(status.Breaches))
+	for _, b := range status.Breaches {
+		breaches = append(breaches, fmt.Sprintf("%s: %s", b.Rule, b.Message))
+	}
+	bot.health.SetRiskStatus(status.Halted, breaches)
+}
+
+// updateRiskEquity feeds the current account equity to the drawdown rule
+func (bot *LiveBot) updateRiskEquity(ctx context.Context) {
+	if !bot.riskGuard.Limits().Enabled() {
+		return
+	}
+
+	equity, err := bot.accountEquity(ctx)
+	if err != nil {
+		bot.logger.LogWarning("Risk Guard", "Could not determine account equity: %v", err)
+		return
+	}
+	bot.riskGuard.UpdateEquity(equity, time.Now())
+	bot.updateRiskHealth()
+}
+
+// accountEquity estimates account equity as free balance plus the margin (or
+// value, for spot) held by the bot's position plus its unrealized PnL
+func (bot *LiveBot) accountEquity(ctx context.Context) (float64, error) {
+	bot.positionMutex.RLock()
+	equity := bot.balance
+	bot.positionMutex.RUnlock()
+
+	positions, err := bot.protectedGetPositions(ctx, bot.category, bot.symbol)
+	if err != nil {
+		return 0, err
+	}
+	for _, pos := range positions {
+		if pos.Symbol != bot.symbol || pos.Side != "Buy" {
+			continue
+		}
+		held, err := parseFloat(pos.PositionIM)
+		if err != nil || held <= 0 {
+			held, _ = parseFloat(pos.PositionValue)
+		}
+		unrealized, _ := parseFloat(pos.UnrealisedPnl)
+		equity += held + unrealized
+	}
+	return equity, nil
+}
+
+// markCycleStart remembers the flat balance before the first buy of a cycle
+// so the cycle's realized PnL can be measured when it closes
+func (bot *LiveBot) markCycleStart() {
+	bot.positionMutex.RLock()
+	defer bot.positionMutex.RUnlock()
+
+	if bot.dcaLevel == 0 && bot.currentPosition == 0 {
+		bot.cycleStartBalance = bot.balance
+	}
+}
+
+// recordCycleClose records the realized PnL of a cycle that just went flat,
+// measured as the change in free balance since the cycle started. Cycles
+// opened before a restart are not counted.
+func (bot *LiveBot) recordCycleClose() {
+	bot.closeLedgerCycle()
+
+	if bot.cycleStartBalance <= 0 {
+		return
+	}
+
+	bot.positionMutex.RLock()
+	realized := bot.balance - bot.cycleStartBalance
+	bot.positionMutex.RUnlock()
+	bot.cycleStartBalance = 0
+
+	bot.logger.Info("📒 Cycle closed - realized PnL $%.2f", realized)
+	bot.riskGuard.RecordRealizedPnL(realized, time.Now())
+	bot.updateRiskHealth()
+}
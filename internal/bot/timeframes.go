@@ -0,0 +1,111 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/exchange"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/strategy"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/data"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
+)
+
+// maxTimeframeKlines is the most klines one request returns
+const maxTimeframeKlines = 1000
+
+// timeframeIntervals lists the kline intervals the exchange adapters support
+var timeframeIntervals = []exchange.KlineInterval{
+	exchange.Interval1m, exchange.Interval3m, exchange.Interval5m, exchange.Interval15m,
+	exchange.Interval30m, exchange.Interval1h, exchange.Interval4h, exchange.Interval1d,
+}
+
+// timeframeKlineInterval returns the exchange kline interval of a candle duration
+func timeframeKlineInterval(d time.Duration) (exchange.KlineInterval, error) {
+	names := make([]string, len(timeframeIntervals))
+	for i, interval := range timeframeIntervals {
+		if id, err := data.IntervalDuration(string(interval)); err == nil && id == d {
+			return interval, nil
+		}
+		names[i] = string(interval)
+	}
+	return "", fmt.Errorf("no %v klines on the exchange (intervals: %s)", d, strings.Join(names, ", "))
+}
+
+// checkTimeframeIntervals returns an error if a higher timeframe cannot be fetched
+func checkTimeframeIntervals(reqs []strategy.TimeframeRequirement) error {
+	for _, req := range reqs {
+		if _, err := timeframeKlineInterval(req.Duration); err != nil {
+			return fmt.Errorf("timeframe %s: %w", req.Interval, err)
+		}
+	}
+	return nil
+}
+
+// feedTimeframes passes the strategy the closed candles of each higher
+// timeframe it confirms entries on. A timeframe whose klines cannot be fetched
+// gets no candles, which blocks entries until the next check.
+func (bot *LiveBot) feedTimeframes() {
+	mtf, ok := bot.strategy.(strategy.MultiTimeframeStrategy)
+	if !ok {
+		return
+	}
+	now := time.Now()
+	for _, req := range mtf.RequiredTimeframes() {
+		candles, err := bot.getTimeframeKlines(req, now)
+		if err != nil {
+			bot.logger.LogWarning("Higher timeframe", "Failed to get %s klines, entries blocked: %v", req.Interval, err)
+		}
+		mtf.SetTimeframeCandles(req.Interval, candles)
+	}
+}
+
+// getTimeframeKlines fetches the last req.Window candles of a higher
+// timeframe that had closed by now, oldest first. The forming candle the
+// exchange also returns is dropped.
+func (bot *LiveBot) getTimeframeKlines(req strategy.TimeframeRequirement, now time.Time) ([]types.OHLCV, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	interval, err := timeframeKlineInterval(req.Duration)
+	if err != nil {
+		return nil, err
+	}
+	limit := req.Window + 1
+	if limit > maxTimeframeKlines {
+		limit = maxTimeframeKlines
+	}
+	klines, err := bot.exchange.GetKlines(ctx, exchange.KlineParams{
+		Category: bot.category,
+		Symbol:   bot.symbol,
+		Interval: interval,
+		Limit:    limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get klines: %w", err)
+	}
+
+	// Exchanges may list the newest kline first
+	sort.Slice(klines, func(i, j int) bool { return klines[i].Timestamp.Before(klines[j].Timestamp) })
+	if len(klines) > 1 {
+		if spacing := data.InferInterval(klines); spacing != req.Duration {
+			return nil, fmt.Errorf("requested %s klines but got %v spacing", req.Interval, spacing)
+		}
+	}
+	closed := klines[:data.ClosedCount(klines, req.Duration, now)]
+	if start := len(closed) - req.Window; start > 0 {
+		closed = closed[start:]
+	}
+	return closed, nil
+}
+
+// describeTimeframes formats the configured higher timeframes for logging
+func describeTimeframes(reqs []strategy.TimeframeRequirement) string {
+	parts := make([]string, len(reqs))
+	for i, req := range reqs {
+		parts[i] = fmt.Sprintf("%s (%d candles)", req.Interval, req.Window)
+	}
+	return strings.Join(parts, ", ")
+}
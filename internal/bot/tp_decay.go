@@ -0,0 +1,85 @@
+package bot
+
+import (
+	"github.com/ducminhle1904/crypto-dca-bot/internal/config"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/strategy"
+)
+
+// tpDecayRepriceStep is how far the decayed TP must fall below the resting TP
+// before the TP orders are re-placed, to avoid re-pricing on every tick
+const tpDecayRepriceStep = 0.0005
+
+// checkTPDecay re-places the TP orders once a time-decay take profit has
+// fallen a step below the TP they rest at. Higher TPs are left alone: within
+// a cycle the decayed TP only falls, and DCA fills re-place the TP anyway.
+func (bot *LiveBot) checkTPDecay() {
+	tracker, ok := bot.strategy.(strategy.CycleAgeTracker)
+	if !ok || !tracker.IsTimeDecayTPEnabled() {
+		return
+	}
+
+	bot.positionMutex.RLock()
+	avgPrice := bot.averagePrice
+	currentPosition := bot.currentPosition
+	bot.positionMutex.RUnlock()
+	if currentPosition <= 0 || avgPrice <= 0 {
+		return
+	}
+
+	placedPercent, known := bot.restingTPPercent(avgPrice)
+	if !known {
+		return
+	}
+
+	recentKlines, err := bot.getRecentKlines()
+	if err != nil || len(recentKlines) == 0 {
+		return
+	}
+	currentPercent, err := bot.strategy.GetDynamicTPPercent(recentKlines[len(recentKlines)-1], recentKlines)
+	if err != nil || currentPercent <= 0 {
+		return
+	}
+
+	if placedPercent > 0 && placedPercent-currentPercent < tpDecayRepriceStep {
+		return
+	}
+
+	bot.logger.Info("⏳ Time-decay TP: %.3f%% -> %.3f%% (full %.3f%%), re-placing TP orders",
+		placedPercent*100, currentPercent*100, tracker.GetFullTPPercent()*100)
+	if err := bot.updateMultiLevelTPOrders(avgPrice); err != nil {
+		bot.logger.LogWarning("Time-Decay TP", "Failed to re-place TP orders: %v", err)
+	}
+}
+
+// restingTPPercent returns the full TP percentage the resting TP orders were
+// placed at, 0 if a full-position TP was not set since start. known is false
+// when there is nothing to re-price.
+func (bot *LiveBot) restingTPPercent(avgPrice float64) (percent float64, known bool) {
+	bot.tpOrderMutex.RLock()
+	defer bot.tpOrderMutex.RUnlock()
+
+	if bot.usePositionTPSL() && bot.config.Strategy.TPMode == config.TPModePositionFull {
+		return bot.positionTPPercent, true
+	}
+
+	levels := float64(bot.config.Strategy.TPLevels)
+	if levels <= 0 {
+		return 0, false
+	}
+	for _, tpInfo := range bot.activeTPOrders {
+		if tpInfo.Filled || tpInfo.Level <= 0 {
+			continue
+		}
+		levelPercent := tpInfo.Percent
+		if levelPercent <= 0 {
+			// Orders recovered from the exchange carry only their price
+			price, err := parseFloat(tpInfo.Price)
+			if err != nil || price <= 0 {
+				continue
+			}
+			levelPercent = price/avgPrice - 1
+		}
+		return levelPercent * levels / float64(tpInfo.Level), true
+	}
+	return 0, false
+}
@@ -6,9 +6,13 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/ducminhle1904/crypto-dca-bot/internal/exchange"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/logger"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/risk"
 	pkgconfig "github.com/ducminhle1904/crypto-dca-bot/pkg/config"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/data"
 )
 
 // LiveBotConfig represents the complete configuration for the live trading bot
@@ -24,10 +28,20 @@ type LiveBotConfig struct {
 	
 	// Notification configuration (optional)
 	Notifications *NotificationConfig `json:"notifications,omitempty"`
+	
+	// Log file format, rotation and retention (optional)
+	Logging *LoggingConfig `json:"logging,omitempty"`
+	
+	// Trade ledger for realized PnL accounting (optional)
+	Ledger *LedgerConfig `json:"ledger,omitempty"`
 }
 
 // StrategyConfig holds trading strategy configuration
 type StrategyConfig struct {
+	// Registered strategy (enhanced_dca when omitted) and its parameters
+	Name   string             `json:"name,omitempty"`   // Strategy from the registry, e.g. multi_indicator
+	Params map[string]float64 `json:"params,omitempty"` // Parameters of the named strategy
+	
 	// Core DCA parameters
 	Symbol                   string  `json:"symbol"`                     // Trading symbol (e.g., BTCUSDT)
 	Category                 string  `json:"category"`                   // Trading category (spot, linear, inverse)
@@ -40,6 +54,21 @@ type StrategyConfig struct {
 	// DCA Spacing Strategy configuration (new)
 	DCASpacing *DCASpacingConfig `json:"dca_spacing,omitempty"` // DCA spacing strategy configuration
 	
+	// Entry sizing mode (signal-based when omitted)
+	PositionSizing *pkgconfig.PositionSizingConfig `json:"position_sizing,omitempty"` // Ladder sizing: volume_scale or custom
+	
+	// Pre-placed limit-order DCA levels (market entries when omitted)
+	LimitGrid *pkgconfig.LimitGridConfig `json:"limit_grid,omitempty"` // Rest the next DCA levels as limit buys
+	
+	// Indicator signal aggregation (majority vote when omitted)
+	SignalAggregation *pkgconfig.SignalAggregationConfig `json:"signal_aggregation,omitempty"` // weighted, strength or quorum votes with required/veto indicators
+	
+	// Custom conditions in the expression language (none when omitted)
+	Conditions        *pkgconfig.ConditionsConfig `json:"conditions,omitempty"` // entry filters, vetoes and take profit modifiers
+	
+	// Higher timeframe entry confirmations (none when omitted)
+	Timeframes        pkgconfig.Timeframes `json:"timeframes,omitempty"` // conditions on closed candles fetched per interval
+	
 	// Market data settings
 	Interval   string `json:"interval"`    // Trading interval (5m, 15m, 1h, etc.)
 	WindowSize int    `json:"window_size"` // Data window size for indicators
@@ -52,6 +81,11 @@ type StrategyConfig struct {
 	TPLevels     int   `json:"tp_levels"`      // Number of TP levels (default 5)
 	TPQuantity   float64 `json:"tp_quantity"`  // Quantity per TP level (default 0.20 = 20%)
 	
+	// Exchange-native TP/SL settings (futures only)
+	TPMode          string  `json:"tp_mode,omitempty"`           // "orders" (default), "position_full", "position_partial"
+	StopLossPercent float64 `json:"stop_loss_percent,omitempty"` // Position stop loss below avg entry (0 disables, e.g. 0.15 = 15%)
+	TPSLTriggerBy   string  `json:"tpsl_trigger_by,omitempty"`   // Trigger price type: LastPrice (default), MarkPrice, IndexPrice
+	
 	// Dynamic take profit configuration
 	DynamicTP    *pkgconfig.DynamicTPConfig `json:"dynamic_tp,omitempty"` // Dynamic TP configuration
 	
@@ -76,13 +110,28 @@ type StrategyConfig struct {
 	// Additional trend indicators
 	SuperTrend  IndicatorSuperTrendConfig  `json:"supertrend"`
 	
+	// Trend-strength indicators
+	ADX         IndicatorADXConfig         `json:"adx"`
+	Ichimoku    IndicatorIchimokuConfig    `json:"ichimoku"`
+	Donchian    IndicatorDonchianConfig    `json:"donchian"`
+	
 	// Volume indicators
-	OBV         IndicatorOBVConfig         `json:"obv"`
+	OBV           IndicatorOBVConfig           `json:"obv"`
+	AnchoredVWAP  IndicatorAnchoredVWAPConfig  `json:"anchored_vwap"`
+	CMF           IndicatorCMFConfig           `json:"cmf"`
+	VolumeProfile IndicatorVolumeProfileConfig `json:"volume_profile"`
 	
 	// Momentum indicators
 	StochasticRSI IndicatorStochasticRSIConfig `json:"stochastic_rsi"`
 }
 
+// Take profit modes
+const (
+	TPModeOrders          = "orders"           // Separate reduce-side limit orders per TP level
+	TPModePositionFull    = "position_full"    // Exchange position TP/SL covering the whole position
+	TPModePositionPartial = "position_partial" // Exchange position TP/SL with one partial limit TP per level
+)
+
 // DCASpacingConfig holds DCA spacing strategy configuration
 type DCASpacingConfig struct {
 	Strategy   string                 `json:"strategy"`   // Strategy name (e.g., "fixed", "volatility_adaptive")
@@ -148,11 +197,50 @@ type IndicatorSuperTrendConfig struct {
 	Multiplier float64 `json:"multiplier"` // ATR multiplier for band calculation
 }
 
+// IndicatorADXConfig holds ADX/DMI configuration
+type IndicatorADXConfig struct {
+	Period    int     `json:"period"`    // Wilder smoothing period for DI and ADX
+	Threshold float64 `json:"threshold"` // ADX level above which a trend counts as strong
+}
+
+// IndicatorIchimokuConfig holds Ichimoku Cloud configuration
+type IndicatorIchimokuConfig struct {
+	Tenkan  int `json:"tenkan"`   // Conversion line period
+	Kijun   int `json:"kijun"`    // Base line period, also the cloud displacement
+	SenkouB int `json:"senkou_b"` // Leading span B period
+}
+
+// IndicatorDonchianConfig holds Donchian Channels configuration
+type IndicatorDonchianConfig struct {
+	Period int `json:"period"` // Highest high / lowest low lookback
+}
+
 // IndicatorOBVConfig holds OBV (On-Balance Volume) configuration
 type IndicatorOBVConfig struct {
 	TrendThreshold float64 `json:"trend_threshold"` // Threshold for trend change detection (default 0.01 = 1%)
 }
 
+// IndicatorAnchoredVWAPConfig holds anchored VWAP configuration
+type IndicatorAnchoredVWAPConfig struct {
+	Anchor         string  `json:"anchor"`          // session, cycle or highest_high
+	AnchorLookback int     `json:"anchor_lookback"` // Candles searched for the highest_high anchor
+	BandMultiplier float64 `json:"band_multiplier"` // Band width in standard deviations
+}
+
+// IndicatorCMFConfig holds Chaikin Money Flow configuration
+type IndicatorCMFConfig struct {
+	Period    int     `json:"period"`    // Money flow lookback
+	Threshold float64 `json:"threshold"` // Accumulation above, distribution below its negative
+}
+
+// IndicatorVolumeProfileConfig holds volume profile configuration
+type IndicatorVolumeProfileConfig struct {
+	Lookback   int     `json:"lookback"`    // Candles in the profile
+	Bins       int     `json:"bins"`        // Price bins
+	NodeFactor float64 `json:"node_factor"` // Bin volume vs average of a high-volume node
+	Proximity  float64 `json:"proximity"`   // Max distance above a node that counts as testing it (0.01 = 1%)
+}
+
 // IndicatorStochasticRSIConfig holds Stochastic RSI indicator configuration
 type IndicatorStochasticRSIConfig struct {
 	Period     int     `json:"period"`      // Period for RSI and Stochastic calculation (default 14)
@@ -164,6 +252,26 @@ type IndicatorStochasticRSIConfig struct {
 type RiskConfig struct {
 	InitialBalance float64 `json:"initial_balance"` // Initial balance for tracking
 	Commission     float64 `json:"commission"`      // Commission rate (0.001 = 0.1%)
+
+	// Account-level risk guard, enforced before every buy (0 disables a rule)
+	MaxCycleCapital    float64 `json:"max_cycle_capital,omitempty"`    // Max USD invested in one DCA cycle
+	MaxDCALevels       int     `json:"max_dca_levels,omitempty"`       // Max buys per cycle, including the first
+	MaxDailyLoss       float64 `json:"max_daily_loss,omitempty"`       // Max realized loss per UTC day in USD
+	MaxDrawdownPercent float64 `json:"max_drawdown_percent,omitempty"` // Max account equity drawdown from peak (0.2 = 20%)
+	MinFreeMargin      float64 `json:"min_free_margin,omitempty"`      // Free balance that must remain after a buy
+	MaxOrderNotional   float64 `json:"max_order_notional,omitempty"`   // Max USD notional of a single buy
+}
+
+// Limits returns the risk guard limits of the config
+func (r RiskConfig) Limits() risk.Limits {
+	return risk.Limits{
+		MaxCycleCapital:    r.MaxCycleCapital,
+		MaxDCALevels:       r.MaxDCALevels,
+		MaxDailyLoss:       r.MaxDailyLoss,
+		MaxDrawdownPercent: r.MaxDrawdownPercent,
+		MinFreeMargin:      r.MinFreeMargin,
+		MaxOrderNotional:   r.MaxOrderNotional,
+	}
 }
 
 // NotificationConfig holds notification settings
@@ -173,17 +281,109 @@ type NotificationConfig struct {
 	TelegramChat  string `json:"telegram_chat,omitempty"`
 }
 
-// LoadLiveBotConfig loads configuration from file
-func LoadLiveBotConfig(configFile string) (*LiveBotConfig, error) {
-	// If config file doesn't contain path separators, look in configs/ directory
-	if !strings.ContainsAny(configFile, "/\\") {
-		configFile = filepath.Join("configs", configFile)
+// LoggingConfig holds log file settings
+type LoggingConfig struct {
+	Format     string `json:"format,omitempty"`       // "text" (default) or "json" (JSON lines)
+	Dir        string `json:"dir,omitempty"`          // Log directory (default "logs")
+	Rotation   string `json:"rotation,omitempty"`     // "daily" (default) or "hourly"
+	MaxSizeMB  int    `json:"max_size_mb,omitempty"`  // Start a new file above this size (0 = no limit)
+	MaxAgeDays int    `json:"max_age_days,omitempty"` // Delete log files older than this (0 = keep)
+	MaxFiles   int    `json:"max_files,omitempty"`    // Keep at most this many log files (0 = no limit)
+	Console    bool   `json:"console,omitempty"`      // Mirror log entries to stdout in human-readable form
+}
+
+// Options returns the logger options of the config, or the defaults when
+// the logging section is omitted
+func (l *LoggingConfig) Options() logger.Options {
+	opts := logger.DefaultOptions()
+	if l == nil {
+		return opts
+	}
+	if l.Format != "" {
+		opts.Format = l.Format
 	}
+	if l.Dir != "" {
+		opts.Dir = l.Dir
+	}
+	if l.Rotation != "" {
+		opts.Rotation = l.Rotation
+	}
+	opts.MaxSizeMB = l.MaxSizeMB
+	opts.MaxAge = time.Duration(l.MaxAgeDays) * 24 * time.Hour
+	opts.MaxFiles = l.MaxFiles
+	opts.Console = l.Console
+	return opts
+}
 
-	// Add .json extension if not present
-	if !strings.HasSuffix(configFile, ".json") {
-		configFile += ".json"
+// Validate checks the log format, rotation and retention settings
+func (l *LoggingConfig) Validate() error {
+	if l == nil {
+		return nil
+	}
+	switch l.Format {
+	case "", logger.FormatText, logger.FormatJSON:
+	default:
+		return fmt.Errorf("invalid format: %s (must be %s or %s)", l.Format, logger.FormatText, logger.FormatJSON)
+	}
+	switch l.Rotation {
+	case "", logger.RotateDaily, logger.RotateHourly:
+	default:
+		return fmt.Errorf("invalid rotation: %s (must be %s or %s)", l.Rotation, logger.RotateDaily, logger.RotateHourly)
+	}
+	if l.MaxSizeMB < 0 || l.MaxAgeDays < 0 || l.MaxFiles < 0 {
+		return fmt.Errorf("max_size_mb, max_age_days and max_files must be non-negative")
 	}
+	return nil
+}
+
+// LedgerConfig holds trade ledger settings
+type LedgerConfig struct {
+	Enabled bool   `json:"enabled"`
+	Path    string `json:"path,omitempty"` // Database file (default data/ledger/<SYMBOL>_<interval>.db)
+}
+
+// LedgerPath returns the ledger database file of the config, or "" when the
+// ledger is disabled
+func (c *LiveBotConfig) LedgerPath() string {
+	if c.Ledger == nil || !c.Ledger.Enabled {
+		return ""
+	}
+	return c.DefaultLedgerPath()
+}
+
+// DefaultLedgerPath returns the configured ledger path, or the per symbol and
+// interval default, whether or not the ledger is enabled
+func (c *LiveBotConfig) DefaultLedgerPath() string {
+	if c.Ledger != nil && c.Ledger.Path != "" {
+		return c.Ledger.Path
+	}
+	return filepath.Join("data", "ledger", fmt.Sprintf("%s_%s.db", c.Strategy.Symbol, c.Strategy.Interval))
+}
+
+// ResolveLedgerPath returns the ledger file of a config file without
+// validating the rest of the config, so the ledger can be queried on a
+// machine without API keys
+func ResolveLedgerPath(configFile string) (string, error) {
+	configFile = ResolveLiveBotConfigPath(configFile)
+
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read config file %s: %w", configFile, err)
+	}
+
+	var config LiveBotConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return "", fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if err := config.setDefaults(); err != nil {
+		return "", fmt.Errorf("failed to set config defaults: %w", err)
+	}
+	return config.DefaultLedgerPath(), nil
+}
+
+// LoadLiveBotConfig loads configuration from file
+func LoadLiveBotConfig(configFile string) (*LiveBotConfig, error) {
+	configFile = ResolveLiveBotConfigPath(configFile)
 
 	data, err := os.ReadFile(configFile)
 	if err != nil {
@@ -207,6 +407,129 @@ func LoadLiveBotConfig(configFile string) (*LiveBotConfig, error) {
 	return &config, nil
 }
 
+// LadderBudget returns the capital plan of the configured DCA ladder against
+// the initial balance, or nil when entries use signal sizing
+func (c *LiveBotConfig) LadderBudget() *pkgconfig.LadderBudget {
+	return c.Strategy.PositionSizing.LadderBudget(c.Strategy.BaseAmount, c.Risk.InitialBalance)
+}
+
+// DCAConfig returns the strategy settings in the form the strategy registry
+// builds from, so the bot trades the strategy a backtest of them would
+func (c *LiveBotConfig) DCAConfig() *pkgconfig.DCAConfig {
+	s := &c.Strategy
+	cfg := &pkgconfig.DCAConfig{
+		StrategyName:      s.Name,
+		StrategyParams:    s.Params,
+		LiveDefaults:      true,
+		Symbol:            s.Symbol,
+		Interval:          s.Interval,
+		InitialBalance:    c.Risk.InitialBalance,
+		Commission:        c.Risk.Commission,
+		WindowSize:        s.WindowSize,
+		BaseAmount:        s.BaseAmount,
+		MaxMultiplier:     s.MaxMultiplier,
+		PositionSizing:    s.PositionSizing,
+		LimitGrid:         s.LimitGrid,
+		SignalAggregation: s.SignalAggregation,
+		Conditions:        s.Conditions,
+		Timeframes:        s.Timeframes,
+		Indicators:        s.Indicators,
+		TPPercent:         s.TPPercent,
+		UseTPLevels:       s.UseTPLevels,
+		Cycle:             s.Cycle,
+		DynamicTP:         s.DynamicTP,
+		RiskLimits:        c.Risk.Limits(),
+
+		RSIPeriod:               s.RSI.Period,
+		RSIOversold:             s.RSI.Oversold,
+		RSIOverbought:           s.RSI.Overbought,
+		MACDFast:                s.MACD.FastPeriod,
+		MACDSlow:                s.MACD.SlowPeriod,
+		MACDSignal:              s.MACD.SignalPeriod,
+		BBPeriod:                s.BollingerBands.Period,
+		BBStdDev:                s.BollingerBands.StdDev,
+		EMAPeriod:               s.EMA.Period,
+		HullMAPeriod:            s.HullMA.Period,
+		SuperTrendPeriod:        s.SuperTrend.Period,
+		SuperTrendMultiplier:    s.SuperTrend.Multiplier,
+		MFIPeriod:               s.MFI.Period,
+		MFIOversold:             s.MFI.Oversold,
+		MFIOverbought:           s.MFI.Overbought,
+		KeltnerPeriod:           s.Keltner.Period,
+		KeltnerMultiplier:       s.Keltner.Multiplier,
+		WaveTrendN1:             s.WaveTrend.N1,
+		WaveTrendN2:             s.WaveTrend.N2,
+		WaveTrendOverbought:     s.WaveTrend.Overbought,
+		WaveTrendOversold:       s.WaveTrend.Oversold,
+		OBVTrendThreshold:       s.OBV.TrendThreshold,
+		StochasticRSIPeriod:     s.StochasticRSI.Period,
+		StochasticRSIOverbought: s.StochasticRSI.Overbought,
+		StochasticRSIOversold:   s.StochasticRSI.Oversold,
+		ADXPeriod:               s.ADX.Period,
+		ADXThreshold:            s.ADX.Threshold,
+		IchimokuTenkan:          s.Ichimoku.Tenkan,
+		IchimokuKijun:           s.Ichimoku.Kijun,
+		IchimokuSenkouB:         s.Ichimoku.SenkouB,
+		DonchianPeriod:          s.Donchian.Period,
+		AVWAPAnchor:             s.AnchoredVWAP.Anchor,
+		AVWAPAnchorLookback:     s.AnchoredVWAP.AnchorLookback,
+		AVWAPBandMultiplier:     s.AnchoredVWAP.BandMultiplier,
+		CMFPeriod:               s.CMF.Period,
+		CMFThreshold:            s.CMF.Threshold,
+		VolumeProfileLookback:   s.VolumeProfile.Lookback,
+		VolumeProfileBins:       s.VolumeProfile.Bins,
+		VolumeProfileNodeFactor: s.VolumeProfile.NodeFactor,
+		VolumeProfileProximity:  s.VolumeProfile.Proximity,
+	}
+	if s.DCASpacing != nil {
+		cfg.DCASpacing = &pkgconfig.DCASpacingConfig{
+			Strategy:   s.DCASpacing.Strategy,
+			Parameters: s.DCASpacing.Parameters,
+		}
+	}
+	return cfg
+}
+
+// ResolveLiveBotConfigPath returns the file LoadLiveBotConfig reads for configFile
+func ResolveLiveBotConfigPath(configFile string) string {
+	// If config file doesn't contain path separators, look in configs/ directory
+	if !strings.ContainsAny(configFile, "/\\") {
+		configFile = filepath.Join("configs", configFile)
+	}
+
+	// Add .json extension if not present
+	if !strings.HasSuffix(configFile, ".json") {
+		configFile += ".json"
+	}
+
+	return configFile
+}
+
+// CheckReloadable reports whether next can replace c while the bot is running.
+// Changes that would orphan the current cycle are rejected: symbol, category,
+// strategy name, interval and exchange always, TP mode while a position is open.
+func (c *LiveBotConfig) CheckReloadable(next *LiveBotConfig, inPosition bool) error {
+	if next.Strategy.Symbol != c.Strategy.Symbol {
+		return fmt.Errorf("symbol cannot change at runtime (%s -> %s)", c.Strategy.Symbol, next.Strategy.Symbol)
+	}
+	if next.Strategy.Category != c.Strategy.Category {
+		return fmt.Errorf("category cannot change at runtime (%s -> %s)", c.Strategy.Category, next.Strategy.Category)
+	}
+	if !strings.EqualFold(strings.TrimSpace(next.Strategy.Name), strings.TrimSpace(c.Strategy.Name)) {
+		return fmt.Errorf("strategy name cannot change at runtime (%q -> %q)", c.Strategy.Name, next.Strategy.Name)
+	}
+	if next.Strategy.Interval != c.Strategy.Interval {
+		return fmt.Errorf("interval cannot change at runtime (%s -> %s)", c.Strategy.Interval, next.Strategy.Interval)
+	}
+	if !strings.EqualFold(next.Exchange.Name, c.Exchange.Name) {
+		return fmt.Errorf("exchange cannot change at runtime (%s -> %s)", c.Exchange.Name, next.Exchange.Name)
+	}
+	if inPosition && next.Strategy.TPMode != c.Strategy.TPMode {
+		return fmt.Errorf("tp_mode cannot change while a position is open (%s -> %s)", c.Strategy.TPMode, next.Strategy.TPMode)
+	}
+	return nil
+}
+
 // setDefaults sets default values for missing configuration
 func (c *LiveBotConfig) setDefaults() error {
 	// Strategy defaults
@@ -256,6 +579,12 @@ func (c *LiveBotConfig) setDefaults() error {
 	if c.Strategy.TPQuantity == 0 {
 		c.Strategy.TPQuantity = 0.20 // 20% per level (1.0 / 5 levels)
 	}
+	if c.Strategy.TPMode == "" {
+		c.Strategy.TPMode = TPModeOrders
+	}
+	if c.Strategy.TPSLTriggerBy == "" {
+		c.Strategy.TPSLTriggerBy = "LastPrice"
+	}
 	if c.Strategy.Interval == "" {
 		c.Strategy.Interval = "5m"
 	}
@@ -348,11 +677,68 @@ func (c *LiveBotConfig) setDefaults() error {
 		c.Strategy.SuperTrend.Multiplier = 2.5
 	}
 
+	// ADX defaults
+	if c.Strategy.ADX.Period == 0 {
+		c.Strategy.ADX.Period = 14
+	}
+	if c.Strategy.ADX.Threshold == 0 {
+		c.Strategy.ADX.Threshold = 25.0 // Strong trend above 25
+	}
+
+	// Ichimoku defaults (classic 9/26/52)
+	if c.Strategy.Ichimoku.Tenkan == 0 {
+		c.Strategy.Ichimoku.Tenkan = 9
+	}
+	if c.Strategy.Ichimoku.Kijun == 0 {
+		c.Strategy.Ichimoku.Kijun = 26
+	}
+	if c.Strategy.Ichimoku.SenkouB == 0 {
+		c.Strategy.Ichimoku.SenkouB = 52
+	}
+
+	// Donchian defaults
+	if c.Strategy.Donchian.Period == 0 {
+		c.Strategy.Donchian.Period = 20
+	}
+
 	// OBV defaults
 	if c.Strategy.OBV.TrendThreshold == 0 {
 		c.Strategy.OBV.TrendThreshold = 0.01 // 1% threshold
 	}
 
+	// Anchored VWAP defaults
+	if c.Strategy.AnchoredVWAP.Anchor == "" {
+		c.Strategy.AnchoredVWAP.Anchor = "session"
+	}
+	if c.Strategy.AnchoredVWAP.AnchorLookback == 0 {
+		c.Strategy.AnchoredVWAP.AnchorLookback = 50
+	}
+	if c.Strategy.AnchoredVWAP.BandMultiplier == 0 {
+		c.Strategy.AnchoredVWAP.BandMultiplier = 2.0
+	}
+
+	// CMF defaults
+	if c.Strategy.CMF.Period == 0 {
+		c.Strategy.CMF.Period = 20
+	}
+	if c.Strategy.CMF.Threshold == 0 {
+		c.Strategy.CMF.Threshold = 0.05
+	}
+
+	// Volume profile defaults
+	if c.Strategy.VolumeProfile.Lookback == 0 {
+		c.Strategy.VolumeProfile.Lookback = 50
+	}
+	if c.Strategy.VolumeProfile.Bins == 0 {
+		c.Strategy.VolumeProfile.Bins = 24
+	}
+	if c.Strategy.VolumeProfile.NodeFactor == 0 {
+		c.Strategy.VolumeProfile.NodeFactor = 1.5
+	}
+	if c.Strategy.VolumeProfile.Proximity == 0 {
+		c.Strategy.VolumeProfile.Proximity = 0.01 // 1% above the node
+	}
+
 	// Stochastic RSI defaults
 	if c.Strategy.StochasticRSI.Period == 0 {
 		c.Strategy.StochasticRSI.Period = 14 // 14 period
@@ -371,6 +757,7 @@ func (c *LiveBotConfig) setDefaults() error {
 	if c.Risk.Commission == 0 {
 		c.Risk.Commission = 0.001 // 0.1%
 	}
+	c.Strategy.DynamicTP.SetTimeDecayFloorDefault(c.Risk.Commission)
 
 	// Exchange defaults (if not specified)
 	if c.Exchange.Name == "" {
@@ -401,12 +788,64 @@ func (c *LiveBotConfig) validate() error {
 		return fmt.Errorf("DCA spacing configuration is required")
 	}
 
+	if err := c.Strategy.PositionSizing.Validate(); err != nil {
+		return fmt.Errorf("invalid position sizing: %w", err)
+	}
+	if err := c.Strategy.SignalAggregation.ValidateFor(c.Strategy.Indicators); err != nil {
+		return fmt.Errorf("invalid signal aggregation: %w", err)
+	}
+	if err := c.Strategy.Conditions.Validate(); err != nil {
+		return fmt.Errorf("invalid conditions: %w", err)
+	}
+	if err := c.Strategy.Timeframes.Validate(); err != nil {
+		return fmt.Errorf("invalid timeframes: %w", err)
+	}
+	if base, err := data.IntervalDuration(c.Strategy.Interval); err == nil {
+		for _, tf := range c.Strategy.Timeframes {
+			if d, _ := data.IntervalDuration(tf.Interval); d <= base {
+				return fmt.Errorf("invalid timeframes: %s is not higher than the trading interval %s", tf.Interval, c.Strategy.Interval)
+			}
+		}
+	}
+	if err := c.Strategy.LimitGrid.Validate(); err != nil {
+		return fmt.Errorf("invalid limit grid: %w", err)
+	}
+
+	// Validate TP mode
+	switch c.Strategy.TPMode {
+	case TPModeOrders:
+	case TPModePositionFull, TPModePositionPartial:
+		if c.Strategy.Category != "linear" && c.Strategy.Category != "inverse" {
+			return fmt.Errorf("tp_mode %s requires linear or inverse category, got %s", c.Strategy.TPMode, c.Strategy.Category)
+		}
+	default:
+		return fmt.Errorf("invalid tp_mode: %s (must be %s, %s or %s)", c.Strategy.TPMode, TPModeOrders, TPModePositionFull, TPModePositionPartial)
+	}
+	if c.Strategy.StopLossPercent < 0 || c.Strategy.StopLossPercent >= 1 {
+		return fmt.Errorf("stop_loss_percent must be between 0 and 1, got %.4f", c.Strategy.StopLossPercent)
+	}
+	if c.Strategy.StopLossPercent > 0 && c.Strategy.TPMode == TPModeOrders {
+		return fmt.Errorf("stop_loss_percent requires tp_mode %s or %s", TPModePositionFull, TPModePositionPartial)
+	}
+	switch c.Strategy.TPSLTriggerBy {
+	case "LastPrice", "MarkPrice", "IndexPrice":
+	default:
+		return fmt.Errorf("invalid tpsl_trigger_by: %s (must be LastPrice, MarkPrice or IndexPrice)", c.Strategy.TPSLTriggerBy)
+	}
+
 
 
 	// Validate risk config
 	if c.Risk.InitialBalance <= 0 {
 		return fmt.Errorf("initial balance must be greater than 0")
 	}
+	if err := c.Risk.Limits().Validate(); err != nil {
+		return fmt.Errorf("invalid risk limits: %w", err)
+	}
+
+	if err := c.Logging.Validate(); err != nil {
+		return fmt.Errorf("invalid logging config: %w", err)
+	}
 
 	// Validate exchange config using factory
 	factory := exchange.NewExchangeFactory()
@@ -288,7 +288,14 @@ func (b *BybitAdapter) PlaceMarketOrder(ctx context.Context, params exchange.Ord
 	// Convert our generic params to Bybit-specific params
 	bybitSide := convertOrderSide(params.Side)
 	
-	order, err := b.client.PlaceMarketOrder(ctx, params.Category, params.Symbol, bybitSide, params.Quantity)
+	order, err := b.client.PlaceOrder(ctx, bybit.PlaceOrderParams{
+		Category:    params.Category,
+		Symbol:      params.Symbol,
+		Side:        bybitSide,
+		OrderType:   bybit.OrderTypeMarket,
+		Qty:         params.Quantity,
+		OrderLinkID: params.ClientOrderID,
+	})
 	if err != nil {
 		return nil, b.convertError(err)
 	}
@@ -296,6 +303,7 @@ func (b *BybitAdapter) PlaceMarketOrder(ctx context.Context, params exchange.Ord
 	// Convert Bybit order to our standard format
 	result := &exchange.Order{
 		OrderID:       order.OrderID,
+		ClientOrderID: order.OrderLinkID,
 		Symbol:        order.Symbol,
 		Side:          params.Side,
 		OrderType:     exchange.OrderTypeMarket,
@@ -303,6 +311,7 @@ func (b *BybitAdapter) PlaceMarketOrder(ctx context.Context, params exchange.Ord
 		Price:         order.AvgPrice,
 		CumExecQty:    order.CumExecQty,
 		CumExecValue:  order.CumExecValue,
+		CumExecFee:    order.CumExecFee,
 		AvgPrice:      order.AvgPrice,
 		OrderStatus:   string(order.OrderStatus), // Convert OrderStatus to string
 		CreatedTime:   order.CreatedTime,
@@ -335,6 +344,9 @@ func (b *BybitAdapter) PlaceMarketOrder(ctx context.Context, params exchange.Ord
 					if historyOrder.CumExecValue != "" {
 						result.CumExecValue = historyOrder.CumExecValue
 					}
+					if historyOrder.CumExecFee != "" {
+						result.CumExecFee = historyOrder.CumExecFee
+					}
 					result.OrderStatus = string(historyOrder.OrderStatus)
 					
 					log.Printf("✅ Updated order execution data from history: Qty=%s, Price=%s, Value=%s, Status=%s", 
@@ -364,7 +376,16 @@ func (b *BybitAdapter) PlaceLimitOrder(ctx context.Context, params exchange.Orde
 		}
 	}
 	
-	order, err := b.client.PlaceLimitOrder(ctx, params.Category, params.Symbol, bybitSide, params.Quantity, params.Price)
+	order, err := b.client.PlaceOrder(ctx, bybit.PlaceOrderParams{
+		Category:    params.Category,
+		Symbol:      params.Symbol,
+		Side:        bybitSide,
+		OrderType:   bybit.OrderTypeLimit,
+		Qty:         params.Quantity,
+		Price:       params.Price,
+		TimeInForce: bybit.TimeInForceGTC,
+		OrderLinkID: params.ClientOrderID,
+	})
 	if err != nil {
 		return nil, b.convertError(err)
 	}
@@ -372,6 +393,7 @@ func (b *BybitAdapter) PlaceLimitOrder(ctx context.Context, params exchange.Orde
 	// Convert Bybit order to our standard format
 	result := &exchange.Order{
 		OrderID:       order.OrderID,
+		ClientOrderID: order.OrderLinkID,
 		Symbol:        order.Symbol,
 		Side:          params.Side,
 		OrderType:     exchange.OrderTypeLimit,
@@ -397,6 +419,42 @@ func (b *BybitAdapter) CancelOrder(ctx context.Context, category, symbol, orderI
 	return nil
 }
 
+// SetPositionTPSL sets or amends position-level take profit / stop loss
+func (b *BybitAdapter) SetPositionTPSL(ctx context.Context, params exchange.PositionTPSLParams) error {
+	if params.Category == "spot" {
+		return &exchange.ExchangeError{
+			Code:    "NOT_SUPPORTED",
+			Message: "Position TP/SL is only available for linear and inverse categories",
+			IsRetryable: false,
+		}
+	}
+
+	stopParams := bybit.TradingStopParams{
+		Category:    params.Category,
+		Symbol:      params.Symbol,
+		TpslMode:    bybit.TPSLModeFull,
+		TakeProfit:  params.TakeProfit,
+		StopLoss:    params.StopLoss,
+		TpTriggerBy: params.TriggerBy,
+		SlTriggerBy: params.TriggerBy,
+	}
+
+	if params.Mode == exchange.TPSLModePartial {
+		stopParams.TpslMode = bybit.TPSLModePartial
+		stopParams.TpSize = params.TPSize
+		stopParams.SlSize = params.SLSize
+		if params.TPLimitPrice != "" {
+			stopParams.TpOrderType = string(bybit.OrderTypeLimit)
+			stopParams.TpLimitPrice = params.TPLimitPrice
+		}
+	}
+
+	if err := b.client.SetTradingStop(ctx, stopParams); err != nil {
+		return b.convertError(err)
+	}
+	return nil
+}
+
 // GetOrderStatus retrieves the status of an order
 func (b *BybitAdapter) GetOrderStatus(ctx context.Context, orderID string) (*exchange.OrderStatus, error) {
 	// This would require implementing GetOrder in the Bybit client
@@ -420,6 +478,7 @@ func (b *BybitAdapter) GetOpenOrders(ctx context.Context, category, symbol strin
 	for _, order := range orders {
 		exchangeOrder := &exchange.Order{
 			OrderID:       order.OrderID,
+			ClientOrderID: order.OrderLinkID,
 			Symbol:        order.Symbol,
 			Side:          exchange.OrderSide(order.Side),
 			OrderType:     exchange.OrderType(order.OrderType),
@@ -428,7 +487,9 @@ func (b *BybitAdapter) GetOpenOrders(ctx context.Context, category, symbol strin
 			OrderStatus:   string(order.OrderStatus),
 			CumExecQty:    order.CumExecQty,
 			CumExecValue:  order.CumExecValue,
+			CumExecFee:    order.CumExecFee,
 			AvgPrice:      order.AvgPrice,
+			StopOrderType: order.StopOrderType,
 			CreatedTime:   order.CreatedTime,
 			UpdatedTime:   order.UpdatedTime,
 		}
@@ -438,6 +499,71 @@ func (b *BybitAdapter) GetOpenOrders(ctx context.Context, category, symbol strin
 	return exchangeOrders, nil
 }
 
+// GetOrderByClientID looks up an order by its orderLinkId, including filled and
+// cancelled orders, so a retried placement can detect an earlier success
+func (b *BybitAdapter) GetOrderByClientID(ctx context.Context, category, symbol, clientOrderID string) (*exchange.Order, error) {
+	order, err := b.client.GetOrderByLinkID(ctx, category, symbol, clientOrderID)
+	if err != nil {
+		return nil, b.convertError(err)
+	}
+	if order == nil {
+		return nil, nil
+	}
+
+	return &exchange.Order{
+		OrderID:       order.OrderID,
+		ClientOrderID: order.OrderLinkID,
+		Symbol:        order.Symbol,
+		Side:          exchange.OrderSide(order.Side),
+		OrderType:     exchange.OrderType(order.OrderType),
+		Quantity:      order.Qty,
+		Price:         order.Price,
+		OrderStatus:   string(order.OrderStatus),
+		CumExecQty:    order.CumExecQty,
+		CumExecValue:  order.CumExecValue,
+		CumExecFee:    order.CumExecFee,
+		AvgPrice:      order.AvgPrice,
+		StopOrderType: order.StopOrderType,
+		CreatedTime:   order.CreatedTime,
+		UpdatedTime:   order.UpdatedTime,
+	}, nil
+}
+
+// SetRateLimitObserver forwards the quotas Bybit reports in its rate-limit
+// headers to observer
+func (b *BybitAdapter) SetRateLimitObserver(observer func(exchange.RateLimitStatus)) {
+	if observer == nil {
+		b.client.SetRateLimitObserver(nil)
+		return
+	}
+	b.client.SetRateLimitObserver(func(status bybit.RateLimitStatus) {
+		observer(convertRateLimitStatus(status))
+	})
+}
+
+// RateLimitStatuses returns the latest quota Bybit reported per endpoint
+func (b *BybitAdapter) RateLimitStatuses() []exchange.RateLimitStatus {
+	statuses := b.client.RateLimitStatuses()
+	result := make([]exchange.RateLimitStatus, 0, len(statuses))
+	for _, status := range statuses {
+		result = append(result, convertRateLimitStatus(status))
+	}
+	return result
+}
+
+// convertRateLimitStatus converts a Bybit quota to the generic type; the
+// group names are shared
+func convertRateLimitStatus(status bybit.RateLimitStatus) exchange.RateLimitStatus {
+	return exchange.RateLimitStatus{
+		Group:     status.Group,
+		Endpoint:  status.Endpoint,
+		Limit:     status.Limit,
+		Remaining: status.Remaining,
+		ResetAt:   status.ResetAt,
+		UpdatedAt: status.UpdatedAt,
+	}
+}
+
 // GetTradingConstraints retrieves trading constraints for a symbol
 func (b *BybitAdapter) GetTradingConstraints(ctx context.Context, category, symbol string) (*exchange.TradingConstraints, error) {
 	minQty, maxQty, qtyStep, err := b.client.GetInstrumentManager().GetQuantityConstraints(ctx, category, symbol)
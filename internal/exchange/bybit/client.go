@@ -3,6 +3,7 @@ package bybit
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	bybit_api "github.com/bybit-exchange/bybit.go.api"
 )
@@ -15,6 +16,7 @@ type Client struct {
 	testnet           bool
 	demo              bool
 	instrumentManager *InstrumentManager
+	rateLimits        *rateLimitTracker
 }
 
 // Config holds the configuration for the Bybit client
@@ -50,6 +52,18 @@ func NewClient(config Config) *Client {
 		apiSecret:  config.APISecret,
 		testnet:    config.Testnet,
 		demo:       config.Demo,
+		rateLimits: newRateLimitTracker(),
+	}
+
+	// Read the rate-limit headers of every response. The library defaults to
+	// http.DefaultClient, so give this client its own instead of wrapping that.
+	base := httpClient.HTTPClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	httpClient.HTTPClient = &http.Client{
+		Transport: &rateLimitTransport{base: base, tracker: client.rateLimits},
+		Timeout:   httpClient.HTTPClient.Timeout,
 	}
 	
 	// Initialize instrument manager
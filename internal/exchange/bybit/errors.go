@@ -32,6 +32,7 @@ const (
 	ErrCodeInvalidPrice        = 110021
 	ErrCodeRateLimitExceeded   = 10006
 	ErrCodeMarketClosed        = 110043
+	ErrCodeNotModified         = 34040
 )
 
 // IsRetryableError determines if an error should be retried
@@ -88,6 +89,14 @@ func IsRateLimitError(err error) bool {
 	return false
 }
 
+// IsNotModifiedError checks if the request was rejected because nothing changed
+func IsNotModifiedError(err error) bool {
+	if bybitErr, ok := err.(*BybitError); ok {
+		return bybitErr.Code == ErrCodeNotModified
+	}
+	return false
+}
+
 // NewBybitError creates a new BybitError
 func NewBybitError(code int, message string, details ...string) *BybitError {
 	err := &BybitError{
@@ -136,6 +145,7 @@ var ErrorCodes = map[int]string{
 	ErrCodeInvalidPrice:        "Invalid price",
 	ErrCodeRateLimitExceeded:   "Rate limit exceeded",
 	ErrCodeMarketClosed:        "Market is closed",
+	ErrCodeNotModified:         "Not modified",
 }
 
 // GetErrorDescription returns a human-readable description for an error code
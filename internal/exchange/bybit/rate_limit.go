@@ -0,0 +1,148 @@
+package bybit
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Bybit reports the per-UID quota of the called endpoint on every private
+// API response
+const (
+	HeaderLimitStatus = "X-Bapi-Limit-Status"          // Requests left in the current window
+	HeaderLimit       = "X-Bapi-Limit"                 // Requests allowed per window
+	HeaderLimitReset  = "X-Bapi-Limit-Reset-Timestamp" // Window reset, Unix milliseconds
+)
+
+// Endpoint groups the rate-limit statuses are reported under
+const (
+	RateLimitGroupOrder    = "order"
+	RateLimitGroupPosition = "position"
+	RateLimitGroupMarket   = "market"
+	RateLimitGroupAccount  = "account"
+)
+
+// RateLimitStatus is the quota Bybit reported for one endpoint
+type RateLimitStatus struct {
+	Group     string    `json:"group"`
+	Endpoint  string    `json:"endpoint"`
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	ResetAt   time.Time `json:"reset_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// EndpointGroup maps a v5 API path to its rate-limit group. Paths outside the
+// known groups return "".
+func EndpointGroup(path string) string {
+	switch {
+	case strings.HasPrefix(path, "/v5/order/"), strings.HasPrefix(path, "/v5/execution/"):
+		return RateLimitGroupOrder
+	case strings.HasPrefix(path, "/v5/position/"):
+		return RateLimitGroupPosition
+	case strings.HasPrefix(path, "/v5/market/"):
+		return RateLimitGroupMarket
+	case strings.HasPrefix(path, "/v5/account/"), strings.HasPrefix(path, "/v5/asset/"):
+		return RateLimitGroupAccount
+	}
+	return ""
+}
+
+// parseRateLimitHeaders reads the quota headers of a response. ok is false
+// when the response carries none.
+func parseRateLimitHeaders(header http.Header) (status RateLimitStatus, ok bool) {
+	remaining, err := strconv.Atoi(header.Get(HeaderLimitStatus))
+	if err != nil {
+		return status, false
+	}
+	limit, err := strconv.Atoi(header.Get(HeaderLimit))
+	if err != nil || limit <= 0 {
+		return status, false
+	}
+	resetMillis, err := strconv.ParseInt(header.Get(HeaderLimitReset), 10, 64)
+	if err != nil {
+		return status, false
+	}
+
+	return RateLimitStatus{
+		Limit:     limit,
+		Remaining: remaining,
+		ResetAt:   time.UnixMilli(resetMillis),
+	}, true
+}
+
+// rateLimitTracker keeps the latest status per endpoint and forwards every
+// update to an optional observer
+type rateLimitTracker struct {
+	mu       sync.Mutex
+	statuses map[string]RateLimitStatus
+	observer func(RateLimitStatus)
+}
+
+func newRateLimitTracker() *rateLimitTracker {
+	return &rateLimitTracker{statuses: make(map[string]RateLimitStatus)}
+}
+
+// record stores the quota headers of a response to path
+func (t *rateLimitTracker) record(path string, header http.Header) {
+	status, ok := parseRateLimitHeaders(header)
+	if !ok {
+		return
+	}
+	status.Group = EndpointGroup(path)
+	status.Endpoint = path
+	status.UpdatedAt = time.Now()
+
+	t.mu.Lock()
+	t.statuses[path] = status
+	observer := t.observer
+	t.mu.Unlock()
+
+	if observer != nil {
+		observer(status)
+	}
+}
+
+// rateLimitTransport records the quota headers of every response
+type rateLimitTransport struct {
+	base    http.RoundTripper
+	tracker *rateLimitTracker
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err == nil && resp != nil {
+		t.tracker.record(req.URL.Path, resp.Header)
+	}
+	return resp, err
+}
+
+// SetRateLimitObserver registers a function called with every quota Bybit
+// reports. It runs on the goroutine that made the request and must not block.
+func (c *Client) SetRateLimitObserver(observer func(RateLimitStatus)) {
+	c.rateLimits.mu.Lock()
+	defer c.rateLimits.mu.Unlock()
+	c.rateLimits.observer = observer
+}
+
+// RateLimitStatuses returns the latest quota of every endpoint called so far,
+// ordered by group and endpoint
+func (c *Client) RateLimitStatuses() []RateLimitStatus {
+	c.rateLimits.mu.Lock()
+	statuses := make([]RateLimitStatus, 0, len(c.rateLimits.statuses))
+	for _, status := range c.rateLimits.statuses {
+		statuses = append(statuses, status)
+	}
+	c.rateLimits.mu.Unlock()
+
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].Group != statuses[j].Group {
+			return statuses[i].Group < statuses[j].Group
+		}
+		return statuses[i].Endpoint < statuses[j].Endpoint
+	})
+	return statuses
+}
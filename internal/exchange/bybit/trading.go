@@ -60,6 +60,7 @@ type Order struct {
 	UpdatedTime   time.Time   `json:"updatedTime"`
 	CumExecQty    string      `json:"cumExecQty"`
 	CumExecValue  string      `json:"cumExecValue"`
+	CumExecFee    string      `json:"cumExecFee"`
 	AvgPrice      string      `json:"avgPrice"`
 	StopOrderType string      `json:"stopOrderType"`
 	TakeProfit    string      `json:"takeProfit"`
@@ -313,6 +314,47 @@ func (c *Client) GetOrderStatus(ctx context.Context, category, symbol, orderID s
 	return nil, fmt.Errorf("order with ID %s not found", orderID)
 }
 
+// GetOrderByLinkID looks up an order by its client-assigned orderLinkId.
+// Active orders are checked first, then order history. Returns nil, nil if
+// the exchange has no order with that ID.
+func (c *Client) GetOrderByLinkID(ctx context.Context, category, symbol, orderLinkID string) (*Order, error) {
+	params := map[string]interface{}{
+		"category":    category,
+		"symbol":      symbol,
+		"orderLinkId": orderLinkID,
+	}
+
+	result, err := c.httpClient.NewUtaBybitServiceWithParams(params).GetOpenOrders(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query order by link ID: %w", err)
+	}
+	orders, err := c.parseOrdersResponse(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse order response: %w", err)
+	}
+	for _, order := range orders {
+		if order.OrderLinkID == orderLinkID {
+			return &order, nil
+		}
+	}
+
+	result, err = c.httpClient.NewUtaBybitServiceWithParams(params).GetOrderHistory(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query order history by link ID: %w", err)
+	}
+	orders, err = c.parseOrdersResponse(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse order history response: %w", err)
+	}
+	for _, order := range orders {
+		if order.OrderLinkID == orderLinkID {
+			return &order, nil
+		}
+	}
+
+	return nil, nil
+}
+
 // parseOrderResponse parses the order placement API response
 func (c *Client) parseOrderResponse(response interface{}) (*Order, error) {
 	// Convert response to ServerResponse first
@@ -347,6 +389,7 @@ func (c *Client) parseOrderResponse(response interface{}) (*Order, error) {
 		OrderStatus   string `json:"orderStatus"`
 		CumExecQty    string `json:"cumExecQty"`
 		CumExecValue  string `json:"cumExecValue"`
+		CumExecFee    string `json:"cumExecFee"`
 		AvgPrice      string `json:"avgPrice"`
 		StopOrderType string `json:"stopOrderType"`
 		TakeProfit    string `json:"takeProfit"`
@@ -371,6 +414,7 @@ func (c *Client) parseOrderResponse(response interface{}) (*Order, error) {
 		UpdatedTime:   parseTimestamp(orderResult.UpdatedTime),
 		CumExecQty:    orderResult.CumExecQty,
 		CumExecValue:  orderResult.CumExecValue,
+		CumExecFee:    orderResult.CumExecFee,
 		AvgPrice:      orderResult.AvgPrice,
 		StopOrderType: orderResult.StopOrderType,
 		TakeProfit:    orderResult.TakeProfit,
@@ -410,6 +454,7 @@ func (c *Client) parseOrdersResponse(response interface{}) ([]Order, error) {
 			AvgPrice      string `json:"avgPrice"`
 			CumExecQty    string `json:"cumExecQty"`
 			CumExecValue  string `json:"cumExecValue"`
+			CumExecFee    string `json:"cumExecFee"`
 			TimeInForce   string `json:"timeInForce"`
 			OrderType     string `json:"orderType"`
 			StopOrderType string `json:"stopOrderType"`
@@ -443,6 +488,7 @@ func (c *Client) parseOrdersResponse(response interface{}) ([]Order, error) {
 			UpdatedTime:   parseTimestamp(orderData.UpdatedTime),
 			CumExecQty:    orderData.CumExecQty,
 			CumExecValue:  orderData.CumExecValue,
+			CumExecFee:    orderData.CumExecFee,
 			AvgPrice:      orderData.AvgPrice,
 			StopOrderType: orderData.StopOrderType,
 			TakeProfit:    orderData.TakeProfit,
@@ -663,6 +709,94 @@ func (c *Client) SetLeverage(ctx context.Context, category, symbol string, buyLe
 	return nil
 }
 
+// TPSLMode defines how position-level TP/SL applies to the position
+type TPSLMode string
+
+const (
+	TPSLModeFull    TPSLMode = "Full"    // TP/SL closes the entire position at market
+	TPSLModePartial TPSLMode = "Partial" // TP/SL closes tpSize/slSize, limit TP supported
+)
+
+// TradingStopParams holds parameters for setting position-level TP/SL
+type TradingStopParams struct {
+	Category     string   `json:"category"`               // "linear", "inverse"
+	Symbol       string   `json:"symbol"`                 // Trading pair symbol
+	TpslMode     TPSLMode `json:"tpslMode"`               // Full or Partial
+	PositionIdx  int      `json:"positionIdx"`            // 0 for one-way mode
+	TakeProfit   string   `json:"takeProfit,omitempty"`   // TP price, "0" cancels
+	StopLoss     string   `json:"stopLoss,omitempty"`     // SL price, "0" cancels
+	TpTriggerBy  string   `json:"tpTriggerBy,omitempty"`  // LastPrice, MarkPrice, IndexPrice
+	SlTriggerBy  string   `json:"slTriggerBy,omitempty"`  // LastPrice, MarkPrice, IndexPrice
+	TpSize       string   `json:"tpSize,omitempty"`       // Partial mode only
+	SlSize       string   `json:"slSize,omitempty"`       // Partial mode only
+	TpOrderType  string   `json:"tpOrderType,omitempty"`  // Market or Limit (Partial mode only)
+	TpLimitPrice string   `json:"tpLimitPrice,omitempty"` // Limit price when TpOrderType is Limit
+}
+
+// SetTradingStop sets or amends the position-level take profit / stop loss
+func (c *Client) SetTradingStop(ctx context.Context, params TradingStopParams) error {
+	if params.Category == "" {
+		params.Category = "linear"
+	}
+	if params.Category == "spot" {
+		return fmt.Errorf("trading stop is not supported for spot category")
+	}
+	if params.Symbol == "" {
+		return fmt.Errorf("symbol is required")
+	}
+	if params.TpslMode == "" {
+		params.TpslMode = TPSLModeFull
+	}
+	if params.TpslMode == TPSLModePartial && params.TakeProfit != "" && params.TpSize == "" {
+		return fmt.Errorf("tpSize is required for partial tpslMode")
+	}
+
+	apiParams := map[string]interface{}{
+		"category":    params.Category,
+		"symbol":      params.Symbol,
+		"tpslMode":    string(params.TpslMode),
+		"positionIdx": params.PositionIdx,
+	}
+
+	if params.TakeProfit != "" {
+		apiParams["takeProfit"] = params.TakeProfit
+	}
+	if params.StopLoss != "" {
+		apiParams["stopLoss"] = params.StopLoss
+	}
+	if params.TpTriggerBy != "" {
+		apiParams["tpTriggerBy"] = params.TpTriggerBy
+	}
+	if params.SlTriggerBy != "" {
+		apiParams["slTriggerBy"] = params.SlTriggerBy
+	}
+	if params.TpSize != "" {
+		apiParams["tpSize"] = params.TpSize
+	}
+	if params.SlSize != "" {
+		apiParams["slSize"] = params.SlSize
+	}
+	if params.TpOrderType != "" {
+		apiParams["tpOrderType"] = params.TpOrderType
+	}
+	if params.TpLimitPrice != "" {
+		apiParams["tpLimitPrice"] = params.TpLimitPrice
+	}
+
+	result, err := c.httpClient.NewUtaBybitServiceWithParams(apiParams).SetPositionTradingStop(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to set trading stop: %w", err)
+	}
+	if result != nil {
+		// Amending with identical TP/SL values is reported as "not modified"
+		if apiErr := ParseAPIError(result.RetCode, result.RetMsg); apiErr != nil && !IsNotModifiedError(apiErr) {
+			return WrapAPIError("SetTradingStop", apiErr)
+		}
+	}
+
+	return nil
+}
+
 
 
 
@@ -0,0 +1,508 @@
+// Package faults wraps a LiveTradingExchange and injects failures around its
+// calls: latency, timeouts, rate limiting, server errors, partial fills, order
+// responses lost after the exchange accepted the order, and stale prices.
+// It is used to check that the bot's recovery handler, circuit breakers and
+// rate limiters behave under realistic failure sequences.
+package faults
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/exchange"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
+)
+
+// Kind is a type of injected fault
+type Kind string
+
+const (
+	Latency      Kind = "latency"       // Delay the call, then run it normally
+	Timeout      Kind = "timeout"       // Block for Rule.Latency, fail without reaching the exchange
+	RateLimit    Kind = "rate_limit"    // Reject with a rate limit error
+	ServerError  Kind = "server_error"  // Reject with a 5xx error
+	PartialFill  Kind = "partial_fill"  // Send only Rule.FillRatio of a market order's quantity
+	LostResponse Kind = "lost_response" // Send the request, then fail as if the response timed out
+	StalePrice   Kind = "stale_price"   // Return the last price and klines returned before the fault
+)
+
+// Op names an exchange operation a rule applies to
+type Op string
+
+const (
+	OpGetLatestPrice        Op = "GetLatestPrice"
+	OpGetKlines             Op = "GetKlines"
+	OpGetTradableBalance    Op = "GetTradableBalance"
+	OpGetPositions          Op = "GetPositions"
+	OpPlaceMarketOrder      Op = "PlaceMarketOrder"
+	OpPlaceLimitOrder       Op = "PlaceLimitOrder"
+	OpCancelOrder           Op = "CancelOrder"
+	OpGetOrderStatus        Op = "GetOrderStatus"
+	OpGetOpenOrders         Op = "GetOpenOrders"
+	OpGetTradingConstraints Op = "GetTradingConstraints"
+	OpConnect               Op = "Connect"
+	OpSetPositionTPSL       Op = "SetPositionTPSL"
+	OpGetOrderByClientID    Op = "GetOrderByClientID"
+)
+
+// appliesTo lists the operations each behavioural fault can affect. Latency,
+// timeouts, rate limits and server errors apply to every operation.
+var appliesTo = map[Kind][]Op{
+	PartialFill:  {OpPlaceMarketOrder},
+	LostResponse: {OpPlaceMarketOrder, OpPlaceLimitOrder, OpCancelOrder, OpSetPositionTPSL},
+	StalePrice:   {OpGetLatestPrice, OpGetKlines},
+}
+
+// Rule injects one kind of fault into matching calls
+type Rule struct {
+	Kind        Kind
+	Ops         []Op          // Operations affected, empty = all the kind applies to
+	Probability float64       // Chance per matching call, 0 = every call
+	Skip        int           // Let this many matching calls through first
+	Count       int           // Inject at most this many times, 0 = no limit
+	Latency     time.Duration // Delay for Latency, how long Timeout blocks
+	FillRatio   float64       // Share of the quantity sent for PartialFill (default 0.5)
+}
+
+// matches reports whether the rule can affect op
+func (r *Rule) matches(op Op) bool {
+	ops := r.Ops
+	if len(ops) == 0 {
+		ops = appliesTo[r.Kind]
+		if len(ops) == 0 {
+			return true
+		}
+	}
+	for _, o := range ops {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
+
+// ruleState tracks how often a rule has matched and fired
+type ruleState struct {
+	Rule
+	seen  int
+	fired int
+}
+
+// Error is an injected failure. Its message uses the wording the bot's error
+// categorization recognizes (timeout, rate limit, ...).
+type Error struct {
+	Kind Kind
+	Op   Op
+}
+
+func (e *Error) Error() string {
+	switch e.Kind {
+	case Timeout:
+		return fmt.Sprintf("%s: request timeout: context deadline exceeded (injected)", e.Op)
+	case RateLimit:
+		return fmt.Sprintf("%s: rate limit exceeded: too many requests (HTTP 429, injected)", e.Op)
+	case ServerError:
+		return fmt.Sprintf("%s: HTTP 503 service unavailable (injected)", e.Op)
+	case LostResponse:
+		return fmt.Sprintf("%s: response timeout after request was sent (injected)", e.Op)
+	default:
+		return fmt.Sprintf("%s: %s fault (injected)", e.Op, e.Kind)
+	}
+}
+
+// IsInjected reports whether err is an injected fault of the given kind
+func IsInjected(err error, kind Kind) bool {
+	for err != nil {
+		if fe, ok := err.(*Error); ok {
+			return fe.Kind == kind
+		}
+		u, ok := err.(interface{ Unwrap() error })
+		if !ok {
+			return false
+		}
+		err = u.Unwrap()
+	}
+	return false
+}
+
+// Stats counts calls and injected faults
+type Stats struct {
+	Calls    map[Op]int   // Calls per operation
+	Injected map[Kind]int // Faults injected per kind
+}
+
+// Injector wraps an exchange and injects faults according to its rules
+type Injector struct {
+	inner exchange.LiveTradingExchange
+
+	mu         sync.Mutex
+	rules      []*ruleState
+	rng        *rand.Rand
+	calls      map[Op]int
+	injected   map[Kind]int
+	lastPrice  map[string]float64       // Last price returned per symbol, served by StalePrice
+	lastKlines map[string][]types.OHLCV // Last klines returned per symbol and interval
+}
+
+// Wrap returns an injector around inner with the given rules
+func Wrap(inner exchange.LiveTradingExchange, rules ...Rule) *Injector {
+	i := &Injector{
+		inner:      inner,
+		rng:        rand.New(rand.NewSource(1)),
+		calls:      make(map[Op]int),
+		injected:   make(map[Kind]int),
+		lastPrice:  make(map[string]float64),
+		lastKlines: make(map[string][]types.OHLCV),
+	}
+	for _, r := range rules {
+		i.AddRule(r)
+	}
+	return i
+}
+
+// Exchange returns the faulty exchange. It implements the same optional
+// interfaces (order lookup, position TP/SL) as the wrapped exchange, so the
+// bot takes the same code paths as without the wrapper.
+func (i *Injector) Exchange() exchange.LiveTradingExchange {
+	_, lookup := i.inner.(exchange.OrderLookupExchange)
+	_, tpsl := i.inner.(exchange.PositionTPSLExchange)
+	switch {
+	case lookup && tpsl:
+		return &fullExchange{i}
+	case lookup:
+		return &lookupExchange{i}
+	case tpsl:
+		return &tpslExchange{i}
+	default:
+		return i
+	}
+}
+
+// Inner returns the wrapped exchange
+func (i *Injector) Inner() exchange.LiveTradingExchange {
+	return i.inner
+}
+
+// SetSeed makes probabilistic rules repeatable
+func (i *Injector) SetSeed(seed int64) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.rng = rand.New(rand.NewSource(seed))
+}
+
+// AddRule adds a fault rule
+func (i *Injector) AddRule(r Rule) {
+	if r.Kind == PartialFill && (r.FillRatio <= 0 || r.FillRatio >= 1) {
+		r.FillRatio = 0.5
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.rules = append(i.rules, &ruleState{Rule: r})
+}
+
+// ClearRules removes all rules, so calls go straight to the wrapped exchange
+func (i *Injector) ClearRules() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.rules = nil
+}
+
+// Stats returns a copy of the call and fault counters
+func (i *Injector) Stats() Stats {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	s := Stats{Calls: make(map[Op]int, len(i.calls)), Injected: make(map[Kind]int, len(i.injected))}
+	for k, v := range i.calls {
+		s.Calls[k] = v
+	}
+	for k, v := range i.injected {
+		s.Injected[k] = v
+	}
+	return s
+}
+
+// fault is the outcome of evaluating the rules for one call
+type fault struct {
+	kind      Kind
+	latency   time.Duration
+	fillRatio float64
+}
+
+// evaluate counts the call and picks the faults to inject. All firing latency
+// rules add up; the first firing rule of any other kind decides the outcome.
+func (i *Injector) evaluate(op Op) fault {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.calls[op]++
+	var f fault
+	for _, r := range i.rules {
+		if !r.matches(op) {
+			continue
+		}
+		if r.Kind != Latency && f.kind != "" {
+			continue
+		}
+		r.seen++
+		if r.seen <= r.Skip || (r.Count > 0 && r.fired >= r.Count) {
+			continue
+		}
+		if r.Probability > 0 && i.rng.Float64() >= r.Probability {
+			continue
+		}
+		r.fired++
+		i.injected[r.Kind]++
+
+		if r.Kind == Latency {
+			f.latency += r.Latency
+			continue
+		}
+		f.kind = r.Kind
+		f.fillRatio = r.FillRatio
+		if r.Kind == Timeout {
+			f.latency += r.Latency
+		}
+	}
+	return f
+}
+
+// enter applies the faults for a call: it sleeps for any latency and returns
+// an error for faults that fail the call before it reaches the exchange
+func (i *Injector) enter(ctx context.Context, op Op) (fault, error) {
+	f := i.evaluate(op)
+	if f.latency > 0 {
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		select {
+		case <-ctx.Done():
+			return f, fmt.Errorf("%s: %w", op, ctx.Err())
+		case <-time.After(f.latency):
+		}
+	}
+	switch f.kind {
+	case Timeout, RateLimit, ServerError:
+		return f, &Error{Kind: f.kind, Op: op}
+	}
+	return f, nil
+}
+
+// Exchange identification
+
+func (i *Injector) GetName() string        { return i.inner.GetName() }
+func (i *Injector) IsDemo() bool           { return i.inner.IsDemo() }
+func (i *Injector) GetEnvironment() string { return i.inner.GetEnvironment() }
+
+// Market data operations
+
+func (i *Injector) GetLatestPrice(ctx context.Context, symbol string) (float64, error) {
+	f, err := i.enter(ctx, OpGetLatestPrice)
+	if err != nil {
+		return 0, err
+	}
+	if f.kind == StalePrice {
+		i.mu.Lock()
+		price, seen := i.lastPrice[symbol]
+		i.mu.Unlock()
+		if seen {
+			return price, nil
+		}
+	}
+
+	price, err := i.inner.GetLatestPrice(ctx, symbol)
+	if err == nil {
+		i.mu.Lock()
+		i.lastPrice[symbol] = price
+		i.mu.Unlock()
+	}
+	return price, err
+}
+
+func (i *Injector) GetKlines(ctx context.Context, params exchange.KlineParams) ([]types.OHLCV, error) {
+	f, err := i.enter(ctx, OpGetKlines)
+	if err != nil {
+		return nil, err
+	}
+	key := params.Symbol + "/" + string(params.Interval)
+	if f.kind == StalePrice {
+		i.mu.Lock()
+		klines, seen := i.lastKlines[key]
+		i.mu.Unlock()
+		if seen {
+			return klines, nil
+		}
+	}
+
+	klines, err := i.inner.GetKlines(ctx, params)
+	if err == nil {
+		i.mu.Lock()
+		i.lastKlines[key] = klines
+		i.mu.Unlock()
+	}
+	return klines, err
+}
+
+// Account management
+
+func (i *Injector) GetTradableBalance(ctx context.Context, accountType exchange.AccountType, asset string) (float64, error) {
+	if _, err := i.enter(ctx, OpGetTradableBalance); err != nil {
+		return 0, err
+	}
+	return i.inner.GetTradableBalance(ctx, accountType, asset)
+}
+
+func (i *Injector) GetPositions(ctx context.Context, category, symbol string) ([]exchange.Position, error) {
+	if _, err := i.enter(ctx, OpGetPositions); err != nil {
+		return nil, err
+	}
+	return i.inner.GetPositions(ctx, category, symbol)
+}
+
+// Trading operations
+
+func (i *Injector) PlaceMarketOrder(ctx context.Context, params exchange.OrderParams) (*exchange.Order, error) {
+	f, err := i.enter(ctx, OpPlaceMarketOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	requested := params.Quantity
+	if f.kind == PartialFill {
+		params.Quantity = scaleQuantity(params.Quantity, f.fillRatio)
+	}
+	order, err := i.inner.PlaceMarketOrder(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	switch f.kind {
+	case LostResponse:
+		return nil, &Error{Kind: LostResponse, Op: OpPlaceMarketOrder}
+	case PartialFill:
+		// The rest of the order was not executed and is no longer working
+		partial := *order
+		partial.Quantity = requested
+		partial.OrderStatus = "PartiallyFilledCanceled"
+		return &partial, nil
+	}
+	return order, nil
+}
+
+func (i *Injector) PlaceLimitOrder(ctx context.Context, params exchange.OrderParams) (*exchange.Order, error) {
+	f, err := i.enter(ctx, OpPlaceLimitOrder)
+	if err != nil {
+		return nil, err
+	}
+	order, err := i.inner.PlaceLimitOrder(ctx, params)
+	if err == nil && f.kind == LostResponse {
+		return nil, &Error{Kind: LostResponse, Op: OpPlaceLimitOrder}
+	}
+	return order, err
+}
+
+func (i *Injector) CancelOrder(ctx context.Context, category, symbol, orderID string) error {
+	f, err := i.enter(ctx, OpCancelOrder)
+	if err != nil {
+		return err
+	}
+	err = i.inner.CancelOrder(ctx, category, symbol, orderID)
+	if err == nil && f.kind == LostResponse {
+		return &Error{Kind: LostResponse, Op: OpCancelOrder}
+	}
+	return err
+}
+
+func (i *Injector) GetOrderStatus(ctx context.Context, orderID string) (*exchange.OrderStatus, error) {
+	if _, err := i.enter(ctx, OpGetOrderStatus); err != nil {
+		return nil, err
+	}
+	return i.inner.GetOrderStatus(ctx, orderID)
+}
+
+func (i *Injector) GetOpenOrders(ctx context.Context, category, symbol string) ([]*exchange.Order, error) {
+	if _, err := i.enter(ctx, OpGetOpenOrders); err != nil {
+		return nil, err
+	}
+	return i.inner.GetOpenOrders(ctx, category, symbol)
+}
+
+// Exchange constraints and limits
+
+func (i *Injector) GetTradingConstraints(ctx context.Context, category, symbol string) (*exchange.TradingConstraints, error) {
+	if _, err := i.enter(ctx, OpGetTradingConstraints); err != nil {
+		return nil, err
+	}
+	return i.inner.GetTradingConstraints(ctx, category, symbol)
+}
+
+// Connection management
+
+func (i *Injector) Connect(ctx context.Context) error {
+	if _, err := i.enter(ctx, OpConnect); err != nil {
+		return err
+	}
+	return i.inner.Connect(ctx)
+}
+
+func (i *Injector) Disconnect() error { return i.inner.Disconnect() }
+func (i *Injector) IsConnected() bool { return i.inner.IsConnected() }
+
+// Optional interfaces, exposed through Exchange() only when inner has them
+
+func (i *Injector) setPositionTPSL(ctx context.Context, params exchange.PositionTPSLParams) error {
+	f, err := i.enter(ctx, OpSetPositionTPSL)
+	if err != nil {
+		return err
+	}
+	err = i.inner.(exchange.PositionTPSLExchange).SetPositionTPSL(ctx, params)
+	if err == nil && f.kind == LostResponse {
+		return &Error{Kind: LostResponse, Op: OpSetPositionTPSL}
+	}
+	return err
+}
+
+func (i *Injector) getOrderByClientID(ctx context.Context, category, symbol, clientOrderID string) (*exchange.Order, error) {
+	if _, err := i.enter(ctx, OpGetOrderByClientID); err != nil {
+		return nil, err
+	}
+	return i.inner.(exchange.OrderLookupExchange).GetOrderByClientID(ctx, category, symbol, clientOrderID)
+}
+
+type lookupExchange struct{ *Injector }
+
+func (e *lookupExchange) GetOrderByClientID(ctx context.Context, category, symbol, clientOrderID string) (*exchange.Order, error) {
+	return e.getOrderByClientID(ctx, category, symbol, clientOrderID)
+}
+
+type tpslExchange struct{ *Injector }
+
+func (e *tpslExchange) SetPositionTPSL(ctx context.Context, params exchange.PositionTPSLParams) error {
+	return e.setPositionTPSL(ctx, params)
+}
+
+type fullExchange struct{ *Injector }
+
+func (e *fullExchange) GetOrderByClientID(ctx context.Context, category, symbol, clientOrderID string) (*exchange.Order, error) {
+	return e.getOrderByClientID(ctx, category, symbol, clientOrderID)
+}
+
+func (e *fullExchange) SetPositionTPSL(ctx context.Context, params exchange.PositionTPSLParams) error {
+	return e.setPositionTPSL(ctx, params)
+}
+
+// scaleQuantity multiplies a quantity string, keeping its decimal places
+func scaleQuantity(quantity string, ratio float64) string {
+	qty, err := strconv.ParseFloat(strings.TrimSpace(quantity), 64)
+	if err != nil {
+		return quantity
+	}
+	decimals := 0
+	if dot := strings.IndexByte(quantity, '.'); dot >= 0 {
+		decimals = len(strings.TrimSpace(quantity)) - dot - 1
+	}
+	return strconv.FormatFloat(qty*ratio, 'f', decimals, 64)
+}
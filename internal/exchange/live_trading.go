@@ -39,6 +39,67 @@ type LiveTradingExchange interface {
 	IsConnected() bool
 }
 
+// PositionTPSLExchange is implemented by exchanges that support position-level
+// take profit / stop loss (e.g. Bybit /v5/position/trading-stop)
+type PositionTPSLExchange interface {
+	SetPositionTPSL(ctx context.Context, params PositionTPSLParams) error
+}
+
+// OrderLookupExchange is implemented by exchanges that can find an order by
+// its client-assigned ID, active or already closed
+type OrderLookupExchange interface {
+	// GetOrderByClientID returns nil, nil when no order carries the ID
+	GetOrderByClientID(ctx context.Context, category, symbol, clientOrderID string) (*Order, error)
+}
+
+// RateLimitReportingExchange is implemented by exchanges that report the
+// remaining API quota on their responses (e.g. Bybit X-Bapi-Limit headers)
+type RateLimitReportingExchange interface {
+	// SetRateLimitObserver registers a function called with every reported
+	// quota; it runs on the requesting goroutine and must not block
+	SetRateLimitObserver(observer func(RateLimitStatus))
+	RateLimitStatuses() []RateLimitStatus
+}
+
+// Endpoint groups an exchange reports rate limits under
+const (
+	RateLimitGroupOrder    = "order"
+	RateLimitGroupPosition = "position"
+	RateLimitGroupMarket   = "market"
+	RateLimitGroupAccount  = "account"
+)
+
+// RateLimitStatus is the quota an exchange reported for one endpoint
+type RateLimitStatus struct {
+	Group     string    `json:"group"`     // order, position, market, account
+	Endpoint  string    `json:"endpoint"`  // API path the quota applies to
+	Limit     int       `json:"limit"`     // Requests allowed per window
+	Remaining int       `json:"remaining"` // Requests left in the current window
+	ResetAt   time.Time `json:"reset_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TPSLMode defines whether position TP/SL covers the whole position or a part of it
+type TPSLMode string
+
+const (
+	TPSLModeFull    TPSLMode = "Full"
+	TPSLModePartial TPSLMode = "Partial"
+)
+
+// PositionTPSLParams represents parameters for setting position-level TP/SL
+type PositionTPSLParams struct {
+	Category     string   `json:"category"` // linear, inverse
+	Symbol       string   `json:"symbol"`
+	Mode         TPSLMode `json:"mode"`
+	TakeProfit   string   `json:"take_profit,omitempty"`    // "0" cancels existing TP
+	StopLoss     string   `json:"stop_loss,omitempty"`      // "0" cancels existing SL
+	TPSize       string   `json:"tp_size,omitempty"`        // Partial mode only
+	SLSize       string   `json:"sl_size,omitempty"`        // Partial mode only
+	TPLimitPrice string   `json:"tp_limit_price,omitempty"` // Partial mode only, places a limit TP
+	TriggerBy    string   `json:"trigger_by,omitempty"`     // LastPrice, MarkPrice, IndexPrice
+}
+
 // KlineParams represents parameters for kline/candlestick data requests
 type KlineParams struct {
 	Category string        `json:"category"` // spot, linear, inverse
@@ -74,12 +135,13 @@ const (
 
 // OrderParams represents parameters for placing orders
 type OrderParams struct {
-	Category  string    `json:"category"`  // spot, linear, inverse
-	Symbol    string    `json:"symbol"`
-	Side      OrderSide `json:"side"`
-	Quantity  string    `json:"quantity"`
-	OrderType OrderType `json:"order_type"`
-	Price     string    `json:"price,omitempty"` // For limit orders
+	Category      string    `json:"category"` // spot, linear, inverse
+	Symbol        string    `json:"symbol"`
+	Side          OrderSide `json:"side"`
+	Quantity      string    `json:"quantity"`
+	OrderType     OrderType `json:"order_type"`
+	Price         string    `json:"price,omitempty"`           // For limit orders
+	ClientOrderID string    `json:"client_order_id,omitempty"` // Idempotency key, echoed back in Order
 }
 
 // OrderSide represents buy or sell side (string-based for API compatibility)
@@ -101,6 +163,7 @@ const (
 // Order represents order information returned by exchanges
 type Order struct {
 	OrderID       string    `json:"order_id"`
+	ClientOrderID string    `json:"client_order_id,omitempty"` // Client-assigned ID (Bybit orderLinkId)
 	Symbol        string    `json:"symbol"`
 	Side          OrderSide `json:"side"`
 	OrderType     OrderType `json:"order_type"`
@@ -108,8 +171,10 @@ type Order struct {
 	Price         string    `json:"price"`
 	CumExecQty    string    `json:"cum_exec_qty"`    // Cumulative executed quantity
 	CumExecValue  string    `json:"cum_exec_value"`  // Cumulative executed value
+	CumExecFee    string    `json:"cum_exec_fee,omitempty"` // Cumulative trading fee in the settle coin
 	AvgPrice      string    `json:"avg_price"`       // Average execution price
 	OrderStatus   string    `json:"order_status"`
+	StopOrderType string    `json:"stop_order_type,omitempty"` // Set for conditional/TP-SL orders (e.g. PartialTakeProfit)
 	CreatedTime   time.Time `json:"created_time"`
 	UpdatedTime   time.Time `json:"updated_time"`
 }
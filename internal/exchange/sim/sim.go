@@ -0,0 +1,569 @@
+// Package sim is a deterministic in-memory exchange for one linear symbol.
+// It keeps a long position, a wallet balance and an order book of the
+// caller's own orders, fills market orders at the current price and limit
+// orders when the price crosses them. Prices only change through SetPrice,
+// so failure scenarios can be replayed exactly.
+package sim
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/exchange"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
+)
+
+// Config describes the simulated market and account
+type Config struct {
+	Symbol        string
+	Price         float64 // Starting price
+	Balance       float64 // Starting wallet balance in USDT
+	FeeRate       float64 // Fee per fill as a fraction of its value
+	Leverage      float64 // Margin is position cost / leverage (default 1)
+	QtyStep       float64
+	MinOrderQty   float64
+	MinOrderValue float64
+	PriceStep     float64
+}
+
+// DefaultConfig returns a market with Bybit-like constraints
+func DefaultConfig(symbol string, price float64) Config {
+	return Config{
+		Symbol:        symbol,
+		Price:         price,
+		Balance:       1000,
+		FeeRate:       0.00055,
+		Leverage:      1,
+		QtyStep:       0.001,
+		MinOrderQty:   0.001,
+		MinOrderValue: 5,
+		PriceStep:     0.0001,
+	}
+}
+
+// Exchange is the simulated exchange. It implements
+// exchange.LiveTradingExchange, exchange.OrderLookupExchange and, for full
+// position TP/SL, exchange.PositionTPSLExchange.
+type Exchange struct {
+	cfg Config
+
+	mu         sync.Mutex
+	connected  bool
+	price      float64
+	history    []float64 // Prices set so far, newest last
+	balance    float64
+	size       float64 // Long position quantity
+	cost       float64 // Position cost at entry prices
+	takeProfit float64 // Full position TP trigger, 0 = none
+	stopLoss   float64 // Full position SL trigger, 0 = none
+	orders     []*exchange.Order
+	byID       map[string]*exchange.Order
+	byClientID map[string]*exchange.Order
+	nextID     int
+	now        time.Time
+}
+
+// New creates a simulated exchange
+func New(cfg Config) *Exchange {
+	if cfg.Leverage <= 0 {
+		cfg.Leverage = 1
+	}
+	return &Exchange{
+		cfg:        cfg,
+		price:      cfg.Price,
+		history:    []float64{cfg.Price},
+		balance:    cfg.Balance,
+		byID:       make(map[string]*exchange.Order),
+		byClientID: make(map[string]*exchange.Order),
+		now:        time.Now().Truncate(time.Minute),
+	}
+}
+
+// SetPrice moves the market and fills any crossed limit orders and position TP/SL
+func (e *Exchange) SetPrice(price float64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.price = price
+	e.history = append(e.history, price)
+	e.now = e.now.Add(time.Minute)
+	e.matchLocked()
+}
+
+// Price returns the current price
+func (e *Exchange) Price() float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.price
+}
+
+// Position returns the long position quantity and average entry price
+func (e *Exchange) Position() (size, avgPrice float64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.size, e.avgPriceLocked()
+}
+
+// Balance returns the wallet balance
+func (e *Exchange) Balance() float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.balance
+}
+
+// Orders returns copies of every order received, oldest first
+func (e *Exchange) Orders() []exchange.Order {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	orders := make([]exchange.Order, len(e.orders))
+	for i, o := range e.orders {
+		orders[i] = *o
+	}
+	return orders
+}
+
+// Exchange identification
+
+func (e *Exchange) GetName() string        { return "Simulated" }
+func (e *Exchange) IsDemo() bool           { return true }
+func (e *Exchange) GetEnvironment() string { return "simulation" }
+
+// Market data operations
+
+func (e *Exchange) GetLatestPrice(ctx context.Context, symbol string) (float64, error) {
+	if err := e.checkSymbol(symbol); err != nil {
+		return 0, err
+	}
+	return e.Price(), nil
+}
+
+// GetKlines returns one-minute candles through the prices set so far. Older
+// candles are synthesized around the starting price with a small oscillation
+// so indicators have a full window.
+func (e *Exchange) GetKlines(ctx context.Context, params exchange.KlineParams) ([]types.OHLCV, error) {
+	if err := e.checkSymbol(params.Symbol); err != nil {
+		return nil, err
+	}
+	limit := params.Limit
+	if limit <= 0 {
+		limit = 200
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	closes := make([]float64, 0, limit)
+	for i := limit - len(e.history); i > 0; i-- {
+		closes = append(closes, e.cfg.Price*(1+0.004*math.Sin(float64(i)/3)))
+	}
+	start := len(e.history) - (limit - len(closes))
+	closes = append(closes, e.history[start:]...)
+
+	klines := make([]types.OHLCV, len(closes))
+	for i, c := range closes {
+		open := c
+		if i > 0 {
+			open = closes[i-1]
+		}
+		klines[i] = types.OHLCV{
+			Timestamp: e.now.Add(time.Duration(i-len(closes)+1) * time.Minute),
+			Open:      open,
+			High:      math.Max(open, c) * 1.001,
+			Low:       math.Min(open, c) * 0.999,
+			Close:     c,
+			Volume:    1000,
+		}
+	}
+	return klines, nil
+}
+
+// Account management
+
+// GetTradableBalance returns the wallet balance less the position margin
+func (e *Exchange) GetTradableBalance(ctx context.Context, accountType exchange.AccountType, asset string) (float64, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.availableLocked(), nil
+}
+
+func (e *Exchange) GetPositions(ctx context.Context, category, symbol string) ([]exchange.Position, error) {
+	if err := e.checkSymbol(symbol); err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	pos := exchange.Position{
+		Symbol:      e.cfg.Symbol,
+		Side:        "",
+		Size:        "0",
+		Leverage:    formatFloat(e.cfg.Leverage),
+		MarkPrice:   formatFloat(e.price),
+		UpdatedTime: e.now,
+	}
+	if e.size > 0 {
+		avg := e.avgPriceLocked()
+		pos.Side = "Buy"
+		pos.Size = formatFloat(e.size)
+		pos.PositionValue = formatFloat(e.cost)
+		pos.AvgPrice = formatFloat(avg)
+		pos.UnrealisedPnl = formatFloat(e.size * (e.price - avg))
+		pos.PositionIM = formatFloat(e.cost / e.cfg.Leverage)
+	}
+	return []exchange.Position{pos}, nil
+}
+
+// Trading operations
+
+func (e *Exchange) PlaceMarketOrder(ctx context.Context, params exchange.OrderParams) (*exchange.Order, error) {
+	params.OrderType = exchange.OrderTypeMarket
+	return e.place(params)
+}
+
+func (e *Exchange) PlaceLimitOrder(ctx context.Context, params exchange.OrderParams) (*exchange.Order, error) {
+	params.OrderType = exchange.OrderTypeLimit
+	return e.place(params)
+}
+
+func (e *Exchange) CancelOrder(ctx context.Context, category, symbol, orderID string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	order := e.byID[orderID]
+	if order == nil || !isOpen(order) {
+		return &exchange.ExchangeError{
+			Code:    "110001",
+			Message: "Order does not exist or too late to cancel",
+			Details: orderID,
+		}
+	}
+	order.OrderStatus = "Cancelled"
+	order.UpdatedTime = e.now
+	return nil
+}
+
+func (e *Exchange) GetOrderStatus(ctx context.Context, orderID string) (*exchange.OrderStatus, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	order := e.byID[orderID]
+	if order == nil {
+		return nil, fmt.Errorf("order %s not found", orderID)
+	}
+	return &exchange.OrderStatus{
+		OrderID:     order.OrderID,
+		Status:      order.OrderStatus,
+		ExecutedQty: order.CumExecQty,
+		Price:       order.Price,
+		UpdatedTime: order.UpdatedTime,
+	}, nil
+}
+
+func (e *Exchange) GetOpenOrders(ctx context.Context, category, symbol string) ([]*exchange.Order, error) {
+	if err := e.checkSymbol(symbol); err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var open []*exchange.Order
+	for _, o := range e.orders {
+		if isOpen(o) {
+			copied := *o
+			open = append(open, &copied)
+		}
+	}
+	return open, nil
+}
+
+// GetOrderByClientID returns the order with the client order ID, open or closed
+func (e *Exchange) GetOrderByClientID(ctx context.Context, category, symbol, clientOrderID string) (*exchange.Order, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	order := e.byClientID[clientOrderID]
+	if order == nil {
+		return nil, nil
+	}
+	copied := *order
+	return &copied, nil
+}
+
+// SetPositionTPSL sets a take profit and stop loss for the whole position.
+// Partial mode is not simulated.
+func (e *Exchange) SetPositionTPSL(ctx context.Context, params exchange.PositionTPSLParams) error {
+	if err := e.checkSymbol(params.Symbol); err != nil {
+		return err
+	}
+	if params.Mode == exchange.TPSLModePartial {
+		return fmt.Errorf("partial position TP/SL is not supported by the simulated exchange")
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.size <= 0 {
+		return &exchange.ExchangeError{Code: "10001", Message: "can not set tp/sl/ts for zero position"}
+	}
+	if params.TakeProfit != "" {
+		e.takeProfit, _ = strconv.ParseFloat(params.TakeProfit, 64)
+	}
+	if params.StopLoss != "" {
+		e.stopLoss, _ = strconv.ParseFloat(params.StopLoss, 64)
+	}
+	e.matchLocked()
+	return nil
+}
+
+// Exchange constraints and limits
+
+func (e *Exchange) GetTradingConstraints(ctx context.Context, category, symbol string) (*exchange.TradingConstraints, error) {
+	if err := e.checkSymbol(symbol); err != nil {
+		return nil, err
+	}
+	return &exchange.TradingConstraints{
+		Symbol:         e.cfg.Symbol,
+		MinOrderQty:    e.cfg.MinOrderQty,
+		MaxOrderQty:    1e9,
+		QtyStep:        e.cfg.QtyStep,
+		MinOrderValue:  e.cfg.MinOrderValue,
+		MaxOrderValue:  1e9,
+		MinPriceStep:   e.cfg.PriceStep,
+		MaxLeverage:    100,
+		MarginCurrency: "USDT",
+	}, nil
+}
+
+// Connection management
+
+func (e *Exchange) Connect(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.connected = true
+	return nil
+}
+
+func (e *Exchange) Disconnect() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.connected = false
+	return nil
+}
+
+func (e *Exchange) IsConnected() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.connected
+}
+
+// place validates and records an order, then fills it if it is marketable
+func (e *Exchange) place(params exchange.OrderParams) (*exchange.Order, error) {
+	if err := e.checkSymbol(params.Symbol); err != nil {
+		return nil, err
+	}
+	qty, err := strconv.ParseFloat(strings.TrimSpace(params.Quantity), 64)
+	if err != nil || qty <= 0 {
+		return nil, &exchange.ExchangeError{Code: "10001", Message: "invalid order quantity", Details: params.Quantity}
+	}
+	var limitPrice float64
+	if params.OrderType == exchange.OrderTypeLimit {
+		limitPrice, err = strconv.ParseFloat(strings.TrimSpace(params.Price), 64)
+		if err != nil || limitPrice <= 0 {
+			return nil, &exchange.ExchangeError{Code: "10001", Message: "invalid order price", Details: params.Price}
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if params.ClientOrderID != "" && e.byClientID[params.ClientOrderID] != nil {
+		return nil, &exchange.ExchangeError{Code: "110072", Message: "OrderLinkedID is duplicate", Details: params.ClientOrderID}
+	}
+	if qty < e.cfg.MinOrderQty {
+		return nil, &exchange.ExchangeError{Code: "110094", Message: "order quantity below minimum", Details: params.Quantity}
+	}
+	if params.Side == exchange.OrderSideBuy {
+		price := e.price
+		if limitPrice > 0 {
+			price = limitPrice
+		}
+		if qty*price < e.cfg.MinOrderValue {
+			return nil, &exchange.ExchangeError{Code: "110094", Message: "order value below minimum", Details: params.Quantity}
+		}
+		if qty*price/e.cfg.Leverage > e.availableLocked() {
+			return nil, exchange.ErrInsufficientBalance
+		}
+	}
+
+	e.nextID++
+	order := &exchange.Order{
+		OrderID:       fmt.Sprintf("sim-%06d", e.nextID),
+		ClientOrderID: params.ClientOrderID,
+		Symbol:        e.cfg.Symbol,
+		Side:          params.Side,
+		OrderType:     params.OrderType,
+		Quantity:      formatFloat(qty),
+		Price:         params.Price,
+		CumExecQty:    "0",
+		CumExecValue:  "0",
+		CumExecFee:    "0",
+		AvgPrice:      "0",
+		OrderStatus:   "New",
+		CreatedTime:   e.now,
+		UpdatedTime:   e.now,
+	}
+	e.orders = append(e.orders, order)
+	e.byID[order.OrderID] = order
+	if order.ClientOrderID != "" {
+		e.byClientID[order.ClientOrderID] = order
+	}
+
+	if params.OrderType == exchange.OrderTypeMarket {
+		e.fillLocked(order, qty, e.price)
+		if order.OrderStatus != "Filled" {
+			// Market orders never rest; a reduce-only sell beyond the position
+			// size is cut to what was executed
+			order.OrderStatus = "Cancelled"
+			if executed, _ := strconv.ParseFloat(order.CumExecQty, 64); executed > 0 {
+				order.OrderStatus = "PartiallyFilledCanceled"
+			}
+		}
+	} else {
+		e.matchLocked()
+	}
+
+	copied := *order
+	return &copied, nil
+}
+
+// matchLocked fills crossed limit orders, oldest first, then checks the
+// position TP/SL
+func (e *Exchange) matchLocked() {
+	for _, o := range e.orders {
+		if !isOpen(o) || o.OrderType != exchange.OrderTypeLimit {
+			continue
+		}
+		limit, _ := strconv.ParseFloat(o.Price, 64)
+		crossed := (o.Side == exchange.OrderSideBuy && e.price <= limit) ||
+			(o.Side == exchange.OrderSideSell && e.price >= limit)
+		if !crossed {
+			continue
+		}
+		qty, _ := strconv.ParseFloat(o.Quantity, 64)
+		executed, _ := strconv.ParseFloat(o.CumExecQty, 64)
+		e.fillLocked(o, qty-executed, limit)
+	}
+
+	if e.size <= 0 {
+		e.takeProfit, e.stopLoss = 0, 0
+		return
+	}
+	if (e.takeProfit > 0 && e.price >= e.takeProfit) || (e.stopLoss > 0 && e.price <= e.stopLoss) {
+		stopType := "TakeProfit"
+		if e.stopLoss > 0 && e.price <= e.stopLoss {
+			stopType = "StopLoss"
+		}
+		e.nextID++
+		order := &exchange.Order{
+			OrderID:       fmt.Sprintf("sim-%06d", e.nextID),
+			Symbol:        e.cfg.Symbol,
+			Side:          exchange.OrderSideSell,
+			OrderType:     exchange.OrderTypeMarket,
+			Quantity:      formatFloat(e.size),
+			CumExecQty:    "0",
+			OrderStatus:   "New",
+			StopOrderType: stopType,
+			CreatedTime:   e.now,
+			UpdatedTime:   e.now,
+		}
+		e.orders = append(e.orders, order)
+		e.byID[order.OrderID] = order
+		e.fillLocked(order, e.size, e.price)
+		e.takeProfit, e.stopLoss = 0, 0
+	}
+}
+
+// fillLocked executes up to qty of an order at price. Sells are reduce-only:
+// they execute at most the position size.
+func (e *Exchange) fillLocked(o *exchange.Order, qty, price float64) {
+	if o.Side == exchange.OrderSideSell {
+		qty = math.Min(qty, e.size)
+	}
+	if qty <= 0 {
+		if o.Side == exchange.OrderSideSell && e.size <= 0 && o.OrderType == exchange.OrderTypeLimit {
+			// Reduce-only orders are cancelled once the position is gone
+			o.OrderStatus = "Cancelled"
+			o.UpdatedTime = e.now
+		}
+		return
+	}
+
+	value := qty * price
+	fee := value * e.cfg.FeeRate
+	e.balance -= fee
+	if o.Side == exchange.OrderSideBuy {
+		e.size += qty
+		e.cost += value
+	} else {
+		avg := e.avgPriceLocked()
+		e.balance += qty * (price - avg)
+		e.cost -= qty * avg
+		e.size -= qty
+		if e.size < e.cfg.QtyStep/2 {
+			e.size, e.cost = 0, 0
+		}
+	}
+
+	executed, _ := strconv.ParseFloat(o.CumExecQty, 64)
+	execValue, _ := strconv.ParseFloat(o.CumExecValue, 64)
+	execFee, _ := strconv.ParseFloat(o.CumExecFee, 64)
+	executed += qty
+	execValue += value
+	execFee += fee
+	o.CumExecQty = formatFloat(executed)
+	o.CumExecValue = formatFloat(execValue)
+	o.CumExecFee = formatFloat(execFee)
+	o.AvgPrice = formatFloat(execValue / executed)
+	o.UpdatedTime = e.now
+
+	total, _ := strconv.ParseFloat(o.Quantity, 64)
+	if executed >= total-e.cfg.QtyStep/2 {
+		o.OrderStatus = "Filled"
+	} else {
+		o.OrderStatus = "PartiallyFilled"
+	}
+}
+
+func (e *Exchange) availableLocked() float64 {
+	return e.balance - e.cost/e.cfg.Leverage
+}
+
+func (e *Exchange) avgPriceLocked() float64 {
+	if e.size <= 0 {
+		return 0
+	}
+	return e.cost / e.size
+}
+
+func (e *Exchange) checkSymbol(symbol string) error {
+	if symbol != e.cfg.Symbol {
+		return exchange.ErrInvalidSymbol
+	}
+	return nil
+}
+
+// isOpen reports whether an order is still working on the book
+func isOpen(o *exchange.Order) bool {
+	return o.OrderStatus == "New" || o.OrderStatus == "PartiallyFilled"
+}
+
+// formatFloat formats a number the way the exchange API returns it
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(math.Round(v*1e8)/1e8, 'f', -1, 64)
+}
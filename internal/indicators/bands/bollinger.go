@@ -2,6 +2,7 @@ package bands
 
 import (
 	"errors"
+	"fmt"
 	"math"
 
 	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators/common"
@@ -285,6 +286,11 @@ func (bb *BollingerBands) GetName() string {
 	}
 }
 
+// SeriesKey identifies the indicator and its parameters in the series cache
+func (bb *BollingerBands) SeriesKey() string {
+	return fmt.Sprintf("bb(%d,%g,%d,%g,%g)", bb.period, bb.stdDev, bb.maType, bb.percentBOversold, bb.percentBOverbought)
+}
+
 // GetRequiredPeriods returns the minimum number of periods needed
 func (bb *BollingerBands) GetRequiredPeriods() int {
 	return bb.period
@@ -0,0 +1,176 @@
+package bands
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators/common"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
+)
+
+const (
+	// DefaultDonchianPeriod is the default lookback of the Donchian Channels
+	DefaultDonchianPeriod = 20
+
+	// donchianZone is the part of the channel near each band that counts as a
+	// buy (lower) or sell (upper) zone
+	donchianZone = 0.2
+)
+
+// DonchianChannels represents the Donchian Channels technical indicator
+//
+//	Upper Band  = highest high over the period
+//	Lower Band  = lowest low over the period
+//	Middle Line = (Upper Band + Lower Band) / 2
+//
+// Buys are signalled in the lower part of the channel unless the current
+// candle broke below the previous channel, which marks a downtrend breakout.
+type DonchianChannels struct {
+	period int // Lookback period
+
+	// Last calculated values
+	lastUpper     float64
+	lastMiddle    float64
+	lastLower     float64
+	previousLower float64 // Lower band of the period before the current candle
+	lastLow       float64 // Low of the current candle
+	lastClose     float64
+}
+
+// NewDonchianChannels creates a new Donchian Channels indicator with the default period
+func NewDonchianChannels() *DonchianChannels {
+	return NewDonchianChannelsWithPeriod(DefaultDonchianPeriod)
+}
+
+// NewDonchianChannelsWithPeriod creates a new Donchian Channels indicator with given period
+func NewDonchianChannelsWithPeriod(period int) *DonchianChannels {
+	return &DonchianChannels{period: period}
+}
+
+// Calculate calculates the Donchian Channels values (returns middle line)
+func (dc *DonchianChannels) Calculate(data []types.OHLCV) (float64, error) {
+	if dc.period < 1 {
+		return 0, errors.New("Donchian period must be positive")
+	}
+	if len(data) < dc.GetRequiredPeriods() {
+		return 0, errors.New("insufficient data points for Donchian Channels calculation")
+	}
+
+	n := len(data)
+	window := data[n-dc.period:]
+	dc.lastUpper = common.HighestHigh(window)
+	dc.lastLower = common.LowestLow(window)
+	dc.lastMiddle = (dc.lastUpper + dc.lastLower) / 2
+	dc.previousLower = common.LowestLow(data[n-1-dc.period : n-1])
+	dc.lastLow = data[n-1].Low
+	dc.lastClose = data[n-1].Close
+
+	return dc.lastMiddle, nil
+}
+
+// ShouldBuy determines if we should buy based on Donchian Channels
+func (dc *DonchianChannels) ShouldBuy(current float64, data []types.OHLCV) (bool, error) {
+	_, err := dc.Calculate(data)
+	if err != nil {
+		return false, err
+	}
+
+	// Buy signal: price in the lower zone of a channel that is not breaking down
+	return dc.GetChannelPosition(current) <= donchianZone && !dc.IsBreakingDown(), nil
+}
+
+// ShouldSell determines if we should sell based on Donchian Channels
+func (dc *DonchianChannels) ShouldSell(current float64, data []types.OHLCV) (bool, error) {
+	_, err := dc.Calculate(data)
+	if err != nil {
+		return false, err
+	}
+
+	// Sell signal: price in the upper zone of the channel
+	return dc.GetChannelPosition(current) >= 1-donchianZone, nil
+}
+
+// GetSignalStrength returns the signal strength based on the last close's
+// position within the channel
+func (dc *DonchianChannels) GetSignalStrength() float64 {
+	return dc.GetSignalStrengthForPrice(dc.lastClose)
+}
+
+// GetSignalStrengthForPrice returns the signal strength based on given price position within channels
+func (dc *DonchianChannels) GetSignalStrengthForPrice(price float64) float64 {
+	if dc.lastUpper == dc.lastLower {
+		return 0 // No channel width
+	}
+
+	position := dc.GetChannelPosition(price)
+	if position <= donchianZone {
+		return (donchianZone - position) / donchianZone
+	} else if position >= 1-donchianZone {
+		return (position - (1 - donchianZone)) / donchianZone
+	}
+
+	return 0 // Neutral zone
+}
+
+// GetName returns the indicator name
+func (dc *DonchianChannels) GetName() string {
+	return "Donchian Channels"
+}
+
+// SeriesKey identifies the indicator and its parameters in the series cache
+func (dc *DonchianChannels) SeriesKey() string {
+	return fmt.Sprintf("donchian(%d)", dc.period)
+}
+
+// String returns the string representation of the Donchian Channels
+func (dc *DonchianChannels) String() string {
+	return fmt.Sprintf("DC(%d)", dc.period)
+}
+
+// GetRequiredPeriods returns the minimum number of periods needed: the
+// channel plus one candle to compare the previous channel with
+func (dc *DonchianChannels) GetRequiredPeriods() int {
+	return dc.period + 1
+}
+
+// GetChannels returns the current channel values
+func (dc *DonchianChannels) GetChannels() (upper, middle, lower float64) {
+	return dc.lastUpper, dc.lastMiddle, dc.lastLower
+}
+
+// GetChannelWidth returns the current width of the channel
+func (dc *DonchianChannels) GetChannelWidth() float64 {
+	return dc.lastUpper - dc.lastLower
+}
+
+// GetChannelPosition returns the position of price within channel (0-1 range)
+func (dc *DonchianChannels) GetChannelPosition(price float64) float64 {
+	if dc.lastUpper == dc.lastLower {
+		return 0.5 // Default middle if no width
+	}
+
+	position := (price - dc.lastLower) / (dc.lastUpper - dc.lastLower)
+	return math.Max(0, math.Min(1, position)) // Clamp to 0-1 range
+}
+
+// IsBreakingDown returns true if the current candle made a new low below the
+// previous channel
+func (dc *DonchianChannels) IsBreakingDown() bool {
+	return dc.lastLow < dc.previousLower
+}
+
+// GetPeriod returns the lookback period
+func (dc *DonchianChannels) GetPeriod() int {
+	return dc.period
+}
+
+// ResetState resets the Donchian Channels internal state for new data periods
+func (dc *DonchianChannels) ResetState() {
+	dc.lastUpper = 0.0
+	dc.lastMiddle = 0.0
+	dc.lastLower = 0.0
+	dc.previousLower = 0.0
+	dc.lastLow = 0.0
+	dc.lastClose = 0.0
+}
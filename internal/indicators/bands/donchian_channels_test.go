@@ -0,0 +1,102 @@
+package bands
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
+)
+
+// donchianFixture holds high, low, close triples
+var donchianFixture = [][3]float64{
+	{10, 8, 9},
+	{12, 9, 11},
+	{11, 7, 8},
+	{13, 10, 12},
+	{12, 9, 9.5},
+	{11, 6, 6.5}, // Breaks below the previous 3-candle low of 7
+}
+
+func donchianCandles(n int) []types.OHLCV {
+	data := make([]types.OHLCV, n)
+	for i, c := range donchianFixture[:n] {
+		data[i] = types.OHLCV{Open: c[2], High: c[0], Low: c[1], Close: c[2]}
+	}
+	return data
+}
+
+// With period 3 on the first five candles the channel spans candles 2-4:
+// upper 13, lower 7, middle 10; the previous channel (candles 1-3) has
+// lower 7, which candle 4's low of 9 does not break
+func TestDonchianReferenceValues(t *testing.T) {
+	dc := NewDonchianChannelsWithPeriod(3)
+	middle, err := dc.Calculate(donchianCandles(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	upper, mid, lower := dc.GetChannels()
+	assertClose(t, "returned middle", middle, 10)
+	assertClose(t, "upper", upper, 13)
+	assertClose(t, "middle", mid, 10)
+	assertClose(t, "lower", lower, 7)
+	assertClose(t, "width", dc.GetChannelWidth(), 6)
+	assertClose(t, "position of the close", dc.GetChannelPosition(9.5), 2.5/6)
+	assertClose(t, "position below the channel", dc.GetChannelPosition(5), 0)
+	if dc.IsBreakingDown() {
+		t.Error("candle 4 does not break the previous channel")
+	}
+
+	tests := []struct {
+		price     float64
+		buy, sell bool
+		strength  float64
+	}{
+		{8, true, false, (0.2 - 1.0/6) / 0.2}, // Position 1/6, in the lower 20%
+		{9.5, false, false, 0},                // Position 5/12, neutral
+		{12.5, false, true, (5.5/6 - 0.8) / 0.2},
+	}
+	data := donchianCandles(5)
+	for _, tt := range tests {
+		buy, _ := dc.ShouldBuy(tt.price, data)
+		sell, _ := dc.ShouldSell(tt.price, data)
+		if buy != tt.buy || sell != tt.sell {
+			t.Errorf("price %.1f: buy %v sell %v, want %v %v", tt.price, buy, sell, tt.buy, tt.sell)
+		}
+		assertClose(t, "strength", dc.GetSignalStrengthForPrice(tt.price), tt.strength)
+	}
+}
+
+// A new low below the previous channel blocks buys even at the bottom of the
+// channel
+func TestDonchianBreakdown(t *testing.T) {
+	dc := NewDonchianChannelsWithPeriod(3)
+	data := donchianCandles(6)
+	buy, err := dc.ShouldBuy(6.5, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	upper, _, lower := dc.GetChannels()
+	assertClose(t, "upper", upper, 13)
+	assertClose(t, "lower", lower, 6)
+	if !dc.IsBreakingDown() {
+		t.Error("low of 6 breaks the previous channel low of 7")
+	}
+	if buy {
+		t.Error("bought into a breakdown")
+	}
+}
+
+func TestDonchianInsufficientData(t *testing.T) {
+	if _, err := NewDonchianChannelsWithPeriod(3).Calculate(donchianCandles(3)); err == nil {
+		t.Error("expected an error with fewer than period + 1 candles")
+	}
+}
+
+func assertClose(t *testing.T, name string, got, want float64) {
+	t.Helper()
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("%s = %.12f, want %.12f", name, got, want)
+	}
+}
@@ -189,6 +189,11 @@ func (kc *KeltnerChannels) GetName() string {
 	return "Keltner Channels"
 }
 
+// SeriesKey identifies the indicator and its parameters in the series cache
+func (kc *KeltnerChannels) SeriesKey() string {
+	return fmt.Sprintf("keltner(%d,%g)", kc.period, kc.multiplier)
+}
+
 // String returns the string representation of the Keltner Channels (like the reference implementation)
 func (kc *KeltnerChannels) String() string {
 	return fmt.Sprintf("KC(%d)", kc.period)
@@ -2,6 +2,7 @@ package common
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
 )
@@ -122,6 +123,11 @@ func (e *EMA) GetName() string {
 	return "EMA"
 }
 
+// SeriesKey identifies the indicator and its parameters in the series cache
+func (e *EMA) SeriesKey() string {
+	return fmt.Sprintf("ema(%d)", e.period)
+}
+
 // GetRequiredPeriods returns the minimum number of periods needed
 func (e *EMA) GetRequiredPeriods() int {
 	return e.period
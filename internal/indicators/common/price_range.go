@@ -0,0 +1,39 @@
+package common
+
+import (
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
+)
+
+// HighestHigh returns the highest high of the candles
+func HighestHigh(data []types.OHLCV) float64 {
+	if len(data) == 0 {
+		return 0
+	}
+	highest := data[0].High
+	for _, candle := range data[1:] {
+		if candle.High > highest {
+			highest = candle.High
+		}
+	}
+	return highest
+}
+
+// LowestLow returns the lowest low of the candles
+func LowestLow(data []types.OHLCV) float64 {
+	if len(data) == 0 {
+		return 0
+	}
+	lowest := data[0].Low
+	for _, candle := range data[1:] {
+		if candle.Low < lowest {
+			lowest = candle.Low
+		}
+	}
+	return lowest
+}
+
+// MidRange returns the midpoint of the highest high and lowest low, the
+// building block of Ichimoku and Donchian middle lines
+func MidRange(data []types.OHLCV) float64 {
+	return (HighestHigh(data) + LowestLow(data)) / 2
+}
@@ -2,6 +2,7 @@ package common
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
 )
@@ -120,6 +121,11 @@ func (s *SMA) GetName() string {
 	return "SMA"
 }
 
+// SeriesKey identifies the indicator and its parameters in the series cache
+func (s *SMA) SeriesKey() string {
+	return fmt.Sprintf("sma(%d)", s.period)
+}
+
 // GetRequiredPeriods returns the minimum number of periods needed
 func (s *SMA) GetRequiredPeriods() int {
 	return s.period
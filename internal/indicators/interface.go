@@ -16,6 +16,26 @@ type TechnicalIndicator interface {
 	ResetState()
 }
 
+// CycleAwareIndicator is implemented by indicators anchored at the start of
+// the current DCA cycle, such as the cycle-anchored VWAP
+type CycleAwareIndicator interface {
+	SetCycleStart(start time.Time) // Zero time when no cycle is open
+}
+
+// EntryFilter is implemented by indicators that only screen entries, such as
+// ADX. They cast no buy vote and stay out of the vote count; their sell
+// signal blocks entries like a veto indicator's.
+type EntryFilter interface {
+	IsEntryFilter() bool
+}
+
+// SeriesKeyer is implemented by indicators whose results depend only on the
+// candles passed and their parameters, so one series can serve every backtest
+// over the same data
+type SeriesKeyer interface {
+	SeriesKey() string // Indicator type and parameters, "" if not cacheable
+}
+
 type Signal struct {
 	Type      SignalType
 	Strength  float64
@@ -2,6 +2,7 @@ package indicators
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -24,6 +25,12 @@ type IndicatorManager struct {
 	cache         map[string]*IndicatorResult
 	lastTimestamp time.Time
 	mutex         sync.RWMutex // Thread-safe caching
+
+	// Series of the bound dataset by indicator name, read by bar index
+	series      map[string][]IndicatorResult
+	seriesData  []types.OHLCV
+	seriesStart int // Bar of the first series entry (the backtest window)
+	nextBar     int // Bar expected on the next call
 }
 
 // NewIndicatorManager creates a new indicator manager
@@ -47,6 +54,7 @@ func (m *IndicatorManager) ResetAllIndicators() {
 	// Clear cache
 	m.cache = make(map[string]*IndicatorResult)
 	m.lastTimestamp = time.Time{}
+	m.unbindSeries()
 }
 
 // AddIndicator adds an indicator to the manager
@@ -65,59 +73,142 @@ func (m *IndicatorManager) ProcessCandle(candle types.OHLCV, data []types.OHLCV)
 	}
 	m.mutex.RUnlock()
 
-	// Process all indicators in batch
+	// Process all indicators in batch, reading bound series where available
 	results := make(map[string]*IndicatorResult, len(m.indicators))
+	bound, index := m.seriesIndex(candle.Timestamp)
 	
 	for _, indicator := range m.indicators {
 		name := indicator.GetName()
-		result := &IndicatorResult{Timestamp: candle.Timestamp}
-		
-		// Skip if insufficient data
-		if len(data) < indicator.GetRequiredPeriods() {
-			result.Error = NewInsufficientDataError(name, len(data), indicator.GetRequiredPeriods())
-			results[name] = result
+		if series, ok := bound[name]; ok {
+			results[name] = &series[index]
 			continue
 		}
-		
-		// Single calculation per indicator (most expensive operation)
-		value, err := indicator.Calculate(data)
-		if err != nil {
-			result.Error = err
-			results[name] = result
+		results[name] = evaluateIndicator(indicator, candle, data)
+	}
+	
+	// Update cache atomically
+	m.mutex.Lock()
+	m.cache = results
+	m.lastTimestamp = candle.Timestamp
+	m.mutex.Unlock()
+	
+	return results
+}
+
+// evaluateIndicator calculates one indicator's value and signals for a candle
+func evaluateIndicator(indicator TechnicalIndicator, candle types.OHLCV, data []types.OHLCV) *IndicatorResult {
+	name := indicator.GetName()
+	result := &IndicatorResult{Timestamp: candle.Timestamp}
+	
+	// Skip if insufficient data
+	if len(data) < indicator.GetRequiredPeriods() {
+		result.Error = NewInsufficientDataError(name, len(data), indicator.GetRequiredPeriods())
+		return result
+	}
+	
+	// Single calculation per indicator (most expensive operation)
+	value, err := indicator.Calculate(data)
+	if err != nil {
+		result.Error = err
+		return result
+	}
+	result.Value = value
+	
+	// Efficient signal calculation using cached value and current price
+	shouldBuy, err := indicator.ShouldBuy(candle.Close, data)
+	if err != nil {
+		result.Error = err
+		return result
+	}
+	result.ShouldBuy = shouldBuy
+	
+	shouldSell, err := indicator.ShouldSell(candle.Close, data)
+	if err != nil {
+		result.Error = err
+		return result
+	}
+	result.ShouldSell = shouldSell
+	
+	// Get signal strength (usually lightweight)
+	result.Strength = indicator.GetSignalStrength()
+	
+	return result
+}
+
+// BindSeries makes the next backtest over data with the given window read
+// its cacheable indicators from series in cache instead of calculating them
+// candle by candle. Series missing from the cache are computed here with the
+// manager's own indicators, which are reset afterwards.
+func (m *IndicatorManager) BindSeries(cache *SeriesCache, data []types.OHLCV, windowSize int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	
+	m.unbindSeries()
+	if cache == nil || windowSize < 0 || len(data) <= windowSize {
+		return
+	}
+	
+	series := make(map[string][]IndicatorResult)
+	for _, indicator := range m.indicators {
+		keyer, ok := indicator.(SeriesKeyer)
+		if !ok {
 			continue
 		}
-		result.Value = value
-		
-		// Efficient signal calculation using cached value and current price
-		shouldBuy, err := indicator.ShouldBuy(candle.Close, data)
-		if err != nil {
-			result.Error = err
-			results[name] = result
+		key := keyer.SeriesKey()
+		if key == "" {
 			continue
 		}
-		result.ShouldBuy = shouldBuy
 		
-		shouldSell, err := indicator.ShouldSell(candle.Close, data)
-		if err != nil {
-			result.Error = err
-			results[name] = result
-			continue
+		indicator := indicator
+		values := cache.Series(data, windowSize, key, func() []IndicatorResult {
+			indicator.ResetState()
+			defer indicator.ResetState()
+			return computeSeries(indicator, data, windowSize)
+		})
+		if values != nil {
+			series[indicator.GetName()] = values
 		}
-		result.ShouldSell = shouldSell
-		
-		// Get signal strength (usually lightweight)
-		result.Strength = indicator.GetSignalStrength()
-		
-		results[name] = result
 	}
 	
-	// Update cache atomically
+	m.series = series
+	m.seriesData = data
+	m.seriesStart = windowSize
+	m.nextBar = windowSize
+}
+
+// unbindSeries returns to calculating every indicator candle by candle
+func (m *IndicatorManager) unbindSeries() {
+	m.series = nil
+	m.seriesData = nil
+	m.seriesStart = 0
+	m.nextBar = 0
+}
+
+// seriesIndex returns the bound series and the index in them of the bar at
+// timestamp t; the series are nil if none are bound or t is not a bar they
+// cover. Bars are usually asked for in order, so the next bar is checked
+// before searching.
+func (m *IndicatorManager) seriesIndex(t time.Time) (map[string][]IndicatorResult, int) {
 	m.mutex.Lock()
-	m.cache = results
-	m.lastTimestamp = candle.Timestamp
-	m.mutex.Unlock()
+	defer m.mutex.Unlock()
 	
-	return results
+	if m.series == nil {
+		return nil, -1
+	}
+	bar := m.nextBar
+	if bar >= len(m.seriesData) || !m.seriesData[bar].Timestamp.Equal(t) {
+		bar = sort.Search(len(m.seriesData), func(i int) bool {
+			return !m.seriesData[i].Timestamp.Before(t)
+		})
+		if bar >= len(m.seriesData) || !m.seriesData[bar].Timestamp.Equal(t) {
+			return nil, -1
+		}
+	}
+	if bar < m.seriesStart {
+		return nil, -1
+	}
+	m.nextBar = bar + 1
+	return m.series, bar - m.seriesStart
 }
 
 // GetCachedResults returns cached results without processing
@@ -143,6 +234,16 @@ func (m *IndicatorManager) ClearCache() {
 	m.lastTimestamp = time.Time{}
 }
 
+// SetCycleStart passes the start of the current DCA cycle to the cycle-aware
+// indicators; the zero time marks that no cycle is open
+func (m *IndicatorManager) SetCycleStart(start time.Time) {
+	for _, indicator := range m.indicators {
+		if aware, ok := indicator.(CycleAwareIndicator); ok {
+			aware.SetCycleStart(start)
+		}
+	}
+}
+
 // GetIndicators returns all managed indicators
 func (m *IndicatorManager) GetIndicators() []TechnicalIndicator {
 	return m.indicators
@@ -2,6 +2,7 @@ package oscillators
 
 import (
 	"errors"
+	"fmt"
 	"math"
 
 	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators/common"
@@ -110,6 +111,11 @@ func (m *MACD) GetName() string {
 	return "MACD (Optimized)"
 }
 
+// SeriesKey identifies the indicator and its parameters in the series cache
+func (m *MACD) SeriesKey() string {
+	return fmt.Sprintf("macd(%d,%d,%d)", m.fastPeriod, m.slowPeriod, m.signalPeriod)
+}
+
 // GetRequiredPeriods returns the minimum number of periods needed
 func (m *MACD) GetRequiredPeriods() int {
 	return m.slowPeriod + m.signalPeriod
@@ -214,6 +214,11 @@ func (m *MFI) GetName() string {
 	return "MFI"
 }
 
+// SeriesKey identifies the indicator and its parameters in the series cache
+func (m *MFI) SeriesKey() string {
+	return fmt.Sprintf("mfi(%d,%g,%g)", m.period, m.oversold, m.overbought)
+}
+
 // String returns the string representation of the MFI (like the reference implementation)
 func (m *MFI) String() string {
 	return fmt.Sprintf("MFI(%d)", m.period)
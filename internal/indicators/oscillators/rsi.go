@@ -2,6 +2,7 @@ package oscillators
 
 import (
 	"errors"
+	"fmt"
 	"math"
 
 	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
@@ -142,6 +143,11 @@ func (r *RSI) GetName() string {
 	return "RSI"
 }
 
+// SeriesKey identifies the indicator and its parameters in the series cache
+func (r *RSI) SeriesKey() string {
+	return fmt.Sprintf("rsi(%d,%g,%g)", r.period, r.oversold, r.overbought)
+}
+
 func (r *RSI) GetRequiredPeriods() int {
 	return r.period + 1
 }
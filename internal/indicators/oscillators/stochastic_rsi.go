@@ -2,6 +2,7 @@ package oscillators
 
 import (
 	"errors"
+	"fmt"
 	"math"
 
 	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
@@ -205,6 +206,11 @@ func (s *StochasticRSI) GetName() string {
 	return "Stochastic RSI"
 }
 
+// SeriesKey identifies the indicator and its parameters in the series cache
+func (s *StochasticRSI) SeriesKey() string {
+	return fmt.Sprintf("stochrsi(%d,%g,%g)", s.period, s.oversold, s.overbought)
+}
+
 // GetRequiredPeriods returns the minimum number of data points required
 func (s *StochasticRSI) GetRequiredPeriods() int {
 	return s.rsi.GetRequiredPeriods() + s.period - 1
@@ -2,6 +2,7 @@ package oscillators
 
 import (
 	"errors"
+	"fmt"
 	"math"
 
 	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators/common"
@@ -229,6 +230,11 @@ func (wt *WaveTrend) GetName() string {
 	return "WaveTrend"
 }
 
+// SeriesKey identifies the indicator and its parameters in the series cache
+func (wt *WaveTrend) SeriesKey() string {
+	return fmt.Sprintf("wavetrend(%d,%d,%g,%g)", wt.n1, wt.n2, wt.overSold, wt.overBought)
+}
+
 // GetRequiredPeriods returns the minimum number of periods needed
 func (wt *WaveTrend) GetRequiredPeriods() int {
 	return wt.getMinRequiredPeriods()
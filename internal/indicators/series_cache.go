@@ -0,0 +1,162 @@
+package indicators
+
+import (
+	"container/list"
+	"sync"
+	"unsafe"
+
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
+)
+
+// SeriesCache shares precomputed indicator result series between backtests
+// over the same data, such as the individuals of an optimization that differ
+// only in TP or sizing. A series is keyed by the dataset, the backtest window
+// and the indicator's SeriesKey, computed once by the first backtest asking
+// for it and read by bar index afterwards.
+//
+// The cache is safe for concurrent use. Once the series held exceed the
+// memory budget, the least recently used ones are evicted; backtests still
+// reading an evicted series keep their copy.
+type SeriesCache struct {
+	budget  int64 // Memory budget in bytes
+	used    int64
+	entries map[seriesKey]*seriesEntry
+	lru     *list.List // Stored entries, most recently used first
+	stats   SeriesCacheStats
+	mutex   sync.Mutex
+}
+
+// SeriesCacheStats reports how well the cache is working
+type SeriesCacheStats struct {
+	Hits      int64 // Series served from the cache or from a computation in progress
+	Misses    int64 // Series computed
+	Evictions int64 // Series dropped to stay within the budget
+	Entries   int   // Series held
+	Bytes     int64 // Estimated size of the series held
+}
+
+// seriesKey identifies a series; the dataset is identified by its first
+// candle and length, so sub-slices such as walk-forward folds get their own
+type seriesKey struct {
+	first     *types.OHLCV
+	length    int
+	window    int
+	indicator string
+}
+
+type seriesEntry struct {
+	key     seriesKey
+	series  []IndicatorResult
+	size    int64
+	ready   chan struct{} // Closed once series is set
+	element *list.Element // Position in the LRU list, nil until stored
+}
+
+// seriesResultBytes is the estimated memory of one bar of a series
+const seriesResultBytes = int64(unsafe.Sizeof(IndicatorResult{}))
+
+// NewSeriesCache creates a cache holding at most budgetBytes of series
+func NewSeriesCache(budgetBytes int64) *SeriesCache {
+	return &SeriesCache{
+		budget:  budgetBytes,
+		entries: make(map[seriesKey]*seriesEntry),
+		lru:     list.New(),
+	}
+}
+
+// Series returns the series of an indicator over data with the given
+// backtest window, calling compute if no backtest computed it yet. Concurrent
+// callers asking for the same series wait for a single computation; they get
+// nil if it panicked.
+func (c *SeriesCache) Series(data []types.OHLCV, windowSize int, indicatorKey string, compute func() []IndicatorResult) []IndicatorResult {
+	if len(data) == 0 {
+		return nil
+	}
+	key := seriesKey{first: &data[0], length: len(data), window: windowSize, indicator: indicatorKey}
+
+	c.mutex.Lock()
+	if entry, ok := c.entries[key]; ok {
+		c.stats.Hits++
+		if entry.element != nil {
+			c.lru.MoveToFront(entry.element)
+		}
+		c.mutex.Unlock()
+		<-entry.ready
+		return entry.series
+	}
+	entry := &seriesEntry{key: key, ready: make(chan struct{})}
+	c.entries[key] = entry
+	c.stats.Misses++
+	c.mutex.Unlock()
+
+	stored := false
+	defer func() {
+		if !stored {
+			// compute panicked: let waiters fall back and a later caller retry
+			c.mutex.Lock()
+			delete(c.entries, key)
+			c.mutex.Unlock()
+			close(entry.ready)
+		}
+	}()
+
+	series := compute()
+
+	c.mutex.Lock()
+	entry.series = series
+	entry.size = int64(len(series)) * seriesResultBytes
+	if entry.size > c.budget {
+		// Larger than the whole budget: serve it once without keeping it
+		delete(c.entries, key)
+	} else {
+		entry.element = c.lru.PushFront(entry)
+		c.used += entry.size
+		c.evict()
+	}
+	stored = true
+	c.mutex.Unlock()
+	close(entry.ready)
+
+	return series
+}
+
+// evict drops least recently used series until the budget is met
+func (c *SeriesCache) evict() {
+	for c.used > c.budget {
+		back := c.lru.Back()
+		if back == nil {
+			return
+		}
+		entry := back.Value.(*seriesEntry)
+		c.lru.Remove(back)
+		delete(c.entries, entry.key)
+		c.used -= entry.size
+		c.stats.Evictions++
+	}
+}
+
+// Stats returns the cache's counters
+func (c *SeriesCache) Stats() SeriesCacheStats {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	stats := c.stats
+	stats.Entries = c.lru.Len()
+	stats.Bytes = c.used
+	return stats
+}
+
+// computeSeries evaluates an indicator over data the way a backtest with the
+// given window does: once per bar from windowSize on, on the window ending at
+// that bar, reusing the previous result for a repeated timestamp
+func computeSeries(indicator TechnicalIndicator, data []types.OHLCV, windowSize int) []IndicatorResult {
+	series := make([]IndicatorResult, len(data)-windowSize)
+	for i := windowSize; i < len(data); i++ {
+		if i > windowSize && data[i].Timestamp.Equal(data[i-1].Timestamp) {
+			series[i-windowSize] = series[i-windowSize-1]
+			continue
+		}
+		series[i-windowSize] = *evaluateIndicator(indicator, data[i], data[i-windowSize:i+1])
+	}
+	return series
+}
@@ -34,6 +34,12 @@ const (
 	IndicatorTypeOBV              IndicatorType = "OBV"
 	IndicatorTypeStochasticRSI    IndicatorType = "STOCHASTIC_RSI"
 	IndicatorTypeSuperTrend       IndicatorType = "SUPERTREND"
+	IndicatorTypeADX              IndicatorType = "ADX"
+	IndicatorTypeIchimoku         IndicatorType = "ICHIMOKU"
+	IndicatorTypeDonchian         IndicatorType = "DONCHIAN"
+	IndicatorTypeAnchoredVWAP     IndicatorType = "ANCHORED_VWAP"
+	IndicatorTypeCMF              IndicatorType = "CMF"
+	IndicatorTypeVolumeProfile    IndicatorType = "VOLUME_PROFILE"
 )
 
 // IndicatorFactory creates technical indicators based on type and parameters
@@ -175,6 +181,98 @@ func (f *IndicatorFactory) CreateIndicator(indicatorType IndicatorType, params m
 		
 		return trend.NewSuperTrendWithParams(period, multiplier), nil
 		
+	case IndicatorTypeADX:
+		period := trend.DefaultADXPeriod
+		threshold := trend.DefaultADXThreshold
+		
+		if p, ok := params["period"].(int); ok {
+			period = p
+		}
+		if t, ok := params["threshold"].(float64); ok {
+			threshold = t
+		}
+		
+		return trend.NewADXWithParams(period, threshold), nil
+		
+	case IndicatorTypeIchimoku:
+		tenkan := trend.DefaultIchimokuTenkan
+		kijun := trend.DefaultIchimokuKijun
+		senkouB := trend.DefaultIchimokuSenkouB
+		
+		if t, ok := params["tenkan"].(int); ok {
+			tenkan = t
+		}
+		if k, ok := params["kijun"].(int); ok {
+			kijun = k
+		}
+		if sb, ok := params["senkou_b"].(int); ok {
+			senkouB = sb
+		}
+		
+		return trend.NewIchimokuWithParams(tenkan, kijun, senkouB), nil
+		
+	case IndicatorTypeDonchian:
+		period := bands.DefaultDonchianPeriod
+		if p, ok := params["period"].(int); ok {
+			period = p
+		}
+		return bands.NewDonchianChannelsWithPeriod(period), nil
+		
+	case IndicatorTypeAnchoredVWAP:
+		anchor := volume.AnchorSession
+		anchorLookback := volume.DefaultVWAPAnchorLookback
+		bandMultiplier := volume.DefaultVWAPBandMultiplier
+		
+		if a, ok := params["anchor"].(string); ok {
+			parsed, err := volume.ParseVWAPAnchor(a)
+			if err != nil {
+				return nil, err
+			}
+			anchor = parsed
+		}
+		if l, ok := params["anchor_lookback"].(int); ok {
+			anchorLookback = l
+		}
+		if m, ok := params["band_multiplier"].(float64); ok {
+			bandMultiplier = m
+		}
+		
+		return volume.NewAnchoredVWAPWithParams(anchor, anchorLookback, bandMultiplier), nil
+		
+	case IndicatorTypeCMF:
+		period := volume.DefaultCMFPeriod
+		threshold := volume.DefaultCMFThreshold
+		
+		if p, ok := params["period"].(int); ok {
+			period = p
+		}
+		if t, ok := params["threshold"].(float64); ok {
+			threshold = t
+		}
+		
+		return volume.NewCMFWithParams(period, threshold), nil
+		
+	case IndicatorTypeVolumeProfile:
+		lookback := volume.DefaultVolumeProfileLookback
+		bins := volume.DefaultVolumeProfileBins
+		nodeFactor := volume.DefaultVolumeNodeFactor
+		proximity := volume.DefaultVolumeProfileProximity
+		
+		if l, ok := params["lookback"].(int); ok {
+			lookback = l
+		}
+		if b, ok := params["bins"].(int); ok {
+			bins = b
+		}
+		if f, ok := params["node_factor"].(float64); ok {
+			nodeFactor = f
+		}
+		if p, ok := params["proximity"].(float64); ok {
+			proximity = p
+		}
+		
+		return volume.NewVolumeProfileWithParams(lookback, bins, nodeFactor, proximity), nil
+		
 	default:
 		return nil, fmt.Errorf("unknown indicator type: %s", indicatorType)
 	}
@@ -195,6 +293,12 @@ func (f *IndicatorFactory) GetAvailableIndicators() []IndicatorType {
 		IndicatorTypeOBV,
 		IndicatorTypeStochasticRSI,
 		IndicatorTypeSuperTrend,
+		IndicatorTypeADX,
+		IndicatorTypeIchimoku,
+		IndicatorTypeDonchian,
+		IndicatorTypeAnchoredVWAP,
+		IndicatorTypeCMF,
+		IndicatorTypeVolumeProfile,
 	}
 }
 
@@ -209,15 +313,15 @@ func ParseIndicatorType(s string) (IndicatorType, error) {
 		return IndicatorTypeRSI, nil
 	case "MACD":
 		return IndicatorTypeMACD, nil
-	case "BOLLINGER_BANDS", "BB":
+	case "BOLLINGER_BANDS", "BOLLINGER", "BB":
 		return IndicatorTypeBollingerBands, nil
 	case "MFI":
 		return IndicatorTypeMFI, nil
 	case "WAVETREND", "WT":
 		return IndicatorTypeWaveTrend, nil
-	case "KELTNER_CHANNELS", "KC":
+	case "KELTNER_CHANNELS", "KELTNER", "KC":
 		return IndicatorTypeKeltnerChannels, nil
-	case "HULL_MA", "HMA":
+	case "HULL_MA", "HULLMA", "HMA":
 		return IndicatorTypeHullMA, nil
 	case "OBV":
 		return IndicatorTypeOBV, nil
@@ -225,11 +329,67 @@ func ParseIndicatorType(s string) (IndicatorType, error) {
 		return IndicatorTypeStochasticRSI, nil
 	case "SUPERTREND", "ST":
 		return IndicatorTypeSuperTrend, nil
+	case "ADX", "DMI":
+		return IndicatorTypeADX, nil
+	case "ICHIMOKU", "ICHI":
+		return IndicatorTypeIchimoku, nil
+	case "DONCHIAN", "DONCHIAN_CHANNELS", "DC":
+		return IndicatorTypeDonchian, nil
+	case "ANCHORED_VWAP", "AVWAP", "VWAP":
+		return IndicatorTypeAnchoredVWAP, nil
+	case "CMF", "CHAIKIN":
+		return IndicatorTypeCMF, nil
+	case "VOLUME_PROFILE", "VP", "VPVR":
+		return IndicatorTypeVolumeProfile, nil
 	default:
 		return "", fmt.Errorf("unknown indicator type: %s", s)
 	}
 }
 
+// TypeOf returns the type of an indicator created by this package's constructors
+func TypeOf(indicator TechnicalIndicator) (IndicatorType, bool) {
+	switch indicator.(type) {
+	case *common.SMA:
+		return IndicatorTypeSMA, true
+	case *common.EMA, *trend.EMA:
+		return IndicatorTypeEMA, true
+	case *oscillators.RSI:
+		return IndicatorTypeRSI, true
+	case *oscillators.MACD:
+		return IndicatorTypeMACD, true
+	case *bands.BollingerBands:
+		return IndicatorTypeBollingerBands, true
+	case *oscillators.MFI:
+		return IndicatorTypeMFI, true
+	case *oscillators.WaveTrend:
+		return IndicatorTypeWaveTrend, true
+	case *bands.KeltnerChannels:
+		return IndicatorTypeKeltnerChannels, true
+	case *trend.HullMA:
+		return IndicatorTypeHullMA, true
+	case *volume.OBV:
+		return IndicatorTypeOBV, true
+	case *oscillators.StochasticRSI:
+		return IndicatorTypeStochasticRSI, true
+	case *trend.SuperTrend:
+		return IndicatorTypeSuperTrend, true
+	case *trend.ADX:
+		return IndicatorTypeADX, true
+	case *trend.Ichimoku:
+		return IndicatorTypeIchimoku, true
+	case *bands.DonchianChannels:
+		return IndicatorTypeDonchian, true
+	case *volume.AnchoredVWAP:
+		return IndicatorTypeAnchoredVWAP, true
+	case *volume.CMF:
+		return IndicatorTypeCMF, true
+	case *volume.VolumeProfile:
+		return IndicatorTypeVolumeProfile, true
+	default:
+		return "", false
+	}
+}
+
 // IndicatorConfig represents configuration for an indicator
 type IndicatorConfig struct {
 	Type       IndicatorType          `json:"type"`
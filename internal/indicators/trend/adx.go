@@ -0,0 +1,242 @@
+package trend
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
+)
+
+const (
+	// DefaultADXPeriod is Wilder's smoothing period for DI and ADX
+	DefaultADXPeriod = 14
+
+	// DefaultADXThreshold is the ADX level above which a trend counts as strong
+	DefaultADXThreshold = 25.0
+)
+
+// ADX represents the Average Directional Index with the +DI/-DI lines (DMI)
+// ADX measures trend strength regardless of direction; the DI lines give the direction
+//
+//	+DM = High - PrevHigh if it exceeds PrevLow - Low and is positive, else 0
+//	-DM = PrevLow - Low if it exceeds High - PrevHigh and is positive, else 0
+//	+DI = 100 * Wilder(+DM) / Wilder(TR), -DI likewise
+//	DX  = 100 * |+DI - -DI| / (+DI + -DI)
+//	ADX = Wilder average of DX
+//
+// ADX is an entry filter: it never votes buy, since trend strength alone says
+// nothing about an entry, and it signals sell in a strong downtrend (ADX above
+// the threshold with -DI above +DI), which blocks buys.
+type ADX struct {
+	period    int     // Wilder smoothing period
+	threshold float64 // ADX level of a strong trend
+
+	// Last calculated values
+	lastADX     float64
+	lastPlusDI  float64
+	lastMinusDI float64
+
+	// Signal state
+	lastSignalStrength float64
+}
+
+// NewADX creates a new ADX indicator with default parameters
+func NewADX() *ADX {
+	return NewADXWithParams(DefaultADXPeriod, DefaultADXThreshold)
+}
+
+// NewADXWithParams creates a new ADX indicator with custom parameters
+func NewADXWithParams(period int, threshold float64) *ADX {
+	return &ADX{
+		period:    period,
+		threshold: threshold,
+	}
+}
+
+// Calculate calculates the ADX value over the given candles. The whole window
+// is recalculated, so repeated calls for the same candle return the same value.
+func (a *ADX) Calculate(data []types.OHLCV) (float64, error) {
+	if a.period < 1 {
+		return 0, errors.New("ADX period must be positive")
+	}
+	if len(data) < a.GetRequiredPeriods() {
+		return 0, errors.New("insufficient data points for ADX calculation")
+	}
+
+	period := float64(a.period)
+	var smoothedTR, smoothedPlusDM, smoothedMinusDM float64
+	var plusDI, minusDI, adx, dxSum float64
+	dxCount := 0
+
+	for i := 1; i < len(data); i++ {
+		tr, plusDM, minusDM := directionalMovement(data[i], data[i-1])
+
+		// Wilder smoothing: the first value is the sum of the first period
+		if i <= a.period {
+			smoothedTR += tr
+			smoothedPlusDM += plusDM
+			smoothedMinusDM += minusDM
+			if i < a.period {
+				continue
+			}
+		} else {
+			smoothedTR = smoothedTR - smoothedTR/period + tr
+			smoothedPlusDM = smoothedPlusDM - smoothedPlusDM/period + plusDM
+			smoothedMinusDM = smoothedMinusDM - smoothedMinusDM/period + minusDM
+		}
+
+		plusDI, minusDI = 0, 0
+		if smoothedTR > 0 {
+			plusDI = 100 * smoothedPlusDM / smoothedTR
+			minusDI = 100 * smoothedMinusDM / smoothedTR
+		}
+		dx := 0.0
+		if sum := plusDI + minusDI; sum > 0 {
+			dx = 100 * math.Abs(plusDI-minusDI) / sum
+		}
+
+		// The first ADX is the mean of the first period DX values
+		dxCount++
+		if dxCount <= a.period {
+			dxSum += dx
+			adx = dxSum / float64(dxCount)
+			continue
+		}
+		adx = (adx*(period-1) + dx) / period
+	}
+
+	a.lastADX = adx
+	a.lastPlusDI = plusDI
+	a.lastMinusDI = minusDI
+	a.calculateSignalStrength()
+
+	return adx, nil
+}
+
+// directionalMovement returns the true range and the +DM/-DM of a candle
+func directionalMovement(current, previous types.OHLCV) (tr, plusDM, minusDM float64) {
+	tr = math.Max(current.High-current.Low,
+		math.Max(math.Abs(current.High-previous.Close), math.Abs(current.Low-previous.Close)))
+
+	upMove := current.High - previous.High
+	downMove := previous.Low - current.Low
+	if upMove > downMove && upMove > 0 {
+		plusDM = upMove
+	}
+	if downMove > upMove && downMove > 0 {
+		minusDM = downMove
+	}
+	return tr, plusDM, minusDM
+}
+
+// calculateSignalStrength rates the current signal: a strong trend rates by
+// its ADX, a ranging market rates higher the weaker the trend
+func (a *ADX) calculateSignalStrength() {
+	if a.IsStrongTrend() {
+		// ADX of twice the threshold or more is a very strong trend
+		a.lastSignalStrength = math.Min(a.lastADX/(2*a.threshold), 1)
+		return
+	}
+	if a.threshold <= 0 {
+		a.lastSignalStrength = 0
+		return
+	}
+	a.lastSignalStrength = 1 - a.lastADX/a.threshold
+}
+
+// ShouldBuy calculates ADX but never signals buy; ADX only filters entries
+func (a *ADX) ShouldBuy(current float64, data []types.OHLCV) (bool, error) {
+	_, err := a.Calculate(data)
+	if err != nil {
+		return false, err
+	}
+	return false, nil
+}
+
+// ShouldSell determines if we should sell based on ADX
+func (a *ADX) ShouldSell(current float64, data []types.OHLCV) (bool, error) {
+	_, err := a.Calculate(data)
+	if err != nil {
+		return false, err
+	}
+
+	// Sell signal: strong trend with sellers in control
+	return a.IsStrongDownTrend(), nil
+}
+
+// IsEntryFilter marks ADX as a filter that casts no buy vote
+func (a *ADX) IsEntryFilter() bool {
+	return true
+}
+
+// GetSignalStrength returns the current signal strength
+func (a *ADX) GetSignalStrength() float64 {
+	return a.lastSignalStrength
+}
+
+// GetName returns the indicator name
+func (a *ADX) GetName() string {
+	return "ADX"
+}
+
+// SeriesKey identifies the indicator and its parameters in the series cache
+func (a *ADX) SeriesKey() string {
+	return fmt.Sprintf("adx(%d,%g)", a.period, a.threshold)
+}
+
+// GetRequiredPeriods returns the minimum number of periods needed: one
+// period for the first DI values and another for the first ADX
+func (a *ADX) GetRequiredPeriods() int {
+	return 2 * a.period
+}
+
+// ResetState resets the ADX internal state for new data periods
+func (a *ADX) ResetState() {
+	a.lastADX = 0.0
+	a.lastPlusDI = 0.0
+	a.lastMinusDI = 0.0
+	a.lastSignalStrength = 0.0
+}
+
+// GetADX returns the last calculated ADX value
+func (a *ADX) GetADX() float64 {
+	return a.lastADX
+}
+
+// GetDI returns the last calculated +DI and -DI values
+func (a *ADX) GetDI() (plusDI, minusDI float64) {
+	return a.lastPlusDI, a.lastMinusDI
+}
+
+// IsStrongTrend returns true if ADX is at or above the threshold
+func (a *ADX) IsStrongTrend() bool {
+	return a.lastADX >= a.threshold
+}
+
+// IsStrongDownTrend returns true if the trend is strong and -DI leads +DI
+func (a *ADX) IsStrongDownTrend() bool {
+	return a.IsStrongTrend() && a.lastMinusDI > a.lastPlusDI
+}
+
+// GetTrendDirection returns trend direction as string
+func (a *ADX) GetTrendDirection() string {
+	switch {
+	case !a.IsStrongTrend():
+		return "RANGE"
+	case a.lastPlusDI >= a.lastMinusDI:
+		return "UP"
+	default:
+		return "DOWN"
+	}
+}
+
+// GetPeriod returns the smoothing period
+func (a *ADX) GetPeriod() int {
+	return a.period
+}
+
+// GetThreshold returns the strong-trend threshold
+func (a *ADX) GetThreshold() float64 {
+	return a.threshold
+}
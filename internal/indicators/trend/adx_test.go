@@ -0,0 +1,136 @@
+package trend
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
+)
+
+// candles builds candles from high, low, close triples
+func candles(hlc ...[3]float64) []types.OHLCV {
+	data := make([]types.OHLCV, len(hlc))
+	for i, c := range hlc {
+		data[i] = types.OHLCV{Open: c[2], High: c[0], Low: c[1], Close: c[2]}
+	}
+	return data
+}
+
+// adxFixture is worked through Wilder's definitions with period 3:
+//
+//	bar  TR   +DM  -DM   smoothed TR  +DI      -DI      DX
+//	1    2    1    0
+//	2    2    1    0
+//	3    2    0    0.5   6            33.3333  8.3333   60
+//	4    2.5  0    1     6.5          20.5128  20.5128  0
+//	5    3.5  1.5  0     7.8333       30.4965  11.3475  45.7627
+//	6    2    0.5  0     7.2222       28.9744  8.2051   55.8621
+//
+// The first ADX, on bar 5, is the mean of the first three DX values
+// (35.2542); bar 6 smooths it to (2 × 35.2542 + 55.8621) / 3 = 42.1235.
+var adxFixture = candles(
+	[3]float64{10, 8, 9},
+	[3]float64{11, 9, 10.5},
+	[3]float64{12, 10, 11},
+	[3]float64{11.5, 9.5, 10},
+	[3]float64{11, 8.5, 9},
+	[3]float64{12.5, 9, 12},
+	[3]float64{13, 11, 12.5},
+)
+
+func TestADXReferenceValues(t *testing.T) {
+	tests := []struct {
+		bars                 int
+		adx, plusDI, minusDI float64
+	}{
+		{6, 35.254237288135593, 30.496453900709220, 11.347517730496454},
+		{7, 42.123514513929485, 28.974358974358974, 8.205128205128204},
+	}
+
+	for _, tt := range tests {
+		adx := NewADXWithParams(3, DefaultADXThreshold)
+		got, err := adx.Calculate(adxFixture[:tt.bars])
+		if err != nil {
+			t.Fatalf("%d bars: %v", tt.bars, err)
+		}
+		plusDI, minusDI := adx.GetDI()
+		assertClose(t, "ADX", got, tt.adx)
+		assertClose(t, "+DI", plusDI, tt.plusDI)
+		assertClose(t, "-DI", minusDI, tt.minusDI)
+	}
+}
+
+func TestADXInsufficientData(t *testing.T) {
+	if _, err := NewADXWithParams(3, DefaultADXThreshold).Calculate(adxFixture[:5]); err == nil {
+		t.Error("expected an error with fewer than 2 × period candles")
+	}
+}
+
+// A steady trend has no opposing movement: one DI is 100 × step / range, the
+// other 0, so DX and ADX are 100
+func TestADXSteadyTrend(t *testing.T) {
+	var up, down [][3]float64
+	for i := 0; i < 40; i++ {
+		x := float64(i)
+		up = append(up, [3]float64{102 + x, 100 + x, 101 + x})
+		down = append(down, [3]float64{202 - x, 200 - x, 201 - x})
+	}
+
+	adx := NewADX()
+	if _, err := adx.Calculate(candles(up...)); err != nil {
+		t.Fatal(err)
+	}
+	plusDI, minusDI := adx.GetDI()
+	assertClose(t, "uptrend ADX", adx.GetADX(), 100)
+	assertClose(t, "uptrend +DI", plusDI, 50)
+	assertClose(t, "uptrend -DI", minusDI, 0)
+	if adx.GetTrendDirection() != "UP" {
+		t.Errorf("uptrend direction %s", adx.GetTrendDirection())
+	}
+
+	if _, err := adx.Calculate(candles(down...)); err != nil {
+		t.Fatal(err)
+	}
+	plusDI, minusDI = adx.GetDI()
+	assertClose(t, "downtrend ADX", adx.GetADX(), 100)
+	assertClose(t, "downtrend +DI", plusDI, 0)
+	assertClose(t, "downtrend -DI", minusDI, 50)
+	if !adx.IsStrongDownTrend() {
+		t.Error("steady downtrend should be a strong downtrend")
+	}
+}
+
+// ADX filters entries: it never votes buy and signals sell only in a strong downtrend
+func TestADXNeverVotesBuy(t *testing.T) {
+	var up, down [][3]float64
+	for i := 0; i < 40; i++ {
+		x := float64(i)
+		up = append(up, [3]float64{102 + x, 100 + x, 101 + x})
+		down = append(down, [3]float64{202 - x, 200 - x, 201 - x})
+	}
+
+	for name, data := range map[string][]types.OHLCV{"uptrend": candles(up...), "downtrend": candles(down...), "fixture": adxFixture} {
+		adx := NewADXWithParams(3, DefaultADXThreshold)
+		buy, err := adx.ShouldBuy(data[len(data)-1].Close, data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if buy {
+			t.Errorf("%s: ADX voted buy", name)
+		}
+		sell, _ := adx.ShouldSell(data[len(data)-1].Close, data)
+		if sell != (name == "downtrend") {
+			t.Errorf("%s: ShouldSell = %v", name, sell)
+		}
+	}
+	if !NewADX().IsEntryFilter() {
+		t.Error("ADX should be an entry filter")
+	}
+}
+
+func assertClose(t *testing.T, name string, got, want float64) {
+	t.Helper()
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("%s = %.12f, want %.12f", name, got, want)
+	}
+}
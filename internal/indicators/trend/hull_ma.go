@@ -235,6 +235,11 @@ func (h *HullMA) GetName() string {
 	return "Hull MA"
 }
 
+// SeriesKey identifies the indicator and its parameters in the series cache
+func (h *HullMA) SeriesKey() string {
+	return fmt.Sprintf("hullma(%d)", h.period)
+}
+
 // String returns the string representation of the Hull MA (like the reference implementation)
 func (h *HullMA) String() string {
 	return fmt.Sprintf("HMA(%d)", h.period)
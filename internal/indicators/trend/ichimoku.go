@@ -0,0 +1,236 @@
+package trend
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators/common"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
+)
+
+const (
+	// DefaultIchimokuTenkan is the conversion line period
+	DefaultIchimokuTenkan = 9
+
+	// DefaultIchimokuKijun is the base line period, also the cloud displacement
+	DefaultIchimokuKijun = 26
+
+	// DefaultIchimokuSenkouB is the leading span B period
+	DefaultIchimokuSenkouB = 52
+)
+
+// Ichimoku represents the Ichimoku Cloud (Ichimoku Kinko Hyo) technical indicator
+//
+//	Tenkan-sen  = (highest high + lowest low) / 2 over the tenkan period
+//	Kijun-sen   = (highest high + lowest low) / 2 over the kijun period
+//	Senkou A    = (Tenkan + Kijun) / 2, plotted kijun periods ahead
+//	Senkou B    = (highest high + lowest low) / 2 over the senkou B period, plotted kijun periods ahead
+//	Chikou span = close, plotted kijun periods back
+//
+// The cloud at the current candle is the Senkou spans calculated kijun
+// periods ago. As a DCA filter it blocks buys when price is below the cloud
+// with Tenkan below Kijun, and signals sell there.
+type Ichimoku struct {
+	tenkanPeriod  int
+	kijunPeriod   int // Also the displacement of the cloud and the Chikou span
+	senkouBPeriod int
+
+	// Last calculated values
+	tenkan      float64
+	kijun       float64
+	senkouA     float64 // Cloud at the current candle
+	senkouB     float64
+	leadSenkouA float64 // Cloud being projected kijun periods ahead
+	leadSenkouB float64
+	chikou      float64 // Current close, compared with the close kijun periods ago
+	chikouRef   float64 // Close kijun periods ago
+	lastClose   float64
+
+	// Signal state
+	lastSignalStrength float64
+}
+
+// NewIchimoku creates a new Ichimoku indicator with the classic 9/26/52 periods
+func NewIchimoku() *Ichimoku {
+	return NewIchimokuWithParams(DefaultIchimokuTenkan, DefaultIchimokuKijun, DefaultIchimokuSenkouB)
+}
+
+// NewIchimokuWithParams creates a new Ichimoku indicator with custom periods
+func NewIchimokuWithParams(tenkanPeriod, kijunPeriod, senkouBPeriod int) *Ichimoku {
+	return &Ichimoku{
+		tenkanPeriod:  tenkanPeriod,
+		kijunPeriod:   kijunPeriod,
+		senkouBPeriod: senkouBPeriod,
+	}
+}
+
+// Calculate calculates the Ichimoku lines and returns the Kijun-sen (base line)
+func (ic *Ichimoku) Calculate(data []types.OHLCV) (float64, error) {
+	if ic.tenkanPeriod < 1 || ic.kijunPeriod < 1 || ic.senkouBPeriod < 1 {
+		return 0, errors.New("Ichimoku periods must be positive")
+	}
+	if len(data) < ic.GetRequiredPeriods() {
+		return 0, errors.New("insufficient data points for Ichimoku calculation")
+	}
+
+	n := len(data)
+	ic.tenkan = common.MidRange(data[n-ic.tenkanPeriod:])
+	ic.kijun = common.MidRange(data[n-ic.kijunPeriod:])
+	ic.leadSenkouA = (ic.tenkan + ic.kijun) / 2
+	ic.leadSenkouB = common.MidRange(data[n-ic.senkouBPeriod:])
+
+	// The cloud under the current candle was projected kijun periods ago
+	past := data[:n-ic.kijunPeriod]
+	m := len(past)
+	pastTenkan := common.MidRange(past[m-ic.tenkanPeriod:])
+	pastKijun := common.MidRange(past[m-ic.kijunPeriod:])
+	ic.senkouA = (pastTenkan + pastKijun) / 2
+	ic.senkouB = common.MidRange(past[m-ic.senkouBPeriod:])
+
+	ic.lastClose = data[n-1].Close
+	ic.chikou = ic.lastClose
+	ic.chikouRef = data[n-1-ic.kijunPeriod].Close
+
+	ic.calculateSignalStrength()
+
+	return ic.kijun, nil
+}
+
+// calculateSignalStrength rates the signal by how many of the four Ichimoku
+// conditions agree with it: price vs cloud, Tenkan vs Kijun, the projected
+// cloud's color and Chikou vs past price
+func (ic *Ichimoku) calculateSignalStrength() {
+	bullish := 0
+	if ic.lastClose > ic.CloudTop() {
+		bullish++
+	}
+	if ic.tenkan > ic.kijun {
+		bullish++
+	}
+	if ic.leadSenkouA > ic.leadSenkouB {
+		bullish++
+	}
+	if ic.chikou > ic.chikouRef {
+		bullish++
+	}
+
+	bearish := 0
+	if ic.lastClose < ic.CloudBottom() {
+		bearish++
+	}
+	if ic.tenkan < ic.kijun {
+		bearish++
+	}
+	if ic.leadSenkouA < ic.leadSenkouB {
+		bearish++
+	}
+	if ic.chikou < ic.chikouRef {
+		bearish++
+	}
+
+	if ic.IsBearish() {
+		ic.lastSignalStrength = float64(bearish) / 4
+		return
+	}
+	ic.lastSignalStrength = float64(bullish) / 4
+}
+
+// ShouldBuy determines if we should buy based on Ichimoku
+func (ic *Ichimoku) ShouldBuy(current float64, data []types.OHLCV) (bool, error) {
+	_, err := ic.Calculate(data)
+	if err != nil {
+		return false, err
+	}
+
+	// Buy unless the cloud confirms a downtrend
+	return !ic.IsBearish(), nil
+}
+
+// ShouldSell determines if we should sell based on Ichimoku
+func (ic *Ichimoku) ShouldSell(current float64, data []types.OHLCV) (bool, error) {
+	_, err := ic.Calculate(data)
+	if err != nil {
+		return false, err
+	}
+
+	// Sell signal: price below the cloud with Tenkan below Kijun
+	return ic.IsBearish(), nil
+}
+
+// GetSignalStrength returns the current signal strength
+func (ic *Ichimoku) GetSignalStrength() float64 {
+	return ic.lastSignalStrength
+}
+
+// GetName returns the indicator name
+func (ic *Ichimoku) GetName() string {
+	return "Ichimoku"
+}
+
+// SeriesKey identifies the indicator and its parameters in the series cache
+func (ic *Ichimoku) SeriesKey() string {
+	return fmt.Sprintf("ichimoku(%d,%d,%d)", ic.tenkanPeriod, ic.kijunPeriod, ic.senkouBPeriod)
+}
+
+// GetRequiredPeriods returns the minimum number of periods needed for the
+// cloud under the current candle and the Chikou comparison
+func (ic *Ichimoku) GetRequiredPeriods() int {
+	longest := max(ic.tenkanPeriod, ic.kijunPeriod, ic.senkouBPeriod)
+	return longest + ic.kijunPeriod
+}
+
+// ResetState resets the Ichimoku internal state for new data periods
+func (ic *Ichimoku) ResetState() {
+	ic.tenkan = 0.0
+	ic.kijun = 0.0
+	ic.senkouA = 0.0
+	ic.senkouB = 0.0
+	ic.leadSenkouA = 0.0
+	ic.leadSenkouB = 0.0
+	ic.chikou = 0.0
+	ic.chikouRef = 0.0
+	ic.lastClose = 0.0
+	ic.lastSignalStrength = 0.0
+}
+
+// GetLines returns the last Tenkan-sen and Kijun-sen values
+func (ic *Ichimoku) GetLines() (tenkan, kijun float64) {
+	return ic.tenkan, ic.kijun
+}
+
+// GetCloud returns Senkou span A and B at the current candle
+func (ic *Ichimoku) GetCloud() (senkouA, senkouB float64) {
+	return ic.senkouA, ic.senkouB
+}
+
+// GetLeadingCloud returns Senkou span A and B projected kijun periods ahead
+func (ic *Ichimoku) GetLeadingCloud() (senkouA, senkouB float64) {
+	return ic.leadSenkouA, ic.leadSenkouB
+}
+
+// GetChikou returns the Chikou span (current close) and the close kijun
+// periods ago it is compared with
+func (ic *Ichimoku) GetChikou() (chikou, pastClose float64) {
+	return ic.chikou, ic.chikouRef
+}
+
+// CloudTop returns the upper edge of the cloud at the current candle
+func (ic *Ichimoku) CloudTop() float64 {
+	return math.Max(ic.senkouA, ic.senkouB)
+}
+
+// CloudBottom returns the lower edge of the cloud at the current candle
+func (ic *Ichimoku) CloudBottom() float64 {
+	return math.Min(ic.senkouA, ic.senkouB)
+}
+
+// IsBearish returns true if price is below the cloud and Tenkan is below Kijun
+func (ic *Ichimoku) IsBearish() bool {
+	return ic.lastClose < ic.CloudBottom() && ic.tenkan < ic.kijun
+}
+
+// GetPeriods returns the Tenkan, Kijun and Senkou B periods
+func (ic *Ichimoku) GetPeriods() (tenkan, kijun, senkouB int) {
+	return ic.tenkanPeriod, ic.kijunPeriod, ic.senkouBPeriod
+}
@@ -0,0 +1,82 @@
+package trend
+
+import "testing"
+
+// ichimokuTrend returns 78 candles, the minimum for 9/26/52, moving one point
+// per candle from start (step 1 or -1), each 2 points high with the close in
+// the middle
+func ichimokuTrend(start, step float64) [][3]float64 {
+	var hlc [][3]float64
+	for i := 0; i < 78; i++ {
+		mid := start + step*float64(i)
+		hlc = append(hlc, [3]float64{mid + 1, mid - 1, mid})
+	}
+	return hlc
+}
+
+// On the rising series candle j spans j to j+2, so the midpoint of the k
+// candles up to j is (2j - k + 3) / 2:
+//
+//	Tenkan(9), Kijun(26) on j=77:      74, 65.5
+//	Leading Senkou A, B(52) on j=77:   (74 + 65.5) / 2 = 69.75, 52.5
+//	Senkou A, B under the current candle, projected on j=51: (48 + 39.5) / 2 = 43.75, 26.5
+//	Chikou: close 78 against the close 26 candles back, 52
+func TestIchimokuReferenceValues(t *testing.T) {
+	ic := NewIchimoku()
+	kijun, err := ic.Calculate(candles(ichimokuTrend(1, 1)...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tenkan, kijunLine := ic.GetLines()
+	senkouA, senkouB := ic.GetCloud()
+	leadA, leadB := ic.GetLeadingCloud()
+	chikou, pastClose := ic.GetChikou()
+
+	assertClose(t, "returned Kijun", kijun, 65.5)
+	assertClose(t, "Tenkan", tenkan, 74)
+	assertClose(t, "Kijun", kijunLine, 65.5)
+	assertClose(t, "Senkou A", senkouA, 43.75)
+	assertClose(t, "Senkou B", senkouB, 26.5)
+	assertClose(t, "leading Senkou A", leadA, 69.75)
+	assertClose(t, "leading Senkou B", leadB, 52.5)
+	assertClose(t, "Chikou", chikou, 78)
+	assertClose(t, "Chikou reference", pastClose, 52)
+
+	// All four conditions are bullish
+	assertClose(t, "strength", ic.GetSignalStrength(), 1)
+	if ic.IsBearish() {
+		t.Error("rising series is not bearish")
+	}
+}
+
+// On the falling series the midpoint of the k candles up to j is
+// (401 - 2j + k) / 2: Tenkan 128 below Kijun 136.5 and the close of 124 below
+// the cloud of 158.25 / 175.5 projected on j=51
+func TestIchimokuBearish(t *testing.T) {
+	ic := NewIchimoku()
+	data := candles(ichimokuTrend(201, -1)...)
+	if _, err := ic.Calculate(data); err != nil {
+		t.Fatal(err)
+	}
+
+	tenkan, kijun := ic.GetLines()
+	senkouA, senkouB := ic.GetCloud()
+	assertClose(t, "Tenkan", tenkan, 128)
+	assertClose(t, "Kijun", kijun, 136.5)
+	assertClose(t, "Senkou A", senkouA, 158.25)
+	assertClose(t, "Senkou B", senkouB, 175.5)
+
+	sell, _ := ic.ShouldSell(data[len(data)-1].Close, data)
+	buy, _ := ic.ShouldBuy(data[len(data)-1].Close, data)
+	if !sell || buy {
+		t.Errorf("below a bearish cloud: buy %v, sell %v", buy, sell)
+	}
+}
+
+func TestIchimokuInsufficientData(t *testing.T) {
+	data := candles(ichimokuTrend(1, 1)...)
+	if _, err := NewIchimoku().Calculate(data[:77]); err == nil {
+		t.Error("expected an error with fewer than 52 + 26 candles")
+	}
+}
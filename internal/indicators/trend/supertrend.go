@@ -2,6 +2,7 @@ package trend
 
 import (
 	"errors"
+	"fmt"
 	"math"
 
 	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators/base"
@@ -219,6 +220,11 @@ func (st *SuperTrend) GetName() string {
 	return "SuperTrend"
 }
 
+// SeriesKey identifies the indicator and its parameters in the series cache
+func (st *SuperTrend) SeriesKey() string {
+	return fmt.Sprintf("supertrend(%d,%g)", st.period, st.multiplier)
+}
+
 // GetRequiredPeriods returns the minimum number of periods needed
 func (st *SuperTrend) GetRequiredPeriods() int {
 	return st.atr.GetRequiredPeriods()
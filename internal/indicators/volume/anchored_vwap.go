@@ -0,0 +1,237 @@
+package volume
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
+)
+
+// Anchors of the anchored VWAP
+const (
+	AnchorSession     = "session"      // Start of the current UTC day
+	AnchorCycle       = "cycle"        // First entry of the current DCA cycle
+	AnchorHighestHigh = "highest_high" // Highest high of the last anchor lookback candles
+)
+
+const (
+	// DefaultVWAPAnchorLookback is the default number of candles searched for the highest high anchor
+	DefaultVWAPAnchorLookback = 50
+
+	// DefaultVWAPBandMultiplier is the default band width in volume-weighted standard deviations
+	DefaultVWAPBandMultiplier = 2.0
+)
+
+// ParseVWAPAnchor normalizes an anchor name
+func ParseVWAPAnchor(anchor string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(anchor)) {
+	case AnchorSession, "day", "":
+		return AnchorSession, nil
+	case AnchorCycle:
+		return AnchorCycle, nil
+	case AnchorHighestHigh, "high", "hh":
+		return AnchorHighestHigh, nil
+	default:
+		return "", fmt.Errorf("unknown VWAP anchor: %s (supported: session, cycle, highest_high)", anchor)
+	}
+}
+
+// AnchoredVWAP represents the anchored Volume Weighted Average Price with
+// standard deviation bands
+//
+//	Typical Price = (High + Low + Close) / 3
+//	VWAP          = sum(Typical Price * Volume) / sum(Volume) since the anchor
+//	Bands         = VWAP ± multiplier * volume-weighted standard deviation
+//
+// The cycle anchor needs the strategy to report the cycle start through
+// SetCycleStart; until a cycle is open it falls back to the session anchor.
+// Buys are signalled below the VWAP, sells above the upper band.
+type AnchoredVWAP struct {
+	anchor         string
+	anchorLookback int     // Candles searched for the highest high anchor
+	bandMultiplier float64 // Band width in standard deviations
+	cycleStart     time.Time
+
+	// Last calculated values
+	lastVWAP      float64
+	lastUpper     float64
+	lastLower     float64
+	lastClose     float64
+	lastAnchorIdx int // Anchor position in the last window
+}
+
+// NewAnchoredVWAP creates a new session-anchored VWAP with default bands
+func NewAnchoredVWAP() *AnchoredVWAP {
+	return NewAnchoredVWAPWithParams(AnchorSession, DefaultVWAPAnchorLookback, DefaultVWAPBandMultiplier)
+}
+
+// NewAnchoredVWAPWithParams creates a new anchored VWAP with custom parameters.
+// An unknown anchor falls back to the session anchor.
+func NewAnchoredVWAPWithParams(anchor string, anchorLookback int, bandMultiplier float64) *AnchoredVWAP {
+	parsed, err := ParseVWAPAnchor(anchor)
+	if err != nil {
+		parsed = AnchorSession
+	}
+	return &AnchoredVWAP{
+		anchor:         parsed,
+		anchorLookback: anchorLookback,
+		bandMultiplier: bandMultiplier,
+	}
+}
+
+// SetCycleStart sets the start of the current DCA cycle; the zero time means no open cycle
+func (v *AnchoredVWAP) SetCycleStart(start time.Time) {
+	v.cycleStart = start
+}
+
+// Calculate calculates the VWAP and its bands from the anchor to the last candle
+func (v *AnchoredVWAP) Calculate(data []types.OHLCV) (float64, error) {
+	if len(data) < v.GetRequiredPeriods() || len(data) == 0 {
+		return 0, errors.New("insufficient data points for anchored VWAP calculation")
+	}
+
+	start := v.anchorIndex(data)
+
+	var sumVolume, sumPV, sumPV2 float64
+	for _, candle := range data[start:] {
+		typical := (candle.High + candle.Low + candle.Close) / 3
+		sumVolume += candle.Volume
+		sumPV += typical * candle.Volume
+		sumPV2 += typical * typical * candle.Volume
+	}
+	if sumVolume <= 0 {
+		return 0, errors.New("no volume since VWAP anchor")
+	}
+
+	vwap := sumPV / sumVolume
+	stdDev := math.Sqrt(math.Max(sumPV2/sumVolume-vwap*vwap, 0))
+
+	v.lastVWAP = vwap
+	v.lastUpper = vwap + v.bandMultiplier*stdDev
+	v.lastLower = vwap - v.bandMultiplier*stdDev
+	v.lastClose = data[len(data)-1].Close
+	v.lastAnchorIdx = start
+
+	return vwap, nil
+}
+
+// anchorIndex returns the position of the anchor candle in data. An anchor
+// older than the window is clipped to the first candle.
+func (v *AnchoredVWAP) anchorIndex(data []types.OHLCV) int {
+	last := len(data) - 1
+
+	switch v.anchor {
+	case AnchorHighestHigh:
+		from := max(len(data)-v.anchorLookback, 0)
+		idx := from
+		for i := from + 1; i <= last; i++ {
+			if data[i].High >= data[idx].High {
+				idx = i // Prefer the most recent peak
+			}
+		}
+		return idx
+	case AnchorCycle:
+		if !v.cycleStart.IsZero() {
+			return firstCandleFrom(data, v.cycleStart)
+		}
+	}
+
+	session := data[last].Timestamp.UTC().Truncate(24 * time.Hour)
+	return firstCandleFrom(data, session)
+}
+
+// firstCandleFrom returns the index of the first candle at or after t, the last
+// candle if all are older
+func firstCandleFrom(data []types.OHLCV, t time.Time) int {
+	for i, candle := range data {
+		if !candle.Timestamp.Before(t) {
+			return i
+		}
+	}
+	return len(data) - 1
+}
+
+// ShouldBuy determines if we should buy based on the anchored VWAP
+// Buy signal: price trades at a discount to the VWAP
+func (v *AnchoredVWAP) ShouldBuy(current float64, data []types.OHLCV) (bool, error) {
+	_, err := v.Calculate(data)
+	if err != nil {
+		return false, err
+	}
+
+	return current < v.lastVWAP, nil
+}
+
+// ShouldSell determines if we should sell based on the anchored VWAP
+// Sell signal: price is stretched above the upper band
+func (v *AnchoredVWAP) ShouldSell(current float64, data []types.OHLCV) (bool, error) {
+	_, err := v.Calculate(data)
+	if err != nil {
+		return false, err
+	}
+
+	return current > v.lastUpper, nil
+}
+
+// GetSignalStrength returns how far the last close sits from the VWAP towards
+// the band on its side (0 at the VWAP, 1 at or beyond the band)
+func (v *AnchoredVWAP) GetSignalStrength() float64 {
+	if v.lastUpper == v.lastLower {
+		return 0 // No band width
+	}
+
+	if v.lastClose < v.lastVWAP {
+		return math.Min((v.lastVWAP-v.lastClose)/(v.lastVWAP-v.lastLower), 1)
+	}
+	return math.Min((v.lastClose-v.lastVWAP)/(v.lastUpper-v.lastVWAP), 1)
+}
+
+// GetName returns the indicator name
+func (v *AnchoredVWAP) GetName() string {
+	return "Anchored VWAP"
+}
+
+// SeriesKey identifies the indicator and its parameters in the series cache.
+// The cycle anchor depends on the trades taken, so it is never cached.
+func (v *AnchoredVWAP) SeriesKey() string {
+	if v.anchor == AnchorCycle {
+		return ""
+	}
+	return fmt.Sprintf("avwap(%s,%d,%g)", v.anchor, v.anchorLookback, v.bandMultiplier)
+}
+
+// GetRequiredPeriods returns the minimum number of periods needed
+func (v *AnchoredVWAP) GetRequiredPeriods() int {
+	if v.anchor == AnchorHighestHigh {
+		return v.anchorLookback
+	}
+	return 1
+}
+
+// GetBands returns the last VWAP and its bands
+func (v *AnchoredVWAP) GetBands() (upper, vwap, lower float64) {
+	return v.lastUpper, v.lastVWAP, v.lastLower
+}
+
+// GetAnchor returns the anchor name
+func (v *AnchoredVWAP) GetAnchor() string {
+	return v.anchor
+}
+
+// GetAnchorIndex returns the anchor position in the last calculated window
+func (v *AnchoredVWAP) GetAnchorIndex() int {
+	return v.lastAnchorIdx
+}
+
+// ResetState resets the anchored VWAP internal state for new data periods
+func (v *AnchoredVWAP) ResetState() {
+	v.cycleStart = time.Time{}
+	v.lastVWAP = 0.0
+	v.lastUpper = 0.0
+	v.lastLower = 0.0
+	v.lastClose = 0.0
+	v.lastAnchorIdx = 0
+}
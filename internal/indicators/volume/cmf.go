@@ -0,0 +1,140 @@
+package volume
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
+)
+
+const (
+	// DefaultCMFPeriod is the default Chaikin Money Flow lookback
+	DefaultCMFPeriod = 20
+
+	// DefaultCMFThreshold is the CMF level that counts as accumulation (above)
+	// or distribution (below its negative)
+	DefaultCMFThreshold = 0.05
+
+	// cmfStrongLevel is the CMF magnitude rated as full signal strength
+	cmfStrongLevel = 0.25
+)
+
+// CMF represents the Chaikin Money Flow technical indicator
+//
+//	Money Flow Multiplier = ((Close - Low) - (High - Close)) / (High - Low)
+//	Money Flow Volume     = Multiplier * Volume
+//	CMF                   = sum(Money Flow Volume) / sum(Volume) over the period
+//
+// CMF ranges from -1 to 1: positive values show buying pressure
+// (accumulation), negative values selling pressure (distribution).
+type CMF struct {
+	period    int
+	threshold float64 // Accumulation/distribution level
+
+	lastValue float64
+}
+
+// NewCMF creates a new Chaikin Money Flow indicator with default parameters
+func NewCMF() *CMF {
+	return NewCMFWithParams(DefaultCMFPeriod, DefaultCMFThreshold)
+}
+
+// NewCMFWithParams creates a new Chaikin Money Flow indicator with custom parameters
+func NewCMFWithParams(period int, threshold float64) *CMF {
+	return &CMF{
+		period:    period,
+		threshold: threshold,
+	}
+}
+
+// Calculate calculates the CMF value over the last period candles
+func (c *CMF) Calculate(data []types.OHLCV) (float64, error) {
+	if c.period < 1 {
+		return 0, errors.New("CMF period must be positive")
+	}
+	if len(data) < c.period {
+		return 0, errors.New("insufficient data points for CMF calculation")
+	}
+
+	var flowVolume, totalVolume float64
+	for _, candle := range data[len(data)-c.period:] {
+		totalVolume += candle.Volume
+
+		spread := candle.High - candle.Low
+		if spread <= 0 {
+			continue // A flat candle carries no money flow
+		}
+		multiplier := ((candle.Close - candle.Low) - (candle.High - candle.Close)) / spread
+		flowVolume += multiplier * candle.Volume
+	}
+
+	if totalVolume <= 0 {
+		return 0, errors.New("no volume in CMF period")
+	}
+
+	c.lastValue = flowVolume / totalVolume
+	return c.lastValue, nil
+}
+
+// ShouldBuy determines if we should buy based on CMF
+// Buy signal: money is flowing in (accumulation)
+func (c *CMF) ShouldBuy(current float64, data []types.OHLCV) (bool, error) {
+	value, err := c.Calculate(data)
+	if err != nil {
+		return false, err
+	}
+
+	return value > c.threshold, nil
+}
+
+// ShouldSell determines if we should sell based on CMF
+// Sell signal: money is flowing out (distribution)
+func (c *CMF) ShouldSell(current float64, data []types.OHLCV) (bool, error) {
+	value, err := c.Calculate(data)
+	if err != nil {
+		return false, err
+	}
+
+	return value < -c.threshold, nil
+}
+
+// GetSignalStrength returns the signal strength based on the CMF magnitude
+func (c *CMF) GetSignalStrength() float64 {
+	return math.Min(math.Abs(c.lastValue)/cmfStrongLevel, 1)
+}
+
+// GetName returns the indicator name
+func (c *CMF) GetName() string {
+	return "CMF"
+}
+
+// SeriesKey identifies the indicator and its parameters in the series cache
+func (c *CMF) SeriesKey() string {
+	return fmt.Sprintf("cmf(%d,%g)", c.period, c.threshold)
+}
+
+// GetRequiredPeriods returns the minimum number of periods needed
+func (c *CMF) GetRequiredPeriods() int {
+	return c.period
+}
+
+// GetLastValue returns the last calculated CMF value
+func (c *CMF) GetLastValue() float64 {
+	return c.lastValue
+}
+
+// GetPeriod returns the lookback period
+func (c *CMF) GetPeriod() int {
+	return c.period
+}
+
+// GetThreshold returns the accumulation/distribution threshold
+func (c *CMF) GetThreshold() float64 {
+	return c.threshold
+}
+
+// ResetState resets the CMF internal state for new data periods
+func (c *CMF) ResetState() {
+	c.lastValue = 0.0
+}
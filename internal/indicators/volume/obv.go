@@ -2,6 +2,7 @@ package volume
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
 )
@@ -191,6 +192,11 @@ func (o *OBV) GetName() string {
 	return "OBV"
 }
 
+// SeriesKey identifies the indicator and its parameters in the series cache
+func (o *OBV) SeriesKey() string {
+	return fmt.Sprintf("obv(%g)", o.trendThreshold)
+}
+
 // GetRequiredPeriods returns the minimum number of periods needed
 func (o *OBV) GetRequiredPeriods() int {
 	return 10 // Need at least 10 periods for trend analysis
@@ -0,0 +1,332 @@
+package volume
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators/common"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
+)
+
+const (
+	// DefaultVolumeProfileLookback is the default number of candles in the profile
+	DefaultVolumeProfileLookback = 50
+
+	// DefaultVolumeProfileBins is the default number of price bins
+	DefaultVolumeProfileBins = 24
+
+	// DefaultVolumeNodeFactor is how many times the average bin volume a bin
+	// needs to count as a high-volume node
+	DefaultVolumeNodeFactor = 1.5
+
+	// DefaultVolumeProfileProximity is how close above a node price must be to
+	// count as testing it as support (0.01 = 1%)
+	DefaultVolumeProfileProximity = 0.01
+
+	// valueAreaShare is the share of the volume inside the value area
+	valueAreaShare = 0.70
+)
+
+// ProfileBin is one price bin of a volume profile
+type ProfileBin struct {
+	Low    float64
+	High   float64
+	Volume float64
+}
+
+// Price returns the middle price of the bin
+func (b ProfileBin) Price() float64 {
+	return (b.Low + b.High) / 2
+}
+
+// VolumeNode is a high-volume node of a volume profile
+type VolumeNode struct {
+	Price    float64 // Middle price of the node's bin
+	Volume   float64
+	Strength float64 // Volume relative to the point of control (0-1)
+}
+
+// Profile is the distribution of traded volume over price
+type Profile struct {
+	Bins          []ProfileBin
+	POC           float64 // Point of control: price of the bin with the most volume
+	ValueAreaLow  float64 // Value area holding 70% of the volume around the POC
+	ValueAreaHigh float64
+	Nodes         []VolumeNode // High-volume nodes, ascending by price
+}
+
+// BuildProfile builds a volume profile of the candles with the given number
+// of bins. Each candle's volume is spread over the bins its range covers.
+// Local volume peaks of at least nodeFactor times the average bin volume
+// become high-volume nodes.
+func BuildProfile(data []types.OHLCV, bins int, nodeFactor float64) (*Profile, error) {
+	if bins < 1 {
+		return nil, errors.New("volume profile needs at least one bin")
+	}
+	if len(data) == 0 {
+		return nil, errors.New("insufficient data points for volume profile")
+	}
+
+	low := common.LowestLow(data)
+	high := common.HighestHigh(data)
+	if high <= low {
+		return nil, errors.New("volume profile needs a price range")
+	}
+	width := (high - low) / float64(bins)
+
+	profile := &Profile{Bins: make([]ProfileBin, bins)}
+	for i := range profile.Bins {
+		profile.Bins[i].Low = low + float64(i)*width
+		profile.Bins[i].High = low + float64(i+1)*width
+	}
+
+	var total float64
+	for _, candle := range data {
+		if candle.Volume <= 0 {
+			continue
+		}
+		total += candle.Volume
+
+		first, last := binIndex(candle.Low, low, width, bins), binIndex(candle.High, low, width, bins)
+		candleRange := candle.High - candle.Low
+		if first == last || candleRange <= 0 {
+			profile.Bins[first].Volume += candle.Volume
+			continue
+		}
+		for i := first; i <= last; i++ {
+			overlap := math.Min(candle.High, profile.Bins[i].High) - math.Max(candle.Low, profile.Bins[i].Low)
+			if overlap > 0 {
+				profile.Bins[i].Volume += candle.Volume * overlap / candleRange
+			}
+		}
+	}
+	if total <= 0 {
+		return nil, errors.New("no volume in volume profile")
+	}
+
+	poc := 0
+	for i, bin := range profile.Bins {
+		if bin.Volume > profile.Bins[poc].Volume {
+			poc = i
+		}
+	}
+	profile.POC = profile.Bins[poc].Price()
+	profile.ValueAreaLow, profile.ValueAreaHigh = valueArea(profile.Bins, poc, total)
+
+	average := total / float64(bins)
+	pocVolume := profile.Bins[poc].Volume
+	for i, bin := range profile.Bins {
+		if bin.Volume < nodeFactor*average {
+			continue
+		}
+		if (i > 0 && profile.Bins[i-1].Volume > bin.Volume) || (i < bins-1 && profile.Bins[i+1].Volume > bin.Volume) {
+			continue // Not a local peak
+		}
+		profile.Nodes = append(profile.Nodes, VolumeNode{
+			Price:    bin.Price(),
+			Volume:   bin.Volume,
+			Strength: bin.Volume / pocVolume,
+		})
+	}
+
+	return profile, nil
+}
+
+// binIndex returns the bin holding price
+func binIndex(price, low, width float64, bins int) int {
+	idx := int((price - low) / width)
+	return max(0, min(idx, bins-1))
+}
+
+// valueArea grows the value area from the POC bin towards the heavier
+// neighbour until it holds the value area share of the volume
+func valueArea(bins []ProfileBin, poc int, total float64) (low, high float64) {
+	lo, hi := poc, poc
+	volume := bins[poc].Volume
+	for volume < valueAreaShare*total && (lo > 0 || hi < len(bins)-1) {
+		below, above := -1.0, -1.0
+		if lo > 0 {
+			below = bins[lo-1].Volume
+		}
+		if hi < len(bins)-1 {
+			above = bins[hi+1].Volume
+		}
+		if above >= below {
+			hi++
+			volume += above
+		} else {
+			lo--
+			volume += below
+		}
+	}
+	return bins[lo].Low, bins[hi].High
+}
+
+// SupportsBelow returns the high-volume nodes at or below price, nearest first
+func (p *Profile) SupportsBelow(price float64) []VolumeNode {
+	var supports []VolumeNode
+	for i := len(p.Nodes) - 1; i >= 0; i-- {
+		if p.Nodes[i].Price <= price {
+			supports = append(supports, p.Nodes[i])
+		}
+	}
+	return supports
+}
+
+// VolumeProfile represents a rolling volume profile (volume by price) indicator
+// High-volume nodes are prices where the market traded heavily and tends to
+// find support. Buys are signalled while price tests a node from above,
+// sells once price is stretched above the value area.
+type VolumeProfile struct {
+	lookback   int     // Candles in the profile
+	bins       int     // Price bins
+	nodeFactor float64 // Bin volume vs average volume of a high-volume node
+	proximity  float64 // Max distance above a node that counts as testing it
+
+	// Last calculated values
+	lastProfile        *Profile
+	lastClose          float64
+	lastSignalStrength float64
+}
+
+// NewVolumeProfile creates a new volume profile indicator with default parameters
+func NewVolumeProfile() *VolumeProfile {
+	return NewVolumeProfileWithParams(DefaultVolumeProfileLookback, DefaultVolumeProfileBins,
+		DefaultVolumeNodeFactor, DefaultVolumeProfileProximity)
+}
+
+// NewVolumeProfileWithParams creates a new volume profile indicator with custom parameters
+func NewVolumeProfileWithParams(lookback, bins int, nodeFactor, proximity float64) *VolumeProfile {
+	return &VolumeProfile{
+		lookback:   lookback,
+		bins:       bins,
+		nodeFactor: nodeFactor,
+		proximity:  proximity,
+	}
+}
+
+// Calculate builds the profile of the last lookback candles and returns the point of control
+func (vp *VolumeProfile) Calculate(data []types.OHLCV) (float64, error) {
+	if vp.lookback < 1 {
+		return 0, errors.New("volume profile lookback must be positive")
+	}
+	if len(data) < vp.lookback {
+		return 0, errors.New("insufficient data points for volume profile calculation")
+	}
+
+	profile, err := BuildProfile(data[len(data)-vp.lookback:], vp.bins, vp.nodeFactor)
+	if err != nil {
+		return 0, err
+	}
+
+	vp.lastProfile = profile
+	vp.lastClose = data[len(data)-1].Close
+	vp.calculateSignalStrength()
+
+	return profile.POC, nil
+}
+
+// calculateSignalStrength rates the last close: near a support node by the
+// node's volume and closeness, above the value area by the distance from it
+func (vp *VolumeProfile) calculateSignalStrength() {
+	vp.lastSignalStrength = 0
+
+	if node, distance, ok := vp.testedSupport(vp.lastClose); ok {
+		closeness := 1.0
+		if vp.proximity > 0 {
+			closeness = 1 - distance/vp.proximity
+		}
+		vp.lastSignalStrength = node.Strength * closeness
+		return
+	}
+
+	p := vp.lastProfile
+	if width := p.ValueAreaHigh - p.ValueAreaLow; vp.lastClose > p.ValueAreaHigh && width > 0 {
+		vp.lastSignalStrength = math.Min((vp.lastClose-p.ValueAreaHigh)/width, 1)
+	}
+}
+
+// testedSupport returns the nearest high-volume node at or below price and
+// its relative distance, if price is within proximity of it
+func (vp *VolumeProfile) testedSupport(price float64) (VolumeNode, float64, bool) {
+	if vp.lastProfile == nil || price <= 0 {
+		return VolumeNode{}, 0, false
+	}
+	supports := vp.lastProfile.SupportsBelow(price)
+	if len(supports) == 0 {
+		return VolumeNode{}, 0, false
+	}
+
+	distance := (price - supports[0].Price) / price
+	return supports[0], distance, distance <= vp.proximity
+}
+
+// ShouldBuy determines if we should buy based on the volume profile
+// Buy signal: price is testing a high-volume node from above
+func (vp *VolumeProfile) ShouldBuy(current float64, data []types.OHLCV) (bool, error) {
+	_, err := vp.Calculate(data)
+	if err != nil {
+		return false, err
+	}
+
+	_, _, testing := vp.testedSupport(current)
+	return testing, nil
+}
+
+// ShouldSell determines if we should sell based on the volume profile
+// Sell signal: price is above the value area
+func (vp *VolumeProfile) ShouldSell(current float64, data []types.OHLCV) (bool, error) {
+	_, err := vp.Calculate(data)
+	if err != nil {
+		return false, err
+	}
+
+	return current > vp.lastProfile.ValueAreaHigh, nil
+}
+
+// GetSignalStrength returns the current signal strength
+func (vp *VolumeProfile) GetSignalStrength() float64 {
+	return vp.lastSignalStrength
+}
+
+// GetName returns the indicator name
+func (vp *VolumeProfile) GetName() string {
+	return "Volume Profile"
+}
+
+// SeriesKey identifies the indicator and its parameters in the series cache
+func (vp *VolumeProfile) SeriesKey() string {
+	return fmt.Sprintf("vp(%d,%d,%g,%g)", vp.lookback, vp.bins, vp.nodeFactor, vp.proximity)
+}
+
+// GetRequiredPeriods returns the minimum number of periods needed
+func (vp *VolumeProfile) GetRequiredPeriods() int {
+	return vp.lookback
+}
+
+// GetProfile returns the last calculated profile, nil before the first calculation
+func (vp *VolumeProfile) GetProfile() *Profile {
+	return vp.lastProfile
+}
+
+// GetSupportLevels returns the prices of the high-volume nodes at or below
+// price in the last profile, nearest first
+func (vp *VolumeProfile) GetSupportLevels(price float64) []float64 {
+	if vp.lastProfile == nil {
+		return nil
+	}
+	supports := vp.lastProfile.SupportsBelow(price)
+	levels := make([]float64, len(supports))
+	for i, node := range supports {
+		levels[i] = node.Price
+	}
+	return levels
+}
+
+// ResetState resets the volume profile internal state for new data periods
+func (vp *VolumeProfile) ResetState() {
+	vp.lastProfile = nil
+	vp.lastClose = 0.0
+	vp.lastSignalStrength = 0.0
+}
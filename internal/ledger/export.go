@@ -0,0 +1,152 @@
+package ledger
+
+import (
+	"github.com/ducminhle1904/crypto-dca-bot/internal/backtest"
+)
+
+// ToBacktestResults converts the ledger into backtest results so live trading
+// can be written with the backtest CSV and Excel reporters. Trades follow the
+// multi-level TP layout of the engine: one trade per buy (PnL 0, its fee as
+// commission), one exit trade per sell carrying the realized PnL, and an exit
+// trade at the close mark price for any quantity closed without a recorded
+// sell. startBalance is used when no equity snapshots exist.
+func ToBacktestResults(fills []Fill, cycles []Cycle, equity []EquityPoint, startBalance float64) *backtest.BacktestResults {
+	results := &backtest.BacktestResults{}
+
+	byID := make(map[string]*Cycle, len(cycles))
+	summaries := make(map[string]*backtest.CycleSummary, len(cycles))
+	for i := range cycles {
+		c := &cycles[i]
+		byID[c.ID] = c
+		results.Cycles = append(results.Cycles, backtest.CycleSummary{
+			CycleNumber:      c.Number,
+			StartTime:        c.StartTime,
+			EndTime:          c.EndTime,
+			Entries:          c.Entries,
+			AvgEntry:         c.AvgEntry(),
+			AvgGrossEntry:    c.AvgEntry(),
+			RealizedPnL:      c.PnL(),
+			TotalCost:        c.Invested,
+			TotalGrossCost:   c.Invested,
+			TotalCommission:  c.Fees,
+			Completed:        c.Closed,
+			FinalExitPrice:   c.ExitPrice,
+			TotalRealizedPnL: c.PnL(),
+		})
+		if c.Closed {
+			results.CompletedCycles++
+		}
+	}
+	for i := range results.Cycles {
+		summaries[cycles[i].ID] = &results.Cycles[i]
+	}
+
+	for _, f := range fills {
+		c := byID[f.CycleID]
+		if c == nil {
+			continue
+		}
+		summary := summaries[f.CycleID]
+
+		if f.Side == SideBuy {
+			trade := backtest.Trade{
+				EntryTime:  f.Time,
+				EntryPrice: f.Price,
+				Quantity:   f.Quantity,
+				Commission: f.Fee,
+				Cycle:      c.Number,
+			}
+			if c.Closed {
+				trade.ExitTime = c.EndTime
+				trade.ExitPrice = c.ExitPrice
+			}
+			results.Trades = append(results.Trades, trade)
+			summary.TotalGrossCost += f.Fee
+			continue
+		}
+
+		results.Trades = append(results.Trades, backtest.Trade{
+			EntryTime:  f.Time,
+			ExitTime:   f.Time,
+			EntryPrice: c.AvgEntry(),
+			ExitPrice:  f.Price,
+			Quantity:   f.Quantity,
+			PnL:        f.RealizedPnL,
+			Commission: f.Fee,
+			Cycle:      c.Number,
+		})
+		if f.Kind == "tp" {
+			summary.TPLevelsHit++
+		}
+		summary.PartialExits = append(summary.PartialExits, backtest.PartialExit{
+			TPLevel:    f.Level,
+			Quantity:   f.Quantity,
+			Price:      f.Price,
+			Timestamp:  f.Time,
+			PnL:        f.RealizedPnL,
+			Commission: f.Fee,
+		})
+		if summary.FinalExitPrice == 0 || !c.Closed {
+			summary.FinalExitPrice = f.Price
+		}
+	}
+
+	// Quantity that closed without a recorded sell, valued at the close price
+	for i := range cycles {
+		c := &cycles[i]
+		if !c.Closed || c.OpenQuantity <= 0 {
+			continue
+		}
+		results.Trades = append(results.Trades, backtest.Trade{
+			EntryTime:  c.EndTime,
+			ExitTime:   c.EndTime,
+			EntryPrice: c.AvgEntry(),
+			ExitPrice:  c.ExitPrice,
+			Quantity:   c.OpenQuantity,
+			PnL:        c.EstimatedPnL,
+			Cycle:      c.Number,
+		})
+	}
+
+	var grossProfit, grossLoss float64
+	for _, t := range results.Trades {
+		switch {
+		case t.PnL > 0:
+			results.WinningTrades++
+			grossProfit += t.PnL
+		case t.PnL < 0:
+			results.LosingTrades++
+			grossLoss -= t.PnL
+		}
+	}
+	results.TotalTrades = len(results.Trades)
+	if grossLoss > 0 {
+		results.ProfitFactor = grossProfit / grossLoss
+	}
+
+	// Balances and drawdown from the equity snapshots
+	results.StartBalance = startBalance
+	results.EndBalance = startBalance + grossProfit - grossLoss
+	if len(equity) > 0 {
+		results.StartBalance = equity[0].Equity
+		results.EndBalance = equity[len(equity)-1].Equity
+		peak := 0.0
+		for _, p := range equity {
+			results.EquityCurve = append(results.EquityCurve, backtest.EquityPoint{
+				Timestamp: p.Time,
+				Balance:   p.Balance,
+				Equity:    p.Equity,
+			})
+			if p.Equity > peak {
+				peak = p.Equity
+			}
+			if peak > 0 && (peak-p.Equity)/peak > results.MaxDrawdown {
+				results.MaxDrawdown = (peak - p.Equity) / peak
+			}
+		}
+	}
+	if results.StartBalance > 0 {
+		results.TotalReturn = (results.EndBalance - results.StartBalance) / results.StartBalance
+	}
+	return results
+}
@@ -0,0 +1,420 @@
+package ledger
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// The ledger is an embedded bbolt database holding every fill of the live
+// bot, the DCA cycle it belongs to and account equity snapshots. Realized PnL
+// is accounted per cycle against the average cost of the open quantity, buy
+// fees included. The database is opened per operation so `live-bot-dca ledger`
+// can query it while the bot is running.
+
+// Fill sides
+const (
+	SideBuy  = "BUY"
+	SideSell = "SELL"
+)
+
+// dustQuantity is the open quantity below which a cycle counts as flat
+const dustQuantity = 1e-9
+
+// DefaultOpenTimeout is how long an operation waits for the database lock
+const DefaultOpenTimeout = 5 * time.Second
+
+var (
+	bucketFills  = []byte("fills")
+	bucketCycles = []byte("cycles")
+	bucketEquity = []byte("equity")
+	bucketOrders = []byte("orders") // order ID -> fill key, for idempotent recording
+)
+
+// Fill is one executed order
+type Fill struct {
+	Seq           uint64    `json:"seq"`
+	Time          time.Time `json:"time"`
+	Symbol        string    `json:"symbol"`
+	CycleID       string    `json:"cycle_id"`
+	Side          string    `json:"side"`  // BUY or SELL
+	Kind          string    `json:"kind"`  // dca, grid, tp, fallback_tp, exit
+	Level         int       `json:"level"` // DCA or TP level of the order
+	Quantity      float64   `json:"quantity"`
+	Price         float64   `json:"price"`
+	Value         float64   `json:"value"`
+	Fee           float64   `json:"fee"`                     // In quote currency
+	FeeEstimated  bool      `json:"fee_estimated,omitempty"` // Fee derived from the commission rate
+	RealizedPnL   float64   `json:"realized_pnl"`            // Sells only, net of both fees
+	OrderID       string    `json:"order_id,omitempty"`
+	ClientOrderID string    `json:"client_order_id,omitempty"`
+}
+
+// Cycle is the accounting state of one DCA cycle
+type Cycle struct {
+	ID           string    `json:"id"`
+	Number       int       `json:"number"` // Sequential, in order of the first fill
+	Symbol       string    `json:"symbol"`
+	StartTime    time.Time `json:"start_time"`
+	EndTime      time.Time `json:"end_time,omitempty"`
+	Entries      int       `json:"entries"`
+	Exits        int       `json:"exits"`
+	BuyQuantity  float64   `json:"buy_quantity"`
+	SellQuantity float64   `json:"sell_quantity"`
+	Invested     float64   `json:"invested"` // Value of all buys
+	Proceeds     float64   `json:"proceeds"` // Value of all sells
+	Fees         float64   `json:"fees"`
+	OpenQuantity float64   `json:"open_quantity"`
+	CostBasis    float64   `json:"cost_basis"`   // Cost of OpenQuantity, buy fees included
+	RealizedPnL  float64   `json:"realized_pnl"` // Sum of recorded sells
+	Closed       bool      `json:"closed"`
+	ExitPrice    float64   `json:"exit_price,omitempty"`    // Mark price when the cycle was closed
+	EstimatedPnL float64   `json:"estimated_pnl,omitempty"` // Value of an unrecorded remainder at ExitPrice
+	Incomplete   bool      `json:"incomplete,omitempty"`    // Sold more than was recorded as bought
+}
+
+// PnL returns the cycle's realized PnL, including the estimate for any
+// quantity that closed without a recorded sell (e.g. an exchange-side TP/SL)
+func (c *Cycle) PnL() float64 {
+	return c.RealizedPnL + c.EstimatedPnL
+}
+
+// AvgEntry returns the average buy price of the cycle
+func (c *Cycle) AvgEntry() float64 {
+	if c.BuyQuantity <= 0 {
+		return 0
+	}
+	return c.Invested / c.BuyQuantity
+}
+
+// Duration returns how long the cycle was (or has been) open
+func (c *Cycle) Duration(now time.Time) time.Duration {
+	if c.Closed {
+		return c.EndTime.Sub(c.StartTime)
+	}
+	return now.Sub(c.StartTime)
+}
+
+// EquityPoint is a snapshot of account equity
+type EquityPoint struct {
+	Time    time.Time `json:"time"`
+	Balance float64   `json:"balance"` // Free balance
+	Equity  float64   `json:"equity"`  // Balance plus position value and unrealized PnL
+	CycleID string    `json:"cycle_id,omitempty"`
+	Note    string    `json:"note,omitempty"` // Why the snapshot was taken, e.g. "cycle_closed"
+}
+
+// Store is a ledger database file
+type Store struct {
+	path    string
+	timeout time.Duration
+}
+
+// Open creates the ledger file and its buckets if needed
+func Open(path string) (*Store, error) {
+	if dir := filepath.Dir(path); dir != "." && dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create ledger directory: %w", err)
+		}
+	}
+
+	s := &Store{path: path, timeout: DefaultOpenTimeout}
+	err := s.update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{bucketFills, bucketCycles, bucketEquity, bucketOrders} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ledger %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// OpenExisting opens a ledger file for querying without creating it
+func OpenExisting(path string) (*Store, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("ledger not found: %w", err)
+	}
+	return &Store{path: path, timeout: DefaultOpenTimeout}, nil
+}
+
+// Path returns the ledger file path
+func (s *Store) Path() string {
+	return s.path
+}
+
+// RecordFill stores a fill and applies it to its cycle, starting the cycle on
+// its first fill. A fill whose order ID is already recorded is ignored and
+// reported as not recorded. The stored fill (with Seq and, for sells,
+// RealizedPnL set) is returned.
+func (s *Store) RecordFill(f Fill) (*Fill, bool, error) {
+	if f.CycleID == "" {
+		return nil, false, fmt.Errorf("fill has no cycle ID")
+	}
+	if f.Side != SideBuy && f.Side != SideSell {
+		return nil, false, fmt.Errorf("invalid fill side: %s", f.Side)
+	}
+	if f.Quantity <= 0 || f.Price <= 0 {
+		return nil, false, fmt.Errorf("fill needs a positive quantity and price, got %.8f @ %.8f", f.Quantity, f.Price)
+	}
+	if f.Value == 0 {
+		f.Value = f.Quantity * f.Price
+	}
+	if f.Time.IsZero() {
+		f.Time = time.Now()
+	}
+
+	recorded := false
+	err := s.update(func(tx *bolt.Tx) error {
+		orders := tx.Bucket(bucketOrders)
+		if f.OrderID != "" && orders.Get([]byte(f.OrderID)) != nil {
+			return nil
+		}
+
+		cycles := tx.Bucket(bucketCycles)
+		cycle, err := getCycle(cycles, f.CycleID)
+		if err != nil {
+			return err
+		}
+		if cycle == nil {
+			number, err := cycles.NextSequence()
+			if err != nil {
+				return err
+			}
+			cycle = &Cycle{ID: f.CycleID, Number: int(number), Symbol: f.Symbol, StartTime: f.Time}
+		}
+		applyFill(cycle, &f)
+
+		fills := tx.Bucket(bucketFills)
+		seq, err := fills.NextSequence()
+		if err != nil {
+			return err
+		}
+		f.Seq = seq
+		if err := putJSON(fills, seqKey(seq), &f); err != nil {
+			return err
+		}
+		if f.OrderID != "" {
+			if err := orders.Put([]byte(f.OrderID), seqKey(seq)); err != nil {
+				return err
+			}
+		}
+		recorded = true
+		return putJSON(cycles, []byte(cycle.ID), cycle)
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return &f, recorded, nil
+}
+
+// applyFill updates the cycle's quantities, cost basis and PnL for a fill
+func applyFill(c *Cycle, f *Fill) {
+	c.Fees += f.Fee
+	if f.Side == SideBuy {
+		c.Entries++
+		c.BuyQuantity += f.Quantity
+		c.Invested += f.Value
+		c.OpenQuantity += f.Quantity
+		c.CostBasis += f.Value + f.Fee
+	} else {
+		c.Exits++
+		c.SellQuantity += f.Quantity
+		c.Proceeds += f.Value
+
+		sold := f.Quantity
+		if sold > c.OpenQuantity {
+			c.Incomplete = true
+			sold = c.OpenQuantity
+		}
+		basis := 0.0
+		if c.OpenQuantity > 0 {
+			basis = c.CostBasis * sold / c.OpenQuantity
+		}
+		// Quantity sold beyond the recorded buys has no known cost and is
+		// left out of the PnL; the cycle is flagged incomplete instead
+		f.RealizedPnL = sold*f.Price - basis - f.Fee*(sold/f.Quantity)
+		c.RealizedPnL += f.RealizedPnL
+		c.CostBasis -= basis
+		c.OpenQuantity -= sold
+	}
+	if c.OpenQuantity < dustQuantity {
+		c.OpenQuantity = 0
+		c.CostBasis = 0
+	}
+
+	// A late fill of a closed cycle (e.g. a TP detected after the position
+	// went flat) replaces part of the close estimate
+	if c.Closed {
+		c.EstimatedPnL = estimateRemainder(c)
+	}
+}
+
+// estimateRemainder values the open quantity of a closed cycle at its exit price
+func estimateRemainder(c *Cycle) float64 {
+	if c.OpenQuantity <= 0 || c.ExitPrice <= 0 {
+		return 0
+	}
+	feeRate := 0.0
+	if c.Invested > 0 && c.Fees > 0 {
+		// Use the cycle's observed fee rate for the unrecorded sell
+		feeRate = c.Fees / (c.Invested + c.Proceeds)
+	}
+	proceeds := c.OpenQuantity * c.ExitPrice
+	return proceeds - proceeds*feeRate - c.CostBasis
+}
+
+// CloseCycle marks a cycle closed once the position is flat. Any quantity
+// without a recorded sell is valued at markPrice as an estimate. Returns nil
+// if the cycle has no fills.
+func (s *Store) CloseCycle(cycleID string, t time.Time, markPrice float64) (*Cycle, error) {
+	var cycle *Cycle
+	err := s.update(func(tx *bolt.Tx) error {
+		cycles := tx.Bucket(bucketCycles)
+		c, err := getCycle(cycles, cycleID)
+		if err != nil || c == nil {
+			return err
+		}
+		if !c.Closed {
+			c.Closed = true
+			c.EndTime = t
+			c.ExitPrice = markPrice
+			c.EstimatedPnL = estimateRemainder(c)
+		}
+		cycle = c
+		return putJSON(cycles, []byte(c.ID), c)
+	})
+	return cycle, err
+}
+
+// RecordEquity stores an equity snapshot
+func (s *Store) RecordEquity(p EquityPoint) error {
+	if p.Time.IsZero() {
+		p.Time = time.Now()
+	}
+	return s.update(func(tx *bolt.Tx) error {
+		equity := tx.Bucket(bucketEquity)
+		seq, err := equity.NextSequence()
+		if err != nil {
+			return err
+		}
+		return putJSON(equity, seqKey(seq), &p)
+	})
+}
+
+// OpenCycle returns the most recent cycle of symbol that is not closed, or nil
+func (s *Store) OpenCycle(symbol string) (*Cycle, error) {
+	cycles, err := s.Cycles()
+	if err != nil {
+		return nil, err
+	}
+	for i := len(cycles) - 1; i >= 0; i-- {
+		if cycles[i].Symbol == symbol && !cycles[i].Closed {
+			return &cycles[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// Fills returns all fills in recording order
+func (s *Store) Fills() ([]Fill, error) {
+	var fills []Fill
+	err := s.view(func(tx *bolt.Tx) error {
+		return forEachJSON(tx.Bucket(bucketFills), func() interface{} {
+			fills = append(fills, Fill{})
+			return &fills[len(fills)-1]
+		})
+	})
+	return fills, err
+}
+
+// Cycles returns all cycles ordered by cycle number
+func (s *Store) Cycles() ([]Cycle, error) {
+	var cycles []Cycle
+	err := s.view(func(tx *bolt.Tx) error {
+		return forEachJSON(tx.Bucket(bucketCycles), func() interface{} {
+			cycles = append(cycles, Cycle{})
+			return &cycles[len(cycles)-1]
+		})
+	})
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i].Number < cycles[j].Number })
+	return cycles, err
+}
+
+// Equity returns all equity snapshots in time order
+func (s *Store) Equity() ([]EquityPoint, error) {
+	var points []EquityPoint
+	err := s.view(func(tx *bolt.Tx) error {
+		return forEachJSON(tx.Bucket(bucketEquity), func() interface{} {
+			points = append(points, EquityPoint{})
+			return &points[len(points)-1]
+		})
+	})
+	return points, err
+}
+
+func (s *Store) update(fn func(tx *bolt.Tx) error) error {
+	db, err := bolt.Open(s.path, 0644, &bolt.Options{Timeout: s.timeout})
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	return db.Update(fn)
+}
+
+func (s *Store) view(fn func(tx *bolt.Tx) error) error {
+	db, err := bolt.Open(s.path, 0644, &bolt.Options{Timeout: s.timeout, ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	return db.View(fn)
+}
+
+func getCycle(b *bolt.Bucket, id string) (*Cycle, error) {
+	data := b.Get([]byte(id))
+	if data == nil {
+		return nil, nil
+	}
+	var c Cycle
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("corrupt cycle %s: %w", id, err)
+	}
+	return &c, nil
+}
+
+func putJSON(b *bolt.Bucket, key []byte, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return b.Put(key, data)
+}
+
+// forEachJSON decodes every value of a bucket (nil if missing) into the value returned by next
+func forEachJSON(b *bolt.Bucket, next func() interface{}) error {
+	if b == nil {
+		return nil
+	}
+	return b.ForEach(func(k, v []byte) error {
+		if err := json.Unmarshal(v, next()); err != nil {
+			return fmt.Errorf("corrupt ledger record %x: %w", k, err)
+		}
+		return nil
+	})
+}
+
+func seqKey(seq uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, seq)
+	return key
+}
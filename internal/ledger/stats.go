@@ -0,0 +1,149 @@
+package ledger
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Reporting periods
+const (
+	PeriodDay   = "day"
+	PeriodMonth = "month"
+)
+
+// CycleStats summarizes closed cycles
+type CycleStats struct {
+	Cycles       int           // Closed cycles
+	OpenCycles   int           // Cycles still in progress
+	Wins         int           // Closed cycles with positive PnL
+	Losses       int           // Closed cycles with negative PnL
+	Incomplete   int           // Cycles with sells beyond the recorded buys
+	TotalPnL     float64       // PnL of closed cycles
+	EstimatedPnL float64       // Part of TotalPnL valued at the close mark price
+	AvgPnL       float64       // Mean PnL per closed cycle
+	BestPnL      float64       // Best closed cycle
+	WorstPnL     float64       // Worst closed cycle
+	TotalFees    float64       // Fees of all cycles
+	Invested     float64       // Buy value of all cycles
+	AvgEntries   float64       // Mean buys per closed cycle
+	AvgDuration  time.Duration // Mean time from first fill to close
+}
+
+// WinRate returns the share of closed cycles with positive PnL
+func (s CycleStats) WinRate() float64 {
+	if s.Cycles == 0 {
+		return 0
+	}
+	return float64(s.Wins) / float64(s.Cycles)
+}
+
+// SummarizeCycles computes statistics over cycles
+func SummarizeCycles(cycles []Cycle) CycleStats {
+	var stats CycleStats
+	var entries int
+	var duration time.Duration
+	for i := range cycles {
+		c := &cycles[i]
+		stats.TotalFees += c.Fees
+		stats.Invested += c.Invested
+		if c.Incomplete {
+			stats.Incomplete++
+		}
+		if !c.Closed {
+			stats.OpenCycles++
+			continue
+		}
+
+		pnl := c.PnL()
+		if stats.Cycles == 0 || pnl > stats.BestPnL {
+			stats.BestPnL = pnl
+		}
+		if stats.Cycles == 0 || pnl < stats.WorstPnL {
+			stats.WorstPnL = pnl
+		}
+		stats.Cycles++
+		stats.TotalPnL += pnl
+		stats.EstimatedPnL += c.EstimatedPnL
+		switch {
+		case pnl > 0:
+			stats.Wins++
+		case pnl < 0:
+			stats.Losses++
+		}
+		entries += c.Entries
+		duration += c.Duration(c.EndTime)
+	}
+	if stats.Cycles > 0 {
+		stats.AvgPnL = stats.TotalPnL / float64(stats.Cycles)
+		stats.AvgEntries = float64(entries) / float64(stats.Cycles)
+		stats.AvgDuration = duration / time.Duration(stats.Cycles)
+	}
+	return stats
+}
+
+// PeriodSummary aggregates fills and closed cycles of one day or month (UTC)
+type PeriodSummary struct {
+	Period       string  // 2006-01-02 or 2006-01
+	Fills        int     // Fills in the period
+	BuyVolume    float64 // Value bought
+	SellVolume   float64 // Value sold
+	Fees         float64 // Fees paid
+	RealizedPnL  float64 // PnL of sells, plus close estimates of cycles closed in the period
+	CyclesClosed int     // Cycles closed in the period
+}
+
+// SummarizeByPeriod groups fills and cycle closes by UTC day or month
+func SummarizeByPeriod(fills []Fill, cycles []Cycle, period string) ([]PeriodSummary, error) {
+	layout, err := periodLayout(period)
+	if err != nil {
+		return nil, err
+	}
+
+	byPeriod := make(map[string]*PeriodSummary)
+	get := func(t time.Time) *PeriodSummary {
+		key := t.UTC().Format(layout)
+		if byPeriod[key] == nil {
+			byPeriod[key] = &PeriodSummary{Period: key}
+		}
+		return byPeriod[key]
+	}
+
+	for _, f := range fills {
+		p := get(f.Time)
+		p.Fills++
+		p.Fees += f.Fee
+		if f.Side == SideBuy {
+			p.BuyVolume += f.Value
+		} else {
+			p.SellVolume += f.Value
+			p.RealizedPnL += f.RealizedPnL
+		}
+	}
+	for _, c := range cycles {
+		if !c.Closed {
+			continue
+		}
+		p := get(c.EndTime)
+		p.CyclesClosed++
+		p.RealizedPnL += c.EstimatedPnL
+	}
+
+	summaries := make([]PeriodSummary, 0, len(byPeriod))
+	for _, p := range byPeriod {
+		summaries = append(summaries, *p)
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].Period < summaries[j].Period })
+	return summaries, nil
+}
+
+func periodLayout(period string) (string, error) {
+	switch period {
+	case "", PeriodDay:
+		return "2006-01-02", nil
+	case PeriodMonth:
+		return "2006-01", nil
+	default:
+		return "", fmt.Errorf("invalid period: %s (must be %s or %s)", period, PeriodDay, PeriodMonth)
+	}
+}
@@ -0,0 +1,105 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// EventType classifies a structured log entry
+type EventType string
+
+const (
+	EventLog            EventType = "log"             // Free-form message
+	EventSession        EventType = "session"         // Session started or ended
+	EventDecision       EventType = "decision"        // Market analysis and trade decision
+	EventStatus         EventType = "status"          // Periodic market and position status
+	EventSpacing        EventType = "dca_spacing"     // DCA spacing threshold check
+	EventOrderRequest   EventType = "order_request"   // Order sized and checked against exchange constraints
+	EventOrderPlaced    EventType = "order_placed"    // Order accepted by the exchange
+	EventOrderFilled    EventType = "order_filled"    // Buy or sell executed, with the resulting position
+	EventTPOrder        EventType = "tp_order"        // Take profit order details
+	EventTPFilled       EventType = "tp_filled"       // Take profit level filled
+	EventGridFilled     EventType = "grid_filled"     // Resting limit-grid DCA level filled
+	EventCycleCompleted EventType = "cycle_completed" // Position closed, cycle finished
+	EventSync           EventType = "sync"            // Balance or position synced from the exchange
+	EventStateChange    EventType = "state_change"    // Bot state transition (debug mode)
+	EventPerformance    EventType = "performance"     // Operation timing
+	EventError          EventType = "error"           // Error with context
+)
+
+// Fields holds the structured payload of an event
+type Fields map[string]interface{}
+
+// Field names lifted from Fields to the top level of an entry so every line
+// about an order can be correlated
+const (
+	FieldOrderID       = "order_id"
+	FieldClientOrderID = "client_order_id"
+)
+
+// Entry is one structured log line. Every entry carries the symbol, interval
+// and the current cycle ID; order events also carry the order IDs.
+type Entry struct {
+	Time          time.Time `json:"ts"`
+	Level         LogLevel  `json:"level"`
+	Event         EventType `json:"event"`
+	Symbol        string    `json:"symbol"`
+	Interval      string    `json:"interval"`
+	CycleID       string    `json:"cycle_id,omitempty"`
+	OrderID       string    `json:"order_id,omitempty"`
+	ClientOrderID string    `json:"client_order_id,omitempty"`
+	Message       string    `json:"msg,omitempty"`
+	Fields        Fields    `json:"fields,omitempty"`
+}
+
+// newEntry builds an entry for the logger, lifting order IDs out of fields.
+// Must be called with l.mu held.
+func (l *Logger) newEntry(level LogLevel, event EventType, message string, fields Fields) *Entry {
+	e := &Entry{
+		Time:     time.Now().UTC(),
+		Level:    level,
+		Event:    event,
+		Symbol:   l.symbol,
+		Interval: l.interval,
+		CycleID:  l.cycleID,
+		Message:  message,
+	}
+	if len(fields) > 0 {
+		e.Fields = make(Fields, len(fields))
+		for k, v := range fields {
+			switch k {
+			case FieldOrderID:
+				e.OrderID = fmt.Sprint(v)
+			case FieldClientOrderID:
+				e.ClientOrderID = fmt.Sprint(v)
+			default:
+				e.Fields[k] = v
+			}
+		}
+		if len(e.Fields) == 0 {
+			e.Fields = nil
+		}
+	}
+	return e
+}
+
+// marshalEntry encodes an entry as one JSON line. Values that cannot be
+// encoded (e.g. NaN) are replaced by their string form rather than dropping
+// the line.
+func marshalEntry(e *Entry) []byte {
+	data, err := json.Marshal(e)
+	if err == nil {
+		return append(data, '\n')
+	}
+	for k, v := range e.Fields {
+		if _, err := json.Marshal(v); err != nil {
+			e.Fields[k] = fmt.Sprint(v)
+		}
+	}
+	data, err = json.Marshal(e)
+	if err != nil {
+		data, _ = json.Marshal(map[string]string{"ts": e.Time.Format(time.RFC3339Nano), "event": string(EventError), "msg": err.Error()})
+	}
+	return append(data, '\n')
+}
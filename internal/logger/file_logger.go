@@ -2,6 +2,7 @@ package logger
 
 import (
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"path/filepath"
@@ -9,17 +10,49 @@ import (
 	"time"
 )
 
-// Logger represents a file logger for trading activities
+// Logger represents a file logger for trading activities. Entries are written
+// as emoji text (default) or as JSON lines with typed events, to files that
+// rotate by time and size.
 type Logger struct {
 	symbol     string
 	interval   string
-	logFile    *os.File
+	logFile    *RotatingWriter
 	logger     *log.Logger
+	console    io.Writer // Human-readable mirror of every entry, nil if disabled
+	format     string
+	cycleID    string // Correlation ID of the current DCA cycle
 	mu         sync.Mutex
 	logDir     string
 	debugMode  bool
 }
 
+// Log file formats
+const (
+	FormatText = "text" // Emoji text blocks (default)
+	FormatJSON = "json" // One JSON object per line, see Entry
+)
+
+// Options controls the log format, rotation and retention
+type Options struct {
+	Dir        string        // Log directory (default "logs")
+	Format     string        // FormatText or FormatJSON
+	Rotation   string        // RotateDaily or RotateHourly
+	MaxSizeMB  int           // Start a new file above this size (0 = no limit)
+	MaxAge     time.Duration // Delete rotated files older than this (0 = keep)
+	MaxFiles   int           // Keep at most this many files (0 = no limit)
+	Console    bool          // Mirror entries to stdout in human-readable form
+	DebugMode  bool
+}
+
+// DefaultOptions returns the options used by NewLogger: daily text files in logs/
+func DefaultOptions() Options {
+	return Options{
+		Dir:      "logs",
+		Format:   FormatText,
+		Rotation: RotateDaily,
+	}
+}
+
 // LogLevel represents different types of log entries
 type LogLevel string
 
@@ -43,21 +76,32 @@ func NewLogger(symbol, interval string) (*Logger, error) {
 
 // NewLoggerWithDebug creates a new file logger with debug mode control
 func NewLoggerWithDebug(symbol, interval string, debugMode bool) (*Logger, error) {
-	// Create log directory if it doesn't exist
-	logDir := "logs"
-	if err := os.MkdirAll(logDir, 0755); err != nil {
-		return nil, fmt.Errorf("failed to create log directory: %w", err)
-	}
+	opts := DefaultOptions()
+	opts.DebugMode = debugMode
+	return NewLoggerWithOptions(symbol, interval, opts)
+}
 
-	// Create log filename with timestamp
-	timestamp := time.Now().Format("2006-01-02")
-	filename := fmt.Sprintf("%s_%s_%s.log", symbol, interval, timestamp)
-	logPath := filepath.Join(logDir, filename)
+// NewLoggerWithOptions creates a file logger writing <symbol>_<interval>_<period>
+// files (.log for text, .jsonl for JSON) in opts.Dir
+func NewLoggerWithOptions(symbol, interval string, opts Options) (*Logger, error) {
+	if opts.Dir == "" {
+		opts.Dir = "logs"
+	}
+	ext := ".log"
+	switch opts.Format {
+	case "", FormatText:
+		opts.Format = FormatText
+	case FormatJSON:
+		ext = ".jsonl"
+	default:
+		return nil, fmt.Errorf("invalid log format: %s (must be %s or %s)", opts.Format, FormatText, FormatJSON)
+	}
 
-	// Open or create log file
-	file, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	// Open or create the log file of the current period
+	prefix := fmt.Sprintf("%s_%s", symbol, interval)
+	file, err := NewRotatingWriter(opts.Dir, prefix, ext, opts.Rotation, int64(opts.MaxSizeMB)*1024*1024, opts.MaxAge, opts.MaxFiles)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open log file: %w", err)
+		return nil, err
 	}
 
 	// Create logger with timestamp and no prefix (we'll add our own formatting)
@@ -68,8 +112,12 @@ func NewLoggerWithDebug(symbol, interval string, debugMode bool) (*Logger, error
 		interval:  interval,
 		logFile:   file,
 		logger:    logger,
-		logDir:    logDir,
-		debugMode: debugMode,
+		format:    opts.Format,
+		logDir:    opts.Dir,
+		debugMode: opts.DebugMode,
+	}
+	if opts.Console {
+		l.console = os.Stdout
 	}
 
 	// Write session start header
@@ -89,16 +137,47 @@ func (l *Logger) writeSessionHeader() {
 ================================================================================
 Symbol: %s | Interval: %s
 Started: %s
-Log File: %s_%s_%s.log
+Log File: %s
 ================================================================================
 `, l.symbol, l.interval, time.Now().Format("2006-01-02 15:04:05"), 
-	l.symbol, l.interval, time.Now().Format("2006-01-02"))
+	filepath.Base(l.logFile.Path()))
+
+	l.write(l.newEntry(LogLevelInfo, EventSession, "DCA trading session started", Fields{"state": "started"}), header)
+}
+
+// write sends an entry to the file, as JSON or as its text form, and the text
+// form to the console. Must be called with l.mu held.
+func (l *Logger) write(e *Entry, text string) {
+	if l.format == FormatJSON {
+		l.logFile.Write(marshalEntry(e))
+	} else {
+		l.logger.Println(text)
+	}
+	if l.console != nil {
+		fmt.Fprintln(l.console, text)
+	}
+}
 
-	l.logger.Print(header)
+// SetCycle sets the correlation ID carried by every following entry. Pass ""
+// when the cycle completes.
+func (l *Logger) SetCycle(cycleID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.cycleID = cycleID
 }
 
 // Log writes a formatted log entry with the specified level
 func (l *Logger) Log(level LogLevel, format string, args ...interface{}) {
+	event := EventLog
+	if level == LogLevelError {
+		event = EventError
+	}
+	l.Event(level, event, nil, format, args...)
+}
+
+// Event writes a typed entry with structured fields. The text format and the
+// console show only the message; JSON lines also carry the fields.
+func (l *Logger) Event(level LogLevel, event EventType, fields Fields, format string, args ...interface{}) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
@@ -106,7 +185,7 @@ func (l *Logger) Log(level LogLevel, format string, args ...interface{}) {
 	message := fmt.Sprintf(format, args...)
 	logEntry := fmt.Sprintf("[%s] [%s] %s", timestamp, level, message)
 	
-	l.logger.Println(logEntry)
+	l.write(l.newEntry(level, event, message, fields), logEntry)
 }
 
 // Info logs an info message
@@ -179,11 +258,21 @@ func (l *Logger) LogMarketStatus(currentPrice float64, action string, balance fl
 
 	statusLog += "\n=========================================================="
 	
-	l.logger.Println(statusLog)
+	l.write(l.newEntry(LogLevelStatus, EventStatus, "Market status", Fields{
+		"price":            currentPrice,
+		"action":           action,
+		"balance":          balance,
+		"position":         position,
+		"avg_price":        avgPrice,
+		"dca_level":        dcaLevel,
+		"unrealized_pnl":   exchangePnL,
+		"filled_tp_orders": filledTPOrders,
+		"active_tp_orders": activeTPCount,
+	}), statusLog)
 }
 
 // LogTradeExecution logs trade execution details
-func (l *Logger) LogTradeExecution(tradeType string, orderID string, quantity string, price string, value string, dcaLevel int, position float64, avgPrice float64) {
+func (l *Logger) LogTradeExecution(tradeType string, orderID string, clientOrderID string, quantity string, price string, value string, dcaLevel int, position float64, avgPrice float64) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
@@ -201,7 +290,17 @@ func (l *Logger) LogTradeExecution(tradeType string, orderID string, quantity st
 =============================================================`, 
 		timestamp, tradeType, orderID, quantity, l.symbol, price, value, dcaLevel, position, avgPrice)
 
-	l.logger.Println(tradeLog)
+	l.write(l.newEntry(LogLevelTrade, EventOrderFilled, tradeType+" executed", Fields{
+		FieldOrderID:       orderID,
+		FieldClientOrderID: clientOrderID,
+		"side":             tradeType,
+		"quantity":         quantity,
+		"price":            price,
+		"value":            value,
+		"dca_level":        dcaLevel,
+		"position":         position,
+		"avg_price":        avgPrice,
+	}), tradeLog)
 }
 
 // LogCycleCompletion logs cycle completion
@@ -220,22 +319,36 @@ func (l *Logger) LogCycleCompletion(exitPrice float64, entryPrice float64, profi
 ==============================================================`, 
 		timestamp, entryPrice, exitPrice, profitPercent)
 
-	l.logger.Println(cycleLog)
+	l.write(l.newEntry(LogLevelTrade, EventCycleCompleted, "Cycle completed", Fields{
+		"entry_price":    entryPrice,
+		"exit_price":     exitPrice,
+		"profit_percent": profitPercent,
+	}), cycleLog)
 }
 
 // LogPositionSync logs position synchronization
 func (l *Logger) LogPositionSync(positionValue float64, entryPrice float64, size string, unrealizedPnL string) {
-	l.Info("Position synced - Size: %s, Value: $%.2f, Entry: $%.2f, PnL: %s", size, positionValue, entryPrice, unrealizedPnL)
+	l.Event(LogLevelInfo, EventSync, Fields{
+		"kind":           "position",
+		"size":           size,
+		"position":       positionValue,
+		"avg_price":      entryPrice,
+		"unrealized_pnl": unrealizedPnL,
+	}, "Position synced - Size: %s, Value: $%.2f, Entry: $%.2f, PnL: %s", size, positionValue, entryPrice, unrealizedPnL)
 }
 
 // LogBalanceSync logs balance synchronization
 func (l *Logger) LogBalanceSync(oldBalance, newBalance float64) {
-	l.Info("Balance synced: $%.2f -> $%.2f", oldBalance, newBalance)
+	l.Event(LogLevelInfo, EventSync, Fields{
+		"kind":        "balance",
+		"old_balance": oldBalance,
+		"balance":     newBalance,
+	}, "Balance synced: $%.2f -> $%.2f", oldBalance, newBalance)
 }
 
 // LogError logs error with context
 func (l *Logger) LogError(context string, err error) {
-	l.Error("%s: %v", context, err)
+	l.Event(LogLevelError, EventError, Fields{"context": context, "error": err.Error()}, "%s: %v", context, err)
 }
 
 // LogWarning logs warning with context
@@ -288,7 +401,12 @@ func (l *Logger) LogDetailedMarketAnalysis(klines []interface{}, indicators map[
 
 	analysisLog += "\n============================================================="
 	
-	l.logger.Println(analysisLog)
+	l.write(l.newEntry(LogLevelStrategy, EventDecision, "Decision: "+decision, Fields{
+		"decision":    decision,
+		"confidence":  confidence,
+		"data_points": len(klines),
+		"indicators":  indicators,
+	}), analysisLog)
 }
 
 // LogDCASpacingDetails logs detailed DCA spacing calculations
@@ -325,7 +443,15 @@ func (l *Logger) LogDCASpacingDetails(level int, currentPrice, lastEntryPrice, t
 
 	spacingLog += "\n============================================================="
 	
-	l.logger.Println(spacingLog)
+	l.write(l.newEntry(LogLevelDCA, EventSpacing, "DCA spacing check", Fields{
+		"dca_level":        level,
+		"strategy":         strategy,
+		"price":            currentPrice,
+		"last_entry_price": lastEntryPrice,
+		"threshold":        threshold,
+		"price_drop":       priceChange / 100,
+		"context":          context,
+	}), spacingLog)
 }
 
 // LogOrderPlacementDetails logs detailed order placement information
@@ -349,7 +475,14 @@ func (l *Logger) LogOrderPlacementDetails(orderType, side, symbol string, quanti
 
 	orderLog += "\n============================================================="
 	
-	l.logger.Println(orderLog)
+	l.write(l.newEntry(LogLevelExchange, EventOrderRequest, fmt.Sprintf("%s %s order", orderType, side), Fields{
+		"order_type":  orderType,
+		"side":        side,
+		"quantity":    quantity,
+		"price":       price,
+		"value":       value,
+		"constraints": constraints,
+	}), orderLog)
 }
 
 // LogTPOrderDetails logs detailed take profit order information
@@ -367,7 +500,20 @@ func (l *Logger) LogTPOrderDetails(level int, quantity, price, percent float64,
 =============================================================`, 
 		timestamp, status, level, orderID, quantity, price, percent*100, status)
 	
-	l.logger.Println(tpLog)
+	event := EventTPOrder
+	if status == "FILLED" {
+		event = EventTPFilled
+	}
+	l.write(l.newEntry(LogLevelTP, event, fmt.Sprintf("TP%d %s", level, status), Fields{
+		FieldOrderID: orderID,
+		"order_type":  "Limit",
+		"side":        "Sell",
+		"tp_level":    level,
+		"quantity":    quantity,
+		"price":       price,
+		"percent":     percent,
+		"status":      status,
+	}), tpLog)
 }
 
 // LogErrorWithContext logs detailed error information with context
@@ -392,7 +538,11 @@ func (l *Logger) LogErrorWithContext(context string, err error, additionalInfo m
 
 	errorLog += "\n============================================================="
 	
-	l.logger.Println(errorLog)
+	l.write(l.newEntry(LogLevelError, EventError, fmt.Sprintf("%s: %v", context, err), Fields{
+		"context": context,
+		"error":   fmt.Sprint(err),
+		"details": additionalInfo,
+	}), errorLog)
 }
 
 // LogPerformanceMetrics logs performance and timing information
@@ -416,7 +566,11 @@ func (l *Logger) LogPerformanceMetrics(operation string, duration time.Duration,
 
 	perfLog += "\n============================================================="
 	
-	l.logger.Println(perfLog)
+	l.write(l.newEntry(LogLevelDebug, EventPerformance, operation, Fields{
+		"operation":   operation,
+		"duration_ms": duration.Milliseconds(),
+		"details":     details,
+	}), perfLog)
 }
 
 // LogStateChange logs important state changes
@@ -439,7 +593,12 @@ func (l *Logger) LogStateChange(component string, oldState, newState interface{}
 =============================================================`, 
 		timestamp, component, oldState, newState, reason)
 	
-	l.logger.Println(stateLog)
+	l.write(l.newEntry(LogLevelDebug, EventStateChange, component+" changed", Fields{
+		"component": component,
+		"old_state": oldState,
+		"new_state": newState,
+		"reason":    reason,
+	}), stateLog)
 }
 
 // SetDebugMode enables or disables debug logging
@@ -479,7 +638,7 @@ Ended: %s
 ================================================================================
 
 `, timestamp)
-		l.logger.Print(footer)
+		l.write(l.newEntry(LogLevelInfo, EventSession, "DCA trading session ended", Fields{"state": "ended"}), footer)
 		
 		return l.logFile.Close()
 	}
@@ -488,7 +647,5 @@ Ended: %s
 
 // GetLogPath returns the current log file path
 func (l *Logger) GetLogPath() string {
-	timestamp := time.Now().Format("2006-01-02")
-	filename := fmt.Sprintf("%s_%s_%s.log", l.symbol, l.interval, timestamp)
-	return filepath.Join(l.logDir, filename)
+	return l.logFile.Path()
 }
@@ -0,0 +1,242 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Rotation periods
+const (
+	RotateDaily  = "daily"  // New file every UTC day (default)
+	RotateHourly = "hourly" // New file every UTC hour
+)
+
+// RotatingWriter writes to <prefix>_<period><ext> files in a directory and
+// starts a new file when the period changes or the file would exceed the size
+// limit. Size rotations within a period are numbered <prefix>_<period>.<n><ext>.
+// Old files are pruned by age and count after every rotation.
+type RotatingWriter struct {
+	dir      string
+	prefix   string
+	ext      string
+	rotation string
+	maxSize  int64         // 0 = no size limit
+	maxAge   time.Duration // 0 = keep files regardless of age
+	maxFiles int           // 0 = keep any number of files
+
+	mu     sync.Mutex
+	file   *os.File
+	path   string
+	size   int64
+	period string
+	seq    int
+	now    func() time.Time
+}
+
+// NewRotatingWriter opens (or appends to) the file of the current period
+func NewRotatingWriter(dir, prefix, ext, rotation string, maxSize int64, maxAge time.Duration, maxFiles int) (*RotatingWriter, error) {
+	if rotation == "" {
+		rotation = RotateDaily
+	}
+	if rotation != RotateDaily && rotation != RotateHourly {
+		return nil, fmt.Errorf("invalid rotation: %s (must be %s or %s)", rotation, RotateDaily, RotateHourly)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	w := &RotatingWriter{
+		dir:      dir,
+		prefix:   prefix,
+		ext:      ext,
+		rotation: rotation,
+		maxSize:  maxSize,
+		maxAge:   maxAge,
+		maxFiles: maxFiles,
+		now:      time.Now,
+	}
+	if err := w.openPeriod(w.periodKey(w.now())); err != nil {
+		return nil, err
+	}
+	w.prune()
+	return w, nil
+}
+
+// Write implements io.Writer, rotating before p if needed
+func (w *RotatingWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	if period := w.periodKey(w.now()); period != w.period {
+		if err := w.rotate(period, 0); err != nil {
+			return 0, err
+		}
+	} else if w.maxSize > 0 && w.size > 0 && w.size+int64(len(p)) > w.maxSize {
+		if err := w.rotate(period, w.seq+1); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Path returns the file currently written to
+func (w *RotatingWriter) Path() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.path
+}
+
+// Close closes the current file
+func (w *RotatingWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+// periodKey formats t as the period part of the file name
+func (w *RotatingWriter) periodKey(t time.Time) string {
+	if w.rotation == RotateHourly {
+		return t.UTC().Format("2006-01-02T15")
+	}
+	return t.UTC().Format("2006-01-02")
+}
+
+// fileName returns the file name of a period and size sequence
+func (w *RotatingWriter) fileName(period string, seq int) string {
+	if seq == 0 {
+		return fmt.Sprintf("%s_%s%s", w.prefix, period, w.ext)
+	}
+	return fmt.Sprintf("%s_%s.%d%s", w.prefix, period, seq, w.ext)
+}
+
+// openPeriod opens the last file of a period that still has room, so a
+// restarted bot keeps appending to today's file
+func (w *RotatingWriter) openPeriod(period string) error {
+	seq := 0
+	matches, _ := filepath.Glob(filepath.Join(w.dir, fmt.Sprintf("%s_%s.*%s", w.prefix, period, w.ext)))
+	for _, path := range matches {
+		n, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), w.prefix+"_"+period+"."), w.ext))
+		if err == nil && n > seq {
+			seq = n
+		}
+	}
+	if w.maxSize > 0 {
+		if info, err := os.Stat(filepath.Join(w.dir, w.fileName(period, seq))); err == nil && info.Size() >= w.maxSize {
+			seq++
+		}
+	}
+	return w.open(period, seq)
+}
+
+// open opens the file of a period and sequence for appending
+func (w *RotatingWriter) open(period string, seq int) error {
+	path := filepath.Join(w.dir, w.fileName(period, seq))
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+
+	w.file = file
+	w.path = path
+	w.size = info.Size()
+	w.period = period
+	w.seq = seq
+	return nil
+}
+
+// rotate closes the current file, opens the next one and prunes old files
+func (w *RotatingWriter) rotate(period string, seq int) error {
+	if w.file != nil {
+		w.file.Close()
+		w.file = nil
+	}
+	var err error
+	if seq == 0 {
+		err = w.openPeriod(period)
+	} else {
+		err = w.open(period, seq)
+	}
+	if err != nil {
+		return err
+	}
+	w.prune()
+	return nil
+}
+
+// prune deletes rotated files older than maxAge and all but the newest maxFiles
+func (w *RotatingWriter) prune() {
+	if w.maxAge <= 0 && w.maxFiles <= 0 {
+		return
+	}
+
+	matches, err := filepath.Glob(filepath.Join(w.dir, w.prefix+"_*"+w.ext))
+	if err != nil {
+		return
+	}
+
+	type logFile struct {
+		path    string
+		modTime time.Time
+	}
+	var files []logFile
+	for _, path := range matches {
+		if path == w.path || !w.ownsFile(filepath.Base(path)) {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil || info.IsDir() {
+			continue
+		}
+		files = append(files, logFile{path, info.ModTime()})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].modTime.After(files[j].modTime) })
+
+	cutoff := w.now().Add(-w.maxAge)
+	for i, f := range files {
+		// The current file counts towards maxFiles
+		tooMany := w.maxFiles > 0 && i+1 >= w.maxFiles
+		tooOld := w.maxAge > 0 && f.modTime.Before(cutoff)
+		if tooMany || tooOld {
+			os.Remove(f.path)
+		}
+	}
+}
+
+// ownsFile reports whether name was written by a writer with this prefix, so
+// BTCUSDT_5m does not prune BTCUSDT_5m_extra files of another bot
+func (w *RotatingWriter) ownsFile(name string) bool {
+	period := strings.TrimSuffix(strings.TrimPrefix(name, w.prefix+"_"), w.ext)
+	if i := strings.IndexByte(period, '.'); i >= 0 {
+		period = period[:i]
+	}
+	layout := "2006-01-02"
+	if len(period) == len("2006-01-02T15") {
+		layout = "2006-01-02T15"
+	}
+	_, err := time.Parse(layout, period)
+	return err == nil
+}
@@ -14,6 +14,8 @@ type HealthChecker struct {
 	isConnected bool
 	errors      []string
 	startTime   time.Time
+	riskHalted  bool
+	riskBreach  []string
 }
 
 type HealthStatus struct {
@@ -24,6 +26,8 @@ type HealthStatus struct {
 	IsConnected bool      `json:"is_connected"`
 	Uptime      string    `json:"uptime"`
 	Errors      []string  `json:"errors,omitempty"`
+	RiskHalted  bool      `json:"risk_halted"`
+	RiskBreach  []string  `json:"risk_breaches,omitempty"`
 }
 
 func NewHealthChecker() *HealthChecker {
@@ -38,14 +42,15 @@ func (h *HealthChecker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	defer h.mu.RUnlock()
 
 	status := "healthy"
-	if !h.isConnected || time.Since(h.lastTrade) > time.Hour*24 {
+	code := http.StatusOK
+	if !h.isConnected || time.Since(h.lastTrade) > time.Hour*24 || h.riskHalted {
 		status = "degraded"
-		w.WriteHeader(http.StatusServiceUnavailable)
+		code = http.StatusServiceUnavailable
 	}
 
 	if len(h.errors) > 0 {
 		status = "unhealthy"
-		w.WriteHeader(http.StatusInternalServerError)
+		code = http.StatusInternalServerError
 	}
 
 	health := HealthStatus{
@@ -56,9 +61,12 @@ func (h *HealthChecker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		IsConnected: h.isConnected,
 		Uptime:      time.Since(h.startTime).String(),
 		Errors:      h.errors,
+		RiskHalted:  h.riskHalted,
+		RiskBreach:  h.riskBreach,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
 	json.NewEncoder(w).Encode(health)
 }
 
@@ -94,3 +102,12 @@ func (h *HealthChecker) AddError(err string) {
 		h.errors = h.errors[len(h.errors)-10:]
 	}
 }
+
+// SetRiskStatus records the risk guard state. A halted guard marks the bot
+// degraded; breaches lists the active rule violations.
+func (h *HealthChecker) SetRiskStatus(halted bool, breaches []string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.riskHalted = halted
+	h.riskBreach = breaches
+}
@@ -19,9 +19,10 @@ type RecoveryHandler struct {
 
 // RetryConfig defines retry behavior for different error categories
 type RetryConfig struct {
-	MaxRetries map[errors.ErrorCategory]int
-	BaseDelay  time.Duration
-	MaxDelay   time.Duration
+	MaxRetries     map[errors.ErrorCategory]int
+	BaseDelay      time.Duration
+	RateLimitDelay time.Duration // Base delay after rate limit errors
+	MaxDelay       time.Duration
 }
 
 // BackoffConfig defines backoff strategies
@@ -69,8 +70,9 @@ func NewRecoveryHandler(logger Logger) *RecoveryHandler {
 			errors.ErrorCategoryPosition:  3,
 			errors.ErrorCategoryStrategy:  1,
 		},
-		BaseDelay: 1 * time.Second,
-		MaxDelay:  30 * time.Second,
+		BaseDelay:      1 * time.Second,
+		RateLimitDelay: 30 * time.Second,
+		MaxDelay:       30 * time.Second,
 	}
 
 	backoffConfig := BackoffConfig{
@@ -88,6 +90,16 @@ func NewRecoveryHandler(logger Logger) *RecoveryHandler {
 	}
 }
 
+// RetryConfig returns the retry limits and delays in use
+func (rh *RecoveryHandler) RetryConfig() RetryConfig {
+	return rh.retryConfig
+}
+
+// SetRetryConfig replaces the retry limits and delays
+func (rh *RecoveryHandler) SetRetryConfig(config RetryConfig) {
+	rh.retryConfig = config
+}
+
 // HandleError processes an error and returns a recovery strategy
 func (rh *RecoveryHandler) HandleError(err error, component, operation string, attempt int) *RecoveryResult {
 	// Categorize the error
@@ -171,8 +183,8 @@ func (rh *RecoveryHandler) calculateDelay(category errors.ErrorCategory, attempt
 	baseDelay := rh.retryConfig.BaseDelay
 	
 	// Rate limiting needs longer delays
-	if category == errors.ErrorCategoryRateLimit {
-		baseDelay = 30 * time.Second
+	if category == errors.ErrorCategoryRateLimit && rh.retryConfig.RateLimitDelay > 0 {
+		baseDelay = rh.retryConfig.RateLimitDelay
 	}
 	
 	var delay time.Duration
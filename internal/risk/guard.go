@@ -0,0 +1,363 @@
+package risk
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/exchange"
+)
+
+// Risk rules enforced by Guard
+const (
+	RuleMaxOrderNotional = "max_order_notional"
+	RuleMaxDCALevels     = "max_dca_levels"
+	RuleMaxCycleCapital  = "max_cycle_capital"
+	RuleMinFreeMargin    = "min_free_margin"
+	RuleMaxDailyLoss     = "max_daily_loss"
+	RuleMaxDrawdown      = "max_drawdown"
+)
+
+// Limits are account-level risk limits. A zero value disables the rule.
+type Limits struct {
+	MaxCycleCapital    float64 `json:"max_cycle_capital,omitempty"`    // Max quote amount invested in one DCA cycle
+	MaxDCALevels       int     `json:"max_dca_levels,omitempty"`       // Max buys per cycle, including the first
+	MaxDailyLoss       float64 `json:"max_daily_loss,omitempty"`       // Max realized loss per UTC day, in quote currency
+	MaxDrawdownPercent float64 `json:"max_drawdown_percent,omitempty"` // Max equity drawdown from its peak (0.2 = 20%)
+	MinFreeMargin      float64 `json:"min_free_margin,omitempty"`      // Free balance that must remain after a buy
+	MaxOrderNotional   float64 `json:"max_order_notional,omitempty"`   // Max notional of a single buy
+}
+
+// Enabled reports whether any rule is active
+func (l Limits) Enabled() bool {
+	return l.MaxCycleCapital > 0 || l.MaxDCALevels > 0 || l.MaxDailyLoss > 0 ||
+		l.MaxDrawdownPercent > 0 || l.MinFreeMargin > 0 || l.MaxOrderNotional > 0
+}
+
+// Validate checks that the limits are usable
+func (l Limits) Validate() error {
+	if l.MaxCycleCapital < 0 {
+		return fmt.Errorf("max_cycle_capital must be non-negative, got %.2f", l.MaxCycleCapital)
+	}
+	if l.MaxDCALevels < 0 {
+		return fmt.Errorf("max_dca_levels must be non-negative, got %d", l.MaxDCALevels)
+	}
+	if l.MaxDailyLoss < 0 {
+		return fmt.Errorf("max_daily_loss must be non-negative, got %.2f", l.MaxDailyLoss)
+	}
+	if l.MaxDrawdownPercent < 0 || l.MaxDrawdownPercent >= 1 {
+		return fmt.Errorf("max_drawdown_percent must be between 0 and 1, got %.4f", l.MaxDrawdownPercent)
+	}
+	if l.MinFreeMargin < 0 {
+		return fmt.Errorf("min_free_margin must be non-negative, got %.2f", l.MinFreeMargin)
+	}
+	if l.MaxOrderNotional < 0 {
+		return fmt.Errorf("max_order_notional must be non-negative, got %.2f", l.MaxOrderNotional)
+	}
+	return nil
+}
+
+// Breach describes a violated risk limit. Order-level breaches only reject
+// the offending buy; halting breaches (daily loss, drawdown) block every buy
+// until they clear.
+type Breach struct {
+	Rule    string    `json:"rule"`
+	Message string    `json:"message"`
+	Halt    bool      `json:"halt"`
+	Time    time.Time `json:"time"`
+}
+
+func (b *Breach) Error() string {
+	return fmt.Sprintf("risk limit %s breached: %s", b.Rule, b.Message)
+}
+
+// Status is a snapshot of the guard for monitoring
+type Status struct {
+	Limits           Limits   `json:"limits"`
+	Halted           bool     `json:"halted"`
+	Equity           float64  `json:"equity"`
+	PeakEquity       float64  `json:"peak_equity"`
+	Drawdown         float64  `json:"drawdown"`
+	Day              string   `json:"day,omitempty"`
+	DailyRealizedPnL float64  `json:"daily_realized_pnl"`
+	Breaches         []Breach `json:"breaches,omitempty"`
+}
+
+// Guard enforces Limits before every buy. It is shared by the live bot and
+// the backtest engine so both apply the same rules.
+//
+// A daily loss halt clears at the next UTC day. A drawdown halt stays until
+// Resume is called, which also re-bases the peak to the current equity.
+type Guard struct {
+	mu         sync.Mutex
+	limits     Limits
+	equity     float64
+	peakEquity float64
+	day        string
+	dailyPnL   float64
+	active     map[string]*Breach // Rule -> breach, cleared when the condition no longer applies
+	onBreach   func(Breach)
+}
+
+// NewGuard creates a guard enforcing limits
+func NewGuard(limits Limits) *Guard {
+	return &Guard{
+		limits: limits,
+		active: make(map[string]*Breach),
+	}
+}
+
+// Limits returns the limits being enforced
+func (g *Guard) Limits() Limits {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.limits
+}
+
+// SetBreachCallback installs fn, called once each time a rule becomes breached.
+// fn runs without the guard's lock held.
+func (g *Guard) SetBreachCallback(fn func(Breach)) {
+	g.mu.Lock()
+	g.onBreach = fn
+	g.mu.Unlock()
+}
+
+// ValidateOrder checks a buy against every rule and returns a *Breach if it
+// must not be placed. Sells always pass since they only reduce exposure.
+// Portfolio.Equity, when set, also feeds the drawdown rule.
+func (g *Guard) ValidateOrder(order *Order, portfolio *Portfolio) error {
+	var fired []Breach
+	defer func() { g.notify(fired) }()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := portfolio.Time
+	if now.IsZero() {
+		now = time.Now()
+	}
+	g.rollDay(now)
+	if portfolio.Equity > 0 {
+		fired = append(fired, g.updateEquity(portfolio.Equity, now)...)
+	}
+
+	if order.Side != exchange.OrderSideBuy {
+		return nil
+	}
+
+	// Halting breaches block every buy
+	for _, rule := range []string{RuleMaxDailyLoss, RuleMaxDrawdown} {
+		if b, ok := g.active[rule]; ok {
+			return b
+		}
+	}
+
+	var breach *Breach
+	switch {
+	case g.limits.MaxOrderNotional > 0 && order.Amount > g.limits.MaxOrderNotional:
+		breach = g.breach(RuleMaxOrderNotional, false, now,
+			"order notional $%.2f exceeds limit $%.2f", order.Amount, g.limits.MaxOrderNotional)
+	case g.limits.MaxDCALevels > 0 && portfolio.DCALevel >= g.limits.MaxDCALevels:
+		breach = g.breach(RuleMaxDCALevels, false, now,
+			"cycle already has %d of %d allowed buys", portfolio.DCALevel, g.limits.MaxDCALevels)
+	case g.limits.MaxCycleCapital > 0 && portfolio.CycleCapital+order.Amount > g.limits.MaxCycleCapital:
+		breach = g.breach(RuleMaxCycleCapital, false, now,
+			"cycle capital would reach $%.2f, limit $%.2f", portfolio.CycleCapital+order.Amount, g.limits.MaxCycleCapital)
+	case g.limits.MinFreeMargin > 0 && portfolio.Balance-order.Amount < g.limits.MinFreeMargin:
+		breach = g.breach(RuleMinFreeMargin, false, now,
+			"free margin would drop to $%.2f, minimum $%.2f", portfolio.Balance-order.Amount, g.limits.MinFreeMargin)
+	default:
+		// Order-level breaches clear as soon as a buy passes again
+		for _, rule := range []string{RuleMaxOrderNotional, RuleMaxDCALevels, RuleMaxCycleCapital, RuleMinFreeMargin} {
+			delete(g.active, rule)
+		}
+		return nil
+	}
+
+	if g.activate(breach) {
+		fired = append(fired, *breach)
+	}
+	return breach
+}
+
+// ShouldStopTrading reports whether a halting breach is active after taking
+// the portfolio's equity into account
+func (g *Guard) ShouldStopTrading(portfolio *Portfolio) bool {
+	var fired []Breach
+	defer func() { g.notify(fired) }()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := portfolio.Time
+	if now.IsZero() {
+		now = time.Now()
+	}
+	g.rollDay(now)
+	if portfolio.Equity > 0 {
+		fired = g.updateEquity(portfolio.Equity, now)
+	}
+	return g.halted()
+}
+
+// UpdateEquity records the current account equity for the drawdown rule and
+// returns the breach if the drawdown limit was just crossed
+func (g *Guard) UpdateEquity(equity float64, t time.Time) *Breach {
+	var fired []Breach
+	defer func() { g.notify(fired) }()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.rollDay(t)
+	fired = g.updateEquity(equity, t)
+	if len(fired) > 0 {
+		return &fired[0]
+	}
+	return nil
+}
+
+// RecordRealizedPnL adds realized profit or loss to the current UTC day and
+// returns the breach if the daily loss limit was just crossed
+func (g *Guard) RecordRealizedPnL(pnl float64, t time.Time) *Breach {
+	var fired []Breach
+	defer func() { g.notify(fired) }()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.rollDay(t)
+	g.dailyPnL += pnl
+
+	if g.limits.MaxDailyLoss <= 0 || -g.dailyPnL < g.limits.MaxDailyLoss {
+		return nil
+	}
+	breach := g.breach(RuleMaxDailyLoss, true, t,
+		"realized loss today $%.2f reached limit $%.2f", -g.dailyPnL, g.limits.MaxDailyLoss)
+	if !g.activate(breach) {
+		return nil
+	}
+	fired = append(fired, *breach)
+	return breach
+}
+
+// Halted reports whether a halting breach is blocking buys
+func (g *Guard) Halted() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.halted()
+}
+
+// Resume clears all breaches and re-bases the drawdown peak to the last known
+// equity. A daily loss limit that is still exceeded trips again on the next
+// realized loss.
+func (g *Guard) Resume() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.active = make(map[string]*Breach)
+	g.peakEquity = g.equity
+}
+
+// Status returns a snapshot of the guard
+func (g *Guard) Status() Status {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	status := Status{
+		Limits:           g.limits,
+		Halted:           g.halted(),
+		Equity:           g.equity,
+		PeakEquity:       g.peakEquity,
+		Day:              g.day,
+		DailyRealizedPnL: g.dailyPnL,
+	}
+	if g.peakEquity > 0 {
+		status.Drawdown = (g.peakEquity - g.equity) / g.peakEquity
+	}
+	for _, b := range g.active {
+		status.Breaches = append(status.Breaches, *b)
+	}
+	sort.Slice(status.Breaches, func(i, j int) bool {
+		return status.Breaches[i].Time.Before(status.Breaches[j].Time)
+	})
+	return status
+}
+
+// updateEquity tracks peak equity and trips the drawdown rule. Caller holds mu.
+func (g *Guard) updateEquity(equity float64, t time.Time) []Breach {
+	g.equity = equity
+	if equity > g.peakEquity {
+		g.peakEquity = equity
+	}
+	if g.limits.MaxDrawdownPercent <= 0 || g.peakEquity <= 0 {
+		return nil
+	}
+
+	drawdown := (g.peakEquity - equity) / g.peakEquity
+	if drawdown < g.limits.MaxDrawdownPercent {
+		return nil
+	}
+	breach := g.breach(RuleMaxDrawdown, true, t,
+		"equity $%.2f is %.2f%% below peak $%.2f (limit %.2f%%)",
+		equity, drawdown*100, g.peakEquity, g.limits.MaxDrawdownPercent*100)
+	if !g.activate(breach) {
+		return nil
+	}
+	return []Breach{*breach}
+}
+
+// rollDay starts a new daily loss window when t is on a later UTC day. Caller holds mu.
+func (g *Guard) rollDay(t time.Time) {
+	day := t.UTC().Format("2006-01-02")
+	if day == g.day {
+		return
+	}
+	g.day = day
+	g.dailyPnL = 0
+	delete(g.active, RuleMaxDailyLoss)
+}
+
+// activate records a breach and reports whether its rule was not already
+// breached. Caller holds mu.
+func (g *Guard) activate(b *Breach) bool {
+	_, exists := g.active[b.Rule]
+	g.active[b.Rule] = b
+	return !exists
+}
+
+// halted reports whether a halting breach is active. Caller holds mu.
+func (g *Guard) halted() bool {
+	for _, b := range g.active {
+		if b.Halt {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Guard) breach(rule string, halt bool, t time.Time, format string, args ...interface{}) *Breach {
+	return &Breach{
+		Rule:    rule,
+		Message: fmt.Sprintf(format, args...),
+		Halt:    halt,
+		Time:    t,
+	}
+}
+
+// notify runs the breach callback for newly breached rules
+func (g *Guard) notify(fired []Breach) {
+	if len(fired) == 0 {
+		return
+	}
+	g.mu.Lock()
+	fn := g.onBreach
+	g.mu.Unlock()
+	if fn == nil {
+		return
+	}
+	for _, b := range fired {
+		fn(b)
+	}
+}
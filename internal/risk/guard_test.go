@@ -0,0 +1,244 @@
+package risk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/exchange"
+)
+
+var guardStart = time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+
+func buy(amount float64) *Order {
+	return &Order{Symbol: "BTCUSDT", Side: exchange.OrderSideBuy, Amount: amount, Price: 100}
+}
+
+func TestGuardTripsAtMaxDrawdown(t *testing.T) {
+	tests := []struct {
+		name   string
+		equity float64 // After a peak of 1000
+		trips  bool
+	}{
+		{"above the limit", 801, false},
+		{"exactly at the limit", 800, true},
+		{"past the limit", 700, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGuard(Limits{MaxDrawdownPercent: 0.2})
+			if b := g.UpdateEquity(1000, guardStart); b != nil {
+				t.Fatalf("breach at the peak: %v", b)
+			}
+
+			b := g.UpdateEquity(tt.equity, guardStart.Add(time.Hour))
+			if (b != nil) != tt.trips || g.Halted() != tt.trips {
+				t.Fatalf("equity %.0f: breach %v, halted %v, want trip %v", tt.equity, b, g.Halted(), tt.trips)
+			}
+			if tt.trips && (b.Rule != RuleMaxDrawdown || !b.Halt) {
+				t.Fatalf("unexpected breach %+v", b)
+			}
+		})
+	}
+}
+
+func TestGuardDrawdownHaltHoldsUntilResume(t *testing.T) {
+	g := NewGuard(Limits{MaxDrawdownPercent: 0.2})
+	var fired []Breach
+	g.SetBreachCallback(func(b Breach) { fired = append(fired, b) })
+
+	g.UpdateEquity(1000, guardStart)
+	g.UpdateEquity(750, guardStart.Add(time.Hour))
+	// Still below the limit: the breach stays active but is not reported again
+	if b := g.UpdateEquity(780, guardStart.Add(2*time.Hour)); b != nil {
+		t.Fatalf("breach reported twice: %v", b)
+	}
+	if len(fired) != 1 {
+		t.Fatalf("callback fired %d times, want 1", len(fired))
+	}
+
+	// Recovering above the limit or rolling into a new day does not clear it
+	g.UpdateEquity(950, guardStart.Add(3*time.Hour))
+	g.UpdateEquity(950, guardStart.Add(48*time.Hour))
+	if !g.Halted() {
+		t.Fatal("drawdown halt cleared without Resume")
+	}
+	err := g.ValidateOrder(buy(10), &Portfolio{Balance: 1000, Time: guardStart.Add(48 * time.Hour)})
+	if b, ok := err.(*Breach); !ok || b.Rule != RuleMaxDrawdown {
+		t.Fatalf("buy while halted: got %v, want the drawdown breach", err)
+	}
+
+	// Resume re-bases the peak to the current equity
+	g.Resume()
+	status := g.Status()
+	if status.Halted || status.PeakEquity != 950 || status.Drawdown != 0 {
+		t.Fatalf("after Resume: %+v", status)
+	}
+	if err := g.ValidateOrder(buy(10), &Portfolio{Balance: 1000, Time: guardStart.Add(49 * time.Hour)}); err != nil {
+		t.Fatalf("buy after Resume rejected: %v", err)
+	}
+
+	// 20% below the new peak of 950, not the old one of 1000
+	if b := g.UpdateEquity(761, guardStart.Add(50*time.Hour)); b != nil {
+		t.Fatalf("tripped against the old peak: %v", b)
+	}
+	if b := g.UpdateEquity(760, guardStart.Add(51*time.Hour)); b == nil {
+		t.Fatal("did not trip 20% below the re-based peak")
+	}
+	if len(fired) != 2 {
+		t.Fatalf("callback fired %d times, want 2", len(fired))
+	}
+}
+
+func TestGuardTripsAtMaxDailyLoss(t *testing.T) {
+	g := NewGuard(Limits{MaxDailyLoss: 100})
+
+	// Profits offset losses within the day
+	steps := []struct {
+		pnl   float64
+		trips bool
+	}{
+		{-60, false},
+		{30, false},
+		{-69, false}, // Day at -99
+		{-1, true},   // Day at -100
+		{-50, false}, // Already breached, not reported again
+	}
+	for i, s := range steps {
+		b := g.RecordRealizedPnL(s.pnl, guardStart.Add(time.Duration(i)*time.Hour))
+		if (b != nil) != s.trips {
+			t.Fatalf("step %d (pnl %.0f): breach %v, want trip %v", i, s.pnl, b, s.trips)
+		}
+		if b != nil && (b.Rule != RuleMaxDailyLoss || !b.Halt) {
+			t.Fatalf("unexpected breach %+v", b)
+		}
+	}
+	if !g.Halted() {
+		t.Fatal("guard not halted after the daily loss limit")
+	}
+	if !g.ShouldStopTrading(&Portfolio{Time: guardStart.Add(13 * time.Hour)}) {
+		t.Fatal("ShouldStopTrading false later the same UTC day")
+	}
+}
+
+func TestGuardDailyLossResetsAtUTCDayRollover(t *testing.T) {
+	g := NewGuard(Limits{MaxDailyLoss: 100})
+	g.RecordRealizedPnL(-150, guardStart)
+
+	// 23:59 UTC is still the same day
+	lastMinute := time.Date(2024, 3, 1, 23, 59, 0, 0, time.UTC)
+	if !g.ShouldStopTrading(&Portfolio{Time: lastMinute}) {
+		t.Fatal("halt cleared before the UTC day ended")
+	}
+
+	// Local time zones must not shift the boundary
+	nextDay := time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC).In(time.FixedZone("UTC-5", -5*3600))
+	if g.ShouldStopTrading(&Portfolio{Time: nextDay}) {
+		t.Fatal("daily loss halt did not clear at the next UTC day")
+	}
+	if status := g.Status(); status.Day != "2024-03-02" || status.DailyRealizedPnL != 0 {
+		t.Fatalf("new day not started: %+v", status)
+	}
+
+	// The new day gets a fresh allowance
+	if b := g.RecordRealizedPnL(-99, nextDay.Add(time.Hour)); b != nil {
+		t.Fatalf("tripped with yesterday's losses carried over: %v", b)
+	}
+	if b := g.RecordRealizedPnL(-1, nextDay.Add(2*time.Hour)); b == nil {
+		t.Fatal("did not trip again at the limit on the new day")
+	}
+}
+
+func TestGuardResumeClearsDailyLossUntilTheNextLoss(t *testing.T) {
+	g := NewGuard(Limits{MaxDailyLoss: 100})
+	g.RecordRealizedPnL(-120, guardStart)
+
+	g.Resume()
+	if g.Halted() {
+		t.Fatal("still halted after Resume")
+	}
+	// The day's total is still past the limit, so the next loss trips again
+	if b := g.RecordRealizedPnL(-1, guardStart.Add(time.Hour)); b == nil {
+		t.Fatal("loss after Resume did not trip the still-exceeded limit")
+	}
+}
+
+func TestGuardOrderLevelRules(t *testing.T) {
+	limits := Limits{
+		MaxOrderNotional: 200,
+		MaxDCALevels:     3,
+		MaxCycleCapital:  500,
+		MinFreeMargin:    100,
+	}
+	tests := []struct {
+		name      string
+		order     *Order
+		portfolio Portfolio
+		rule      string // Empty when the order passes
+	}{
+		{"within every limit", buy(150), Portfolio{Balance: 1000, CycleCapital: 300, DCALevel: 2}, ""},
+		{"order notional", buy(201), Portfolio{Balance: 1000}, RuleMaxOrderNotional},
+		{"dca levels", buy(50), Portfolio{Balance: 1000, DCALevel: 3}, RuleMaxDCALevels},
+		{"cycle capital", buy(150), Portfolio{Balance: 1000, CycleCapital: 400, DCALevel: 2}, RuleMaxCycleCapital},
+		{"free margin", buy(150), Portfolio{Balance: 240}, RuleMinFreeMargin},
+		{"sells always pass", &Order{Side: exchange.OrderSideSell, Amount: 5000}, Portfolio{DCALevel: 9}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGuard(limits)
+			tt.portfolio.Time = guardStart
+			err := g.ValidateOrder(tt.order, &tt.portfolio)
+			if tt.rule == "" {
+				if err != nil {
+					t.Fatalf("unexpected rejection: %v", err)
+				}
+				return
+			}
+			b, ok := err.(*Breach)
+			if !ok || b.Rule != tt.rule {
+				t.Fatalf("got %v, want a %s breach", err, tt.rule)
+			}
+			if b.Halt || g.Halted() {
+				t.Fatal("an order-level breach halted the guard")
+			}
+		})
+	}
+}
+
+func TestGuardOrderLevelBreachClearsOnNextPassingBuy(t *testing.T) {
+	g := NewGuard(Limits{MaxOrderNotional: 200})
+	portfolio := &Portfolio{Balance: 1000, Time: guardStart}
+
+	if err := g.ValidateOrder(buy(300), portfolio); err == nil {
+		t.Fatal("oversized order accepted")
+	}
+	if len(g.Status().Breaches) != 1 {
+		t.Fatalf("breach not recorded: %+v", g.Status())
+	}
+	if err := g.ValidateOrder(buy(100), portfolio); err != nil {
+		t.Fatalf("valid order rejected: %v", err)
+	}
+	if breaches := g.Status().Breaches; len(breaches) != 0 {
+		t.Fatalf("breach not cleared: %+v", breaches)
+	}
+}
+
+func TestLimitsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		limits  Limits
+		wantErr bool
+	}{
+		{"zero disables everything", Limits{}, false},
+		{"typical", Limits{MaxDailyLoss: 50, MaxDrawdownPercent: 0.25, MaxDCALevels: 5}, false},
+		{"negative daily loss", Limits{MaxDailyLoss: -1}, true},
+		{"drawdown of 100%", Limits{MaxDrawdownPercent: 1}, true},
+		{"negative dca levels", Limits{MaxDCALevels: -1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.limits.Validate(); (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package risk
 
 import (
+	"time"
+
 	"github.com/ducminhle1904/crypto-dca-bot/internal/exchange"
 )
 
@@ -14,13 +16,10 @@ type Order struct {
 
 // Portfolio represents the current portfolio state
 type Portfolio struct {
-	Balance float64
-	Symbol  string
-}
-
-// Signal represents a trading signal
-type Signal struct {
-	Type     string
-	Strength float64
-	Price    float64
+	Balance      float64 // Free balance available for new orders
+	Symbol       string
+	Equity       float64   // Balance plus open position value; 0 if unknown
+	CycleCapital float64   // Quote amount already invested in the current cycle
+	DCALevel     int       // Buys already made in the current cycle
+	Time         time.Time // When the snapshot was taken; zero means now
 }
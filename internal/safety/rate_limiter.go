@@ -2,6 +2,7 @@ package safety
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"time"
 )
@@ -14,6 +15,67 @@ type RateLimiter struct {
 	lastRefill   time.Time     // Last time tokens were added
 	mutex        sync.Mutex    // Protects token count
 	name         string        // Name for logging/identification
+	quotas       map[string]Quota // Exchange-reported quotas by endpoint
+	lastAllowed  time.Time     // Last time an operation was let through
+}
+
+// maxQuotaWait caps how long an exchange-reported quota can hold a call back,
+// so a skewed reset timestamp cannot stall the bot
+const maxQuotaWait = 5 * time.Second
+
+// Quota is the request allowance an exchange reports for one endpoint
+// (e.g. Bybit X-Bapi-Limit-Status / X-Bapi-Limit / X-Bapi-Limit-Reset-Timestamp)
+type Quota struct {
+	Group     string    // Endpoint group the endpoint belongs to
+	Endpoint  string    // Endpoint the quota applies to; the group when empty
+	Limit     int       // Requests allowed per window
+	Remaining int       // Requests left in the current window
+	ResetAt   time.Time // When the window resets
+}
+
+// key identifies the quota among those of a limiter
+func (q Quota) key() string {
+	if q.Endpoint != "" {
+		return q.Endpoint
+	}
+	return q.Group
+}
+
+// reserve is the part of the quota kept back for requests already in flight
+func (q Quota) reserve() int {
+	if r := q.Limit / 10; r > 1 {
+		return r
+	}
+	return 1
+}
+
+// Active reports whether the quota's window has not reset yet
+func (q Quota) Active(now time.Time) bool {
+	return q.Limit > 0 && now.Before(q.ResetAt)
+}
+
+// Exhausted reports whether the quota is down to its reserve before the
+// window resets; calls are then held until the reset
+func (q Quota) Exhausted(now time.Time) bool {
+	return q.Active(now) && q.Remaining <= q.reserve()
+}
+
+// delay returns how long the next call should wait to stay within the quota.
+// Below half the quota the remaining calls are spread over the rest of the
+// window instead of being spent in one burst.
+func (q Quota) delay(now, lastAllowed time.Time) time.Duration {
+	if !q.Active(now) {
+		return 0
+	}
+	untilReset := q.ResetAt.Sub(now)
+	if q.Remaining <= q.reserve() {
+		return untilReset
+	}
+	if q.Remaining*2 > q.Limit {
+		return 0
+	}
+	interval := untilReset / time.Duration(q.Remaining-q.reserve())
+	return lastAllowed.Add(interval).Sub(now)
 }
 
 // NewRateLimiter creates a new rate limiter
@@ -39,14 +101,72 @@ func (rl *RateLimiter) AllowN(n int) bool {
 
 	rl.refillTokens()
 
+	now := time.Now()
+	if rl.quotaDelay(now) > 0 {
+		return false
+	}
+
 	if rl.tokens >= n {
 		rl.tokens -= n
+		rl.spendQuota(n)
+		rl.lastAllowed = now
 		return true
 	}
 
 	return false
 }
 
+// UpdateQuota records the quota the exchange reported for an endpoint. Each
+// endpoint keeps its own quota; until their windows reset, calls are paced on
+// the most restrictive one so none is exhausted.
+func (rl *RateLimiter) UpdateQuota(q Quota) {
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+
+	if rl.quotas == nil {
+		rl.quotas = make(map[string]Quota)
+	}
+	rl.quotas[q.key()] = q
+}
+
+// quotaDelay returns the longest wait any active quota requires, dropping
+// quotas whose window has reset. Caller must hold the mutex.
+func (rl *RateLimiter) quotaDelay(now time.Time) time.Duration {
+	var longest time.Duration
+	for key, q := range rl.quotas {
+		if !q.Active(now) {
+			delete(rl.quotas, key)
+			continue
+		}
+		if d := q.delay(now, rl.lastAllowed); d > longest {
+			longest = d
+		}
+	}
+	if longest > maxQuotaWait {
+		longest = maxQuotaWait
+	}
+	return longest
+}
+
+// spendQuota counts calls against the known quotas until the exchange
+// reports fresh values. The limiter does not know which endpoint a call goes
+// to, so calls are charged once, to the quota closest to exhaustion; the
+// exchange's next report corrects the count. Caller must hold the mutex.
+func (rl *RateLimiter) spendQuota(n int) {
+	var tightest string
+	for key, q := range rl.quotas {
+		if tightest == "" || q.Remaining-q.reserve() < rl.quotas[tightest].Remaining-rl.quotas[tightest].reserve() {
+			tightest = key
+		}
+	}
+	if tightest == "" {
+		return
+	}
+	q := rl.quotas[tightest]
+	q.Remaining -= n
+	rl.quotas[tightest] = q
+}
+
 // Wait waits until an operation is allowed
 func (rl *RateLimiter) Wait(ctx context.Context) error {
 	return rl.WaitN(ctx, 1)
@@ -98,15 +218,20 @@ func (rl *RateLimiter) calculateWaitTime(n int) time.Duration {
 
 	rl.refillTokens()
 
+	quotaWait := rl.quotaDelay(time.Now())
 	if rl.tokens >= n {
-		return 0
+		return quotaWait
 	}
 
 	tokensNeeded := n - rl.tokens
 	secondsToWait := float64(tokensNeeded) / float64(rl.refillRate)
 	
 	// Add small buffer to account for timing precision
-	return time.Duration(secondsToWait*1000+100) * time.Millisecond
+	tokenWait := time.Duration(secondsToWait*1000+100) * time.Millisecond
+	if quotaWait > tokenWait {
+		return quotaWait
+	}
+	return tokenWait
 }
 
 // GetStats returns current statistics about the rate limiter
@@ -116,13 +241,20 @@ func (rl *RateLimiter) GetStats() RateLimiterStats {
 
 	rl.refillTokens()
 
-	return RateLimiterStats{
+	now := time.Now()
+	stats := RateLimiterStats{
 		Name:       rl.name,
 		Capacity:   rl.capacity,
 		Tokens:     rl.tokens,
 		RefillRate: rl.refillRate,
 		LastRefill: rl.lastRefill,
+		QuotaDelay: rl.quotaDelay(now),
+	}
+	for _, q := range rl.quotas {
+		stats.Quotas = append(stats.Quotas, q)
 	}
+	sort.Slice(stats.Quotas, func(i, j int) bool { return stats.Quotas[i].key() < stats.Quotas[j].key() })
+	return stats
 }
 
 // RateLimiterStats holds statistics about a rate limiter
@@ -132,6 +264,8 @@ type RateLimiterStats struct {
 	Tokens     int
 	RefillRate int
 	LastRefill time.Time
+	Quotas     []Quota       // Active exchange-reported quotas
+	QuotaDelay time.Duration // Wait the quotas currently impose on the next call
 }
 
 // RateLimiterManager manages multiple rate limiters
@@ -217,7 +351,24 @@ func NewAdaptiveRateLimiter(name string, capacity, initialRate, minRate, maxRate
 
 // Allow checks if an operation is allowed and records the attempt
 func (arl *AdaptiveRateLimiter) Allow() bool {
-	return arl.baseLimiter.Allow()
+	arl.mutex.Lock()
+	limiter := arl.baseLimiter
+	arl.mutex.Unlock()
+	return limiter.Allow()
+}
+
+// UpdateQuota passes an exchange-reported quota to the underlying limiter.
+// An exhausted quota counts as a failure, so the adaptive rate backs off
+// before the exchange starts rejecting calls.
+func (arl *AdaptiveRateLimiter) UpdateQuota(q Quota) {
+	arl.mutex.Lock()
+	defer arl.mutex.Unlock()
+
+	arl.baseLimiter.UpdateQuota(q)
+	if q.Exhausted(time.Now()) {
+		arl.failureCount++
+		arl.adjustRateIfNeeded()
+	}
 }
 
 // RecordSuccess records a successful operation
@@ -265,7 +416,12 @@ func (arl *AdaptiveRateLimiter) adjustRateIfNeeded() {
 
 	if newRate != arl.currentRate {
 		arl.currentRate = newRate
-		arl.baseLimiter = NewRateLimiter(arl.baseLimiter.name, arl.baseLimiter.capacity, newRate)
+		previous := arl.baseLimiter
+		arl.baseLimiter = NewRateLimiter(previous.name, previous.capacity, newRate)
+		// Known exchange quotas still apply at the new rate
+		for _, q := range previous.GetStats().Quotas {
+			arl.baseLimiter.UpdateQuota(q)
+		}
 	}
 
 	// Reset counters
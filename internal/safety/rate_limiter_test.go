@@ -0,0 +1,63 @@
+package safety
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimiterQuotasPerEndpoint(t *testing.T) {
+	rl := NewRateLimiter("trading", 100, 100)
+	reset := time.Now().Add(time.Minute)
+
+	rl.UpdateQuota(Quota{Group: "order", Endpoint: "/v5/order/create", Limit: 10, Remaining: 1, ResetAt: reset})
+	rl.UpdateQuota(Quota{Group: "order", Endpoint: "/v5/order/realtime", Limit: 10, Remaining: 10, ResetAt: reset})
+
+	stats := rl.GetStats()
+	if len(stats.Quotas) != 2 {
+		t.Fatalf("expected a quota per endpoint, got %+v", stats.Quotas)
+	}
+	if stats.QuotaDelay <= 0 {
+		t.Errorf("exhausted /v5/order/create quota should hold calls, delay %v", stats.QuotaDelay)
+	}
+	if rl.Allow() {
+		t.Error("call allowed while the most restrictive quota is exhausted")
+	}
+
+	// A refreshed quota on the exhausted endpoint releases the calls
+	rl.UpdateQuota(Quota{Group: "order", Endpoint: "/v5/order/create", Limit: 10, Remaining: 10, ResetAt: reset})
+	if !rl.Allow() {
+		t.Fatal("call held after the quota was refreshed")
+	}
+}
+
+func TestRateLimiterSpendsQuotaOnce(t *testing.T) {
+	rl := NewRateLimiter("trading", 100, 100)
+	reset := time.Now().Add(time.Minute)
+
+	rl.UpdateQuota(Quota{Group: "order", Endpoint: "/v5/order/create", Limit: 100, Remaining: 80, ResetAt: reset})
+	rl.UpdateQuota(Quota{Group: "order", Endpoint: "/v5/order/cancel", Limit: 100, Remaining: 90, ResetAt: reset})
+	if !rl.Allow() {
+		t.Fatal("call held with plenty of quota left")
+	}
+
+	remaining := make(map[string]int)
+	for _, q := range rl.GetStats().Quotas {
+		remaining[q.Endpoint] = q.Remaining
+	}
+	if remaining["/v5/order/create"] != 79 || remaining["/v5/order/cancel"] != 90 {
+		t.Errorf("expected the call charged to the tightest quota only, got %v", remaining)
+	}
+}
+
+func TestRateLimiterGroupQuotaWithoutEndpoint(t *testing.T) {
+	rl := NewRateLimiter("market_data", 100, 100)
+	reset := time.Now().Add(time.Minute)
+
+	rl.UpdateQuota(Quota{Group: "market", Limit: 10, Remaining: 5, ResetAt: reset})
+	rl.UpdateQuota(Quota{Group: "market", Limit: 10, Remaining: 9, ResetAt: reset})
+
+	stats := rl.GetStats()
+	if len(stats.Quotas) != 1 || stats.Quotas[0].Remaining != 9 {
+		t.Errorf("expected one group quota with the latest values, got %+v", stats.Quotas)
+	}
+}
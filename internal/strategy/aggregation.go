@@ -0,0 +1,213 @@
+package strategy
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/config"
+)
+
+// signalAggregator combines indicator results into a buy score according to
+// the configured aggregation mode, with required and veto indicators checked
+// before the score
+type signalAggregator struct {
+	mode     string
+	weights  map[indicators.IndicatorType]float64
+	required []indicators.IndicatorType
+	veto     []indicators.IndicatorType
+	quorum   int
+}
+
+// signalScore is the outcome of aggregating one candle's indicator results
+type signalScore struct {
+	Passed     bool    // Whether the score clears the mode's bar
+	Confidence float64 // Score in the 0-1 range
+	Strength   float64 // Signal strength used for position sizing
+	BuyVotes   int
+	Voters     int    // Indicators that vote, i.e. all but entry filters
+	Active     int    // Indicators signalling buy or sell
+	Blocked    string // Why a required or veto indicator blocked the entry, if it did
+}
+
+// newSignalAggregator resolves the indicator names of the configuration; a nil
+// configuration gives the majority vote
+func newSignalAggregator(cfg *config.SignalAggregationConfig) (*signalAggregator, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	a := &signalAggregator{
+		mode:    cfg.ModeName(),
+		weights: make(map[indicators.IndicatorType]float64),
+	}
+	if cfg == nil {
+		return a, nil
+	}
+	a.quorum = cfg.Quorum
+
+	for name, w := range cfg.Weights {
+		t, err := indicators.ParseIndicatorType(name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid weight: %w", err)
+		}
+		a.weights[t] = w
+	}
+	for _, name := range cfg.Required {
+		t, err := indicators.ParseIndicatorType(name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid required indicator: %w", err)
+		}
+		a.required = append(a.required, t)
+	}
+	for _, name := range cfg.Veto {
+		t, err := indicators.ParseIndicatorType(name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid veto indicator: %w", err)
+		}
+		a.veto = append(a.veto, t)
+	}
+
+	return a, nil
+}
+
+// weight returns the vote weight of an indicator type, 1 when not configured
+func (a *signalAggregator) weight(t indicators.IndicatorType) float64 {
+	if w, ok := a.weights[t]; ok {
+		return w
+	}
+	return 1
+}
+
+// score aggregates the results of the configured indicators. Failed
+// indicators count towards the total without voting, as in the majority vote.
+// Entry filters stay out of the vote; their sell signal blocks the entry.
+func (a *signalAggregator) score(configured []indicators.TechnicalIndicator, results map[string]*indicators.IndicatorResult, minConfidence float64) signalScore {
+	var s signalScore
+	var totalWeight, buyWeight, buyStrength float64
+
+	buying := make(map[indicators.IndicatorType]bool)
+	for _, ind := range configured {
+		result := results[ind.GetName()]
+		if isEntryFilter(ind) {
+			if result != nil && result.Error == nil && result.ShouldSell && s.Blocked == "" {
+				s.Blocked = fmt.Sprintf("Filtered by %s sell signal", ind.GetName())
+			}
+			continue
+		}
+
+		t, _ := indicators.TypeOf(ind)
+		w := a.weight(t)
+		totalWeight += w
+		s.Voters++
+
+		if result == nil || result.Error != nil {
+			continue
+		}
+		if result.ShouldBuy {
+			s.BuyVotes++
+			s.Active++
+			buyWeight += w
+			buyStrength += w * result.Strength
+			buying[t] = true
+		} else if result.ShouldSell {
+			s.Active++
+			if a.isVeto(t) && s.Blocked == "" {
+				s.Blocked = fmt.Sprintf("Vetoed by %s sell signal", ind.GetName())
+			}
+		}
+	}
+
+	if s.Voters > 0 {
+		s.Confidence = float64(s.BuyVotes) / float64(s.Voters)
+	}
+	switch a.mode {
+	case config.AggregationModeWeighted:
+		s.Confidence = 0
+		if totalWeight > 0 {
+			s.Confidence = buyWeight / totalWeight
+		}
+	case config.AggregationModeStrength:
+		s.Confidence = 0
+		if totalWeight > 0 {
+			s.Confidence = buyStrength / totalWeight
+		}
+	}
+	if s.Confidence > 1.0 {
+		s.Confidence = 1.0
+	}
+	s.Strength = s.Confidence
+
+	if a.mode == config.AggregationModeQuorum {
+		s.Passed = s.BuyVotes >= a.quorum
+	} else {
+		s.Passed = s.Confidence >= minConfidence
+	}
+
+	if s.Blocked == "" {
+		for _, t := range a.required {
+			if !buying[t] {
+				s.Blocked = fmt.Sprintf("Required %s not signalling buy", t)
+				break
+			}
+		}
+	}
+	if s.Blocked != "" {
+		s.Passed = false
+	}
+
+	return s
+}
+
+// isEntryFilter returns true if the indicator only filters entries
+func isEntryFilter(ind indicators.TechnicalIndicator) bool {
+	filter, ok := ind.(indicators.EntryFilter)
+	return ok && filter.IsEntryFilter()
+}
+
+// isVeto returns true if a sell signal of the indicator type blocks entries
+func (a *signalAggregator) isVeto(t indicators.IndicatorType) bool {
+	for _, v := range a.veto {
+		if v == t {
+			return true
+		}
+	}
+	return false
+}
+
+// describe formats the score for a decision reason, tagged with the mode
+func (a *signalAggregator) describe(s signalScore, minConfidence float64) string {
+	switch a.mode {
+	case config.AggregationModeWeighted, config.AggregationModeStrength:
+		comparison := "≥"
+		if !s.Passed {
+			comparison = "<"
+		}
+		return fmt.Sprintf("[%s]: score %.1f%% %s %.1f%% (%d/%d active)",
+			a.mode, s.Confidence*100, comparison, minConfidence*100, s.BuyVotes, s.Active)
+	case config.AggregationModeQuorum:
+		return fmt.Sprintf("[%s]: %d/%d buy votes (need %d)", a.mode, s.BuyVotes, s.Voters, a.quorum)
+	default:
+		if s.Passed {
+			return fmt.Sprintf("[%s]: %d/%d active", a.mode, s.BuyVotes, s.Active)
+		}
+		return fmt.Sprintf("[%s]: %d/%d active (%.1f%% < %.1f%%)",
+			a.mode, s.BuyVotes, s.Active, s.Confidence*100, minConfidence*100)
+	}
+}
+
+// String returns the aggregation mode with its required and veto indicators
+func (a *signalAggregator) String() string {
+	var parts []string
+	parts = append(parts, a.mode)
+	if a.mode == config.AggregationModeQuorum {
+		parts[0] = fmt.Sprintf("%s %d", a.mode, a.quorum)
+	}
+	if len(a.required) > 0 {
+		parts = append(parts, fmt.Sprintf("required %v", a.required))
+	}
+	if len(a.veto) > 0 {
+		parts = append(parts, fmt.Sprintf("veto %v", a.veto))
+	}
+	return strings.Join(parts, ", ")
+}
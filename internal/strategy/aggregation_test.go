@@ -0,0 +1,58 @@
+package strategy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators/oscillators"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators/trend"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/config"
+)
+
+// ADX filters entries: it must neither add a buy vote nor dilute the others
+func TestAggregatorLeavesEntryFiltersOutOfTheVote(t *testing.T) {
+	rsi, macd, adx := oscillators.NewRSI(14), oscillators.NewMACD(12, 26, 9), trend.NewADX()
+	configured := []indicators.TechnicalIndicator{rsi, macd, adx}
+
+	tests := []struct {
+		mode       string
+		adxSell    bool
+		confidence float64
+		passed     bool
+	}{
+		{config.AggregationModeMajority, false, 0.5, true},
+		{config.AggregationModeWeighted, false, 0.5, true},
+		{config.AggregationModeMajority, true, 0.5, false}, // Strong downtrend blocks the entry
+	}
+
+	for _, tt := range tests {
+		aggregator, err := newSignalAggregator(&config.SignalAggregationConfig{Mode: tt.mode})
+		if err != nil {
+			t.Fatal(err)
+		}
+		results := map[string]*indicators.IndicatorResult{
+			rsi.GetName():  {ShouldBuy: true, Strength: 1},
+			macd.GetName(): {},
+			adx.GetName():  {ShouldSell: tt.adxSell},
+		}
+
+		score := aggregator.score(configured, results, 0.5)
+		if score.Voters != 2 || score.BuyVotes != 1 {
+			t.Errorf("%s: %d/%d votes, want 1/2", tt.mode, score.BuyVotes, score.Voters)
+		}
+		if score.Confidence != tt.confidence || score.Passed != tt.passed {
+			t.Errorf("%s, ADX sell %v: confidence %.2f passed %v, want %.2f %v",
+				tt.mode, tt.adxSell, score.Confidence, score.Passed, tt.confidence, tt.passed)
+		}
+		if tt.adxSell && !strings.Contains(score.Blocked, adx.GetName()) {
+			t.Errorf("blocked reason %q should name ADX", score.Blocked)
+		}
+	}
+}
+
+func TestAggregationRejectsRequiredEntryFilter(t *testing.T) {
+	if _, err := newSignalAggregator(&config.SignalAggregationConfig{Required: []string{"adx"}}); err == nil {
+		t.Error("ADX never votes buy and cannot be required")
+	}
+}
@@ -0,0 +1,233 @@
+package strategy
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators/bands"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators/common"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators/oscillators"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators/trend"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators/volume"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/strategy/spacing"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/config"
+)
+
+func init() {
+	Register(Definition{
+		Name:        DefaultStrategyName,
+		Description: "DCA entries on indicator consensus with configurable spacing, sizing, conditions and take profit",
+		Factory: func(cfg *config.DCAConfig) (Strategy, error) {
+			return newEnhancedDCAFromConfig(cfg)
+		},
+		DCASettings: true,
+	})
+
+	Register(Definition{
+		Name:        "multi_indicator",
+		Description: "Buys on a regime-weighted consensus of RSI(14), SMA(50), Bollinger Bands(20, 2) and MACD(12, 26, 9)",
+		Factory:     newMultiIndicatorFromConfig,
+		Params: []ParamSpec{
+			{
+				Name:        "volatility_threshold",
+				Description: "ATR(14) / price ratio above which the market counts as volatile",
+				Default:     0.05, Min: 0.001, Max: 1,
+				Search: []float64{0.02, 0.03, 0.04, 0.05, 0.06, 0.08},
+			},
+			{
+				Name:        "consensus_threshold",
+				Description: "Weighted indicator score a buy needs (0-1)",
+				Default:     0.6, Min: 0, Max: 1,
+				Search: []float64{0.4, 0.5, 0.6, 0.7, 0.8},
+			},
+		},
+	})
+}
+
+// newEnhancedDCAFromConfig builds the enhanced DCA strategy with its spacing,
+// sizing, conditions and indicators as configured
+func newEnhancedDCAFromConfig(cfg *config.DCAConfig) (*EnhancedDCAStrategy, error) {
+	// Initialize Enhanced DCA strategy with base trading amount
+	dca := NewEnhancedDCAStrategy(cfg.BaseAmount)
+	if !cfg.LiveDefaults {
+		// The live bot keeps the strategy's built-in 3.0 cap
+		dca.SetMaxMultiplier(cfg.MaxMultiplier)
+	}
+
+	// Configure DCA spacing strategy (required for all configurations)
+	if cfg.DCASpacing == nil {
+		return nil, fmt.Errorf("no DCA spacing strategy configured - please specify dca_spacing in your configuration")
+	}
+
+	spacingConfig := spacing.SpacingConfig{
+		Strategy:   cfg.DCASpacing.Strategy,
+		Parameters: cfg.DCASpacing.Parameters,
+	}
+
+	spacingStrategy, err := spacing.CreateSpacingStrategy(spacingConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create spacing strategy: %w", err)
+	}
+
+	if err := spacingStrategy.ValidateConfig(); err != nil {
+		return nil, fmt.Errorf("invalid spacing strategy configuration: %w", err)
+	}
+
+	dca.SetSpacingStrategy(spacingStrategy)
+	if err := dca.CheckSpacingWindow(cfg.WindowSize); err != nil {
+		return nil, err
+	}
+
+	// Configure dynamic take profit if specified; TP modifiers otherwise scale the fixed TP
+	dca.SetCommission(cfg.Commission)
+	if dynamicTP := cfg.Conditions.DynamicTPFor(cfg.DynamicTP, cfg.TPPercent); dynamicTP != nil {
+		dca.SetDynamicTPConfig(dynamicTP)
+		if err := dca.validateDynamicTPConfig(); err != nil {
+			return nil, fmt.Errorf("invalid dynamic TP configuration: %w", err)
+		}
+	}
+
+	// Configure ladder sizing against the initial balance
+	dca.SetPositionSizing(cfg.PositionSizing, cfg.InitialBalance)
+
+	// Pre-place later DCA levels as limit orders if the grid is enabled
+	dca.SetLimitGrid(cfg.LimitGrid.GridLevels())
+
+	// Combine indicator signals as configured (majority vote when omitted)
+	if err := dca.SetSignalAggregation(cfg.SignalAggregation); err != nil {
+		return nil, err
+	}
+
+	// Filter entries and scale the take profit with expression conditions
+	if err := dca.SetConditions(cfg.Conditions); err != nil {
+		return nil, err
+	}
+	if err := dca.CheckConditionWindow(cfg.WindowSize); err != nil {
+		return nil, err
+	}
+
+	// Confirm entries on higher timeframes
+	if err := dca.SetTimeframes(cfg.Timeframes); err != nil {
+		return nil, err
+	}
+
+	// Indicator inclusion map
+	include := make(map[string]bool)
+	for _, name := range cfg.Indicators {
+		include[strings.ToLower(strings.TrimSpace(name))] = true
+	}
+
+	// Instantiate indicators in a fixed order so results are deterministic
+	if include["rsi"] {
+		rsi := oscillators.NewRSI(cfg.RSIPeriod)
+		rsi.SetOversold(cfg.RSIOversold)
+		rsi.SetOverbought(cfg.RSIOverbought)
+		dca.AddIndicator(rsi)
+	}
+	if include["macd"] {
+		dca.AddIndicator(oscillators.NewMACD(cfg.MACDFast, cfg.MACDSlow, cfg.MACDSignal))
+	}
+	if include["bb"] || include["bollinger"] {
+		if cfg.LiveDefaults {
+			dca.AddIndicator(bands.NewBollingerBands(cfg.BBPeriod, cfg.BBStdDev))
+		} else {
+			dca.AddIndicator(bands.NewBollingerBandsEMA(cfg.BBPeriod, cfg.BBStdDev))
+		}
+	}
+	if include["ema"] {
+		dca.AddIndicator(common.NewEMA(cfg.EMAPeriod))
+	}
+	if include["sma"] {
+		dca.AddIndicator(common.NewSMA(cfg.EMAPeriod))
+	}
+	if include["hullma"] || include["hull_ma"] {
+		dca.AddIndicator(trend.NewHullMA(cfg.HullMAPeriod))
+	}
+	if include["supertrend"] || include["st"] {
+		dca.AddIndicator(trend.NewSuperTrendWithParams(cfg.SuperTrendPeriod, cfg.SuperTrendMultiplier))
+	}
+	if include["mfi"] {
+		mfi := oscillators.NewMFIWithPeriod(cfg.MFIPeriod)
+		mfi.SetOversold(cfg.MFIOversold)
+		mfi.SetOverbought(cfg.MFIOverbought)
+		dca.AddIndicator(mfi)
+	}
+	if include["keltner"] || include["keltner_channels"] || include["kc"] {
+		dca.AddIndicator(bands.NewKeltnerChannelsCustom(cfg.KeltnerPeriod, cfg.KeltnerMultiplier))
+	}
+	if include["wavetrend"] || include["wt"] {
+		wavetrend := oscillators.NewWaveTrendCustom(cfg.WaveTrendN1, cfg.WaveTrendN2)
+		wavetrend.SetOverbought(cfg.WaveTrendOverbought)
+		wavetrend.SetOversold(cfg.WaveTrendOversold)
+		dca.AddIndicator(wavetrend)
+	}
+	if include["obv"] {
+		if cfg.LiveDefaults {
+			dca.AddIndicator(volume.NewOBVWithThreshold(cfg.OBVTrendThreshold))
+		} else {
+			dca.AddIndicator(volume.NewOBV())
+		}
+	}
+	if include["stochrsi"] || include["stochastic_rsi"] || include["stoch_rsi"] {
+		if cfg.LiveDefaults {
+			dca.AddIndicator(oscillators.NewStochasticRSIWithThresholds(cfg.StochasticRSIPeriod,
+				cfg.StochasticRSIOverbought, cfg.StochasticRSIOversold))
+		} else {
+			dca.AddIndicator(oscillators.NewStochasticRSI())
+		}
+	}
+	if include["adx"] || include["dmi"] {
+		dca.AddIndicator(trend.NewADXWithParams(cfg.ADXPeriod, cfg.ADXThreshold))
+	}
+	if include["ichimoku"] || include["ichi"] {
+		dca.AddIndicator(trend.NewIchimokuWithParams(cfg.IchimokuTenkan, cfg.IchimokuKijun, cfg.IchimokuSenkouB))
+	}
+	if include["donchian"] || include["donchian_channels"] || include["dc"] {
+		dca.AddIndicator(bands.NewDonchianChannelsWithPeriod(cfg.DonchianPeriod))
+	}
+	if include["anchored_vwap"] || include["avwap"] || include["vwap"] {
+		dca.AddIndicator(volume.NewAnchoredVWAPWithParams(cfg.AVWAPAnchor, cfg.AVWAPAnchorLookback, cfg.AVWAPBandMultiplier))
+	}
+	if include["cmf"] || include["chaikin"] {
+		dca.AddIndicator(volume.NewCMFWithParams(cfg.CMFPeriod, cfg.CMFThreshold))
+	}
+	if include["volume_profile"] || include["vp"] || include["vpvr"] {
+		dca.AddIndicator(volume.NewVolumeProfileWithParams(cfg.VolumeProfileLookback, cfg.VolumeProfileBins,
+			cfg.VolumeProfileNodeFactor, cfg.VolumeProfileProximity))
+	}
+
+	return dca, nil
+}
+
+// newMultiIndicatorFromConfig builds the multi-indicator strategy. Its
+// indicators are fixed, so DCA settings it would ignore are rejected.
+func newMultiIndicatorFromConfig(cfg *config.DCAConfig) (Strategy, error) {
+	var unsupported []string
+	if cfg.PositionSizing != nil {
+		unsupported = append(unsupported, "position_sizing")
+	}
+	if cfg.LimitGrid.IsEnabled() {
+		unsupported = append(unsupported, "limit_grid")
+	}
+	if cfg.SignalAggregation != nil {
+		unsupported = append(unsupported, "signal_aggregation")
+	}
+	if !cfg.Conditions.IsEmpty() {
+		unsupported = append(unsupported, "conditions")
+	}
+	if !cfg.Timeframes.IsEmpty() {
+		unsupported = append(unsupported, "timeframes")
+	}
+	if cfg.DynamicTP != nil {
+		unsupported = append(unsupported, "dynamic_tp")
+	}
+	if len(unsupported) > 0 {
+		return nil, fmt.Errorf("settings not supported by this strategy: %s", strings.Join(unsupported, ", "))
+	}
+
+	m := NewMultiIndicatorStrategy()
+	m.SetBaseAmount(cfg.BaseAmount)
+	m.SetVolatilityThreshold(cfg.StrategyParams["volatility_threshold"])
+	m.SetConsensusThreshold(cfg.StrategyParams["consensus_threshold"])
+	return m, nil
+}
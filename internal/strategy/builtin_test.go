@@ -0,0 +1,85 @@
+package strategy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/config"
+)
+
+func builtinTestConfig(liveDefaults bool) *config.DCAConfig {
+	cfg := config.NewDefaultDCAConfig()
+	cfg.Indicators = []string{"bb"}
+	cfg.MaxMultiplier = 5.0
+	cfg.LiveDefaults = liveDefaults
+	cfg.DCASpacing = &config.DCASpacingConfig{
+		Strategy:   "fixed",
+		Parameters: map[string]interface{}{"base_threshold": 0.01, "threshold_multiplier": 1.15},
+	}
+	return cfg
+}
+
+// The live bot builds indicators and sizing as it did before the registry
+func TestEnhancedDCABuilderKeepsLiveDefaults(t *testing.T) {
+	tests := []struct {
+		name          string
+		liveDefaults  bool
+		bollinger     string
+		maxMultiplier float64
+	}{
+		{name: "backtest", liveDefaults: false, bollinger: "Bollinger Bands (EMA-based)", maxMultiplier: 5.0},
+		{name: "live", liveDefaults: true, bollinger: "Bollinger Bands (SMA-based)", maxMultiplier: 3.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dca, err := newEnhancedDCAFromConfig(builtinTestConfig(tt.liveDefaults))
+			if err != nil {
+				t.Fatal(err)
+			}
+			built := dca.indicatorManager.GetIndicators()
+			if len(built) != 1 || built[0].GetName() != tt.bollinger {
+				t.Errorf("expected %s, got %v", tt.bollinger, built)
+			}
+			if dca.maxMultiplier != tt.maxMultiplier {
+				t.Errorf("expected max multiplier %.1f, got %.1f", tt.maxMultiplier, dca.maxMultiplier)
+			}
+		})
+	}
+}
+
+// Spacing strategies only see the window, so longer lookbacks are rejected
+func TestEnhancedDCABuilderChecksSpacingHistory(t *testing.T) {
+	tests := []struct {
+		name    string
+		spacing string
+		params  map[string]interface{}
+		window  int
+		wantErr bool
+	}{
+		{name: "drawdown default", spacing: "drawdown_percentile", window: config.DefaultWindowSize},
+		{name: "drawdown beyond window", spacing: "drawdown_percentile", params: map[string]interface{}{"lookback": 500}, window: 100, wantErr: true},
+		{name: "drawdown within a larger window", spacing: "drawdown_percentile", params: map[string]interface{}{"lookback": 500}, window: 500},
+		{name: "swing low default", spacing: "swing_low", window: config.DefaultWindowSize},
+		{name: "swing low beyond window", spacing: "swing_low", params: map[string]interface{}{"lookback": 150}, window: 100, wantErr: true},
+		{name: "keltner band beyond window", spacing: "band", params: map[string]interface{}{"band_type": "keltner", "period": 30}, window: 50, wantErr: true},
+		{name: "fixed reads no history", spacing: "fixed", window: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.NewDefaultDCAConfig()
+			cfg.Indicators = []string{"rsi"}
+			cfg.WindowSize = tt.window
+			cfg.DCASpacing = &config.DCASpacingConfig{Strategy: tt.spacing, Parameters: tt.params}
+
+			_, err := newEnhancedDCAFromConfig(cfg)
+			if tt.wantErr && (err == nil || !strings.Contains(err.Error(), "candles of history")) {
+				t.Fatalf("expected a history error, got %v", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,363 @@
+package strategy
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators/bands"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators/base"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators/common"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators/oscillators"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators/trend"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators/volume"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/config"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/expr"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
+)
+
+// calculator is the part of an indicator the conditions need; base.ATR has
+// no signals, so the full TechnicalIndicator interface would exclude it
+type calculator interface {
+	Calculate(data []types.OHLCV) (float64, error)
+	GetRequiredPeriods() int
+	ResetState()
+}
+
+// boundIndicator is an indicator instance shared by the calls with the same
+// name, parameters and offset, with its latest value. An offset instance is
+// fed the data without its last offset candles.
+type boundIndicator struct {
+	indicator calculator
+	offset    int
+	value     float64
+	err       error
+}
+
+// tpModifier scales the take profit while its condition holds
+type tpModifier struct {
+	when       *expr.Expression
+	multiplier float64
+}
+
+// conditionSet evaluates the expression conditions of the strategy. Each
+// referenced indicator is calculated once per candle, like the indicator
+// manager does for the signal indicators.
+type conditionSet struct {
+	entry       []*expr.Expression
+	veto        []*expr.Expression
+	tpModifiers []tpModifier
+
+	bound         map[string]*boundIndicator // Keyed by expr.Call.Key()
+	order         []*boundIndicator          // Calculation order, as referenced
+	lastTimestamp time.Time
+	candle        types.OHLCV
+	data          []types.OHLCV
+	dcaLevel      int
+	avgEntryPrice float64
+	mutex         sync.Mutex
+}
+
+// conditionCheck is the outcome of the entry and veto conditions on one candle
+type conditionCheck struct {
+	Failed string   // First entry condition that does not hold
+	Vetoed string   // First veto condition that holds
+	Trace  []string // Every condition and comparison with its outcome
+}
+
+// newConditionSet parses the conditions and creates the indicators they
+// reference; empty conditions give a nil set
+func newConditionSet(cfg *config.ConditionsConfig) (*conditionSet, error) {
+	if cfg.IsEmpty() {
+		return nil, nil
+	}
+
+	c := &conditionSet{bound: make(map[string]*boundIndicator)}
+	for _, src := range cfg.Entry {
+		e, err := c.parse(src)
+		if err != nil {
+			return nil, fmt.Errorf("entry condition: %w", err)
+		}
+		c.entry = append(c.entry, e)
+	}
+	for _, src := range cfg.Veto {
+		e, err := c.parse(src)
+		if err != nil {
+			return nil, fmt.Errorf("veto condition: %w", err)
+		}
+		c.veto = append(c.veto, e)
+	}
+	for _, m := range cfg.TPModifiers {
+		e, err := c.parse(m.When)
+		if err != nil {
+			return nil, fmt.Errorf("tp modifier: %w", err)
+		}
+		if m.Multiplier <= 0 {
+			return nil, fmt.Errorf("tp modifier %q: multiplier must be positive, got %.4f", m.When, m.Multiplier)
+		}
+		c.tpModifiers = append(c.tpModifiers, tpModifier{when: e, multiplier: m.Multiplier})
+	}
+
+	return c, nil
+}
+
+// parse parses a condition and binds its indicator calls
+func (c *conditionSet) parse(src string) (*expr.Expression, error) {
+	e, err := expr.ParseCondition(src)
+	if err != nil {
+		return nil, err
+	}
+	for _, call := range e.Calls() {
+		if _, ok := c.bound[call.Key()]; ok {
+			continue
+		}
+		b := &boundIndicator{indicator: newConditionIndicator(call), offset: call.Offset}
+		c.bound[call.Key()] = b
+		c.order = append(c.order, b)
+	}
+	return e, nil
+}
+
+// newConditionIndicator creates the indicator for a call; the parser has
+// already checked the name and arguments
+func newConditionIndicator(call *expr.Call) calculator {
+	arg := func(i int) float64 { return call.Args[i] }
+	period := func(i int) int { return int(call.Args[i]) }
+
+	switch call.Name {
+	case "rsi":
+		return oscillators.NewRSI(period(0))
+	case "sma":
+		return common.NewSMA(period(0))
+	case "ema":
+		return common.NewEMA(period(0))
+	case "hma":
+		return trend.NewHullMA(period(0))
+	case "atr":
+		return base.NewATR(period(0))
+	case "mfi":
+		return oscillators.NewMFIWithPeriod(period(0))
+	case "cmf":
+		return volume.NewCMFWithParams(period(0), volume.DefaultCMFThreshold)
+	case "stochrsi":
+		return oscillators.NewStochasticRSIWithPeriod(period(0))
+	case "obv":
+		return volume.NewOBV()
+	case "supertrend":
+		return trend.NewSuperTrendWithParams(period(0), arg(1))
+	case "adx":
+		return trend.NewADXWithParams(period(0), trend.DefaultADXThreshold)
+	case "wavetrend":
+		return oscillators.NewWaveTrendCustom(period(0), period(1))
+	case "macd":
+		return oscillators.NewMACD(period(0), period(1), period(2))
+	case "bb":
+		// Classic SMA-based bands
+		return bands.NewBollingerBands(period(0), arg(1))
+	case "keltner":
+		return bands.NewKeltnerChannelsCustom(period(0), arg(1))
+	case "donchian":
+		return bands.NewDonchianChannelsWithPeriod(period(0))
+	case "ichimoku":
+		return trend.NewIchimokuWithParams(period(0), period(1), period(2))
+	default:
+		panic(fmt.Sprintf("no indicator for expression function %s", call.Name))
+	}
+}
+
+// update calculates every bound indicator once per candle and records the
+// cycle state the fields read
+func (c *conditionSet) update(candle types.OHLCV, data []types.OHLCV, dcaLevel int, avgEntryPrice float64) {
+	c.candle = candle
+	c.data = data
+	c.dcaLevel = dcaLevel
+	c.avgEntryPrice = avgEntryPrice
+
+	if candle.Timestamp.Equal(c.lastTimestamp) {
+		return
+	}
+	for _, b := range c.order {
+		if b.offset >= len(data) {
+			b.value, b.err = 0, fmt.Errorf("not enough history for an offset of %d", b.offset)
+			continue
+		}
+		b.value, b.err = b.indicator.Calculate(data[:len(data)-b.offset])
+	}
+	c.lastTimestamp = candle.Timestamp
+}
+
+// check evaluates the entry and veto conditions. A condition that cannot be
+// evaluated yet (e.g. an indicator still warming up) fails closed: it blocks
+// entries whether it is an entry or a veto condition.
+func (c *conditionSet) check(candle types.OHLCV, data []types.OHLCV, dcaLevel int, avgEntryPrice float64) conditionCheck {
+	var result conditionCheck
+	if c == nil {
+		return result
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.update(candle, data, dcaLevel, avgEntryPrice)
+
+	for _, e := range c.veto {
+		holds, ok := c.eval("veto", e, &result.Trace)
+		if (holds || !ok) && result.Vetoed == "" {
+			result.Vetoed = e.String()
+		}
+	}
+	for _, e := range c.entry {
+		holds, ok := c.eval("entry", e, &result.Trace)
+		if (!holds || !ok) && result.Failed == "" {
+			result.Failed = e.String()
+		}
+	}
+	return result
+}
+
+// tpMultiplier returns the product of the multipliers of the TP modifiers
+// that hold, 1 when none does, with the evaluation trace
+func (c *conditionSet) tpMultiplier(candle types.OHLCV, data []types.OHLCV, dcaLevel int, avgEntryPrice float64) (float64, []string) {
+	multiplier := 1.0
+	if c == nil || len(c.tpModifiers) == 0 {
+		return multiplier, nil
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.update(candle, data, dcaLevel, avgEntryPrice)
+
+	var trace []string
+	for _, m := range c.tpModifiers {
+		if holds, ok := c.eval(fmt.Sprintf("tp ×%.2f", m.multiplier), m.when, &trace); holds && ok {
+			multiplier *= m.multiplier
+		}
+	}
+	return multiplier, trace
+}
+
+// eval evaluates one condition, appending a line with its outcome followed
+// by its comparisons to trace; ok is false if it could not be evaluated
+func (c *conditionSet) eval(role string, e *expr.Expression, trace *[]string) (holds, ok bool) {
+	result, err := e.Eval(c)
+	if err != nil {
+		*trace = append(*trace, fmt.Sprintf("%s: %s → unavailable (%v)", role, e, err))
+		return false, false
+	}
+	*trace = append(*trace, fmt.Sprintf("%s: %s → %t", role, e, result.Bool))
+	for _, line := range result.Trace {
+		*trace = append(*trace, "  "+line)
+	}
+	return result.Bool, true
+}
+
+// requiredPeriods returns the candles the slowest referenced indicator needs,
+// including its offset
+func (c *conditionSet) requiredPeriods() int {
+	required := 0
+	if c == nil {
+		return required
+	}
+	for _, b := range c.order {
+		if n := b.indicator.GetRequiredPeriods() + b.offset; n > required {
+			required = n
+		}
+	}
+	return required
+}
+
+// reset clears the state of the bound indicators for a new period
+func (c *conditionSet) reset() {
+	if c == nil {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, b := range c.order {
+		b.indicator.ResetState()
+		b.value, b.err = 0, nil
+	}
+	c.lastTimestamp = time.Time{}
+	c.data = nil
+}
+
+// Field implements expr.Env; the parser only allows offsets on price fields
+func (c *conditionSet) Field(name string, offset int) (float64, error) {
+	candle := c.candle
+	if offset > 0 {
+		if offset >= len(c.data) {
+			return 0, fmt.Errorf("not enough history for an offset of %d", offset)
+		}
+		candle = c.data[len(c.data)-1-offset]
+	}
+
+	switch name {
+	case expr.FieldOpen:
+		return candle.Open, nil
+	case expr.FieldHigh:
+		return candle.High, nil
+	case expr.FieldLow:
+		return candle.Low, nil
+	case expr.FieldClose:
+		return candle.Close, nil
+	case expr.FieldVolume:
+		return candle.Volume, nil
+	case expr.FieldDCALevel:
+		return float64(c.dcaLevel), nil
+	case expr.FieldPctFromAvg:
+		if c.avgEntryPrice <= 0 {
+			return 0, nil
+		}
+		return (c.candle.Close - c.avgEntryPrice) / c.avgEntryPrice * 100, nil
+	default:
+		return 0, fmt.Errorf("unknown field")
+	}
+}
+
+// Indicator implements expr.Env, reading the requested output of the bound
+// indicator after this candle's calculation
+func (c *conditionSet) Indicator(call *expr.Call) (float64, error) {
+	b, ok := c.bound[call.Key()]
+	if !ok {
+		return 0, fmt.Errorf("indicator not bound")
+	}
+	if b.err != nil {
+		return 0, b.err
+	}
+
+	switch ind := b.indicator.(type) {
+	case *bands.BollingerBands:
+		upper, middle, lower, percentB := ind.GetBandsWithPercentB()
+		return pickMember(call.Member, map[string]float64{"upper": upper, "middle": middle, "lower": lower, "percent_b": percentB})
+	case *bands.KeltnerChannels:
+		upper, middle, lower := ind.GetChannels()
+		return pickMember(call.Member, map[string]float64{"upper": upper, "middle": middle, "lower": lower})
+	case *bands.DonchianChannels:
+		upper, middle, lower := ind.GetChannels()
+		return pickMember(call.Member, map[string]float64{"upper": upper, "middle": middle, "lower": lower})
+	case *oscillators.MACD:
+		macd, signal, histogram := ind.GetLastValues()
+		return pickMember(call.Member, map[string]float64{"": macd, "macd": macd, "signal": signal, "histogram": histogram})
+	case *trend.ADX:
+		plusDI, minusDI := ind.GetDI()
+		return pickMember(call.Member, map[string]float64{"": ind.GetADX(), "adx": ind.GetADX(), "plus_di": plusDI, "minus_di": minusDI})
+	case *oscillators.WaveTrend:
+		wt1, wt2 := ind.GetWaves()
+		return pickMember(call.Member, map[string]float64{"": wt1, "wt1": wt1, "wt2": wt2})
+	case *trend.Ichimoku:
+		tenkan, kijun := ind.GetLines()
+		senkouA, senkouB := ind.GetCloud()
+		return pickMember(call.Member, map[string]float64{"tenkan": tenkan, "kijun": kijun, "senkou_a": senkouA, "senkou_b": senkouB})
+	default:
+		return b.value, nil
+	}
+}
+
+// pickMember returns the named output of a multi-output indicator
+func pickMember(member string, outputs map[string]float64) (float64, error) {
+	v, ok := outputs[strings.ToLower(member)]
+	if !ok {
+		return 0, fmt.Errorf("unknown member .%s", member)
+	}
+	return v, nil
+}
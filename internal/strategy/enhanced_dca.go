@@ -23,10 +23,12 @@ type EnhancedDCAStrategy struct {
 	// Trading parameters
 	baseAmount       float64   // Base investment amount per DCA entry
 	maxMultiplier    float64   // Maximum position size multiplier
+	commission       float64   // Commission rate per fill, the time-decay TP floor covers two
 	minConfidence    float64   // Minimum confidence threshold for trade execution
 	
 	// State tracking
 	lastTradeTime    time.Time // Last trade execution timestamp
+	cycleStartTime   time.Time // First entry of the open cycle (zero when flat)
 	lastEntryPrice   float64   // Previous entry price for DCA spacing calculations
 	dcaLevel         int       // Current DCA level (0=first entry, 1+=subsequent)
 	
@@ -34,6 +36,18 @@ type EnhancedDCAStrategy struct {
 	spacingStrategy  spacing.DCASpacingStrategy // Configurable DCA entry spacing logic
 	atrCalculator    *base.ATR                  // Average True Range for volatility analysis
 	dynamicTPConfig  *config.DynamicTPConfig    // Dynamic take profit configuration
+	positionSizing   *config.PositionSizingConfig // Entry sizing mode (nil = signal sizing)
+	ladderAmounts    []float64                  // Amount per DCA level for ladder sizing modes
+	gridLevels       int                        // DCA levels pre-placed as limit buys (0 = market entries)
+	aggregation      *config.SignalAggregationConfig // Signal aggregation (nil = majority vote)
+	aggregator       *signalAggregator          // Resolved aggregation rules
+	conditions       *config.ConditionsConfig   // Expression conditions (nil = none)
+	conditionSet     *conditionSet              // Parsed conditions with their indicators
+	avgEntryPrice    float64                    // Average entry of the open position (0 = flat)
+	tpTrace          []string                   // Trace of the last take profit modifier evaluation
+	timeframes       config.Timeframes          // Higher timeframe confirmations (nil = none)
+	timeframeFilters []*timeframeFilter         // Parsed timeframe conditions with their candles
+	decisionContext  DecisionContext            // DCA state behind the last decision
 }
 
 // NewEnhancedDCAStrategy creates a new enhanced DCA strategy instance
@@ -48,6 +62,7 @@ func NewEnhancedDCAStrategy(baseAmount float64) *EnhancedDCAStrategy {
 		spacingStrategy:  nil, // Will be set by orchestrator
 		atrCalculator:    base.NewATR(14), // Default 14-period ATR (will be updated after config)
 		dynamicTPConfig:  nil, // Will be set by orchestrator if dynamic TP is enabled
+		aggregator:       &signalAggregator{mode: config.AggregationModeMajority},
 	}
 }
 
@@ -69,11 +84,170 @@ func (s *EnhancedDCAStrategy) SetDynamicTPConfig(dynamicTPConfig *config.Dynamic
 	s.synchronizeATRPeriod()
 }
 
+// SetCommission sets the commission rate per fill
+func (s *EnhancedDCAStrategy) SetCommission(commission float64) {
+	s.commission = commission
+}
+
 // GetDynamicTPConfig returns the current dynamic TP configuration
 func (s *EnhancedDCAStrategy) GetDynamicTPConfig() *config.DynamicTPConfig {
 	return s.dynamicTPConfig
 }
 
+// SetPositionSizing sets the entry sizing mode. For ladder modes the amount of
+// every DCA level is pre-computed against balance (clipped if the budget policy
+// says so) and the resulting ladder budget is returned; signal sizing returns nil.
+func (s *EnhancedDCAStrategy) SetPositionSizing(sizing *config.PositionSizingConfig, balance float64) *config.LadderBudget {
+	s.positionSizing = sizing
+	s.ladderAmounts = nil
+
+	budget := sizing.LadderBudget(s.baseAmount, balance)
+	if budget != nil {
+		s.ladderAmounts = budget.Amounts
+	}
+	return budget
+}
+
+// GetPositionSizing returns the current sizing configuration (nil = signal sizing)
+func (s *EnhancedDCAStrategy) GetPositionSizing() *config.PositionSizingConfig {
+	return s.positionSizing
+}
+
+// SetSignalAggregation sets how indicator signals are combined into the buy
+// confidence; nil restores the majority vote
+func (s *EnhancedDCAStrategy) SetSignalAggregation(aggregation *config.SignalAggregationConfig) error {
+	aggregator, err := newSignalAggregator(aggregation)
+	if err != nil {
+		return fmt.Errorf("invalid signal aggregation: %w", err)
+	}
+	s.aggregation = aggregation
+	s.aggregator = aggregator
+	return nil
+}
+
+// GetSignalAggregation returns the signal aggregation configuration (nil = majority vote)
+func (s *EnhancedDCAStrategy) GetSignalAggregation() *config.SignalAggregationConfig {
+	return s.aggregation
+}
+
+// SetConditions sets the expression entry filters, vetoes and take profit
+// modifiers; nil removes them
+func (s *EnhancedDCAStrategy) SetConditions(conditions *config.ConditionsConfig) error {
+	set, err := newConditionSet(conditions)
+	if err != nil {
+		return fmt.Errorf("invalid conditions: %w", err)
+	}
+	s.conditions = conditions
+	s.conditionSet = set
+	return nil
+}
+
+// GetConditions returns the expression conditions (nil = none)
+func (s *EnhancedDCAStrategy) GetConditions() *config.ConditionsConfig {
+	return s.conditions
+}
+
+// CheckConditionWindow returns an error if the indicators of the conditions
+// need more candles than the analysis window holds; such a condition could
+// never be evaluated and would block every entry
+func (s *EnhancedDCAStrategy) CheckConditionWindow(windowSize int) error {
+	if required := s.conditionSet.requiredPeriods(); required > windowSize {
+		return fmt.Errorf("conditions need %d candles of history but the window is %d", required, windowSize)
+	}
+	return nil
+}
+
+// CheckSpacingWindow returns an error if the spacing strategy reads more
+// candles of history than the window holds, which would silently shorten it
+func (s *EnhancedDCAStrategy) CheckSpacingWindow(windowSize int) error {
+	reader, ok := s.spacingStrategy.(spacing.HistoryReader)
+	if !ok {
+		return nil
+	}
+	if required := reader.GetRequiredPeriods(); required > windowSize {
+		return fmt.Errorf("%s spacing needs %d candles of history but the window is %d", s.spacingStrategy.GetName(), required, windowSize)
+	}
+	return nil
+}
+
+// SetAverageEntryPrice sets the average entry price of the open position,
+// read by the pct_from_avg condition field
+func (s *EnhancedDCAStrategy) SetAverageEntryPrice(price float64) {
+	s.avgEntryPrice = price
+}
+
+// SetLimitGrid enables pre-placed limit-order DCA with levels resting orders;
+// 0 restores market entries on signal
+func (s *EnhancedDCAStrategy) SetLimitGrid(levels int) {
+	if levels < 0 {
+		levels = 0
+	}
+	s.gridLevels = levels
+}
+
+// IsGridEnabled returns true if DCA levels after the first entry are pre-placed as limit buys
+func (s *EnhancedDCAStrategy) IsGridEnabled() bool {
+	return s.gridLevels > 0
+}
+
+// PlanGrid computes the resting limit buys for the next DCA levels. Each level
+// is priced by the spacing strategy below the previous one, starting from the
+// last entry price. Ladder sizing modes use their level amounts and stop at the
+// end of the ladder; signal sizing uses the base amount since no signal is
+// available when a resting order fills.
+func (s *EnhancedDCAStrategy) PlanGrid(data []types.OHLCV) []GridOrder {
+	if s.gridLevels == 0 || s.dcaLevel == 0 || s.lastEntryPrice <= 0 || len(data) == 0 {
+		return nil
+	}
+
+	currentCandle := data[len(data)-1]
+	atrValue := 0.0
+	if atr, err := s.atrCalculator.Calculate(data); err == nil {
+		atrValue = atr
+	}
+
+	orders := make([]GridOrder, 0, s.gridLevels)
+	anchor := s.lastEntryPrice
+	for i := 0; i < s.gridLevels; i++ {
+		level := s.dcaLevel + i
+		if s.ladderAmounts != nil && level >= len(s.ladderAmounts) {
+			break // Ladder exhausted
+		}
+
+		threshold := 0.01 // Same fallback as calculateCurrentThreshold
+		if s.spacingStrategy != nil {
+			threshold = s.spacingStrategy.CalculateThreshold(level, &spacing.MarketContext{
+				CurrentPrice:   currentCandle.Close,
+				LastEntryPrice: anchor,
+				ATR:            atrValue,
+				CurrentCandle:  currentCandle,
+				RecentCandles:  data,
+				Timestamp:      currentCandle.Timestamp,
+			})
+		}
+		if threshold <= 0 || threshold >= 1 {
+			break
+		}
+
+		amount := s.baseAmount
+		if s.ladderAmounts != nil {
+			amount = s.ladderAmounts[level]
+		}
+
+		price := anchor * (1 - threshold)
+		orders = append(orders, GridOrder{Level: level, Price: price, Amount: amount})
+		anchor = price
+	}
+	return orders
+}
+
+// RecordGridFill re-anchors the grid at a filled limit buy and advances the DCA level
+func (s *EnhancedDCAStrategy) RecordGridFill(price float64, t time.Time) {
+	s.lastEntryPrice = price
+	s.lastTradeTime = t
+	s.dcaLevel++
+}
+
 // synchronizeATRPeriod ensures both DCA spacing and dynamic TP use the same ATR period
 // Priority: DCA spacing atr_period > Dynamic TP ATRPeriod > Default 14
 func (s *EnhancedDCAStrategy) synchronizeATRPeriod() {
@@ -110,18 +284,21 @@ func (s *EnhancedDCAStrategy) ShouldExecuteTrade(data []types.OHLCV) (*TradeDeci
 
 	currentCandle := data[len(data)-1]
 	currentPrice := currentCandle.Close
+	s.recordDecisionContext(currentPrice)
 
 	// Process all indicators in batch (major optimization)
 	results := s.indicatorManager.ProcessCandle(currentCandle, data)
 	
+	// Expression conditions are evaluated on every candle so their indicators
+	// stay current and every decision carries the trace
+	conditions := s.conditionSet.check(currentCandle, data, s.dcaLevel, s.avgEntryPrice)
+	s.checkTimeframes(&conditions)
+	
 	// Check if we have any indicators configured
 	if len(results) == 0 {
-		return &TradeDecision{Action: ActionHold, Reason: "No indicators configured"}, nil
+		return &TradeDecision{Action: ActionHold, Reason: "No indicators configured", Trace: conditions.Trace}, nil
 	}
 
-	// Efficiently count signals using batch results
-	buySignals, sellSignals, _, _ := s.indicatorManager.CountActiveSignals(results)
-	
 	// Track failed indicators for debugging
 	failedCount := 0
 	workingCount := 0
@@ -146,24 +323,64 @@ func (s *EnhancedDCAStrategy) ShouldExecuteTrade(data []types.OHLCV) (*TradeDeci
 	// Get total configured indicators (always base calculations on this)
 	totalConfiguredIndicators := s.GetIndicatorCount()
 	workingIndicatorsCount := len(results) - failedCount
-	activeSignals := buySignals + sellSignals
 	
 	// If no indicators are working, hold
 	if workingIndicatorsCount == 0 || totalConfiguredIndicators == 0 {
 		return &TradeDecision{
 			Action: ActionHold, 
 			Reason: fmt.Sprintf("No working indicators (%d failed out of %d total)", failedCount, totalConfiguredIndicators),
+			Trace:  conditions.Trace,
 		}, nil
 	}
 	
-	// Calculate confidence based on ALL configured indicators (not just active signals)
-	// Ensure confidence never exceeds 1.0 to prevent edge cases
-	confidence := float64(buySignals) / float64(totalConfiguredIndicators)
-	if confidence > 1.0 {
-		confidence = 1.0
+	// Aggregate signals across ALL configured indicators (not just active signals)
+	score := s.aggregator.score(s.indicatorManager.GetIndicators(), results, s.minConfidence)
+	confidence := score.Confidence
+
+	// In grid mode only the first entry is taken on signal; later levels rest
+	// on the book as limit orders placed via PlanGrid
+	if s.gridLevels > 0 && s.dcaLevel > 0 {
+		return &TradeDecision{
+			Action: ActionHold,
+			Reason: fmt.Sprintf("DCA levels resting as limit orders (DCA Level %d)", s.dcaLevel),
+			Trace:  conditions.Trace,
+		}, nil
+	}
+
+	if score.Blocked != "" {
+		return &TradeDecision{
+			Action: ActionHold,
+			Reason: fmt.Sprintf("%s [%s]", score.Blocked, s.aggregator.mode),
+			Trace:  conditions.Trace,
+		}, nil
+	}
+
+	if conditions.Vetoed != "" {
+		return &TradeDecision{
+			Action: ActionHold,
+			Reason: "Vetoed by condition: " + conditions.Vetoed,
+			Trace:  conditions.Trace,
+		}, nil
+	}
+
+	if score.Passed && conditions.Failed != "" {
+		return &TradeDecision{
+			Action: ActionHold,
+			Reason: "Entry condition not met: " + conditions.Failed,
+			Trace:  conditions.Trace,
+		}, nil
+	}
+
+	if score.Passed {
+	// Ladder sizing modes stop buying once every level has been filled
+	if s.ladderAmounts != nil && s.dcaLevel >= len(s.ladderAmounts) {
+		return &TradeDecision{
+			Action: ActionHold,
+			Reason: fmt.Sprintf("DCA ladder exhausted: %d/%d levels filled", s.dcaLevel, len(s.ladderAmounts)),
+			Trace:  conditions.Trace,
+		}, nil
 	}
 
-	if confidence >= s.minConfidence {
 	// Apply price threshold check for DCA entries with defensive checks
 	if s.spacingStrategy != nil && s.lastEntryPrice > 0 && currentPrice > 0 {
 		// Add defensive check to prevent division by zero
@@ -171,11 +388,13 @@ func (s *EnhancedDCAStrategy) ShouldExecuteTrade(data []types.OHLCV) (*TradeDeci
 			return &TradeDecision{
 				Action: ActionHold,
 				Reason: "Invalid last entry price for threshold calculation (zero)",
+				Trace:  conditions.Trace,
 			}, nil
 		}
 		
 		priceDrop := (s.lastEntryPrice - currentPrice) / s.lastEntryPrice
 		requiredThreshold := s.calculateCurrentThreshold(currentCandle, data)
+		s.decisionContext.Threshold = requiredThreshold
 		
 		if priceDrop < requiredThreshold {
 			strategyInfo := "Fixed Progressive"
@@ -187,15 +406,22 @@ func (s *EnhancedDCAStrategy) ShouldExecuteTrade(data []types.OHLCV) (*TradeDeci
 				Action: ActionHold,
 				Reason: fmt.Sprintf("Price threshold not met: %.2f%% < %.2f%% (DCA Level %d, Strategy: %s)", 
 					priceDrop*100, requiredThreshold*100, s.dcaLevel, strategyInfo),
+				Trace:  conditions.Trace,
 			}, nil
 		}
 	}
 
-		// Calculate net strength based on buy signals across ALL indicators  
-		netStrength := float64(buySignals) / float64(totalConfiguredIndicators)
+		// Net strength from the aggregated buy signals across ALL indicators
+		netStrength := score.Strength
 		
 		amount := s.calculatePositionSize(netStrength, confidence)
 		
+		// The first entry opens the cycle that cycle-anchored indicators start from
+		if s.dcaLevel == 0 {
+			s.cycleStartTime = currentCandle.Timestamp
+			s.indicatorManager.SetCycleStart(currentCandle.Timestamp)
+		}
+		
 		// Update last entry price, time, and increment DCA level
 		s.lastEntryPrice = currentPrice
 		s.lastTradeTime = currentCandle.Timestamp
@@ -206,17 +432,37 @@ func (s *EnhancedDCAStrategy) ShouldExecuteTrade(data []types.OHLCV) (*TradeDeci
 			Amount:     amount,
 			Confidence: confidence,
 			Strength:   netStrength,
-			Reason:     fmt.Sprintf("Buy consensus: %d/%d active", buySignals, activeSignals),
+			Reason:     "Buy consensus " + s.aggregator.describe(score, s.minConfidence),
+			Trace:      conditions.Trace,
 		}, nil
 	}
 
 	return &TradeDecision{
 		Action: ActionHold,
-		Reason: fmt.Sprintf("Insufficient buy consensus: %d/%d active (%.1f%% < %.1f%%)", 
-				buySignals, activeSignals, confidence*100, s.minConfidence*100),
+		Reason: "Insufficient buy consensus " + s.aggregator.describe(score, s.minConfidence),
+		Trace:  conditions.Trace,
 	}, nil
 }
 
+// recordDecisionContext captures the DCA state the decision on a candle closing at price starts from
+func (s *EnhancedDCAStrategy) recordDecisionContext(price float64) {
+	s.decisionContext = DecisionContext{
+		DCALevel:       s.dcaLevel,
+		LastEntryPrice: s.lastEntryPrice,
+	}
+	if s.lastEntryPrice > 0 {
+		s.decisionContext.PriceDrop = (s.lastEntryPrice - price) / s.lastEntryPrice
+	}
+	if s.spacingStrategy != nil {
+		s.decisionContext.SpacingStrategy = s.spacingStrategy.GetName()
+	}
+}
+
+// GetDecisionContext returns the DCA state behind the last decision
+func (s *EnhancedDCAStrategy) GetDecisionContext() DecisionContext {
+	return s.decisionContext
+}
+
 // calculateCurrentThreshold calculates the price threshold based on DCA level using the configured spacing strategy
 func (s *EnhancedDCAStrategy) calculateCurrentThreshold(currentCandle types.OHLCV, recentCandles []types.OHLCV) float64 {
 	if s.spacingStrategy == nil {
@@ -255,6 +501,11 @@ func (s *EnhancedDCAStrategy) calculateWithSpacingStrategy(currentCandle types.O
 
 
 func (s *EnhancedDCAStrategy) calculatePositionSize(strength, confidence float64) float64 {
+	// Ladder modes size each entry by its DCA level only
+	if s.ladderAmounts != nil && s.dcaLevel < len(s.ladderAmounts) {
+		return s.ladderAmounts[s.dcaLevel]
+	}
+
 	// Calculate signal-based multiplier (0.5x to 2.0x based on confidence and strength)
 	signalMultiplier := 0.5 + (confidence * strength * 1.5)
 	
@@ -281,8 +532,11 @@ func (s *EnhancedDCAStrategy) OnCycleComplete() {
 	s.lastEntryPrice = 0.0
 	// Reset DCA level for next cycle
 	s.dcaLevel = 0
+	s.avgEntryPrice = 0
+	s.cycleStartTime = time.Time{}
 	// Clear indicator cache to start fresh for next cycle
 	s.indicatorManager.ClearCache()
+	s.indicatorManager.SetCycleStart(time.Time{})
 	// Reset spacing strategy state
 	if s.spacingStrategy != nil {
 		s.spacingStrategy.Reset()
@@ -301,6 +555,11 @@ func (s *EnhancedDCAStrategy) GetIndicatorCount() int {
 	return len(s.indicatorManager.GetIndicators())
 }
 
+// BindSeries makes the next run over data read cacheable indicators from cache
+func (s *EnhancedDCAStrategy) BindSeries(cache *indicators.SeriesCache, data []types.OHLCV, windowSize int) {
+	s.indicatorManager.BindSeries(cache, data, windowSize)
+}
+
 // GetLastResults returns the most recent indicator results (useful for debugging)
 func (s *EnhancedDCAStrategy) GetLastResults() map[string]*indicators.IndicatorResult {
 	return s.indicatorManager.GetCachedResults()
@@ -334,6 +593,26 @@ func (s *EnhancedDCAStrategy) GetConfiguration() map[string]interface{} {
 		config["spacing_parameters"] = s.spacingStrategy.GetParameters()
 	}
 	
+	// Add ladder sizing info if a ladder mode is configured
+	if s.ladderAmounts != nil {
+		config["sizing_mode"] = s.positionSizing.Mode
+		config["ladder_amounts"] = s.ladderAmounts
+	}
+	
+	if s.gridLevels > 0 {
+		config["limit_grid_levels"] = s.gridLevels
+	}
+	
+	config["signal_aggregation"] = s.aggregator.String()
+	
+	if !s.conditions.IsEmpty() {
+		config["conditions"] = s.conditions
+	}
+	
+	if !s.timeframes.IsEmpty() {
+		config["timeframes"] = s.timeframes
+	}
+	
 	return config
 }
 
@@ -345,7 +624,11 @@ func (s *EnhancedDCAStrategy) ResetForNewPeriod() {
 	// Reset strategy state
 	s.lastEntryPrice = 0.0
 	s.lastTradeTime = time.Time{}
+	s.cycleStartTime = time.Time{}
 	s.dcaLevel = 0
+	s.avgEntryPrice = 0
+	s.conditionSet.reset()
+	s.resetTimeframes()
 	
 	// Reset spacing strategy state
 	if s.spacingStrategy != nil {
@@ -366,10 +649,35 @@ func (s *EnhancedDCAStrategy) SetLastEntryPrice(price float64) {
 	s.lastEntryPrice = price
 }
 
-// IsDynamicTPEnabled returns true if dynamic TP is configured and enabled
+// SetCycleStartTime sets when the open cycle started (for live bot state synchronization)
+func (s *EnhancedDCAStrategy) SetCycleStartTime(t time.Time) {
+	s.cycleStartTime = t
+	s.indicatorManager.SetCycleStart(t)
+}
+
+// IsTimeDecayTPEnabled returns true if the take profit decays with the cycle's age
+func (s *EnhancedDCAStrategy) IsTimeDecayTPEnabled() bool {
+	return s.dynamicTPConfig != nil && s.dynamicTPConfig.Strategy == "time_decay"
+}
+
+// GetFullTPPercent returns the take profit before time decay
+func (s *EnhancedDCAStrategy) GetFullTPPercent() float64 {
+	if s.dynamicTPConfig == nil {
+		return 0
+	}
+	return s.dynamicTPConfig.BaseTPPercent
+}
+
+// IsDynamicTPEnabled returns true if dynamic TP is configured and enabled. A
+// fixed base TP counts as dynamic when take profit modifiers scale it.
 func (s *EnhancedDCAStrategy) IsDynamicTPEnabled() bool {
-	return s.dynamicTPConfig != nil && 
-		   s.dynamicTPConfig.Strategy != "" && 
+	if s.dynamicTPConfig == nil {
+		return false
+	}
+	if s.conditions.HasTPModifiers() && s.dynamicTPConfig.BaseTPPercent > 0 {
+		return true
+	}
+	return s.dynamicTPConfig.Strategy != "" && 
 		   s.dynamicTPConfig.Strategy != "fixed"
 }
 
@@ -379,7 +687,21 @@ func (s *EnhancedDCAStrategy) GetDynamicTPPercent(currentCandle types.OHLCV, dat
 		return 0, nil // Return 0 if dynamic TP is not enabled
 	}
 
-	return s.calculateDynamicTP(currentCandle, data)
+	tp, err := s.calculateDynamicTP(currentCandle, data)
+	if err != nil {
+		return tp, err
+	}
+
+	// Scale by the take profit modifiers whose condition holds
+	multiplier, trace := s.conditionSet.tpMultiplier(currentCandle, data, s.dcaLevel, s.avgEntryPrice)
+	s.tpTrace = trace
+	return tp * multiplier, nil
+}
+
+// GetTPModifierTrace returns the evaluation trace of the take profit modifiers
+// from the last dynamic TP calculation
+func (s *EnhancedDCAStrategy) GetTPModifierTrace() []string {
+	return s.tpTrace
 }
 
 // calculateDynamicTP calculates the dynamic TP based on the configured strategy
@@ -393,6 +715,8 @@ func (s *EnhancedDCAStrategy) calculateDynamicTP(currentCandle types.OHLCV, data
 		return s.calculateVolatilityBasedTP(currentCandle, data)
 	case "indicator_based":
 		return s.calculateIndicatorBasedTP(currentCandle, data)
+	case "time_decay":
+		return s.calculateTimeDecayTP(currentCandle)
 	default:
 		return s.dynamicTPConfig.BaseTPPercent, nil // Fixed fallback
 	}
@@ -484,6 +808,37 @@ func (s *EnhancedDCAStrategy) calculateIndicatorBasedTP(currentCandle types.OHLC
 	return dynamicTP, nil
 }
 
+// calculateTimeDecayTP lowers the take profit of a long-running cycle so the
+// capital is freed instead of waiting for the full target.
+// Formula: TP = BaseTP - (BaseTP - Floor) × min(max(age - grace, 0) / decay, 1)
+// Once the cycle has break_even_levels entries the floor applies at once.
+func (s *EnhancedDCAStrategy) calculateTimeDecayTP(currentCandle types.OHLCV) (float64, error) {
+	decayConfig := s.dynamicTPConfig.TimeDecayConfig
+	if decayConfig == nil {
+		return s.dynamicTPConfig.BaseTPPercent, fmt.Errorf("time decay config is nil")
+	}
+
+	if decayConfig.BreakEvenLevels > 0 && s.dcaLevel >= decayConfig.BreakEvenLevels {
+		return decayConfig.FloorPercent, nil
+	}
+
+	// Without a known cycle start the cycle has not aged
+	if s.cycleStartTime.IsZero() || currentCandle.Timestamp.IsZero() {
+		return s.dynamicTPConfig.BaseTPPercent, nil
+	}
+
+	ageDays := currentCandle.Timestamp.Sub(s.cycleStartTime).Hours() / 24
+	decayed := (ageDays - decayConfig.GraceDays) / decayConfig.DecayDays
+	if decayed <= 0 {
+		return s.dynamicTPConfig.BaseTPPercent, nil
+	}
+	if decayed > 1 {
+		decayed = 1
+	}
+
+	return s.dynamicTPConfig.BaseTPPercent - (s.dynamicTPConfig.BaseTPPercent-decayConfig.FloorPercent)*decayed, nil
+}
+
 // createSignalFromResult creates a signal from indicator result
 func (s *EnhancedDCAStrategy) createSignalFromResult(result *indicators.IndicatorResult) indicators.Signal {
 	if result.ShouldBuy {
@@ -556,6 +911,28 @@ func (s *EnhancedDCAStrategy) validateDynamicTPConfig() error {
 			return fmt.Errorf("min TP percent (%.4f) must be less than max TP percent (%.4f)", 
 				ic.MinTPPercent, ic.MaxTPPercent)
 		}
+
+	case "time_decay":
+		if s.dynamicTPConfig.TimeDecayConfig == nil {
+			return fmt.Errorf("time decay config is required for time_decay strategy")
+		}
+		tc := s.dynamicTPConfig.TimeDecayConfig
+		if tc.GraceDays < 0 {
+			return fmt.Errorf("grace days must not be negative, got: %.2f", tc.GraceDays)
+		}
+		if tc.DecayDays <= 0 {
+			return fmt.Errorf("decay days must be positive, got: %.2f", tc.DecayDays)
+		}
+		if tc.FloorPercent < 0 || tc.FloorPercent >= s.dynamicTPConfig.BaseTPPercent {
+			return fmt.Errorf("floor percent (%.4f) must be between 0 and the base TP percent (%.4f)", 
+				tc.FloorPercent, s.dynamicTPConfig.BaseTPPercent)
+		}
+		if min := config.MinTimeDecayFloor(s.commission); tc.FloorPercent < min {
+			return fmt.Errorf("floor percent (%.4f) must cover the buy and sell commission (%.4f)", tc.FloorPercent, min)
+		}
+		if tc.BreakEvenLevels < 0 {
+			return fmt.Errorf("break even levels must not be negative, got: %d", tc.BreakEvenLevels)
+		}
 	}
 
 	return nil
@@ -3,6 +3,8 @@ package strategy
 import (
 	"time"
 
+	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators"
+	"github.com/ducminhle1904/crypto-dca-bot/internal/strategy/spacing"
 	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
 )
 
@@ -30,6 +32,116 @@ type Strategy interface {
 	IsDynamicTPEnabled() bool
 }
 
+// GridPlanner is implemented by strategies that can pre-place their next DCA
+// levels as resting limit buys instead of buying at market on a signal
+type GridPlanner interface {
+	// IsGridEnabled returns true if DCA levels after the first entry are pre-placed
+	IsGridEnabled() bool
+
+	// PlanGrid returns the resting limit buys for the next DCA levels, highest
+	// price first, anchored at the last entry. Returns nil when flat or disabled.
+	PlanGrid(data []types.OHLCV) []GridOrder
+
+	// RecordGridFill advances the DCA level after a grid order filled at price
+	RecordGridFill(price float64, t time.Time)
+}
+
+// PositionTracker is implemented by strategies that read the average entry
+// price of the open position, kept current by the engine or bot after each fill
+type PositionTracker interface {
+	SetAverageEntryPrice(price float64) // 0 when flat
+}
+
+// DCAStateSyncer is implemented by strategies that track the DCA cycle; the
+// live bot restores it from the open position after a restart or reload
+type DCAStateSyncer interface {
+	SetDCALevel(level int)
+	SetLastEntryPrice(price float64)
+}
+
+// CycleAgeTracker is implemented by strategies whose take profit can decay
+// with the age of the open cycle. The strategy starts the cycle clock on its
+// first entry; the live bot restores it after a restart or reload.
+type CycleAgeTracker interface {
+	// IsTimeDecayTPEnabled returns true if the take profit decays with the cycle's age
+	IsTimeDecayTPEnabled() bool
+
+	// GetFullTPPercent returns the take profit before time decay
+	GetFullTPPercent() float64
+
+	// SetCycleStartTime sets when the open cycle started (zero when flat)
+	SetCycleStartTime(t time.Time)
+}
+
+// SeriesBinder is implemented by strategies whose indicators can be read from
+// series shared between backtests over the same data
+type SeriesBinder interface {
+	// BindSeries makes the next run over data with the given window read its
+	// cacheable indicators from cache
+	BindSeries(cache *indicators.SeriesCache, data []types.OHLCV, windowSize int)
+}
+
+// SpacingProvider is implemented by strategies that space their DCA entries;
+// the live bot re-checks that spacing before every averaging buy
+type SpacingProvider interface {
+	GetSpacingStrategy() spacing.DCASpacingStrategy
+}
+
+// IndicatorReporter is implemented by strategies built from configurable
+// indicators, for logging their last values
+type IndicatorReporter interface {
+	GetIndicatorCount() int
+	GetLastResults() map[string]*indicators.IndicatorResult
+}
+
+// DecisionExplainer is implemented by strategies that report the DCA state
+// behind their last decision, for the backtest's decision snapshots
+type DecisionExplainer interface {
+	GetDecisionContext() DecisionContext
+}
+
+// DecisionContext is the DCA state a strategy decided on
+type DecisionContext struct {
+	DCALevel        int     // DCA level the decision was for (0 = first entry)
+	LastEntryPrice  float64 // Previous entry price (0 when flat)
+	PriceDrop       float64 // Drop of the close below the last entry price (0 when flat)
+	Threshold       float64 // Drop required by the spacing strategy (0 when not checked)
+	SpacingStrategy string  // Name of the spacing strategy ("" when none)
+}
+
+// TPModifierTracer is implemented by strategies whose take profit is scaled
+// by expression conditions
+type TPModifierTracer interface {
+	// GetTPModifierTrace returns the trace of the last take profit evaluation
+	GetTPModifierTrace() []string
+}
+
+// MultiTimeframeStrategy is implemented by strategies that confirm entries on
+// higher timeframes. Before each decision the engine or bot passes, for every
+// required timeframe, the last Window candles that had closed by the decision
+// time, oldest first; a candle still forming must never be passed.
+type MultiTimeframeStrategy interface {
+	// RequiredTimeframes returns the higher timeframes the strategy reads
+	RequiredTimeframes() []TimeframeRequirement
+
+	// SetTimeframeCandles sets the closed candles of a timeframe for the next decision
+	SetTimeframeCandles(interval string, candles []types.OHLCV)
+}
+
+// TimeframeRequirement is a higher timeframe a strategy reads
+type TimeframeRequirement struct {
+	Interval string        // As configured, e.g. "4h"
+	Duration time.Duration // Candle duration
+	Window   int           // Closed candles needed per decision
+}
+
+// GridOrder is one pre-placed DCA level
+type GridOrder struct {
+	Level  int     // DCA level the order fills (1 = first averaging entry)
+	Price  float64 // Limit price
+	Amount float64 // Order amount in quote currency
+}
+
 // TradeDecision represents a trading decision made by a strategy
 type TradeDecision struct {
 	Action     TradeAction
@@ -38,6 +150,7 @@ type TradeDecision struct {
 	Strength   float64
 	Reason     string
 	Timestamp  time.Time
+	Trace      []string // Evaluation trace of the expression conditions, if any
 }
 
 // TradeAction represents the type of trading action
@@ -32,6 +32,8 @@ type WeightedIndicator struct {
 type MultiIndicatorStrategy struct {
 	indicators          []WeightedIndicator
 	volatilityThreshold float64
+	consensusThreshold  float64 // Weighted score an action needs
+	baseAmount          float64 // Amount per buy (0 = left to the risk manager)
 }
 
 // NewMultiIndicatorStrategy creates a new multi-indicator strategy
@@ -72,9 +74,26 @@ func NewMultiIndicatorStrategy() *MultiIndicatorStrategy {
 			},
 		},
 		volatilityThreshold: 0.05, // 5% threshold for volatility regime
+		consensusThreshold:  0.6,
 	}
 }
 
+// SetBaseAmount sets the amount of every buy
+func (m *MultiIndicatorStrategy) SetBaseAmount(amount float64) {
+	m.baseAmount = amount
+}
+
+// SetVolatilityThreshold sets the ATR/price ratio above which the market
+// counts as volatile
+func (m *MultiIndicatorStrategy) SetVolatilityThreshold(threshold float64) {
+	m.volatilityThreshold = threshold
+}
+
+// SetConsensusThreshold sets the weighted score a buy or sell needs
+func (m *MultiIndicatorStrategy) SetConsensusThreshold(threshold float64) {
+	m.consensusThreshold = threshold
+}
+
 // ShouldExecuteTrade aggregates indicator signals and returns a trade decision
 func (m *MultiIndicatorStrategy) ShouldExecuteTrade(data []types.OHLCV) (*TradeDecision, error) {
 	if len(data) < 50 {
@@ -120,12 +139,12 @@ func (m *MultiIndicatorStrategy) ShouldExecuteTrade(data []types.OHLCV) (*TradeD
 		sellScore /= totalWeight
 
 
-		if buyScore > 0.6 && buyScore > sellScore {
+		if buyScore > m.consensusThreshold && buyScore > sellScore {
 			action = ActionBuy
 			confidence = buyScore
 			strength = buyScore
 			reason = "Buy consensus among indicators"
-		} else if sellScore > 0.6 && sellScore > buyScore {
+		} else if sellScore > m.consensusThreshold && sellScore > buyScore {
 			action = ActionSell
 			confidence = sellScore
 			strength = sellScore
@@ -138,9 +157,14 @@ func (m *MultiIndicatorStrategy) ShouldExecuteTrade(data []types.OHLCV) (*TradeD
 		}
 	}
 
+	amount := 0.0 // Left to the risk manager unless a base amount is set
+	if action == ActionBuy {
+		amount = m.baseAmount
+	}
+
 	return &TradeDecision{
 		Action:     action,
-		Amount:     0, // Amount should be set by risk manager
+		Amount:     amount,
 		Confidence: confidence,
 		Strength:   strength,
 		Reason:     reason,
@@ -231,3 +255,13 @@ func (m *MultiIndicatorStrategy) ResetForNewPeriod() {
 		weightedIndicator.Indicator.ResetState()
 	}
 }
+
+// GetDynamicTPPercent returns 0, the multi-indicator strategy uses the fixed TP
+func (m *MultiIndicatorStrategy) GetDynamicTPPercent(currentCandle types.OHLCV, data []types.OHLCV) (float64, error) {
+	return 0, nil
+}
+
+// IsDynamicTPEnabled returns false, the multi-indicator strategy uses the fixed TP
+func (m *MultiIndicatorStrategy) IsDynamicTPEnabled() bool {
+	return false
+}
@@ -0,0 +1,181 @@
+package strategy
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/config"
+)
+
+// DefaultStrategyName is the strategy used when a config names none
+const DefaultStrategyName = "enhanced_dca"
+
+// Factory builds a strategy from a validated configuration. The strategy's
+// own parameters are in cfg.StrategyParams with defaults already applied.
+type Factory func(cfg *config.DCAConfig) (Strategy, error)
+
+// ParamSpec describes one strategy_params entry of a strategy
+type ParamSpec struct {
+	Name        string
+	Description string
+	Default     float64
+	Min         float64   // Inclusive lower bound
+	Max         float64   // Inclusive upper bound
+	Integer     bool      // Only whole numbers are valid
+	Search      []float64 // Values tried by the optimizers (none = not optimized)
+}
+
+// Definition is a named strategy in the registry
+type Definition struct {
+	Name        string
+	Description string
+	Factory     Factory
+	Params      []ParamSpec // Schema of the strategy_params the strategy reads
+
+	// DCASettings is true if the strategy reads the DCA settings (spacing,
+	// indicators, sizing, grid, aggregation, conditions, timeframes, dynamic
+	// TP); the optimizers then search them too
+	DCASettings bool
+}
+
+// Param returns the spec of a strategy parameter
+func (d *Definition) Param(name string) (ParamSpec, bool) {
+	for _, p := range d.Params {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return ParamSpec{}, false
+}
+
+// ResolveParams checks params against the schema and returns them with the
+// defaults of omitted parameters filled in
+func (d *Definition) ResolveParams(params map[string]float64) (map[string]float64, error) {
+	for name := range params {
+		if _, ok := d.Param(name); !ok {
+			return nil, fmt.Errorf("strategy %s has no parameter %q (parameters: %s)", d.Name, name, d.paramNames())
+		}
+	}
+
+	resolved := make(map[string]float64, len(d.Params))
+	for _, p := range d.Params {
+		v, ok := params[p.Name]
+		if !ok {
+			v = p.Default
+		}
+		if v < p.Min || v > p.Max {
+			return nil, fmt.Errorf("strategy %s parameter %s must be between %g and %g, got %g", d.Name, p.Name, p.Min, p.Max, v)
+		}
+		if p.Integer && v != math.Trunc(v) {
+			return nil, fmt.Errorf("strategy %s parameter %s must be a whole number, got %g", d.Name, p.Name, v)
+		}
+		resolved[p.Name] = v
+	}
+	return resolved, nil
+}
+
+func (d *Definition) paramNames() string {
+	if len(d.Params) == 0 {
+		return "none"
+	}
+	names := make([]string, len(d.Params))
+	for i, p := range d.Params {
+		names[i] = p.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]*Definition)
+)
+
+// Register adds a strategy to the registry. Strategies outside this package
+// register from an init function, so importing their package is enough to
+// make them selectable by name. It panics on an invalid or duplicate
+// definition, like a misconfigured build.
+func Register(def Definition) {
+	name := normalizeStrategyName(def.Name)
+	if name == "" || def.Factory == nil {
+		panic("strategy: Register needs a name and a factory")
+	}
+	for _, p := range def.Params {
+		if p.Default < p.Min || p.Default > p.Max {
+			panic(fmt.Sprintf("strategy: %s parameter %s default %g is outside [%g, %g]", name, p.Name, p.Default, p.Min, p.Max))
+		}
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, exists := registry[name]; exists {
+		panic(fmt.Sprintf("strategy: %s registered twice", name))
+	}
+	def.Name = name
+	def.Params = append([]ParamSpec(nil), def.Params...)
+	registry[name] = &def
+}
+
+// Lookup returns the registered strategy with the given name; an empty name
+// selects DefaultStrategyName
+func Lookup(name string) (*Definition, error) {
+	key := normalizeStrategyName(name)
+	if key == "" {
+		key = DefaultStrategyName
+	}
+
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	def, ok := registry[key]
+	if !ok {
+		return nil, fmt.Errorf("unknown strategy: %s (available: %s)", name, strings.Join(namesLocked(), ", "))
+	}
+	return def, nil
+}
+
+// Registered returns the registered strategies sorted by name
+func Registered() []*Definition {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	defs := make([]*Definition, 0, len(registry))
+	for _, name := range namesLocked() {
+		defs = append(defs, registry[name])
+	}
+	return defs
+}
+
+func namesLocked() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func normalizeStrategyName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// New builds the strategy cfg selects. The configuration is not modified;
+// the factory sees a copy with the strategy parameter defaults applied.
+func New(cfg *config.DCAConfig) (Strategy, error) {
+	def, err := Lookup(cfg.StrategyName)
+	if err != nil {
+		return nil, err
+	}
+	params, err := def.ResolveParams(cfg.StrategyParams)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := *cfg
+	resolved.StrategyParams = params
+	strat, err := def.Factory(&resolved)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s strategy: %w", def.Name, err)
+	}
+	return strat, nil
+}
@@ -0,0 +1,187 @@
+package spacing
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
+)
+
+// Band types of BandSpacing
+const (
+	BandBollinger = "bollinger"
+	BandKeltner   = "keltner"
+)
+
+// BandSpacing implements DCA spacing that requires price below a lower band
+// Level k needs price below middle - (multiplier + k*level_step) * width, where
+// the band is Bollinger (SMA, standard deviation of closes) or Keltner (EMA,
+// average true range). The minimum threshold below the last entry still
+// applies, so a band above the last entry does not trigger an immediate buy.
+type BandSpacing struct {
+	progression *FixedProgressiveSpacing // Fallback threshold and bounds
+
+	bandType   string  // bollinger or keltner
+	period     int     // Band period
+	multiplier float64 // Band width multiplier at level 0
+	levelStep  float64 // Added to the multiplier per DCA level
+}
+
+// NewBandSpacing creates a new band spacing strategy
+func NewBandSpacing(params map[string]interface{}) (*BandSpacing, error) {
+	progression, err := NewFixedProgressiveSpacing(params)
+	if err != nil {
+		return nil, err
+	}
+
+	strategy := &BandSpacing{
+		progression: progression,
+		bandType:    BandBollinger,
+		period:      20,  // 20-period band
+		multiplier:  2.0, // 2 standard deviations (or ATRs)
+		levelStep:   0.5, // Half a width deeper per level
+	}
+
+	// Override with provided parameters
+	if val, ok := params["band_type"].(string); ok {
+		strategy.bandType = strings.ToLower(strings.TrimSpace(val))
+	}
+	if val, ok := intParameter(params, "period"); ok {
+		strategy.period = val
+	}
+	if val, ok := params["band_multiplier"].(float64); ok {
+		strategy.multiplier = val
+	}
+	if val, ok := params["level_step"].(float64); ok {
+		strategy.levelStep = val
+	}
+
+	return strategy, nil
+}
+
+// CalculateThreshold returns the drop from the last entry to the level's lower
+// band, bounded by the minimum and maximum thresholds, or the fixed
+// progressive threshold until the band can be calculated
+func (s *BandSpacing) CalculateThreshold(level int, context *MarketContext) float64 {
+	fallback := s.progression.CalculateThreshold(level, context)
+	if context == nil {
+		return fallback
+	}
+
+	anchor := context.LastEntryPrice
+	if anchor <= 0 {
+		anchor = context.CurrentPrice
+	}
+	if anchor <= 0 {
+		return fallback
+	}
+
+	middle, width, ok := s.band(context.RecentCandles)
+	if !ok {
+		return fallback
+	}
+
+	lower := middle - (s.multiplier+float64(level)*s.levelStep)*width
+	drop := 1 - lower/anchor
+	drop = math.Max(drop, s.progression.minThreshold)
+	return math.Min(drop, s.progression.maxThreshold)
+}
+
+// band returns the middle line and the width unit of the configured band over
+// the last period candles
+func (s *BandSpacing) band(candles []types.OHLCV) (middle, width float64, ok bool) {
+	if s.bandType == BandKeltner {
+		// EMA seeded over the first period candles and ATR need period+1 candles
+		if len(candles) < 2*s.period+1 {
+			return 0, 0, false
+		}
+		window := candles[len(candles)-2*s.period:]
+		middle = 0
+		for _, c := range window[:s.period] {
+			middle += c.Close
+		}
+		middle /= float64(s.period)
+		alpha := 2.0 / float64(s.period+1)
+		for _, c := range window[s.period:] {
+			middle = alpha*c.Close + (1-alpha)*middle
+		}
+
+		trueRange := 0.0
+		for i := len(candles) - s.period; i < len(candles); i++ {
+			c, prev := candles[i], candles[i-1].Close
+			trueRange += math.Max(c.High-c.Low, math.Max(math.Abs(c.High-prev), math.Abs(c.Low-prev)))
+		}
+		return middle, trueRange / float64(s.period), true
+	}
+
+	if len(candles) < s.period {
+		return 0, 0, false
+	}
+	window := candles[len(candles)-s.period:]
+	for _, c := range window {
+		middle += c.Close
+	}
+	middle /= float64(s.period)
+	variance := 0.0
+	for _, c := range window {
+		variance += (c.Close - middle) * (c.Close - middle)
+	}
+	return middle, math.Sqrt(variance / float64(s.period)), true
+}
+
+// GetName returns the strategy name
+func (s *BandSpacing) GetName() string {
+	if s.bandType == BandKeltner {
+		return "Keltner Band"
+	}
+	return "Bollinger Band"
+}
+
+// GetParameters returns the current strategy parameters
+func (s *BandSpacing) GetParameters() map[string]interface{} {
+	params := s.progression.GetParameters()
+	params["band_type"] = s.bandType
+	params["period"] = s.period
+	params["band_multiplier"] = s.multiplier
+	params["level_step"] = s.levelStep
+	return params
+}
+
+// GetRequiredPeriods returns the candles the band is computed from
+func (s *BandSpacing) GetRequiredPeriods() int {
+	if s.bandType == BandKeltner {
+		return 2*s.period + 1
+	}
+	return s.period
+}
+
+// ValidateConfig validates the strategy configuration
+func (s *BandSpacing) ValidateConfig() error {
+	if err := s.progression.ValidateConfig(); err != nil {
+		return err
+	}
+
+	if s.bandType != BandBollinger && s.bandType != BandKeltner {
+		return fmt.Errorf("band_type must be %s or %s, got: %s", BandBollinger, BandKeltner, s.bandType)
+	}
+
+	if s.period < 2 || s.period > 200 {
+		return fmt.Errorf("period must be between 2 and 200, got: %d", s.period)
+	}
+
+	if s.multiplier <= 0 || s.multiplier > 5.0 {
+		return fmt.Errorf("band_multiplier must be between 0 and 5.0, got: %.2f", s.multiplier)
+	}
+
+	if s.levelStep < 0 || s.levelStep > 2.0 {
+		return fmt.Errorf("level_step must be between 0 and 2.0, got: %.2f", s.levelStep)
+	}
+
+	return nil
+}
+
+// Reset resets the strategy state (called at cycle completion)
+func (s *BandSpacing) Reset() {
+	// The band is recalculated from the recent candles on every call
+}
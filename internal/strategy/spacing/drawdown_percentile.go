@@ -0,0 +1,193 @@
+package spacing
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
+)
+
+// DrawdownPercentileSpacing implements DCA spacing sized from the symbol's
+// historical pullback depths
+// A pullback runs from a high to the lowest low before the next higher high.
+// Level k waits for a drop from the last entry equal to the
+// base_percentile + k*percentile_step percentile of the completed pullbacks in
+// the recent candles, so each level is deeper than most past pullbacks.
+type DrawdownPercentileSpacing struct {
+	progression *FixedProgressiveSpacing // Fallback threshold and bounds
+
+	lookback       int     // Candles the pullbacks are measured in
+	basePercentile float64 // Percentile of pullback depths at level 0 (0-100)
+	percentileStep float64 // Added to the percentile per DCA level
+	maxPercentile  float64 // Upper bound of the percentile
+	minDepth       float64 // Pullbacks shallower than this are noise (0.005 = 0.5%)
+	minSamples     int     // Pullbacks needed before the distribution is used
+}
+
+// NewDrawdownPercentileSpacing creates a new drawdown-percentile spacing strategy
+func NewDrawdownPercentileSpacing(params map[string]interface{}) (*DrawdownPercentileSpacing, error) {
+	progression, err := NewFixedProgressiveSpacing(params)
+	if err != nil {
+		return nil, err
+	}
+
+	strategy := &DrawdownPercentileSpacing{
+		progression:    progression,
+		lookback:       100,   // The default window of 100 candles
+		basePercentile: 50,    // Median pullback at level 0
+		percentileStep: 10,    // 10 percentiles deeper per level
+		maxPercentile:  95,    // Never beyond the 95th percentile
+		minDepth:       0.005, // Ignore pullbacks under 0.5%
+		minSamples:     5,     // At least 5 pullbacks
+	}
+
+	// Override with provided parameters
+	if val, ok := intParameter(params, "lookback"); ok {
+		strategy.lookback = val
+	}
+	if val, ok := params["base_percentile"].(float64); ok {
+		strategy.basePercentile = val
+	}
+	if val, ok := params["percentile_step"].(float64); ok {
+		strategy.percentileStep = val
+	}
+	if val, ok := params["max_percentile"].(float64); ok {
+		strategy.maxPercentile = val
+	}
+	if val, ok := params["min_depth"].(float64); ok {
+		strategy.minDepth = val
+	}
+	if val, ok := intParameter(params, "min_samples"); ok {
+		strategy.minSamples = val
+	}
+
+	return strategy, nil
+}
+
+// CalculateThreshold returns the level's percentile of the historical
+// pullback depths, bounded by the minimum and maximum thresholds, or the
+// fixed progressive threshold while there are too few pullbacks
+func (s *DrawdownPercentileSpacing) CalculateThreshold(level int, context *MarketContext) float64 {
+	fallback := s.progression.CalculateThreshold(level, context)
+	if context == nil {
+		return fallback
+	}
+
+	candles := context.RecentCandles
+	if len(candles) > s.lookback {
+		candles = candles[len(candles)-s.lookback:]
+	}
+	depths := pullbackDepths(candles, s.minDepth)
+	if len(depths) < s.minSamples {
+		return fallback
+	}
+
+	p := math.Min(s.basePercentile+float64(level)*s.percentileStep, s.maxPercentile)
+	threshold := percentile(depths, p)
+	threshold = math.Max(threshold, s.progression.minThreshold)
+	return math.Min(threshold, s.progression.maxThreshold)
+}
+
+// pullbackDepths returns the depths of the completed pullbacks in candles,
+// sorted ascending. The pullback still running at the end is left out, since
+// its depth is not known yet.
+func pullbackDepths(candles []types.OHLCV, minDepth float64) []float64 {
+	if len(candles) == 0 {
+		return nil
+	}
+
+	var depths []float64
+	peak := candles[0].High
+	trough := peak
+	for _, c := range candles[1:] {
+		if c.High > peak {
+			if peak > 0 {
+				if depth := 1 - trough/peak; depth >= minDepth {
+					depths = append(depths, depth)
+				}
+			}
+			peak, trough = c.High, c.High
+			continue
+		}
+		if c.Low < trough {
+			trough = c.Low
+		}
+	}
+
+	sort.Float64s(depths)
+	return depths
+}
+
+// percentile returns the p-th percentile (0-100) of sorted values with linear
+// interpolation
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 1 {
+		return sorted[0]
+	}
+	rank := p / 100 * float64(len(sorted)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+	return sorted[lo] + (sorted[hi]-sorted[lo])*(rank-float64(lo))
+}
+
+// GetName returns the strategy name
+func (s *DrawdownPercentileSpacing) GetName() string {
+	return "Drawdown Percentile"
+}
+
+// GetParameters returns the current strategy parameters
+func (s *DrawdownPercentileSpacing) GetParameters() map[string]interface{} {
+	params := s.progression.GetParameters()
+	params["lookback"] = s.lookback
+	params["base_percentile"] = s.basePercentile
+	params["percentile_step"] = s.percentileStep
+	params["max_percentile"] = s.maxPercentile
+	params["min_depth"] = s.minDepth
+	params["min_samples"] = s.minSamples
+	return params
+}
+
+// GetRequiredPeriods returns the candles the pullbacks are measured in
+func (s *DrawdownPercentileSpacing) GetRequiredPeriods() int {
+	return s.lookback
+}
+
+// ValidateConfig validates the strategy configuration
+func (s *DrawdownPercentileSpacing) ValidateConfig() error {
+	if err := s.progression.ValidateConfig(); err != nil {
+		return err
+	}
+
+	if s.lookback < 20 {
+		return fmt.Errorf("lookback must be at least 20, got: %d", s.lookback)
+	}
+
+	if s.basePercentile < 1 || s.basePercentile > 99 {
+		return fmt.Errorf("base_percentile must be between 1 and 99, got: %.1f", s.basePercentile)
+	}
+
+	if s.percentileStep < 0 || s.percentileStep > 50 {
+		return fmt.Errorf("percentile_step must be between 0 and 50, got: %.1f", s.percentileStep)
+	}
+
+	if s.maxPercentile < s.basePercentile || s.maxPercentile > 100 {
+		return fmt.Errorf("max_percentile must be between base_percentile (%.1f) and 100, got: %.1f",
+			s.basePercentile, s.maxPercentile)
+	}
+
+	if s.minDepth < 0 || s.minDepth > 0.2 {
+		return fmt.Errorf("min_depth must be between 0 and 0.2, got: %.4f", s.minDepth)
+	}
+
+	if s.minSamples < 1 {
+		return fmt.Errorf("min_samples must be at least 1, got: %d", s.minSamples)
+	}
+
+	return nil
+}
+
+// Reset resets the strategy state (called at cycle completion)
+func (s *DrawdownPercentileSpacing) Reset() {
+	// Pullbacks are measured in the recent candles on every call
+}
@@ -16,8 +16,33 @@ func CreateSpacingStrategy(config SpacingConfig) (DCASpacingStrategy, error) {
 	case "fixed", "fixed_progressive", "":
 		return NewFixedProgressiveSpacing(config.Parameters)
 	
+	case "volume_profile", "vp":
+		return NewVolumeProfileSpacing(config.Parameters)
+	
+	case "swing_low":
+		return NewSwingLowSpacing(config.Parameters)
+	
+	case "band":
+		return NewBandSpacing(config.Parameters)
+	
+	case "drawdown_percentile":
+		return NewDrawdownPercentileSpacing(config.Parameters)
+	
 	default:
-		return nil, fmt.Errorf("unknown spacing strategy: %s (supported: volatility_adaptive, fixed)", config.Strategy)
+		return nil, fmt.Errorf("unknown spacing strategy: %s (supported: volatility_adaptive, fixed, volume_profile, swing_low, band, drawdown_percentile)", config.Strategy)
+	}
+}
+
+// intParameter reads an integer parameter given either as int (flags) or
+// float64 (JSON)
+func intParameter(params map[string]interface{}, key string) (int, bool) {
+	switch val := params[key].(type) {
+	case int:
+		return val, true
+	case float64:
+		return int(val), true
+	default:
+		return 0, false
 	}
 }
 
@@ -26,6 +51,10 @@ func GetAvailableStrategies() []string {
 	return []string{
 		"fixed",              // Fixed progressive spacing (default)
 		"volatility_adaptive", // ATR-based adaptive spacing
+		"volume_profile",     // Levels snapped to high-volume support nodes
+		"swing_low",          // Levels under the most recent swing low
+		"band",               // Levels below a lower Bollinger/Keltner band widened per level
+		"drawdown_percentile", // Levels at percentiles of historical pullback depths
 	}
 }
 
@@ -36,6 +65,14 @@ func GetStrategyDescription(strategyName string) string {
 		return "Fixed progressive spacing - consistent threshold progression per DCA level"
 	case "volatility_adaptive", "atr":
 		return "ATR-based volatility-adaptive spacing - wider spacing in volatile markets, tighter in stable markets"
+	case "volume_profile", "vp":
+		return "Volume-profile spacing - fixed progression with levels moved onto nearby high-volume support nodes"
+	case "swing_low":
+		return "Swing-low spacing - each level just below the most recent confirmed swing low under the last entry"
+	case "band":
+		return "Band spacing - each level below a lower Bollinger or Keltner band that widens with the DCA level"
+	case "drawdown_percentile":
+		return "Drawdown-percentile spacing - level k at a rising percentile of the symbol's historical pullback depths"
 	default:
 		return "Unknown strategy"
 	}
@@ -58,6 +95,44 @@ func GetDefaultParameters(strategyName string) map[string]interface{} {
 			"base_threshold":       0.01, // 1%
 			"threshold_multiplier": 1.15, // 1.15x per level
 		}
+	case "volume_profile", "vp":
+		return map[string]interface{}{
+			"base_threshold":       0.01, // 1%
+			"threshold_multiplier": 1.15, // 1.15x per level
+			"lookback":             50,   // 50-candle profile
+			"bins":                 24,   // 24 price bins
+			"node_factor":          1.5,  // Node at 1.5x average bin volume
+			"snap_range":           0.5,  // Snap within ±50% of the target drop
+		}
+	case "swing_low":
+		return map[string]interface{}{
+			"base_threshold":       0.01,  // 1% fallback without a swing low
+			"threshold_multiplier": 1.15,  // 1.15x per level
+			"lookback":             100,   // 100 candles of swing lows
+			"pivot_strength":       3,     // 3 higher lows on each side
+			"buffer":               0.002, // 0.2% below the swing low
+		}
+	case "band":
+		return map[string]interface{}{
+			"base_threshold":       0.01,  // 1% until the band is available
+			"threshold_multiplier": 1.15,  // 1.15x per level
+			"min_threshold":        0.003, // At least 0.3% below the last entry
+			"band_type":            BandBollinger, // bollinger or keltner
+			"period":               20,    // 20-period band
+			"band_multiplier":      2.0,   // 2 standard deviations (or ATRs)
+			"level_step":           0.5,   // Half a width deeper per level
+		}
+	case "drawdown_percentile":
+		return map[string]interface{}{
+			"base_threshold":       0.01,  // 1% while too few pullbacks are known
+			"threshold_multiplier": 1.15,  // 1.15x per level
+			"lookback":             100,   // The default window of 100 candles
+			"base_percentile":      50.0,  // Median pullback at level 0
+			"percentile_step":      10.0,  // 10 percentiles deeper per level
+			"max_percentile":       95.0,  // Never beyond the 95th percentile
+			"min_depth":            0.005, // Ignore pullbacks under 0.5%
+			"min_samples":          5,     // At least 5 pullbacks
+		}
 	default:
 		return map[string]interface{}{}
 	}
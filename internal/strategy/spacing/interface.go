@@ -26,6 +26,13 @@ type DCASpacingStrategy interface {
 	Reset()
 }
 
+// HistoryReader is implemented by spacing strategies that read past candles
+// from MarketContext.RecentCandles, which holds the strategy's data window
+type HistoryReader interface {
+	// GetRequiredPeriods returns the candles of history the strategy reads
+	GetRequiredPeriods() int
+}
+
 // MarketContext contains market data needed by spacing strategies
 type MarketContext struct {
 	// Price information
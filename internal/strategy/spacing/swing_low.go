@@ -0,0 +1,149 @@
+package spacing
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
+)
+
+// SwingLowSpacing implements DCA spacing that places each level under the most
+// recent swing low
+// A swing low is a candle whose low is below the lows of pivot_strength
+// candles on each side. The next level sits buffer below the most recent swing
+// low under the last entry; without one, the fixed progressive threshold is used.
+type SwingLowSpacing struct {
+	progression *FixedProgressiveSpacing // Fallback threshold and bounds
+
+	lookback      int     // Candles searched for swing lows
+	pivotStrength int     // Higher lows needed on each side of a swing low
+	buffer        float64 // Distance below the swing low (0.002 = 0.2%)
+}
+
+// NewSwingLowSpacing creates a new swing-low spacing strategy
+func NewSwingLowSpacing(params map[string]interface{}) (*SwingLowSpacing, error) {
+	progression, err := NewFixedProgressiveSpacing(params)
+	if err != nil {
+		return nil, err
+	}
+
+	strategy := &SwingLowSpacing{
+		progression:   progression,
+		lookback:      100,   // 100 candles of swing lows
+		pivotStrength: 3,     // 3 higher lows on each side
+		buffer:        0.002, // 0.2% below the swing low
+	}
+
+	// Override with provided parameters
+	if val, ok := intParameter(params, "lookback"); ok {
+		strategy.lookback = val
+	}
+	if val, ok := intParameter(params, "pivot_strength"); ok {
+		strategy.pivotStrength = val
+	}
+	if val, ok := params["buffer"].(float64); ok {
+		strategy.buffer = val
+	}
+
+	return strategy, nil
+}
+
+// CalculateThreshold returns the drop from the last entry to just below the
+// most recent swing low under it, or the fixed progressive threshold when no
+// swing low qualifies
+func (s *SwingLowSpacing) CalculateThreshold(level int, context *MarketContext) float64 {
+	fallback := s.progression.CalculateThreshold(level, context)
+	if context == nil {
+		return fallback
+	}
+
+	anchor := context.LastEntryPrice
+	if anchor <= 0 {
+		anchor = context.CurrentPrice
+	}
+	if anchor <= 0 {
+		return fallback
+	}
+
+	// Swing lows closer than the minimum threshold would trigger at once
+	ceiling := anchor * (1 - s.progression.minThreshold)
+	low, ok := recentSwingLow(context.RecentCandles, s.lookback, s.pivotStrength, ceiling)
+	if !ok {
+		return fallback
+	}
+
+	drop := 1 - low*(1-s.buffer)/anchor
+	return math.Min(drop, s.progression.maxThreshold)
+}
+
+// recentSwingLow returns the low of the most recent swing low below ceiling
+// within the last lookback candles. A swing low needs strength candles after
+// it, so only confirmed pivots are returned.
+func recentSwingLow(candles []types.OHLCV, lookback, strength int, ceiling float64) (float64, bool) {
+	start := len(candles) - lookback
+	if start < 0 {
+		start = 0
+	}
+	for i := len(candles) - 1 - strength; i >= start+strength; i-- {
+		low := candles[i].Low
+		if low <= 0 || low >= ceiling {
+			continue
+		}
+		pivot := true
+		for j := i - strength; j <= i+strength && pivot; j++ {
+			if j != i && candles[j].Low <= low {
+				pivot = false
+			}
+		}
+		if pivot {
+			return low, true
+		}
+	}
+	return 0, false
+}
+
+// GetName returns the strategy name
+func (s *SwingLowSpacing) GetName() string {
+	return "Swing Low"
+}
+
+// GetParameters returns the current strategy parameters
+func (s *SwingLowSpacing) GetParameters() map[string]interface{} {
+	params := s.progression.GetParameters()
+	params["lookback"] = s.lookback
+	params["pivot_strength"] = s.pivotStrength
+	params["buffer"] = s.buffer
+	return params
+}
+
+// GetRequiredPeriods returns the candles searched for swing lows
+func (s *SwingLowSpacing) GetRequiredPeriods() int {
+	return s.lookback
+}
+
+// ValidateConfig validates the strategy configuration
+func (s *SwingLowSpacing) ValidateConfig() error {
+	if err := s.progression.ValidateConfig(); err != nil {
+		return err
+	}
+
+	if s.pivotStrength < 1 || s.pivotStrength > 20 {
+		return fmt.Errorf("pivot_strength must be between 1 and 20, got: %d", s.pivotStrength)
+	}
+
+	if s.lookback < 2*s.pivotStrength+1 {
+		return fmt.Errorf("lookback must be at least %d for pivot_strength %d, got: %d",
+			2*s.pivotStrength+1, s.pivotStrength, s.lookback)
+	}
+
+	if s.buffer < 0 || s.buffer > 0.05 {
+		return fmt.Errorf("buffer must be between 0 and 0.05, got: %.4f", s.buffer)
+	}
+
+	return nil
+}
+
+// Reset resets the strategy state (called at cycle completion)
+func (s *SwingLowSpacing) Reset() {
+	// Swing lows are found in the recent candles on every call
+}
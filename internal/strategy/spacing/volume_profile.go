@@ -0,0 +1,143 @@
+package spacing
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators/volume"
+)
+
+// VolumeProfileSpacing implements DCA spacing that clusters entries at volume support
+// The fixed progressive threshold gives a target drop per level; when a
+// high-volume node of the recent volume profile lies close to the target, the
+// level moves onto that node, where price is more likely to find support.
+type VolumeProfileSpacing struct {
+	progression *FixedProgressiveSpacing // Target threshold per level
+
+	lookback   int     // Candles in the volume profile
+	bins       int     // Price bins of the volume profile
+	nodeFactor float64 // Bin volume vs average volume of a high-volume node
+	snapRange  float64 // How far from the target a node may be, as a share of the target (0.5 = ±50%)
+}
+
+// NewVolumeProfileSpacing creates a new volume-profile spacing strategy
+func NewVolumeProfileSpacing(params map[string]interface{}) (*VolumeProfileSpacing, error) {
+	progression, err := NewFixedProgressiveSpacing(params)
+	if err != nil {
+		return nil, err
+	}
+
+	strategy := &VolumeProfileSpacing{
+		progression: progression,
+		lookback:    volume.DefaultVolumeProfileLookback,
+		bins:        volume.DefaultVolumeProfileBins,
+		nodeFactor:  volume.DefaultVolumeNodeFactor,
+		snapRange:   0.5, // Snap to nodes within ±50% of the target drop
+	}
+
+	// Override with provided parameters
+	if val, ok := intParameter(params, "lookback"); ok {
+		strategy.lookback = val
+	}
+	if val, ok := intParameter(params, "bins"); ok {
+		strategy.bins = val
+	}
+	if val, ok := params["node_factor"].(float64); ok {
+		strategy.nodeFactor = val
+	}
+	if val, ok := params["snap_range"].(float64); ok {
+		strategy.snapRange = val
+	}
+
+	return strategy, nil
+}
+
+// CalculateThreshold returns the drop to the strongest high-volume node near
+// the level's target, or the target itself when no node qualifies
+func (s *VolumeProfileSpacing) CalculateThreshold(level int, context *MarketContext) float64 {
+	target := s.progression.CalculateThreshold(level, context)
+	if context == nil || len(context.RecentCandles) < s.lookback {
+		return target
+	}
+
+	anchor := context.LastEntryPrice
+	if anchor <= 0 {
+		anchor = context.CurrentPrice
+	}
+	if anchor <= 0 {
+		return target
+	}
+
+	profile, err := volume.BuildProfile(context.RecentCandles[len(context.RecentCandles)-s.lookback:], s.bins, s.nodeFactor)
+	if err != nil {
+		return target
+	}
+
+	best := target
+	bestVolume := 0.0
+	for _, node := range profile.SupportsBelow(anchor) {
+		drop := 1 - node.Price/anchor
+		if math.Abs(drop-target) > target*s.snapRange {
+			continue
+		}
+		if drop < s.progression.minThreshold || drop > s.progression.maxThreshold {
+			continue
+		}
+		if node.Volume > bestVolume {
+			best = drop
+			bestVolume = node.Volume
+		}
+	}
+
+	return best
+}
+
+// GetName returns the strategy name
+func (s *VolumeProfileSpacing) GetName() string {
+	return "Volume Profile"
+}
+
+// GetParameters returns the current strategy parameters
+func (s *VolumeProfileSpacing) GetParameters() map[string]interface{} {
+	params := s.progression.GetParameters()
+	params["lookback"] = s.lookback
+	params["bins"] = s.bins
+	params["node_factor"] = s.nodeFactor
+	params["snap_range"] = s.snapRange
+	return params
+}
+
+// GetRequiredPeriods returns the candles in the volume profile
+func (s *VolumeProfileSpacing) GetRequiredPeriods() int {
+	return s.lookback
+}
+
+// ValidateConfig validates the strategy configuration
+func (s *VolumeProfileSpacing) ValidateConfig() error {
+	if err := s.progression.ValidateConfig(); err != nil {
+		return err
+	}
+
+	if s.lookback < 2 {
+		return fmt.Errorf("lookback must be at least 2, got: %d", s.lookback)
+	}
+
+	if s.bins < 2 || s.bins > 200 {
+		return fmt.Errorf("bins must be between 2 and 200, got: %d", s.bins)
+	}
+
+	if s.nodeFactor < 1.0 {
+		return fmt.Errorf("node_factor must be at least 1.0, got: %.2f", s.nodeFactor)
+	}
+
+	if s.snapRange < 0 || s.snapRange > 1.0 {
+		return fmt.Errorf("snap_range must be between 0 and 1.0, got: %.2f", s.snapRange)
+	}
+
+	return nil
+}
+
+// Reset resets the strategy state (called at cycle completion)
+func (s *VolumeProfileSpacing) Reset() {
+	// The profile is rebuilt from the recent candles on every call
+}
@@ -0,0 +1,90 @@
+package strategy
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/config"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
+)
+
+func timeDecayStrategy(floor float64) *EnhancedDCAStrategy {
+	s := NewEnhancedDCAStrategy(10)
+	s.SetCommission(0.001)
+	s.SetDynamicTPConfig(&config.DynamicTPConfig{
+		Strategy:      "time_decay",
+		BaseTPPercent: 0.02,
+		TimeDecayConfig: &config.DynamicTPTimeDecayConfig{
+			GraceDays:       2,
+			DecayDays:       4,
+			FloorPercent:    floor,
+			BreakEvenLevels: 3,
+		},
+	})
+	return s
+}
+
+func TestCalculateTimeDecayTP(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name     string
+		age      time.Duration
+		dcaLevel int
+		noStart  bool
+		want     float64
+	}{
+		{name: "new cycle", age: 0, want: 0.02},
+		{name: "end of grace", age: 2 * day, want: 0.02},
+		{name: "an hour into the decay", age: 2*day + time.Hour, want: 0.02 - 0.012/96},
+		{name: "quarter decayed", age: 3 * day, want: 0.017},
+		{name: "half way to the floor", age: 4 * day, want: 0.014},
+		{name: "fully decayed", age: 6 * day, want: 0.008},
+		{name: "clamped at the floor", age: 30 * day, want: 0.008},
+		{name: "below break-even levels", age: 0, dcaLevel: 2, want: 0.02},
+		{name: "break-even after K levels", age: 0, dcaLevel: 3, want: 0.008},
+		{name: "break-even ignores the grace", age: day, dcaLevel: 5, noStart: true, want: 0.008},
+		{name: "unknown cycle start", age: 10 * day, noStart: true, want: 0.02},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := timeDecayStrategy(0.008)
+			s.dcaLevel = tt.dcaLevel
+			if !tt.noStart {
+				s.SetCycleStartTime(start)
+			}
+			got, err := s.calculateTimeDecayTP(types.OHLCV{Timestamp: start.Add(tt.age)})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := got - tt.want; diff > 1e-12 || diff < -1e-12 {
+				t.Errorf("expected TP %.6f, got %.6f", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTimeDecayFloorCoversCommission(t *testing.T) {
+	tests := []struct {
+		floor   float64
+		wantErr string
+	}{
+		{floor: 0.002},
+		{floor: 0.005},
+		{floor: 0.0019, wantErr: "must cover the buy and sell commission"},
+		{floor: 0, wantErr: "must cover the buy and sell commission"},
+		{floor: 0.02, wantErr: "must be between 0 and the base TP percent"},
+	}
+	for _, tt := range tests {
+		err := timeDecayStrategy(tt.floor).validateDynamicTPConfig()
+		if tt.wantErr == "" && err != nil {
+			t.Errorf("floor %.4f: unexpected error: %v", tt.floor, err)
+		}
+		if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+			t.Errorf("floor %.4f: expected error containing %q, got %v", tt.floor, tt.wantErr, err)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package strategy
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/config"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/data"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
+)
+
+// minTimeframeWindow is the smallest default window of a higher timeframe
+const minTimeframeWindow = 10
+
+// timeframeFilter holds the conditions of one higher timeframe and the closed
+// candles last passed for it
+type timeframeFilter struct {
+	interval   string
+	duration   time.Duration
+	window     int
+	conditions *conditionSet
+	candles    []types.OHLCV
+}
+
+// SetTimeframes sets the higher timeframe confirmations; nil removes them
+func (s *EnhancedDCAStrategy) SetTimeframes(timeframes config.Timeframes) error {
+	filters := make([]*timeframeFilter, 0, len(timeframes))
+	for _, tf := range timeframes {
+		d, err := data.IntervalDuration(tf.Interval)
+		if err != nil {
+			return fmt.Errorf("invalid timeframe: %w", err)
+		}
+		set, err := newConditionSet(&config.ConditionsConfig{Entry: tf.Conditions})
+		if err != nil {
+			return fmt.Errorf("invalid timeframe %s: %w", tf.Interval, err)
+		}
+		if set == nil {
+			return fmt.Errorf("invalid timeframe %s: no conditions", tf.Interval)
+		}
+
+		required := set.requiredPeriods()
+		window := tf.Window
+		if window == 0 {
+			window = 2 * required
+			if window < minTimeframeWindow {
+				window = minTimeframeWindow
+			}
+		}
+		if window < required {
+			return fmt.Errorf("timeframe %s conditions need %d candles of history but the window is %d", tf.Interval, required, window)
+		}
+
+		filters = append(filters, &timeframeFilter{interval: tf.Interval, duration: d, window: window, conditions: set})
+	}
+
+	s.timeframes = timeframes
+	s.timeframeFilters = filters
+	return nil
+}
+
+// GetTimeframes returns the higher timeframe confirmations (nil = none)
+func (s *EnhancedDCAStrategy) GetTimeframes() config.Timeframes {
+	return s.timeframes
+}
+
+// RequiredTimeframes implements MultiTimeframeStrategy
+func (s *EnhancedDCAStrategy) RequiredTimeframes() []TimeframeRequirement {
+	reqs := make([]TimeframeRequirement, len(s.timeframeFilters))
+	for i, f := range s.timeframeFilters {
+		reqs[i] = TimeframeRequirement{Interval: f.interval, Duration: f.duration, Window: f.window}
+	}
+	return reqs
+}
+
+// SetTimeframeCandles implements MultiTimeframeStrategy
+func (s *EnhancedDCAStrategy) SetTimeframeCandles(interval string, candles []types.OHLCV) {
+	for _, f := range s.timeframeFilters {
+		if f.interval == interval {
+			f.candles = candles
+		}
+	}
+}
+
+// checkTimeframes evaluates the higher timeframe conditions on the candles
+// passed for them, adding their outcome to check. A timeframe without closed
+// candles yet fails closed like an unavailable condition.
+func (s *EnhancedDCAStrategy) checkTimeframes(check *conditionCheck) {
+	for _, f := range s.timeframeFilters {
+		label := "[" + f.interval + "] "
+		if len(f.candles) == 0 {
+			check.Trace = append(check.Trace, label+"no closed candles yet")
+			if check.Failed == "" {
+				check.Failed = label + "no closed candles yet"
+			}
+			continue
+		}
+
+		result := f.conditions.check(f.candles[len(f.candles)-1], f.candles, s.dcaLevel, s.avgEntryPrice)
+		for _, line := range result.Trace {
+			check.Trace = append(check.Trace, label+line)
+		}
+		if result.Failed != "" && check.Failed == "" {
+			check.Failed = label + result.Failed
+		}
+	}
+}
+
+// resetTimeframes clears the candles and indicator state of every timeframe
+func (s *EnhancedDCAStrategy) resetTimeframes() {
+	for _, f := range s.timeframeFilters {
+		f.candles = nil
+		f.conditions.reset()
+	}
+}
@@ -0,0 +1,212 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators"
+)
+
+// Signal aggregation modes
+const (
+	AggregationModeMajority = "majority" // Buy votes / configured indicators (default)
+	AggregationModeWeighted = "weighted" // Weighted buy votes / total weight
+	AggregationModeStrength = "strength" // Weighted buy signal strengths / total weight
+	AggregationModeQuorum   = "quorum"   // Buy once at least quorum indicators vote buy
+)
+
+// SignalAggregationConfig selects how indicator signals are combined into the
+// buy confidence. Weights, required and veto lists are keyed by indicator name
+// as used in the indicators list (e.g. "rsi", "bb"); unlisted indicators weigh 1.
+type SignalAggregationConfig struct {
+	Mode     string             `json:"mode"`               // "majority" (default), "weighted", "strength", "quorum"
+	Weights  map[string]float64 `json:"weights,omitempty"`  // Vote weight per indicator (weighted, strength)
+	Required []string           `json:"required,omitempty"` // Indicators that must signal buy for any entry
+	Veto     []string           `json:"veto,omitempty"`     // Indicators whose sell signal blocks entries
+	Quorum   int                `json:"quorum,omitempty"`   // Buy votes needed (quorum)
+}
+
+// IsEntryFilterIndicator reports whether an indicator only filters entries
+// (ADX): it casts no buy vote and its sell signal always blocks entries
+func IsEntryFilterIndicator(name string) bool {
+	t, err := indicators.ParseIndicatorType(name)
+	return err == nil && t == indicators.IndicatorTypeADX
+}
+
+// IsWeighted returns true if the mode uses per-indicator weights
+func (a *SignalAggregationConfig) IsWeighted() bool {
+	return a != nil && (a.Mode == AggregationModeWeighted || a.Mode == AggregationModeStrength)
+}
+
+// ModeName returns the configured mode, "majority" when unset
+func (a *SignalAggregationConfig) ModeName() string {
+	if a == nil || a.Mode == "" {
+		return AggregationModeMajority
+	}
+	return a.Mode
+}
+
+// Weight returns the vote weight of an indicator, 1 when not configured
+func (a *SignalAggregationConfig) Weight(indicator string) float64 {
+	if a == nil {
+		return 1
+	}
+	if w, ok := a.Weights[strings.ToLower(indicator)]; ok {
+		return w
+	}
+	return 1
+}
+
+// SetWeight sets the vote weight of an indicator
+func (a *SignalAggregationConfig) SetWeight(indicator string, weight float64) {
+	if a.Weights == nil {
+		a.Weights = make(map[string]float64)
+	}
+	a.Weights[strings.ToLower(indicator)] = weight
+}
+
+// Clone returns a deep copy of the configuration
+func (a *SignalAggregationConfig) Clone() *SignalAggregationConfig {
+	if a == nil {
+		return nil
+	}
+	clone := *a
+	if a.Weights != nil {
+		clone.Weights = make(map[string]float64, len(a.Weights))
+		for k, v := range a.Weights {
+			clone.Weights[k] = v
+		}
+	}
+	clone.Required = append([]string(nil), a.Required...)
+	clone.Veto = append([]string(nil), a.Veto...)
+	return &clone
+}
+
+// Validate checks the aggregation mode and its parameters
+func (a *SignalAggregationConfig) Validate() error {
+	if a == nil {
+		return nil
+	}
+	switch a.Mode {
+	case "", AggregationModeMajority, AggregationModeWeighted, AggregationModeStrength:
+	case AggregationModeQuorum:
+		if a.Quorum < 1 {
+			return fmt.Errorf("quorum mode requires quorum of at least 1, got %d", a.Quorum)
+		}
+	default:
+		return fmt.Errorf("invalid signal aggregation mode: %s (must be %s, %s, %s or %s)",
+			a.Mode, AggregationModeMajority, AggregationModeWeighted, AggregationModeStrength, AggregationModeQuorum)
+	}
+	for name, w := range a.Weights {
+		if w < 0 {
+			return fmt.Errorf("weight of %s must be non-negative, got %.4f", name, w)
+		}
+	}
+	for _, name := range a.Required {
+		if IsEntryFilterIndicator(name) {
+			return fmt.Errorf("%s only filters entries and cannot be required", name)
+		}
+		for _, veto := range a.Veto {
+			if strings.EqualFold(name, veto) {
+				return fmt.Errorf("%s cannot be both required and veto", name)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateFor checks the configuration against the configured indicators:
+// weight, required and veto names must be among them, the quorum cannot exceed
+// the voting indicators and weighted modes need a positive total weight. An
+// empty list (indicators chosen later from flags or by a strategy) only runs
+// Validate.
+func (a *SignalAggregationConfig) ValidateFor(indicatorNames []string) error {
+	if err := a.Validate(); err != nil {
+		return err
+	}
+	if a == nil || len(indicatorNames) == 0 {
+		return nil
+	}
+
+	configured := make(map[indicators.IndicatorType]bool, len(indicatorNames))
+	for _, name := range indicatorNames {
+		if t, err := indicators.ParseIndicatorType(name); err == nil {
+			configured[t] = true
+		}
+	}
+	check := func(kind, name string) error {
+		t, err := indicators.ParseIndicatorType(name)
+		if err != nil {
+			return fmt.Errorf("invalid %s indicator: %w", kind, err)
+		}
+		if !configured[t] {
+			return fmt.Errorf("%s indicator %s is not among the configured indicators %v", kind, name, indicatorNames)
+		}
+		return nil
+	}
+
+	names := make([]string, 0, len(a.Weights))
+	for name := range a.Weights {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := check("weighted", name); err != nil {
+			return err
+		}
+	}
+	for _, name := range a.Required {
+		if err := check("required", name); err != nil {
+			return err
+		}
+	}
+	for _, name := range a.Veto {
+		if err := check("veto", name); err != nil {
+			return err
+		}
+	}
+
+	voters := 0
+	for _, name := range indicatorNames {
+		if !IsEntryFilterIndicator(name) {
+			voters++
+		}
+	}
+	if a.Mode == AggregationModeQuorum && a.Quorum > voters {
+		return fmt.Errorf("quorum %d exceeds the %d voting indicators", a.Quorum, voters)
+	}
+	if a.IsWeighted() && voters > 0 && a.VoteWeight(indicatorNames) <= 0 {
+		return fmt.Errorf("%s mode requires a positive total weight, the weights of %v sum to 0", a.Mode, indicatorNames)
+	}
+	return nil
+}
+
+// VoteWeight returns the total vote weight of the voting indicators, resolving
+// weight names and indicator aliases (bb, bollinger) to the same indicator
+func (a *SignalAggregationConfig) VoteWeight(indicatorNames []string) float64 {
+	weights := make(map[indicators.IndicatorType]float64)
+	if a != nil {
+		for name, w := range a.Weights {
+			if t, err := indicators.ParseIndicatorType(name); err == nil {
+				weights[t] = w
+			}
+		}
+	}
+	total := 0.0
+	for _, name := range indicatorNames {
+		if IsEntryFilterIndicator(name) {
+			continue
+		}
+		t, err := indicators.ParseIndicatorType(name)
+		if err != nil {
+			continue
+		}
+		if w, ok := weights[t]; ok {
+			total += w
+		} else {
+			total++
+		}
+	}
+	return total
+}
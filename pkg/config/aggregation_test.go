@@ -0,0 +1,90 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSignalAggregationValidateFor(t *testing.T) {
+	configured := []string{"rsi", "bb", "adx"}
+
+	tests := []struct {
+		name    string
+		cfg     *SignalAggregationConfig
+		wantErr string
+	}{
+		{name: "nil config", cfg: nil},
+		{name: "majority", cfg: &SignalAggregationConfig{Mode: AggregationModeMajority}},
+		{name: "required alias", cfg: &SignalAggregationConfig{Required: []string{"bollinger"}}},
+		{name: "veto filter", cfg: &SignalAggregationConfig{Veto: []string{"dmi"}}},
+		{
+			name:    "required not configured",
+			cfg:     &SignalAggregationConfig{Required: []string{"macd"}},
+			wantErr: "required indicator macd is not among",
+		},
+		{
+			name:    "veto not configured",
+			cfg:     &SignalAggregationConfig{Veto: []string{"supertrend"}},
+			wantErr: "veto indicator supertrend is not among",
+		},
+		{
+			name:    "unknown veto",
+			cfg:     &SignalAggregationConfig{Veto: []string{"foo"}},
+			wantErr: "unknown indicator type",
+		},
+		{
+			name:    "weight not configured",
+			cfg:     &SignalAggregationConfig{Mode: AggregationModeWeighted, Weights: map[string]float64{"macd": 2}},
+			wantErr: "weighted indicator macd is not among",
+		},
+		{name: "quorum of voters", cfg: &SignalAggregationConfig{Mode: AggregationModeQuorum, Quorum: 2}},
+		{
+			name:    "quorum counts filters out",
+			cfg:     &SignalAggregationConfig{Mode: AggregationModeQuorum, Quorum: 3},
+			wantErr: "quorum 3 exceeds the 2 voting indicators",
+		},
+		{
+			name:    "weights sum to zero",
+			cfg:     &SignalAggregationConfig{Mode: AggregationModeWeighted, Weights: map[string]float64{"rsi": 0, "bollinger": 0}},
+			wantErr: "positive total weight",
+		},
+		{
+			name: "unlisted weight defaults to 1",
+			cfg:  &SignalAggregationConfig{Mode: AggregationModeStrength, Weights: map[string]float64{"rsi": 0}},
+		},
+		{
+			name: "zero weights outside weighted modes",
+			cfg:  &SignalAggregationConfig{Mode: AggregationModeMajority, Weights: map[string]float64{"rsi": 0, "bb": 0}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.ValidateFor(configured)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestSignalAggregationValidateForWithoutIndicators(t *testing.T) {
+	// Indicators chosen later (flags, strategies) skip the cross-checks
+	cfg := &SignalAggregationConfig{Mode: AggregationModeQuorum, Quorum: 5, Required: []string{"macd"}}
+	if err := cfg.ValidateFor(nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSignalAggregationVoteWeight(t *testing.T) {
+	cfg := &SignalAggregationConfig{Mode: AggregationModeWeighted, Weights: map[string]float64{"bollinger": 0.5, "adx": 3}}
+	if got := cfg.VoteWeight([]string{"rsi", "bb", "adx"}); got != 1.5 {
+		t.Errorf("expected rsi 1 + bb 0.5 without the adx filter, got %.2f", got)
+	}
+}
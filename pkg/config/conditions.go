@@ -0,0 +1,81 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/expr"
+)
+
+// ConditionsConfig holds custom conditions written in the expression language
+// of pkg/expr, e.g. "rsi(14) < 30 && close < bb(20,2).lower". They are checked
+// on top of the indicator signals on every candle.
+type ConditionsConfig struct {
+	Entry       []string           `json:"entry,omitempty"`        // All must hold for an entry
+	Veto        []string           `json:"veto,omitempty"`         // Any that holds blocks entries
+	TPModifiers []TPModifierConfig `json:"tp_modifiers,omitempty"` // Scale the take profit while their condition holds
+}
+
+// TPModifierConfig scales the take profit percentage by Multiplier while the
+// When condition holds; modifiers that hold together compound
+type TPModifierConfig struct {
+	When       string  `json:"when"`
+	Multiplier float64 `json:"multiplier"`
+}
+
+// IsEmpty returns true if no condition is configured
+func (c *ConditionsConfig) IsEmpty() bool {
+	return c == nil || (len(c.Entry) == 0 && len(c.Veto) == 0 && len(c.TPModifiers) == 0)
+}
+
+// HasTPModifiers returns true if any take profit modifier is configured
+func (c *ConditionsConfig) HasTPModifiers() bool {
+	return c != nil && len(c.TPModifiers) > 0
+}
+
+// DynamicTPFor returns the dynamic TP configuration the strategy should use:
+// dynamicTP itself, or a fixed tpPercent base for the TP modifiers when no
+// dynamic TP is configured
+func (c *ConditionsConfig) DynamicTPFor(dynamicTP *DynamicTPConfig, tpPercent float64) *DynamicTPConfig {
+	if dynamicTP != nil || !c.HasTPModifiers() {
+		return dynamicTP
+	}
+	return &DynamicTPConfig{Strategy: "fixed", BaseTPPercent: tpPercent}
+}
+
+// Clone returns a deep copy of the configuration
+func (c *ConditionsConfig) Clone() *ConditionsConfig {
+	if c == nil {
+		return nil
+	}
+	return &ConditionsConfig{
+		Entry:       append([]string(nil), c.Entry...),
+		Veto:        append([]string(nil), c.Veto...),
+		TPModifiers: append([]TPModifierConfig(nil), c.TPModifiers...),
+	}
+}
+
+// Validate parses and type-checks every condition
+func (c *ConditionsConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	for _, src := range c.Entry {
+		if _, err := expr.ParseCondition(src); err != nil {
+			return fmt.Errorf("entry condition: %w", err)
+		}
+	}
+	for _, src := range c.Veto {
+		if _, err := expr.ParseCondition(src); err != nil {
+			return fmt.Errorf("veto condition: %w", err)
+		}
+	}
+	for _, m := range c.TPModifiers {
+		if _, err := expr.ParseCondition(m.When); err != nil {
+			return fmt.Errorf("tp modifier: %w", err)
+		}
+		if m.Multiplier <= 0 {
+			return fmt.Errorf("tp modifier %q: multiplier must be positive, got %.4f", m.When, m.Multiplier)
+		}
+	}
+	return nil
+}
@@ -1,5 +1,7 @@
 package config
 
+import "github.com/ducminhle1904/crypto-dca-bot/internal/risk"
+
 // DCA-specific configuration constants
 const (
 	// Default DCA parameter values
@@ -39,6 +41,21 @@ const (
 	DefaultStochasticRSIPeriod = 14
 	DefaultStochasticRSIOverbought = 80.0
 	DefaultStochasticRSIOversold = 20.0
+	DefaultADXPeriod      = 14
+	DefaultADXThreshold   = 25.0
+	DefaultIchimokuTenkan = 9
+	DefaultIchimokuKijun  = 26
+	DefaultIchimokuSenkouB = 52
+	DefaultDonchianPeriod = 20
+	DefaultAVWAPAnchor    = "session"
+	DefaultAVWAPAnchorLookback = 50
+	DefaultAVWAPBandMultiplier = 2.0
+	DefaultCMFPeriod      = 20
+	DefaultCMFThreshold   = 0.05
+	DefaultVolumeProfileLookback = 50
+	DefaultVolumeProfileBins = 24
+	DefaultVolumeProfileNodeFactor = 1.5
+	DefaultVolumeProfileProximity = 0.01
 	
 	// Technical indicator validation constants
 	MinRSIPeriod           = 2     // Minimum RSI period
@@ -53,6 +70,11 @@ const (
 	MinMFIPeriod           = 2     // Minimum MFI period
 	MinKeltnerPeriod       = 2     // Minimum Keltner period
 	MinWaveTrendPeriod     = 2     // Minimum WaveTrend period
+	MinADXPeriod           = 2     // Minimum ADX period
+	MinIchimokuPeriod      = 1     // Minimum Ichimoku line period
+	MinDonchianPeriod      = 2     // Minimum Donchian period
+	MinCMFPeriod           = 2     // Minimum CMF period
+	MinVolumeProfileBins   = 2     // Minimum volume profile bins
 )
 
 // DCAConfig holds all configuration for DCA backtesting
@@ -64,6 +86,16 @@ type DCAConfig struct {
 	Commission     float64 `json:"commission"`
 	WindowSize     int     `json:"window_size"`
 	
+	// Decisions recorded for the report (off, entries, all) - a run option, never saved
+	DecisionSnapshots string `json:"-"`
+	
+	// Registered strategy (enhanced_dca when omitted) and its parameters
+	StrategyName   string             `json:"strategy_name,omitempty"`
+	StrategyParams map[string]float64 `json:"strategy_params,omitempty"`
+	
+	// Build indicators and sizing as the live bot always has - set by the live bot, never saved
+	LiveDefaults bool `json:"-"`
+	
 	// DCA Strategy parameters
 	BaseAmount     float64 `json:"base_amount"`
 	MaxMultiplier  float64 `json:"max_multiplier"`
@@ -71,6 +103,21 @@ type DCAConfig struct {
 	// DCA Spacing Strategy configuration
 	DCASpacing     *DCASpacingConfig `json:"dca_spacing,omitempty"`
 	
+	// Entry sizing mode (signal-based when omitted)
+	PositionSizing *PositionSizingConfig `json:"position_sizing,omitempty"`
+	
+	// Pre-placed limit-order DCA levels (market entries when omitted)
+	LimitGrid      *LimitGridConfig `json:"limit_grid,omitempty"`
+	
+	// Indicator signal aggregation (majority vote when omitted)
+	SignalAggregation *SignalAggregationConfig `json:"signal_aggregation,omitempty"`
+	
+	// Custom entry, veto and take profit conditions in the expression language
+	Conditions     *ConditionsConfig `json:"conditions,omitempty"`
+	
+	// Higher timeframe entry confirmations
+	Timeframes     Timeframes        `json:"timeframes,omitempty"`
+	
 	RSIPeriod      int     `json:"rsi_period"`
 	RSIOversold    float64 `json:"rsi_oversold"`
 	RSIOverbought  float64 `json:"rsi_overbought"`
@@ -100,6 +147,21 @@ type DCAConfig struct {
 	StochasticRSIPeriod int `json:"stochastic_rsi_period"`
 	StochasticRSIOverbought float64 `json:"stochastic_rsi_overbought"`
 	StochasticRSIOversold float64 `json:"stochastic_rsi_oversold"`
+	ADXPeriod      int     `json:"adx_period"`
+	ADXThreshold   float64 `json:"adx_threshold"`
+	IchimokuTenkan int     `json:"ichimoku_tenkan"`
+	IchimokuKijun  int     `json:"ichimoku_kijun"`
+	IchimokuSenkouB int    `json:"ichimoku_senkou_b"`
+	DonchianPeriod int     `json:"donchian_period"`
+	AVWAPAnchor    string  `json:"avwap_anchor"`          // session, cycle or highest_high
+	AVWAPAnchorLookback int `json:"avwap_anchor_lookback"` // Candles searched for the highest_high anchor
+	AVWAPBandMultiplier float64 `json:"avwap_band_multiplier"`
+	CMFPeriod      int     `json:"cmf_period"`
+	CMFThreshold   float64 `json:"cmf_threshold"`
+	VolumeProfileLookback int `json:"volume_profile_lookback"`
+	VolumeProfileBins int  `json:"volume_profile_bins"`
+	VolumeProfileNodeFactor float64 `json:"volume_profile_node_factor"`
+	VolumeProfileProximity float64 `json:"volume_profile_proximity"`
 	// Indicator inclusion
 	Indicators     []string `json:"indicators"`
 
@@ -113,6 +175,9 @@ type DCAConfig struct {
 	
 	// Minimum lot size for realistic simulation
 	MinOrderQty    float64 `json:"min_order_qty"`
+	
+	// Account-level risk guard, same rules as the live bot (zero disables a rule)
+	RiskLimits     risk.Limits `json:"risk_limits"`
 }
 
 // Implement Config interface
@@ -272,6 +337,66 @@ func (c *DCAConfig) SetStochasticRSIOversold(val float64) {
 	c.StochasticRSIOversold = val
 }
 
+func (c *DCAConfig) SetADXPeriod(val int) {
+	c.ADXPeriod = val
+}
+
+func (c *DCAConfig) SetADXThreshold(val float64) {
+	c.ADXThreshold = val
+}
+
+func (c *DCAConfig) SetIchimokuTenkan(val int) {
+	c.IchimokuTenkan = val
+}
+
+func (c *DCAConfig) SetIchimokuKijun(val int) {
+	c.IchimokuKijun = val
+}
+
+func (c *DCAConfig) SetIchimokuSenkouB(val int) {
+	c.IchimokuSenkouB = val
+}
+
+func (c *DCAConfig) SetDonchianPeriod(val int) {
+	c.DonchianPeriod = val
+}
+
+func (c *DCAConfig) SetAVWAPAnchor(val string) {
+	c.AVWAPAnchor = val
+}
+
+func (c *DCAConfig) SetAVWAPAnchorLookback(val int) {
+	c.AVWAPAnchorLookback = val
+}
+
+func (c *DCAConfig) SetAVWAPBandMultiplier(val float64) {
+	c.AVWAPBandMultiplier = val
+}
+
+func (c *DCAConfig) SetCMFPeriod(val int) {
+	c.CMFPeriod = val
+}
+
+func (c *DCAConfig) SetCMFThreshold(val float64) {
+	c.CMFThreshold = val
+}
+
+func (c *DCAConfig) SetVolumeProfileLookback(val int) {
+	c.VolumeProfileLookback = val
+}
+
+func (c *DCAConfig) SetVolumeProfileBins(val int) {
+	c.VolumeProfileBins = val
+}
+
+func (c *DCAConfig) SetVolumeProfileNodeFactor(val float64) {
+	c.VolumeProfileNodeFactor = val
+}
+
+func (c *DCAConfig) SetVolumeProfileProximity(val float64) {
+	c.VolumeProfileProximity = val
+}
+
 // NewDefaultDCAConfig creates a new DCA configuration with default values
 func NewDefaultDCAConfig() *DCAConfig {
 	return &DCAConfig{
@@ -308,6 +433,21 @@ func NewDefaultDCAConfig() *DCAConfig {
 		StochasticRSIPeriod: DefaultStochasticRSIPeriod,
 		StochasticRSIOverbought: DefaultStochasticRSIOverbought,
 		StochasticRSIOversold: DefaultStochasticRSIOversold,
+		ADXPeriod:      DefaultADXPeriod,
+		ADXThreshold:   DefaultADXThreshold,
+		IchimokuTenkan: DefaultIchimokuTenkan,
+		IchimokuKijun:  DefaultIchimokuKijun,
+		IchimokuSenkouB: DefaultIchimokuSenkouB,
+		DonchianPeriod: DefaultDonchianPeriod,
+		AVWAPAnchor:    DefaultAVWAPAnchor,
+		AVWAPAnchorLookback: DefaultAVWAPAnchorLookback,
+		AVWAPBandMultiplier: DefaultAVWAPBandMultiplier,
+		CMFPeriod:      DefaultCMFPeriod,
+		CMFThreshold:   DefaultCMFThreshold,
+		VolumeProfileLookback: DefaultVolumeProfileLookback,
+		VolumeProfileBins: DefaultVolumeProfileBins,
+		VolumeProfileNodeFactor: DefaultVolumeProfileNodeFactor,
+		VolumeProfileProximity: DefaultVolumeProfileProximity,
 		// DCASpacing is nil by default - uses legacy fixed spacing
 		DCASpacing:     nil,
 	}
@@ -321,10 +461,11 @@ type DCASpacingConfig struct {
 
 // DynamicTPConfig holds dynamic take profit configuration
 type DynamicTPConfig struct {
-	Strategy         string                `json:"strategy"`           // TP strategy: "fixed", "volatility_adaptive", "indicator_based"
+	Strategy         string                `json:"strategy"`           // TP strategy: "fixed", "volatility_adaptive", "indicator_based", "time_decay"
 	BaseTPPercent    float64               `json:"base_tp_percent"`    // Base TP percentage (e.g., 0.02)
 	VolatilityConfig *DynamicTPVolatilityConfig `json:"volatility_config,omitempty"` // Volatility-based TP config
 	IndicatorConfig  *DynamicTPIndicatorConfig  `json:"indicator_config,omitempty"`  // Indicator-based TP config
+	TimeDecayConfig  *DynamicTPTimeDecayConfig  `json:"time_decay_config,omitempty"` // Time-decay TP config
 }
 
 // DynamicTPVolatilityConfig holds volatility-adaptive TP configuration
@@ -343,6 +484,29 @@ type DynamicTPIndicatorConfig struct {
 	MaxTPPercent       float64            `json:"max_tp_percent"`      // Maximum TP
 }
 
+// DynamicTPTimeDecayConfig holds time-decay TP configuration. The TP falls
+// linearly from the base TP to the floor over DecayDays once the cycle is
+// older than GraceDays.
+type DynamicTPTimeDecayConfig struct {
+	GraceDays       float64 `json:"grace_days"`        // Cycle age before the TP starts to decay (e.g., 2)
+	DecayDays       float64 `json:"decay_days"`        // Days from the base TP down to the floor (e.g., 7)
+	FloorPercent    float64 `json:"floor_percent"`     // TP at the end of the decay, at least break-even plus fees (0 = 2x commission)
+	BreakEvenLevels int     `json:"break_even_levels"` // Use the floor once the cycle has this many entries (0 = off)
+}
+
+// MinTimeDecayFloor returns the lowest time-decay TP floor for a commission
+// rate: the buy and the sell fee, so a decayed exit never loses money
+func MinTimeDecayFloor(commission float64) float64 {
+	return 2 * commission
+}
+
+// SetTimeDecayFloorDefault sets an unset time-decay floor to MinTimeDecayFloor
+func (c *DynamicTPConfig) SetTimeDecayFloorDefault(commission float64) {
+	if c != nil && c.TimeDecayConfig != nil && c.TimeDecayConfig.FloorPercent == 0 {
+		c.TimeDecayConfig.FloorPercent = MinTimeDecayFloor(commission)
+	}
+}
+
 // GetDCASpacingConfig returns the spacing configuration, or nil for legacy fixed spacing
 func (c *DCAConfig) GetDCASpacingConfig() *DCASpacingConfig {
 	return c.DCASpacing
@@ -358,6 +522,12 @@ func (c *DCAConfig) HasSpacingStrategy() bool {
 	return c.DCASpacing != nil && c.DCASpacing.Strategy != ""
 }
 
+// LadderBudget returns the capital plan of the configured DCA ladder against
+// the initial balance, or nil when entries use signal sizing
+func (c *DCAConfig) LadderBudget() *LadderBudget {
+	return c.PositionSizing.LadderBudget(c.BaseAmount, c.InitialBalance)
+}
+
 // GetDynamicTPConfig returns the dynamic TP configuration, or nil for fixed TP
 func (c *DCAConfig) GetDynamicTPConfig() *DynamicTPConfig {
 	return c.DynamicTP
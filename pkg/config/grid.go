@@ -0,0 +1,47 @@
+package config
+
+import "fmt"
+
+// DefaultGridLevels is the number of resting DCA levels kept on the book when levels is not set
+const DefaultGridLevels = 3
+
+// LimitGridConfig enables pre-placed limit-order DCA. After the first (market)
+// entry the next DCA levels rest on the book as limit buys at the prices the
+// spacing strategy computes, instead of market buys after a candle closes
+// below the threshold. The grid is re-anchored after every fill and cancelled
+// when the cycle completes.
+type LimitGridConfig struct {
+	Enabled         bool    `json:"enabled"`
+	Levels          int     `json:"levels,omitempty"`           // Resting DCA levels kept on the book (default 3)
+	MakerCommission float64 `json:"maker_commission,omitempty"` // Backtest commission for grid fills (0 = use commission)
+}
+
+// IsEnabled returns true if DCA levels are pre-placed as limit orders
+func (g *LimitGridConfig) IsEnabled() bool {
+	return g != nil && g.Enabled
+}
+
+// GridLevels returns the number of resting DCA levels, or 0 if the grid is disabled
+func (g *LimitGridConfig) GridLevels() int {
+	if !g.IsEnabled() {
+		return 0
+	}
+	if g.Levels > 0 {
+		return g.Levels
+	}
+	return DefaultGridLevels
+}
+
+// Validate checks the grid parameters
+func (g *LimitGridConfig) Validate() error {
+	if g == nil {
+		return nil
+	}
+	if g.Levels < 0 {
+		return fmt.Errorf("levels must be non-negative, got %d", g.Levels)
+	}
+	if g.MakerCommission < 0 || g.MakerCommission > 0.01 {
+		return fmt.Errorf("maker_commission must be between 0 and 0.01, got %.6f", g.MakerCommission)
+	}
+	return nil
+}
@@ -7,6 +7,8 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/risk"
 )
 
 // DCAConfigManager implements ConfigManager for DCA configurations
@@ -54,6 +56,7 @@ func (m *DCAConfigManager) LoadConfig(configFile, dataFile, symbol string, balan
 			return nil, fmt.Errorf("failed to load config file: %w", err)
 		}
 	}
+	cfg.DynamicTP.SetTimeDecayFloorDefault(cfg.Commission)
 	
 	// Validate configuration
 	if err := m.ValidateConfig(cfg); err != nil {
@@ -101,9 +104,28 @@ func (m *DCAConfigManager) loadFromNestedConfig(data []byte, cfg *DCAConfig) err
 	cfg.Cycle = strategy.Cycle
 	cfg.Indicators = strategy.Indicators
 	
+	// Map the selected strategy
+	cfg.StrategyName = strategy.Name
+	cfg.StrategyParams = strategy.Params
+	
 	// Map DCA spacing strategy
 	cfg.DCASpacing = strategy.DCASpacing
 	
+	// Map entry sizing mode
+	cfg.PositionSizing = strategy.PositionSizing
+	
+	// Map limit-order grid
+	cfg.LimitGrid = strategy.LimitGrid
+	
+	// Map signal aggregation
+	cfg.SignalAggregation = strategy.SignalAggregation
+	
+	// Map expression conditions
+	cfg.Conditions = strategy.Conditions
+	
+	// Map higher timeframe confirmations
+	cfg.Timeframes = strategy.Timeframes
+	
 	// Map Dynamic TP strategy
 	cfg.DynamicTP = strategy.DynamicTP
 	
@@ -156,6 +178,33 @@ func (m *DCAConfigManager) loadFromNestedConfig(data []byte, cfg *DCAConfig) err
 		cfg.StochasticRSIOverbought = strategy.StochasticRSI.Overbought
 		cfg.StochasticRSIOversold = strategy.StochasticRSI.Oversold
 	}
+	if strategy.ADX != nil {
+		cfg.ADXPeriod = strategy.ADX.Period
+		cfg.ADXThreshold = strategy.ADX.Threshold
+	}
+	if strategy.Ichimoku != nil {
+		cfg.IchimokuTenkan = strategy.Ichimoku.Tenkan
+		cfg.IchimokuKijun = strategy.Ichimoku.Kijun
+		cfg.IchimokuSenkouB = strategy.Ichimoku.SenkouB
+	}
+	if strategy.Donchian != nil {
+		cfg.DonchianPeriod = strategy.Donchian.Period
+	}
+	if strategy.AnchoredVWAP != nil {
+		cfg.AVWAPAnchor = strategy.AnchoredVWAP.Anchor
+		cfg.AVWAPAnchorLookback = strategy.AnchoredVWAP.AnchorLookback
+		cfg.AVWAPBandMultiplier = strategy.AnchoredVWAP.BandMultiplier
+	}
+	if strategy.CMF != nil {
+		cfg.CMFPeriod = strategy.CMF.Period
+		cfg.CMFThreshold = strategy.CMF.Threshold
+	}
+	if strategy.VolumeProfile != nil {
+		cfg.VolumeProfileLookback = strategy.VolumeProfile.Lookback
+		cfg.VolumeProfileBins = strategy.VolumeProfile.Bins
+		cfg.VolumeProfileNodeFactor = strategy.VolumeProfile.NodeFactor
+		cfg.VolumeProfileProximity = strategy.VolumeProfile.Proximity
+	}
 
 	// Map risk parameters
 	if nestedCfg.Risk.InitialBalance > 0 {
@@ -167,6 +216,14 @@ func (m *DCAConfigManager) loadFromNestedConfig(data []byte, cfg *DCAConfig) err
 	if nestedCfg.Risk.MinOrderQty > 0 {
 		cfg.MinOrderQty = nestedCfg.Risk.MinOrderQty
 	}
+	cfg.RiskLimits = risk.Limits{
+		MaxCycleCapital:    nestedCfg.Risk.MaxCycleCapital,
+		MaxDCALevels:       nestedCfg.Risk.MaxDCALevels,
+		MaxDailyLoss:       nestedCfg.Risk.MaxDailyLoss,
+		MaxDrawdownPercent: nestedCfg.Risk.MaxDrawdownPercent,
+		MinFreeMargin:      nestedCfg.Risk.MinFreeMargin,
+		MaxOrderNotional:   nestedCfg.Risk.MaxOrderNotional,
+	}
 
 	return nil
 }
@@ -191,6 +248,8 @@ func (m *DCAConfigManager) ConvertToNested(cfg Config) (NestedConfig, error) {
 	
 	// Create strategy configuration
 	strategyConfig := StrategyConfig{
+		Name:           dcaCfg.StrategyName,
+		Params:         dcaCfg.StrategyParams,
 		Symbol:         dcaCfg.Symbol,
 		DataFile:       dcaCfg.DataFile,
 		BaseAmount:     dcaCfg.BaseAmount,
@@ -202,6 +261,11 @@ func (m *DCAConfigManager) ConvertToNested(cfg Config) (NestedConfig, error) {
 		Cycle:          dcaCfg.Cycle,
 		Indicators:     dcaCfg.Indicators,
 		DCASpacing:     dcaCfg.DCASpacing,
+		PositionSizing: dcaCfg.PositionSizing,
+		LimitGrid:      dcaCfg.LimitGrid,
+		SignalAggregation: dcaCfg.SignalAggregation,
+		Conditions:     dcaCfg.Conditions,
+		Timeframes:     dcaCfg.Timeframes,
 		DynamicTP:      dcaCfg.DynamicTP,
 	}
 	
@@ -267,6 +331,39 @@ func (m *DCAConfigManager) ConvertToNested(cfg Config) (NestedConfig, error) {
 				Overbought: dcaCfg.StochasticRSIOverbought,
 				Oversold:   dcaCfg.StochasticRSIOversold,
 			}
+		case "adx", "dmi":
+			strategyConfig.ADX = &ADXConfig{
+				Period:    dcaCfg.ADXPeriod,
+				Threshold: dcaCfg.ADXThreshold,
+			}
+		case "ichimoku", "ichi":
+			strategyConfig.Ichimoku = &IchimokuConfig{
+				Tenkan:  dcaCfg.IchimokuTenkan,
+				Kijun:   dcaCfg.IchimokuKijun,
+				SenkouB: dcaCfg.IchimokuSenkouB,
+			}
+		case "donchian", "dc":
+			strategyConfig.Donchian = &DonchianConfig{
+				Period: dcaCfg.DonchianPeriod,
+			}
+		case "anchored_vwap", "avwap", "vwap":
+			strategyConfig.AnchoredVWAP = &AnchoredVWAPConfig{
+				Anchor:         dcaCfg.AVWAPAnchor,
+				AnchorLookback: dcaCfg.AVWAPAnchorLookback,
+				BandMultiplier: dcaCfg.AVWAPBandMultiplier,
+			}
+		case "cmf", "chaikin":
+			strategyConfig.CMF = &CMFConfig{
+				Period:    dcaCfg.CMFPeriod,
+				Threshold: dcaCfg.CMFThreshold,
+			}
+		case "volume_profile", "vp", "vpvr":
+			strategyConfig.VolumeProfile = &VolumeProfileConfig{
+				Lookback:   dcaCfg.VolumeProfileLookback,
+				Bins:       dcaCfg.VolumeProfileBins,
+				NodeFactor: dcaCfg.VolumeProfileNodeFactor,
+				Proximity:  dcaCfg.VolumeProfileProximity,
+			}
 		}
 	}
 	
@@ -282,9 +379,15 @@ func (m *DCAConfigManager) ConvertToNested(cfg Config) (NestedConfig, error) {
 			},
 		},
 		Risk: RiskConfig{
-			InitialBalance: dcaCfg.InitialBalance,
-			Commission:     dcaCfg.Commission,
-			MinOrderQty:    dcaCfg.MinOrderQty,
+			InitialBalance:     dcaCfg.InitialBalance,
+			Commission:         dcaCfg.Commission,
+			MinOrderQty:        dcaCfg.MinOrderQty,
+			MaxCycleCapital:    dcaCfg.RiskLimits.MaxCycleCapital,
+			MaxDCALevels:       dcaCfg.RiskLimits.MaxDCALevels,
+			MaxDailyLoss:       dcaCfg.RiskLimits.MaxDailyLoss,
+			MaxDrawdownPercent: dcaCfg.RiskLimits.MaxDrawdownPercent,
+			MinFreeMargin:      dcaCfg.RiskLimits.MinFreeMargin,
+			MaxOrderNotional:   dcaCfg.RiskLimits.MaxOrderNotional,
 		},
 		Notifications: NotificationsConfig{
 			Enabled:       false,
@@ -9,6 +9,8 @@ type NestedConfig struct {
 }
 
 type StrategyConfig struct {
+	Name           string             `json:"name,omitempty"`   // Registered strategy (enhanced_dca when omitted)
+	Params         map[string]float64 `json:"params,omitempty"` // Parameters of the named strategy
 	Symbol         string             `json:"symbol"`
 	DataFile       string             `json:"data_file"`
 	BaseAmount     float64            `json:"base_amount"`
@@ -23,6 +25,21 @@ type StrategyConfig struct {
 	// DCA Spacing Strategy
 	DCASpacing     *DCASpacingConfig  `json:"dca_spacing,omitempty"`
 	
+	// Entry sizing mode
+	PositionSizing *PositionSizingConfig `json:"position_sizing,omitempty"`
+	
+	// Pre-placed limit-order DCA levels
+	LimitGrid      *LimitGridConfig   `json:"limit_grid,omitempty"`
+	
+	// Indicator signal aggregation
+	SignalAggregation *SignalAggregationConfig `json:"signal_aggregation,omitempty"`
+	
+	// Custom expression conditions
+	Conditions     *ConditionsConfig `json:"conditions,omitempty"`
+	
+	// Higher timeframe confirmations
+	Timeframes     Timeframes        `json:"timeframes,omitempty"`
+	
 	// Dynamic TP Strategy
 	DynamicTP      *DynamicTPConfig   `json:"dynamic_tp,omitempty"`
 	
@@ -37,6 +54,12 @@ type StrategyConfig struct {
 	WaveTrend      *WaveTrendConfig   `json:"wavetrend,omitempty"`
 	OBV            *OBVConfig         `json:"obv,omitempty"`
 	StochasticRSI  *StochasticRSIConfig `json:"stochastic_rsi,omitempty"`
+	ADX            *ADXConfig         `json:"adx,omitempty"`
+	Ichimoku       *IchimokuConfig    `json:"ichimoku,omitempty"`
+	Donchian       *DonchianConfig    `json:"donchian,omitempty"`
+	AnchoredVWAP   *AnchoredVWAPConfig `json:"anchored_vwap,omitempty"`
+	CMF            *CMFConfig         `json:"cmf,omitempty"`
+	VolumeProfile  *VolumeProfileConfig `json:"volume_profile,omitempty"`
 }
 
 type RSIConfig struct {
@@ -76,6 +99,14 @@ type RiskConfig struct {
 	InitialBalance float64 `json:"initial_balance"`
 	Commission     float64 `json:"commission"`
 	MinOrderQty    float64 `json:"min_order_qty"`
+	
+	// Account-level risk guard (0 disables a rule)
+	MaxCycleCapital    float64 `json:"max_cycle_capital,omitempty"`
+	MaxDCALevels       int     `json:"max_dca_levels,omitempty"`
+	MaxDailyLoss       float64 `json:"max_daily_loss,omitempty"`
+	MaxDrawdownPercent float64 `json:"max_drawdown_percent,omitempty"`
+	MinFreeMargin      float64 `json:"min_free_margin,omitempty"`
+	MaxOrderNotional   float64 `json:"max_order_notional,omitempty"`
 }
 
 type NotificationsConfig struct {
@@ -120,3 +151,36 @@ type StochasticRSIConfig struct {
 	Overbought float64 `json:"overbought"`
 	Oversold   float64 `json:"oversold"`
 }
+
+type ADXConfig struct {
+	Period    int     `json:"period"`
+	Threshold float64 `json:"threshold"` // ADX level of a strong trend
+}
+
+type IchimokuConfig struct {
+	Tenkan  int `json:"tenkan"`
+	Kijun   int `json:"kijun"` // Also the cloud displacement
+	SenkouB int `json:"senkou_b"`
+}
+
+type DonchianConfig struct {
+	Period int `json:"period"`
+}
+
+type AnchoredVWAPConfig struct {
+	Anchor         string  `json:"anchor"`          // session, cycle or highest_high
+	AnchorLookback int     `json:"anchor_lookback"` // Candles searched for the highest_high anchor
+	BandMultiplier float64 `json:"band_multiplier"` // Band width in standard deviations
+}
+
+type CMFConfig struct {
+	Period    int     `json:"period"`
+	Threshold float64 `json:"threshold"` // Accumulation above, distribution below its negative
+}
+
+type VolumeProfileConfig struct {
+	Lookback   int     `json:"lookback"`
+	Bins       int     `json:"bins"`
+	NodeFactor float64 `json:"node_factor"` // Bin volume vs average of a high-volume node
+	Proximity  float64 `json:"proximity"`   // Max distance above a node that counts as testing it
+}
@@ -0,0 +1,172 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Position sizing modes
+const (
+	SizingModeSignal      = "signal"       // Base amount scaled by indicator confidence × strength (default)
+	SizingModeVolumeScale = "volume_scale" // Base amount × volume_scale^level (classic safety-order ladder)
+	SizingModeCustom      = "custom"       // Base amount × level_multipliers[level]
+)
+
+// Ladder budget policies
+const (
+	BudgetPolicyWarn = "warn" // Report an oversized ladder and trade it as configured (default)
+	BudgetPolicyClip = "clip" // Scale every level down so the full ladder fits the balance
+)
+
+// DefaultLadderLevels is the ladder depth used by volume_scale when max_levels is not set
+const DefaultLadderLevels = 5
+
+// PositionSizingConfig selects how the amount of each DCA entry is sized.
+// Ladder modes (volume_scale, custom) size entries by DCA level alone and stop
+// buying once the ladder is exhausted.
+type PositionSizingConfig struct {
+	Mode             string    `json:"mode"`                        // "signal" (default), "volume_scale", "custom"
+	VolumeScale      float64   `json:"volume_scale,omitempty"`      // Amount multiplier per DCA level (volume_scale, e.g. 1.5)
+	LevelMultipliers []float64 `json:"level_multipliers,omitempty"` // Base amount multiplier per DCA level (custom, e.g. [1, 1, 2, 4])
+	MaxLevels        int       `json:"max_levels,omitempty"`        // Entries in the ladder including the first (volume_scale; custom uses the table length)
+	BudgetPolicy     string    `json:"budget_policy,omitempty"`     // "warn" (default) or "clip" when the ladder exceeds the balance
+}
+
+// LadderBudget is the pre-computed capital requirement of a full DCA ladder
+type LadderBudget struct {
+	Amounts  []float64 // Amount per DCA level, after clipping
+	Required float64   // Total capital of the ladder as configured
+	Total    float64   // Total capital of Amounts
+	Balance  float64   // Balance the ladder was checked against
+	Fits     bool      // Whether the configured ladder fits the balance
+	Clipped  bool      // Whether Amounts were scaled down to fit
+}
+
+// IsLadder returns true if entries are sized by DCA level rather than by signal
+func (p *PositionSizingConfig) IsLadder() bool {
+	return p != nil && (p.Mode == SizingModeVolumeScale || p.Mode == SizingModeCustom)
+}
+
+// Levels returns the number of entries in the ladder, or 0 for signal sizing
+func (p *PositionSizingConfig) Levels() int {
+	if !p.IsLadder() {
+		return 0
+	}
+	if p.Mode == SizingModeCustom {
+		return len(p.LevelMultipliers)
+	}
+	if p.MaxLevels > 0 {
+		return p.MaxLevels
+	}
+	return DefaultLadderLevels
+}
+
+// LevelMultiplier returns the base amount multiplier for a DCA level (0 = first
+// entry), or 0 if the level is beyond the ladder
+func (p *PositionSizingConfig) LevelMultiplier(level int) float64 {
+	if level < 0 || level >= p.Levels() {
+		return 0
+	}
+	if p.Mode == SizingModeCustom {
+		return p.LevelMultipliers[level]
+	}
+	return math.Pow(p.VolumeScale, float64(level))
+}
+
+// Validate checks the sizing mode and its parameters
+func (p *PositionSizingConfig) Validate() error {
+	if p == nil {
+		return nil
+	}
+	switch p.Mode {
+	case "", SizingModeSignal:
+	case SizingModeVolumeScale:
+		if p.VolumeScale <= 0 {
+			return fmt.Errorf("volume_scale must be positive, got %.4f", p.VolumeScale)
+		}
+		if p.MaxLevels < 0 {
+			return fmt.Errorf("max_levels must be non-negative, got %d", p.MaxLevels)
+		}
+	case SizingModeCustom:
+		if len(p.LevelMultipliers) == 0 {
+			return fmt.Errorf("custom sizing requires level_multipliers")
+		}
+		for i, m := range p.LevelMultipliers {
+			if m <= 0 {
+				return fmt.Errorf("level_multipliers[%d] must be positive, got %.4f", i, m)
+			}
+		}
+	default:
+		return fmt.Errorf("invalid position sizing mode: %s (must be %s, %s or %s)",
+			p.Mode, SizingModeSignal, SizingModeVolumeScale, SizingModeCustom)
+	}
+	switch p.BudgetPolicy {
+	case "", BudgetPolicyWarn, BudgetPolicyClip:
+	default:
+		return fmt.Errorf("invalid budget_policy: %s (must be %s or %s)", p.BudgetPolicy, BudgetPolicyWarn, BudgetPolicyClip)
+	}
+	return nil
+}
+
+// LadderBudget computes the amount of every ladder level for baseAmount and
+// checks the total against balance. With the clip policy an oversized ladder
+// is scaled down proportionally so it fits. Returns nil for signal sizing.
+func (p *PositionSizingConfig) LadderBudget(baseAmount, balance float64) *LadderBudget {
+	levels := p.Levels()
+	if levels == 0 {
+		return nil
+	}
+
+	budget := &LadderBudget{
+		Amounts: make([]float64, levels),
+		Balance: balance,
+	}
+	for i := range budget.Amounts {
+		budget.Amounts[i] = baseAmount * p.LevelMultiplier(i)
+		budget.Required += budget.Amounts[i]
+	}
+	budget.Total = budget.Required
+	budget.Fits = balance <= 0 || budget.Required <= balance
+
+	if !budget.Fits && p.BudgetPolicy == BudgetPolicyClip {
+		scale := balance / budget.Required
+		budget.Total = 0
+		for i := range budget.Amounts {
+			budget.Amounts[i] *= scale
+			budget.Total += budget.Amounts[i]
+		}
+		budget.Clipped = true
+	}
+	return budget
+}
+
+// String summarizes the ladder amounts and total against the balance
+func (b *LadderBudget) String() string {
+	if b == nil || len(b.Amounts) == 0 {
+		return "no ladder"
+	}
+	amounts := make([]string, len(b.Amounts))
+	for i, a := range b.Amounts {
+		amounts[i] = fmt.Sprintf("$%.2f", a)
+	}
+	summary := fmt.Sprintf("%d levels [%s], total $%.2f of $%.2f balance",
+		len(b.Amounts), strings.Join(amounts, ", "), b.Total, b.Balance)
+	if b.Clipped {
+		summary += fmt.Sprintf(" (clipped from $%.2f)", b.Required)
+	}
+	return summary
+}
+
+// Warning returns a message if the configured ladder does not fit the balance
+func (b *LadderBudget) Warning() string {
+	if b == nil || b.Fits {
+		return ""
+	}
+	if b.Clipped {
+		return fmt.Sprintf("DCA ladder needs $%.2f but balance is $%.2f - levels clipped by %.1f%% to fit",
+			b.Required, b.Balance, (1-b.Total/b.Required)*100)
+	}
+	return fmt.Sprintf("DCA ladder needs $%.2f but balance is $%.2f - the last levels cannot be funded (set budget_policy \"clip\" to scale it down)",
+		b.Required, b.Balance)
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/data"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/expr"
+)
+
+// TimeframeConfig confirms entries with conditions evaluated on the closed
+// candles of a higher timeframe, e.g. a 4h trend filter for a 5m strategy
+type TimeframeConfig struct {
+	Interval   string   `json:"interval"`            // e.g. "1h", "4h", "1d"
+	Conditions []string `json:"conditions"`          // All must hold for an entry
+	Window     int      `json:"window,omitempty"`    // Closed candles per decision (0 = twice what the conditions need)
+	DataFile   string   `json:"data_file,omitempty"` // Backtests: load these candles instead of resampling the data
+}
+
+// Timeframes lists the higher timeframes that confirm entries
+type Timeframes []TimeframeConfig
+
+// IsEmpty returns true if no higher timeframe is configured
+func (t Timeframes) IsEmpty() bool {
+	return len(t) == 0
+}
+
+// Clone returns a deep copy of the configuration
+func (t Timeframes) Clone() Timeframes {
+	if t == nil {
+		return nil
+	}
+	clone := make(Timeframes, len(t))
+	for i, tf := range t {
+		clone[i] = tf
+		clone[i].Conditions = append([]string(nil), tf.Conditions...)
+	}
+	return clone
+}
+
+// Validate checks the intervals and parses every condition
+func (t Timeframes) Validate() error {
+	seen := make(map[string]bool)
+	for _, tf := range t {
+		d, err := data.IntervalDuration(tf.Interval)
+		if err != nil {
+			return err
+		}
+		if seen[d.String()] {
+			return fmt.Errorf("timeframe %s is configured twice", tf.Interval)
+		}
+		seen[d.String()] = true
+
+		if len(tf.Conditions) == 0 {
+			return fmt.Errorf("timeframe %s has no conditions", tf.Interval)
+		}
+		for _, src := range tf.Conditions {
+			if _, err := expr.ParseCondition(src); err != nil {
+				return fmt.Errorf("timeframe %s: %w", tf.Interval, err)
+			}
+		}
+		if tf.Window < 0 {
+			return fmt.Errorf("timeframe %s: window must be non-negative, got %d", tf.Interval, tf.Window)
+		}
+	}
+	return nil
+}
@@ -53,6 +53,26 @@ func (v *DCAValidator) validateDCAConfig(cfg *DCAConfig) error {
 	}
 	// Note: DCA spacing can be added later from command line flags, so we don't require it here
 	
+	if err := cfg.PositionSizing.Validate(); err != nil {
+		return fmt.Errorf("invalid position sizing: %w", err)
+	}
+	
+	if err := cfg.LimitGrid.Validate(); err != nil {
+		return fmt.Errorf("invalid limit grid: %w", err)
+	}
+	
+	if err := cfg.SignalAggregation.ValidateFor(cfg.Indicators); err != nil {
+		return fmt.Errorf("invalid signal aggregation: %w", err)
+	}
+	
+	if err := cfg.Conditions.Validate(); err != nil {
+		return fmt.Errorf("invalid conditions: %w", err)
+	}
+	
+	if err := cfg.Timeframes.Validate(); err != nil {
+		return fmt.Errorf("invalid timeframes: %w", err)
+	}
+	
 	if cfg.TPPercent < 0 || cfg.TPPercent > MaxThreshold {
 		return fmt.Errorf("TP percent must be between 0 and %.2f (0-100%%), got: %.4f", MaxThreshold, cfg.TPPercent)
 	}
@@ -71,6 +91,10 @@ func (v *DCAValidator) validateDCAConfig(cfg *DCAConfig) error {
 		return fmt.Errorf("minimum order quantity must be non-negative, got: %.6f", cfg.MinOrderQty)
 	}
 	
+	if err := cfg.RiskLimits.Validate(); err != nil {
+		return fmt.Errorf("invalid risk limits: %w", err)
+	}
+	
 	return nil
 }
 
@@ -123,6 +147,30 @@ func (v *DCAValidator) validateIndicators(cfg *DCAConfig) error {
 			if err := v.validateWaveTrend(cfg); err != nil {
 				return err
 			}
+		case "adx", "dmi":
+			if err := v.validateADX(cfg); err != nil {
+				return err
+			}
+		case "ichimoku", "ichi":
+			if err := v.validateIchimoku(cfg); err != nil {
+				return err
+			}
+		case "donchian", "dc":
+			if err := v.validateDonchian(cfg); err != nil {
+				return err
+			}
+		case "anchored_vwap", "avwap", "vwap":
+			if err := v.validateAnchoredVWAP(cfg); err != nil {
+				return err
+			}
+		case "cmf", "chaikin":
+			if err := v.validateCMF(cfg); err != nil {
+				return err
+			}
+		case "volume_profile", "vp", "vpvr":
+			if err := v.validateVolumeProfile(cfg); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
@@ -255,6 +303,117 @@ func (v *DCAValidator) validateWaveTrend(cfg *DCAConfig) error {
 	return nil
 }
 
+// validateADX validates ADX indicator parameters
+func (v *DCAValidator) validateADX(cfg *DCAConfig) error {
+	if cfg.ADXPeriod < MinADXPeriod {
+		return fmt.Errorf("ADX period must be at least %d, got: %d", MinADXPeriod, cfg.ADXPeriod)
+	}
+	
+	if cfg.ADXThreshold <= 0 || cfg.ADXThreshold >= MaxRSIValue {
+		return fmt.Errorf("ADX threshold must be between 0 and %d, got: %.1f", MaxRSIValue, cfg.ADXThreshold)
+	}
+	
+	// The strategy sees window_size+1 candles
+	if required := 2 * cfg.ADXPeriod; required > cfg.WindowSize+1 {
+		return fmt.Errorf("ADX period %d needs %d candles, more than window size %d", cfg.ADXPeriod, required, cfg.WindowSize)
+	}
+	
+	return nil
+}
+
+// validateIchimoku validates Ichimoku indicator parameters
+func (v *DCAValidator) validateIchimoku(cfg *DCAConfig) error {
+	if cfg.IchimokuTenkan < MinIchimokuPeriod || cfg.IchimokuKijun < MinIchimokuPeriod || cfg.IchimokuSenkouB < MinIchimokuPeriod {
+		return fmt.Errorf("Ichimoku periods must be at least %d, got: tenkan=%d, kijun=%d, senkou_b=%d",
+			MinIchimokuPeriod, cfg.IchimokuTenkan, cfg.IchimokuKijun, cfg.IchimokuSenkouB)
+	}
+	
+	if cfg.IchimokuTenkan >= cfg.IchimokuKijun || cfg.IchimokuKijun >= cfg.IchimokuSenkouB {
+		return fmt.Errorf("Ichimoku periods must increase: tenkan (%d) < kijun (%d) < senkou_b (%d)",
+			cfg.IchimokuTenkan, cfg.IchimokuKijun, cfg.IchimokuSenkouB)
+	}
+	
+	// The cloud under the current candle was projected kijun candles ago
+	if required := cfg.IchimokuSenkouB + cfg.IchimokuKijun; required > cfg.WindowSize+1 {
+		return fmt.Errorf("Ichimoku senkou_b %d with kijun %d needs %d candles, more than window size %d",
+			cfg.IchimokuSenkouB, cfg.IchimokuKijun, required, cfg.WindowSize)
+	}
+	
+	return nil
+}
+
+// validateDonchian validates Donchian Channels indicator parameters
+func (v *DCAValidator) validateDonchian(cfg *DCAConfig) error {
+	if cfg.DonchianPeriod < MinDonchianPeriod {
+		return fmt.Errorf("Donchian period must be at least %d, got: %d", MinDonchianPeriod, cfg.DonchianPeriod)
+	}
+	
+	if cfg.DonchianPeriod > cfg.WindowSize {
+		return fmt.Errorf("Donchian period %d exceeds window size %d", cfg.DonchianPeriod, cfg.WindowSize)
+	}
+	
+	return nil
+}
+
+// validateAnchoredVWAP validates anchored VWAP indicator parameters
+func (v *DCAValidator) validateAnchoredVWAP(cfg *DCAConfig) error {
+	switch cfg.AVWAPAnchor {
+	case "session", "cycle":
+	case "highest_high":
+		if cfg.AVWAPAnchorLookback < 1 || cfg.AVWAPAnchorLookback > cfg.WindowSize {
+			return fmt.Errorf("anchored VWAP anchor lookback must be between 1 and window size %d, got: %d",
+				cfg.WindowSize, cfg.AVWAPAnchorLookback)
+		}
+	default:
+		return fmt.Errorf("anchored VWAP anchor must be session, cycle or highest_high, got: %q", cfg.AVWAPAnchor)
+	}
+	
+	if cfg.AVWAPBandMultiplier <= 0 {
+		return fmt.Errorf("anchored VWAP band multiplier must be positive, got: %.2f", cfg.AVWAPBandMultiplier)
+	}
+	
+	return nil
+}
+
+// validateCMF validates Chaikin Money Flow indicator parameters
+func (v *DCAValidator) validateCMF(cfg *DCAConfig) error {
+	if cfg.CMFPeriod < MinCMFPeriod {
+		return fmt.Errorf("CMF period must be at least %d, got: %d", MinCMFPeriod, cfg.CMFPeriod)
+	}
+	
+	if cfg.CMFPeriod > cfg.WindowSize {
+		return fmt.Errorf("CMF period %d exceeds window size %d", cfg.CMFPeriod, cfg.WindowSize)
+	}
+	
+	if cfg.CMFThreshold < 0 || cfg.CMFThreshold >= 1 {
+		return fmt.Errorf("CMF threshold must be between 0 and 1, got: %.3f", cfg.CMFThreshold)
+	}
+	
+	return nil
+}
+
+// validateVolumeProfile validates volume profile indicator parameters
+func (v *DCAValidator) validateVolumeProfile(cfg *DCAConfig) error {
+	if cfg.VolumeProfileLookback < 2 || cfg.VolumeProfileLookback > cfg.WindowSize {
+		return fmt.Errorf("volume profile lookback must be between 2 and window size %d, got: %d",
+			cfg.WindowSize, cfg.VolumeProfileLookback)
+	}
+	
+	if cfg.VolumeProfileBins < MinVolumeProfileBins {
+		return fmt.Errorf("volume profile bins must be at least %d, got: %d", MinVolumeProfileBins, cfg.VolumeProfileBins)
+	}
+	
+	if cfg.VolumeProfileNodeFactor < 1 {
+		return fmt.Errorf("volume profile node factor must be at least 1.0, got: %.2f", cfg.VolumeProfileNodeFactor)
+	}
+	
+	if cfg.VolumeProfileProximity <= 0 || cfg.VolumeProfileProximity >= 1 {
+		return fmt.Errorf("volume profile proximity must be between 0 and 1, got: %.4f", cfg.VolumeProfileProximity)
+	}
+	
+	return nil
+}
+
 // Implement the DCAConfig Validate method
 func (cfg *DCAConfig) Validate() error {
 	validator := NewDCAValidator()
@@ -0,0 +1,140 @@
+package data
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
+)
+
+// Candle timestamps are open times: a candle of interval d opened at t closes
+// at t+d and may only be read by decisions made at or after t+d.
+
+// weekAnchor is the Monday weekly candles open on (the Unix epoch is a Thursday)
+var weekAnchor = time.Date(1970, 1, 5, 0, 0, 0, 0, time.UTC)
+
+// IntervalDuration parses an interval such as "5m", "4h", "1d", "1w" or the
+// exchange form "240", "D", "W"
+func IntervalDuration(interval string) (time.Duration, error) {
+	s := strings.TrimSpace(interval)
+	if strings.HasSuffix(s, "M") {
+		return 0, fmt.Errorf("monthly interval %q is not supported", interval)
+	}
+	s = strings.ToLower(s)
+	switch s {
+	case "d":
+		return 24 * time.Hour, nil
+	case "w":
+		return 7 * 24 * time.Hour, nil
+	}
+
+	if minutes, err := strconv.Atoi(s); err == nil && minutes > 0 {
+		return time.Duration(minutes) * time.Minute, nil
+	}
+	if len(s) >= 2 {
+		if n, err := strconv.Atoi(s[:len(s)-1]); err == nil && n > 0 {
+			switch s[len(s)-1] {
+			case 'm':
+				return time.Duration(n) * time.Minute, nil
+			case 'h':
+				return time.Duration(n) * time.Hour, nil
+			case 'd':
+				return time.Duration(n) * 24 * time.Hour, nil
+			case 'w':
+				return time.Duration(n) * 7 * 24 * time.Hour, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("invalid interval %q (use e.g. 15m, 1h, 4h, 1d, 1w)", interval)
+}
+
+// InferInterval returns the most common spacing between consecutive candles
+func InferInterval(candles []types.OHLCV) time.Duration {
+	counts := make(map[time.Duration]int)
+	limit := len(candles)
+	if limit > 500 {
+		limit = 500
+	}
+	for i := 1; i < limit; i++ {
+		if d := candles[i].Timestamp.Sub(candles[i-1].Timestamp); d > 0 {
+			counts[d]++
+		}
+	}
+
+	var interval time.Duration
+	best := 0
+	for d, n := range counts {
+		if n > best || (n == best && d < interval) {
+			interval, best = d, n
+		}
+	}
+	return interval
+}
+
+// bucketStart returns the open time of the interval candle containing t.
+// Candles are aligned to UTC midnight; weekly candles open on Mondays.
+func bucketStart(t time.Time, interval time.Duration) time.Time {
+	anchor := time.Unix(0, 0).UTC()
+	if interval%(7*24*time.Hour) == 0 {
+		anchor = weekAnchor
+	}
+	elapsed := t.Sub(anchor)
+	start := elapsed - elapsed%interval
+	if elapsed < 0 && elapsed%interval != 0 {
+		start -= interval
+	}
+	return anchor.Add(start)
+}
+
+// Resample aggregates candles of a lower interval into candles of the given
+// interval. A leading candle that would miss the start of its period is
+// dropped; the last candle may still be forming and is only readable once
+// closed (see ClosedCount).
+func Resample(candles []types.OHLCV, interval time.Duration) ([]types.OHLCV, error) {
+	if len(candles) == 0 {
+		return nil, nil
+	}
+	base := InferInterval(candles)
+	if base <= 0 {
+		return nil, fmt.Errorf("cannot infer the interval of the data")
+	}
+	if interval <= base || interval%base != 0 {
+		return nil, fmt.Errorf("cannot resample %v candles to %v: the interval must be a higher multiple of the data interval", base, interval)
+	}
+
+	var out []types.OHLCV
+	for _, c := range candles {
+		start := bucketStart(c.Timestamp, interval)
+		if len(out) == 0 && !c.Timestamp.Equal(start) {
+			continue // Partial leading period
+		}
+		if len(out) == 0 || !out[len(out)-1].Timestamp.Equal(start) {
+			out = append(out, types.OHLCV{
+				Open: c.Open, High: c.High, Low: c.Low, Close: c.Close, Volume: c.Volume,
+				Timestamp: start,
+			})
+			continue
+		}
+		last := &out[len(out)-1]
+		if c.High > last.High {
+			last.High = c.High
+		}
+		if c.Low < last.Low {
+			last.Low = c.Low
+		}
+		last.Close = c.Close
+		last.Volume += c.Volume
+	}
+	return out, nil
+}
+
+// ClosedCount returns how many of the candles, sorted by open time, have
+// closed by at
+func ClosedCount(candles []types.OHLCV, interval time.Duration, at time.Time) int {
+	return sort.Search(len(candles), func(i int) bool {
+		return candles[i].Timestamp.Add(interval).After(at)
+	})
+}
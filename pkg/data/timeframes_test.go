@@ -0,0 +1,138 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
+)
+
+// series returns n candles of the given interval from start; candle i opens
+// at 100+i, closes at 101+i and trades volume 1
+func series(start time.Time, interval time.Duration, n int) []types.OHLCV {
+	candles := make([]types.OHLCV, n)
+	for i := range candles {
+		p := 100 + float64(i)
+		candles[i] = types.OHLCV{
+			Timestamp: start.Add(time.Duration(i) * interval),
+			Open:      p, High: p + 2, Low: p - 1, Close: p + 1, Volume: 1,
+		}
+	}
+	return candles
+}
+
+func TestResampleDropsPartialLeadingBucket(t *testing.T) {
+	// 1h candles from 02:00 to 13:00: the 00:00 4h bucket misses its first
+	// two hours and is dropped
+	start := time.Date(2024, 3, 1, 2, 0, 0, 0, time.UTC)
+	out, err := Resample(series(start, time.Hour, 12), 4*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []types.OHLCV{
+		{Timestamp: start.Add(2 * time.Hour), Open: 102, High: 107, Low: 101, Close: 106, Volume: 4},
+		{Timestamp: start.Add(6 * time.Hour), Open: 106, High: 111, Low: 105, Close: 110, Volume: 4},
+		// Still forming: two of four hours, readable only once closed
+		{Timestamp: start.Add(10 * time.Hour), Open: 110, High: 113, Low: 109, Close: 112, Volume: 2},
+	}
+	if len(out) != len(want) {
+		t.Fatalf("expected %d candles, got %d: %+v", len(want), len(out), out)
+	}
+	for i := range want {
+		if out[i] != want[i] {
+			t.Errorf("candle %d: expected %+v, got %+v", i, want[i], out[i])
+		}
+	}
+}
+
+func TestResampleWeeklyAnchorsOnMonday(t *testing.T) {
+	week := 7 * 24 * time.Hour
+	monday := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) // 2024-01-01 is a Monday
+
+	tests := []struct {
+		name      string
+		start     time.Time
+		days      int
+		wantFirst time.Time
+		wantCount int
+	}{
+		{name: "starts on monday", start: monday, days: 14, wantFirst: monday, wantCount: 2},
+		{name: "starts on wednesday", start: monday.AddDate(0, 0, 2), days: 14, wantFirst: monday.AddDate(0, 0, 7), wantCount: 2},
+		{name: "starts on sunday", start: monday.AddDate(0, 0, -1), days: 8, wantFirst: monday, wantCount: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := Resample(series(tt.start, 24*time.Hour, tt.days), week)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(out) != tt.wantCount {
+				t.Fatalf("expected %d weekly candles, got %d", tt.wantCount, len(out))
+			}
+			for i, c := range out {
+				if c.Timestamp.Weekday() != time.Monday {
+					t.Errorf("weekly candle %d opens on %s", i, c.Timestamp.Weekday())
+				}
+			}
+			if !out[0].Timestamp.Equal(tt.wantFirst) {
+				t.Errorf("expected the first weekly candle at %s, got %s", tt.wantFirst, out[0].Timestamp)
+			}
+			if out[0].Volume != 7 {
+				t.Errorf("expected a full first week, got volume %.0f", out[0].Volume)
+			}
+		})
+	}
+}
+
+func TestResampleRejectsLowerInterval(t *testing.T) {
+	candles := series(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Hour, 10)
+	for _, interval := range []time.Duration{time.Hour, 30 * time.Minute, 90 * time.Minute} {
+		if _, err := Resample(candles, interval); err == nil {
+			t.Errorf("expected resampling 1h candles to %v to fail", interval)
+		}
+	}
+}
+
+func TestClosedCountBoundaries(t *testing.T) {
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	candles := series(start, 4*time.Hour, 3) // 00:00, 04:00, 08:00
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want int
+	}{
+		{name: "before the first close", at: start.Add(4*time.Hour - time.Nanosecond), want: 0},
+		{name: "first candle closes exactly at the decision", at: start.Add(4 * time.Hour), want: 1},
+		{name: "second candle one tick before its close", at: start.Add(8*time.Hour - time.Nanosecond), want: 1},
+		{name: "second candle closes exactly at the decision", at: start.Add(8 * time.Hour), want: 2},
+		{name: "last candle closed", at: start.Add(12 * time.Hour), want: 3},
+		{name: "decision long after", at: start.Add(48 * time.Hour), want: 3},
+		{name: "decision before the data", at: start.Add(-time.Hour), want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClosedCount(candles, 4*time.Hour, tt.at); got != tt.want {
+				t.Errorf("expected %d closed candles at %s, got %d", tt.want, tt.at.Format(time.RFC3339Nano), got)
+			}
+		})
+	}
+}
+
+func TestIntervalDuration(t *testing.T) {
+	tests := map[string]time.Duration{
+		"5m": 5 * time.Minute, "4h": 4 * time.Hour, "1d": 24 * time.Hour, "1w": 7 * 24 * time.Hour,
+		"240": 4 * time.Hour, "D": 24 * time.Hour, "W": 7 * 24 * time.Hour,
+	}
+	for interval, want := range tests {
+		if got, err := IntervalDuration(interval); err != nil || got != want {
+			t.Errorf("%s: expected %v, got %v (%v)", interval, want, got, err)
+		}
+	}
+	for _, interval := range []string{"", "1M", "0h", "abc"} {
+		if _, err := IntervalDuration(interval); err == nil {
+			t.Errorf("%q: expected an error", interval)
+		}
+	}
+}
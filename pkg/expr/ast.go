@@ -0,0 +1,292 @@
+package expr
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Type is the static type of an expression
+type Type int
+
+const (
+	TypeNumber Type = iota
+	TypeBool
+)
+
+func (t Type) String() string {
+	if t == TypeBool {
+		return "bool"
+	}
+	return "number"
+}
+
+// Call is a reference to an indicator output, e.g. bb(20,2).lower, or to its
+// value some candles back, e.g. ema(200)[1]
+type Call struct {
+	Name   string
+	Args   []float64
+	Member string // Selected output, "" for the indicator's value
+	Offset int    // Candles back, 0 for the current candle
+}
+
+// Key identifies the indicator instance the call reads, e.g. "bb(20,2)" or
+// "ema(200)[1]"; calls that differ only by member share an instance
+func (c *Call) Key() string {
+	return c.signature() + formatOffset(c.Offset)
+}
+
+func (c *Call) String() string {
+	s := c.signature()
+	if c.Member != "" {
+		s += "." + c.Member
+	}
+	return s + formatOffset(c.Offset)
+}
+
+// signature formats the name and arguments, e.g. "bb(20,2)"
+func (c *Call) signature() string {
+	args := make([]string, len(c.Args))
+	for i, a := range c.Args {
+		args[i] = formatNumber(a)
+	}
+	return c.Name + "(" + strings.Join(args, ",") + ")"
+}
+
+// formatOffset formats a candle offset as "[n]", "" for the current candle
+func formatOffset(offset int) string {
+	if offset == 0 {
+		return ""
+	}
+	return "[" + strconv.Itoa(offset) + "]"
+}
+
+// value is the result of evaluating a node
+type value struct {
+	num     float64
+	boolean bool
+}
+
+// node is a type-checked expression node
+type node interface {
+	typ() Type
+	String() string
+	eval(env Env, trace *[]string) (value, error)
+}
+
+type numberNode struct{ v float64 }
+
+func (n *numberNode) typ() Type      { return TypeNumber }
+func (n *numberNode) String() string { return formatNumber(n.v) }
+func (n *numberNode) eval(Env, *[]string) (value, error) {
+	return value{num: n.v}, nil
+}
+
+type boolNode struct{ v bool }
+
+func (n *boolNode) typ() Type      { return TypeBool }
+func (n *boolNode) String() string { return strconv.FormatBool(n.v) }
+func (n *boolNode) eval(Env, *[]string) (value, error) {
+	return value{boolean: n.v}, nil
+}
+
+type fieldNode struct {
+	name   string
+	offset int
+}
+
+func (n *fieldNode) typ() Type      { return TypeNumber }
+func (n *fieldNode) String() string { return n.name + formatOffset(n.offset) }
+func (n *fieldNode) eval(env Env, _ *[]string) (value, error) {
+	v, err := env.Field(n.name, n.offset)
+	if err != nil {
+		return value{}, fmt.Errorf("%s: %w", n, err)
+	}
+	return value{num: v}, nil
+}
+
+type callNode struct{ call *Call }
+
+func (n *callNode) typ() Type      { return TypeNumber }
+func (n *callNode) String() string { return n.call.String() }
+func (n *callNode) eval(env Env, _ *[]string) (value, error) {
+	v, err := env.Indicator(n.call)
+	if err != nil {
+		return value{}, fmt.Errorf("%s: %w", n.call, err)
+	}
+	return value{num: v}, nil
+}
+
+type builtinNode struct {
+	name string
+	args []node
+}
+
+func (n *builtinNode) typ() Type { return TypeNumber }
+func (n *builtinNode) String() string {
+	args := make([]string, len(n.args))
+	for i, a := range n.args {
+		args[i] = a.String()
+	}
+	return n.name + "(" + strings.Join(args, ", ") + ")"
+}
+func (n *builtinNode) eval(env Env, trace *[]string) (value, error) {
+	args := make([]float64, len(n.args))
+	for i, a := range n.args {
+		v, err := a.eval(env, trace)
+		if err != nil {
+			return value{}, err
+		}
+		args[i] = v.num
+	}
+	switch n.name {
+	case "abs":
+		return value{num: math.Abs(args[0])}, nil
+	case "min":
+		return value{num: math.Min(args[0], args[1])}, nil
+	default:
+		return value{num: math.Max(args[0], args[1])}, nil
+	}
+}
+
+type unaryNode struct {
+	op string
+	x  node
+}
+
+func (n *unaryNode) typ() Type { return n.x.typ() }
+func (n *unaryNode) String() string {
+	return n.op + wrap(n.x, precUnary)
+}
+func (n *unaryNode) eval(env Env, trace *[]string) (value, error) {
+	v, err := n.x.eval(env, trace)
+	if err != nil {
+		return value{}, err
+	}
+	if n.op == "!" {
+		return value{boolean: !v.boolean}, nil
+	}
+	return value{num: -v.num}, nil
+}
+
+type binaryNode struct {
+	op   string
+	x, y node
+}
+
+func (n *binaryNode) typ() Type {
+	switch n.op {
+	case "+", "-", "*", "/":
+		return TypeNumber
+	default:
+		return TypeBool
+	}
+}
+
+func (n *binaryNode) String() string {
+	prec := precedence(n.op)
+	// Operators are left-associative, so a right operand of equal
+	// precedence keeps its parentheses
+	return wrap(n.x, prec) + " " + n.op + " " + wrap(n.y, prec+1)
+}
+
+// eval evaluates both operands of logical operators so the trace covers
+// every sub-condition, and records each comparison with its operand values
+func (n *binaryNode) eval(env Env, trace *[]string) (value, error) {
+	x, err := n.x.eval(env, trace)
+	if err != nil {
+		return value{}, err
+	}
+	y, err := n.y.eval(env, trace)
+	if err != nil {
+		return value{}, err
+	}
+
+	var result value
+	switch n.op {
+	case "&&":
+		result.boolean = x.boolean && y.boolean
+	case "||":
+		result.boolean = x.boolean || y.boolean
+	case "+":
+		result.num = x.num + y.num
+	case "-":
+		result.num = x.num - y.num
+	case "*":
+		result.num = x.num * y.num
+	case "/":
+		if y.num == 0 {
+			return value{}, fmt.Errorf("division by zero in %s", n)
+		}
+		result.num = x.num / y.num
+	case "==", "!=":
+		if n.x.typ() == TypeBool {
+			result.boolean = (x.boolean == y.boolean) == (n.op == "==")
+		} else {
+			result.boolean = (x.num == y.num) == (n.op == "==")
+		}
+	case "<":
+		result.boolean = x.num < y.num
+	case "<=":
+		result.boolean = x.num <= y.num
+	case ">":
+		result.boolean = x.num > y.num
+	case ">=":
+		result.boolean = x.num >= y.num
+	}
+
+	if isComparison(n.op) && n.x.typ() == TypeNumber {
+		*trace = append(*trace, fmt.Sprintf("%s: %t (%s %s %s)",
+			n, result.boolean, formatNumber(x.num), n.op, formatNumber(y.num)))
+	}
+	return result, nil
+}
+
+// Operator precedence, loosest first
+const (
+	precOr = iota + 1
+	precAnd
+	precCompare
+	precAdd
+	precMul
+	precUnary
+)
+
+func precedence(op string) int {
+	switch op {
+	case "||":
+		return precOr
+	case "&&":
+		return precAnd
+	case "+", "-":
+		return precAdd
+	case "*", "/":
+		return precMul
+	default:
+		return precCompare
+	}
+}
+
+func isComparison(op string) bool {
+	return precedence(op) == precCompare
+}
+
+// wrap formats n, parenthesized when it binds looser than prec
+func wrap(n node, prec int) string {
+	if b, ok := n.(*binaryNode); ok && precedence(b.op) < prec {
+		return "(" + b.String() + ")"
+	}
+	return n.String()
+}
+
+// formatNumber formats a number with at most 4 decimals and no trailing zeros
+func formatNumber(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 4, 64)
+	s = strings.TrimRight(s, "0")
+	s = strings.TrimSuffix(s, ".")
+	if s == "-0" {
+		s = "0"
+	}
+	return s
+}
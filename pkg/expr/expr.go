@@ -0,0 +1,102 @@
+// Package expr implements the expression language for custom strategy
+// conditions, e.g.
+//
+//	rsi(14) < 30 && close < bb(20,2).lower && ema(50) > ema(200)
+//
+// Expressions combine price and cycle fields (close, dca_level, pct_from_avg,
+// ...), indicator functions with literal parameters and an optional output
+// member (bb(20,2).lower, macd(12,26,9).histogram), the helpers abs, min and
+// max, arithmetic, comparisons and the logical operators !, && and ||. Price
+// fields and indicators can be read some candles back: close[1], ema(200)[1].
+// Expressions are type-checked when parsed; indicator values are supplied at
+// evaluation time by an Env.
+package expr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Env supplies the values an expression reads
+type Env interface {
+	// Field returns a price or cycle field by name, offset candles back
+	Field(name string, offset int) (float64, error)
+
+	// Indicator returns the current value of an indicator output
+	Indicator(call *Call) (float64, error)
+}
+
+// Expression is a parsed and type-checked expression
+type Expression struct {
+	source string
+	root   node
+	calls  []*Call
+}
+
+// Result is the outcome of evaluating an expression
+type Result struct {
+	Bool   bool     // Value of a condition
+	Number float64  // Value of a number expression
+	Trace  []string // Every comparison with its operand values and outcome
+}
+
+// Parse parses and type-checks an expression of any type
+func Parse(src string) (*Expression, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression %q: %w", src, err)
+	}
+	if len(tokens) == 1 {
+		return nil, fmt.Errorf("empty expression")
+	}
+
+	p := &parser{tokens: tokens}
+	root, err := p.parseBinary(precOr)
+	if err == nil && p.peek().kind != tokEOF {
+		t := p.peek()
+		err = fmt.Errorf("unexpected %s at column %d", t, t.pos+1)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression %q: %w", src, err)
+	}
+
+	return &Expression{source: strings.TrimSpace(src), root: root, calls: p.calls}, nil
+}
+
+// ParseCondition parses an expression that must evaluate to true or false
+func ParseCondition(src string) (*Expression, error) {
+	e, err := Parse(src)
+	if err != nil {
+		return nil, err
+	}
+	if e.Type() != TypeBool {
+		return nil, fmt.Errorf("invalid condition %q: evaluates to a number, not true/false", src)
+	}
+	return e, nil
+}
+
+// Type returns the static type of the expression
+func (e *Expression) Type() Type {
+	return e.root.typ()
+}
+
+// Calls returns the indicator outputs the expression reads, in source order
+func (e *Expression) Calls() []*Call {
+	return e.calls
+}
+
+// String returns the expression as written
+func (e *Expression) String() string {
+	return e.source
+}
+
+// Eval evaluates the expression. Both sides of && and || are evaluated so the
+// trace lists every comparison.
+func (e *Expression) Eval(env Env) (Result, error) {
+	var trace []string
+	v, err := e.root.eval(env, &trace)
+	if err != nil {
+		return Result{Trace: trace}, err
+	}
+	return Result{Bool: v.boolean, Number: v.num, Trace: trace}, nil
+}
@@ -0,0 +1,279 @@
+package expr
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// mapEnv serves fields by name and offset and indicators by call, e.g.
+// "bb(20,2).lower" or "ema(200)[1]"
+type mapEnv struct {
+	fields     map[string][]float64
+	indicators map[string]float64
+}
+
+func (e mapEnv) Field(name string, offset int) (float64, error) {
+	values, ok := e.fields[name]
+	if !ok || offset >= len(values) {
+		return 0, fmt.Errorf("not available")
+	}
+	return values[offset], nil
+}
+
+func (e mapEnv) Indicator(call *Call) (float64, error) {
+	v, ok := e.indicators[call.String()]
+	if !ok {
+		return 0, fmt.Errorf("not available")
+	}
+	return v, nil
+}
+
+var testEnv = mapEnv{
+	fields: map[string][]float64{
+		"close":        {95, 100, 104},
+		"open":         {98},
+		"dca_level":    {2},
+		"pct_from_avg": {-3.5},
+	},
+	indicators: map[string]float64{
+		"rsi(14)":              28,
+		"bb(20,2).lower":       96,
+		"ema(50)":              101,
+		"ema(200)":             99,
+		"ema(200)[1]":          99.5,
+		"macd(12,26,9)":        -0.4,
+		"macd(12,26,9).signal": -0.1,
+	},
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		src     string
+		wantErr string
+	}{
+		{src: "", wantErr: "empty expression"},
+		{src: "   ", wantErr: "empty expression"},
+		{src: "close <", wantErr: "unexpected end of expression at column 8"},
+		{src: "(close < 100", wantErr: `expected ")" but found end of expression`},
+		{src: "close < 100)", wantErr: `unexpected ")" at column 12`},
+		{src: "close 100", wantErr: `unexpected "100" at column 7`},
+		{src: "close # 1", wantErr: `unexpected character '#' at column 7`},
+		{src: "1.2.3 > 0", wantErr: `invalid number "1.2.3" at column 1`},
+
+		// Unknown identifiers
+		{src: "closing < 100", wantErr: `unknown field "closing" at column 1`},
+		{src: "vwap(20) > close", wantErr: `unknown function "vwap" at column 1`},
+		{src: "rsi(14).value < 30", wantErr: "rsi() at column 1 has no members"},
+		{src: "bb(20,2).mid < close", wantErr: "bb() has no member .mid (members: .upper, .middle, .lower, .percent_b)"},
+		{src: "close < bb(20,2)", wantErr: "bb() at column 9 needs a member"},
+
+		// Arguments
+		{src: "rsi() < 30", wantErr: "rsi(period) at column 1 takes 1 argument(s), got 0"},
+		{src: "rsi(14, 2) < 30", wantErr: "takes 1 argument(s), got 2"},
+		{src: "rsi(0) < 30", wantErr: "rsi() period must be positive, got 0"},
+		{src: "rsi(14.5) < 30", wantErr: "rsi() period must be a whole number, got 14.5"},
+		{src: "rsi(close) < 30", wantErr: `rsi() arguments must be number literals, found "close"`},
+		{src: "abs(1, 2) > 0", wantErr: "abs() at column 1 takes 1 argument(s), got 2"},
+		{src: "max(1 2) > 0", wantErr: `expected "," but found "2"`},
+		{src: "abs(close > 1) > 0", wantErr: "abs() at column 1 takes number arguments, got bool"},
+
+		// Candle offsets
+		{src: "close[1.5] > 0", wantErr: "must be a whole number from 0 to 500"},
+		{src: "close[501] > 0", wantErr: "must be a whole number from 0 to 500"},
+		{src: "close[1 > 0", wantErr: `expected "]" but found ">"`},
+		{src: "dca_level[1] > 0", wantErr: "dca_level at column 1 cannot be read candles back"},
+
+		// Types
+		{src: "close && true", wantErr: "operator && at column 7 needs bool operands, got number and bool"},
+		{src: "1 < 2 < 3", wantErr: "operator < at column 7 needs number operands, got bool and number"},
+		{src: "close + (1 < 2)", wantErr: "operator + at column 7 needs number operands, got number and bool"},
+		{src: "true == 1", wantErr: "operator == at column 6 needs bool operands, got bool and number"},
+		{src: "!close", wantErr: "operator ! at column 1 needs a bool operand, got number"},
+		{src: "-true", wantErr: "operator - at column 1 needs a number operand, got bool"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			_, err := Parse(tt.src)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestParseConditionRejectsNumbers(t *testing.T) {
+	if _, err := ParseCondition("close - open"); err == nil || !strings.Contains(err.Error(), "evaluates to a number") {
+		t.Errorf("expected a number expression to be rejected as a condition, got %v", err)
+	}
+	if _, err := ParseCondition("close > open"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestPrecedence(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string // Fully reduced form, parenthesized only where needed
+		num  float64
+	}{
+		{src: "1 + 2 * 3", want: "1 + 2 * 3", num: 7},
+		{src: "(1 + 2) * 3", want: "(1 + 2) * 3", num: 9},
+		{src: "1 + (2 * 3)", want: "1 + 2 * 3", num: 7},
+		{src: "10 - 4 - 3", want: "10 - 4 - 3", num: 3},
+		{src: "10 - (4 - 3)", want: "10 - (4 - 3)", num: 9},
+		{src: "100 / 10 / 5", want: "100 / 10 / 5", num: 2},
+		{src: "-2 * 3", want: "-2 * 3", num: -6},
+		{src: "2 - -3", want: "2 - -3", num: 5},
+		{src: "-(2 + 3) * 2", want: "-(2 + 3) * 2", num: -10},
+		{src: "max(1, 2) * abs(-3) - min(4, 5)", want: "max(1, 2) * abs(-3) - min(4, 5)", num: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			e, err := Parse(tt.src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := e.root.String(); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+			r, err := e.Eval(testEnv)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(r.Number-tt.num) > 1e-12 {
+				t.Errorf("expected %g, got %g", tt.num, r.Number)
+			}
+		})
+	}
+}
+
+func TestLogicalPrecedence(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+		ok   bool
+	}{
+		// && binds tighter than ||
+		{src: "true || false && false", want: "true || false && false", ok: true},
+		{src: "(true || false) && false", want: "(true || false) && false", ok: false},
+		// Comparisons bind tighter than && and looser than arithmetic
+		{src: "close + 1 > 104 && close < 110", want: "close + 1 > 104 && close < 110", ok: true},
+		{src: "!(close > 100) || dca_level == 2", want: "!(close > 100) || dca_level == 2", ok: true},
+		{src: "!true == false", want: "!true == false", ok: true},
+		{src: "1 < 2 == true", want: "1 < 2 == true", ok: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			e, err := ParseCondition(tt.src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := e.root.String(); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+			r, err := e.Eval(mapEnv{fields: map[string][]float64{"close": {104}, "dca_level": {2}}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r.Bool != tt.ok {
+				t.Errorf("expected %t, got %t", tt.ok, r.Bool)
+			}
+		})
+	}
+}
+
+func TestEval(t *testing.T) {
+	tests := []struct {
+		src string
+		ok  bool
+	}{
+		{src: "rsi(14) < 30 && close < bb(20,2).lower && ema(50) > ema(200)", ok: true},
+		{src: "ema(200) < ema(200)[1]", ok: true},
+		{src: "close < close[1] && close[1] < close[2]", ok: true},
+		{src: "macd(12,26,9) < macd(12,26,9).signal", ok: true},
+		{src: "RSI(14) >= 30 || DCA_LEVEL > 2", ok: false},
+		{src: "pct_from_avg <= -3.5 && dca_level != 0", ok: true},
+		{src: "abs(pct_from_avg) > 3", ok: true},
+		{src: "(close - open) / open * 100 < -3", ok: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			e, err := ParseCondition(tt.src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r, err := e.Eval(testEnv)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r.Bool != tt.ok {
+				t.Errorf("expected %t, got %t (trace %v)", tt.ok, r.Bool, r.Trace)
+			}
+		})
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	tests := []struct {
+		src     string
+		wantErr string
+	}{
+		{src: "close / (dca_level - 2) > 1", wantErr: "division by zero in close / (dca_level - 2)"},
+		{src: "close / 0 > 1", wantErr: "division by zero in close / 0"},
+		{src: "rsi(14) < 30 && close / (open - 98) > 0", wantErr: "division by zero"},
+		{src: "close[5] > 0", wantErr: "close[5]: not available"},
+		{src: "ema(20) > 0", wantErr: "ema(20): not available"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			e, err := Parse(tt.src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := e.Eval(testEnv); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestEvalTraceListsEveryComparison(t *testing.T) {
+	e, err := ParseCondition("rsi(14) < 30 || close > ema(50) * 2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := e.Eval(testEnv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"rsi(14) < 30: true (28 < 30)",
+		"close > ema(50) * 2: false (95 > 202)",
+	}
+	if !r.Bool || !reflect.DeepEqual(r.Trace, want) {
+		t.Errorf("expected true with trace %q, got %t with %q", want, r.Bool, r.Trace)
+	}
+}
+
+func TestCalls(t *testing.T) {
+	e, err := Parse("bb(20,2).lower < close && bb(20, 2.0).upper[1] > close && obv() > 0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, c := range e.Calls() {
+		got = append(got, c.String()+" key "+c.Key())
+	}
+	want := []string{
+		"bb(20,2).lower key bb(20,2)",
+		"bb(20,2).upper[1] key bb(20,2)[1]",
+		"obv() key obv()",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected calls %q, got %q", want, got)
+	}
+}
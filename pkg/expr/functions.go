@@ -0,0 +1,137 @@
+package expr
+
+import (
+	"sort"
+	"strings"
+)
+
+// Price and cycle fields readable by name
+const (
+	FieldOpen       = "open"
+	FieldHigh       = "high"
+	FieldLow        = "low"
+	FieldClose      = "close"
+	FieldVolume     = "volume"
+	FieldDCALevel   = "dca_level"    // Entries in the current cycle (0 when flat)
+	FieldPctFromAvg = "pct_from_avg" // Close vs average entry in percent (0 when flat)
+)
+
+// fields maps the field names to whether they can be read candles back
+var fields = map[string]bool{
+	FieldOpen:       true,
+	FieldHigh:       true,
+	FieldLow:        true,
+	FieldClose:      true,
+	FieldVolume:     true,
+	FieldDCALevel:   false,
+	FieldPctFromAvg: false,
+}
+
+// maxOffset bounds how many candles back a value can be read
+const maxOffset = 500
+
+// param is an indicator function parameter; all parameters must be positive
+// number literals, integers where the indicator takes a period
+type param struct {
+	name    string
+	integer bool
+}
+
+// function describes an indicator function. The first member is the value of
+// a call without a member, unless memberRequired is set.
+type function struct {
+	params         []param
+	members        []string
+	memberRequired bool
+}
+
+var (
+	period     = param{name: "period", integer: true}
+	multiplier = param{name: "multiplier"}
+)
+
+// functions maps indicator function names to their signatures
+var functions = map[string]function{
+	"rsi":        {params: []param{period}},
+	"sma":        {params: []param{period}},
+	"ema":        {params: []param{period}},
+	"hma":        {params: []param{period}},
+	"atr":        {params: []param{period}},
+	"mfi":        {params: []param{period}},
+	"cmf":        {params: []param{period}},
+	"stochrsi":   {params: []param{period}},
+	"obv":        {},
+	"supertrend": {params: []param{period, multiplier}},
+	"adx":        {params: []param{period}, members: []string{"adx", "plus_di", "minus_di"}},
+	"wavetrend":  {params: []param{{name: "n1", integer: true}, {name: "n2", integer: true}}, members: []string{"wt1", "wt2"}},
+	"macd": {
+		params:  []param{{name: "fast", integer: true}, {name: "slow", integer: true}, {name: "signal", integer: true}},
+		members: []string{"macd", "signal", "histogram"},
+	},
+	"bb": {
+		params:         []param{period, {name: "stddev"}},
+		members:        []string{"upper", "middle", "lower", "percent_b"},
+		memberRequired: true,
+	},
+	"keltner": {
+		params:         []param{period, multiplier},
+		members:        []string{"upper", "middle", "lower"},
+		memberRequired: true,
+	},
+	"donchian": {
+		params:         []param{period},
+		members:        []string{"upper", "middle", "lower"},
+		memberRequired: true,
+	},
+	"ichimoku": {
+		params:         []param{{name: "tenkan", integer: true}, {name: "kijun", integer: true}, {name: "senkou_b", integer: true}},
+		members:        []string{"tenkan", "kijun", "senkou_a", "senkou_b"},
+		memberRequired: true,
+	},
+}
+
+// builtins maps numeric helper functions to their argument counts
+var builtins = map[string]int{
+	"abs": 1,
+	"min": 2,
+	"max": 2,
+}
+
+// hasMember returns true if the function has the named output
+func (f function) hasMember(name string) bool {
+	for _, m := range f.members {
+		if m == name {
+			return true
+		}
+	}
+	return false
+}
+
+// memberList formats the outputs of a function for error messages
+func (f function) memberList() string {
+	names := make([]string, len(f.members))
+	for i, m := range f.members {
+		names[i] = "." + m
+	}
+	return strings.Join(names, ", ")
+}
+
+// Functions returns the names of the indicator functions, sorted
+func Functions() []string {
+	names := make([]string, 0, len(functions))
+	for name := range functions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Fields returns the names of the price and cycle fields, sorted
+func Fields() []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,109 @@
+package expr
+
+import (
+	"fmt"
+	"strconv"
+	"unicode"
+)
+
+// tokenKind identifies a lexical token
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNumber
+	tokIdent
+	tokLParen
+	tokRParen
+	tokComma
+	tokDot
+	tokLBracket
+	tokRBracket
+	tokOp
+)
+
+// token is one lexical token with its column in the source (0-based)
+type token struct {
+	kind tokenKind
+	text string
+	num  float64
+	pos  int
+}
+
+func (t token) String() string {
+	if t.kind == tokEOF {
+		return "end of expression"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// operators lists the operator tokens, two-character operators first
+var operators = []string{"&&", "||", "<=", ">=", "==", "!=", "<", ">", "!", "+", "-", "*", "/"}
+
+// lex splits src into tokens
+func lex(src string) ([]token, error) {
+	var tokens []token
+	runes := []rune(src)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			text := string(runes[start:i])
+			num, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q at column %d", text, start+1)
+			}
+			tokens = append(tokens, token{kind: tokNumber, text: text, num: num, pos: start})
+
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokIdent, text: string(runes[start:i]), pos: start})
+
+		case r == '(':
+			tokens = append(tokens, token{kind: tokLParen, text: "(", pos: i})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokRParen, text: ")", pos: i})
+			i++
+		case r == ',':
+			tokens = append(tokens, token{kind: tokComma, text: ",", pos: i})
+			i++
+		case r == '.':
+			tokens = append(tokens, token{kind: tokDot, text: ".", pos: i})
+			i++
+		case r == '[':
+			tokens = append(tokens, token{kind: tokLBracket, text: "[", pos: i})
+			i++
+		case r == ']':
+			tokens = append(tokens, token{kind: tokRBracket, text: "]", pos: i})
+			i++
+
+		default:
+			matched := false
+			for _, op := range operators {
+				if i+len(op) <= len(runes) && string(runes[i:i+len(op)]) == op {
+					tokens = append(tokens, token{kind: tokOp, text: op, pos: i})
+					i += len(op)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("unexpected character %q at column %d", r, i+1)
+			}
+		}
+	}
+
+	return append(tokens, token{kind: tokEOF, pos: len(runes)}), nil
+}
@@ -0,0 +1,254 @@
+package expr
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// parser is a recursive-descent parser that type-checks each node as it is built
+type parser struct {
+	tokens []token
+	pos    int
+	calls  []*Call
+}
+
+func (p *parser) peek() token { return p.tokens[p.pos] }
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) expect(kind tokenKind, text string) (token, error) {
+	t := p.next()
+	if t.kind != kind {
+		return t, fmt.Errorf("expected %q but found %s at column %d", text, t, t.pos+1)
+	}
+	return t, nil
+}
+
+// parseBinary parses left-associative binary operators from prec upwards
+func (p *parser) parseBinary(prec int) (node, error) {
+	if prec == precUnary {
+		return p.parseUnary()
+	}
+
+	x, err := p.parseBinary(prec + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		if t.kind != tokOp || t.text == "!" || precedence(t.text) != prec {
+			return x, nil
+		}
+		p.next()
+		y, err := p.parseBinary(prec + 1)
+		if err != nil {
+			return nil, err
+		}
+		if x, err = newBinary(t, x, y); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// newBinary checks the operand types of a binary operator
+func newBinary(op token, x, y node) (node, error) {
+	want := TypeNumber
+	switch op.text {
+	case "&&", "||":
+		want = TypeBool
+	case "==", "!=":
+		want = x.typ()
+	}
+	if x.typ() != want || y.typ() != want {
+		return nil, fmt.Errorf("operator %s at column %d needs %s operands, got %s and %s",
+			op.text, op.pos+1, want, x.typ(), y.typ())
+	}
+	return &binaryNode{op: op.text, x: x, y: y}, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	t := p.peek()
+	if t.kind == tokOp && (t.text == "!" || t.text == "-") {
+		p.next()
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		want := TypeNumber
+		if t.text == "!" {
+			want = TypeBool
+		}
+		if x.typ() != want {
+			return nil, fmt.Errorf("operator %s at column %d needs a %s operand, got %s", t.text, t.pos+1, want, x.typ())
+		}
+		if num, ok := x.(*numberNode); ok && t.text == "-" {
+			return &numberNode{v: -num.v}, nil
+		}
+		return &unaryNode{op: t.text, x: x}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokNumber:
+		return &numberNode{v: t.num}, nil
+
+	case tokLParen:
+		x, err := p.parseBinary(precOr)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokRParen, ")"); err != nil {
+			return nil, err
+		}
+		return x, nil
+
+	case tokIdent:
+		name := strings.ToLower(t.text)
+		if p.peek().kind == tokLParen {
+			if _, ok := builtins[name]; ok {
+				return p.parseBuiltin(t, name)
+			}
+			return p.parseCall(t, name)
+		}
+		switch name {
+		case "true", "false":
+			return &boolNode{v: name == "true"}, nil
+		}
+		offsetable, ok := fields[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown field %q at column %d (fields: %s)", t.text, t.pos+1, strings.Join(Fields(), ", "))
+		}
+		offset, err := p.parseOffset()
+		if err != nil {
+			return nil, err
+		}
+		if offset > 0 && !offsetable {
+			return nil, fmt.Errorf("%s at column %d cannot be read candles back", name, t.pos+1)
+		}
+		return &fieldNode{name: name, offset: offset}, nil
+	}
+
+	return nil, fmt.Errorf("unexpected %s at column %d", t, t.pos+1)
+}
+
+// parseOffset parses an optional "[n]" suffix reading a value n candles back
+func (p *parser) parseOffset() (int, error) {
+	if p.peek().kind != tokLBracket {
+		return 0, nil
+	}
+	p.next() // [
+	t := p.next()
+	if t.kind != tokNumber || t.num != math.Trunc(t.num) || t.num > maxOffset {
+		return 0, fmt.Errorf("candle offset at column %d must be a whole number from 0 to %d, found %s", t.pos+1, maxOffset, t)
+	}
+	if _, err := p.expect(tokRBracket, "]"); err != nil {
+		return 0, err
+	}
+	return int(t.num), nil
+}
+
+// parseBuiltin parses a numeric helper whose arguments are any number expressions
+func (p *parser) parseBuiltin(name token, fn string) (node, error) {
+	p.next() // (
+	var args []node
+	for p.peek().kind != tokRParen {
+		if len(args) > 0 {
+			if _, err := p.expect(tokComma, ","); err != nil {
+				return nil, err
+			}
+		}
+		arg, err := p.parseBinary(precOr)
+		if err != nil {
+			return nil, err
+		}
+		if arg.typ() != TypeNumber {
+			return nil, fmt.Errorf("%s() at column %d takes number arguments, got %s", fn, name.pos+1, arg.typ())
+		}
+		args = append(args, arg)
+	}
+	p.next() // )
+
+	if len(args) != builtins[fn] {
+		return nil, fmt.Errorf("%s() at column %d takes %d argument(s), got %d", fn, name.pos+1, builtins[fn], len(args))
+	}
+	return &builtinNode{name: fn, args: args}, nil
+}
+
+// parseCall parses an indicator call with literal arguments and an optional member
+func (p *parser) parseCall(name token, fn string) (node, error) {
+	f, ok := functions[fn]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %q at column %d (indicators: %s; helpers: abs, min, max)",
+			name.text, name.pos+1, strings.Join(Functions(), ", "))
+	}
+
+	p.next() // (
+	call := &Call{Name: fn}
+	for p.peek().kind != tokRParen {
+		if len(call.Args) > 0 {
+			if _, err := p.expect(tokComma, ","); err != nil {
+				return nil, err
+			}
+		}
+		arg := p.next()
+		if arg.kind != tokNumber {
+			return nil, fmt.Errorf("%s() arguments must be number literals, found %s at column %d", fn, arg, arg.pos+1)
+		}
+		call.Args = append(call.Args, arg.num)
+	}
+	p.next() // )
+
+	if len(call.Args) != len(f.params) {
+		names := make([]string, len(f.params))
+		for i, prm := range f.params {
+			names[i] = prm.name
+		}
+		return nil, fmt.Errorf("%s(%s) at column %d takes %d argument(s), got %d",
+			fn, strings.Join(names, ", "), name.pos+1, len(f.params), len(call.Args))
+	}
+	for i, prm := range f.params {
+		v := call.Args[i]
+		if v <= 0 {
+			return nil, fmt.Errorf("%s() %s must be positive, got %s", fn, prm.name, formatNumber(v))
+		}
+		if prm.integer && v != math.Trunc(v) {
+			return nil, fmt.Errorf("%s() %s must be a whole number, got %s", fn, prm.name, formatNumber(v))
+		}
+	}
+
+	if p.peek().kind == tokDot {
+		p.next()
+		member, err := p.expect(tokIdent, "member name")
+		if err != nil {
+			return nil, err
+		}
+		call.Member = strings.ToLower(member.text)
+		if !f.hasMember(call.Member) {
+			if len(f.members) == 0 {
+				return nil, fmt.Errorf("%s() at column %d has no members", fn, name.pos+1)
+			}
+			return nil, fmt.Errorf("%s() has no member .%s (members: %s)", fn, member.text, f.memberList())
+		}
+	} else if f.memberRequired {
+		return nil, fmt.Errorf("%s() at column %d needs a member: %s", fn, name.pos+1, f.memberList())
+	}
+
+	offset, err := p.parseOffset()
+	if err != nil {
+		return nil, err
+	}
+	call.Offset = offset
+
+	p.calls = append(p.calls, call)
+	return &callNode{call: call}, nil
+}
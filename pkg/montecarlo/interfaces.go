@@ -0,0 +1,113 @@
+package montecarlo
+
+import (
+	"time"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/backtest"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/config"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
+)
+
+// Package montecarlo estimates the spread of backtest outcomes by resampling
+// cycles, synthesizing alternate price paths and randomizing execution costs
+
+// Simulator runs Monte Carlo robustness experiments around a baseline backtest
+type Simulator interface {
+	Run(cfg *config.DCAConfig, data []types.OHLCV, baseline *backtest.BacktestResults) (*Results, error)
+}
+
+// BacktestFunc runs the strategy described by cfg on data. fill may be nil,
+// meaning entries fill at the signal candle close.
+type BacktestFunc func(cfg *config.DCAConfig, data []types.OHLCV, fill backtest.EntryFillFunc) *backtest.BacktestResults
+
+// Experiment names reported in ExperimentResult
+const (
+	ExperimentCycleResample = "cycle_resample" // Bootstrap of completed cycle outcomes
+	ExperimentPricePaths    = "price_paths"    // Strategy rerun on block-bootstrapped candles
+	ExperimentExecution     = "execution"      // Strategy rerun on the original candles with random latency and fees
+)
+
+// Defaults for a Monte Carlo run
+const (
+	DefaultRuns          = 500
+	DefaultBlockSize     = 24  // Candles per bootstrap block, keeps intraday autocorrelation
+	DefaultRuinThreshold = 0.5 // Drawdown counted as ruin
+)
+
+// Config controls a Monte Carlo run
+type Config struct {
+	Runs          int           // Simulations per experiment
+	Seed          int64         // RNG seed, 0 = time-based (reported in Results)
+	BlockSize     int           // Candles per bootstrap block
+	MaxLatency    time.Duration // Upper bound of the random entry delay, 0 disables latency
+	FeeJitter     float64       // Relative commission spread: 0.5 draws from [0.5x, 1.5x] of the configured fee
+	RuinThreshold float64       // Max drawdown at or above this counts as ruin
+	MaxWorkers    int           // Parallel reruns, 0 = number of CPUs
+}
+
+// DefaultConfig returns the default Monte Carlo settings
+func DefaultConfig() Config {
+	return Config{
+		Runs:          DefaultRuns,
+		BlockSize:     DefaultBlockSize,
+		RuinThreshold: DefaultRuinThreshold,
+	}
+}
+
+// Outcome holds the metrics of one simulated run
+type Outcome struct {
+	FinalReturn        float64       `json:"final_return"`         // Fraction of starting balance
+	MaxDrawdown        float64       `json:"max_drawdown"`         // Fraction of peak equity
+	MaxCapitalDeployed float64       `json:"max_capital_deployed"` // Largest gross cost held in one cycle
+	LongestOpenCycle   time.Duration `json:"-"`
+	Commission         float64       `json:"commission,omitempty"`
+}
+
+// Distribution summarizes one metric across all runs of an experiment
+type Distribution struct {
+	Mean   float64 `json:"mean"`
+	StdDev float64 `json:"std_dev"`
+	Min    float64 `json:"min"`
+	P5     float64 `json:"p5"`
+	P25    float64 `json:"p25"`
+	P50    float64 `json:"p50"`
+	P75    float64 `json:"p75"`
+	P95    float64 `json:"p95"`
+	Max    float64 `json:"max"`
+}
+
+// ExperimentResult holds the outcomes and metric distributions of one experiment
+type ExperimentResult struct {
+	Name                  string       `json:"name"`
+	Runs                  int          `json:"runs"`
+	FinalReturn           Distribution `json:"final_return"`
+	MaxDrawdown           Distribution `json:"max_drawdown"`
+	MaxCapitalDeployed    Distribution `json:"max_capital_deployed"`
+	LongestOpenCycleHours Distribution `json:"longest_open_cycle_hours"`
+	ProbabilityOfLoss     float64      `json:"probability_of_loss"` // Share of runs with a negative final return
+	ProbabilityOfRuin     float64      `json:"probability_of_ruin"` // Share of runs reaching the ruin drawdown
+	Outcomes              []Outcome    `json:"-"`
+}
+
+// Results is the output of a Monte Carlo run
+type Results struct {
+	Seed          int64              `json:"seed"`
+	Runs          int                `json:"runs"`
+	BlockSize     int                `json:"block_size"`
+	MaxLatency    time.Duration      `json:"-"`
+	FeeJitter     float64            `json:"fee_jitter"`
+	RuinThreshold float64            `json:"ruin_threshold"`
+	Baseline      Outcome            `json:"baseline"`
+	Experiments   []ExperimentResult `json:"experiments"`
+	Elapsed       time.Duration      `json:"-"`
+}
+
+// Experiment returns the named experiment, or nil if it was not run
+func (r *Results) Experiment(name string) *ExperimentResult {
+	for i := range r.Experiments {
+		if r.Experiments[i].Name == name {
+			return &r.Experiments[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+package montecarlo
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/backtest"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/config"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
+)
+
+// randomWalk builds n hourly candles from a seeded random walk
+func randomWalk(n int, seed int64) []types.OHLCV {
+	rng := rand.New(rand.NewSource(seed))
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	data := make([]types.OHLCV, n)
+	price := 100.0
+	for i := range data {
+		open := price
+		price *= 1 + 0.02*(rng.Float64()-0.5)
+		data[i] = types.OHLCV{
+			Timestamp: start.Add(time.Duration(i) * time.Hour),
+			Open:      open,
+			High:      math.Max(open, price) * 1.003,
+			Low:       math.Min(open, price) * 0.997,
+			Close:     price,
+			Volume:    1000 + float64(i),
+		}
+	}
+	return data
+}
+
+func testCycles() []backtest.CycleSummary {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	return []backtest.CycleSummary{
+		{CycleNumber: 1, StartTime: start, EndTime: start.Add(2 * time.Hour), RealizedPnL: 12, TotalGrossCost: 300, Completed: true},
+		{CycleNumber: 2, StartTime: start, EndTime: start.Add(30 * time.Hour), RealizedPnL: -40, TotalGrossCost: 900, Completed: true},
+		{CycleNumber: 3, StartTime: start, EndTime: start.Add(5 * time.Hour), RealizedPnL: 8, TotalGrossCost: 200, Completed: true},
+		{CycleNumber: 4, StartTime: start, EndTime: start.Add(9 * time.Hour), RealizedPnL: 20, TotalGrossCost: 500, Completed: true},
+	}
+}
+
+// pathBacktest is a stand-in for the strategy: it buys at the first candle
+// (through fill when set) and reports the return at the last close after
+// commission, with cycles so the resampling experiment runs too.
+func pathBacktest(cfg *config.DCAConfig, data []types.OHLCV, fill backtest.EntryFillFunc) *backtest.BacktestResults {
+	entry := data[0].Close
+	if fill != nil {
+		entry = fill(data, 0)
+	}
+	ret := data[len(data)-1].Close/entry - 1 - 2*cfg.Commission
+
+	low := entry
+	for _, c := range data {
+		low = math.Min(low, c.Low)
+	}
+	return &backtest.BacktestResults{
+		TotalReturn:  ret,
+		MaxDrawdown:  (entry - low) / entry,
+		StartBalance: 1000,
+		Cycles:       testCycles(),
+	}
+}
+
+func TestRunIsDeterministicForASeed(t *testing.T) {
+	data := randomWalk(300, 7)
+	cfg := &config.DCAConfig{WindowSize: 10, Commission: 0.001}
+	mc := Config{
+		Runs:       40,
+		Seed:       42,
+		BlockSize:  12,
+		MaxLatency: 90 * time.Minute,
+		FeeJitter:  0.5,
+		MaxWorkers: 4,
+	}
+
+	run := func(mc Config) *Results {
+		t.Helper()
+		results, err := NewSimulatorWithBacktest(mc, pathBacktest).Run(cfg, data, nil)
+		if err != nil {
+			t.Fatalf("Run: %v", err)
+		}
+		if len(results.Experiments) != 3 {
+			t.Fatalf("expected 3 experiments, got %d", len(results.Experiments))
+		}
+		results.Elapsed = 0
+		return results
+	}
+
+	first, second := run(mc), run(mc)
+	if !reflect.DeepEqual(first, second) {
+		t.Fatal("two runs with the same seed produced different results")
+	}
+
+	// Scheduling must not change the outcome
+	serial := mc
+	serial.MaxWorkers = 1
+	if !reflect.DeepEqual(first, run(serial)) {
+		t.Fatal("results depend on the number of workers")
+	}
+
+	other := mc
+	other.Seed = 43
+	different := run(other)
+	for _, name := range []string{ExperimentCycleResample, ExperimentPricePaths, ExperimentExecution} {
+		if reflect.DeepEqual(first.Experiment(name).Outcomes, different.Experiment(name).Outcomes) {
+			t.Errorf("%s: seeds 42 and 43 produced identical outcomes", name)
+		}
+	}
+}
+
+func TestRunReportsTheResolvedSeed(t *testing.T) {
+	data := randomWalk(100, 1)
+	cfg := &config.DCAConfig{WindowSize: 10}
+
+	results, err := NewSimulatorWithBacktest(Config{Runs: 5}, pathBacktest).Run(cfg, data, nil)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if results.Seed == 0 {
+		t.Fatal("seed 0 was not resolved to a time-based seed")
+	}
+
+	replay, err := NewSimulatorWithBacktest(Config{Runs: 5, Seed: results.Seed}, pathBacktest).Run(cfg, data, nil)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	for i := range results.Experiments {
+		if !reflect.DeepEqual(results.Experiments[i].Outcomes, replay.Experiments[i].Outcomes) {
+			t.Errorf("%s: replaying the reported seed gave different outcomes", results.Experiments[i].Name)
+		}
+	}
+}
+
+func TestResampleCycles(t *testing.T) {
+	cycles := testCycles()
+
+	t.Run("same seed same outcome", func(t *testing.T) {
+		a := resampleCycles(cycles, 1000, rand.New(rand.NewSource(9)))
+		b := resampleCycles(cycles, 1000, rand.New(rand.NewSource(9)))
+		if a != b {
+			t.Fatalf("outcomes differ: %+v vs %+v", a, b)
+		}
+	})
+
+	t.Run("single cycle is replayed len times", func(t *testing.T) {
+		got := resampleCycles(cycles[:1], 1000, rand.New(rand.NewSource(1)))
+		want := Outcome{FinalReturn: 0.012, MaxCapitalDeployed: 300, LongestOpenCycle: 2 * time.Hour}
+		if math.Abs(got.FinalReturn-want.FinalReturn) > 1e-12 || got.MaxDrawdown != 0 ||
+			got.MaxCapitalDeployed != want.MaxCapitalDeployed || got.LongestOpenCycle != want.LongestOpenCycle {
+			t.Fatalf("got %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("outcomes stay within the sampled cycles", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(3))
+		for i := 0; i < 200; i++ {
+			o := resampleCycles(cycles, 1000, rng)
+			// Four draws of PnL in [-40, 20]
+			if o.FinalReturn < -0.16-1e-12 || o.FinalReturn > 0.08+1e-12 {
+				t.Fatalf("final return %.4f outside the possible range", o.FinalReturn)
+			}
+			if o.MaxCapitalDeployed > 900 || o.LongestOpenCycle > 30*time.Hour {
+				t.Fatalf("outcome %+v exceeds every input cycle", o)
+			}
+		}
+	})
+
+	t.Run("no cycles or balance", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(1))
+		if o := resampleCycles(nil, 1000, rng); o != (Outcome{}) {
+			t.Errorf("no cycles: got %+v", o)
+		}
+		if o := resampleCycles(cycles, 0, rng); o != (Outcome{}) {
+			t.Errorf("zero balance: got %+v", o)
+		}
+	})
+}
+
+func TestBlockBootstrap(t *testing.T) {
+	data := randomWalk(200, 5)
+
+	t.Run("same seed same path", func(t *testing.T) {
+		a := blockBootstrap(data, 24, rand.New(rand.NewSource(11)))
+		b := blockBootstrap(data, 24, rand.New(rand.NewSource(11)))
+		if !reflect.DeepEqual(a, b) {
+			t.Fatal("paths differ for the same seed")
+		}
+		c := blockBootstrap(data, 24, rand.New(rand.NewSource(12)))
+		if reflect.DeepEqual(a, c) {
+			t.Fatal("seeds 11 and 12 produced the same path")
+		}
+	})
+
+	t.Run("path shape", func(t *testing.T) {
+		path := blockBootstrap(data, 24, rand.New(rand.NewSource(11)))
+		if len(path) != len(data) {
+			t.Fatalf("length %d, want %d", len(path), len(data))
+		}
+		if path[0] != data[0] {
+			t.Errorf("first candle changed: %+v", path[0])
+		}
+		for i := 1; i < len(path); i++ {
+			if !path[i].Timestamp.Equal(data[i].Timestamp) {
+				t.Fatalf("candle %d timestamp %v, want %v", i, path[i].Timestamp, data[i].Timestamp)
+			}
+			if path[i].Low > path[i].Close || path[i].Close > path[i].High {
+				t.Fatalf("candle %d close %.4f outside [%.4f, %.4f]", i, path[i].Close, path[i].Low, path[i].High)
+			}
+		}
+	})
+
+	t.Run("one block spanning the data reproduces it", func(t *testing.T) {
+		path := blockBootstrap(data, len(data), rand.New(rand.NewSource(1)))
+		for i := range data {
+			if math.Abs(path[i].Close-data[i].Close) > 1e-9 {
+				t.Fatalf("candle %d close %.6f, want %.6f", i, path[i].Close, data[i].Close)
+			}
+		}
+	})
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := []float64{1, 2, 3, 4, 5}
+	tests := []struct {
+		p    float64
+		want float64
+	}{
+		{0, 1},
+		{0.25, 2},
+		{0.5, 3},
+		{0.9, 4.6},
+		{1, 5},
+	}
+	for _, tt := range tests {
+		if got := percentile(sorted, tt.p); math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("percentile(%.2f) = %.4f, want %.4f", tt.p, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package montecarlo
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/backtest"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
+)
+
+// completedCycles returns the cycles closed by take profit
+func completedCycles(results *backtest.BacktestResults) []backtest.CycleSummary {
+	if results == nil {
+		return nil
+	}
+	var completed []backtest.CycleSummary
+	for _, c := range results.Cycles {
+		if c.Completed {
+			completed = append(completed, c)
+		}
+	}
+	return completed
+}
+
+// resampleCycles draws len(cycles) cycles with replacement and replays their
+// realized PnL in the drawn order. Drawdown is measured on realized equity, so
+// it excludes the unrealized dip inside each cycle.
+func resampleCycles(cycles []backtest.CycleSummary, startBalance float64, rng *rand.Rand) Outcome {
+	var outcome Outcome
+	if startBalance <= 0 || len(cycles) == 0 {
+		return outcome
+	}
+
+	equity := startBalance
+	peak := startBalance
+	for range cycles {
+		c := cycles[rng.Intn(len(cycles))]
+
+		equity += c.RealizedPnL
+		if equity > peak {
+			peak = equity
+		}
+		if dd := (peak - equity) / peak; dd > outcome.MaxDrawdown {
+			outcome.MaxDrawdown = dd
+		}
+		if c.TotalGrossCost > outcome.MaxCapitalDeployed {
+			outcome.MaxCapitalDeployed = c.TotalGrossCost
+		}
+		if d := c.EndTime.Sub(c.StartTime); d > outcome.LongestOpenCycle {
+			outcome.LongestOpenCycle = d
+		}
+	}
+
+	outcome.FinalReturn = (equity - startBalance) / startBalance
+	return outcome
+}
+
+// blockBootstrap builds a synthetic price path of the same length by stitching
+// together random blocks of candle returns. Each candle is rescaled to the
+// previous synthetic close, so intrabar shape and within-block autocorrelation
+// are preserved while the overall path changes. Timestamps are kept.
+func blockBootstrap(data []types.OHLCV, blockSize int, rng *rand.Rand) []types.OHLCV {
+	n := len(data)
+	out := make([]types.OHLCV, n)
+	if n < 3 {
+		copy(out, data)
+		return out
+	}
+	if blockSize > n-1 {
+		blockSize = n - 1
+	}
+
+	out[0] = data[0]
+	prevClose := data[0].Close
+	for t := 1; t < n; {
+		start := 1 + rng.Intn(n-blockSize)
+		for k := 0; k < blockSize && t < n; k++ {
+			src := data[start+k]
+			ref := data[start+k-1].Close
+			if ref <= 0 {
+				ref = src.Open
+			}
+			scale := 1.0
+			if ref > 0 {
+				scale = prevClose / ref
+			}
+
+			out[t] = types.OHLCV{
+				Timestamp: data[t].Timestamp,
+				Open:      src.Open * scale,
+				High:      src.High * scale,
+				Low:       src.Low * scale,
+				Close:     src.Close * scale,
+				Volume:    src.Volume,
+			}
+			prevClose = out[t].Close
+			t++
+		}
+	}
+	return out
+}
+
+// summarizeExperiment builds the metric distributions of an experiment
+func summarizeExperiment(name string, outcomes []Outcome, ruinThreshold float64) ExperimentResult {
+	n := len(outcomes)
+	returns := make([]float64, n)
+	drawdowns := make([]float64, n)
+	capital := make([]float64, n)
+	hours := make([]float64, n)
+
+	losses, ruins := 0, 0
+	for i, o := range outcomes {
+		returns[i] = o.FinalReturn
+		drawdowns[i] = o.MaxDrawdown
+		capital[i] = o.MaxCapitalDeployed
+		hours[i] = o.LongestOpenCycle.Hours()
+		if o.FinalReturn < 0 {
+			losses++
+		}
+		if o.MaxDrawdown >= ruinThreshold {
+			ruins++
+		}
+	}
+
+	result := ExperimentResult{
+		Name:                  name,
+		Runs:                  n,
+		FinalReturn:           newDistribution(returns),
+		MaxDrawdown:           newDistribution(drawdowns),
+		MaxCapitalDeployed:    newDistribution(capital),
+		LongestOpenCycleHours: newDistribution(hours),
+		Outcomes:              outcomes,
+	}
+	if n > 0 {
+		result.ProbabilityOfLoss = float64(losses) / float64(n)
+		result.ProbabilityOfRuin = float64(ruins) / float64(n)
+	}
+	return result
+}
+
+// newDistribution computes summary statistics and percentiles
+func newDistribution(values []float64) Distribution {
+	if len(values) == 0 {
+		return Distribution{}
+	}
+
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	mean := 0.0
+	for _, v := range sorted {
+		mean += v
+	}
+	mean /= float64(len(sorted))
+
+	variance := 0.0
+	for _, v := range sorted {
+		variance += (v - mean) * (v - mean)
+	}
+	variance /= float64(len(sorted))
+
+	return Distribution{
+		Mean:   mean,
+		StdDev: math.Sqrt(variance),
+		Min:    sorted[0],
+		P5:     percentile(sorted, 0.05),
+		P25:    percentile(sorted, 0.25),
+		P50:    percentile(sorted, 0.50),
+		P75:    percentile(sorted, 0.75),
+		P95:    percentile(sorted, 0.95),
+		Max:    sorted[len(sorted)-1],
+	}
+}
+
+// percentile uses linear interpolation between closest ranks on sorted values
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 1 {
+		return sorted[0]
+	}
+	pos := p * float64(len(sorted)-1)
+	lower := int(math.Floor(pos))
+	upper := int(math.Ceil(pos))
+	if lower == upper {
+		return sorted[lower]
+	}
+	return sorted[lower] + (pos-float64(lower))*(sorted[upper]-sorted[lower])
+}
@@ -0,0 +1,227 @@
+package montecarlo
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/backtest"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/config"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/optimization"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
+)
+
+// DefaultSimulator implements the Simulator interface
+type DefaultSimulator struct {
+	config   Config
+	backtest BacktestFunc
+}
+
+// NewDefaultSimulator creates a simulator that reruns the strategy with the optimizer's backtest path
+func NewDefaultSimulator(mcConfig Config) *DefaultSimulator {
+	return NewSimulatorWithBacktest(mcConfig, func(cfg *config.DCAConfig, data []types.OHLCV, fill backtest.EntryFillFunc) *backtest.BacktestResults {
+		return optimization.RunBacktestWithFill(cfg, data, fill)
+	})
+}
+
+// NewSimulatorWithBacktest creates a simulator with a custom backtest function
+func NewSimulatorWithBacktest(mcConfig Config, fn BacktestFunc) *DefaultSimulator {
+	return &DefaultSimulator{config: normalizeConfig(mcConfig), backtest: fn}
+}
+
+// Run executes all experiments enabled by the configuration
+func (s *DefaultSimulator) Run(cfg *config.DCAConfig, data []types.OHLCV, baseline *backtest.BacktestResults) (*Results, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("no configuration provided")
+	}
+	if len(data) <= cfg.WindowSize+1 {
+		return nil, fmt.Errorf("not enough data for Monte Carlo: %d candles with window %d", len(data), cfg.WindowSize)
+	}
+
+	start := time.Now()
+	mc := s.config
+
+	if baseline == nil {
+		baseline = s.backtest(cfg, data, nil)
+	}
+
+	results := &Results{
+		Seed:          mc.Seed,
+		Runs:          mc.Runs,
+		BlockSize:     mc.BlockSize,
+		MaxLatency:    mc.MaxLatency,
+		FeeJitter:     mc.FeeJitter,
+		RuinThreshold: mc.RuinThreshold,
+		Baseline:      outcomeFromResults(baseline, cfg.Commission),
+	}
+
+	log.Printf("🎲 Monte Carlo: %d runs per experiment (seed %d, block %d candles, latency ≤ %v, fee jitter ±%.0f%%)",
+		mc.Runs, mc.Seed, mc.BlockSize, mc.MaxLatency, mc.FeeJitter*100)
+
+	// 1. Resample completed cycles - no reruns needed
+	if completed := completedCycles(baseline); len(completed) > 0 {
+		outcomes := make([]Outcome, mc.Runs)
+		for run := 0; run < mc.Runs; run++ {
+			rng := rand.New(rand.NewSource(runSeed(mc.Seed, 0, run)))
+			outcomes[run] = resampleCycles(completed, baseline.StartBalance, rng)
+		}
+		results.Experiments = append(results.Experiments, summarizeExperiment(ExperimentCycleResample, outcomes, mc.RuinThreshold))
+	} else {
+		log.Printf("⚠️ Monte Carlo: no completed cycles, skipping cycle resampling")
+	}
+
+	// 2. Alternate price paths (with execution noise when enabled)
+	outcomes := s.rerun(cfg, mc, 1, func(rng *rand.Rand) []types.OHLCV {
+		return blockBootstrap(data, mc.BlockSize, rng)
+	})
+	results.Experiments = append(results.Experiments, summarizeExperiment(ExperimentPricePaths, outcomes, mc.RuinThreshold))
+
+	// 3. Original path with execution noise only
+	if mc.MaxLatency > 0 || mc.FeeJitter > 0 {
+		outcomes := s.rerun(cfg, mc, 2, func(*rand.Rand) []types.OHLCV { return data })
+		results.Experiments = append(results.Experiments, summarizeExperiment(ExperimentExecution, outcomes, mc.RuinThreshold))
+	}
+
+	results.Elapsed = time.Since(start)
+	log.Printf("✅ Monte Carlo completed in %v", results.Elapsed.Round(time.Millisecond))
+	return results, nil
+}
+
+// rerun backtests the strategy mc.Runs times in parallel. Each run gets its
+// own RNG derived from the seed so results do not depend on scheduling.
+func (s *DefaultSimulator) rerun(cfg *config.DCAConfig, mc Config, experiment int, pathFn func(rng *rand.Rand) []types.OHLCV) []Outcome {
+	outcomes := make([]Outcome, mc.Runs)
+	workerChan := make(chan struct{}, mc.MaxWorkers)
+	var wg sync.WaitGroup
+
+	for run := 0; run < mc.Runs; run++ {
+		wg.Add(1)
+		workerChan <- struct{}{}
+		go func(run int) {
+			defer wg.Done()
+			defer func() { <-workerChan }()
+
+			rng := rand.New(rand.NewSource(runSeed(mc.Seed, experiment, run)))
+			path := pathFn(rng)
+
+			runCfg := *cfg
+			runCfg.Commission = jitterCommission(cfg.Commission, mc.FeeJitter, rng)
+
+			var fill backtest.EntryFillFunc
+			if mc.MaxLatency > 0 {
+				fill = latencyFill(mc.MaxLatency, rng)
+			}
+
+			outcomes[run] = outcomeFromResults(s.backtest(&runCfg, path, fill), runCfg.Commission)
+		}(run)
+	}
+
+	wg.Wait()
+	return outcomes
+}
+
+// RunMonteCarlo runs the default simulator - convenience function
+func RunMonteCarlo(cfg *config.DCAConfig, data []types.OHLCV, baseline *backtest.BacktestResults, mcConfig Config) (*Results, error) {
+	return NewDefaultSimulator(mcConfig).Run(cfg, data, baseline)
+}
+
+// normalizeConfig fills in defaults and resolves the seed
+func normalizeConfig(mc Config) Config {
+	if mc.Runs <= 0 {
+		mc.Runs = DefaultRuns
+	}
+	if mc.BlockSize <= 0 {
+		mc.BlockSize = DefaultBlockSize
+	}
+	if mc.RuinThreshold <= 0 {
+		mc.RuinThreshold = DefaultRuinThreshold
+	}
+	if mc.FeeJitter < 0 {
+		mc.FeeJitter = 0
+	}
+	if mc.MaxWorkers <= 0 {
+		mc.MaxWorkers = runtime.NumCPU()
+	}
+	if mc.Seed == 0 {
+		mc.Seed = time.Now().UnixNano()
+	}
+	return mc
+}
+
+// runSeed derives a per-run seed so each experiment and run is reproducible
+func runSeed(seed int64, experiment, run int) int64 {
+	return seed + int64(experiment)*1_000_003 + int64(run)*7_919
+}
+
+// outcomeFromResults extracts the Monte Carlo metrics from a backtest
+func outcomeFromResults(results *backtest.BacktestResults, commission float64) Outcome {
+	if results == nil {
+		return Outcome{Commission: commission}
+	}
+
+	outcome := Outcome{
+		FinalReturn: results.TotalReturn,
+		MaxDrawdown: results.MaxDrawdown,
+		Commission:  commission,
+	}
+
+	for _, c := range results.Cycles {
+		if c.TotalGrossCost > outcome.MaxCapitalDeployed {
+			outcome.MaxCapitalDeployed = c.TotalGrossCost
+		}
+		if d := c.EndTime.Sub(c.StartTime); d > outcome.LongestOpenCycle {
+			outcome.LongestOpenCycle = d
+		}
+	}
+
+	// Without cycle tracking every buy stays open until the end
+	if len(results.Cycles) == 0 {
+		for _, t := range results.Trades {
+			outcome.MaxCapitalDeployed += t.EntryPrice*t.Quantity + t.Commission
+		}
+	}
+
+	return outcome
+}
+
+// jitterCommission draws a commission uniformly from [1-jitter, 1+jitter] times the base
+func jitterCommission(base, jitter float64, rng *rand.Rand) float64 {
+	if jitter <= 0 {
+		return base
+	}
+	c := base * (1 + jitter*(2*rng.Float64()-1))
+	if c < 0 {
+		return 0
+	}
+	return c
+}
+
+// latencyFill delays each entry by a uniform random duration up to maxLatency and
+// fills at the close-to-close interpolated price at that moment
+func latencyFill(maxLatency time.Duration, rng *rand.Rand) backtest.EntryFillFunc {
+	return func(data []types.OHLCV, index int) float64 {
+		delay := time.Duration(rng.Int63n(int64(maxLatency) + 1))
+		return priceAfter(data, index, delay)
+	}
+}
+
+// priceAfter returns the price delay after the close of data[index]
+func priceAfter(data []types.OHLCV, index int, delay time.Duration) float64 {
+	price := data[index].Close
+	for j := index; j+1 < len(data) && delay > 0; j++ {
+		step := data[j+1].Timestamp.Sub(data[j].Timestamp)
+		if step <= 0 {
+			break
+		}
+		if delay < step {
+			frac := float64(delay) / float64(step)
+			return data[j].Close + frac*(data[j+1].Close-data[j].Close)
+		}
+		delay -= step
+		price = data[j+1].Close
+	}
+	return price
+}
@@ -1,7 +1,6 @@
 package optimization
 
 import (
-	"fmt"
 	"log"
 	"math/rand"
 	"sort"
@@ -10,15 +9,8 @@ import (
 	"time"
 
 	"github.com/ducminhle1904/crypto-dca-bot/internal/backtest"
-	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators/bands"
-	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators/common"
-	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators/oscillators"
-	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators/trend"
-	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators/volume"
 	"github.com/ducminhle1904/crypto-dca-bot/internal/strategy"
-	"github.com/ducminhle1904/crypto-dca-bot/internal/strategy/spacing"
 	configpkg "github.com/ducminhle1904/crypto-dca-bot/pkg/config"
-	datamanager "github.com/ducminhle1904/crypto-dca-bot/pkg/data"
 	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
 )
 
@@ -42,78 +34,14 @@ type GAIndividual struct {
 	Results *backtest.BacktestResults
 }
 
-// OptimizeWithGA runs genetic algorithm optimization - extracted from main.go optimizeForInterval
+// OptimizeWithGA runs genetic algorithm optimization - extracted from main.go optimizeForInterval.
+// It uses DefaultSearchOptions (clock-derived seed, standard generations);
+// use OptimizeWithOptions for seeded runs, budgets and other search methods.
 func OptimizeWithGA(baseConfig interface{}, dataFile string, selectedPeriod time.Duration) (*backtest.BacktestResults, interface{}, error) {
-	// Create local RNG with random seed for non-deterministic optimization
-	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
-	
 	// MinOrderQty should be fetched at orchestrator level before calling OptimizeWithGA
 	// to ensure consistency with single backtest runs
-	
-	// Preload data once for performance
-	data, err := datamanager.LoadHistoricalDataCached(dataFile)
-	if err != nil {
-		log.Fatalf("Failed to load data for optimization: %v", err)
-	}
-	
-	if len(data) == 0 {
-		log.Fatalf("No valid data found for optimization in file: %s", dataFile)
-	}
-	
-	if selectedPeriod > 0 {
-		data = datamanager.FilterDataByPeriod(data, selectedPeriod)
-		if len(data) == 0 {
-			log.Fatalf("No data remaining for optimization after applying period filter of %v", selectedPeriod)
-		}
-		log.Printf("ℹ️ Filtered to last %v of data (%s → %s)",
-			selectedPeriod,
-			data[0].Timestamp.Format("2006-01-02"),
-			data[len(data)-1].Timestamp.Format("2006-01-02"))
-	}
-
-	// GA Parameters
-	populationSize := GAPopulationSize
-	generations := GAGenerations
-	mutationRate := GAMutationRate
-	crossoverRate := GACrossoverRate
-	eliteSize := GAEliteSize
-
-	// Silent GA optimization - only show final result
-
-	// Initialize population
-	population := InitializePopulation(baseConfig, populationSize, rng)
-	
-	var bestIndividual *GAIndividual
-	
-	for gen := 0; gen < generations; gen++ {
-		// Evaluate fitness for all individuals in parallel
-		EvaluatePopulationParallel(population, data)
-		
-		// Sort by fitness (descending)
-		SortPopulationByFitness(population)
-		
-		// Track best individual
-		if bestIndividual == nil || population[0].Fitness > bestIndividual.Fitness {
-			bestIndividual = &GAIndividual{
-				Config:  copyConfig(population[0].Config), // Deep copy to prevent mutation
-				Fitness: population[0].Fitness,
-				Results: population[0].Results,
-			}
-		}
-		
-		// Silent generation processing
-		
-		// Create next generation
-		if gen < generations-1 {
-			population = CreateNextGeneration(population, eliteSize, crossoverRate, mutationRate, baseConfig, rng)
-		}
-	}
-	
-	// Re-run the best configuration to ensure consistency with standalone runs
-	// The GA cached results may have strategy state contamination from parallel execution
-	// This ensures the final result matches exactly what would be produced by a regular backtest
-	finalResults := RunBacktestWithData(bestIndividual.Config, data)
-	return finalResults, bestIndividual.Config, nil
+	results, bestConfig, _, err := OptimizeWithOptions(baseConfig, dataFile, selectedPeriod, DefaultSearchOptions())
+	return results, bestConfig, err
 }
 
 // InitializePopulation creates initial random population - extracted from main.go
@@ -276,6 +204,29 @@ func copyConfig(config interface{}) interface{} {
 		copied.DCASpacing = &spacingCopy
 	}
 	
+	// Deep copy ladder sizing configuration
+	if dcaConfig.PositionSizing != nil {
+		sizingCopy := *dcaConfig.PositionSizing
+		if dcaConfig.PositionSizing.LevelMultipliers != nil {
+			sizingCopy.LevelMultipliers = make([]float64, len(dcaConfig.PositionSizing.LevelMultipliers))
+			copy(sizingCopy.LevelMultipliers, dcaConfig.PositionSizing.LevelMultipliers)
+		}
+		copied.PositionSizing = &sizingCopy
+	}
+	if dcaConfig.LimitGrid != nil {
+		gridCopy := *dcaConfig.LimitGrid
+		copied.LimitGrid = &gridCopy
+	}
+	copied.SignalAggregation = dcaConfig.SignalAggregation.Clone()
+	copied.Conditions = dcaConfig.Conditions.Clone()
+	copied.Timeframes = dcaConfig.Timeframes.Clone()
+	if dcaConfig.StrategyParams != nil {
+		copied.StrategyParams = make(map[string]float64, len(dcaConfig.StrategyParams))
+		for k, v := range dcaConfig.StrategyParams {
+			copied.StrategyParams[k] = v
+		}
+	}
+	
 	// Deep copy Dynamic TP configuration
 	if dcaConfig.DynamicTP != nil {
 		dynamicTPCopy := *dcaConfig.DynamicTP
@@ -286,6 +237,12 @@ func copyConfig(config interface{}) interface{} {
 			dynamicTPCopy.VolatilityConfig = &volatilityConfigCopy
 		}
 		
+		// Deep copy TimeDecayConfig if present
+		if dcaConfig.DynamicTP.TimeDecayConfig != nil {
+			timeDecayConfigCopy := *dcaConfig.DynamicTP.TimeDecayConfig
+			dynamicTPCopy.TimeDecayConfig = &timeDecayConfigCopy
+		}
+		
 		// Deep copy IndicatorConfig if present
 		if dcaConfig.DynamicTP.IndicatorConfig != nil {
 			indicatorConfigCopy := *dcaConfig.DynamicTP.IndicatorConfig
@@ -328,6 +285,7 @@ func RandomizeConfig(config interface{}, rng *rand.Rand) {
 	if dcaConfig.DCASpacing != nil {
 		originalStrategy = dcaConfig.DCASpacing.Strategy
 	}
+	bandType := spacingBandType(dcaConfig) // The band is kept as configured
 	
 	// Create DCA spacing configuration with randomized parameters based on strategy
 	switch originalStrategy {
@@ -343,6 +301,58 @@ func RandomizeConfig(config interface{}, rng *rand.Rand) {
 				"min_threshold":         0.003, // 0.3% safety limit
 			},
 		}
+	case "volume_profile":
+		dcaConfig.DCASpacing = &configpkg.DCASpacingConfig{
+			Strategy: "volume_profile",
+			Parameters: map[string]interface{}{
+				"base_threshold":       RandomChoice(ranges.PriceThresholds, rng),
+				"threshold_multiplier": RandomChoice(ranges.PriceThresholdMultipliers, rng),
+				"lookback":             RandomChoice(ranges.VolumeProfileLookbacks, rng),
+				"snap_range":           RandomChoice(ranges.VolumeProfileSnapRanges, rng),
+				"bins":                 24,
+				"node_factor":          1.5,
+				"max_threshold":        0.10, // 10% safety limit
+				"min_threshold":        0.003, // 0.3% safety limit
+			},
+		}
+	case "swing_low":
+		dcaConfig.DCASpacing = &configpkg.DCASpacingConfig{
+			Strategy: "swing_low",
+			Parameters: map[string]interface{}{
+				"base_threshold":       RandomChoice(ranges.PriceThresholds, rng),
+				"threshold_multiplier": RandomChoice(ranges.PriceThresholdMultipliers, rng),
+				"lookback":             RandomChoice(ranges.SwingLookbacks, rng),
+				"pivot_strength":       RandomChoice(ranges.SwingPivotStrengths, rng),
+				"buffer":               RandomChoice(ranges.SwingBuffers, rng),
+				"max_threshold":        0.10, // 10% safety limit
+				"min_threshold":        0.003, // 0.3% safety limit
+			},
+		}
+	case "band":
+		dcaConfig.DCASpacing = &configpkg.DCASpacingConfig{
+			Strategy: "band",
+			Parameters: map[string]interface{}{
+				"base_threshold":  RandomChoice(ranges.PriceThresholds, rng),
+				"band_type":       bandType,
+				"period":          RandomChoice(ranges.BandSpacingPeriods, rng),
+				"band_multiplier": RandomChoice(ranges.BandSpacingMultipliers, rng),
+				"level_step":      RandomChoice(ranges.BandLevelSteps, rng),
+				"max_threshold":   0.10, // 10% safety limit
+				"min_threshold":   0.003, // 0.3% safety limit
+			},
+		}
+	case "drawdown_percentile":
+		dcaConfig.DCASpacing = &configpkg.DCASpacingConfig{
+			Strategy: "drawdown_percentile",
+			Parameters: map[string]interface{}{
+				"base_threshold":       RandomChoice(ranges.PriceThresholds, rng),
+				"threshold_multiplier": RandomChoice(ranges.PriceThresholdMultipliers, rng),
+				"base_percentile":      RandomChoice(ranges.DrawdownBasePercentiles, rng),
+				"percentile_step":      RandomChoice(ranges.DrawdownPercentileSteps, rng),
+				"max_threshold":        0.10, // 10% safety limit
+				"min_threshold":        0.003, // 0.3% safety limit
+			},
+		}
 	default: // "fixed"
 		dcaConfig.DCASpacing = &configpkg.DCASpacingConfig{
 			Strategy: "fixed",
@@ -355,6 +365,12 @@ func RandomizeConfig(config interface{}, rng *rand.Rand) {
 		}
 	}
 	
+	// Randomize the volume scale ladder if configured (custom tables are kept as written)
+	if dcaConfig.PositionSizing != nil && dcaConfig.PositionSizing.Mode == configpkg.SizingModeVolumeScale {
+		dcaConfig.PositionSizing.VolumeScale = RandomChoice(ranges.VolumeScales, rng)
+		dcaConfig.PositionSizing.MaxLevels = RandomChoice(ranges.LadderLevels, rng)
+	}
+	
 	// Default to classic indicators for genetic algorithm optimization if none specified
 	if len(dcaConfig.Indicators) == 0 {
 		dcaConfig.Indicators = []string{"rsi", "macd", "bb", "ema"}
@@ -366,6 +382,16 @@ func RandomizeConfig(config interface{}, rng *rand.Rand) {
 		indicatorSet[strings.ToLower(ind)] = true
 	}
 	
+	// Randomize vote weights if a weighted aggregation mode is configured
+	if dcaConfig.SignalAggregation.IsWeighted() {
+		for _, ind := range dcaConfig.Indicators {
+			if configpkg.IsEntryFilterIndicator(ind) {
+				continue
+			}
+			dcaConfig.SignalAggregation.SetWeight(ind, RandomChoice(ranges.AggregationWeights, rng))
+		}
+	}
+	
 	// Randomize classic indicator parameters if present
 	if indicatorSet["rsi"] {
 		dcaConfig.RSIPeriod = RandomChoice(ranges.RSIPeriods, rng)
@@ -416,6 +442,34 @@ func RandomizeConfig(config interface{}, rng *rand.Rand) {
 		dcaConfig.StochasticRSIOverbought = RandomChoice(ranges.StochasticRSIOverboughts, rng)
 		dcaConfig.StochasticRSIOversold = RandomChoice(ranges.StochasticRSIOversolds, rng)
 	}
+	if indicatorSet["adx"] || indicatorSet["dmi"] {
+		dcaConfig.ADXPeriod = RandomChoice(ranges.ADXPeriods, rng)
+		dcaConfig.ADXThreshold = RandomChoice(ranges.ADXThresholds, rng)
+	}
+	if indicatorSet["ichimoku"] || indicatorSet["ichi"] {
+		dcaConfig.IchimokuTenkan = RandomChoice(ranges.IchimokuTenkans, rng)
+		dcaConfig.IchimokuKijun = RandomChoice(ranges.IchimokuKijuns, rng)
+		dcaConfig.IchimokuSenkouB = RandomChoice(ranges.IchimokuSenkouBs, rng)
+	}
+	if indicatorSet["donchian"] || indicatorSet["dc"] {
+		dcaConfig.DonchianPeriod = RandomChoice(ranges.DonchianPeriods, rng)
+	}
+	if indicatorSet["anchored_vwap"] || indicatorSet["avwap"] || indicatorSet["vwap"] {
+		if dcaConfig.AVWAPAnchor == "highest_high" {
+			dcaConfig.AVWAPAnchorLookback = RandomChoice(ranges.AVWAPAnchorLookbacks, rng)
+		}
+		dcaConfig.AVWAPBandMultiplier = RandomChoice(ranges.AVWAPBandMultipliers, rng)
+	}
+	if indicatorSet["cmf"] || indicatorSet["chaikin"] {
+		dcaConfig.CMFPeriod = RandomChoice(ranges.CMFPeriods, rng)
+		dcaConfig.CMFThreshold = RandomChoice(ranges.CMFThresholds, rng)
+	}
+	if indicatorSet["volume_profile"] || indicatorSet["vp"] || indicatorSet["vpvr"] {
+		dcaConfig.VolumeProfileLookback = RandomChoice(ranges.VolumeProfileLookbacks, rng)
+		dcaConfig.VolumeProfileBins = RandomChoice(ranges.VolumeProfileBins, rng)
+		dcaConfig.VolumeProfileNodeFactor = RandomChoice(ranges.VolumeProfileNodeFactors, rng)
+		dcaConfig.VolumeProfileProximity = RandomChoice(ranges.VolumeProfileProximities, rng)
+	}
 	
 	// Randomize Dynamic TP parameters if dynamic TP is configured
 	if dcaConfig.DynamicTP != nil {
@@ -437,9 +491,19 @@ func RandomizeConfig(config interface{}, rng *rand.Rand) {
 				dcaConfig.DynamicTP.IndicatorConfig.MaxTPPercent = RandomChoice(ranges.TPMaxPercents, rng)
 				// Weights are preserved from original config for indicator-based TP
 			}
+		case "time_decay":
+			if dcaConfig.DynamicTP.TimeDecayConfig != nil {
+				dcaConfig.DynamicTP.TimeDecayConfig.GraceDays = RandomChoice(ranges.TPDecayGraceDays, rng)
+				dcaConfig.DynamicTP.TimeDecayConfig.DecayDays = RandomChoice(ranges.TPDecayDays, rng)
+				dcaConfig.DynamicTP.TimeDecayConfig.BreakEvenLevels = RandomChoice(ranges.TPBreakEvenLevels, rng)
+				// FloorPercent is preserved from original config - it covers fees, not a tuning knob
+			}
 		}
 	}
 	
+	// Randomize the registered strategy's own parameters
+	randomizeStrategyParams(dcaConfig, rng)
+	
 	// Fix parameter ordering constraints after randomization
 	validateAndFixParameterOrdering(dcaConfig)
 	
@@ -448,6 +512,17 @@ func RandomizeConfig(config interface{}, rng *rand.Rand) {
 }
 
 func RunBacktestWithData(config interface{}, data []types.OHLCV) *backtest.BacktestResults {
+	return RunBacktestWithFill(config, data, nil)
+}
+
+// RunBacktestWithFill runs a backtest with an optional entry fill model (nil = close-price fills)
+func RunBacktestWithFill(config interface{}, data []types.OHLCV, fill backtest.EntryFillFunc) *backtest.BacktestResults {
+	return runBacktest(config, data, fill, false)
+}
+
+// runBacktest runs a backtest, recording the config's decision snapshots if
+// withSnapshots is set; searches leave them off to keep evaluations fast
+func runBacktest(config interface{}, data []types.OHLCV, fill backtest.EntryFillFunc, withSnapshots bool) *backtest.BacktestResults {
 	// Convert interface{} to config.DCAConfig which implements BacktestConfig interface
 	dcaConfig, ok := config.(*configpkg.DCAConfig)
 	if !ok {
@@ -457,7 +532,7 @@ func RunBacktestWithData(config interface{}, data []types.OHLCV) *backtest.Backt
 	
 	// CRITICAL FIX: Use the SAME strategy creation logic as regular backtest
 	// This ensures 100% consistency between optimization and regular backtest runs
-	strat, err := createDCAStrategyFromConfig(dcaConfig)
+	strat, err := strategy.New(dcaConfig)
 	if err != nil {
 		log.Printf("⚠️ GA: Failed to create strategy: %v", err)
 		return &backtest.BacktestResults{TotalReturn: 0.0}
@@ -472,6 +547,18 @@ func RunBacktestWithData(config interface{}, data []types.OHLCV) *backtest.Backt
 	}
 	
 	engine := backtest.NewBacktestEngine(dcaConfig.InitialBalance, dcaConfig.Commission, strat, tp, dcaConfig.MinOrderQty, dcaConfig.UseTPLevels)
+	engine.SetEntryFillFunc(fill)
+	engine.SetSeriesCache(indicatorCache.Load())
+	engine.SetRiskLimits(dcaConfig.RiskLimits)
+	if withSnapshots {
+		engine.SetDecisionSnapshots(dcaConfig.DecisionSnapshots)
+	}
+	if dcaConfig.LimitGrid.IsEnabled() {
+		engine.SetGridCommission(dcaConfig.LimitGrid.MakerCommission)
+	}
+	if err := engine.LoadTimeframeData(dcaConfig.Timeframes); err != nil {
+		log.Printf("⚠️ GA: %v, resampling instead", err)
+	}
 	results := engine.Run(data, dcaConfig.WindowSize)
 	results.UpdateMetrics()
 	
@@ -506,12 +593,32 @@ func CrossoverConfigs(child, parent1, parent2 interface{}, rng *rand.Rand) {
 		childConfig.TPPercent = parent2Config.TPPercent
 	}
 	
+	// Ladder sizing crossover - only between parents using the same sizing mode
+	if childConfig.PositionSizing != nil && parent2Config.PositionSizing != nil &&
+	   childConfig.PositionSizing.Mode == parent2Config.PositionSizing.Mode {
+		if rng.Float64() < 0.5 {
+			childConfig.PositionSizing.VolumeScale = parent2Config.PositionSizing.VolumeScale
+		}
+		if rng.Float64() < 0.5 {
+			childConfig.PositionSizing.MaxLevels = parent2Config.PositionSizing.MaxLevels
+		}
+	}
+	
 	// Crossover parameters for each indicator that's present
 	indicatorSet := make(map[string]bool)
 	for _, ind := range childConfig.Indicators {
 		indicatorSet[strings.ToLower(ind)] = true
 	}
 	
+	// Crossover vote weights per indicator
+	if childConfig.SignalAggregation.IsWeighted() && parent2Config.SignalAggregation.IsWeighted() {
+		for _, ind := range childConfig.Indicators {
+			if !configpkg.IsEntryFilterIndicator(ind) && rng.Float64() < 0.5 {
+				childConfig.SignalAggregation.SetWeight(ind, parent2Config.SignalAggregation.Weight(ind))
+			}
+		}
+	}
+	
 	// Crossover classic indicator parameters if present
 	if indicatorSet["rsi"] {
 		if rng.Float64() < 0.5 { childConfig.RSIPeriod = parent2Config.RSIPeriod }
@@ -562,6 +669,32 @@ func CrossoverConfigs(child, parent1, parent2 interface{}, rng *rand.Rand) {
 		if rng.Float64() < 0.5 { childConfig.StochasticRSIOverbought = parent2Config.StochasticRSIOverbought }
 		if rng.Float64() < 0.5 { childConfig.StochasticRSIOversold = parent2Config.StochasticRSIOversold }
 	}
+	if indicatorSet["adx"] || indicatorSet["dmi"] {
+		if rng.Float64() < 0.5 { childConfig.ADXPeriod = parent2Config.ADXPeriod }
+		if rng.Float64() < 0.5 { childConfig.ADXThreshold = parent2Config.ADXThreshold }
+	}
+	if indicatorSet["ichimoku"] || indicatorSet["ichi"] {
+		if rng.Float64() < 0.5 { childConfig.IchimokuTenkan = parent2Config.IchimokuTenkan }
+		if rng.Float64() < 0.5 { childConfig.IchimokuKijun = parent2Config.IchimokuKijun }
+		if rng.Float64() < 0.5 { childConfig.IchimokuSenkouB = parent2Config.IchimokuSenkouB }
+	}
+	if indicatorSet["donchian"] || indicatorSet["dc"] {
+		if rng.Float64() < 0.5 { childConfig.DonchianPeriod = parent2Config.DonchianPeriod }
+	}
+	if indicatorSet["anchored_vwap"] || indicatorSet["avwap"] || indicatorSet["vwap"] {
+		if rng.Float64() < 0.5 { childConfig.AVWAPAnchorLookback = parent2Config.AVWAPAnchorLookback }
+		if rng.Float64() < 0.5 { childConfig.AVWAPBandMultiplier = parent2Config.AVWAPBandMultiplier }
+	}
+	if indicatorSet["cmf"] || indicatorSet["chaikin"] {
+		if rng.Float64() < 0.5 { childConfig.CMFPeriod = parent2Config.CMFPeriod }
+		if rng.Float64() < 0.5 { childConfig.CMFThreshold = parent2Config.CMFThreshold }
+	}
+	if indicatorSet["volume_profile"] || indicatorSet["vp"] || indicatorSet["vpvr"] {
+		if rng.Float64() < 0.5 { childConfig.VolumeProfileLookback = parent2Config.VolumeProfileLookback }
+		if rng.Float64() < 0.5 { childConfig.VolumeProfileBins = parent2Config.VolumeProfileBins }
+		if rng.Float64() < 0.5 { childConfig.VolumeProfileNodeFactor = parent2Config.VolumeProfileNodeFactor }
+		if rng.Float64() < 0.5 { childConfig.VolumeProfileProximity = parent2Config.VolumeProfileProximity }
+	}
 	
 	// Crossover Dynamic TP parameters if both parents have dynamic TP configured
 	if childConfig.DynamicTP != nil && parent2Config.DynamicTP != nil {
@@ -595,9 +728,24 @@ func CrossoverConfigs(child, parent1, parent2 interface{}, rng *rand.Rand) {
 					childConfig.DynamicTP.IndicatorConfig.MaxTPPercent = parent2Config.DynamicTP.IndicatorConfig.MaxTPPercent
 				}
 			}
+		case "time_decay":
+			if childConfig.DynamicTP.TimeDecayConfig != nil && parent2Config.DynamicTP.TimeDecayConfig != nil {
+				if rng.Float64() < 0.5 {
+					childConfig.DynamicTP.TimeDecayConfig.GraceDays = parent2Config.DynamicTP.TimeDecayConfig.GraceDays
+				}
+				if rng.Float64() < 0.5 {
+					childConfig.DynamicTP.TimeDecayConfig.DecayDays = parent2Config.DynamicTP.TimeDecayConfig.DecayDays
+				}
+				if rng.Float64() < 0.5 {
+					childConfig.DynamicTP.TimeDecayConfig.BreakEvenLevels = parent2Config.DynamicTP.TimeDecayConfig.BreakEvenLevels
+				}
+			}
 		}
 	}
 	
+	// Crossover the registered strategy's own parameters
+	crossoverStrategyParams(childConfig, parent2Config, rng)
+	
 	// Fix parameter ordering constraints after crossover
 	validateAndFixParameterOrdering(childConfig)
 	
@@ -642,18 +790,80 @@ func MutateConfig(config, baseConfig interface{}, rng *rand.Rand) {
 			if rng.Float64() < 0.1 {
 				dcaConfig.DCASpacing.Parameters["threshold_multiplier"] = RandomChoice(ranges.PriceThresholdMultipliers, rng)
 			}
+		case "volume_profile":
+			if rng.Float64() < 0.1 {
+				dcaConfig.DCASpacing.Parameters["threshold_multiplier"] = RandomChoice(ranges.PriceThresholdMultipliers, rng)
+			}
+			if rng.Float64() < 0.1 {
+				dcaConfig.DCASpacing.Parameters["lookback"] = RandomChoice(ranges.VolumeProfileLookbacks, rng)
+			}
+			if rng.Float64() < 0.1 {
+				dcaConfig.DCASpacing.Parameters["snap_range"] = RandomChoice(ranges.VolumeProfileSnapRanges, rng)
+			}
+		case "swing_low":
+			if rng.Float64() < 0.1 {
+				dcaConfig.DCASpacing.Parameters["threshold_multiplier"] = RandomChoice(ranges.PriceThresholdMultipliers, rng)
+			}
+			if rng.Float64() < 0.1 {
+				dcaConfig.DCASpacing.Parameters["lookback"] = RandomChoice(ranges.SwingLookbacks, rng)
+			}
+			if rng.Float64() < 0.1 {
+				dcaConfig.DCASpacing.Parameters["pivot_strength"] = RandomChoice(ranges.SwingPivotStrengths, rng)
+			}
+			if rng.Float64() < 0.1 {
+				dcaConfig.DCASpacing.Parameters["buffer"] = RandomChoice(ranges.SwingBuffers, rng)
+			}
+		case "band":
+			if rng.Float64() < 0.1 {
+				dcaConfig.DCASpacing.Parameters["period"] = RandomChoice(ranges.BandSpacingPeriods, rng)
+			}
+			if rng.Float64() < 0.1 {
+				dcaConfig.DCASpacing.Parameters["band_multiplier"] = RandomChoice(ranges.BandSpacingMultipliers, rng)
+			}
+			if rng.Float64() < 0.1 {
+				dcaConfig.DCASpacing.Parameters["level_step"] = RandomChoice(ranges.BandLevelSteps, rng)
+			}
+		case "drawdown_percentile":
+			if rng.Float64() < 0.1 {
+				dcaConfig.DCASpacing.Parameters["threshold_multiplier"] = RandomChoice(ranges.PriceThresholdMultipliers, rng)
+			}
+			if rng.Float64() < 0.1 {
+				dcaConfig.DCASpacing.Parameters["base_percentile"] = RandomChoice(ranges.DrawdownBasePercentiles, rng)
+			}
+			if rng.Float64() < 0.1 {
+				dcaConfig.DCASpacing.Parameters["percentile_step"] = RandomChoice(ranges.DrawdownPercentileSteps, rng)
+			}
 		}
 	}
 	if rng.Float64() < 0.1 {
 		dcaConfig.TPPercent = RandomChoice(ranges.TPCandidates, rng)
 	}
 	
+	// Mutate volume scale ladder parameters
+	if dcaConfig.PositionSizing != nil && dcaConfig.PositionSizing.Mode == configpkg.SizingModeVolumeScale {
+		if rng.Float64() < 0.1 {
+			dcaConfig.PositionSizing.VolumeScale = RandomChoice(ranges.VolumeScales, rng)
+		}
+		if rng.Float64() < 0.1 {
+			dcaConfig.PositionSizing.MaxLevels = RandomChoice(ranges.LadderLevels, rng)
+		}
+	}
+	
 	// Mutate indicator parameters based on what indicators are present using predefined ranges
 	indicatorSet := make(map[string]bool)
 	for _, ind := range dcaConfig.Indicators {
 		indicatorSet[strings.ToLower(ind)] = true
 	}
 	
+	// Mutate vote weights
+	if dcaConfig.SignalAggregation.IsWeighted() {
+		for _, ind := range dcaConfig.Indicators {
+			if !configpkg.IsEntryFilterIndicator(ind) && rng.Float64() < 0.1 {
+				dcaConfig.SignalAggregation.SetWeight(ind, RandomChoice(ranges.AggregationWeights, rng))
+			}
+		}
+	}
+	
 	// Mutate classic indicator parameters if present
 	if indicatorSet["rsi"] {
 		if rng.Float64() < 0.1 { dcaConfig.RSIPeriod = RandomChoice(ranges.RSIPeriods, rng) }
@@ -704,6 +914,32 @@ func MutateConfig(config, baseConfig interface{}, rng *rand.Rand) {
 		if rng.Float64() < 0.1 { dcaConfig.StochasticRSIOverbought = RandomChoice(ranges.StochasticRSIOverboughts, rng) }
 		if rng.Float64() < 0.1 { dcaConfig.StochasticRSIOversold = RandomChoice(ranges.StochasticRSIOversolds, rng) }
 	}
+	if indicatorSet["adx"] || indicatorSet["dmi"] {
+		if rng.Float64() < 0.1 { dcaConfig.ADXPeriod = RandomChoice(ranges.ADXPeriods, rng) }
+		if rng.Float64() < 0.1 { dcaConfig.ADXThreshold = RandomChoice(ranges.ADXThresholds, rng) }
+	}
+	if indicatorSet["ichimoku"] || indicatorSet["ichi"] {
+		if rng.Float64() < 0.1 { dcaConfig.IchimokuTenkan = RandomChoice(ranges.IchimokuTenkans, rng) }
+		if rng.Float64() < 0.1 { dcaConfig.IchimokuKijun = RandomChoice(ranges.IchimokuKijuns, rng) }
+		if rng.Float64() < 0.1 { dcaConfig.IchimokuSenkouB = RandomChoice(ranges.IchimokuSenkouBs, rng) }
+	}
+	if indicatorSet["donchian"] || indicatorSet["dc"] {
+		if rng.Float64() < 0.1 { dcaConfig.DonchianPeriod = RandomChoice(ranges.DonchianPeriods, rng) }
+	}
+	if indicatorSet["anchored_vwap"] || indicatorSet["avwap"] || indicatorSet["vwap"] {
+		if dcaConfig.AVWAPAnchor == "highest_high" && rng.Float64() < 0.1 { dcaConfig.AVWAPAnchorLookback = RandomChoice(ranges.AVWAPAnchorLookbacks, rng) }
+		if rng.Float64() < 0.1 { dcaConfig.AVWAPBandMultiplier = RandomChoice(ranges.AVWAPBandMultipliers, rng) }
+	}
+	if indicatorSet["cmf"] || indicatorSet["chaikin"] {
+		if rng.Float64() < 0.1 { dcaConfig.CMFPeriod = RandomChoice(ranges.CMFPeriods, rng) }
+		if rng.Float64() < 0.1 { dcaConfig.CMFThreshold = RandomChoice(ranges.CMFThresholds, rng) }
+	}
+	if indicatorSet["volume_profile"] || indicatorSet["vp"] || indicatorSet["vpvr"] {
+		if rng.Float64() < 0.1 { dcaConfig.VolumeProfileLookback = RandomChoice(ranges.VolumeProfileLookbacks, rng) }
+		if rng.Float64() < 0.1 { dcaConfig.VolumeProfileBins = RandomChoice(ranges.VolumeProfileBins, rng) }
+		if rng.Float64() < 0.1 { dcaConfig.VolumeProfileNodeFactor = RandomChoice(ranges.VolumeProfileNodeFactors, rng) }
+		if rng.Float64() < 0.1 { dcaConfig.VolumeProfileProximity = RandomChoice(ranges.VolumeProfileProximities, rng) }
+	}
 	
 	// Mutate Dynamic TP parameters if configured (10% chance each)
 	if dcaConfig.DynamicTP != nil {
@@ -737,9 +973,24 @@ func MutateConfig(config, baseConfig interface{}, rng *rand.Rand) {
 					dcaConfig.DynamicTP.IndicatorConfig.MaxTPPercent = RandomChoice(ranges.TPMaxPercents, rng)
 				}
 			}
+		case "time_decay":
+			if dcaConfig.DynamicTP.TimeDecayConfig != nil {
+				if rng.Float64() < 0.1 {
+					dcaConfig.DynamicTP.TimeDecayConfig.GraceDays = RandomChoice(ranges.TPDecayGraceDays, rng)
+				}
+				if rng.Float64() < 0.1 {
+					dcaConfig.DynamicTP.TimeDecayConfig.DecayDays = RandomChoice(ranges.TPDecayDays, rng)
+				}
+				if rng.Float64() < 0.1 {
+					dcaConfig.DynamicTP.TimeDecayConfig.BreakEvenLevels = RandomChoice(ranges.TPBreakEvenLevels, rng)
+				}
+			}
 		}
 	}
 	
+	// Mutate the registered strategy's own parameters
+	mutateStrategyParams(dcaConfig, rng)
+	
 	// Fix parameter ordering constraints after mutation
 	validateAndFixParameterOrdering(dcaConfig)
 	
@@ -847,6 +1098,30 @@ func validateAndFixParameterOrdering(dcaConfig *configpkg.DCAConfig) {
 					}
 				}
 			}
+		case "time_decay":
+			if dcaConfig.DynamicTP.TimeDecayConfig != nil {
+				// The floor must stay below a randomized base TP
+				if dcaConfig.DynamicTP.TimeDecayConfig.FloorPercent >= dcaConfig.DynamicTP.BaseTPPercent {
+					dcaConfig.DynamicTP.TimeDecayConfig.FloorPercent = dcaConfig.DynamicTP.BaseTPPercent / 2
+				}
+			}
+		}
+	}
+	
+	// Fix spacing lookbacks beyond the window the strategy sees
+	if dcaConfig.DCASpacing != nil && dcaConfig.WindowSize > 0 {
+		if lookback, ok := dcaConfig.DCASpacing.Parameters["lookback"].(int); ok && lookback > dcaConfig.WindowSize {
+			dcaConfig.DCASpacing.Parameters["lookback"] = dcaConfig.WindowSize
+		}
+	}
+	
+	// Fix vote weights summing to 0: give the first voting indicator full weight
+	if dcaConfig.SignalAggregation.IsWeighted() && dcaConfig.SignalAggregation.VoteWeight(dcaConfig.Indicators) <= 0 {
+		for _, ind := range dcaConfig.Indicators {
+			if !configpkg.IsEntryFilterIndicator(ind) {
+				dcaConfig.SignalAggregation.SetWeight(ind, 1.0)
+				break
+			}
 		}
 	}
 }
@@ -938,100 +1213,6 @@ func runDCABacktestWithData(cfg *configpkg.DCAConfig, data []types.OHLCV) *backt
 	return RunBacktestWithData(cfg, data)
 }
 
-// createDCAStrategyFromConfig creates a strategy from DCAConfig using the SAME logic as backtest runner
-// This ensures 100% consistency between optimization and regular backtest runs
-func createDCAStrategyFromConfig(cfg *configpkg.DCAConfig) (strategy.Strategy, error) {
-	// Initialize Enhanced DCA strategy with base trading amount
-	dca := strategy.NewEnhancedDCAStrategy(cfg.BaseAmount)
-	dca.SetMaxMultiplier(cfg.MaxMultiplier)
-
-	// Configure DCA spacing strategy (required for all configurations)
-	if cfg.DCASpacing == nil {
-		return nil, fmt.Errorf("no DCA spacing strategy configured - please specify dca_spacing in your configuration")
-	}
-
-	spacingConfig := spacing.SpacingConfig{
-		Strategy:   cfg.DCASpacing.Strategy,
-		Parameters: cfg.DCASpacing.Parameters,
-	}
-	
-	spacingStrategy, err := spacing.CreateSpacingStrategy(spacingConfig)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create spacing strategy: %w", err)
-	}
-	
-	if err := spacingStrategy.ValidateConfig(); err != nil {
-		return nil, fmt.Errorf("invalid spacing strategy configuration: %w", err)
-	}
-	
-	dca.SetSpacingStrategy(spacingStrategy)
-
-	// Configure dynamic take profit if specified
-	if cfg.DynamicTP != nil {
-		dca.SetDynamicTPConfig(cfg.DynamicTP)
-	}
-
-	// Indicator inclusion map
-	include := make(map[string]bool)
-	for _, name := range cfg.Indicators {
-		include[strings.ToLower(strings.TrimSpace(name))] = true
-	}
-
-	// Instantiate indicators based on what's actually in the indicators list
-	if include["rsi"] {
-		rsi := oscillators.NewRSI(cfg.RSIPeriod)
-		rsi.SetOversold(cfg.RSIOversold)
-		rsi.SetOverbought(cfg.RSIOverbought)
-		dca.AddIndicator(rsi)
-	}
-	if include["macd"] {
-		macd := oscillators.NewMACD(cfg.MACDFast, cfg.MACDSlow, cfg.MACDSignal)
-		dca.AddIndicator(macd)
-	}
-	if include["bb"] || include["bollinger"] {
-		bb := bands.NewBollingerBandsEMA(cfg.BBPeriod, cfg.BBStdDev)
-		dca.AddIndicator(bb)
-	}
-	if include["ema"] {
-		ema := common.NewEMA(cfg.EMAPeriod)
-		dca.AddIndicator(ema)
-	}
-	if include["hullma"] || include["hull_ma"] {
-		hullMA := trend.NewHullMA(cfg.HullMAPeriod)
-		dca.AddIndicator(hullMA)
-	}
-	if include["supertrend"] || include["st"] {
-		supertrend := trend.NewSuperTrendWithParams(cfg.SuperTrendPeriod, cfg.SuperTrendMultiplier)
-		dca.AddIndicator(supertrend)
-	}
-	if include["mfi"] {
-		mfi := oscillators.NewMFIWithPeriod(cfg.MFIPeriod)
-		mfi.SetOversold(cfg.MFIOversold)
-		mfi.SetOverbought(cfg.MFIOverbought)
-		dca.AddIndicator(mfi)
-	}
-	if include["keltner"] || include["kc"] {
-		keltner := bands.NewKeltnerChannelsCustom(cfg.KeltnerPeriod, cfg.KeltnerMultiplier)
-		dca.AddIndicator(keltner)
-	}
-	if include["wavetrend"] || include["wt"] {
-		wavetrend := oscillators.NewWaveTrendCustom(cfg.WaveTrendN1, cfg.WaveTrendN2)
-		wavetrend.SetOverbought(cfg.WaveTrendOverbought)
-		wavetrend.SetOversold(cfg.WaveTrendOversold)
-		dca.AddIndicator(wavetrend)
-	}
-	if include["obv"] {
-		obv := volume.NewOBV()
-		dca.AddIndicator(obv)
-	}
-	if include["stochrsi"] || include["stochastic_rsi"] || include["stoch_rsi"] {
-		stochRSI := oscillators.NewStochasticRSI()
-		dca.AddIndicator(stochRSI)
-	}
-
-	return dca, nil
-}
-
 // getMinTPPercent extracts minimum TP percentage from dynamic TP config
 func getMinTPPercent(cfg *configpkg.DynamicTPConfig) float64 {
 	if cfg.VolatilityConfig != nil {
@@ -1054,120 +1235,6 @@ func getMaxTPPercent(cfg *configpkg.DynamicTPConfig) float64 {
 	return cfg.BaseTPPercent // Fallback
 }
 
-// createDCAStrategy creates a strategy from DCAConfig (DEPRECATED - use createDCAStrategyFromConfig instead)
-func createDCAStrategy(cfg *configpkg.DCAConfig) strategy.Strategy {
-	// Use optimized Enhanced DCA strategy
-	dca := strategy.NewEnhancedDCAStrategy(cfg.BaseAmount)
-	
-	// Set maximum position multiplier from configuration
-	dca.SetMaxMultiplier(cfg.MaxMultiplier)
-	
-	// Configure spacing strategy - required for consistency
-	if cfg.DCASpacing == nil {
-		// This should not happen in GA optimization, but handle gracefully
-		return dca
-	}
-	
-	spacingConfig := spacing.SpacingConfig{
-		Strategy:   cfg.DCASpacing.Strategy,
-		Parameters: cfg.DCASpacing.Parameters,
-	}
-	
-	spacingStrategy, err := spacing.CreateSpacingStrategy(spacingConfig)
-	if err != nil {
-		// Log error but continue with default fixed spacing for GA robustness
-		log.Printf("⚠️ GA: Failed to create spacing strategy: %v, using default fixed spacing", err)
-		defaultConfig := spacing.SpacingConfig{
-			Strategy: "fixed",
-			Parameters: map[string]interface{}{
-				"base_threshold":       0.01,  // 1%
-				"threshold_multiplier": 1.15,  // 1.15x
-			},
-		}
-		spacingStrategy, _ = spacing.CreateSpacingStrategy(defaultConfig)
-	}
-	
-	if err := spacingStrategy.ValidateConfig(); err != nil {
-		// Log error but continue with default fixed spacing for GA robustness
-		log.Printf("⚠️ GA: Spacing strategy validation failed: %v, using default fixed spacing", err)
-		defaultConfig := spacing.SpacingConfig{
-			Strategy: "fixed",
-			Parameters: map[string]interface{}{
-				"base_threshold":       0.01,  // 1%
-				"threshold_multiplier": 1.15,  // 1.15x
-			},
-		}
-		spacingStrategy, _ = spacing.CreateSpacingStrategy(defaultConfig)
-	}
-	
-	dca.SetSpacingStrategy(spacingStrategy)
-	
-	// Create a set for efficient lookup
-	include := make(map[string]bool)
-	for _, name := range cfg.Indicators {
-		include[strings.ToLower(strings.TrimSpace(name))] = true
-	}
-	
-	// Add indicators in EXACT same order as orchestrator for deterministic results
-	if include["rsi"] {
-		rsi := oscillators.NewRSI(cfg.RSIPeriod)
-		rsi.SetOversold(cfg.RSIOversold)
-		rsi.SetOverbought(cfg.RSIOverbought)
-		dca.AddIndicator(rsi)
-	}
-	if include["macd"] {
-		macd := oscillators.NewMACD(cfg.MACDFast, cfg.MACDSlow, cfg.MACDSignal)
-		dca.AddIndicator(macd)
-	}
-	if include["bb"] || include["bollinger"] {
-		bb := bands.NewBollingerBandsEMA(cfg.BBPeriod, cfg.BBStdDev)
-		dca.AddIndicator(bb)
-	}
-	if include["ema"] {
-		ema := common.NewEMA(cfg.EMAPeriod)
-		dca.AddIndicator(ema)
-	}
-	if include["hullma"] || include["hull_ma"] {
-		hullMA := trend.NewHullMA(cfg.HullMAPeriod)
-		dca.AddIndicator(hullMA)
-	}
-	if include["supertrend"] || include["st"] {
-		supertrend := trend.NewSuperTrendWithParams(cfg.SuperTrendPeriod, cfg.SuperTrendMultiplier)
-		dca.AddIndicator(supertrend)
-	}
-	if include["mfi"] {
-		mfi := oscillators.NewMFIWithPeriod(cfg.MFIPeriod)
-		mfi.SetOversold(cfg.MFIOversold)
-		mfi.SetOverbought(cfg.MFIOverbought)
-		dca.AddIndicator(mfi)
-	}
-	if include["keltner"] || include["kc"] {
-		keltner := bands.NewKeltnerChannelsCustom(cfg.KeltnerPeriod, cfg.KeltnerMultiplier)
-		dca.AddIndicator(keltner)
-	}
-	if include["wavetrend"] || include["wt"] {
-		wavetrend := oscillators.NewWaveTrendCustom(cfg.WaveTrendN1, cfg.WaveTrendN2)
-		wavetrend.SetOverbought(cfg.WaveTrendOverbought)
-		wavetrend.SetOversold(cfg.WaveTrendOversold)
-		dca.AddIndicator(wavetrend)
-	}
-	if include["obv"] {
-		obv := volume.NewOBV()
-		dca.AddIndicator(obv)
-	}
-	if include["stochrsi"] || include["stochastic_rsi"] || include["stoch_rsi"] {
-		stochRSI := oscillators.NewStochasticRSI()
-		dca.AddIndicator(stochRSI)
-	}
-	
-	// CRITICAL FIX: Configure dynamic TP if present in the config
-	if cfg.DynamicTP != nil {
-		dca.SetDynamicTPConfig(cfg.DynamicTP)
-	}
-	
-	return dca
-}
-
 // RandomChoice selects a random element from a slice - extracted from main.go
 func RandomChoice[T any](choices []T, rng *rand.Rand) T {
 	if len(choices) == 0 {
@@ -0,0 +1,40 @@
+package optimization
+
+import (
+	"sync/atomic"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators"
+)
+
+// DefaultIndicatorCacheBytes is the default memory budget of the indicator
+// series cache. A year of 5m candles takes about 7.5 MB per indicator series.
+const DefaultIndicatorCacheBytes int64 = 512 << 20
+
+// indicatorCache holds the indicator series shared by every backtest the
+// optimizers run, so individuals with the same indicator parameters compute
+// them once; nil when disabled
+var indicatorCache atomic.Pointer[indicators.SeriesCache]
+
+func init() {
+	SetIndicatorCacheBudget(DefaultIndicatorCacheBytes)
+}
+
+// SetIndicatorCacheBudget replaces the indicator series cache with an empty
+// one holding at most budgetBytes; 0 disables the cache
+func SetIndicatorCacheBudget(budgetBytes int64) {
+	if budgetBytes <= 0 {
+		indicatorCache.Store(nil)
+		return
+	}
+	indicatorCache.Store(indicators.NewSeriesCache(budgetBytes))
+}
+
+// IndicatorCacheStats returns the counters of the indicator series cache and
+// false if the cache is disabled
+func IndicatorCacheStats() (indicators.SeriesCacheStats, bool) {
+	cache := indicatorCache.Load()
+	if cache == nil {
+		return indicators.SeriesCacheStats{}, false
+	}
+	return cache.Stats(), true
+}
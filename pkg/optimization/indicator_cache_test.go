@@ -0,0 +1,118 @@
+package optimization
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	configpkg "github.com/ducminhle1904/crypto-dca-bot/pkg/config"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
+)
+
+// randomWalk returns n candles of the given interval following a seeded
+// random walk with a slow cycle, so indicators fire and cycles close
+func randomWalk(n int, interval time.Duration) []types.OHLCV {
+	rng := rand.New(rand.NewSource(7))
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	candles := make([]types.OHLCV, n)
+	price := 30000.0
+	for i := range candles {
+		open := price
+		price *= 1 + rng.NormFloat64()*0.002 + 0.0004*math.Sin(float64(i)/500)
+		high := math.Max(open, price) * (1 + rng.Float64()*0.001)
+		low := math.Min(open, price) * (1 - rng.Float64()*0.001)
+		candles[i] = types.OHLCV{
+			Timestamp: start.Add(time.Duration(i) * interval),
+			Open:      open, High: high, Low: low, Close: price, Volume: 10 + rng.Float64()*90,
+		}
+	}
+	return candles
+}
+
+func cacheTestConfig(indicatorNames ...string) *configpkg.DCAConfig {
+	cfg := configpkg.NewDefaultDCAConfig()
+	cfg.Symbol = "BTCUSDT"
+	cfg.Indicators = indicatorNames
+	cfg.DCASpacing = &configpkg.DCASpacingConfig{
+		Strategy:   "fixed",
+		Parameters: map[string]interface{}{"base_threshold": 0.01, "threshold_multiplier": 1.15},
+	}
+	return cfg
+}
+
+// withIndicatorCache runs fn with a cache of budgetBytes (0 = disabled) and
+// restores the default cache afterwards
+func withIndicatorCache(budgetBytes int64, fn func()) {
+	SetIndicatorCacheBudget(budgetBytes)
+	defer SetIndicatorCacheBudget(DefaultIndicatorCacheBytes)
+	fn()
+}
+
+func TestBoundSeriesBacktestMatchesUncached(t *testing.T) {
+	data := randomWalk(3000, time.Hour)
+	configs := map[string]*configpkg.DCAConfig{
+		"oscillators":      cacheTestConfig("rsi", "macd", "bb", "stochrsi", "mfi", "wavetrend"),
+		"trend":            cacheTestConfig("hma", "supertrend", "ichimoku", "adx", "rsi"),
+		"bands and volume": cacheTestConfig("keltner", "donchian", "obv", "cmf", "sma", "ema"),
+	}
+
+	for name, cfg := range configs {
+		t.Run(name, func(t *testing.T) {
+			uncached := RunBacktestWithData(cfg, data)
+			if uncached.TotalTrades == 0 {
+				t.Fatal("expected the reference run to trade")
+			}
+
+			withIndicatorCache(DefaultIndicatorCacheBytes, func() {
+				// The first run computes and binds the series, the second reads them
+				for _, run := range []string{"computing", "reading"} {
+					cached := RunBacktestWithData(cfg, data)
+					if !reflect.DeepEqual(cached, uncached) {
+						t.Errorf("%s run: expected %.6f%% over %d trades, got %.6f%% over %d trades",
+							run, uncached.TotalReturn*100, uncached.TotalTrades, cached.TotalReturn*100, cached.TotalTrades)
+					}
+				}
+				stats, ok := IndicatorCacheStats()
+				if !ok || stats.Misses == 0 || stats.Hits < stats.Misses {
+					t.Errorf("expected the second run to read every series computed by the first, got %+v", stats)
+				}
+			})
+		})
+	}
+}
+
+// BenchmarkGAIndicatorCache runs the same seeded GA over a year of 5m
+// candles with and without the indicator series cache:
+//
+//	go test ./pkg/optimization -run '^$' -bench GAIndicatorCache -benchtime 1x
+func BenchmarkGAIndicatorCache(b *testing.B) {
+	data := randomWalk(365*24*12, 5*time.Minute)
+	cfg := cacheTestConfig("rsi", "macd", "bb")
+	opts := DefaultSearchOptions()
+	opts.Seed = 42
+	opts.MaxEvaluations = 120
+
+	for _, bench := range []struct {
+		name   string
+		budget int64
+	}{
+		{name: "cache=off", budget: 0},
+		{name: "cache=on", budget: DefaultIndicatorCacheBytes},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			defer SetIndicatorCacheBudget(DefaultIndicatorCacheBytes)
+			for i := 0; i < b.N; i++ {
+				// Each optimization starts from an empty cache
+				SetIndicatorCacheBudget(bench.budget)
+				if _, _, _, err := OptimizeDataWithOptions(cfg, data, opts); err != nil {
+					b.Fatal(err)
+				}
+			}
+			if stats, ok := IndicatorCacheStats(); ok {
+				b.ReportMetric(float64(stats.Hits), "hits/op")
+			}
+		})
+	}
+}
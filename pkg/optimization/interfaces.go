@@ -87,15 +87,56 @@ type OptimizationRanges struct {
 	StochasticRSIPeriods []int
 	StochasticRSIOverboughts []float64
 	StochasticRSIOversolds []float64
+	ADXPeriods         []int
+	ADXThresholds      []float64
+	IchimokuTenkans    []int
+	IchimokuKijuns     []int
+	IchimokuSenkouBs   []int
+	DonchianPeriods    []int
+	AVWAPAnchorLookbacks []int
+	AVWAPBandMultipliers []float64
+	CMFPeriods         []int
+	CMFThresholds      []float64
+	VolumeProfileLookbacks   []int
+	VolumeProfileBins        []int
+	VolumeProfileNodeFactors []float64
+	VolumeProfileProximities []float64
 	
 	// DCA Spacing: Volatility Adaptive parameters
 	VolatilitySensitivity []float64
 	ATRPeriods           []int
 	LevelMultipliers     []float64
 	
+	// DCA Spacing: Volume Profile parameters (profile lookback shared with the indicator)
+	VolumeProfileSnapRanges []float64
+	
+	// DCA Spacing: Swing Low parameters
+	SwingLookbacks       []int
+	SwingPivotStrengths  []int
+	SwingBuffers         []float64
+	
+	// DCA Spacing: Band parameters
+	BandSpacingPeriods     []int
+	BandSpacingMultipliers []float64
+	BandLevelSteps         []float64
+	
+	// DCA Spacing: Drawdown Percentile parameters
+	DrawdownBasePercentiles []float64
+	DrawdownPercentileSteps []float64
+	
+	// Ladder sizing: volume_scale parameters
+	VolumeScales         []float64
+	LadderLevels         []int
+	
+	// Signal aggregation: per-indicator vote weights (weighted and strength modes)
+	AggregationWeights   []float64
+	
 	// Dynamic TP parameters
 	TPVolatilityMultipliers []float64 // Volatility-based TP multipliers
 	TPMinPercents          []float64 // Minimum TP percentages
 	TPMaxPercents          []float64 // Maximum TP percentages
 	TPStrengthMultipliers  []float64 // Indicator strength multipliers
+	TPDecayGraceDays       []float64 // Time-decay TP: days before the TP starts decaying
+	TPDecayDays            []float64 // Time-decay TP: days to decay from the full TP to the floor
+	TPBreakEvenLevels      []int     // Time-decay TP: DCA level that drops the TP to the floor (0 = off)
 }
@@ -30,15 +30,44 @@ var DefaultOptimizationRanges = OptimizationRanges{
 	StochasticRSIPeriods: []int{10, 12, 14, 16, 18, 20, 22},
 	StochasticRSIOverboughts: []float64{75.0, 80.0, 85.0, 90.0},
 	StochasticRSIOversolds: []float64{10.0, 15.0, 20.0, 25.0},
+	ADXPeriods:         []int{10, 12, 14, 16, 18, 20, 25},
+	ADXThresholds:      []float64{18, 20, 22, 25, 28, 30, 35},
+	IchimokuTenkans:    []int{7, 8, 9, 10, 12},
+	IchimokuKijuns:     []int{20, 22, 24, 26, 30},
+	IchimokuSenkouBs:   []int{40, 44, 48, 52, 60},
+	DonchianPeriods:    []int{10, 14, 20, 25, 30, 40, 55},
+	AVWAPAnchorLookbacks: []int{20, 30, 40, 50, 75, 100},
+	AVWAPBandMultipliers: []float64{1.0, 1.5, 2.0, 2.5, 3.0},
+	CMFPeriods:         []int{10, 14, 20, 21, 30},
+	CMFThresholds:      []float64{0.0, 0.02, 0.05, 0.08, 0.1, 0.15},
+	VolumeProfileLookbacks:   []int{30, 40, 50, 60, 80, 100},
+	VolumeProfileBins:        []int{12, 16, 20, 24, 32},
+	VolumeProfileNodeFactors: []float64{1.2, 1.5, 1.8, 2.0},
+	VolumeProfileProximities: []float64{0.005, 0.01, 0.015, 0.02},
 	VolatilitySensitivity: []float64{1.0, 1.2, 1.5, 1.8, 2.0, 2.5, 3.0, 3.5, 4.0},
 	ATRPeriods:           []int{10, 12, 14, 16, 18, 21, 24, 28},
 	LevelMultipliers:     []float64{1.05, 1.1, 1.15, 1.2, 1.25, 1.3, 1.35, 1.4},
+	VolumeProfileSnapRanges: []float64{0.25, 0.5, 0.75},
+	SwingLookbacks:       []int{30, 50, 75, 100},
+	SwingPivotStrengths:  []int{2, 3, 4, 5},
+	SwingBuffers:         []float64{0, 0.001, 0.002, 0.003, 0.005},
+	BandSpacingPeriods:     []int{14, 20, 26, 34},
+	BandSpacingMultipliers: []float64{1.0, 1.5, 2.0, 2.5, 3.0},
+	BandLevelSteps:         []float64{0, 0.25, 0.5, 0.75, 1.0},
+	DrawdownBasePercentiles: []float64{30, 40, 50, 60, 70},
+	DrawdownPercentileSteps: []float64{0, 5, 10, 15, 20},
+	VolumeScales:         []float64{1.0, 1.1, 1.2, 1.3, 1.4, 1.5, 1.6, 1.8, 2.0},
+	LadderLevels:         []int{3, 4, 5, 6, 7, 8, 10},
+	AggregationWeights:   []float64{0.0, 0.25, 0.5, 0.75, 1.0},
 	
 	// Dynamic TP optimization ranges
 	TPVolatilityMultipliers: []float64{0.3, 0.5, 0.7, 0.8, 1.0, 1.2, 1.5, 1.8, 2.0},
 	TPMinPercents:          []float64{0.005, 0.008, 0.01, 0.012, 0.015, 0.018, 0.02},
 	TPMaxPercents:          []float64{0.04, 0.05, 0.06, 0.07, 0.08, 0.09, 0.10, 0.12},
 	TPStrengthMultipliers:  []float64{0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 1.0, 1.2},
+	TPDecayGraceDays:       []float64{0, 1, 2, 3, 5},
+	TPDecayDays:            []float64{3, 5, 7, 10, 14, 21},
+	TPBreakEvenLevels:      []int{0, 4, 5, 6, 8},
 }
 
 // GetDefaultOptimizationRanges returns the default optimization ranges
@@ -0,0 +1,399 @@
+package optimization
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/backtest"
+	configpkg "github.com/ducminhle1904/crypto-dca-bot/pkg/config"
+	datamanager "github.com/ducminhle1904/crypto-dca-bot/pkg/data"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
+)
+
+// SearchMethod selects the optimization algorithm
+type SearchMethod string
+
+const (
+	SearchMethodGA     SearchMethod = "ga"
+	SearchMethodGrid   SearchMethod = "grid"
+	SearchMethodRandom SearchMethod = "random"
+	SearchMethodTPE    SearchMethod = "tpe"
+)
+
+// Stop reasons reported in SearchResult
+const (
+	StopReasonCompleted      = "completed"
+	StopReasonMaxEvaluations = "max evaluations reached"
+	StopReasonMaxDuration    = "time budget exhausted"
+	StopReasonEarlyStopped   = "early stopped (no improvement)"
+	StopReasonSpaceExhausted = "search space exhausted"
+)
+
+// Search defaults
+const (
+	DefaultRandomEvaluations   = GAPopulationSize * GAGenerations // Same backtest count as a full GA run
+	MaxGridPointsWithoutBudget = 100000                           // Refuse unbounded grids larger than this
+)
+
+// ParseSearchMethod converts a CLI value into a SearchMethod
+func ParseSearchMethod(value string) (SearchMethod, error) {
+	switch SearchMethod(strings.ToLower(strings.TrimSpace(value))) {
+	case "", SearchMethodGA:
+		return SearchMethodGA, nil
+	case SearchMethodGrid:
+		return SearchMethodGrid, nil
+	case SearchMethodRandom:
+		return SearchMethodRandom, nil
+	case SearchMethodTPE:
+		return SearchMethodTPE, nil
+	default:
+		return "", fmt.Errorf("unknown optimizer %q (valid: ga, grid, random, tpe)", value)
+	}
+}
+
+// SearchOptions controls which optimizer runs and how long it may run
+type SearchOptions struct {
+	Method            SearchMethod
+	Seed              int64         // 0 = derive from clock; the effective seed is always reported
+	MaxEvaluations    int           // Backtest budget; 0 = method default
+	MaxDuration       time.Duration // Wall-clock budget; 0 = unlimited
+	EarlyStopPatience int           // Stop after this many evaluations without improvement; 0 = disabled
+	MinImprovement    float64       // Minimum fitness gain that resets the patience counter
+	MaxWorkers        int           // Parallel backtests; 0 = MaxParallelWorkers
+}
+
+// DefaultSearchOptions returns options equivalent to the historical GA behaviour
+func DefaultSearchOptions() SearchOptions {
+	return SearchOptions{
+		Method:         SearchMethodGA,
+		MinImprovement: 1e-6,
+		MaxWorkers:     MaxParallelWorkers,
+	}
+}
+
+// EvaluationRecord is one row of the optimization results table
+type EvaluationRecord struct {
+	Index           int               `json:"index"`
+	Params          map[string]string `json:"params"`
+	Fitness         float64           `json:"fitness"`
+	TotalReturn     float64           `json:"total_return"`
+	MaxDrawdown     float64           `json:"max_drawdown"`
+	SharpeRatio     float64           `json:"sharpe_ratio"`
+	ProfitFactor    float64           `json:"profit_factor"`
+	TotalTrades     int               `json:"total_trades"`
+	CompletedCycles int               `json:"completed_cycles"`
+	Duration        time.Duration     `json:"duration_ns"`
+	IsBest          bool              `json:"is_best"`
+
+	Config *configpkg.DCAConfig `json:"-"`
+}
+
+// SearchResult holds the outcome of an optimization run
+type SearchResult struct {
+	Method      SearchMethod
+	Seed        int64
+	BestConfig  *configpkg.DCAConfig
+	BestResults *backtest.BacktestResults
+	Evaluations []EvaluationRecord
+	ParamNames  []string
+	StopReason  string
+	Elapsed     time.Duration
+	SpaceSize   float64
+}
+
+// SearchOptimizer is implemented by every optimization algorithm
+type SearchOptimizer interface {
+	Name() SearchMethod
+	Search(baseConfig *configpkg.DCAConfig, data []types.OHLCV) (*SearchResult, error)
+}
+
+// NewSearchOptimizer creates the optimizer selected in opts
+func NewSearchOptimizer(opts SearchOptions) (SearchOptimizer, error) {
+	opts = normalizeSearchOptions(opts)
+
+	switch opts.Method {
+	case SearchMethodGA:
+		return &GAOptimizer{opts: opts}, nil
+	case SearchMethodGrid:
+		return &GridOptimizer{opts: opts}, nil
+	case SearchMethodRandom:
+		return &RandomOptimizer{opts: opts}, nil
+	case SearchMethodTPE:
+		return &TPEOptimizer{opts: opts}, nil
+	default:
+		return nil, fmt.Errorf("unknown optimizer %q", opts.Method)
+	}
+}
+
+// OptimizeWithOptions loads data and runs the optimizer selected in opts.
+// The returned config is the best config found; the search result carries the
+// full table of evaluated configs.
+func OptimizeWithOptions(baseConfig interface{}, dataFile string, selectedPeriod time.Duration, opts SearchOptions) (*backtest.BacktestResults, interface{}, *SearchResult, error) {
+	dcaConfig, ok := baseConfig.(*configpkg.DCAConfig)
+	if !ok {
+		return nil, nil, nil, fmt.Errorf("optimization requires *config.DCAConfig, got %T", baseConfig)
+	}
+
+	data, err := loadOptimizationData(dataFile, selectedPeriod)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return OptimizeDataWithOptions(dcaConfig, data, opts)
+}
+
+// OptimizeDataWithOptions runs the optimizer selected in opts on preloaded data
+func OptimizeDataWithOptions(baseConfig *configpkg.DCAConfig, data []types.OHLCV, opts SearchOptions) (*backtest.BacktestResults, interface{}, *SearchResult, error) {
+	optimizer, err := NewSearchOptimizer(opts)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	result, err := optimizer.Search(baseConfig, data)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return result.BestResults, result.BestConfig, result, nil
+}
+
+func loadOptimizationData(dataFile string, selectedPeriod time.Duration) ([]types.OHLCV, error) {
+	data, err := datamanager.LoadHistoricalDataCached(dataFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load data for optimization: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("no valid data found for optimization in file: %s", dataFile)
+	}
+
+	if selectedPeriod > 0 {
+		data = datamanager.FilterDataByPeriod(data, selectedPeriod)
+		if len(data) == 0 {
+			return nil, fmt.Errorf("no data remaining for optimization after applying period filter of %v", selectedPeriod)
+		}
+		log.Printf("ℹ️ Filtered to last %v of data (%s → %s)",
+			selectedPeriod,
+			data[0].Timestamp.Format("2006-01-02"),
+			data[len(data)-1].Timestamp.Format("2006-01-02"))
+	}
+
+	return data, nil
+}
+
+func normalizeSearchOptions(opts SearchOptions) SearchOptions {
+	if opts.Method == "" {
+		opts.Method = SearchMethodGA
+	}
+	if opts.Seed == 0 {
+		opts.Seed = time.Now().UnixNano()
+	}
+	if opts.MaxWorkers <= 0 {
+		opts.MaxWorkers = MaxParallelWorkers
+	}
+	if opts.MinImprovement <= 0 {
+		opts.MinImprovement = 1e-6
+	}
+	return opts
+}
+
+// searchEvaluator runs backtests for an optimizer, records every evaluation
+// and enforces the evaluation/time budgets and early stopping.
+type searchEvaluator struct {
+	opts   SearchOptions
+	space  *SearchSpace
+	data   []types.OHLCV
+	start  time.Time
+	budget int
+
+	records []EvaluationRecord
+	cache   map[string]float64
+	best    *EvaluationRecord
+	bestAt  int
+	stopped string
+}
+
+func newSearchEvaluator(opts SearchOptions, space *SearchSpace, data []types.OHLCV, defaultBudget int) *searchEvaluator {
+	budget := opts.MaxEvaluations
+	if budget <= 0 {
+		budget = defaultBudget
+	}
+	return &searchEvaluator{
+		opts:   opts,
+		space:  space,
+		data:   data,
+		start:  time.Now(),
+		budget: budget,
+		cache:  make(map[string]float64),
+	}
+}
+
+// remaining returns how many more backtests the budget allows (-1 = unlimited)
+func (e *searchEvaluator) remaining() int {
+	if e.budget <= 0 {
+		return -1
+	}
+	return e.budget - len(e.records)
+}
+
+// done reports whether the search must stop, recording the reason
+func (e *searchEvaluator) done() bool {
+	if e.stopped != "" {
+		return true
+	}
+	if e.budget > 0 && len(e.records) >= e.budget {
+		e.stopped = StopReasonMaxEvaluations
+	} else if e.opts.MaxDuration > 0 && time.Since(e.start) >= e.opts.MaxDuration {
+		e.stopped = StopReasonMaxDuration
+	} else if e.opts.EarlyStopPatience > 0 && e.best != nil && len(e.records)-e.bestAt >= e.opts.EarlyStopPatience {
+		e.stopped = StopReasonEarlyStopped
+	}
+	return e.stopped != ""
+}
+
+// seen reports whether an identical config was already evaluated
+func (e *searchEvaluator) seen(cfg *configpkg.DCAConfig) (float64, bool) {
+	fitness, ok := e.cache[e.space.Key(cfg)]
+	return fitness, ok
+}
+
+// evaluate runs the configs in parallel and returns their fitness values in
+// input order. Configs already evaluated are served from the cache and do not
+// consume budget; configs beyond the remaining budget are not run and get -Inf.
+func (e *searchEvaluator) evaluate(configs []*configpkg.DCAConfig) []float64 {
+	fitness := make([]float64, len(configs))
+	type job struct {
+		pos    int
+		record EvaluationRecord
+	}
+
+	var jobs []*job
+	pending := make(map[string]bool)
+	for i, cfg := range configs {
+		key := e.space.Key(cfg)
+		if f, ok := e.cache[key]; ok {
+			fitness[i] = f
+			continue
+		}
+		if pending[key] {
+			fitness[i] = math.Inf(-1) // duplicate within the batch, filled in below
+			continue
+		}
+		if rem := e.remaining(); rem >= 0 && len(jobs) >= rem {
+			fitness[i] = math.Inf(-1)
+			continue
+		}
+		pending[key] = true
+		jobs = append(jobs, &job{pos: i, record: EvaluationRecord{
+			Index:  len(e.records) + len(jobs) + 1,
+			Params: e.space.Describe(cfg),
+			Config: cfg,
+		}})
+	}
+
+	var wg sync.WaitGroup
+	workerChan := make(chan struct{}, e.opts.MaxWorkers)
+	for _, j := range jobs {
+		wg.Add(1)
+		go func(j *job) {
+			defer wg.Done()
+
+			workerChan <- struct{}{}        // Acquire worker slot
+			defer func() { <-workerChan }() // Release worker slot
+
+			started := time.Now()
+			results := RunBacktestWithData(j.record.Config, e.data)
+			j.record.Duration = time.Since(started)
+			j.record.Fitness = results.TotalReturn
+			j.record.TotalReturn = results.TotalReturn
+			j.record.MaxDrawdown = results.MaxDrawdown
+			j.record.SharpeRatio = results.SharpeRatio
+			j.record.ProfitFactor = results.ProfitFactor
+			j.record.TotalTrades = results.TotalTrades
+			j.record.CompletedCycles = results.CompletedCycles
+		}(j)
+	}
+	wg.Wait()
+
+	// Record in submission order so tables are reproducible for a given seed
+	for _, j := range jobs {
+		e.records = append(e.records, j.record)
+		e.cache[e.space.Key(j.record.Config)] = j.record.Fitness
+		fitness[j.pos] = j.record.Fitness
+
+		if e.best == nil || j.record.Fitness > e.best.Fitness+e.opts.MinImprovement {
+			rec := j.record
+			e.best = &rec
+			e.bestAt = len(e.records)
+		}
+	}
+
+	// Resolve in-batch duplicates
+	for i, cfg := range configs {
+		if math.IsInf(fitness[i], -1) {
+			if f, ok := e.cache[e.space.Key(cfg)]; ok {
+				fitness[i] = f
+			}
+		}
+	}
+
+	return fitness
+}
+
+// finish re-runs the best config and assembles the search result
+func (e *searchEvaluator) finish(method SearchMethod, seed int64) (*SearchResult, error) {
+	if e.best == nil {
+		return nil, fmt.Errorf("%s optimizer evaluated no configurations", method)
+	}
+	if e.stopped == "" {
+		e.stopped = StopReasonCompleted
+	}
+
+	// Re-run the best configuration to ensure consistency with standalone runs,
+	// this time with the decision snapshots the report asks for
+	bestResults := runBacktest(e.best.Config, e.data, nil, true)
+
+	for i := range e.records {
+		e.records[i].IsBest = e.records[i].Index == e.best.Index
+	}
+
+	names := make([]string, len(e.space.Parameters))
+	for i, p := range e.space.Parameters {
+		names[i] = p.Name
+	}
+
+	result := &SearchResult{
+		Method:      method,
+		Seed:        seed,
+		BestConfig:  e.best.Config,
+		BestResults: bestResults,
+		Evaluations: e.records,
+		ParamNames:  names,
+		StopReason:  e.stopped,
+		Elapsed:     time.Since(e.start),
+		SpaceSize:   e.space.Size(),
+	}
+
+	log.Printf("🧬 %s optimizer: %d backtests in %v (seed %d, %s), best return %.2f%%",
+		strings.ToUpper(string(method)), len(e.records), result.Elapsed.Round(time.Millisecond),
+		seed, e.stopped, e.best.Fitness*100)
+
+	return result, nil
+}
+
+// TopEvaluations returns the n best evaluations sorted by fitness (descending)
+func (r *SearchResult) TopEvaluations(n int) []EvaluationRecord {
+	sorted := make([]EvaluationRecord, len(r.Evaluations))
+	copy(sorted, r.Evaluations)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Fitness > sorted[j].Fitness
+	})
+	if n > 0 && n < len(sorted) {
+		sorted = sorted[:n]
+	}
+	return sorted
+}
@@ -0,0 +1,343 @@
+package optimization
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+	"sort"
+
+	configpkg "github.com/ducminhle1904/crypto-dca-bot/pkg/config"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
+)
+
+// TPE tuning constants
+const (
+	TPEGamma            = 0.25 // Fraction of trials treated as "good"
+	TPECandidates       = 24   // Candidates drawn from the good distribution per pick
+	TPEPriorWeight      = 1.0  // Uniform prior added to every category count
+	TPEMinStartupTrials = 10   // Random trials before the model is used
+)
+
+// maxDuplicateDraws bounds consecutive duplicate samples before a sampler
+// concludes the reachable space is exhausted
+const maxDuplicateDraws = 1000
+
+// maxIdleGenerations bounds consecutive GA generations without a new config
+const maxIdleGenerations = 50
+
+// GAOptimizer wraps the genetic algorithm with seeding, budgets and early stopping
+type GAOptimizer struct {
+	opts SearchOptions
+}
+
+// Name returns the optimizer method
+func (o *GAOptimizer) Name() SearchMethod { return SearchMethodGA }
+
+// Search runs the genetic algorithm. Without an evaluation budget it runs the
+// standard GAGenerations; with one it keeps evolving until the budget is used.
+func (o *GAOptimizer) Search(baseConfig *configpkg.DCAConfig, data []types.OHLCV) (*SearchResult, error) {
+	rng := rand.New(rand.NewSource(o.opts.Seed))
+	space := BuildSearchSpace(baseConfig, nil)
+	eval := newSearchEvaluator(o.opts, space, data, 0)
+
+	generations := GAGenerations
+	if o.opts.MaxEvaluations > 0 {
+		generations = math.MaxInt32
+	}
+
+	population := InitializePopulation(baseConfig, GAPopulationSize, rng)
+	idleGenerations := 0
+	for gen := 0; gen < generations; gen++ {
+		configs := make([]*configpkg.DCAConfig, 0, len(population))
+		var pending []*GAIndividual
+		for _, individual := range population {
+			cfg := individual.Config.(*configpkg.DCAConfig)
+			if fitness, ok := eval.seen(cfg); ok {
+				individual.Fitness = fitness // Elites and repeated children reuse earlier backtests
+				continue
+			}
+			configs = append(configs, cfg)
+			pending = append(pending, individual)
+		}
+
+		// A converged population only reproduces known configs; stop instead of
+		// spinning when running on an evaluation budget
+		if len(configs) == 0 {
+			idleGenerations++
+			if idleGenerations >= maxIdleGenerations {
+				eval.stopped = StopReasonSpaceExhausted
+				break
+			}
+		} else {
+			idleGenerations = 0
+		}
+
+		fitness := eval.evaluate(configs)
+		for i, individual := range pending {
+			individual.Fitness = fitness[i]
+		}
+
+		SortPopulationByFitness(population)
+
+		if eval.done() {
+			break
+		}
+		if gen < generations-1 {
+			population = CreateNextGeneration(population, GAEliteSize, GACrossoverRate, GAMutationRate, baseConfig, rng)
+		}
+	}
+
+	return eval.finish(SearchMethodGA, o.opts.Seed)
+}
+
+// GridOptimizer enumerates every combination of the search space
+type GridOptimizer struct {
+	opts SearchOptions
+}
+
+// Name returns the optimizer method
+func (o *GridOptimizer) Name() SearchMethod { return SearchMethodGrid }
+
+// Search walks the grid in mixed-radix order (last parameter varies fastest)
+// until every point is evaluated or a budget is exhausted.
+func (o *GridOptimizer) Search(baseConfig *configpkg.DCAConfig, data []types.OHLCV) (*SearchResult, error) {
+	space := BuildSearchSpace(baseConfig, nil)
+	size := space.Size()
+
+	if o.opts.MaxEvaluations <= 0 && o.opts.MaxDuration <= 0 && size > MaxGridPointsWithoutBudget {
+		return nil, fmt.Errorf("grid has %.0f points; set a budget (-max-evals or -max-time) or use random/tpe search", size)
+	}
+	if o.opts.MaxEvaluations > 0 && float64(o.opts.MaxEvaluations) < size {
+		log.Printf("⚠️ Grid has %.0f points but the budget allows %d - only the first part of the grid will be evaluated", size, o.opts.MaxEvaluations)
+	}
+
+	eval := newSearchEvaluator(o.opts, space, data, 0)
+	point := make([]int, space.Dimensions())
+	batchSize := o.opts.MaxWorkers * 4
+	exhausted := false
+
+	for !exhausted && !eval.done() {
+		batch := make([]*configpkg.DCAConfig, 0, batchSize)
+		for len(batch) < batchSize && !exhausted {
+			batch = append(batch, space.NewConfig(baseConfig, point))
+			exhausted = !nextGridPoint(point, space)
+		}
+		eval.evaluate(batch)
+	}
+
+	if exhausted && !eval.done() {
+		eval.stopped = StopReasonCompleted
+	}
+
+	return eval.finish(SearchMethodGrid, o.opts.Seed)
+}
+
+// nextGridPoint advances point to the next grid position, returning false
+// after the last one
+func nextGridPoint(point []int, space *SearchSpace) bool {
+	for i := len(point) - 1; i >= 0; i-- {
+		point[i]++
+		if point[i] < space.Parameters[i].Size {
+			return true
+		}
+		point[i] = 0
+	}
+	return false
+}
+
+// RandomOptimizer samples the search space uniformly
+type RandomOptimizer struct {
+	opts SearchOptions
+}
+
+// Name returns the optimizer method
+func (o *RandomOptimizer) Name() SearchMethod { return SearchMethodRandom }
+
+// Search draws unique random points until a budget is exhausted
+func (o *RandomOptimizer) Search(baseConfig *configpkg.DCAConfig, data []types.OHLCV) (*SearchResult, error) {
+	rng := rand.New(rand.NewSource(o.opts.Seed))
+	space := BuildSearchSpace(baseConfig, nil)
+	eval := newSearchEvaluator(o.opts, space, data, DefaultRandomEvaluations)
+
+	for !eval.done() {
+		batch, exhausted := drawUniqueConfigs(eval, baseConfig, o.opts.MaxWorkers, func() []int {
+			return randomPoint(space, rng)
+		})
+		eval.evaluate(batch)
+		if exhausted {
+			eval.stopped = StopReasonSpaceExhausted
+		}
+	}
+
+	return eval.finish(SearchMethodRandom, o.opts.Seed)
+}
+
+// TPEOptimizer implements a Tree-structured Parzen Estimator over the
+// categorical search space. After a random start-up phase, trials are split
+// into good (top TPEGamma) and bad sets; each parameter gets a smoothed
+// categorical density per set and candidates maximising l(x)/g(x) are evaluated.
+type TPEOptimizer struct {
+	opts SearchOptions
+}
+
+// Name returns the optimizer method
+func (o *TPEOptimizer) Name() SearchMethod { return SearchMethodTPE }
+
+type tpeTrial struct {
+	point   []int
+	fitness float64
+}
+
+// Search runs TPE until a budget is exhausted
+func (o *TPEOptimizer) Search(baseConfig *configpkg.DCAConfig, data []types.OHLCV) (*SearchResult, error) {
+	rng := rand.New(rand.NewSource(o.opts.Seed))
+	space := BuildSearchSpace(baseConfig, nil)
+	eval := newSearchEvaluator(o.opts, space, data, DefaultRandomEvaluations)
+
+	startup := TPEMinStartupTrials
+	if 2*space.Dimensions() > startup {
+		startup = 2 * space.Dimensions()
+	}
+
+	var trials []tpeTrial
+	for !eval.done() {
+		var points [][]int
+		sampler := func() []int { return randomPoint(space, rng) }
+		if len(trials) >= startup {
+			model := newTPEModel(space, trials)
+			sampler = func() []int { return model.suggest(rng) }
+		}
+
+		batch, exhausted := drawUniqueConfigsWithPoints(eval, baseConfig, o.opts.MaxWorkers, sampler, &points)
+		fitness := eval.evaluate(batch)
+		for i := range batch {
+			if !math.IsInf(fitness[i], -1) {
+				trials = append(trials, tpeTrial{point: points[i], fitness: fitness[i]})
+			}
+		}
+		if exhausted {
+			eval.stopped = StopReasonSpaceExhausted
+		}
+	}
+
+	return eval.finish(SearchMethodTPE, o.opts.Seed)
+}
+
+// tpeModel holds the per-parameter good/bad categorical densities
+type tpeModel struct {
+	space *SearchSpace
+	good  [][]float64
+	bad   [][]float64
+}
+
+func newTPEModel(space *SearchSpace, trials []tpeTrial) *tpeModel {
+	sorted := make([]tpeTrial, len(trials))
+	copy(sorted, trials)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].fitness > sorted[j].fitness })
+
+	nGood := int(math.Ceil(TPEGamma * float64(len(sorted))))
+	if nGood < 1 {
+		nGood = 1
+	}
+
+	model := &tpeModel{space: space}
+	model.good = categoricalDensities(space, sorted[:nGood])
+	model.bad = categoricalDensities(space, sorted[nGood:])
+	return model
+}
+
+func categoricalDensities(space *SearchSpace, trials []tpeTrial) [][]float64 {
+	densities := make([][]float64, space.Dimensions())
+	for d, p := range space.Parameters {
+		counts := make([]float64, p.Size)
+		for k := range counts {
+			counts[k] = TPEPriorWeight
+		}
+		for _, t := range trials {
+			counts[t.point[d]]++
+		}
+		total := float64(len(trials)) + TPEPriorWeight*float64(p.Size)
+		for k := range counts {
+			counts[k] /= total
+		}
+		densities[d] = counts
+	}
+	return densities
+}
+
+// suggest draws TPECandidates points from the good densities and returns the
+// one with the highest l(x)/g(x) ratio
+func (m *tpeModel) suggest(rng *rand.Rand) []int {
+	var best []int
+	bestScore := math.Inf(-1)
+
+	for c := 0; c < TPECandidates; c++ {
+		point := make([]int, m.space.Dimensions())
+		score := 0.0
+		for d := range point {
+			point[d] = sampleCategorical(m.good[d], rng)
+			score += math.Log(m.good[d][point[d]]) - math.Log(m.bad[d][point[d]])
+		}
+		if score > bestScore {
+			best, bestScore = point, score
+		}
+	}
+	return best
+}
+
+func sampleCategorical(weights []float64, rng *rand.Rand) int {
+	r := rng.Float64()
+	cumulative := 0.0
+	for k, w := range weights {
+		cumulative += w
+		if r < cumulative {
+			return k
+		}
+	}
+	return len(weights) - 1
+}
+
+func randomPoint(space *SearchSpace, rng *rand.Rand) []int {
+	point := make([]int, space.Dimensions())
+	for d, p := range space.Parameters {
+		point[d] = rng.Intn(p.Size)
+	}
+	return point
+}
+
+// drawUniqueConfigs samples up to n configs that have not been evaluated yet.
+// It reports exhausted when the sampler keeps producing known configs.
+func drawUniqueConfigs(eval *searchEvaluator, baseConfig *configpkg.DCAConfig, n int, sample func() []int) ([]*configpkg.DCAConfig, bool) {
+	var points [][]int
+	return drawUniqueConfigsWithPoints(eval, baseConfig, n, sample, &points)
+}
+
+func drawUniqueConfigsWithPoints(eval *searchEvaluator, baseConfig *configpkg.DCAConfig, n int, sample func() []int, points *[][]int) ([]*configpkg.DCAConfig, bool) {
+	if rem := eval.remaining(); rem >= 0 && rem < n {
+		n = rem
+	}
+
+	batch := make([]*configpkg.DCAConfig, 0, n)
+	inBatch := make(map[string]bool)
+	duplicates := 0
+
+	for len(batch) < n {
+		point := sample()
+		cfg := eval.space.NewConfig(baseConfig, point)
+		key := eval.space.Key(cfg)
+		if _, seen := eval.seen(cfg); seen || inBatch[key] {
+			duplicates++
+			if duplicates >= maxDuplicateDraws {
+				return batch, true
+			}
+			continue
+		}
+		duplicates = 0
+		inBatch[key] = true
+		batch = append(batch, cfg)
+		*points = append(*points, point)
+	}
+
+	return batch, false
+}
@@ -0,0 +1,600 @@
+package optimization
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/strategy/spacing"
+	configpkg "github.com/ducminhle1904/crypto-dca-bot/pkg/config"
+)
+
+// SearchParameter is one discrete dimension of the optimization search space.
+// Values are addressed by index so grid, random and TPE search can treat every
+// dimension as a categorical choice.
+type SearchParameter struct {
+	Name   string
+	Size   int
+	values []float64 // Numeric value per index
+	apply  func(cfg *configpkg.DCAConfig, idx int)
+	read   func(cfg *configpkg.DCAConfig) string
+	number func(cfg *configpkg.DCAConfig) (float64, bool)
+}
+
+// SearchSpace is the set of parameters explored for a given base config.
+// It mirrors the fields randomized by RandomizeConfig so all optimizers search
+// the same space as the genetic algorithm.
+type SearchSpace struct {
+	Parameters []SearchParameter
+}
+
+// BuildSearchSpace derives the search space from the base config (indicators,
+// spacing strategy and dynamic TP strategy) and the optimization ranges.
+func BuildSearchSpace(baseConfig *configpkg.DCAConfig, ranges *OptimizationRanges) *SearchSpace {
+	if ranges == nil {
+		ranges = GetDefaultOptimizationRanges()
+	}
+	s := &SearchSpace{}
+
+	// Strategies without the DCA settings share only the take profit
+	if !searchesDCASettings(baseConfig) {
+		s.addFloat("tp_percent", ranges.TPCandidates,
+			func(c *configpkg.DCAConfig, v float64) { c.TPPercent = v },
+			func(c *configpkg.DCAConfig) float64 { return c.TPPercent })
+		s.addStrategyParams(baseConfig)
+		return s
+	}
+
+	s.addFloat("max_multiplier", ranges.Multipliers,
+		func(c *configpkg.DCAConfig, v float64) { c.MaxMultiplier = v },
+		func(c *configpkg.DCAConfig) float64 { return c.MaxMultiplier })
+	s.addFloat("tp_percent", ranges.TPCandidates,
+		func(c *configpkg.DCAConfig, v float64) { c.TPPercent = v },
+		func(c *configpkg.DCAConfig) float64 { return c.TPPercent })
+
+	// DCA spacing parameters depend on the configured spacing strategy
+	s.addSpacing("base_threshold", ranges.PriceThresholds)
+	switch spacingStrategyOf(baseConfig) {
+	case "volatility_adaptive":
+		s.addSpacing("volatility_sensitivity", ranges.VolatilitySensitivity)
+		s.addSpacing("atr_period", ranges.ATRPeriods)
+		s.addSpacing("level_multiplier", ranges.LevelMultipliers)
+	case "volume_profile":
+		s.addSpacing("threshold_multiplier", ranges.PriceThresholdMultipliers)
+		s.addSpacing("lookback", ranges.VolumeProfileLookbacks)
+		s.addSpacing("snap_range", ranges.VolumeProfileSnapRanges)
+	case "swing_low":
+		s.addSpacing("threshold_multiplier", ranges.PriceThresholdMultipliers)
+		s.addSpacing("lookback", ranges.SwingLookbacks)
+		s.addSpacing("pivot_strength", ranges.SwingPivotStrengths)
+		s.addSpacing("buffer", ranges.SwingBuffers)
+	case "band":
+		s.addSpacing("period", ranges.BandSpacingPeriods)
+		s.addSpacing("band_multiplier", ranges.BandSpacingMultipliers)
+		s.addSpacing("level_step", ranges.BandLevelSteps)
+	case "drawdown_percentile":
+		s.addSpacing("threshold_multiplier", ranges.PriceThresholdMultipliers)
+		s.addSpacing("base_percentile", ranges.DrawdownBasePercentiles)
+		s.addSpacing("percentile_step", ranges.DrawdownPercentileSteps)
+	default:
+		s.addSpacing("threshold_multiplier", ranges.PriceThresholdMultipliers)
+	}
+
+	// Volume scale ladder parameters, only when that sizing mode is configured
+	if baseConfig != nil && baseConfig.PositionSizing != nil && baseConfig.PositionSizing.Mode == configpkg.SizingModeVolumeScale {
+		s.addFloat("volume_scale", ranges.VolumeScales,
+			func(c *configpkg.DCAConfig, v float64) { c.PositionSizing.VolumeScale = v },
+			func(c *configpkg.DCAConfig) float64 { return c.PositionSizing.VolumeScale })
+		s.addInt("ladder_levels", ranges.LadderLevels,
+			func(c *configpkg.DCAConfig, v int) { c.PositionSizing.MaxLevels = v },
+			func(c *configpkg.DCAConfig) int { return c.PositionSizing.MaxLevels })
+	}
+
+	indicatorSet := make(map[string]bool)
+	for _, ind := range indicatorsOf(baseConfig) {
+		indicatorSet[strings.ToLower(ind)] = true
+	}
+
+	// Vote weight per indicator, only for the weighted aggregation modes
+	if baseConfig != nil && baseConfig.SignalAggregation.IsWeighted() {
+		for _, ind := range indicatorsOf(baseConfig) {
+			name := strings.ToLower(ind)
+			if configpkg.IsEntryFilterIndicator(name) {
+				continue // No vote to weigh
+			}
+			s.addFloat("weight_"+name, ranges.AggregationWeights,
+				func(c *configpkg.DCAConfig, v float64) { c.SignalAggregation.SetWeight(name, v) },
+				func(c *configpkg.DCAConfig) float64 { return c.SignalAggregation.Weight(name) })
+		}
+	}
+
+	if indicatorSet["rsi"] {
+		s.addInt("rsi_period", ranges.RSIPeriods,
+			func(c *configpkg.DCAConfig, v int) { c.RSIPeriod = v },
+			func(c *configpkg.DCAConfig) int { return c.RSIPeriod })
+		s.addFloat("rsi_oversold", ranges.RSIOversold,
+			func(c *configpkg.DCAConfig, v float64) { c.RSIOversold = v },
+			func(c *configpkg.DCAConfig) float64 { return c.RSIOversold })
+		// Overbought is sampled from the mirrored oversold range, as in RandomizeConfig
+		s.addFloat("rsi_overbought", mirrorRange(ranges.RSIOversold),
+			func(c *configpkg.DCAConfig, v float64) { c.RSIOverbought = v },
+			func(c *configpkg.DCAConfig) float64 { return c.RSIOverbought })
+	}
+	if indicatorSet["macd"] {
+		s.addInt("macd_fast", ranges.MACDFast,
+			func(c *configpkg.DCAConfig, v int) { c.MACDFast = v },
+			func(c *configpkg.DCAConfig) int { return c.MACDFast })
+		s.addInt("macd_slow", ranges.MACDSlow,
+			func(c *configpkg.DCAConfig, v int) { c.MACDSlow = v },
+			func(c *configpkg.DCAConfig) int { return c.MACDSlow })
+		s.addInt("macd_signal", ranges.MACDSignal,
+			func(c *configpkg.DCAConfig, v int) { c.MACDSignal = v },
+			func(c *configpkg.DCAConfig) int { return c.MACDSignal })
+	}
+	if indicatorSet["bb"] || indicatorSet["bollinger"] {
+		s.addInt("bb_period", ranges.BBPeriods,
+			func(c *configpkg.DCAConfig, v int) { c.BBPeriod = v },
+			func(c *configpkg.DCAConfig) int { return c.BBPeriod })
+		s.addFloat("bb_std_dev", ranges.BBStdDev,
+			func(c *configpkg.DCAConfig, v float64) { c.BBStdDev = v },
+			func(c *configpkg.DCAConfig) float64 { return c.BBStdDev })
+	}
+	if indicatorSet["ema"] {
+		s.addInt("ema_period", ranges.EMAPeriods,
+			func(c *configpkg.DCAConfig, v int) { c.EMAPeriod = v },
+			func(c *configpkg.DCAConfig) int { return c.EMAPeriod })
+	}
+	if indicatorSet["hullma"] || indicatorSet["hull_ma"] {
+		s.addInt("hull_ma_period", ranges.HullMAPeriods,
+			func(c *configpkg.DCAConfig, v int) { c.HullMAPeriod = v },
+			func(c *configpkg.DCAConfig) int { return c.HullMAPeriod })
+	}
+	if indicatorSet["supertrend"] || indicatorSet["st"] {
+		s.addInt("supertrend_period", ranges.SuperTrendPeriods,
+			func(c *configpkg.DCAConfig, v int) { c.SuperTrendPeriod = v },
+			func(c *configpkg.DCAConfig) int { return c.SuperTrendPeriod })
+		s.addFloat("supertrend_multiplier", ranges.SuperTrendMultipliers,
+			func(c *configpkg.DCAConfig, v float64) { c.SuperTrendMultiplier = v },
+			func(c *configpkg.DCAConfig) float64 { return c.SuperTrendMultiplier })
+	}
+	if indicatorSet["mfi"] {
+		s.addInt("mfi_period", ranges.MFIPeriods,
+			func(c *configpkg.DCAConfig, v int) { c.MFIPeriod = v },
+			func(c *configpkg.DCAConfig) int { return c.MFIPeriod })
+		s.addFloat("mfi_oversold", ranges.MFIOversold,
+			func(c *configpkg.DCAConfig, v float64) { c.MFIOversold = v },
+			func(c *configpkg.DCAConfig) float64 { return c.MFIOversold })
+		s.addFloat("mfi_overbought", ranges.MFIOverbought,
+			func(c *configpkg.DCAConfig, v float64) { c.MFIOverbought = v },
+			func(c *configpkg.DCAConfig) float64 { return c.MFIOverbought })
+	}
+	if indicatorSet["keltner"] || indicatorSet["kc"] {
+		s.addInt("keltner_period", ranges.KeltnerPeriods,
+			func(c *configpkg.DCAConfig, v int) { c.KeltnerPeriod = v },
+			func(c *configpkg.DCAConfig) int { return c.KeltnerPeriod })
+		s.addFloat("keltner_multiplier", ranges.KeltnerMultipliers,
+			func(c *configpkg.DCAConfig, v float64) { c.KeltnerMultiplier = v },
+			func(c *configpkg.DCAConfig) float64 { return c.KeltnerMultiplier })
+	}
+	if indicatorSet["wavetrend"] || indicatorSet["wt"] {
+		s.addInt("wavetrend_n1", ranges.WaveTrendN1,
+			func(c *configpkg.DCAConfig, v int) { c.WaveTrendN1 = v },
+			func(c *configpkg.DCAConfig) int { return c.WaveTrendN1 })
+		s.addInt("wavetrend_n2", ranges.WaveTrendN2,
+			func(c *configpkg.DCAConfig, v int) { c.WaveTrendN2 = v },
+			func(c *configpkg.DCAConfig) int { return c.WaveTrendN2 })
+		s.addFloat("wavetrend_overbought", ranges.WaveTrendOverbought,
+			func(c *configpkg.DCAConfig, v float64) { c.WaveTrendOverbought = v },
+			func(c *configpkg.DCAConfig) float64 { return c.WaveTrendOverbought })
+		s.addFloat("wavetrend_oversold", ranges.WaveTrendOversold,
+			func(c *configpkg.DCAConfig, v float64) { c.WaveTrendOversold = v },
+			func(c *configpkg.DCAConfig) float64 { return c.WaveTrendOversold })
+	}
+	if indicatorSet["obv"] {
+		s.addFloat("obv_trend_threshold", ranges.OBVTrendThresholds,
+			func(c *configpkg.DCAConfig, v float64) { c.OBVTrendThreshold = v },
+			func(c *configpkg.DCAConfig) float64 { return c.OBVTrendThreshold })
+	}
+	if indicatorSet["stochrsi"] || indicatorSet["stochastic_rsi"] || indicatorSet["stoch_rsi"] {
+		s.addInt("stoch_rsi_period", ranges.StochasticRSIPeriods,
+			func(c *configpkg.DCAConfig, v int) { c.StochasticRSIPeriod = v },
+			func(c *configpkg.DCAConfig) int { return c.StochasticRSIPeriod })
+		s.addFloat("stoch_rsi_overbought", ranges.StochasticRSIOverboughts,
+			func(c *configpkg.DCAConfig, v float64) { c.StochasticRSIOverbought = v },
+			func(c *configpkg.DCAConfig) float64 { return c.StochasticRSIOverbought })
+		s.addFloat("stoch_rsi_oversold", ranges.StochasticRSIOversolds,
+			func(c *configpkg.DCAConfig, v float64) { c.StochasticRSIOversold = v },
+			func(c *configpkg.DCAConfig) float64 { return c.StochasticRSIOversold })
+	}
+	if indicatorSet["adx"] || indicatorSet["dmi"] {
+		s.addInt("adx_period", ranges.ADXPeriods,
+			func(c *configpkg.DCAConfig, v int) { c.ADXPeriod = v },
+			func(c *configpkg.DCAConfig) int { return c.ADXPeriod })
+		s.addFloat("adx_threshold", ranges.ADXThresholds,
+			func(c *configpkg.DCAConfig, v float64) { c.ADXThreshold = v },
+			func(c *configpkg.DCAConfig) float64 { return c.ADXThreshold })
+	}
+	if indicatorSet["ichimoku"] || indicatorSet["ichi"] {
+		s.addInt("ichimoku_tenkan", ranges.IchimokuTenkans,
+			func(c *configpkg.DCAConfig, v int) { c.IchimokuTenkan = v },
+			func(c *configpkg.DCAConfig) int { return c.IchimokuTenkan })
+		s.addInt("ichimoku_kijun", ranges.IchimokuKijuns,
+			func(c *configpkg.DCAConfig, v int) { c.IchimokuKijun = v },
+			func(c *configpkg.DCAConfig) int { return c.IchimokuKijun })
+		s.addInt("ichimoku_senkou_b", ranges.IchimokuSenkouBs,
+			func(c *configpkg.DCAConfig, v int) { c.IchimokuSenkouB = v },
+			func(c *configpkg.DCAConfig) int { return c.IchimokuSenkouB })
+	}
+	if indicatorSet["donchian"] || indicatorSet["dc"] {
+		s.addInt("donchian_period", ranges.DonchianPeriods,
+			func(c *configpkg.DCAConfig, v int) { c.DonchianPeriod = v },
+			func(c *configpkg.DCAConfig) int { return c.DonchianPeriod })
+	}
+	if indicatorSet["anchored_vwap"] || indicatorSet["avwap"] || indicatorSet["vwap"] {
+		if baseConfig != nil && baseConfig.AVWAPAnchor == "highest_high" {
+			s.addInt("avwap_anchor_lookback", ranges.AVWAPAnchorLookbacks,
+				func(c *configpkg.DCAConfig, v int) { c.AVWAPAnchorLookback = v },
+				func(c *configpkg.DCAConfig) int { return c.AVWAPAnchorLookback })
+		}
+		s.addFloat("avwap_band_multiplier", ranges.AVWAPBandMultipliers,
+			func(c *configpkg.DCAConfig, v float64) { c.AVWAPBandMultiplier = v },
+			func(c *configpkg.DCAConfig) float64 { return c.AVWAPBandMultiplier })
+	}
+	if indicatorSet["cmf"] || indicatorSet["chaikin"] {
+		s.addInt("cmf_period", ranges.CMFPeriods,
+			func(c *configpkg.DCAConfig, v int) { c.CMFPeriod = v },
+			func(c *configpkg.DCAConfig) int { return c.CMFPeriod })
+		s.addFloat("cmf_threshold", ranges.CMFThresholds,
+			func(c *configpkg.DCAConfig, v float64) { c.CMFThreshold = v },
+			func(c *configpkg.DCAConfig) float64 { return c.CMFThreshold })
+	}
+	if indicatorSet["volume_profile"] || indicatorSet["vp"] || indicatorSet["vpvr"] {
+		s.addInt("volume_profile_lookback", ranges.VolumeProfileLookbacks,
+			func(c *configpkg.DCAConfig, v int) { c.VolumeProfileLookback = v },
+			func(c *configpkg.DCAConfig) int { return c.VolumeProfileLookback })
+		s.addInt("volume_profile_bins", ranges.VolumeProfileBins,
+			func(c *configpkg.DCAConfig, v int) { c.VolumeProfileBins = v },
+			func(c *configpkg.DCAConfig) int { return c.VolumeProfileBins })
+		s.addFloat("volume_profile_node_factor", ranges.VolumeProfileNodeFactors,
+			func(c *configpkg.DCAConfig, v float64) { c.VolumeProfileNodeFactor = v },
+			func(c *configpkg.DCAConfig) float64 { return c.VolumeProfileNodeFactor })
+		s.addFloat("volume_profile_proximity", ranges.VolumeProfileProximities,
+			func(c *configpkg.DCAConfig, v float64) { c.VolumeProfileProximity = v },
+			func(c *configpkg.DCAConfig) float64 { return c.VolumeProfileProximity })
+	}
+
+	// Dynamic TP parameters, only when dynamic TP is configured
+	if baseConfig != nil && baseConfig.DynamicTP != nil {
+		s.addFloat("dynamic_tp_base_percent", ranges.TPCandidates,
+			func(c *configpkg.DCAConfig, v float64) { c.DynamicTP.BaseTPPercent = v },
+			func(c *configpkg.DCAConfig) float64 { return c.DynamicTP.BaseTPPercent })
+
+		switch baseConfig.DynamicTP.Strategy {
+		case "volatility_adaptive":
+			if baseConfig.DynamicTP.VolatilityConfig != nil {
+				s.addFloat("dynamic_tp_volatility_multiplier", ranges.TPVolatilityMultipliers,
+					func(c *configpkg.DCAConfig, v float64) { c.DynamicTP.VolatilityConfig.Multiplier = v },
+					func(c *configpkg.DCAConfig) float64 { return c.DynamicTP.VolatilityConfig.Multiplier })
+				s.addFloat("dynamic_tp_min_percent", ranges.TPMinPercents,
+					func(c *configpkg.DCAConfig, v float64) { c.DynamicTP.VolatilityConfig.MinTPPercent = v },
+					func(c *configpkg.DCAConfig) float64 { return c.DynamicTP.VolatilityConfig.MinTPPercent })
+				s.addFloat("dynamic_tp_max_percent", ranges.TPMaxPercents,
+					func(c *configpkg.DCAConfig, v float64) { c.DynamicTP.VolatilityConfig.MaxTPPercent = v },
+					func(c *configpkg.DCAConfig) float64 { return c.DynamicTP.VolatilityConfig.MaxTPPercent })
+			}
+		case "indicator_based":
+			if baseConfig.DynamicTP.IndicatorConfig != nil {
+				s.addFloat("dynamic_tp_strength_multiplier", ranges.TPStrengthMultipliers,
+					func(c *configpkg.DCAConfig, v float64) { c.DynamicTP.IndicatorConfig.StrengthMultiplier = v },
+					func(c *configpkg.DCAConfig) float64 { return c.DynamicTP.IndicatorConfig.StrengthMultiplier })
+				s.addFloat("dynamic_tp_min_percent", ranges.TPMinPercents,
+					func(c *configpkg.DCAConfig, v float64) { c.DynamicTP.IndicatorConfig.MinTPPercent = v },
+					func(c *configpkg.DCAConfig) float64 { return c.DynamicTP.IndicatorConfig.MinTPPercent })
+				s.addFloat("dynamic_tp_max_percent", ranges.TPMaxPercents,
+					func(c *configpkg.DCAConfig, v float64) { c.DynamicTP.IndicatorConfig.MaxTPPercent = v },
+					func(c *configpkg.DCAConfig) float64 { return c.DynamicTP.IndicatorConfig.MaxTPPercent })
+			}
+		case "time_decay":
+			if baseConfig.DynamicTP.TimeDecayConfig != nil {
+				s.addFloat("dynamic_tp_decay_grace_days", ranges.TPDecayGraceDays,
+					func(c *configpkg.DCAConfig, v float64) { c.DynamicTP.TimeDecayConfig.GraceDays = v },
+					func(c *configpkg.DCAConfig) float64 { return c.DynamicTP.TimeDecayConfig.GraceDays })
+				s.addFloat("dynamic_tp_decay_days", ranges.TPDecayDays,
+					func(c *configpkg.DCAConfig, v float64) { c.DynamicTP.TimeDecayConfig.DecayDays = v },
+					func(c *configpkg.DCAConfig) float64 { return c.DynamicTP.TimeDecayConfig.DecayDays })
+				s.addInt("dynamic_tp_break_even_levels", ranges.TPBreakEvenLevels,
+					func(c *configpkg.DCAConfig, v int) { c.DynamicTP.TimeDecayConfig.BreakEvenLevels = v },
+					func(c *configpkg.DCAConfig) int { return c.DynamicTP.TimeDecayConfig.BreakEvenLevels })
+			}
+		}
+	}
+
+	s.addStrategyParams(baseConfig)
+
+	return s
+}
+
+// Dimensions returns the number of parameters in the space
+func (s *SearchSpace) Dimensions() int {
+	return len(s.Parameters)
+}
+
+// Size returns the total number of grid points. It is a float64 because the
+// cartesian product easily exceeds the int range for larger indicator sets.
+func (s *SearchSpace) Size() float64 {
+	size := 1.0
+	for _, p := range s.Parameters {
+		size *= float64(p.Size)
+	}
+	return size
+}
+
+// NewConfig builds a config for the given point (one index per parameter)
+// starting from a deep copy of the base config.
+func (s *SearchSpace) NewConfig(baseConfig *configpkg.DCAConfig, point []int) *configpkg.DCAConfig {
+	cfg := copyConfig(baseConfig).(*configpkg.DCAConfig)
+	prepareSearchConfig(cfg)
+
+	for i, p := range s.Parameters {
+		if i < len(point) {
+			p.apply(cfg, point[i])
+		}
+	}
+
+	validateAndFixParameterOrdering(cfg)
+	synchronizeATRPeriodsInConfig(cfg)
+	return cfg
+}
+
+// Describe returns the parameter values of a config keyed by parameter name.
+// It works for any config, including ones produced by the genetic operators.
+func (s *SearchSpace) Describe(cfg *configpkg.DCAConfig) map[string]string {
+	params := make(map[string]string, len(s.Parameters))
+	for _, p := range s.Parameters {
+		params[p.Name] = p.read(cfg)
+	}
+	return params
+}
+
+// Key returns a stable identifier for a config within this space, used to
+// avoid re-running identical backtests.
+func (s *SearchSpace) Key(cfg *configpkg.DCAConfig) string {
+	parts := make([]string, len(s.Parameters))
+	for i, p := range s.Parameters {
+		parts[i] = p.Name + "=" + p.read(cfg)
+	}
+	return strings.Join(parts, "|")
+}
+
+// ExtractParameters returns the numeric value of every search parameter in the
+// config. It implements validation.ParameterAnalyzer.
+func (s *SearchSpace) ExtractParameters(config interface{}) map[string]float64 {
+	cfg, ok := config.(*configpkg.DCAConfig)
+	if !ok {
+		return nil
+	}
+	params := make(map[string]float64, len(s.Parameters))
+	for _, p := range s.Parameters {
+		if v, ok := p.number(cfg); ok {
+			params[p.Name] = v
+		}
+	}
+	return params
+}
+
+// BuildConsensusConfig copies the template config and sets every parameter to
+// the range value nearest to the requested one. It implements
+// validation.ParameterAnalyzer.
+func (s *SearchSpace) BuildConsensusConfig(template interface{}, values map[string]float64) interface{} {
+	base, ok := template.(*configpkg.DCAConfig)
+	if !ok {
+		return nil
+	}
+	cfg := copyConfig(base).(*configpkg.DCAConfig)
+	if cfg.DCASpacing == nil {
+		prepareSearchConfig(cfg)
+	}
+	if cfg.DCASpacing.Parameters == nil {
+		cfg.DCASpacing.Parameters = make(map[string]interface{})
+	}
+
+	for _, p := range s.Parameters {
+		if v, ok := values[p.Name]; ok {
+			p.apply(cfg, nearestIndex(p.values, v))
+		}
+	}
+
+	validateAndFixParameterOrdering(cfg)
+	synchronizeATRPeriodsInConfig(cfg)
+	return cfg
+}
+
+func nearestIndex(values []float64, target float64) int {
+	best := 0
+	for i, v := range values {
+		if math.Abs(v-target) < math.Abs(values[best]-target) {
+			best = i
+		}
+	}
+	return best
+}
+
+// prepareSearchConfig applies the fixed settings RandomizeConfig uses before
+// parameters are assigned, so every optimizer starts from the same baseline.
+func prepareSearchConfig(cfg *configpkg.DCAConfig) {
+	cfg.BaseAmount = 40.0
+
+	switch spacingStrategyOf(cfg) {
+	case "volatility_adaptive":
+		cfg.DCASpacing = &configpkg.DCASpacingConfig{
+			Strategy: "volatility_adaptive",
+			Parameters: map[string]interface{}{
+				"max_threshold": 0.05,  // 5% safety limit for adaptive
+				"min_threshold": 0.003, // 0.3% safety limit
+			},
+		}
+	case "volume_profile":
+		cfg.DCASpacing = &configpkg.DCASpacingConfig{
+			Strategy: "volume_profile",
+			Parameters: map[string]interface{}{
+				"max_threshold": 0.10,  // 10% safety limit
+				"min_threshold": 0.003, // 0.3% safety limit
+				"bins":          24,
+				"node_factor":   1.5,
+			},
+		}
+	case "swing_low", "drawdown_percentile":
+		cfg.DCASpacing = &configpkg.DCASpacingConfig{
+			Strategy: cfg.DCASpacing.Strategy,
+			Parameters: map[string]interface{}{
+				"max_threshold": 0.10,  // 10% safety limit
+				"min_threshold": 0.003, // 0.3% safety limit
+			},
+		}
+	case "band":
+		cfg.DCASpacing = &configpkg.DCASpacingConfig{
+			Strategy: "band",
+			Parameters: map[string]interface{}{
+				"max_threshold": 0.10,  // 10% safety limit
+				"min_threshold": 0.003, // 0.3% safety limit
+				"band_type":     spacingBandType(cfg),
+			},
+		}
+	default:
+		cfg.DCASpacing = &configpkg.DCASpacingConfig{
+			Strategy: "fixed",
+			Parameters: map[string]interface{}{
+				"max_threshold": 0.10,  // 10% safety limit
+				"min_threshold": 0.003, // 0.3% safety limit
+			},
+		}
+	}
+
+	if len(cfg.Indicators) == 0 {
+		cfg.Indicators = []string{"rsi", "macd", "bb", "ema"}
+	}
+}
+
+func spacingStrategyOf(cfg *configpkg.DCAConfig) string {
+	if cfg != nil && cfg.DCASpacing != nil {
+		switch cfg.DCASpacing.Strategy {
+		case "volatility_adaptive", "volume_profile", "swing_low", "band", "drawdown_percentile":
+			return cfg.DCASpacing.Strategy
+		}
+	}
+	return "fixed"
+}
+
+// spacingBandType returns the band the band spacing of cfg uses, which the
+// optimizers keep as configured
+func spacingBandType(cfg *configpkg.DCAConfig) string {
+	if cfg != nil && cfg.DCASpacing != nil {
+		if bandType, ok := cfg.DCASpacing.Parameters["band_type"].(string); ok && bandType != "" {
+			return bandType
+		}
+	}
+	return spacing.BandBollinger
+}
+
+func indicatorsOf(cfg *configpkg.DCAConfig) []string {
+	if cfg == nil || len(cfg.Indicators) == 0 {
+		return []string{"rsi", "macd", "bb", "ema"}
+	}
+	return cfg.Indicators
+}
+
+func mirrorRange(values []float64) []float64 {
+	mirrored := make([]float64, len(values))
+	for i, v := range values {
+		mirrored[len(values)-1-i] = 100.0 - v
+	}
+	return mirrored
+}
+
+func (s *SearchSpace) addFloat(name string, values []float64, set func(*configpkg.DCAConfig, float64), get func(*configpkg.DCAConfig) float64) {
+	if len(values) == 0 {
+		return
+	}
+	s.Parameters = append(s.Parameters, SearchParameter{
+		Name:   name,
+		Size:   len(values),
+		values: values,
+		apply:  func(c *configpkg.DCAConfig, idx int) { set(c, values[idx]) },
+		read:   func(c *configpkg.DCAConfig) string { return formatSearchValue(get(c)) },
+		number: func(c *configpkg.DCAConfig) (float64, bool) { return get(c), true },
+	})
+}
+
+func (s *SearchSpace) addInt(name string, values []int, set func(*configpkg.DCAConfig, int), get func(*configpkg.DCAConfig) int) {
+	if len(values) == 0 {
+		return
+	}
+	numeric := make([]float64, len(values))
+	for i, v := range values {
+		numeric[i] = float64(v)
+	}
+	s.Parameters = append(s.Parameters, SearchParameter{
+		Name:   name,
+		Size:   len(values),
+		values: numeric,
+		apply:  func(c *configpkg.DCAConfig, idx int) { set(c, values[idx]) },
+		read:   func(c *configpkg.DCAConfig) string { return fmt.Sprintf("%d", get(c)) },
+		number: func(c *configpkg.DCAConfig) (float64, bool) { return float64(get(c)), true },
+	})
+}
+
+func (s *SearchSpace) addSpacing(name string, values interface{}) {
+	var numeric []float64
+	var valueAt func(idx int) interface{}
+	switch v := values.(type) {
+	case []float64:
+		numeric = v
+		valueAt = func(idx int) interface{} { return v[idx] }
+	case []int:
+		for _, n := range v {
+			numeric = append(numeric, float64(n))
+		}
+		valueAt = func(idx int) interface{} { return v[idx] }
+	}
+	if len(numeric) == 0 {
+		return
+	}
+	s.Parameters = append(s.Parameters, SearchParameter{
+		Name:   name,
+		Size:   len(numeric),
+		values: numeric,
+		number: func(c *configpkg.DCAConfig) (float64, bool) {
+			if c.DCASpacing == nil {
+				return 0, false
+			}
+			switch v := c.DCASpacing.Parameters[name].(type) {
+			case float64:
+				return v, true
+			case int:
+				return float64(v), true
+			default:
+				return 0, false
+			}
+		},
+		apply: func(c *configpkg.DCAConfig, idx int) {
+			c.DCASpacing.Parameters[name] = valueAt(idx)
+		},
+		read: func(c *configpkg.DCAConfig) string {
+			if c.DCASpacing == nil {
+				return ""
+			}
+			switch v := c.DCASpacing.Parameters[name].(type) {
+			case float64:
+				return formatSearchValue(v)
+			case nil:
+				return ""
+			default:
+				return fmt.Sprintf("%v", v)
+			}
+		},
+	})
+}
+
+func formatSearchValue(v float64) string {
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.4f", v), "0"), ".")
+}
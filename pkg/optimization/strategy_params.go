@@ -0,0 +1,98 @@
+package optimization
+
+import (
+	"math/rand"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/strategy"
+	configpkg "github.com/ducminhle1904/crypto-dca-bot/pkg/config"
+)
+
+// Registered strategies declare the strategy_params the optimizers may tune
+// (ParamSpec.Search). The genetic algorithm and the search space both draw
+// them from those values; strategies without the DCA settings share only the
+// take profit with the built-in DCA search.
+
+// strategyDefinitionOf returns the registered strategy cfg selects, or nil
+// for an unknown name (the backtest reports that error)
+func strategyDefinitionOf(cfg *configpkg.DCAConfig) *strategy.Definition {
+	name := ""
+	if cfg != nil {
+		name = cfg.StrategyName
+	}
+	def, err := strategy.Lookup(name)
+	if err != nil {
+		return nil
+	}
+	return def
+}
+
+// searchesDCASettings returns true if the optimizers tune the DCA settings of cfg
+func searchesDCASettings(cfg *configpkg.DCAConfig) bool {
+	def := strategyDefinitionOf(cfg)
+	return def == nil || def.DCASettings
+}
+
+// searchableParams returns the strategy parameters of cfg the optimizers tune
+func searchableParams(cfg *configpkg.DCAConfig) []strategy.ParamSpec {
+	def := strategyDefinitionOf(cfg)
+	if def == nil {
+		return nil
+	}
+	var specs []strategy.ParamSpec
+	for _, p := range def.Params {
+		if len(p.Search) > 0 {
+			specs = append(specs, p)
+		}
+	}
+	return specs
+}
+
+// strategyParam returns a strategy parameter of cfg, or its default if unset
+func strategyParam(cfg *configpkg.DCAConfig, p strategy.ParamSpec) float64 {
+	if v, ok := cfg.StrategyParams[p.Name]; ok {
+		return v
+	}
+	return p.Default
+}
+
+func setStrategyParam(cfg *configpkg.DCAConfig, name string, v float64) {
+	if cfg.StrategyParams == nil {
+		cfg.StrategyParams = make(map[string]float64)
+	}
+	cfg.StrategyParams[name] = v
+}
+
+// addStrategyParams adds the searchable strategy parameters of cfg
+func (s *SearchSpace) addStrategyParams(cfg *configpkg.DCAConfig) {
+	for _, p := range searchableParams(cfg) {
+		p := p
+		s.addFloat("strategy_"+p.Name, p.Search,
+			func(c *configpkg.DCAConfig, v float64) { setStrategyParam(c, p.Name, v) },
+			func(c *configpkg.DCAConfig) float64 { return strategyParam(c, p) })
+	}
+}
+
+// randomizeStrategyParams draws every searchable strategy parameter
+func randomizeStrategyParams(cfg *configpkg.DCAConfig, rng *rand.Rand) {
+	for _, p := range searchableParams(cfg) {
+		setStrategyParam(cfg, p.Name, RandomChoice(p.Search, rng))
+	}
+}
+
+// mutateStrategyParams redraws each searchable strategy parameter with a 10% chance
+func mutateStrategyParams(cfg *configpkg.DCAConfig, rng *rand.Rand) {
+	for _, p := range searchableParams(cfg) {
+		if rng.Float64() < 0.1 {
+			setStrategyParam(cfg, p.Name, RandomChoice(p.Search, rng))
+		}
+	}
+}
+
+// crossoverStrategyParams takes each searchable strategy parameter from parent2 with a 50% chance
+func crossoverStrategyParams(child, parent2 *configpkg.DCAConfig, rng *rand.Rand) {
+	for _, p := range searchableParams(child) {
+		if rng.Float64() < 0.5 {
+			setStrategyParam(child, p.Name, strategyParam(parent2, p))
+		}
+	}
+}
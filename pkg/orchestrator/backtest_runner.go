@@ -11,13 +11,7 @@ import (
 
 	"github.com/ducminhle1904/crypto-dca-bot/internal/backtest"
 	"github.com/ducminhle1904/crypto-dca-bot/internal/exchange/bybit"
-	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators/bands"
-	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators/common"
-	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators/oscillators"
-	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators/trend"
-	"github.com/ducminhle1904/crypto-dca-bot/internal/indicators/volume"
 	"github.com/ducminhle1904/crypto-dca-bot/internal/strategy"
-	"github.com/ducminhle1904/crypto-dca-bot/internal/strategy/spacing"
 	"github.com/ducminhle1904/crypto-dca-bot/pkg/config"
 	datamanager "github.com/ducminhle1904/crypto-dca-bot/pkg/data"
 	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
@@ -40,6 +34,23 @@ func (r *DefaultBacktestRunner) RunWithData(cfg *config.DCAConfig, data []types.
 	// Log configuration summary
 	r.logBacktestConfig(cfg, data)
 	
+	engine, err := r.newEngine(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := engine.PrepareTimeframes(data); err != nil {
+		return nil, fmt.Errorf("invalid timeframes: %w", err)
+	}
+	results := engine.Run(data, cfg.WindowSize)
+	
+	// Update all metrics
+	results.UpdateMetrics()
+	
+	return results, nil
+}
+
+// newEngine creates a backtest engine with a fresh strategy for cfg
+func (r *DefaultBacktestRunner) newEngine(cfg *config.DCAConfig) (*backtest.BacktestEngine, error) {
 	// Create strategy with configured indicators
 	strat, err := r.createStrategy(cfg)
 	if err != nil {
@@ -50,19 +61,29 @@ func (r *DefaultBacktestRunner) RunWithData(cfg *config.DCAConfig, data []types.
 	// This is crucial for walk-forward validation accuracy
 	strat.ResetForNewPeriod()
 	
-	// Create and run backtest engine
+	// Create backtest engine
 	tp := cfg.TPPercent
 	if !cfg.Cycle {
 		tp = 0
 	}
 	
 	engine := backtest.NewBacktestEngine(cfg.InitialBalance, cfg.Commission, strat, tp, cfg.MinOrderQty, cfg.UseTPLevels)
-	results := engine.Run(data, cfg.WindowSize)
-	
-	// Update all metrics
-	results.UpdateMetrics()
-	
-	return results, nil
+	engine.SetRiskLimits(cfg.RiskLimits)
+	engine.SetDecisionSnapshots(cfg.DecisionSnapshots)
+	if cfg.LimitGrid.IsEnabled() {
+		engine.SetGridCommission(cfg.LimitGrid.MakerCommission)
+	}
+	if err := engine.LoadTimeframeData(cfg.Timeframes); err != nil {
+		return nil, err
+	}
+	return engine, nil
+}
+
+// AuditLookahead runs the look-ahead audit on the backtest of cfg over data
+func (r *DefaultBacktestRunner) AuditLookahead(cfg *config.DCAConfig, data []types.OHLCV, cuts int) (*backtest.LookaheadAudit, error) {
+	return backtest.AuditLookahead(func() (*backtest.BacktestEngine, error) {
+		return r.newEngine(cfg)
+	}, data, cfg.WindowSize, cuts)
 }
 
 // RunWithFile executes a backtest by loading data from file
@@ -150,95 +171,8 @@ func (r *DefaultBacktestRunner) FetchAndSetMinOrderQty(cfg *config.DCAConfig) er
 
 // createStrategy creates and configures a DCA strategy from the provided configuration
 func (r *DefaultBacktestRunner) createStrategy(cfg *config.DCAConfig) (strategy.Strategy, error) {
-	// Initialize Enhanced DCA strategy with base trading amount
-	dca := strategy.NewEnhancedDCAStrategy(cfg.BaseAmount)
-	dca.SetMaxMultiplier(cfg.MaxMultiplier)
-
-	// Configure DCA spacing strategy (required for all configurations)
-	if cfg.DCASpacing == nil {
-		return nil, fmt.Errorf("no DCA spacing strategy configured - please specify dca_spacing in your configuration")
-	}
-
-	spacingConfig := spacing.SpacingConfig{
-		Strategy:   cfg.DCASpacing.Strategy,
-		Parameters: cfg.DCASpacing.Parameters,
-	}
-	
-	spacingStrategy, err := spacing.CreateSpacingStrategy(spacingConfig)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create spacing strategy: %w", err)
-	}
-	
-	if err := spacingStrategy.ValidateConfig(); err != nil {
-		return nil, fmt.Errorf("invalid spacing strategy configuration: %w", err)
-	}
-	
-	dca.SetSpacingStrategy(spacingStrategy)
-
-	// Configure dynamic take profit if specified
-	if cfg.DynamicTP != nil {
-		dca.SetDynamicTPConfig(cfg.DynamicTP)
-	}
-
-	// Indicator inclusion map
-	include := make(map[string]bool)
-	for _, name := range cfg.Indicators {
-		include[strings.ToLower(strings.TrimSpace(name))] = true
-	}
-
-	// Instantiate indicators based on what's actually in the indicators list
-	if include["rsi"] {
-		rsi := oscillators.NewRSI(cfg.RSIPeriod)
-		rsi.SetOversold(cfg.RSIOversold)
-		rsi.SetOverbought(cfg.RSIOverbought)
-		dca.AddIndicator(rsi)
-	}
-	if include["macd"] {
-		macd := oscillators.NewMACD(cfg.MACDFast, cfg.MACDSlow, cfg.MACDSignal)
-		dca.AddIndicator(macd)
-	}
-	if include["bb"] || include["bollinger"] {
-		bb := bands.NewBollingerBandsEMA(cfg.BBPeriod, cfg.BBStdDev)
-		dca.AddIndicator(bb)
-	}
-	if include["ema"] {
-		ema := common.NewEMA(cfg.EMAPeriod)
-		dca.AddIndicator(ema)
-	}
-	if include["hullma"] || include["hull_ma"] {
-		hullMA := trend.NewHullMA(cfg.HullMAPeriod)
-		dca.AddIndicator(hullMA)
-	}
-	if include["supertrend"] || include["st"] {
-		supertrend := trend.NewSuperTrendWithParams(cfg.SuperTrendPeriod, cfg.SuperTrendMultiplier)
-		dca.AddIndicator(supertrend)
-	}
-	if include["mfi"] {
-		mfi := oscillators.NewMFIWithPeriod(cfg.MFIPeriod)
-		mfi.SetOversold(cfg.MFIOversold)
-		mfi.SetOverbought(cfg.MFIOverbought)
-		dca.AddIndicator(mfi)
-	}
-	if include["keltner"] || include["kc"] {
-		keltner := bands.NewKeltnerChannelsCustom(cfg.KeltnerPeriod, cfg.KeltnerMultiplier)
-		dca.AddIndicator(keltner)
-	}
-	if include["wavetrend"] || include["wt"] {
-		wavetrend := oscillators.NewWaveTrendCustom(cfg.WaveTrendN1, cfg.WaveTrendN2)
-		wavetrend.SetOverbought(cfg.WaveTrendOverbought)
-		wavetrend.SetOversold(cfg.WaveTrendOversold)
-		dca.AddIndicator(wavetrend)
-	}
-	if include["obv"] {
-		obv := volume.NewOBV()
-		dca.AddIndicator(obv)
-	}
-	if include["stochrsi"] || include["stochastic_rsi"] || include["stoch_rsi"] {
-		stochRSI := oscillators.NewStochasticRSI()
-		dca.AddIndicator(stochRSI)
-	}
-
-	return dca, nil
+	// The strategy registered under cfg.StrategyName (enhanced DCA by default)
+	return strategy.New(cfg)
 }
 
 // getMinTPPercent extracts minimum TP percentage from dynamic TP config
@@ -300,6 +234,18 @@ func (r *DefaultBacktestRunner) getIndicatorDescription(indicators []string) str
 			formattedIndicators = append(formattedIndicators, "Keltner")
 		case "wavetrend", "wt":
 			formattedIndicators = append(formattedIndicators, "WaveTrend")
+		case "adx", "dmi":
+			formattedIndicators = append(formattedIndicators, "ADX")
+		case "ichimoku", "ichi":
+			formattedIndicators = append(formattedIndicators, "Ichimoku")
+		case "donchian", "dc":
+			formattedIndicators = append(formattedIndicators, "Donchian")
+		case "anchored_vwap", "avwap", "vwap":
+			formattedIndicators = append(formattedIndicators, "Anchored VWAP")
+		case "cmf", "chaikin":
+			formattedIndicators = append(formattedIndicators, "CMF")
+		case "volume_profile", "vp", "vpvr":
+			formattedIndicators = append(formattedIndicators, "Volume Profile")
 		default:
 			formattedIndicators = append(formattedIndicators, strings.ToUpper(ind))
 		}
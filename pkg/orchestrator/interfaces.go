@@ -5,6 +5,8 @@ import (
 
 	"github.com/ducminhle1904/crypto-dca-bot/internal/backtest"
 	"github.com/ducminhle1904/crypto-dca-bot/pkg/config"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/montecarlo"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/optimization"
 	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
 	"github.com/ducminhle1904/crypto-dca-bot/pkg/validation"
 )
@@ -19,6 +21,18 @@ type Orchestrator interface {
 	
 	// RunMultiIntervalAnalysis executes backtests across all available intervals
 	RunMultiIntervalAnalysis(cfg *config.DCAConfig, dataRoot, exchange string, optimize bool, selectedPeriod time.Duration, wfConfig *validation.WalkForwardConfig) (*IntervalAnalysisResult, error)
+	
+	// RunMonteCarlo executes Monte Carlo robustness analysis around a finished backtest
+	RunMonteCarlo(cfg *config.DCAConfig, baseline *backtest.BacktestResults, selectedPeriod time.Duration, mcConfig montecarlo.Config) (*montecarlo.Results, error)
+	
+	// RunLookaheadAudit checks that no backtest decision can read a candle that had not closed yet
+	RunLookaheadAudit(cfg *config.DCAConfig, selectedPeriod time.Duration, cuts int) (*backtest.LookaheadAudit, error)
+	
+	// LastSearchResult returns the evaluation table of the most recent optimization run (nil if none)
+	LastSearchResult() *optimization.SearchResult
+	
+	// LastWalkForwardSummary returns the most recent walk-forward validation summary (nil if none)
+	LastWalkForwardSummary() *validation.WalkForwardSummary
 }
 
 // Workflow represents different execution workflows
@@ -45,6 +59,7 @@ type IntervalResult struct {
 	Interval     string
 	Results      *backtest.BacktestResults
 	OptimizedCfg *config.DCAConfig
+	Search       *optimization.SearchResult // Set when the interval was optimized
 	Error        error
 }
 
@@ -66,6 +81,9 @@ type BacktestRunner interface {
 	
 	// FetchAndSetMinOrderQty fetches minimum order quantity from exchange and updates config
 	FetchAndSetMinOrderQty(cfg *config.DCAConfig) error
+	
+	// AuditLookahead runs the look-ahead audit on the backtest of cfg over data
+	AuditLookahead(cfg *config.DCAConfig, data []types.OHLCV, cuts int) (*backtest.LookaheadAudit, error)
 }
 
 // IntervalRunner interface for multi-interval operations
@@ -20,13 +20,20 @@ type DefaultIntervalRunner struct {
 	backtestRunner BacktestRunner
 	minQtyCache    map[string]float64 // Cache minimum order quantities to avoid API calls
 	cacheMutex     sync.RWMutex
+	searchOptions  optimization.SearchOptions
 }
 
 // NewDefaultIntervalRunner creates a new default interval runner with performance optimizations
 func NewDefaultIntervalRunner() IntervalRunner {
+	return NewDefaultIntervalRunnerWithSearchOptions(optimization.DefaultSearchOptions())
+}
+
+// NewDefaultIntervalRunnerWithSearchOptions creates an interval runner that optimizes with the given options
+func NewDefaultIntervalRunnerWithSearchOptions(opts optimization.SearchOptions) IntervalRunner {
 	return &DefaultIntervalRunner{
 		backtestRunner: NewDefaultBacktestRunner(),
 		minQtyCache:    make(map[string]float64),
+		searchOptions:  opts,
 	}
 }
 
@@ -108,6 +115,7 @@ func (r *DefaultIntervalRunner) RunForInterval(cfg *config.DCAConfig, dataRoot,
 	
 	var optimizedCfg *config.DCAConfig
 	var results *backtest.BacktestResults
+	var searchResult *optimization.SearchResult
 	var err error
 	
 	if optimize {
@@ -115,7 +123,7 @@ func (r *DefaultIntervalRunner) RunForInterval(cfg *config.DCAConfig, dataRoot,
 		
 		// Run optimization
 		var optimizedCfgInterface interface{}
-		results, optimizedCfgInterface, err = optimization.OptimizeWithGA(&cfgCopy, cfgCopy.DataFile, selectedPeriod)
+		results, optimizedCfgInterface, searchResult, err = optimization.OptimizeWithOptions(&cfgCopy, cfgCopy.DataFile, selectedPeriod, r.searchOptions)
 		if err != nil {
 			return nil, fmt.Errorf("optimization failed for interval %s: %w", interval, err)
 		}
@@ -136,6 +144,7 @@ func (r *DefaultIntervalRunner) RunForInterval(cfg *config.DCAConfig, dataRoot,
 		Interval:     interval,
 		Results:      results,
 		OptimizedCfg: optimizedCfg,
+		Search:       searchResult,
 	}, nil
 }
 
@@ -9,6 +9,7 @@ import (
 	"github.com/ducminhle1904/crypto-dca-bot/internal/backtest"
 	"github.com/ducminhle1904/crypto-dca-bot/pkg/config"
 	datamanager "github.com/ducminhle1904/crypto-dca-bot/pkg/data"
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/montecarlo"
 	"github.com/ducminhle1904/crypto-dca-bot/pkg/optimization"
 	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
 	"github.com/ducminhle1904/crypto-dca-bot/pkg/validation"
@@ -16,15 +17,25 @@ import (
 
 // DefaultOrchestrator implements the Orchestrator interface
 type DefaultOrchestrator struct {
-	backtestRunner BacktestRunner
-	intervalRunner IntervalRunner
+	backtestRunner  BacktestRunner
+	intervalRunner  IntervalRunner
+	searchOptions   optimization.SearchOptions
+	lastSearch      *optimization.SearchResult
+	lastWalkForward *validation.WalkForwardSummary
 }
 
 // NewOrchestrator creates a new orchestrator with default components
 func NewOrchestrator() Orchestrator {
+	return NewOrchestratorWithSearchOptions(optimization.DefaultSearchOptions())
+}
+
+// NewOrchestratorWithSearchOptions creates a new orchestrator whose optimization
+// runs use the given optimizer, seed and budgets
+func NewOrchestratorWithSearchOptions(opts optimization.SearchOptions) Orchestrator {
 	return &DefaultOrchestrator{
 		backtestRunner: NewDefaultBacktestRunner(),
-		intervalRunner: NewDefaultIntervalRunner(),
+		intervalRunner: NewDefaultIntervalRunnerWithSearchOptions(opts),
+		searchOptions:  opts,
 	}
 }
 
@@ -33,9 +44,20 @@ func NewOrchestratorWithComponents(backtestRunner BacktestRunner, intervalRunner
 	return &DefaultOrchestrator{
 		backtestRunner: backtestRunner,
 		intervalRunner: intervalRunner,
+		searchOptions:  optimization.DefaultSearchOptions(),
 	}
 }
 
+// LastSearchResult returns the evaluation table of the most recent optimization run
+func (o *DefaultOrchestrator) LastSearchResult() *optimization.SearchResult {
+	return o.lastSearch
+}
+
+// LastWalkForwardSummary returns the summary of the most recent walk-forward validation
+func (o *DefaultOrchestrator) LastWalkForwardSummary() *validation.WalkForwardSummary {
+	return o.lastWalkForward
+}
+
 // RunSingleBacktest executes a single backtest with the given configuration
 func (o *DefaultOrchestrator) RunSingleBacktest(cfg *config.DCAConfig, selectedPeriod time.Duration) (*backtest.BacktestResults, error) {
 	start := time.Now()
@@ -84,14 +106,15 @@ func (o *DefaultOrchestrator) RunOptimizedBacktest(cfg *config.DCAConfig, select
 		}
 	}
 	
-	// Run genetic algorithm optimization
-	log.Println("🧬 Running genetic algorithm optimization...")
+	// Run the selected optimizer
+	log.Printf("🧬 Running %s optimization...", describeSearchMethod(o.searchOptions.Method))
 	optimizationStart := time.Now()
 	
-	bestResults, bestConfigInterface, err := optimization.OptimizeWithGA(cfg, cfg.DataFile, selectedPeriod)
+	bestResults, bestConfigInterface, searchResult, err := optimization.OptimizeWithOptions(cfg, cfg.DataFile, selectedPeriod, o.searchOptions)
 	if err != nil {
 		return nil, nil, fmt.Errorf("optimization failed: %w", err)
 	}
+	o.lastSearch = searchResult
 	
 	bestConfig := bestConfigInterface.(*config.DCAConfig)
 	
@@ -152,6 +175,8 @@ func (o *DefaultOrchestrator) RunMultiIntervalAnalysis(cfg *config.DCAConfig, da
 		return nil, fmt.Errorf("no successful results found for any interval")
 	}
 	
+	o.lastSearch = bestResult.Search
+	
 	log.Printf("✅ Multi-interval analysis completed - Best interval: %s (%.2f%%)", 
 		bestResult.Interval, bestResult.Results.TotalReturn*100)
 	
@@ -163,6 +188,44 @@ func (o *DefaultOrchestrator) RunMultiIntervalAnalysis(cfg *config.DCAConfig, da
 	}, nil
 }
 
+// RunMonteCarlo reruns the strategy on resampled cycles, synthetic price paths and
+// randomized execution to estimate the spread of outcomes around a backtest
+func (o *DefaultOrchestrator) RunMonteCarlo(cfg *config.DCAConfig, baseline *backtest.BacktestResults, selectedPeriod time.Duration, mcConfig montecarlo.Config) (*montecarlo.Results, error) {
+	if strings.TrimSpace(cfg.DataFile) == "" {
+		return nil, fmt.Errorf("data file path is empty in configuration")
+	}
+	
+	data, err := datamanager.LoadHistoricalDataCached(cfg.DataFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Monte Carlo data from '%s': %w", cfg.DataFile, err)
+	}
+	
+	if selectedPeriod > 0 {
+		data = datamanager.FilterDataByPeriod(data, selectedPeriod)
+	}
+	
+	return montecarlo.RunMonteCarlo(cfg, data, baseline, mcConfig)
+}
+
+// RunLookaheadAudit checks that no backtest decision can read a candle that
+// had not closed yet, see backtest.AuditLookahead
+func (o *DefaultOrchestrator) RunLookaheadAudit(cfg *config.DCAConfig, selectedPeriod time.Duration, cuts int) (*backtest.LookaheadAudit, error) {
+	if strings.TrimSpace(cfg.DataFile) == "" {
+		return nil, fmt.Errorf("data file path is empty in configuration")
+	}
+	
+	data, err := datamanager.LoadHistoricalDataCached(cfg.DataFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load audit data from '%s': %w", cfg.DataFile, err)
+	}
+	
+	if selectedPeriod > 0 {
+		data = datamanager.FilterDataByPeriod(data, selectedPeriod)
+	}
+	
+	return o.backtestRunner.AuditLookahead(cfg, data, cuts)
+}
+
 // runWalkForwardValidation executes walk-forward validation with clean, concise logging
 func (o *DefaultOrchestrator) runWalkForwardValidation(cfg *config.DCAConfig, selectedPeriod time.Duration, wfConfig *validation.WalkForwardConfig) error {
 	// Validate and load data for validation
@@ -190,11 +253,14 @@ func (o *DefaultOrchestrator) runWalkForwardValidation(cfg *config.DCAConfig, se
 		log.Printf("ℹ️ Using default minimum order quantity: %.6f", cfg.MinOrderQty)
 	}
 
-	// Run walk-forward validation with clean logging
-	summary, err := validation.RunWalkForwardValidation(cfg, data, *wfConfig,
-		func(configInterface interface{}, data []types.OHLCV) (*backtest.BacktestResults, interface{}, error) {
-			// MinOrderQty already fetched above - just run optimization
-			return optimization.OptimizeWithGA(configInterface, cfg.DataFile, 0)
+	// Run walk-forward validation with clean logging; the search space doubles as
+	// the parameter analyzer for per-fold stability and the consensus config
+	space := optimization.BuildSearchSpace(cfg, nil)
+	summary, err := validation.RunWalkForwardValidationWithAnalyzer(cfg, data, *wfConfig,
+		func(configInterface interface{}, trainData []types.OHLCV) (*backtest.BacktestResults, interface{}, error) {
+			// MinOrderQty already fetched above - optimize on this fold's training data only
+			results, bestConfig, _, err := optimization.OptimizeDataWithOptions(configInterface.(*config.DCAConfig), trainData, o.searchOptions)
+			return results, bestConfig, err
 		},
 		func(cfg interface{}, data []types.OHLCV) *backtest.BacktestResults {
 			// Testing function with debugging
@@ -218,11 +284,13 @@ func (o *DefaultOrchestrator) runWalkForwardValidation(cfg *config.DCAConfig, se
 			}
 			
 			return results
-		})
+		},
+		space)
 	
 	if err != nil {
 		return fmt.Errorf("walk-forward validation failed: %w", err)
 	}
+	o.lastWalkForward = summary
 	
 	// Display clean summary results
 	o.printCleanWalkForwardSummary(summary)
@@ -280,6 +348,27 @@ func (o *DefaultOrchestrator) printCleanWalkForwardSummary(summary *validation.W
 		profitable = "Unprofitable"
 	}
 	
+	if summary.ConsensusTestResults != nil {
+		log.Printf("Consensus: Test=%.1f%% (from %s) | Stable params=%.0f%%", 
+			summary.ConsensusTestResults.TotalReturn*100, 
+			summary.ConsensusTestStart.Format("2006-01-02"), 
+			summary.StabilityScore*100)
+	}
+	
 	log.Printf("Assessment: %s | %s | Risk: %s", assessment, profitable, summary.OverfittingRisk)
 	log.Println(strings.Repeat("-", 65) + "\n")
 }
+
+// describeSearchMethod returns a human-readable optimizer name for logs
+func describeSearchMethod(method optimization.SearchMethod) string {
+	switch method {
+	case optimization.SearchMethodGrid:
+		return "grid search"
+	case optimization.SearchMethodRandom:
+		return "random search"
+	case optimization.SearchMethodTPE:
+		return "TPE (Bayesian)"
+	default:
+		return "genetic algorithm"
+	}
+}
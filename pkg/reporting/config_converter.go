@@ -60,6 +60,25 @@ type MainBacktestConfig struct {
 	StochasticRSIOverbought float64 `json:"stochastic_rsi_overbought"`
 	StochasticRSIOversold   float64 `json:"stochastic_rsi_oversold"`
 	
+	// Trend-strength indicator parameters
+	ADXPeriod       int     `json:"adx_period"`
+	ADXThreshold    float64 `json:"adx_threshold"`
+	IchimokuTenkan  int     `json:"ichimoku_tenkan"`
+	IchimokuKijun   int     `json:"ichimoku_kijun"`
+	IchimokuSenkouB int     `json:"ichimoku_senkou_b"`
+	DonchianPeriod  int     `json:"donchian_period"`
+	
+	// Volume-weighted indicator parameters
+	AVWAPAnchor             string  `json:"avwap_anchor"`
+	AVWAPAnchorLookback     int     `json:"avwap_anchor_lookback"`
+	AVWAPBandMultiplier     float64 `json:"avwap_band_multiplier"`
+	CMFPeriod               int     `json:"cmf_period"`
+	CMFThreshold            float64 `json:"cmf_threshold"`
+	VolumeProfileLookback   int     `json:"volume_profile_lookback"`
+	VolumeProfileBins       int     `json:"volume_profile_bins"`
+	VolumeProfileNodeFactor float64 `json:"volume_profile_node_factor"`
+	VolumeProfileProximity  float64 `json:"volume_profile_proximity"`
+	
 	// Indicator inclusion
 	Indicators     []string `json:"indicators"`
 
@@ -71,6 +90,19 @@ type MainBacktestConfig struct {
 	// Dynamic TP configuration
 	DynamicTP      *config.DynamicTPConfig `json:"dynamic_tp,omitempty"`
 	
+	// Signal aggregation configuration
+	SignalAggregation *config.SignalAggregationConfig `json:"signal_aggregation,omitempty"`
+	
+	// Expression conditions
+	Conditions        *config.ConditionsConfig `json:"conditions,omitempty"`
+	
+	// Higher timeframe confirmations
+	Timeframes        config.Timeframes `json:"timeframes,omitempty"`
+	
+	// Registered strategy and its parameters
+	StrategyName      string             `json:"strategy_name,omitempty"`
+	StrategyParams    map[string]float64 `json:"strategy_params,omitempty"`
+	
 	// Minimum lot size for realistic simulation
 	MinOrderQty    float64 `json:"min_order_qty"`
 }
@@ -84,6 +116,8 @@ func ConvertToNestedConfig(cfg MainBacktestConfig) NestedConfig {
 	
 	// Create strategy config with indicators
 	strategyConfig := StrategyConfig{
+		Name:           cfg.StrategyName,
+		Params:         cfg.StrategyParams,
 		Symbol:         cfg.Symbol,
 		DataFile:       cfg.DataFile,
 		BaseAmount:     cfg.BaseAmount,
@@ -95,6 +129,9 @@ func ConvertToNestedConfig(cfg MainBacktestConfig) NestedConfig {
 		UseTPLevels:    true,
 		Cycle:          cfg.Cycle,
 		DynamicTP:      cfg.DynamicTP,
+		SignalAggregation: cfg.SignalAggregation,
+		Conditions:     cfg.Conditions,
+		Timeframes:     cfg.Timeframes,
 		Indicators:     cfg.Indicators,
 	}
 	
@@ -159,6 +196,39 @@ func ConvertToNestedConfig(cfg MainBacktestConfig) NestedConfig {
 				Overbought: cfg.StochasticRSIOverbought,
 				Oversold:   cfg.StochasticRSIOversold,
 			}
+		case "adx", "dmi":
+			strategyConfig.ADX = &ADXConfig{
+				Period:    cfg.ADXPeriod,
+				Threshold: cfg.ADXThreshold,
+			}
+		case "ichimoku", "ichi":
+			strategyConfig.Ichimoku = &IchimokuConfig{
+				Tenkan:  cfg.IchimokuTenkan,
+				Kijun:   cfg.IchimokuKijun,
+				SenkouB: cfg.IchimokuSenkouB,
+			}
+		case "donchian", "dc":
+			strategyConfig.Donchian = &DonchianConfig{
+				Period: cfg.DonchianPeriod,
+			}
+		case "anchored_vwap", "avwap", "vwap":
+			strategyConfig.AnchoredVWAP = &AnchoredVWAPConfig{
+				Anchor:         cfg.AVWAPAnchor,
+				AnchorLookback: cfg.AVWAPAnchorLookback,
+				BandMultiplier: cfg.AVWAPBandMultiplier,
+			}
+		case "cmf", "chaikin":
+			strategyConfig.CMF = &CMFConfig{
+				Period:    cfg.CMFPeriod,
+				Threshold: cfg.CMFThreshold,
+			}
+		case "volume_profile", "vp", "vpvr":
+			strategyConfig.VolumeProfile = &VolumeProfileConfig{
+				Lookback:   cfg.VolumeProfileLookback,
+				Bins:       cfg.VolumeProfileBins,
+				NodeFactor: cfg.VolumeProfileNodeFactor,
+				Proximity:  cfg.VolumeProfileProximity,
+			}
 		}
 	}
 	
@@ -14,6 +14,8 @@ type NestedConfig struct {
 }
 
 type StrategyConfig struct {
+	Name             string                     `json:"name,omitempty"`
+	Params           map[string]float64         `json:"params,omitempty"`
 	Symbol           string                     `json:"symbol"`
 	DataFile         string                     `json:"data_file"`           
 	BaseAmount       float64                    `json:"base_amount"`
@@ -27,6 +29,9 @@ type StrategyConfig struct {
 	UseTPLevels      bool                       `json:"use_tp_levels"`
 	Cycle            bool                       `json:"cycle"`
 	DynamicTP        *config.DynamicTPConfig   `json:"dynamic_tp,omitempty"`
+	SignalAggregation *config.SignalAggregationConfig `json:"signal_aggregation,omitempty"`
+	Conditions       *config.ConditionsConfig   `json:"conditions,omitempty"`
+	Timeframes       config.Timeframes          `json:"timeframes,omitempty"`
 	Indicators       []string                   `json:"indicators"`
 	RSI            *RSIConfig                 `json:"rsi,omitempty"`
 	MACD           *MACDConfig                `json:"macd,omitempty"`
@@ -39,6 +44,12 @@ type StrategyConfig struct {
 	WaveTrend      *WaveTrendConfig           `json:"wavetrend,omitempty"`
 	OBV            *OBVConfig                 `json:"obv,omitempty"`
 	StochasticRSI  *StochasticRSIConfig      `json:"stochastic_rsi,omitempty"`
+	ADX            *ADXConfig                 `json:"adx,omitempty"`
+	Ichimoku       *IchimokuConfig            `json:"ichimoku,omitempty"`
+	Donchian       *DonchianConfig            `json:"donchian,omitempty"`
+	AnchoredVWAP   *AnchoredVWAPConfig        `json:"anchored_vwap,omitempty"`
+	CMF            *CMFConfig                 `json:"cmf,omitempty"`
+	VolumeProfile  *VolumeProfileConfig       `json:"volume_profile,omitempty"`
 }
 
 type RSIConfig struct {
@@ -122,3 +133,36 @@ type StochasticRSIConfig struct {
 	Overbought float64 `json:"overbought"`
 	Oversold   float64 `json:"oversold"`
 }
+
+type ADXConfig struct {
+	Period    int     `json:"period"`
+	Threshold float64 `json:"threshold"`
+}
+
+type IchimokuConfig struct {
+	Tenkan  int `json:"tenkan"`
+	Kijun   int `json:"kijun"`
+	SenkouB int `json:"senkou_b"`
+}
+
+type DonchianConfig struct {
+	Period int `json:"period"`
+}
+
+type AnchoredVWAPConfig struct {
+	Anchor         string  `json:"anchor"`
+	AnchorLookback int     `json:"anchor_lookback"`
+	BandMultiplier float64 `json:"band_multiplier"`
+}
+
+type CMFConfig struct {
+	Period    int     `json:"period"`
+	Threshold float64 `json:"threshold"`
+}
+
+type VolumeProfileConfig struct {
+	Lookback   int     `json:"lookback"`
+	Bins       int     `json:"bins"`
+	NodeFactor float64 `json:"node_factor"`
+	Proximity  float64 `json:"proximity"`
+}
@@ -33,6 +33,14 @@ func (r *DefaultConsoleReporter) OutputResults(results *backtest.BacktestResults
 	fmt.Printf("📊 Calmar Ratio:       %.2f\n", results.CalmarRatio)
 	fmt.Printf("💹 Profit Factor:      %.2f\n", results.ProfitFactor)
 	fmt.Printf("🔄 Total Trades:       %d\n", results.TotalTrades)
+	if results.LimitGridFills > 0 {
+		fmt.Printf("🧱 Grid Fills:         %d\n", results.LimitGridFills)
+	}
+	if results.DecayedExits > 0 {
+		fmt.Printf("⏳ Decayed Exits:      %d (%d never reached the full TP)\n", results.DecayedExits, results.DecayedExitsUnfilled)
+		fmt.Printf("⏳ Capital-Days Saved: %.0f ($ × days)\n", results.CapitalDaysSaved)
+		fmt.Printf("⏳ Profit Given Up:    $%.2f\n", results.DecayLostProfit)
+	}
 	
 	// Avoid division by zero
 	winRate := 0.0
@@ -40,6 +40,11 @@ func (r *DefaultExcelReporter) WriteTradesXLSX(results *backtest.BacktestResults
 	fx.SetSheetName(fx.GetSheetName(0), tradesSheet)
 	fx.NewSheet(cyclesSheet)
 	fx.NewSheet(detailedSheet)
+	
+	const snapshotsSheet = "Signal Snapshots"
+	if len(results.DecisionSnapshots) > 0 {
+		fx.NewSheet(snapshotsSheet)
+	}
 
 	// Create professional styles
 	styles, err := r.createExcelStyles(fx)
@@ -59,6 +64,12 @@ func (r *DefaultExcelReporter) WriteTradesXLSX(results *backtest.BacktestResults
 	if err := r.writeDetailedAnalysisSheet(fx, detailedSheet, results, styles); err != nil {
 		return err
 	}
+	
+	if len(results.DecisionSnapshots) > 0 {
+		if err := r.writeSnapshotsSheet(fx, snapshotsSheet, results, styles); err != nil {
+			return err
+		}
+	}
 
 	// Save workbook
 	return fx.SaveAs(path)
@@ -0,0 +1,292 @@
+package reporting
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/backtest"
+)
+
+// DefaultHTMLReporter writes a single self-contained HTML report with
+// interactive charts. The page has no external dependencies and works offline.
+type DefaultHTMLReporter struct{}
+
+// NewDefaultHTMLReporter creates a new HTML reporter
+func NewDefaultHTMLReporter() *DefaultHTMLReporter {
+	return &DefaultHTMLReporter{}
+}
+
+// WriteTradesHTML writes the HTML report without symbol/interval context
+func (r *DefaultHTMLReporter) WriteTradesHTML(results *backtest.BacktestResults, path string) error {
+	return r.WriteTradesHTMLWithContext(results, "", "", path)
+}
+
+// WriteTradesHTMLWithContext writes the HTML report with price, equity, drawdown
+// and exposure charts, a per-cycle table and dynamic TP analytics
+func (r *DefaultHTMLReporter) WriteTradesHTMLWithContext(results *backtest.BacktestResults, symbol, interval, path string) error {
+	if results == nil {
+		return fmt.Errorf("no backtest results to write")
+	}
+	if dir := filepath.Dir(path); dir != "." && dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", dir, err)
+		}
+	}
+
+	page, err := r.render(results, symbol, interval)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, page, 0644)
+}
+
+// htmlSeries is the chart payload embedded in the page as JSON
+type htmlSeries struct {
+	Times    []int64      `json:"times"` // Unix milliseconds
+	Price    []float64    `json:"price"`
+	Equity   []float64    `json:"equity"`
+	Drawdown []float64    `json:"drawdown"` // Percent below running equity peak
+	Exposure []float64    `json:"exposure"` // Percent of equity in position
+	Entries  []htmlMarker `json:"entries"`
+	Exits    []htmlMarker `json:"exits"`
+	TPTimes  []int64      `json:"tp_times,omitempty"`
+	TPTarget []float64    `json:"tp_target,omitempty"` // Percent
+	TPVol    []float64    `json:"tp_volatility,omitempty"`
+}
+
+type htmlMarker struct {
+	Time  int64   `json:"t"`
+	Price float64 `json:"p"`
+	Cycle int     `json:"c"`
+	Label string  `json:"l"`
+}
+
+type htmlMetric struct {
+	Label string
+	Value string
+	Tone  string // "good", "bad" or ""
+}
+
+type htmlCycle struct {
+	Number    int
+	Start     string
+	End       string
+	Duration  string
+	Hours     float64
+	Entries   int
+	AvgEntry  float64
+	Cost      float64
+	PnL       float64
+	PnLPct    float64
+	TPLevels  int
+	Completed bool
+}
+
+type htmlPage struct {
+	Title     string
+	Subtitle  string
+	Generated string
+	Summary   []htmlMetric
+	Cycles    []htmlCycle
+	DynamicTP []htmlMetric
+	Data      template.JS
+}
+
+func (r *DefaultHTMLReporter) render(results *backtest.BacktestResults, symbol, interval string) ([]byte, error) {
+	series := buildHTMLSeries(results)
+	data, err := json.Marshal(series)
+	if err != nil {
+		return nil, err
+	}
+
+	title := "DCA Backtest Report"
+	if symbol != "" {
+		title += " – " + strings.ToUpper(symbol)
+		if interval != "" {
+			title += " " + interval
+		}
+	}
+
+	subtitle := ""
+	if len(series.Times) > 0 {
+		subtitle = fmt.Sprintf("%s → %s",
+			time.UnixMilli(series.Times[0]).UTC().Format("2006-01-02 15:04"),
+			time.UnixMilli(series.Times[len(series.Times)-1]).UTC().Format("2006-01-02 15:04"))
+	}
+
+	page := htmlPage{
+		Title:     title,
+		Subtitle:  subtitle,
+		Generated: time.Now().Format("2006-01-02 15:04:05"),
+		Summary:   buildHTMLSummary(results),
+		Cycles:    buildHTMLCycles(results),
+		DynamicTP: buildHTMLDynamicTP(results),
+		Data:      template.JS(data), // encoding/json escapes <, > and & so this is safe in a script
+	}
+
+	tmpl, err := template.New("report").Parse(htmlReportTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, page); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// buildHTMLSeries converts the equity curve and trades into chart series
+func buildHTMLSeries(results *backtest.BacktestResults) htmlSeries {
+	s := htmlSeries{}
+
+	peak := 0.0
+	for _, p := range results.EquityCurve {
+		s.Times = append(s.Times, p.Timestamp.UnixMilli())
+		s.Price = append(s.Price, p.Price)
+		s.Equity = append(s.Equity, p.Equity)
+		s.Exposure = append(s.Exposure, p.Exposure*100)
+
+		if p.Equity > peak {
+			peak = p.Equity
+		}
+		dd := 0.0
+		if peak > 0 {
+			dd = (peak - p.Equity) / peak * 100
+		}
+		s.Drawdown = append(s.Drawdown, -dd)
+	}
+
+	// Exits are shared by every trade of a cycle, so de-duplicate them
+	seenExit := make(map[string]bool)
+	for _, t := range results.Trades {
+		s.Entries = append(s.Entries, htmlMarker{
+			Time:  t.EntryTime.UnixMilli(),
+			Price: t.EntryPrice,
+			Cycle: t.Cycle,
+			Label: fmt.Sprintf("Entry %.6g @ %.4f", t.Quantity, t.EntryPrice),
+		})
+		if t.TPTarget > 0 {
+			s.TPTimes = append(s.TPTimes, t.EntryTime.UnixMilli())
+			s.TPTarget = append(s.TPTarget, t.TPTarget*100)
+			s.TPVol = append(s.TPVol, t.MarketVolatility*100)
+		}
+	}
+
+	for _, c := range results.Cycles {
+		if len(c.PartialExits) > 0 {
+			for _, pe := range c.PartialExits {
+				s.Exits = append(s.Exits, htmlMarker{
+					Time:  pe.Timestamp.UnixMilli(),
+					Price: pe.Price,
+					Cycle: c.CycleNumber,
+					Label: fmt.Sprintf("TP%d @ %.4f", pe.TPLevel, pe.Price),
+				})
+			}
+		}
+	}
+	if len(s.Exits) == 0 {
+		for _, t := range results.Trades {
+			if t.ExitTime.IsZero() || t.ExitPrice <= 0 {
+				continue
+			}
+			key := fmt.Sprintf("%d/%f", t.ExitTime.UnixMilli(), t.ExitPrice)
+			if seenExit[key] {
+				continue
+			}
+			seenExit[key] = true
+			s.Exits = append(s.Exits, htmlMarker{
+				Time:  t.ExitTime.UnixMilli(),
+				Price: t.ExitPrice,
+				Cycle: t.Cycle,
+				Label: fmt.Sprintf("Exit @ %.4f", t.ExitPrice),
+			})
+		}
+	}
+
+	return s
+}
+
+func buildHTMLSummary(results *backtest.BacktestResults) []htmlMetric {
+	tone := func(good bool) string {
+		if good {
+			return "good"
+		}
+		return "bad"
+	}
+
+	winRate := 0.0
+	if results.TotalTrades > 0 {
+		winRate = float64(results.WinningTrades) / float64(results.TotalTrades) * 100
+	}
+
+	return []htmlMetric{
+		{"Total Return", fmt.Sprintf("%.2f%%", results.TotalReturn*100), tone(results.TotalReturn >= 0)},
+		{"Annualized Return", fmt.Sprintf("%.2f%%", results.AnnualizedReturn*100), tone(results.AnnualizedReturn >= 0)},
+		{"Max Drawdown", fmt.Sprintf("%.2f%%", results.MaxDrawdown*100), tone(results.MaxDrawdown < 0.2)},
+		{"Sharpe", fmt.Sprintf("%.2f", results.SharpeRatio), ""},
+		{"Sortino", fmt.Sprintf("%.2f", results.SortinoRatio), ""},
+		{"Profit Factor", fmt.Sprintf("%.2f", results.ProfitFactor), ""},
+		{"Start Balance", fmt.Sprintf("$%.2f", results.StartBalance), ""},
+		{"End Balance", fmt.Sprintf("$%.2f", results.EndBalance), tone(results.EndBalance >= results.StartBalance)},
+		{"Trades", fmt.Sprintf("%d", results.TotalTrades), ""},
+		{"Win Rate", fmt.Sprintf("%.1f%%", winRate), ""},
+		{"Completed Cycles", fmt.Sprintf("%d / %d", results.CompletedCycles, len(results.Cycles)), ""},
+		{"Max Exposure", fmt.Sprintf("%.1f%%", results.MaxExposure*100), ""},
+	}
+}
+
+func buildHTMLCycles(results *backtest.BacktestResults) []htmlCycle {
+	cycles := make([]htmlCycle, 0, len(results.Cycles))
+	for _, c := range results.Cycles {
+		d := c.EndTime.Sub(c.StartTime)
+		pnl := c.RealizedPnL
+		pnlPct := 0.0
+		if c.TotalGrossCost > 0 {
+			pnlPct = pnl / c.TotalGrossCost * 100
+		}
+		cycles = append(cycles, htmlCycle{
+			Number:    c.CycleNumber,
+			Start:     c.StartTime.Format("2006-01-02 15:04"),
+			End:       c.EndTime.Format("2006-01-02 15:04"),
+			Duration:  d.Round(time.Minute).String(),
+			Hours:     d.Hours(),
+			Entries:   c.Entries,
+			AvgEntry:  c.AvgEntry,
+			Cost:      c.TotalGrossCost,
+			PnL:       pnl,
+			PnLPct:    pnlPct,
+			TPLevels:  c.TPLevelsHit,
+			Completed: c.Completed,
+		})
+	}
+	return cycles
+}
+
+func buildHTMLDynamicTP(results *backtest.BacktestResults) []htmlMetric {
+	m := results.DynamicTPMetrics
+	if m == nil || !m.Enabled {
+		return nil
+	}
+	return []htmlMetric{
+		{"Strategy", m.Strategy, ""},
+		{"Average TP", fmt.Sprintf("%.3f%%", m.AvgTPPercent*100), ""},
+		{"Min TP Used", fmt.Sprintf("%.3f%%", m.MinTPUsed*100), ""},
+		{"Max TP Used", fmt.Sprintf("%.3f%%", m.MaxTPUsed*100), ""},
+		{"Range Utilization", fmt.Sprintf("%.1f%%", m.TPRangeUtilization*100), ""},
+		{"Hit Rate", fmt.Sprintf("%.1f%%", m.DynamicTPHitRate*100), ""},
+		{"Bounds Applied", fmt.Sprintf("%d / %d", m.BoundsHitCount, m.TotalCalculations), ""},
+	}
+}
+
+// Package-level convenience function
+func WriteTradesHTML(results *backtest.BacktestResults, symbol, interval, path string) error {
+	reporter := NewDefaultHTMLReporter()
+	return reporter.WriteTradesHTMLWithContext(results, symbol, interval, path)
+}
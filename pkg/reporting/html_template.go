@@ -0,0 +1,238 @@
+package reporting
+
+// htmlReportTemplate is the self-contained page used by DefaultHTMLReporter.
+// Charts are drawn as inline SVG by the embedded script - no CDN required.
+const htmlReportTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+  :root { --bg:#0f1419; --panel:#182029; --grid:#2a3541; --text:#d9e1e8; --muted:#8a99a8;
+          --good:#26a69a; --bad:#ef5350; --accent:#42a5f5; --warn:#ffb74d; }
+  * { box-sizing: border-box; }
+  body { margin:0; padding:24px; background:var(--bg); color:var(--text);
+         font:14px/1.45 -apple-system, "Segoe UI", Roboto, Helvetica, Arial, sans-serif; }
+  h1 { margin:0 0 4px; font-size:22px; }
+  h2 { margin:0 0 12px; font-size:16px; color:var(--muted); font-weight:600; }
+  .sub { color:var(--muted); margin-bottom:20px; }
+  .panel { background:var(--panel); border-radius:8px; padding:16px; margin-bottom:18px; }
+  .cards { display:grid; grid-template-columns:repeat(auto-fill, minmax(160px, 1fr)); gap:10px; }
+  .card { background:var(--bg); border-radius:6px; padding:10px 12px; }
+  .card .label { color:var(--muted); font-size:12px; }
+  .card .value { font-size:18px; font-weight:600; }
+  .good { color:var(--good); } .bad { color:var(--bad); }
+  .chart { position:relative; width:100%; }
+  .chart svg { display:block; width:100%; }
+  .tooltip { position:absolute; pointer-events:none; background:rgba(15,20,25,.92); border:1px solid var(--grid);
+             border-radius:4px; padding:6px 8px; font-size:12px; white-space:nowrap; display:none; z-index:2; }
+  .legend { color:var(--muted); font-size:12px; margin-top:6px; }
+  .legend span { margin-right:14px; }
+  .swatch { display:inline-block; width:10px; height:10px; border-radius:2px; margin-right:4px; vertical-align:middle; }
+  table { width:100%; border-collapse:collapse; font-size:13px; }
+  th, td { padding:6px 8px; text-align:right; border-bottom:1px solid var(--grid); }
+  th:first-child, td:first-child, th:nth-child(2), td:nth-child(2), th:nth-child(3), td:nth-child(3) { text-align:left; }
+  th { color:var(--muted); cursor:pointer; user-select:none; position:sticky; top:0; background:var(--panel); }
+  th:hover { color:var(--text); }
+  .table-wrap { max-height:520px; overflow:auto; }
+  .empty { color:var(--muted); }
+  footer { color:var(--muted); font-size:12px; text-align:center; margin-top:12px; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<div class="sub">{{.Subtitle}}</div>
+
+<div class="panel">
+  <h2>Summary</h2>
+  <div class="cards">
+  {{range .Summary}}<div class="card"><div class="label">{{.Label}}</div><div class="value {{.Tone}}">{{.Value}}</div></div>
+  {{end}}</div>
+</div>
+
+<div class="panel">
+  <h2>Price with DCA entries and TP exits</h2>
+  <div id="chart-price" class="chart"></div>
+  <div class="legend"><span><i class="swatch" style="background:var(--accent)"></i>Price</span>
+    <span><i class="swatch" style="background:var(--good)"></i>▲ Entry</span>
+    <span><i class="swatch" style="background:var(--bad)"></i>▼ Take profit</span></div>
+</div>
+
+<div class="panel">
+  <h2>Equity</h2>
+  <div id="chart-equity" class="chart"></div>
+</div>
+
+<div class="panel">
+  <h2>Drawdown (%)</h2>
+  <div id="chart-drawdown" class="chart"></div>
+</div>
+
+<div class="panel">
+  <h2>Exposure (% of equity in position)</h2>
+  <div id="chart-exposure" class="chart"></div>
+</div>
+
+<div class="panel">
+  <h2>Cycles</h2>
+  {{if .Cycles}}<div class="table-wrap"><table id="cycles">
+    <thead><tr><th data-type="num">#</th><th>Start</th><th>End</th><th data-type="num">Duration (h)</th>
+      <th data-type="num">Entries</th><th data-type="num">Avg Entry</th><th data-type="num">Capital</th>
+      <th data-type="num">PnL</th><th data-type="num">PnL %</th><th data-type="num">TP Levels</th><th>Status</th></tr></thead>
+    <tbody>
+    {{range .Cycles}}<tr><td>{{.Number}}</td><td>{{.Start}}</td><td>{{.End}}</td>
+      <td data-v="{{.Hours}}" title="{{.Duration}}">{{printf "%.1f" .Hours}}</td><td>{{.Entries}}</td>
+      <td>{{printf "%.4f" .AvgEntry}}</td><td>{{printf "%.2f" .Cost}}</td>
+      <td class="{{if ge .PnL 0.0}}good{{else}}bad{{end}}">{{printf "%.2f" .PnL}}</td>
+      <td class="{{if ge .PnLPct 0.0}}good{{else}}bad{{end}}">{{printf "%.2f" .PnLPct}}</td>
+      <td>{{.TPLevels}}</td><td>{{if .Completed}}✅ Closed{{else}}⏳ Open{{end}}</td></tr>
+    {{end}}</tbody>
+  </table></div>{{else}}<div class="empty">No cycles recorded (take profit disabled).</div>{{end}}
+</div>
+
+<div class="panel">
+  <h2>Dynamic take profit</h2>
+  {{if .DynamicTP}}<div class="cards">
+  {{range .DynamicTP}}<div class="card"><div class="label">{{.Label}}</div><div class="value">{{.Value}}</div></div>
+  {{end}}</div>
+  <div id="chart-tp" class="chart" style="margin-top:14px"></div>
+  <div class="legend"><span><i class="swatch" style="background:var(--warn)"></i>TP target %</span>
+    <span><i class="swatch" style="background:var(--accent)"></i>Volatility %</span></div>
+  {{else}}<div class="empty">Dynamic TP was not enabled for this backtest.</div>{{end}}
+</div>
+
+<footer>Generated {{.Generated}}</footer>
+
+<script>
+(function () {
+  "use strict";
+  var D = {{.Data}};
+  var css = getComputedStyle(document.documentElement);
+  function color(name) { return css.getPropertyValue(name).trim(); }
+
+  function fmtTime(t) { return new Date(t).toISOString().slice(0, 16).replace("T", " "); }
+  function fmtNum(v, digits) {
+    if (v === null || v === undefined || isNaN(v)) { return "–"; }
+    var a = Math.abs(v);
+    if (digits === undefined) { digits = a >= 1000 ? 0 : a >= 10 ? 2 : 4; }
+    return v.toLocaleString(undefined, { minimumFractionDigits: digits, maximumFractionDigits: digits });
+  }
+  function lowerBound(xs, t) {
+    var lo = 0, hi = xs.length - 1;
+    while (lo < hi) { var mid = (lo + hi) >> 1; if (xs[mid] < t) { lo = mid + 1; } else { hi = mid; } }
+    if (lo > 0 && Math.abs(xs[lo - 1] - t) < Math.abs(xs[lo] - t)) { lo--; }
+    return lo;
+  }
+
+  // lineChart renders series [{y, color, label, fill, digits}] over x (ms) with optional markers
+  function lineChart(id, x, series, opts) {
+    var el = document.getElementById(id);
+    if (!el || !x || x.length === 0) { if (el) { el.innerHTML = '<div class="empty">No data</div>'; } return; }
+    opts = opts || {};
+    var W = el.clientWidth || 900, H = opts.height || 260, m = { l: 70, r: 16, t: 10, b: 26 };
+    var xmin = x[0], xmax = x[x.length - 1];
+    var ymin = Infinity, ymax = -Infinity;
+    series.forEach(function (s) { s.y.forEach(function (v) { if (v < ymin) { ymin = v; } if (v > ymax) { ymax = v; } }); });
+    (opts.markers || []).forEach(function (g) { g.points.forEach(function (p) { if (p.p < ymin) { ymin = p.p; } if (p.p > ymax) { ymax = p.p; } }); });
+    if (opts.zero) { ymin = Math.min(ymin, 0); ymax = Math.max(ymax, 0); }
+    if (ymin === ymax) { ymin -= 1; ymax += 1; }
+    var pad = (ymax - ymin) * 0.05; ymin -= opts.zero && ymin === 0 ? 0 : pad; ymax += opts.zero && ymax === 0 ? 0 : pad;
+
+    function sx(t) { return m.l + (t - xmin) / ((xmax - xmin) || 1) * (W - m.l - m.r); }
+    function sy(v) { return m.t + (1 - (v - ymin) / (ymax - ymin)) * (H - m.t - m.b); }
+
+    var svg = ['<svg viewBox="0 0 ' + W + ' ' + H + '" height="' + H + '">'];
+    for (var i = 0; i <= 4; i++) {
+      var v = ymin + (ymax - ymin) * i / 4, y = sy(v);
+      svg.push('<line x1="' + m.l + '" x2="' + (W - m.r) + '" y1="' + y + '" y2="' + y + '" stroke="' + color("--grid") + '"/>');
+      svg.push('<text x="' + (m.l - 6) + '" y="' + (y + 4) + '" fill="' + color("--muted") + '" font-size="11" text-anchor="end">' + fmtNum(v) + '</text>');
+    }
+    for (var j = 0; j <= 5; j++) {
+      var t = xmin + (xmax - xmin) * j / 5, xx = sx(t);
+      svg.push('<text x="' + xx + '" y="' + (H - 8) + '" fill="' + color("--muted") + '" font-size="11" text-anchor="middle">' + fmtTime(t).slice(0, 10) + '</text>');
+    }
+    series.forEach(function (s) {
+      var d = "";
+      for (var k = 0; k < s.y.length; k++) { d += (k ? "L" : "M") + sx(x[k]).toFixed(1) + " " + sy(s.y[k]).toFixed(1); }
+      if (s.fill) {
+        var base = sy(opts.zero ? 0 : ymin);
+        svg.push('<path d="' + d + 'L' + sx(x[s.y.length - 1]).toFixed(1) + ' ' + base + 'L' + sx(x[0]).toFixed(1) + ' ' + base + 'Z" fill="' + s.color + '" opacity="0.25"/>');
+      }
+      svg.push('<path d="' + d + '" fill="none" stroke="' + s.color + '" stroke-width="1.4"/>');
+    });
+    (opts.markers || []).forEach(function (g) {
+      g.points.forEach(function (p) {
+        var px = sx(p.t), py = sy(p.p), r = 4;
+        var pts = g.up ? [px, py - r, px - r, py + r, px + r, py + r] : [px, py + r, px - r, py - r, px + r, py - r];
+        svg.push('<polygon points="' + pts.join(",") + '" fill="' + g.color + '"><title>' + fmtTime(p.t) + " · " + p.l + (p.c ? " · cycle " + p.c : "") + '</title></polygon>');
+      });
+    });
+    svg.push('<line class="cursor" y1="' + m.t + '" y2="' + (H - m.b) + '" stroke="' + color("--muted") + '" stroke-dasharray="3,3" visibility="hidden"/>');
+    svg.push("</svg>");
+    el.innerHTML = svg.join("") + '<div class="tooltip"></div>';
+
+    var cursor = el.querySelector(".cursor"), tip = el.querySelector(".tooltip"), svgEl = el.querySelector("svg");
+    svgEl.addEventListener("mousemove", function (ev) {
+      var rect = svgEl.getBoundingClientRect();
+      var px = (ev.clientX - rect.left) * W / rect.width;
+      var t = xmin + (px - m.l) / (W - m.l - m.r) * (xmax - xmin);
+      var idx = lowerBound(x, t), cx = sx(x[idx]);
+      cursor.setAttribute("x1", cx); cursor.setAttribute("x2", cx); cursor.setAttribute("visibility", "visible");
+      var html = "<b>" + fmtTime(x[idx]) + "</b>";
+      series.forEach(function (s) { html += "<br>" + s.label + ": " + fmtNum(s.y[idx], s.digits) + (s.suffix || ""); });
+      tip.innerHTML = html; tip.style.display = "block";
+      var left = cx * rect.width / W + 12;
+      if (left + tip.offsetWidth > rect.width) { left -= tip.offsetWidth + 24; }
+      tip.style.left = left + "px"; tip.style.top = "8px";
+    });
+    svgEl.addEventListener("mouseleave", function () { cursor.setAttribute("visibility", "hidden"); tip.style.display = "none"; });
+  }
+
+  function drawAll() {
+    lineChart("chart-price", D.times, [{ y: D.price, color: color("--accent"), label: "Price" }], {
+      height: 340,
+      markers: [
+        { points: D.entries || [], color: color("--good"), up: true },
+        { points: D.exits || [], color: color("--bad"), up: false }
+      ]
+    });
+    lineChart("chart-equity", D.times, [{ y: D.equity, color: color("--good"), label: "Equity", digits: 2, fill: true }]);
+    lineChart("chart-drawdown", D.times, [{ y: D.drawdown, color: color("--bad"), label: "Drawdown", digits: 2, suffix: "%", fill: true }], { zero: true, height: 200 });
+    lineChart("chart-exposure", D.times, [{ y: D.exposure, color: color("--warn"), label: "Exposure", digits: 1, suffix: "%", fill: true }], { zero: true, height: 200 });
+    if (D.tp_times && D.tp_times.length) {
+      lineChart("chart-tp", D.tp_times, [
+        { y: D.tp_target, color: color("--warn"), label: "TP target", digits: 3, suffix: "%" },
+        { y: D.tp_volatility, color: color("--accent"), label: "Volatility", digits: 3, suffix: "%" }
+      ], { zero: true, height: 220 });
+    }
+  }
+
+  // Click-to-sort for the cycle table
+  var table = document.getElementById("cycles");
+  if (table) {
+    table.querySelectorAll("th").forEach(function (th, col) {
+      var asc = true;
+      th.addEventListener("click", function () {
+        var numeric = th.getAttribute("data-type") === "num";
+        var body = table.tBodies[0], rows = Array.prototype.slice.call(body.rows);
+        rows.sort(function (a, b) {
+          var ca = a.cells[col], cb = b.cells[col];
+          var va = ca.getAttribute("data-v") || ca.textContent, vb = cb.getAttribute("data-v") || cb.textContent;
+          var r = numeric ? parseFloat(va) - parseFloat(vb) : va.localeCompare(vb);
+          return asc ? r : -r;
+        });
+        asc = !asc;
+        rows.forEach(function (r) { body.appendChild(r); });
+      });
+    });
+  }
+
+  drawAll();
+  var resizeTimer;
+  window.addEventListener("resize", function () { clearTimeout(resizeTimer); resizeTimer = setTimeout(drawAll, 150); });
+})();
+</script>
+</body>
+</html>
+`
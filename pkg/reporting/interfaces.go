@@ -19,6 +19,7 @@ type ConsoleReporter interface {
 type FileReporter interface {
 	WriteTradesCSV(results *backtest.BacktestResults, path string) error
 	WriteTradesXLSX(results *backtest.BacktestResults, path string) error
+	WriteTradesHTML(results *backtest.BacktestResults, path string) error
 	WriteBestConfigJSON(config interface{}, path string) error
 }
 
@@ -73,5 +74,6 @@ type ReportingConfig struct {
 	OutputDirectory  string
 	ExcelEnabled     bool
 	CSVEnabled       bool
+	HTMLEnabled      bool
 	JSONEnabled      bool
 }
@@ -0,0 +1,144 @@
+package reporting
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/montecarlo"
+)
+
+// PrintMonteCarloSummary prints percentile tables for each Monte Carlo experiment
+func PrintMonteCarloSummary(results *montecarlo.Results) {
+	if results == nil {
+		return
+	}
+
+	fmt.Println("\n" + strings.Repeat("=", 50))
+	fmt.Println("🎲 MONTE CARLO ROBUSTNESS")
+	fmt.Println(strings.Repeat("=", 50))
+	fmt.Printf("Runs:          %d per experiment\n", results.Runs)
+	fmt.Printf("Seed:          %d\n", results.Seed)
+	fmt.Printf("Block size:    %d candles\n", results.BlockSize)
+	fmt.Printf("Max latency:   %v\n", results.MaxLatency)
+	fmt.Printf("Fee jitter:    ±%.0f%%\n", results.FeeJitter*100)
+	fmt.Printf("Baseline:      return %.2f%%, max DD %.2f%%, max capital $%.2f, longest cycle %s\n",
+		results.Baseline.FinalReturn*100, results.Baseline.MaxDrawdown*100,
+		results.Baseline.MaxCapitalDeployed, formatCycleHours(results.Baseline.LongestOpenCycle.Hours()))
+
+	for _, exp := range results.Experiments {
+		fmt.Printf("\n%s (%d runs)\n", monteCarloExperimentTitle(exp.Name), exp.Runs)
+		fmt.Printf("  %-20s %10s %10s %10s %10s %10s\n", "Metric", "P5", "P25", "P50", "P75", "P95")
+		printDistributionRow("Final Return %", exp.FinalReturn, 100, "%10.2f")
+		printDistributionRow("Max Drawdown %", exp.MaxDrawdown, 100, "%10.2f")
+		printDistributionRow("Max Capital $", exp.MaxCapitalDeployed, 1, "%10.2f")
+		printDistributionRow("Longest Cycle (h)", exp.LongestOpenCycleHours, 1, "%10.1f")
+		fmt.Printf("  P(loss): %.1f%% | P(drawdown ≥ %.0f%%): %.1f%%\n",
+			exp.ProbabilityOfLoss*100, results.RuinThreshold*100, exp.ProbabilityOfRuin*100)
+	}
+}
+
+// WriteMonteCarloJSON writes the Monte Carlo settings and distributions as JSON
+func WriteMonteCarloJSON(results *montecarlo.Results, path string) error {
+	if results == nil {
+		return fmt.Errorf("no Monte Carlo results to write")
+	}
+	if dir := filepath.Dir(path); dir != "." && dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+
+	payload := struct {
+		*montecarlo.Results
+		MaxLatencyMs          int64   `json:"max_latency_ms"`
+		ElapsedMs             int64   `json:"elapsed_ms"`
+		BaselineLongestCycleH float64 `json:"baseline_longest_open_cycle_hours"`
+	}{
+		Results:               results,
+		MaxLatencyMs:          results.MaxLatency.Milliseconds(),
+		ElapsedMs:             results.Elapsed.Milliseconds(),
+		BaselineLongestCycleH: results.Baseline.LongestOpenCycle.Hours(),
+	}
+
+	data, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// WriteMonteCarloRunsCSV writes every simulated run, one row per run and experiment
+func WriteMonteCarloRunsCSV(results *montecarlo.Results, path string) error {
+	if results == nil {
+		return fmt.Errorf("no Monte Carlo results to write")
+	}
+	if dir := filepath.Dir(path); dir != "." && dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	defer w.Flush()
+
+	header := []string{"Experiment", "Run", "Final_Return_%", "Max_Drawdown_%", "Max_Capital_Deployed", "Longest_Open_Cycle_Hours", "Commission"}
+	if err := w.Write(header); err != nil {
+		return err
+	}
+
+	for _, exp := range results.Experiments {
+		for i, o := range exp.Outcomes {
+			row := []string{
+				exp.Name,
+				strconv.Itoa(i + 1),
+				strconv.FormatFloat(o.FinalReturn*100, 'f', 4, 64),
+				strconv.FormatFloat(o.MaxDrawdown*100, 'f', 4, 64),
+				strconv.FormatFloat(o.MaxCapitalDeployed, 'f', 2, 64),
+				strconv.FormatFloat(o.LongestOpenCycle.Hours(), 'f', 2, 64),
+				strconv.FormatFloat(o.Commission, 'f', 6, 64),
+			}
+			if err := w.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func printDistributionRow(label string, d montecarlo.Distribution, scale float64, format string) {
+	fmt.Printf("  %-20s", label)
+	for _, v := range []float64{d.P5, d.P25, d.P50, d.P75, d.P95} {
+		fmt.Printf(" "+format, v*scale)
+	}
+	fmt.Println()
+}
+
+func monteCarloExperimentTitle(name string) string {
+	switch name {
+	case montecarlo.ExperimentCycleResample:
+		return "🔁 Cycle resampling (completed cycles, realized equity)"
+	case montecarlo.ExperimentPricePaths:
+		return "📈 Block-bootstrapped price paths"
+	case montecarlo.ExperimentExecution:
+		return "⏱️  Execution noise (latency + fees)"
+	default:
+		return name
+	}
+}
+
+func formatCycleHours(hours float64) string {
+	return time.Duration(hours * float64(time.Hour)).Round(time.Minute).String()
+}
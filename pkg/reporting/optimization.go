@@ -0,0 +1,138 @@
+package reporting
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/optimization"
+)
+
+// PrintSearchSummary prints the optimizer run header and the top evaluated configs
+func PrintSearchSummary(result *optimization.SearchResult, top int) {
+	if result == nil {
+		return
+	}
+
+	fmt.Println("\n" + strings.Repeat("=", 50))
+	fmt.Println("🧬 OPTIMIZATION SEARCH")
+	fmt.Println(strings.Repeat("=", 50))
+	fmt.Printf("Optimizer:     %s\n", strings.ToUpper(string(result.Method)))
+	fmt.Printf("Seed:          %d (pass -seed %d to reproduce)\n", result.Seed, result.Seed)
+	fmt.Printf("Backtests:     %d of %.0f grid points\n", len(result.Evaluations), result.SpaceSize)
+	fmt.Printf("Elapsed:       %v\n", result.Elapsed.Round(time.Millisecond))
+	fmt.Printf("Stop reason:   %s\n", result.StopReason)
+
+	rows := result.TopEvaluations(top)
+	if len(rows) == 0 {
+		return
+	}
+
+	fmt.Printf("\nTop %d configurations:\n", len(rows))
+	fmt.Printf("%-6s %9s %9s %8s %7s %7s  %s\n", "#", "Return", "MaxDD", "Sharpe", "Trades", "Cycles", "Parameters")
+	for _, rec := range rows {
+		fmt.Printf("%-6d %8.2f%% %8.2f%% %8.2f %7d %7d  %s\n",
+			rec.Index, rec.TotalReturn*100, rec.MaxDrawdown*100, rec.SharpeRatio,
+			rec.TotalTrades, rec.CompletedCycles, formatSearchParams(result.ParamNames, rec.Params))
+	}
+}
+
+// WriteSearchEvaluationsCSV writes every evaluated config with its metrics,
+// one row per backtest in evaluation order
+func WriteSearchEvaluationsCSV(result *optimization.SearchResult, path string) error {
+	if result == nil {
+		return fmt.Errorf("no search result to write")
+	}
+	if dir := filepath.Dir(path); dir != "." && dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	defer w.Flush()
+
+	header := []string{"Index", "Best", "Fitness", "Total_Return_%", "Max_Drawdown_%", "Sharpe", "Profit_Factor", "Trades", "Completed_Cycles", "Duration_ms"}
+	header = append(header, result.ParamNames...)
+	if err := w.Write(header); err != nil {
+		return err
+	}
+
+	for _, rec := range result.Evaluations {
+		row := []string{
+			strconv.Itoa(rec.Index),
+			strconv.FormatBool(rec.IsBest),
+			strconv.FormatFloat(rec.Fitness, 'f', 6, 64),
+			strconv.FormatFloat(rec.TotalReturn*100, 'f', 4, 64),
+			strconv.FormatFloat(rec.MaxDrawdown*100, 'f', 4, 64),
+			strconv.FormatFloat(rec.SharpeRatio, 'f', 4, 64),
+			strconv.FormatFloat(rec.ProfitFactor, 'f', 4, 64),
+			strconv.Itoa(rec.TotalTrades),
+			strconv.Itoa(rec.CompletedCycles),
+			strconv.FormatInt(rec.Duration.Milliseconds(), 10),
+		}
+		for _, name := range result.ParamNames {
+			row = append(row, rec.Params[name])
+		}
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteSearchResultJSON writes the search metadata and all evaluations as JSON
+func WriteSearchResultJSON(result *optimization.SearchResult, path string) error {
+	if result == nil {
+		return fmt.Errorf("no search result to write")
+	}
+	if dir := filepath.Dir(path); dir != "." && dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+
+	payload := struct {
+		Method      string                          `json:"method"`
+		Seed        int64                           `json:"seed"`
+		StopReason  string                          `json:"stop_reason"`
+		ElapsedMs   int64                           `json:"elapsed_ms"`
+		SpaceSize   float64                         `json:"space_size"`
+		Parameters  []string                        `json:"parameters"`
+		Evaluations []optimization.EvaluationRecord `json:"evaluations"`
+	}{
+		Method:      string(result.Method),
+		Seed:        result.Seed,
+		StopReason:  result.StopReason,
+		ElapsedMs:   result.Elapsed.Milliseconds(),
+		SpaceSize:   result.SpaceSize,
+		Parameters:  result.ParamNames,
+		Evaluations: result.Evaluations,
+	}
+
+	data, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func formatSearchParams(names []string, params map[string]string) string {
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, name+"="+params[name])
+	}
+	return strings.Join(parts, " ")
+}
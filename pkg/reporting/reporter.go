@@ -9,6 +9,7 @@ type DefaultReporter struct {
 	console  *DefaultConsoleReporter
 	csv      *DefaultCSVReporter
 	excel    *DefaultExcelReporter
+	html     *DefaultHTMLReporter
 	json     *DefaultJSONFormatter
 	paths    *DefaultPathManager
 }
@@ -19,6 +20,7 @@ func NewDefaultReporter() *DefaultReporter {
 		console: NewDefaultConsoleReporter(),
 		csv:     NewDefaultCSVReporter(),
 		excel:   NewDefaultExcelReporter(),
+		html:    NewDefaultHTMLReporter(),
 		json:    NewDefaultJSONFormatter(),
 		paths:   NewDefaultPathManager(),
 	}
@@ -50,6 +52,10 @@ func (r *DefaultReporter) WriteTradesXLSX(results *backtest.BacktestResults, pat
 	return r.excel.WriteTradesXLSX(results, path)
 }
 
+func (r *DefaultReporter) WriteTradesHTML(results *backtest.BacktestResults, path string) error {
+	return r.html.WriteTradesHTML(results, path)
+}
+
 func (r *DefaultReporter) WriteBestConfigJSON(config interface{}, path string) error {
 	return WriteBestConfigJSON(config, path)
 }
@@ -114,6 +120,13 @@ func (m *ReportingManager) ReportResults(results *backtest.BacktestResults, symb
 				return err
 			}
 		}
+		
+		if m.config.HTMLEnabled {
+			htmlPath := outputDir + "/report.html"
+			if err := m.reporter.html.WriteTradesHTMLWithContext(results, symbol, interval, htmlPath); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
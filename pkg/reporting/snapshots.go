@@ -0,0 +1,166 @@
+package reporting
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/ducminhle1904/crypto-dca-bot/internal/backtest"
+	"github.com/xuri/excelize/v2"
+)
+
+// snapshotColumns are the fixed columns of the snapshots sheet; three columns
+// per indicator (value, vote, strength) follow them
+var snapshotColumns = []string{
+	"Time", "Action", "Source", "Filled", "Cycle", "Price", "Fill Price", "Amount ($)",
+	"Confidence", "Strength", "DCA Level", "Last Entry", "Price Drop", "Spacing Threshold",
+	"Spacing Strategy", "Reason",
+}
+
+// writeSnapshotsSheet writes one row per decision snapshot with every
+// indicator's value, vote and strength side by side. The sheet is streamed,
+// as a run recording every decision can hold a row per candle.
+func (r *DefaultExcelReporter) writeSnapshotsSheet(fx *excelize.File, sheet string, results *backtest.BacktestResults, styles ExcelStyles) error {
+	snapshots := results.DecisionSnapshots
+	title := fmt.Sprintf("📸 DECISION SNAPSHOTS (%d)", len(snapshots))
+
+	// Rows 1-3 hold the title and headers
+	if maxRows := excelize.TotalRows - 3; len(snapshots) > maxRows {
+		snapshots = snapshots[:maxRows]
+		title += fmt.Sprintf(" - first %d shown, see signal_snapshots.json for all", maxRows)
+	}
+
+	names := snapshotIndicatorNames(snapshots)
+	headers := append([]string{}, snapshotColumns...)
+	for _, name := range names {
+		headers = append(headers, name, name+" Vote", name+" Strength")
+	}
+
+	preciseStyle, err := fx.NewStyle(&excelize.Style{NumFmt: 10}) // 0.00%
+	if err != nil {
+		return err
+	}
+
+	sw, err := fx.NewStreamWriter(sheet)
+	if err != nil {
+		return fmt.Errorf("failed to open snapshot sheet: %w", err)
+	}
+	sw.SetColWidth(1, 1, 18)   // Time
+	sw.SetColWidth(2, 15, 12)  // Action .. Spacing Strategy
+	sw.SetColWidth(16, 16, 60) // Reason
+	if len(names) > 0 {
+		sw.SetColWidth(17, len(headers), 14) // Indicators
+	}
+
+	if err := sw.SetRow("A1", []interface{}{excelize.Cell{StyleID: styles.HeaderStyle, Value: title}}); err != nil {
+		return err
+	}
+	headerRow := make([]interface{}, len(headers))
+	for i, h := range headers {
+		headerRow[i] = excelize.Cell{StyleID: styles.HeaderStyle, Value: h}
+	}
+	if err := sw.SetRow("A3", headerRow); err != nil {
+		return err
+	}
+
+	column := make(map[string]int, len(names)) // Indicator name -> first column index in row
+	for i, name := range names {
+		column[name] = len(snapshotColumns) + 3*i
+	}
+	currency := func(v float64) excelize.Cell { return excelize.Cell{StyleID: styles.CurrencyStyle, Value: v} }
+	percent := func(v float64) excelize.Cell { return excelize.Cell{StyleID: preciseStyle, Value: v} }
+
+	for i, s := range snapshots {
+		row := make([]interface{}, len(headers))
+		row[0] = s.Time.Format("2006-01-02 15:04:05")
+		row[1] = s.Action
+		row[2] = s.Source
+		row[3] = s.Filled
+		if s.Cycle > 0 {
+			row[4] = s.Cycle
+		}
+		row[5] = currency(s.Price)
+		if s.Filled {
+			row[6] = currency(s.FillPrice)
+		}
+		row[7] = currency(s.Amount)
+		row[8] = s.Confidence
+		row[9] = s.Strength
+		row[10] = s.DCALevel
+		if s.LastEntryPrice > 0 {
+			row[11] = currency(s.LastEntryPrice)
+			row[12] = percent(s.PriceDrop)
+		}
+		if s.SpacingThreshold > 0 {
+			row[13] = percent(s.SpacingThreshold)
+		}
+		row[14] = s.SpacingStrategy
+		row[15] = s.Reason
+
+		for _, indicator := range s.Indicators {
+			col := column[indicator.Name]
+			if indicator.Error != "" {
+				row[col+1] = "ERROR: " + indicator.Error
+				continue
+			}
+			row[col] = indicator.Value
+			row[col+1] = snapshotVote(indicator)
+			row[col+2] = indicator.Strength
+		}
+
+		cell, _ := excelize.CoordinatesToCellName(1, i+4)
+		if err := sw.SetRow(cell, row); err != nil {
+			return fmt.Errorf("failed to write snapshot row %d: %w", i+1, err)
+		}
+	}
+
+	return sw.Flush()
+}
+
+// snapshotIndicatorNames returns the indicator names found in snapshots, sorted
+func snapshotIndicatorNames(snapshots []backtest.DecisionSnapshot) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, s := range snapshots {
+		for _, indicator := range s.Indicators {
+			if !seen[indicator.Name] {
+				seen[indicator.Name] = true
+				names = append(names, indicator.Name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// snapshotVote renders an indicator's vote
+func snapshotVote(indicator backtest.IndicatorSnapshot) string {
+	switch {
+	case indicator.Buy:
+		return "BUY"
+	case indicator.Sell:
+		return "SELL"
+	default:
+		return "-"
+	}
+}
+
+// WriteDecisionSnapshotsJSON writes the decision snapshots of a backtest as JSON
+func WriteDecisionSnapshotsJSON(results *backtest.BacktestResults, path string) error {
+	if results == nil || len(results.DecisionSnapshots) == 0 {
+		return fmt.Errorf("no decision snapshots to write")
+	}
+	if dir := filepath.Dir(path); dir != "." && dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+
+	data, err := json.MarshalIndent(results.DecisionSnapshots, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,282 @@
+package reporting
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/ducminhle1904/crypto-dca-bot/pkg/validation"
+	"github.com/xuri/excelize/v2"
+)
+
+// WalkForwardFoldExport is the per-fold row of the walk-forward export
+type WalkForwardFoldExport struct {
+	Fold          int                `json:"fold"`
+	TrainStart    time.Time          `json:"train_start"`
+	TrainEnd      time.Time          `json:"train_end"`
+	TestStart     time.Time          `json:"test_start"`
+	TestEnd       time.Time          `json:"test_end"`
+	TrainReturn   float64            `json:"train_return_pct"`
+	TestReturn    float64            `json:"test_return_pct"`
+	TrainDrawdown float64            `json:"train_drawdown_pct"`
+	TestDrawdown  float64            `json:"test_drawdown_pct"`
+	TrainTrades   int                `json:"train_trades"`
+	TestTrades    int                `json:"test_trades"`
+	Parameters    map[string]float64 `json:"parameters,omitempty"`
+}
+
+// ParameterStabilityExport is the per-parameter row of the walk-forward export
+type ParameterStabilityExport struct {
+	Name           string    `json:"name"`
+	Values         []float64 `json:"values"`
+	Mean           float64   `json:"mean"`
+	StdDev         float64   `json:"std_dev"`
+	Min            float64   `json:"min"`
+	Max            float64   `json:"max"`
+	CoeffVariation *float64  `json:"coeff_variation"` // nil when undefined (zero mean)
+	Mode           float64   `json:"mode"`
+	ModeShare      float64   `json:"mode_share"`
+	Stable         bool      `json:"stable"`
+	ConsensusValue float64   `json:"consensus_value"`
+}
+
+// WalkForwardExport is the serializable form of a WalkForwardSummary
+type WalkForwardExport struct {
+	Mode                 string                     `json:"mode"`
+	EmbargoDays          int                        `json:"embargo_days"`
+	FoldCount            int                        `json:"fold_count"`
+	AverageTrainReturn   float64                    `json:"average_train_return_pct"`
+	AverageTestReturn    float64                    `json:"average_test_return_pct"`
+	AverageTrainDrawdown float64                    `json:"average_train_drawdown_pct"`
+	AverageTestDrawdown  float64                    `json:"average_test_drawdown_pct"`
+	ReturnDegradation    float64                    `json:"return_degradation_pct"`
+	IsRobust             bool                       `json:"is_robust"`
+	OverfittingRisk      string                     `json:"overfitting_risk"`
+	StabilityScore       float64                    `json:"stability_score"`
+	ConsensusTestReturn  *float64                   `json:"consensus_test_return_pct,omitempty"` // On the data after the last train window
+	ConsensusTestStart   *time.Time                 `json:"consensus_test_start,omitempty"`
+	ConsensusTestEnd     *time.Time                 `json:"consensus_test_end,omitempty"`
+	ConsensusParameters  map[string]float64         `json:"consensus_parameters,omitempty"`
+	Folds                []WalkForwardFoldExport    `json:"folds"`
+	ParameterStability   []ParameterStabilityExport `json:"parameter_stability,omitempty"`
+}
+
+// NewWalkForwardExport converts a summary into its export form
+func NewWalkForwardExport(summary *validation.WalkForwardSummary) WalkForwardExport {
+	export := WalkForwardExport{
+		Mode:                 summary.Mode,
+		EmbargoDays:          summary.EmbargoDays,
+		FoldCount:            len(summary.Results),
+		AverageTrainReturn:   summary.AverageTrainReturn,
+		AverageTestReturn:    summary.AverageTestReturn,
+		AverageTrainDrawdown: summary.AverageTrainDrawdown,
+		AverageTestDrawdown:  summary.AverageTestDrawdown,
+		ReturnDegradation:    summary.ReturnDegradation,
+		IsRobust:             summary.IsRobust,
+		OverfittingRisk:      summary.OverfittingRisk,
+		StabilityScore:       summary.StabilityScore,
+		ConsensusParameters:  summary.ConsensusParameters,
+	}
+	if summary.ConsensusTestResults != nil {
+		consensus := summary.ConsensusTestResults.TotalReturn * 100
+		start, end := summary.ConsensusTestStart, summary.ConsensusTestEnd
+		export.ConsensusTestReturn = &consensus
+		export.ConsensusTestStart = &start
+		export.ConsensusTestEnd = &end
+	}
+
+	for _, r := range summary.Results {
+		fold := WalkForwardFoldExport{
+			Fold:       r.Fold,
+			TrainStart: r.TrainStart,
+			TrainEnd:   r.TrainEnd,
+			TestStart:  r.TestStart,
+			TestEnd:    r.TestEnd,
+			Parameters: r.Parameters,
+		}
+		if r.TrainResults != nil {
+			fold.TrainReturn = r.TrainResults.TotalReturn * 100
+			fold.TrainDrawdown = r.TrainResults.MaxDrawdown * 100
+			fold.TrainTrades = r.TrainResults.TotalTrades
+		}
+		if r.TestResults != nil {
+			fold.TestReturn = r.TestResults.TotalReturn * 100
+			fold.TestDrawdown = r.TestResults.MaxDrawdown * 100
+			fold.TestTrades = r.TestResults.TotalTrades
+		}
+		export.Folds = append(export.Folds, fold)
+	}
+
+	for _, ps := range summary.ParameterStability {
+		row := ParameterStabilityExport{
+			Name:           ps.Name,
+			Values:         ps.Values,
+			Mean:           ps.Mean,
+			StdDev:         ps.StdDev,
+			Min:            ps.Min,
+			Max:            ps.Max,
+			Mode:           ps.Mode,
+			ModeShare:      ps.ModeShare,
+			Stable:         ps.Stable,
+			ConsensusValue: ps.ConsensusValue,
+		}
+		if !math.IsInf(ps.CoeffVariation, 0) {
+			cv := ps.CoeffVariation
+			row.CoeffVariation = &cv
+		}
+		export.ParameterStability = append(export.ParameterStability, row)
+	}
+
+	return export
+}
+
+// WriteWalkForwardJSON writes the walk-forward summary, fold comparison and
+// parameter stability to a JSON file
+func WriteWalkForwardJSON(summary *validation.WalkForwardSummary, path string) error {
+	if summary == nil {
+		return fmt.Errorf("no walk-forward summary to write")
+	}
+	if dir := filepath.Dir(path); dir != "." && dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+
+	data, err := json.MarshalIndent(NewWalkForwardExport(summary), "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// WriteWalkForwardXLSX writes the walk-forward report as an Excel workbook with
+// Summary, Folds and Parameter Stability sheets
+func WriteWalkForwardXLSX(summary *validation.WalkForwardSummary, path string) error {
+	if summary == nil {
+		return fmt.Errorf("no walk-forward summary to write")
+	}
+	if dir := filepath.Dir(path); dir != "." && dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", dir, err)
+		}
+	}
+
+	fx := excelize.NewFile()
+	defer fx.Close()
+
+	const summarySheet = "Summary"
+	const foldsSheet = "Folds"
+	const stabilitySheet = "Parameter Stability"
+
+	fx.SetSheetName(fx.GetSheetName(0), summarySheet)
+	fx.NewSheet(foldsSheet)
+	fx.NewSheet(stabilitySheet)
+
+	styles, err := NewDefaultExcelReporter().createExcelStyles(fx)
+	if err != nil {
+		return err
+	}
+
+	export := NewWalkForwardExport(summary)
+
+	// Summary sheet
+	summaryRows := [][]interface{}{
+		{"Metric", "Value"},
+		{"Mode", export.Mode},
+		{"Embargo (days)", export.EmbargoDays},
+		{"Folds", export.FoldCount},
+		{"Avg Train Return %", export.AverageTrainReturn},
+		{"Avg Test Return %", export.AverageTestReturn},
+		{"Avg Train Drawdown %", export.AverageTrainDrawdown},
+		{"Avg Test Drawdown %", export.AverageTestDrawdown},
+		{"Return Degradation %", export.ReturnDegradation},
+		{"Robust", export.IsRobust},
+		{"Overfitting Risk", export.OverfittingRisk},
+		{"Stability Score", export.StabilityScore},
+	}
+	if export.ConsensusTestReturn != nil {
+		summaryRows = append(summaryRows,
+			[]interface{}{"Consensus Test Return %", *export.ConsensusTestReturn},
+			[]interface{}{"Consensus Test Window", export.ConsensusTestStart.Format("2006-01-02") + " - " + export.ConsensusTestEnd.Format("2006-01-02")})
+	}
+	writeSheetRows(fx, summarySheet, summaryRows, styles)
+	fx.SetColWidth(summarySheet, "A", "A", 30)
+	fx.SetColWidth(summarySheet, "B", "B", 18)
+
+	// Folds sheet: one row per fold with its best parameters
+	paramNames := make([]string, 0, len(export.ConsensusParameters))
+	seen := make(map[string]bool)
+	for _, f := range export.Folds {
+		for name := range f.Parameters {
+			if !seen[name] {
+				seen[name] = true
+				paramNames = append(paramNames, name)
+			}
+		}
+	}
+	sort.Strings(paramNames)
+
+	foldHeader := []interface{}{"Fold", "Train Start", "Train End", "Test Start", "Test End",
+		"Train Return %", "Test Return %", "Train DD %", "Test DD %", "Train Trades", "Test Trades"}
+	for _, name := range paramNames {
+		foldHeader = append(foldHeader, name)
+	}
+	foldRows := [][]interface{}{foldHeader}
+	for _, f := range export.Folds {
+		row := []interface{}{f.Fold,
+			f.TrainStart.Format("2006-01-02"), f.TrainEnd.Format("2006-01-02"),
+			f.TestStart.Format("2006-01-02"), f.TestEnd.Format("2006-01-02"),
+			f.TrainReturn, f.TestReturn, f.TrainDrawdown, f.TestDrawdown, f.TrainTrades, f.TestTrades}
+		for _, name := range paramNames {
+			if v, ok := f.Parameters[name]; ok {
+				row = append(row, v)
+			} else {
+				row = append(row, "")
+			}
+		}
+		foldRows = append(foldRows, row)
+	}
+	if len(paramNames) > 0 && len(export.ConsensusParameters) > 0 {
+		row := []interface{}{"Consensus", "", "", "", "", "", "", "", "", "", ""}
+		for _, name := range paramNames {
+			row = append(row, export.ConsensusParameters[name])
+		}
+		foldRows = append(foldRows, row)
+	}
+	writeSheetRows(fx, foldsSheet, foldRows, styles)
+
+	// Parameter stability sheet
+	stabilityRows := [][]interface{}{{"Parameter", "Stable", "Consensus", "Mean", "Std Dev", "Min", "Max", "Coeff. Variation", "Mode", "Mode Share"}}
+	for _, ps := range export.ParameterStability {
+		var cv interface{} = "n/a"
+		if ps.CoeffVariation != nil {
+			cv = *ps.CoeffVariation
+		}
+		stabilityRows = append(stabilityRows, []interface{}{
+			ps.Name, ps.Stable, ps.ConsensusValue, ps.Mean, ps.StdDev, ps.Min, ps.Max, cv, ps.Mode, ps.ModeShare,
+		})
+	}
+	writeSheetRows(fx, stabilitySheet, stabilityRows, styles)
+	fx.SetColWidth(stabilitySheet, "A", "A", 32)
+
+	return fx.SaveAs(path)
+}
+
+// writeSheetRows writes rows starting at A1, styling the first row as a header
+func writeSheetRows(fx *excelize.File, sheet string, rows [][]interface{}, styles ExcelStyles) {
+	for r, values := range rows {
+		for c, v := range values {
+			cell, _ := excelize.CoordinatesToCellName(c+1, r+1)
+			fx.SetCellValue(sheet, cell, v)
+			if r == 0 {
+				fx.SetCellStyle(sheet, cell, cell, styles.HeaderStyle)
+			} else {
+				fx.SetCellStyle(sheet, cell, cell, styles.BaseStyle)
+			}
+		}
+	}
+}
@@ -19,26 +19,46 @@ type WalkForwardValidator interface {
 type DataSplitter interface {
 	SplitByRatio(data []types.OHLCV, ratio float64) ([]types.OHLCV, []types.OHLCV)
 	CreateRollingFolds(data []types.OHLCV, trainDays, testDays, rollDays int) []WalkForwardFold
+	CreateAnchoredFolds(data []types.OHLCV, trainDays, testDays, rollDays, embargoDays int) []WalkForwardFold
+	CreateRollingFoldsWithEmbargo(data []types.OHLCV, trainDays, testDays, rollDays, embargoDays int) []WalkForwardFold
 }
 
+// ParameterAnalyzer extracts comparable numeric parameters from optimized
+// configs and builds a config from a set of parameter values. It enables the
+// per-fold parameter stability analysis and the consensus config.
+type ParameterAnalyzer interface {
+	ExtractParameters(config interface{}) map[string]float64
+	BuildConsensusConfig(template interface{}, values map[string]float64) interface{}
+}
+
+// Walk-forward modes reported in WalkForwardSummary
+const (
+	WalkForwardModeHoldout  = "holdout"
+	WalkForwardModeRolling  = "rolling"
+	WalkForwardModeAnchored = "anchored"
+)
+
 // WalkForwardConfig holds the configuration for walk-forward validation
 type WalkForwardConfig struct {
-	Enable     bool
-	Rolling    bool
-	SplitRatio float64
-	TrainDays  int
-	TestDays   int
-	RollDays   int
+	Enable      bool
+	Rolling     bool
+	Anchored    bool // Expanding train window that always starts at the first candle
+	SplitRatio  float64
+	TrainDays   int // Train window (initial window when anchored)
+	TestDays    int
+	RollDays    int
+	EmbargoDays int // Gap left out between train end and test start
 }
 
 // WalkForwardFold represents a single fold in walk-forward validation
 type WalkForwardFold struct {
-	Train      []types.OHLCV
-	Test       []types.OHLCV
-	TrainStart time.Time
-	TrainEnd   time.Time
-	TestStart  time.Time
-	TestEnd    time.Time
+	Train          []types.OHLCV
+	Test           []types.OHLCV
+	TrainStart     time.Time
+	TrainEnd       time.Time
+	TestStart      time.Time
+	TestEnd        time.Time
+	EmbargoCandles int // Candles skipped between train and test
 }
 
 // WalkForwardResults holds the results for a single fold
@@ -47,11 +67,33 @@ type WalkForwardResults struct {
 	TestResults  *backtest.BacktestResults
 	BestConfig   interface{}
 	Fold         int
+	TrainStart   time.Time
+	TrainEnd     time.Time
+	TestStart    time.Time
+	TestEnd      time.Time
+	Parameters   map[string]float64 // Best-config parameters (set when a ParameterAnalyzer is configured)
+}
+
+// ParameterStability describes how much one parameter moved across folds
+type ParameterStability struct {
+	Name           string
+	Values         []float64 // Best value per fold, in fold order
+	Mean           float64
+	StdDev         float64
+	Min            float64
+	Max            float64
+	CoeffVariation float64 // StdDev / |Mean|
+	Mode           float64 // Most frequent value
+	ModeShare      float64 // Fraction of folds that chose Mode
+	Stable         bool
+	ConsensusValue float64
 }
 
 // WalkForwardSummary holds the summary of all walk-forward validation results
 type WalkForwardSummary struct {
 	Results              []WalkForwardResults
+	Mode                 string
+	EmbargoDays          int
 	AverageTrainReturn   float64
 	AverageTestReturn    float64
 	AverageTrainDrawdown float64
@@ -59,4 +101,15 @@ type WalkForwardSummary struct {
 	ReturnDegradation    float64
 	IsRobust             bool
 	OverfittingRisk      string
+
+	// Parameter stability across folds (requires a ParameterAnalyzer and at least two folds)
+	ParameterStability   []ParameterStability
+	StabilityScore       float64 // Fraction of parameters judged stable
+	ConsensusParameters  map[string]float64
+	ConsensusConfig      interface{}
+	// The consensus is built from every fold's training results, so it is only
+	// tested on the test window after the last training window
+	ConsensusTestResults *backtest.BacktestResults
+	ConsensusTestStart   time.Time
+	ConsensusTestEnd     time.Time
 }
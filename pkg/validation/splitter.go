@@ -30,10 +30,15 @@ func (s *DefaultDataSplitter) SplitByRatio(data []types.OHLCV, ratio float64) ([
 
 // CreateRollingFolds creates rolling walk-forward folds - extracted from main.go
 func (s *DefaultDataSplitter) CreateRollingFolds(data []types.OHLCV, trainDays, testDays, rollDays int) []WalkForwardFold {
+	return s.CreateRollingFoldsWithEmbargo(data, trainDays, testDays, rollDays, 0)
+}
+
+// CreateRollingFoldsWithEmbargo creates rolling folds with an embargo gap
+// between each train window and its test window
+func (s *DefaultDataSplitter) CreateRollingFoldsWithEmbargo(data []types.OHLCV, trainDays, testDays, rollDays, embargoDays int) []WalkForwardFold {
 	var folds []WalkForwardFold
 	
 	trainDur := time.Duration(trainDays) * 24 * time.Hour
-	testDur := time.Duration(testDays) * 24 * time.Hour
 	rollDur := time.Duration(rollDays) * 24 * time.Hour
 	
 	if len(data) < 100 {
@@ -44,43 +49,17 @@ func (s *DefaultDataSplitter) CreateRollingFolds(data []types.OHLCV, trainDays,
 	for {
 		// Find train window
 		trainEndTs := data[start].Timestamp.Add(trainDur)
-		trainEnd := start
-		for trainEnd < len(data) && data[trainEnd].Timestamp.Before(trainEndTs) {
-			trainEnd++
-		}
-		
-		// Find test window
-		testEndTs := trainEndTs.Add(testDur)
-		testEnd := trainEnd
-		for testEnd < len(data) && data[testEnd].Timestamp.Before(testEndTs) {
-			testEnd++
-		}
-		
-		// Check if we have enough data
-		trainSize := trainEnd - start
-		testSize := testEnd - trainEnd
+		trainEnd := indexAtOrAfter(data, start, trainEndTs)
 		
-		if trainSize < 50 || testSize < 10 {
+		fold, ok := buildFold(data, start, trainEnd, trainEndTs, testDays, embargoDays)
+		if !ok {
 			break // Not enough data for this fold
 		}
 		
-		fold := WalkForwardFold{
-			Train:      data[start:trainEnd],
-			Test:       data[trainEnd:testEnd],
-			TrainStart: data[start].Timestamp,
-			TrainEnd:   data[trainEnd-1].Timestamp,
-			TestStart:  data[trainEnd].Timestamp,
-			TestEnd:    data[testEnd-1].Timestamp,
-		}
-		
 		folds = append(folds, fold)
 		
 		// Roll forward
-		nextStartTs := data[start].Timestamp.Add(rollDur)
-		nextStart := start
-		for nextStart < len(data) && data[nextStart].Timestamp.Before(nextStartTs) {
-			nextStart++
-		}
+		nextStart := indexAtOrAfter(data, start, data[start].Timestamp.Add(rollDur))
 		
 		if nextStart <= start {
 			nextStart = start + 1
@@ -95,6 +74,90 @@ func (s *DefaultDataSplitter) CreateRollingFolds(data []types.OHLCV, trainDays,
 	return folds
 }
 
+// CreateAnchoredFolds creates anchored (expanding-window) folds: every train
+// window starts at the first candle and grows by rollDays per fold, while the
+// test window follows the train window after the embargo gap
+func (s *DefaultDataSplitter) CreateAnchoredFolds(data []types.OHLCV, trainDays, testDays, rollDays, embargoDays int) []WalkForwardFold {
+	var folds []WalkForwardFold
+	
+	if len(data) < 100 {
+		return folds // Need minimum data
+	}
+	if rollDays <= 0 {
+		rollDays = testDays // Default to non-overlapping test windows
+	}
+	if rollDays <= 0 {
+		return folds
+	}
+	
+	rollDur := time.Duration(rollDays) * 24 * time.Hour
+	trainEndTs := data[0].Timestamp.Add(time.Duration(trainDays) * 24 * time.Hour)
+	
+	for {
+		trainEnd := indexAtOrAfter(data, 0, trainEndTs)
+		
+		fold, ok := buildFold(data, 0, trainEnd, trainEndTs, testDays, embargoDays)
+		if !ok {
+			break // Not enough data for this fold
+		}
+		
+		folds = append(folds, fold)
+		trainEndTs = trainEndTs.Add(rollDur)
+	}
+	
+	return folds
+}
+
+// buildFold assembles a fold from the train range [start, trainEnd) followed
+// by the embargo gap and the test window
+func buildFold(data []types.OHLCV, start, trainEnd int, trainEndTs time.Time, testDays, embargoDays int) (WalkForwardFold, bool) {
+	testStartTs := trainEndTs.Add(time.Duration(embargoDays) * 24 * time.Hour)
+	testStart := indexAtOrAfter(data, trainEnd, testStartTs)
+	
+	// Find test window
+	testEndTs := testStartTs.Add(time.Duration(testDays) * 24 * time.Hour)
+	testEnd := indexAtOrAfter(data, testStart, testEndTs)
+	
+	// Check if we have enough data
+	trainSize := trainEnd - start
+	testSize := testEnd - testStart
+	
+	if trainSize < 50 || testSize < 10 {
+		return WalkForwardFold{}, false
+	}
+	
+	return WalkForwardFold{
+		Train:          data[start:trainEnd],
+		Test:           data[testStart:testEnd],
+		TrainStart:     data[start].Timestamp,
+		TrainEnd:       data[trainEnd-1].Timestamp,
+		TestStart:      data[testStart].Timestamp,
+		TestEnd:        data[testEnd-1].Timestamp,
+		EmbargoCandles: testStart - trainEnd,
+	}, true
+}
+
+// indexAtOrAfter returns the first index >= from whose timestamp is not before ts
+func indexAtOrAfter(data []types.OHLCV, from int, ts time.Time) int {
+	i := from
+	for i < len(data) && data[i].Timestamp.Before(ts) {
+		i++
+	}
+	return i
+}
+
+// ApplyEmbargo drops test candles that fall within embargoDays of the last
+// train candle and returns the remaining test data and the number dropped
+func ApplyEmbargo(train, test []types.OHLCV, embargoDays int) ([]types.OHLCV, int) {
+	if embargoDays <= 0 || len(train) == 0 || len(test) == 0 {
+		return test, 0
+	}
+	
+	cutoff := train[len(train)-1].Timestamp.Add(time.Duration(embargoDays) * 24 * time.Hour)
+	skip := indexAtOrAfter(test, 0, cutoff)
+	return test[skip:], skip
+}
+
 // Package-level convenience functions
 
 // SplitByRatio is a convenience function that uses the default splitter
@@ -108,3 +171,9 @@ func CreateRollingFolds(data []types.OHLCV, trainDays, testDays, rollDays int) [
 	splitter := NewDefaultDataSplitter()
 	return splitter.CreateRollingFolds(data, trainDays, testDays, rollDays)
 }
+
+// CreateAnchoredFolds is a convenience function that uses the default splitter
+func CreateAnchoredFolds(data []types.OHLCV, trainDays, testDays, rollDays, embargoDays int) []WalkForwardFold {
+	splitter := NewDefaultDataSplitter()
+	return splitter.CreateAnchoredFolds(data, trainDays, testDays, rollDays, embargoDays)
+}
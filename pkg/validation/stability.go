@@ -0,0 +1,144 @@
+package validation
+
+import (
+	"math"
+	"sort"
+)
+
+// Stability thresholds for per-parameter analysis across folds
+const (
+	StableCoeffVariation = 0.25 // Parameter is stable if its coefficient of variation stays below this
+	StableModeShare      = 0.5  // ... or if more than this share of folds picked the same value
+	MinStabilityScore    = 0.5  // Multi-fold runs need at least this share of stable parameters to be robust
+)
+
+// analyzeParameterStability compares the best-config parameters of every fold.
+// Results are sorted from most to least stable.
+func analyzeParameterStability(results []WalkForwardResults) []ParameterStability {
+	if len(results) < 2 {
+		return nil
+	}
+
+	// Collect parameter names present in every fold
+	names := make(map[string]int)
+	for _, r := range results {
+		for name := range r.Parameters {
+			names[name]++
+		}
+	}
+
+	var stability []ParameterStability
+	for name, count := range names {
+		if count != len(results) {
+			continue // Parameter not comparable across all folds
+		}
+
+		values := make([]float64, len(results))
+		for i, r := range results {
+			values[i] = r.Parameters[name]
+		}
+
+		stability = append(stability, summarizeParameter(name, values))
+	}
+
+	sort.Slice(stability, func(i, j int) bool {
+		if stability[i].Stable != stability[j].Stable {
+			return stability[i].Stable
+		}
+		if stability[i].CoeffVariation != stability[j].CoeffVariation {
+			return stability[i].CoeffVariation < stability[j].CoeffVariation
+		}
+		return stability[i].Name < stability[j].Name
+	})
+
+	return stability
+}
+
+// summarizeParameter computes spread statistics and the consensus value
+func summarizeParameter(name string, values []float64) ParameterStability {
+	ps := ParameterStability{
+		Name:   name,
+		Values: values,
+		Mean:   average(values),
+		StdDev: stdDev(values),
+		Min:    values[0],
+		Max:    values[0],
+	}
+
+	counts := make(map[float64]int)
+	for _, v := range values {
+		ps.Min = math.Min(ps.Min, v)
+		ps.Max = math.Max(ps.Max, v)
+		counts[v]++
+	}
+
+	// Mode, ties broken by the smaller value for determinism
+	bestCount := 0
+	for v, c := range counts {
+		if c > bestCount || (c == bestCount && v < ps.Mode) {
+			ps.Mode, bestCount = v, c
+		}
+	}
+	ps.ModeShare = float64(bestCount) / float64(len(values))
+
+	if math.Abs(ps.Mean) > 1e-12 {
+		ps.CoeffVariation = ps.StdDev / math.Abs(ps.Mean)
+	} else if ps.StdDev > 0 {
+		ps.CoeffVariation = math.Inf(1)
+	}
+
+	ps.Stable = ps.CoeffVariation <= StableCoeffVariation || ps.ModeShare > StableModeShare
+
+	// Mode when a clear majority exists, otherwise the median
+	if ps.ModeShare > StableModeShare {
+		ps.ConsensusValue = ps.Mode
+	} else {
+		ps.ConsensusValue = median(values)
+	}
+
+	return ps
+}
+
+// buildConsensusParameters takes consensus values for stable parameters and
+// the most recent fold's values for the rest, since the latest market regime
+// is the best tie-breaker for parameters that did not settle
+func buildConsensusParameters(stability []ParameterStability) map[string]float64 {
+	params := make(map[string]float64, len(stability))
+	for _, ps := range stability {
+		if ps.Stable {
+			params[ps.Name] = ps.ConsensusValue
+		} else {
+			params[ps.Name] = ps.Values[len(ps.Values)-1]
+		}
+	}
+	return params
+}
+
+// stabilityScore returns the fraction of stable parameters
+func stabilityScore(stability []ParameterStability) float64 {
+	if len(stability) == 0 {
+		return 0
+	}
+	stable := 0
+	for _, ps := range stability {
+		if ps.Stable {
+			stable++
+		}
+	}
+	return float64(stable) / float64(len(stability))
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
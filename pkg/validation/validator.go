@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"math"
+	"time"
 
 	"github.com/ducminhle1904/crypto-dca-bot/internal/backtest"
 	"github.com/ducminhle1904/crypto-dca-bot/pkg/types"
@@ -14,6 +15,7 @@ type DefaultWalkForwardValidator struct {
 	splitter  DataSplitter
 	optimizer func(config interface{}, data []types.OHLCV) (*backtest.BacktestResults, interface{}, error)
 	backtester func(config interface{}, data []types.OHLCV) *backtest.BacktestResults
+	analyzer  ParameterAnalyzer
 	// No quiet mode - always use clean, consistent logging
 }
 
@@ -34,6 +36,11 @@ func (v *DefaultWalkForwardValidator) SetBacktester(backtester func(config inter
 	v.backtester = backtester
 }
 
+// SetParameterAnalyzer enables per-fold parameter comparison and the consensus config
+func (v *DefaultWalkForwardValidator) SetParameterAnalyzer(analyzer ParameterAnalyzer) {
+	v.analyzer = analyzer
+}
+
 // Removed quiet mode - always use clean, consistent logging
 
 // Validate performs walk-forward validation - extracted from main.go runWalkForwardValidation
@@ -41,22 +48,31 @@ func (v *DefaultWalkForwardValidator) Validate(config interface{}, data []types.
 	// Clean walkforward validation header
 	log.Printf("🔄 Walk-Forward Validation Starting")
 	
-	if wfConfig.Rolling {
-		return v.validateRolling(config, data, wfConfig)
+	if wfConfig.Anchored {
+		return v.validateFolds(config, data, wfConfig, WalkForwardModeAnchored)
+	} else if wfConfig.Rolling {
+		return v.validateFolds(config, data, wfConfig, WalkForwardModeRolling)
 	} else {
 		return v.validateHoldout(config, data, wfConfig)
 	}
 }
 
-// validateRolling performs rolling walk-forward validation
-func (v *DefaultWalkForwardValidator) validateRolling(config interface{}, data []types.OHLCV, wfConfig WalkForwardConfig) (*WalkForwardSummary, error) {
-	// Rolling walk-forward
-	log.Printf("Mode: Rolling Walk-Forward")
-	log.Printf("Train: %d days, Test: %d days, Roll: %d days", wfConfig.TrainDays, wfConfig.TestDays, wfConfig.RollDays)
-	
-	folds := v.splitter.CreateRollingFolds(data, wfConfig.TrainDays, wfConfig.TestDays, wfConfig.RollDays)
+// validateFolds performs rolling or anchored walk-forward validation
+func (v *DefaultWalkForwardValidator) validateFolds(config interface{}, data []types.OHLCV, wfConfig WalkForwardConfig, mode string) (*WalkForwardSummary, error) {
+	var folds []WalkForwardFold
+	if mode == WalkForwardModeAnchored {
+		log.Printf("Mode: Anchored Walk-Forward")
+		log.Printf("Initial Train: %d days (expanding), Test: %d days, Step: %d days, Embargo: %d days",
+			wfConfig.TrainDays, wfConfig.TestDays, wfConfig.RollDays, wfConfig.EmbargoDays)
+		folds = v.splitter.CreateAnchoredFolds(data, wfConfig.TrainDays, wfConfig.TestDays, wfConfig.RollDays, wfConfig.EmbargoDays)
+	} else {
+		log.Printf("Mode: Rolling Walk-Forward")
+		log.Printf("Train: %d days, Test: %d days, Roll: %d days, Embargo: %d days",
+			wfConfig.TrainDays, wfConfig.TestDays, wfConfig.RollDays, wfConfig.EmbargoDays)
+		folds = v.splitter.CreateRollingFoldsWithEmbargo(data, wfConfig.TrainDays, wfConfig.TestDays, wfConfig.RollDays, wfConfig.EmbargoDays)
+	}
 	if len(folds) == 0 {
-		return nil, fmt.Errorf("not enough data for rolling walk-forward validation")
+		return nil, fmt.Errorf("not enough data for %s walk-forward validation", mode)
 	}
 	
 	log.Printf("Created %d folds", len(folds))
@@ -85,11 +101,18 @@ func (v *DefaultWalkForwardValidator) validateRolling(config interface{}, data [
 			TestResults:  testResults,
 			BestConfig:   bestConfig,
 			Fold:         i + 1,
+			TrainStart:   fold.TrainStart,
+			TrainEnd:     fold.TrainEnd,
+			TestStart:    fold.TestStart,
+			TestEnd:      fold.TestEnd,
+		}
+		if v.analyzer != nil {
+			result.Parameters = v.analyzer.ExtractParameters(bestConfig)
 		}
 		
 		allResults = append(allResults, result)
 		
-		log.Printf("✅ GA → %.2f%% | Test → %.2f%% | Drawdown: %.2f%%", 
+		log.Printf("✅ Train → %.2f%% | Test → %.2f%% | Drawdown: %.2f%%", 
 			trainResults.TotalReturn*100,
 			testResults.TotalReturn*100,
 			testResults.MaxDrawdown*100)
@@ -97,12 +120,67 @@ func (v *DefaultWalkForwardValidator) validateRolling(config interface{}, data [
 	
 	// Calculate summary
 	summary := v.calculateSummary(allResults)
+	summary.Mode = mode
+	summary.EmbargoDays = wfConfig.EmbargoDays
+	
+	v.analyzeStability(summary, folds)
 	
 	v.printRollingSummary(summary)
+	v.printParameterStability(summary)
 	
 	return summary, nil
 }
 
+// analyzeStability compares per-fold best configs, builds the consensus config
+// and tests it out-of-sample on the data after the last training window
+func (v *DefaultWalkForwardValidator) analyzeStability(summary *WalkForwardSummary, folds []WalkForwardFold) {
+	if v.analyzer == nil || len(summary.Results) < 2 {
+		return
+	}
+	
+	summary.ParameterStability = analyzeParameterStability(summary.Results)
+	if len(summary.ParameterStability) == 0 {
+		return
+	}
+	summary.StabilityScore = stabilityScore(summary.ParameterStability)
+	summary.ConsensusParameters = buildConsensusParameters(summary.ParameterStability)
+	
+	// Use the most recent fold's best config as the template for non-searched settings
+	template := summary.Results[len(summary.Results)-1].BestConfig
+	summary.ConsensusConfig = v.analyzer.BuildConsensusConfig(template, summary.ConsensusParameters)
+	if summary.ConsensusConfig == nil {
+		return
+	}
+	
+	// Every fold's train window shaped the consensus; earlier test windows overlap
+	// later train windows, so only a test window after all of them is out-of-sample
+	var lastTrainEnd time.Time
+	for _, r := range summary.Results {
+		if r.TrainEnd.After(lastTrainEnd) {
+			lastTrainEnd = r.TrainEnd
+		}
+	}
+	for i := len(folds) - 1; i >= 0; i-- {
+		fold := folds[i]
+		if len(fold.Test) == 0 || !fold.TestStart.After(lastTrainEnd) {
+			continue
+		}
+		summary.ConsensusTestResults = v.backtester(summary.ConsensusConfig, fold.Test)
+		summary.ConsensusTestStart = fold.TestStart
+		summary.ConsensusTestEnd = fold.TestEnd
+		break
+	}
+	
+	// A strategy whose optimal parameters jump around between folds is not robust,
+	// even when the average test return holds up
+	if summary.StabilityScore < MinStabilityScore {
+		summary.IsRobust = false
+		if summary.OverfittingRisk == "LOW" {
+			summary.OverfittingRisk = "MODERATE"
+		}
+	}
+}
+
 // validateHoldout performs simple holdout validation
 func (v *DefaultWalkForwardValidator) validateHoldout(config interface{}, data []types.OHLCV, wfConfig WalkForwardConfig) (*WalkForwardSummary, error) {
 	// Simple holdout validation
@@ -110,6 +188,11 @@ func (v *DefaultWalkForwardValidator) validateHoldout(config interface{}, data [
 	log.Printf("Split: %.0f%% train, %.0f%% test", wfConfig.SplitRatio*100, (1-wfConfig.SplitRatio)*100)
 	
 	trainData, testData := v.splitter.SplitByRatio(data, wfConfig.SplitRatio)
+	if wfConfig.EmbargoDays > 0 {
+		var dropped int
+		testData, dropped = ApplyEmbargo(trainData, testData, wfConfig.EmbargoDays)
+		log.Printf("Embargo: %d days (%d candles skipped)", wfConfig.EmbargoDays, dropped)
+	}
 	if len(testData) < 50 {
 		return nil, fmt.Errorf("not enough test data for validation")
 	}
@@ -139,10 +222,19 @@ func (v *DefaultWalkForwardValidator) validateHoldout(config interface{}, data [
 		TestResults:  testResults,
 		BestConfig:   bestConfig,
 		Fold:         1,
+		TrainStart:   trainData[0].Timestamp,
+		TrainEnd:     trainData[len(trainData)-1].Timestamp,
+		TestStart:    testData[0].Timestamp,
+		TestEnd:      testData[len(testData)-1].Timestamp,
+	}
+	if v.analyzer != nil {
+		result.Parameters = v.analyzer.ExtractParameters(bestConfig)
 	}
 	
 	// Calculate summary
 	summary := v.calculateSummary([]WalkForwardResults{result})
+	summary.Mode = WalkForwardModeHoldout
+	summary.EmbargoDays = wfConfig.EmbargoDays
 	
 	v.printHoldoutResults(trainResults, testResults, summary.ReturnDegradation)
 	
@@ -233,6 +325,34 @@ func (v *DefaultWalkForwardValidator) printRollingSummary(summary *WalkForwardSu
 	}
 }
 
+// printParameterStability prints the per-parameter comparison across folds and the consensus config
+func (v *DefaultWalkForwardValidator) printParameterStability(summary *WalkForwardSummary) {
+	if len(summary.ParameterStability) == 0 {
+		return
+	}
+	
+	fmt.Printf("\nPARAMETER STABILITY (%d folds):\n", len(summary.Results))
+	fmt.Printf("  %-34s %10s %10s %10s %7s %6s  %s\n", "Parameter", "Min", "Max", "Consensus", "CV", "Mode%", "Status")
+	for _, ps := range summary.ParameterStability {
+		status := "⚠️  unstable"
+		if ps.Stable {
+			status = "✅ stable"
+		}
+		fmt.Printf("  %-34s %10.4g %10.4g %10.4g %7.2f %5.0f%%  %s\n",
+			ps.Name, ps.Min, ps.Max, ps.ConsensusValue, ps.CoeffVariation, ps.ModeShare*100, status)
+	}
+	
+	fmt.Printf("\n  Stability Score: %.0f%% of parameters stable\n", summary.StabilityScore*100)
+	if summary.ConsensusTestResults != nil {
+		fmt.Printf("  Consensus Config Test Return: %.2f%% on %s to %s (after the last train window)\n",
+			summary.ConsensusTestResults.TotalReturn*100,
+			summary.ConsensusTestStart.Format("2006-01-02"), summary.ConsensusTestEnd.Format("2006-01-02"))
+	}
+	if summary.StabilityScore < MinStabilityScore {
+		fmt.Printf("  ⚠️  PARAMETERS UNSTABLE - Optimal settings shift between folds\n")
+	}
+}
+
 // printHoldoutResults prints results for holdout validation
 func (v *DefaultWalkForwardValidator) printHoldoutResults(trainResults, testResults *backtest.BacktestResults, returnDegradation float64) {
 	fmt.Println("\n📈 ================ WALK-FORWARD RESULTS ================")
@@ -304,9 +424,22 @@ func RunWalkForwardValidation(config interface{}, data []types.OHLCV, wfConfig W
 	optimizer func(interface{}, []types.OHLCV) (*backtest.BacktestResults, interface{}, error),
 	backtester func(interface{}, []types.OHLCV) *backtest.BacktestResults) (*WalkForwardSummary, error) {
 	
+	return RunWalkForwardValidationWithAnalyzer(config, data, wfConfig, optimizer, backtester, nil)
+}
+
+// RunWalkForwardValidationWithAnalyzer runs walk-forward validation with
+// per-fold parameter stability analysis and a consensus config
+func RunWalkForwardValidationWithAnalyzer(config interface{}, data []types.OHLCV, wfConfig WalkForwardConfig, 
+	optimizer func(interface{}, []types.OHLCV) (*backtest.BacktestResults, interface{}, error),
+	backtester func(interface{}, []types.OHLCV) *backtest.BacktestResults,
+	analyzer ParameterAnalyzer) (*WalkForwardSummary, error) {
+	
 	validator := NewDefaultWalkForwardValidator()
 	validator.SetOptimizer(optimizer)
 	validator.SetBacktester(backtester)
+	if analyzer != nil {
+		validator.SetParameterAnalyzer(analyzer)
+	}
 	// Always show clean, consistent logging
 	
 	return validator.Validate(config, data, wfConfig)